@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/thola/internal/request"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
+)
+
+// batchRequests contains the requests which can be processed in a batch, mapped by their path.
+var batchRequests = map[string]func() request.Request{
+	"/identify":                  func() request.Request { return &request.IdentifyRequest{} },
+	"/check/identify":            func() request.Request { return &request.CheckIdentifyRequest{} },
+	"/check/snmp":                func() request.Request { return &request.CheckSNMPRequest{} },
+	"/check/interface-metrics":   func() request.Request { return &request.CheckInterfaceMetricsRequest{} },
+	"/check/ups":                 func() request.Request { return &request.CheckUPSRequest{} },
+	"/check/memory-usage":        func() request.Request { return &request.CheckMemoryUsageRequest{} },
+	"/check/cpu-load":            func() request.Request { return &request.CheckCPULoadRequest{} },
+	"/check/sbc":                 func() request.Request { return &request.CheckSBCRequest{} },
+	"/check/server":              func() request.Request { return &request.CheckServerRequest{} },
+	"/check/disk":                func() request.Request { return &request.CheckDiskRequest{} },
+	"/check/hardware-health":     func() request.Request { return &request.CheckHardwareHealthRequest{} },
+	"/check/poe":                 func() request.Request { return &request.CheckPOERequest{} },
+	"/check/environment":         func() request.Request { return &request.CheckEnvironmentRequest{} },
+	"/check/high-availability":   func() request.Request { return &request.CheckHighAvailabilityRequest{} },
+	"/check/routes":              func() request.Request { return &request.CheckRoutesRequest{} },
+	"/check/firewall":            func() request.Request { return &request.CheckFirewallRequest{} },
+	"/read/interfaces":           func() request.Request { return &request.ReadInterfacesRequest{} },
+	"/read/count-interfaces":     func() request.Request { return &request.ReadCountInterfacesRequest{} },
+	"/read/cpu-load":             func() request.Request { return &request.ReadCPULoadRequest{} },
+	"/read/memory-usage":         func() request.Request { return &request.ReadMemoryUsageRequest{} },
+	"/read/ups":                  func() request.Request { return &request.ReadUPSRequest{} },
+	"/read/sbc":                  func() request.Request { return &request.ReadSBCRequest{} },
+	"/read/server":               func() request.Request { return &request.ReadServerRequest{} },
+	"/read/disk":                 func() request.Request { return &request.ReadDiskRequest{} },
+	"/read/hardware-health":      func() request.Request { return &request.ReadHardwareHealthRequest{} },
+	"/read/high-availability":    func() request.Request { return &request.ReadHighAvailabilityRequest{} },
+	"/read/available-components": func() request.Request { return &request.ReadAvailableComponentsRequest{} },
+	"/read/components":           func() request.Request { return &request.ReadComponentsRequest{} },
+	"/read/neighbors":            func() request.Request { return &request.ReadNeighborsRequest{} },
+	"/read/vlans":                func() request.Request { return &request.ReadVLANsRequest{} },
+	"/read/routes":               func() request.Request { return &request.ReadRoutesRequest{} },
+	"/read/entity":               func() request.Request { return &request.ReadEntityRequest{} },
+	"/read/wlan":                 func() request.Request { return &request.ReadWLANRequest{} },
+	"/read/firewall":             func() request.Request { return &request.ReadFirewallRequest{} },
+	"/read/oid":                  func() request.Request { return &request.ReadOIDRequest{} },
+}
+
+// batchRequestBody is the body of a batch request. Every entry of requests is a complete request for one device.
+type batchRequestBody struct {
+	request.BatchOptions
+	Requests []json.RawMessage `json:"requests"`
+}
+
+func batchRequest(newRequest func() request.Request) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		body := batchRequestBody{}
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+
+		var requests []request.Request
+		for i, raw := range body.Requests {
+			r := newRequest()
+			if err := json.Unmarshal(raw, r); err != nil {
+				return returnInFormat(ctx, http.StatusBadRequest, tholaerr.NewOutputError("Request failed", tholaerr.WithCode(errors.Wrapf(err, "request %d of the batch is invalid", i), tholaerr.ErrorCodeInvalidRequest)))
+			}
+			requests = append(requests, r)
+		}
+
+		reqCTX := newRequestContext(ctx)
+		log.Ctx(reqCTX).Debug().Int("requests", len(requests)).Msg("incoming batch request")
+
+		resp, err := request.ProcessBatch(reqCTX, requests, body.BatchOptions, func(c context.Context, r request.Request) (request.Response, error) {
+			ip, err := request.BatchHost(r)
+			if err != nil {
+				return nil, err
+			}
+			return processAPIRequest(c, r, &ip)
+		})
+		if err != nil {
+			return handleError(ctx, err)
+		}
+		return returnInFormat(ctx, http.StatusOK, resp)
+	}
+}
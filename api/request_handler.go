@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/inexio/thola/api/statistics"
 	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/parser"
 	"github.com/inexio/thola/internal/request"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/labstack/echo/v4"
@@ -17,6 +19,7 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -49,14 +52,7 @@ func StartAPI() {
 
 	if (viper.GetString("api.username") != "") && (viper.GetString("api.password") != "") {
 		log.Ctx(ctx).Debug().Msg("set authorization for api")
-		e.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
-			// Be careful to use constant time comparison to prevent timing attacks
-			if subtle.ConstantTimeCompare([]byte(username), []byte(viper.GetString("restapi.username"))) == 1 &&
-				subtle.ConstantTimeCompare([]byte(password), []byte(viper.GetString("restapi.password"))) == 1 {
-				return true, nil
-			}
-			return false, nil
-		}))
+		e.Use(middleware.BasicAuth(basicAuthValidator))
 	}
 
 	if viper.GetString("api.ratelimit") != "" {
@@ -313,6 +309,33 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/check/sbc", checkSBC)
 
+	// swagger:operation POST /check/firewall check checkFirewall
+	// ---
+	// summary: Checks the sessions and vpn tunnels of a firewall.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/CheckFirewallRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/CheckResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/check/firewall", checkFirewall)
+
 	// swagger:operation POST /check/server check checkServer
 	// ---
 	// summary: Check a linux server.
@@ -394,6 +417,60 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/check/hardware-health", checkHardwareHealth)
 
+	// swagger:operation POST /check/poe check checkPOE
+	// ---
+	// summary: Check the power over ethernet of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/CheckPOERequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/CheckResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/check/poe", checkPOE)
+
+	// swagger:operation POST /check/environment check checkEnvironment
+	// ---
+	// summary: Check the environment sensors of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/CheckEnvironmentRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/CheckResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/check/environment", checkEnvironment)
+
 	// swagger:operation POST /check/high-availability check checkHighAvailability
 	// ---
 	// summary: Check the high availability status of a device.
@@ -421,6 +498,33 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/check/high-availability", checkHighAvailability)
 
+	// swagger:operation POST /check/routes check checkRoutes
+	// ---
+	// summary: Checks the count of routes of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/CheckRoutesRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/CheckResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/check/routes", checkRoutes)
+
 	// swagger:operation POST /read/interfaces read readInterfaces
 	// ---
 	// summary: Reads out data of the interfaces of a device.
@@ -718,49 +822,472 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/read/available-components", readAvailableComponents)
 
-	// Start server
-	go func() {
-		var err error
-		if viper.GetString("api.certfile") != "" && viper.GetString("api.keyfile") != "" {
-			err = e.StartTLS(":"+viper.GetString("api.port"), viper.GetString("api.certfile"), viper.GetString("api.keyfile"))
-		} else {
-			err = e.Start(":" + viper.GetString("api.port"))
-		}
-
-		if dbErr := db.CloseConnection(ctx); dbErr != nil {
-			log.Ctx(ctx).Err(dbErr).Msg("failed to close connection to the db")
-		}
+	// swagger:operation POST /read/components read readComponents
+	// ---
+	// summary: Reads out all available components of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadComponentsRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadComponentsResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/components", readComponents)
 
-		if err != nil && err == http.ErrServerClosed {
-			log.Ctx(ctx).Info().Msg("shutting down the server")
-		} else {
-			log.Ctx(ctx).Fatal().Err(err).Msg("unexpected server error")
-		}
-	}()
+	// swagger:operation POST /read/neighbors read readNeighbors
+	// ---
+	// summary: Reads out the LLDP/CDP neighbors of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadNeighborsRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadNeighborsResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/neighbors", readNeighbors)
 
-	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
-	// Also close the connection to the database.
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
-	<-quit
+	// swagger:operation POST /read/vlans read readVLANs
+	// ---
+	// summary: Reads out the VLANs of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadVLANsRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadVLANsResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/vlans", readVLANs)
 
-	log.Ctx(ctx).Debug().Msg("received shutdown signal")
+	// swagger:operation POST /read/routes read readRoutes
+	// ---
+	// summary: Reads out the routing table or the count of routes of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadRoutesRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadRoutesResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/routes", readRoutes)
 
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	// swagger:operation POST /read/entity read readEntity
+	// ---
+	// summary: Reads out the physical entities of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadEntityRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadEntityResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/entity", readEntity)
 
-	if err = e.Shutdown(ctx); err != nil {
-		log.Ctx(ctx).Fatal().Err(err).Msg("shutting down the server failed")
-	}
-}
+	// swagger:operation POST /read/wlan read readWLAN
+	// ---
+	// summary: Reads out the access points of a wireless controller.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadWLANRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadWLANResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/wlan", readWLAN)
 
-func identify(ctx echo.Context) error {
-	r := request.IdentifyRequest{}
-	if err := ctx.Bind(&r); err != nil {
-		return err
-	}
-	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
-	if err != nil {
+	// swagger:operation POST /read/firewall read readFirewall
+	// ---
+	// summary: Reads out the sessions, vpn tunnels and licenses of a firewall.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadFirewallRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadFirewallResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/firewall", readFirewall)
+
+	// swagger:operation POST /read/oid read readOID
+	// ---
+	// summary: Reads out the raw values of an OID mapped by their index.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadOIDRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadOIDResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	//   406:
+	//     description: Returns an error if the OID is not available on the device.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/oid", readOID)
+
+	// swagger:operation POST /read/config read readConfig
+	// ---
+	// summary: Copies the configuration of a device to a server.
+	// description: The copy is triggered with snmp set requests, so snmp writes need to be enabled in the configuration
+	//   of the API, the request needs to allow write operations explicitly and a community with write access is needed.
+	//   The destination has to be one of the config backup servers of the API configuration. Only supported for some
+	//   device classes. It can't be processed as batch request.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadConfigRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadConfigResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	//   500:
+	//     description: Returns an error if the device class doesn't support config backups.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/config", readConfig)
+
+	// swagger:operation POST /{request}/batch batch batchRequest
+	// ---
+	// summary: Processes a request for multiple devices concurrently.
+	// description: Batch endpoints exist for every check and read request, e.g. /read/interfaces/batch.
+	//   The requests are processed by a pool of workers. A failed request does not fail the batch,
+	//   every result carries its own status.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: request
+	//   in: path
+	//   description: Path of the request, e.g. read/interfaces.
+	//   required: true
+	//   type: string
+	// - name: body
+	//   in: body
+	//   description: Requests to process and the options of the batch.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/BatchOptions'
+	// responses:
+	//   200:
+	//     description: Returns the results mapped by host.
+	//     schema:
+	//       $ref: '#/definitions/BatchResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	for path, newRequest := range batchRequests {
+		e.POST(path+"/batch", batchRequest(newRequest))
+	}
+
+	// swagger:operation GET /cache/identify cache getIdentifyCache
+	// ---
+	// summary: Returns the statistics of the identify cache.
+	// produces:
+	// - application/json
+	// - application/xml
+	// responses:
+	//   200:
+	//     description: Returns the statistics.
+	//     schema:
+	//       $ref: '#/definitions/CacheStatistics'
+	e.GET("/cache/identify", getIdentifyCache)
+
+	// swagger:operation DELETE /cache/identify cache flushIdentifyCache
+	// ---
+	// summary: Removes all entries from the identify cache.
+	// description: The endpoint is only available if authorization is configured for the API.
+	// responses:
+	//   204:
+	//     description: The identify cache was flushed.
+	//   403:
+	//     description: Authorization is not configured for the API.
+	e.DELETE("/cache/identify", flushIdentifyCache)
+
+	// swagger:operation GET /cache/match cache getMatchCache
+	// ---
+	// summary: Returns the statistics of the match cache.
+	// produces:
+	// - application/json
+	// - application/xml
+	// responses:
+	//   200:
+	//     description: Returns the statistics.
+	//     schema:
+	//       $ref: '#/definitions/CacheStatistics'
+	e.GET("/cache/match", getMatchCache)
+
+	// swagger:operation DELETE /cache/match cache flushMatchCache
+	// ---
+	// summary: Removes all entries from the match cache.
+	// description: The endpoint is only available if authorization is configured for the API.
+	// responses:
+	//   204:
+	//     description: The match cache was flushed.
+	//   403:
+	//     description: Authorization is not configured for the API.
+	e.DELETE("/cache/match", flushMatchCache)
+
+	// swagger:operation GET /cache/snmp-sessions cache getSNMPSessionPool
+	// ---
+	// summary: Returns the statistics of the snmp session pool.
+	// produces:
+	// - application/json
+	// - application/xml
+	// responses:
+	//   200:
+	//     description: Returns the statistics.
+	//     schema:
+	//       $ref: '#/definitions/SNMPSessionPoolStatistics'
+	e.GET("/cache/snmp-sessions", getSNMPSessionPool)
+
+	// swagger:operation POST /admin/reload-device-classes admin reloadDeviceClasses
+	// ---
+	// summary: Reads in the device classes again and replaces the active ones.
+	// description: Requests that are already running keep using the old device classes.
+	//   The endpoint is only available if authorization is configured for the API.
+	//   Sending SIGHUP to the process also reloads the device classes.
+	// produces:
+	// - application/json
+	// - application/xml
+	// responses:
+	//   200:
+	//     description: The device classes were reloaded.
+	//     schema:
+	//       $ref: '#/definitions/ReloadDeviceClassesResponse'
+	//   403:
+	//     description: Authorization is not configured for the API.
+	//   422:
+	//     description: A device class file couldn't be read in, the old device classes stay active.
+	//     schema:
+	//       $ref: '#/definitions/ReloadDeviceClassesResponse'
+	e.POST("/admin/reload-device-classes", reloadDeviceClasses)
+
+	// Start server
+	go func() {
+		var err error
+		if viper.GetString("api.certfile") != "" && viper.GetString("api.keyfile") != "" {
+			err = e.StartTLS(":"+viper.GetString("api.port"), viper.GetString("api.certfile"), viper.GetString("api.keyfile"))
+		} else {
+			err = e.Start(":" + viper.GetString("api.port"))
+		}
+
+		if dbErr := db.CloseConnection(ctx); dbErr != nil {
+			log.Ctx(ctx).Err(dbErr).Msg("failed to close connection to the db")
+		}
+
+		if err != nil && err == http.ErrServerClosed {
+			log.Ctx(ctx).Info().Msg("shutting down the server")
+		} else {
+			log.Ctx(ctx).Fatal().Err(err).Msg("unexpected server error")
+		}
+	}()
+
+	var traps *trapReceiver
+	if viper.GetBool("api.trap-receiver") {
+		traps, err = startTrapReceiver(ctx, trapReceiverConfig{
+			address:    ":" + viper.GetString("api.trap-port"),
+			listener:   getTrapListenerData(),
+			webhookURL: viper.GetString("api.trap-webhook-url"),
+			workers:    viper.GetInt("api.trap-workers"),
+			timeout:    viper.GetInt("api.trap-timeout"),
+		})
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("starting the snmp trap receiver failed")
+		}
+	}
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			log.Ctx(ctx).Debug().Msg("received reload signal")
+			if _, err := request.ReloadDeviceClasses(ctx); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("reloading the device classes failed, the old device classes stay active")
+			}
+		}
+	}()
+
+	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
+	// Also close the connection to the database.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt)
+	<-quit
+
+	log.Ctx(ctx).Debug().Msg("received shutdown signal")
+
+	if traps != nil {
+		traps.stop()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err = e.Shutdown(ctx); err != nil {
+		log.Ctx(ctx).Fatal().Err(err).Msg("shutting down the server failed")
+	}
+}
+
+func getTrapListenerData() network.SNMPTrapListenerData {
+	data := network.SNMPTrapListenerData{
+		Community: viper.GetString("api.trap-community"),
+		Relays:    viper.GetStringSlice("api.trap-relays"),
+	}
+	for key, value := range map[string]**string{
+		"api.trap-v3-level":      &data.V3Data.Level,
+		"api.trap-v3-user":       &data.V3Data.User,
+		"api.trap-v3-auth-key":   &data.V3Data.AuthKey,
+		"api.trap-v3-auth-proto": &data.V3Data.AuthProtocol,
+		"api.trap-v3-priv-key":   &data.V3Data.PrivKey,
+		"api.trap-v3-priv-proto": &data.V3Data.PrivProtocol,
+	} {
+		if v := viper.GetString(key); v != "" {
+			*value = &v
+		}
+	}
+	return data
+}
+
+func identify(ctx echo.Context) error {
+	r := request.IdentifyRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
 		return handleError(ctx, err)
 	}
 	return returnInFormat(ctx, http.StatusOK, resp)
@@ -862,6 +1389,18 @@ func checkSBC(ctx echo.Context) error {
 	return returnInFormat(ctx, http.StatusOK, resp)
 }
 
+func checkFirewall(ctx echo.Context) error {
+	r := request.CheckFirewallRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
 func checkServer(ctx echo.Context) error {
 	r := request.CheckServerRequest{}
 	if err := ctx.Bind(&r); err != nil {
@@ -898,6 +1437,30 @@ func checkHardwareHealth(ctx echo.Context) error {
 	return returnInFormat(ctx, http.StatusOK, resp)
 }
 
+func checkPOE(ctx echo.Context) error {
+	r := request.CheckPOERequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func checkEnvironment(ctx echo.Context) error {
+	r := request.CheckEnvironmentRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
 func checkHighAvailability(ctx echo.Context) error {
 	r := request.CheckHighAvailabilityRequest{}
 	if err := ctx.Bind(&r); err != nil {
@@ -910,6 +1473,18 @@ func checkHighAvailability(ctx echo.Context) error {
 	return returnInFormat(ctx, http.StatusOK, resp)
 }
 
+func checkRoutes(ctx echo.Context) error {
+	r := request.CheckRoutesRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
 func readInterfaces(ctx echo.Context) error {
 	r := request.ReadInterfacesRequest{}
 	if err := ctx.Bind(&r); err != nil {
@@ -1042,23 +1617,199 @@ func readAvailableComponents(ctx echo.Context) error {
 	return returnInFormat(ctx, http.StatusOK, resp)
 }
 
+func readComponents(ctx echo.Context) error {
+	r := request.ReadComponentsRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readNeighbors(ctx echo.Context) error {
+	r := request.ReadNeighborsRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readVLANs(ctx echo.Context) error {
+	r := request.ReadVLANsRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readRoutes(ctx echo.Context) error {
+	r := request.ReadRoutesRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readEntity(ctx echo.Context) error {
+	r := request.ReadEntityRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readWLAN(ctx echo.Context) error {
+	r := request.ReadWLANRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readFirewall(ctx echo.Context) error {
+	r := request.ReadFirewallRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readOID(ctx echo.Context) error {
+	r := request.ReadOIDRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readConfig(ctx echo.Context) error {
+	r := request.ReadConfigRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func getIdentifyCache(ctx echo.Context) error {
+	return returnInFormat(ctx, http.StatusOK, request.GetIdentifyCacheStatistics())
+}
+
+func flushIdentifyCache(ctx echo.Context) error {
+	if !authorizationConfigured() {
+		return returnInFormat(ctx, http.StatusForbidden, tholaerr.NewOutputError("Forbidden", errors.New("api authorization needs to be configured to flush the identify cache")))
+	}
+
+	request.FlushIdentifyCache()
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func getMatchCache(ctx echo.Context) error {
+	return returnInFormat(ctx, http.StatusOK, request.GetMatchCacheStatistics())
+}
+
+func flushMatchCache(ctx echo.Context) error {
+	if !authorizationConfigured() {
+		return returnInFormat(ctx, http.StatusForbidden, tholaerr.NewOutputError("Forbidden", errors.New("api authorization needs to be configured to flush the match cache")))
+	}
+
+	request.FlushMatchCache()
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func getSNMPSessionPool(ctx echo.Context) error {
+	return returnInFormat(ctx, http.StatusOK, request.GetSNMPSessionPoolStatistics())
+}
+
+func reloadDeviceClasses(ctx echo.Context) error {
+	if !authorizationConfigured() {
+		return returnInFormat(ctx, http.StatusForbidden, tholaerr.NewOutputError("Forbidden", errors.New("api authorization needs to be configured to reload device classes")))
+	}
+
+	resp, err := request.ReloadDeviceClasses(newRequestContext(ctx))
+	if err != nil {
+		if resp.Error != nil {
+			return returnInFormat(ctx, http.StatusUnprocessableEntity, resp)
+		}
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+// basicAuthValidator checks the credentials of a request against the username and password of the api.
+func basicAuthValidator(username, password string, _ echo.Context) (bool, error) {
+	// Be careful to use constant time comparison to prevent timing attacks
+	if subtle.ConstantTimeCompare([]byte(username), []byte(viper.GetString("api.username"))) == 1 &&
+		subtle.ConstantTimeCompare([]byte(password), []byte(viper.GetString("api.password"))) == 1 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// authorizationConfigured returns whether basic authorization is configured for the api. Admin endpoints are only
+// available if it is configured.
+func authorizationConfigured() bool {
+	return viper.GetString("api.username") != "" && viper.GetString("api.password") != ""
+}
+
 func handleError(ctx echo.Context, err error) error {
 	if tholaerr.IsNetworkError(err) {
-		return returnInFormat(ctx, http.StatusBadRequest, tholaerr.OutputError{Error: "Network error: " + err.Error()})
+		return returnInFormat(ctx, http.StatusBadRequest, tholaerr.NewOutputError("Network error", err))
 	}
 	if tholaerr.IsNotImplementedError(err) {
-		return returnInFormat(ctx, http.StatusInternalServerError, tholaerr.OutputError{Error: "Function not implemented: " + err.Error()})
+		return returnInFormat(ctx, http.StatusInternalServerError, tholaerr.NewOutputError("Function not implemented", err))
 	}
 	if tholaerr.IsNotFoundError(err) {
-		return returnInFormat(ctx, http.StatusNotAcceptable, tholaerr.OutputError{Error: "Not found: " + err.Error()})
+		return returnInFormat(ctx, http.StatusNotAcceptable, tholaerr.NewOutputError("Not found", err))
 	}
 	if tholaerr.IsTooManyRequestsError(err) {
-		return returnInFormat(ctx, http.StatusTooManyRequests, tholaerr.OutputError{Error: "Too many requests: " + err.Error()})
+		return returnInFormat(ctx, http.StatusTooManyRequests, tholaerr.NewOutputError("Too many requests", err))
 	}
-	return returnInFormat(ctx, http.StatusBadRequest, tholaerr.OutputError{Error: "Request failed: " + err.Error()})
+	return returnInFormat(ctx, http.StatusBadRequest, tholaerr.NewOutputError("Request failed", err))
 }
 
 func returnInFormat(ctx echo.Context, statusCode int, resp interface{}) error {
+	if ctx.QueryParam("format") == "prometheus" && statusCode == http.StatusOK {
+		b, err := parser.ToPrometheus(resp)
+		if err != nil {
+			return ctx.String(http.StatusNotAcceptable, "Response cannot be returned in prometheus format")
+		}
+		return ctx.Blob(statusCode, parser.PrometheusContentType, b)
+	}
 	if viper.GetString("api.format") == "json" {
 		return ctx.JSON(statusCode, resp)
 	} else if viper.GetString("api.format") == "xml" {
@@ -1068,10 +1819,17 @@ func returnInFormat(ctx echo.Context, statusCode int, resp interface{}) error {
 }
 
 func handleAPIRequest(echoCTX echo.Context, r request.Request, ip *string) (request.Response, error) {
-	logger := log.With().Str("request_id", echoCTX.Request().Header.Get(echo.HeaderXRequestID)).Logger()
-	ctx := logger.WithContext(context.Background())
+	ctx := newRequestContext(echoCTX)
 	log.Ctx(ctx).Debug().Msg("incoming request")
+	return processAPIRequest(ctx, r, ip)
+}
+
+func newRequestContext(echoCTX echo.Context) context.Context {
+	return request.NewContextWithRequestID(context.Background(), echoCTX.Request().Header.Get(echo.HeaderXRequestID))
+}
 
+func processAPIRequest(ctx context.Context, r request.Request, ip *string) (request.Response, error) {
+	ctx = request.NewContextWithAPIRequest(ctx)
 	if ip != nil && !viper.GetBool("request.no-ip-lock") {
 		ctx, cancel := request.CheckForTimeout(ctx, r)
 		defer cancel()
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBasicAuthValidator(t *testing.T) {
+	viper.Set("api.username", "admin")
+	viper.Set("api.password", "secret")
+	viper.Set("restapi.username", "old")
+	viper.Set("restapi.password", "old")
+	defer func() {
+		viper.Set("api.username", "")
+		viper.Set("api.password", "")
+		viper.Set("restapi.username", "")
+		viper.Set("restapi.password", "")
+	}()
+
+	e := echo.New()
+	e.Use(middleware.BasicAuth(basicAuthValidator))
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, tc := range []struct {
+		username, password string
+		expected           int
+	}{
+		{"admin", "secret", http.StatusOK},
+		{"admin", "wrong", http.StatusUnauthorized},
+		{"old", "old", http.StatusUnauthorized},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(tc.username, tc.password)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, tc.expected, rec.Code, "%s:%s", tc.username, tc.password)
+	}
+}
@@ -1,18 +1,20 @@
 package api
 
 import (
+	"github.com/inexio/thola/internal/request"
 	"github.com/labstack/echo/v4"
-	"github.com/rs/xid"
 )
 
+// requestIDMiddleware sets the request ID of every request. Clients can supply their own ID with the X-Request-ID
+// header, invalid IDs are replaced by a new one. The ID is returned in the X-Request-ID header of the response.
 func requestIDMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			req := c.Request()
 			res := c.Response()
 			rid := req.Header.Get(echo.HeaderXRequestID)
-			if rid == "" {
-				rid = xid.New().String()
+			if !request.ValidRequestID(rid) {
+				rid = request.NewRequestID()
 				req.Header.Set(echo.HeaderXRequestID, rid)
 			}
 			res.Header().Set(echo.HeaderXRequestID, rid)
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(requestIDMiddleware())
+	e.GET("/", func(c echo.Context) error {
+		rid, ok := request.RequestIDFromContext(newRequestContext(c))
+		if !ok {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.String(http.StatusOK, rid)
+	})
+
+	for _, tc := range []struct {
+		header   string
+		expected string
+	}{
+		{"my-check-42", "my-check-42"},
+		{"", ""},
+		{"invalid id", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.header != "" {
+			req.Header.Set(echo.HeaderXRequestID, tc.header)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		rid := rec.Header().Get(echo.HeaderXRequestID)
+		if tc.expected != "" {
+			assert.Equal(t, tc.expected, rid)
+		} else {
+			// a new id is generated if the client didn't supply a valid one
+			assert.True(t, request.ValidRequestID(rid), rid)
+			assert.NotEqual(t, tc.header, rid)
+		}
+		// the id of the response is the id of the context of the request
+		assert.Equal(t, rid, rec.Body.String())
+	}
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/request"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// trapRequests contains the requests which are triggered by traps, mapped by the snmpTrapOID of the trap.
+var trapRequests = map[network.OID]trapRequest{
+	"1.3.6.1.6.3.1.1.5.1": {"coldStart", "identify", newTrapIdentifyRequest},
+	"1.3.6.1.6.3.1.1.5.2": {"warmStart", "identify", newTrapIdentifyRequest},
+	"1.3.6.1.6.3.1.1.5.3": {"linkDown", "read interfaces", newTrapReadInterfacesRequest},
+	"1.3.6.1.6.3.1.1.5.4": {"linkUp", "read interfaces", newTrapReadInterfacesRequest},
+}
+
+type trapRequest struct {
+	trap       string
+	name       string
+	newRequest func(host string, timeout int) request.Request
+}
+
+func newTrapIdentifyRequest(host string, timeout int) request.Request {
+	// the device may have been replaced or updated while it restarted, so the identify cache is not used
+	return &request.IdentifyRequest{
+		BaseRequest: request.BaseRequest{
+			DeviceData:      request.DeviceData{IPAddress: host},
+			Timeout:         &timeout,
+			NoIdentifyCache: true,
+		},
+	}
+}
+
+func newTrapReadInterfacesRequest(host string, timeout int) request.Request {
+	return &request.ReadInterfacesRequest{
+		ReadRequest: request.ReadRequest{
+			BaseRequest: request.BaseRequest{
+				DeviceData: request.DeviceData{IPAddress: host},
+				Timeout:    &timeout,
+			},
+		},
+	}
+}
+
+const (
+	// defaultTrapWorkers is the amount of traps that are processed concurrently if nothing else is configured.
+	defaultTrapWorkers = 10
+	// defaultTrapTimeout is the timeout in seconds of the requests triggered by traps if nothing else is configured.
+	defaultTrapTimeout = 60
+	// trapQueueSize is the maximum amount of traps that wait for a worker, further traps are dropped.
+	trapQueueSize = 1000
+)
+
+// trapJob is a trap which waits for a worker.
+type trapJob struct {
+	trap    network.SNMPTrap
+	request trapRequest
+}
+
+// trapJobKey identifies the request of a trap job. A trap is merged into a job with the same key which is still
+// waiting, so that e.g. a flapping port only triggers one read of the interfaces.
+type trapJobKey struct {
+	host    string
+	request string
+}
+
+// trapWebhookBody is the body which is sent to the webhook for every processed trap.
+type trapWebhookBody struct {
+	Host     string           `json:"host"`
+	Trap     string           `json:"trap"`
+	Request  string           `json:"request"`
+	Response request.Response `json:"response,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// trapReceiverConfig contains the settings of the trap receiver.
+type trapReceiverConfig struct {
+	address    string
+	listener   network.SNMPTrapListenerData
+	webhookURL string
+	// workers is the amount of traps that are processed concurrently.
+	workers int
+	// timeout is the timeout in seconds of the requests triggered by traps, including the wait for the ip lock.
+	timeout int
+	// process processes the request which was triggered by a trap.
+	process func(ctx context.Context, r request.Request, ip *string) (request.Response, error)
+}
+
+// trapReceiver receives snmp traps, reads out the devices that sent them and pushes the results to a webhook.
+type trapReceiver struct {
+	listener   *network.SNMPTrapListener
+	webhookURL string
+	process    func(ctx context.Context, r request.Request, ip *string) (request.Response, error)
+	httpClient *http.Client
+	timeout    int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	jobs   chan trapJob
+
+	mu      sync.Mutex
+	pending map[trapJobKey]struct{}
+	stopped bool
+}
+
+// startTrapReceiver starts a trap receiver and returns as soon as it is ready to receive traps.
+func startTrapReceiver(ctx context.Context, config trapReceiverConfig) (*trapReceiver, error) {
+	if config.webhookURL == "" {
+		return nil, errors.New("no webhook url for the trap receiver set")
+	}
+	if config.process == nil {
+		config.process = processAPIRequest
+	}
+	if config.workers <= 0 {
+		config.workers = defaultTrapWorkers
+	}
+	if config.timeout <= 0 {
+		config.timeout = defaultTrapTimeout
+	}
+
+	t := trapReceiver{
+		webhookURL: config.webhookURL,
+		process:    config.process,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		timeout:    config.timeout,
+		jobs:       make(chan trapJob, trapQueueSize),
+		pending:    make(map[trapJobKey]struct{}),
+	}
+	t.ctx, t.cancel = context.WithCancel(ctx)
+
+	t.wg.Add(config.workers)
+	for i := 0; i < config.workers; i++ {
+		go t.work()
+	}
+
+	var err error
+	t.listener, err = network.NewSNMPTrapListener(config.listener, t.handle)
+	if err != nil {
+		t.stopWorkers()
+		return nil, errors.Wrap(err, "failed to create snmp trap listener")
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- t.listener.Listen(config.address)
+	}()
+	select {
+	case <-t.listener.Listening():
+		log.Ctx(ctx).Info().Str("address", config.address).Msg("started snmp trap receiver")
+		return &t, nil
+	case err := <-errs:
+		t.stopWorkers()
+		return nil, errors.Wrap(err, "failed to listen for snmp traps")
+	}
+}
+
+// stop stops receiving traps and waits until the traps that were already received are processed. Requests of traps
+// that are still running are canceled.
+func (t *trapReceiver) stop() {
+	t.listener.Close()
+	t.stopWorkers()
+}
+
+func (t *trapReceiver) stopWorkers() {
+	t.mu.Lock()
+	if !t.stopped {
+		t.stopped = true
+		close(t.jobs)
+	}
+	t.mu.Unlock()
+	t.cancel()
+	t.wg.Wait()
+}
+
+// handle queues the request of a trap. The trap is dropped if the same request for the device is already waiting or if
+// the queue is full.
+func (t *trapReceiver) handle(trap network.SNMPTrap) {
+	r, ok := trapRequests[trap.OID]
+	if !ok {
+		log.Ctx(t.ctx).Debug().Str("host", trap.Host).Str("trap_oid", trap.OID.String()).Msg("ignored snmp trap")
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return
+	}
+
+	key := trapJobKey{host: trap.Host, request: r.name}
+	if _, ok := t.pending[key]; ok {
+		log.Ctx(t.ctx).Debug().Str("host", trap.Host).Str("trap", r.trap).Msg("merged snmp trap into pending request")
+		return
+	}
+	select {
+	case t.jobs <- trapJob{trap: trap, request: r}:
+		t.pending[key] = struct{}{}
+	default:
+		log.Ctx(t.ctx).Warn().Str("host", trap.Host).Str("trap", r.trap).Msg("dropped snmp trap, too many traps are waiting")
+	}
+}
+
+func (t *trapReceiver) work() {
+	defer t.wg.Done()
+	for job := range t.jobs {
+		// traps that are received from now on trigger a new request, as they may report a newer state
+		t.mu.Lock()
+		delete(t.pending, trapJobKey{host: job.trap.Host, request: job.request.name})
+		t.mu.Unlock()
+
+		t.processJob(job)
+	}
+}
+
+func (t *trapReceiver) processJob(job trapJob) {
+	logger := log.Ctx(t.ctx).With().Str("host", job.trap.Host).Str("trap", job.request.trap).Logger()
+	ctx := logger.WithContext(t.ctx)
+	log.Ctx(ctx).Debug().Msg("processing snmp trap")
+
+	body := trapWebhookBody{
+		Host:    job.trap.Host,
+		Trap:    job.request.trap,
+		Request: job.request.name,
+	}
+	host := job.trap.Host
+	resp, err := t.process(ctx, job.request.newRequest(host, t.timeout), &host)
+	if err != nil {
+		body.Error = err.Error()
+	} else {
+		body.Response = resp
+	}
+
+	if err := t.push(ctx, body); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to push result of snmp trap to webhook")
+	}
+}
+
+func (t *trapReceiver) push(ctx context.Context, body trapWebhookBody) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal webhook body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.webhookURL, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "webhook request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status code %d", resp.StatusCode)
+	}
+	return nil
+}
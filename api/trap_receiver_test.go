@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/request"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func sendTestTrap(t *testing.T, port int, trapOID string) {
+	client := &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      uint16(port),
+		Transport: "udp",
+		Community: "traps",
+		Version:   gosnmp.Version2c,
+		Timeout:   time.Second,
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Conn.Close()
+
+	_, err := client.SendTrap(gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: trapOID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrapReceiver(t *testing.T) {
+	bodies := make(chan map[string]interface{}, 10)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		bodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	receiver, err := startTrapReceiver(context.Background(), trapReceiverConfig{
+		address:    fmt.Sprintf("127.0.0.1:%d", port),
+		listener:   network.SNMPTrapListenerData{Community: "traps"},
+		webhookURL: webhook.URL,
+		timeout:    30,
+		process: func(_ context.Context, r request.Request, ip *string) (request.Response, error) {
+			switch req := r.(type) {
+			case *request.IdentifyRequest:
+				assert.True(t, req.NoIdentifyCache)
+				if assert.NotNil(t, req.Timeout) {
+					assert.Equal(t, 30, *req.Timeout)
+				}
+				return &request.IdentifyResponse{Device: device.Device{Class: "routeros"}}, nil
+			case *request.ReadInterfacesRequest:
+				if assert.NotNil(t, req.Timeout) {
+					assert.Equal(t, 30, *req.Timeout)
+				}
+				return nil, errors.New("device is not reachable")
+			}
+			t.Errorf("unexpected request %T for %s", r, *ip)
+			return nil, errors.New("unexpected request")
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	receive := func() map[string]interface{} {
+		select {
+		case body := <-bodies:
+			return body
+		case <-time.After(5 * time.Second):
+			t.Fatal("webhook wasn't called")
+		}
+		return nil
+	}
+
+	// traps without a request are ignored
+	sendTestTrap(t, port, ".1.3.6.1.6.3.1.1.5.5")
+
+	sendTestTrap(t, port, ".1.3.6.1.6.3.1.1.5.1")
+	body := receive()
+	assert.Equal(t, "127.0.0.1", body["host"])
+	assert.Equal(t, "coldStart", body["trap"])
+	assert.Equal(t, "identify", body["request"])
+	if assert.IsType(t, map[string]interface{}{}, body["response"]) {
+		assert.Equal(t, "routeros", body["response"].(map[string]interface{})["class"])
+	}
+
+	sendTestTrap(t, port, ".1.3.6.1.6.3.1.1.5.3")
+	body = receive()
+	assert.Equal(t, "linkDown", body["trap"])
+	assert.Equal(t, "read interfaces", body["request"])
+	assert.Equal(t, "device is not reachable", body["error"])
+	assert.Nil(t, body["response"])
+
+	receiver.stop()
+
+	// no traps are received after the receiver was stopped
+	sendTestTrap(t, port, ".1.3.6.1.6.3.1.1.5.4")
+	select {
+	case body := <-bodies:
+		t.Errorf("webhook was called after the receiver was stopped: %v", body)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestTrapReceiver_handle(t *testing.T) {
+	receiver := trapReceiver{
+		ctx:     context.Background(),
+		jobs:    make(chan trapJob, 2),
+		pending: make(map[trapJobKey]struct{}),
+	}
+	linkDown := network.SNMPTrap{Host: "10.0.0.1", OID: "1.3.6.1.6.3.1.1.5.3"}
+	linkUp := network.SNMPTrap{Host: "10.0.0.1", OID: "1.3.6.1.6.3.1.1.5.4"}
+
+	receiver.handle(linkDown)
+	// the interfaces of the device are already waiting to be read
+	receiver.handle(linkUp)
+	assert.Len(t, receiver.jobs, 1)
+
+	receiver.handle(network.SNMPTrap{Host: "10.0.0.1", OID: "1.3.6.1.6.3.1.1.5.1"})
+	assert.Len(t, receiver.jobs, 2)
+
+	// the queue is full
+	receiver.handle(network.SNMPTrap{Host: "10.0.0.2", OID: "1.3.6.1.6.3.1.1.5.3"})
+	assert.Len(t, receiver.jobs, 2)
+	assert.Len(t, receiver.pending, 2)
+}
@@ -22,6 +22,29 @@ func init() {
 	apiCMD.Flags().String("certfile", "", "Cert file for SSL encryption")
 	apiCMD.Flags().String("keyfile", "", "Key file for SSL encryption")
 	apiCMD.Flags().String("ratelimit", "", "Ratelimit for the API (e.g. 1000 reqs/hour: \"1000-H\")")
+	apiCMD.Flags().Duration("identify-cache-ttl", 0, "TTL of the in-process cache for identified devices (0 => cache is disabled)")
+	apiCMD.Flags().Int("identify-cache-size", 1000, "Maximum amount of devices in the in-process identify cache")
+	apiCMD.Flags().Duration("match-cache-ttl", 0, "TTL of the in-process cache for the matched device classes of devices (0 => cache is disabled)")
+	apiCMD.Flags().Int("match-cache-size", 1000, "Maximum amount of devices in the in-process match cache")
+	apiCMD.Flags().Duration("snmp-session-pool-ttl", 0, "Idle TTL of pooled snmp sessions (0 => pool is disabled)")
+	apiCMD.Flags().Int("snmp-session-pool-size", 100, "Maximum amount of idle sessions in the snmp session pool")
+	apiCMD.Flags().Int("snmp-session-pool-host-limit", 2, "Maximum amount of pooled snmp sessions per device and connection data")
+	apiCMD.Flags().String("interface-metrics-state-dir", "", "Directory in which the counters of check interface-metrics are persisted in rate mode (empty => in-memory)")
+	apiCMD.Flags().String("snmprec-dir", "", "Directory with recorded snmp data which can be used as devices with the file:// scheme (empty => file:// devices are rejected)")
+	apiCMD.Flags().StringSlice("config-backup-servers", nil, "IP addresses or networks of the servers to which read config requests may copy the configuration of devices (empty => read config requests are rejected)")
+	apiCMD.Flags().Bool("trap-receiver", false, "Start an SNMP trap receiver which reads out devices on linkUp/linkDown and coldStart/warmStart traps")
+	apiCMD.Flags().Int("trap-port", 162, "UDP port of the SNMP trap receiver")
+	apiCMD.Flags().String("trap-community", "", "Community which is accepted for SNMP v1 and v2c traps (empty => v1 and v2c traps are dropped)")
+	apiCMD.Flags().String("trap-v3-level", "", "The level of the SNMP v3 traps ('noAuthNoPriv', 'authNoPriv' or 'authPriv')")
+	apiCMD.Flags().String("trap-v3-user", "", "The username of the SNMP v3 traps (empty => v3 traps are dropped)")
+	apiCMD.Flags().String("trap-v3-auth-key", "", "The authentication passphrase of the SNMP v3 traps")
+	apiCMD.Flags().String("trap-v3-auth-proto", "", "The authentication protocol of the SNMP v3 traps (e.g. 'MD5' or 'SHA')")
+	apiCMD.Flags().String("trap-v3-priv-key", "", "The privacy passphrase of the SNMP v3 traps")
+	apiCMD.Flags().String("trap-v3-priv-proto", "", "The privacy protocol of the SNMP v3 traps (e.g. 'DES' or 'AES')")
+	apiCMD.Flags().String("trap-webhook-url", "", "URL of the webhook which receives the results of the reads triggered by SNMP traps")
+	apiCMD.Flags().StringSlice("trap-relays", nil, "IP addresses or networks of trap relays whose traps may name the device in the agent address or snmpTrapAddress (empty => the sender is always the device)")
+	apiCMD.Flags().Int("trap-workers", 10, "Amount of SNMP traps that are processed concurrently")
+	apiCMD.Flags().Int("trap-timeout", 60, "Timeout in seconds of the requests triggered by SNMP traps")
 
 	err := viper.BindPFlag("api.port", apiCMD.Flags().Lookup("port"))
 	if err != nil {
@@ -79,6 +102,167 @@ func init() {
 			Msg("Can't bind flag ratelimit")
 		return
 	}
+	err = viper.BindPFlag("api.identify-cache-ttl", apiCMD.Flags().Lookup("identify-cache-ttl"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag identify-cache-ttl")
+		return
+	}
+	err = viper.BindPFlag("api.identify-cache-size", apiCMD.Flags().Lookup("identify-cache-size"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag identify-cache-size")
+		return
+	}
+	err = viper.BindPFlag("api.match-cache-ttl", apiCMD.Flags().Lookup("match-cache-ttl"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag match-cache-ttl")
+		return
+	}
+	err = viper.BindPFlag("api.match-cache-size", apiCMD.Flags().Lookup("match-cache-size"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag match-cache-size")
+		return
+	}
+	err = viper.BindPFlag("api.snmp-session-pool-ttl", apiCMD.Flags().Lookup("snmp-session-pool-ttl"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag snmp-session-pool-ttl")
+		return
+	}
+	err = viper.BindPFlag("api.snmp-session-pool-size", apiCMD.Flags().Lookup("snmp-session-pool-size"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag snmp-session-pool-size")
+		return
+	}
+	err = viper.BindPFlag("api.snmp-session-pool-host-limit", apiCMD.Flags().Lookup("snmp-session-pool-host-limit"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag snmp-session-pool-host-limit")
+		return
+	}
+	err = viper.BindPFlag("api.interface-metrics-state-dir", apiCMD.Flags().Lookup("interface-metrics-state-dir"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag interface-metrics-state-dir")
+		return
+	}
+	err = viper.BindPFlag("api.snmprec-dir", apiCMD.Flags().Lookup("snmprec-dir"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag snmprec-dir")
+		return
+	}
+	err = viper.BindPFlag("api.config-backup-servers", apiCMD.Flags().Lookup("config-backup-servers"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag config-backup-servers")
+		return
+	}
+	err = viper.BindPFlag("api.trap-receiver", apiCMD.Flags().Lookup("trap-receiver"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-receiver")
+		return
+	}
+	err = viper.BindPFlag("api.trap-port", apiCMD.Flags().Lookup("trap-port"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-port")
+		return
+	}
+	err = viper.BindPFlag("api.trap-community", apiCMD.Flags().Lookup("trap-community"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-community")
+		return
+	}
+	err = viper.BindPFlag("api.trap-v3-level", apiCMD.Flags().Lookup("trap-v3-level"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-v3-level")
+		return
+	}
+	err = viper.BindPFlag("api.trap-v3-user", apiCMD.Flags().Lookup("trap-v3-user"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-v3-user")
+		return
+	}
+	err = viper.BindPFlag("api.trap-v3-auth-key", apiCMD.Flags().Lookup("trap-v3-auth-key"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-v3-auth-key")
+		return
+	}
+	err = viper.BindPFlag("api.trap-v3-auth-proto", apiCMD.Flags().Lookup("trap-v3-auth-proto"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-v3-auth-proto")
+		return
+	}
+	err = viper.BindPFlag("api.trap-v3-priv-key", apiCMD.Flags().Lookup("trap-v3-priv-key"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-v3-priv-key")
+		return
+	}
+	err = viper.BindPFlag("api.trap-v3-priv-proto", apiCMD.Flags().Lookup("trap-v3-priv-proto"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-v3-priv-proto")
+		return
+	}
+	err = viper.BindPFlag("api.trap-webhook-url", apiCMD.Flags().Lookup("trap-webhook-url"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-webhook-url")
+		return
+	}
+	err = viper.BindPFlag("api.trap-relays", apiCMD.Flags().Lookup("trap-relays"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-relays")
+		return
+	}
+	err = viper.BindPFlag("api.trap-workers", apiCMD.Flags().Lookup("trap-workers"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-workers")
+		return
+	}
+	err = viper.BindPFlag("api.trap-timeout", apiCMD.Flags().Lookup("trap-timeout"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag trap-timeout")
+		return
+	}
 }
 
 var apiCMD = &cobra.Command{
@@ -86,7 +270,9 @@ var apiCMD = &cobra.Command{
 	Short: "Start and configure the API of Thola",
 	Long: "Start and configure the API of Thola.\n\n" +
 		"You can set a port and authorization for the API. The authorization method is HTTP basic auth.\n" +
-		"If the username or password is empty, the API won't use any authorization.",
+		"If the username or password is empty, the API won't use any authorization.\n\n" +
+		"The optional SNMP trap receiver reads out the interfaces of a device when it sends a linkUp or linkDown trap\n" +
+		"and identifies it again when it sends a coldStart or warmStart trap. The results are posted to the webhook URL.",
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		err := rootCMD.PersistentPreRunE(cmd, args)
 		if err != nil {
@@ -104,6 +290,15 @@ var apiCMD = &cobra.Command{
 		if viper.GetString("api.username") == "" && viper.GetString("api.password") != "" {
 			return errors.New("password but no username for api authorization set")
 		}
+		if viper.GetBool("api.trap-receiver") && viper.GetString("api.trap-webhook-url") == "" {
+			return errors.New("trap receiver but no webhook url set")
+		}
+		if viper.GetBool("api.trap-receiver") && viper.GetString("api.trap-community") == "" && viper.GetString("api.trap-v3-user") == "" {
+			return errors.New("trap receiver but neither a community nor a v3 user for traps set")
+		}
+		if viper.GetBool("api.trap-receiver") && (viper.GetInt("api.trap-workers") <= 0 || viper.GetInt("api.trap-timeout") <= 0) {
+			return errors.New("trap workers and trap timeout must be greater than 0")
+		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
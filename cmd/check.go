@@ -107,3 +107,32 @@ func generateCheckThresholds(cmd *cobra.Command, warningMin, warningMax, critica
 
 	return thresholds
 }
+
+// generateCheckThreshold generates a threshold from the range flags in the nagios range syntax, e.g. "80", "10:20",
+// "~:10" or "@10:20". A plain number is an upper bound. If a range flag is not set, the range of the fallback is used.
+func generateCheckThreshold(cmd *cobra.Command, warning, critical string, fallback monitoringplugin.Thresholds) request.Threshold {
+	threshold, err := request.NewThresholdFromMinMax(fallback)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid thresholds")
+	}
+
+	for _, f := range []struct {
+		flagName string
+		r        **request.ThresholdRange
+	}{{warning, &threshold.Warning}, {critical, &threshold.Critical}} {
+		if f.flagName == "" || !cmd.Flags().Changed(f.flagName) {
+			continue
+		}
+		v, err := cmd.Flags().GetString(f.flagName)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("flag '%s' is not a string", f.flagName)
+		}
+		r, err := request.ParseThresholdRange(v)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("flag '%s' is not a valid threshold range", f.flagName)
+		}
+		*f.r = &r
+	}
+
+	return threshold
+}
@@ -0,0 +1,44 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/inexio/thola/internal/communicator/create"
+	"github.com/inexio/thola/internal/deviceclass"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func init() {
+	rootCMD.AddCommand(checkConfigCMD)
+}
+
+var checkConfigCMD = &cobra.Command{
+	Use:   "check-config",
+	Short: "Validate the device classes",
+	Long: "Validate the device classes without reading out any device.\n\n" +
+		"The built-in device classes are validated, or the device classes of the 'device-class-dir' if it is set.\n" +
+		"Every problem is printed with its file, yaml path and reason. The exit code is 1 if the device classes are invalid.",
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := create.ValidateDeviceClasses()
+		if err == nil {
+			fmt.Println("device classes are valid")
+			return
+		}
+
+		validationErr, ok := errors.Cause(err).(*deviceclass.ValidationError)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "failed to validate device classes: %s\n", err)
+			os.Exit(3)
+		}
+		for _, problem := range validationErr.Problems {
+			fmt.Fprintln(os.Stderr, problem.Error())
+		}
+		fmt.Fprintf(os.Stderr, "device classes are invalid (problems: %d)\n", len(validationErr.Problems))
+		os.Exit(1)
+	},
+}
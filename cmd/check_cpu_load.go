@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/request"
 	"github.com/spf13/cobra"
 )
@@ -9,8 +10,8 @@ func init() {
 	addDeviceFlags(checkCpuLoad)
 	checkCMD.AddCommand(checkCpuLoad)
 
-	checkCpuLoad.Flags().Float64("warning", 0, "warning threshold for cpu load")
-	checkCpuLoad.Flags().Float64("critical", 0, "critical threshold for cpu load")
+	checkCpuLoad.Flags().String("warning", "", "warning threshold for cpu load in the nagios range syntax, e.g. '80' or '@10:20'")
+	checkCpuLoad.Flags().String("critical", "", "critical threshold for cpu load in the nagios range syntax, e.g. '80' or '@10:20'")
 }
 
 var checkCpuLoad = &cobra.Command{
@@ -21,7 +22,7 @@ var checkCpuLoad = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		r := request.CheckCPULoadRequest{
 			CheckDeviceRequest: getCheckDeviceRequest(args[0]),
-			CPULoadThresholds:  generateCheckThresholds(cmd, "", "warning", "", "critical", true),
+			CPULoadThresholds:  generateCheckThreshold(cmd, "warning", "critical", monitoringplugin.Thresholds{}),
 		}
 		handleRequest(&r)
 	},
@@ -1,16 +1,18 @@
 package cmd
 
 import (
+	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/request"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	addDeviceFlags(checkDiskCMD)
+	addDiskOptionsFlags(checkDiskCMD)
 	checkCMD.AddCommand(checkDiskCMD)
 
-	checkDiskCMD.Flags().Float64("warning", 0, "warning threshold for free disk space")
-	checkDiskCMD.Flags().Float64("critical", 0, "critical threshold for free disk space")
+	checkDiskCMD.Flags().String("warning", "", "warning threshold for free disk space in the nagios range syntax, e.g. '80' or '@10:20'")
+	checkDiskCMD.Flags().String("critical", "", "critical threshold for free disk space in the nagios range syntax, e.g. '80' or '@10:20'")
 }
 
 var checkDiskCMD = &cobra.Command{
@@ -20,8 +22,9 @@ var checkDiskCMD = &cobra.Command{
 		"The metrics will be printed as performance data.",
 	Run: func(cmd *cobra.Command, args []string) {
 		r := request.CheckDiskRequest{
+			DiskOptions:        getDiskOptions(),
 			CheckDeviceRequest: getCheckDeviceRequest(args[0]),
-			DiskThresholds:     generateCheckThresholds(cmd, "", "warning", "", "critical", true),
+			DiskThresholds:     generateCheckThreshold(cmd, "warning", "critical", monitoringplugin.Thresholds{}),
 		}
 		handleRequest(&r)
 	},
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/request"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+func init() {
+	addDeviceFlags(checkEnvironmentCMD)
+	checkCMD.AddCommand(checkEnvironmentCMD)
+
+	checkEnvironmentCMD.Flags().Float64("temperature-warning-min", 0, "warning threshold for temperature sensors in degrees celsius (min)")
+	checkEnvironmentCMD.Flags().Float64("temperature-warning-max", 0, "warning threshold for temperature sensors in degrees celsius (max)")
+	checkEnvironmentCMD.Flags().Float64("temperature-critical-min", 0, "critical threshold for temperature sensors in degrees celsius (min)")
+	checkEnvironmentCMD.Flags().Float64("temperature-critical-max", 0, "critical threshold for temperature sensors in degrees celsius (max)")
+	checkEnvironmentCMD.Flags().Float64("humidity-warning-min", 0, "warning threshold for humidity sensors in percent (min)")
+	checkEnvironmentCMD.Flags().Float64("humidity-warning-max", 0, "warning threshold for humidity sensors in percent (max)")
+	checkEnvironmentCMD.Flags().Float64("humidity-critical-min", 0, "critical threshold for humidity sensors in percent (min)")
+	checkEnvironmentCMD.Flags().Float64("humidity-critical-max", 0, "critical threshold for humidity sensors in percent (max)")
+	checkEnvironmentCMD.Flags().StringToString("sensor-warning-min", nil, "warning thresholds of single sensors mapped by their description (min), e.g. 'Rack 1=10'")
+	checkEnvironmentCMD.Flags().StringToString("sensor-warning-max", nil, "warning thresholds of single sensors mapped by their description (max), e.g. 'Rack 1=30'")
+	checkEnvironmentCMD.Flags().StringToString("sensor-critical-min", nil, "critical thresholds of single sensors mapped by their description (min)")
+	checkEnvironmentCMD.Flags().StringToString("sensor-critical-max", nil, "critical thresholds of single sensors mapped by their description (max)")
+	checkEnvironmentCMD.Flags().String("dry-contact-alarm-state", "", "state of dry contacts that results in a critical status ('open' or 'closed')")
+}
+
+var checkEnvironmentCMD = &cobra.Command{
+	Use:   "environment",
+	Short: "Check the environment sensors of a device",
+	Long: "Checks the environment sensors of a device.\n\n" +
+		"Temperature and humidity sensors are checked against the given thresholds, the thresholds of a single sensor can be overridden by its description.\n" +
+		"Dry contacts that are in the given alarm state result in a critical status.\n" +
+		"The metrics will be printed as performance data.",
+	Run: func(cmd *cobra.Command, args []string) {
+		r := request.CheckEnvironmentRequest{
+			CheckDeviceRequest:    getCheckDeviceRequest(args[0]),
+			TemperatureThresholds: generateCheckThresholds(cmd, "temperature-warning-min", "temperature-warning-max", "temperature-critical-min", "temperature-critical-max", false),
+			HumidityThresholds:    generateCheckThresholds(cmd, "humidity-warning-min", "humidity-warning-max", "humidity-critical-min", "humidity-critical-max", false),
+			SensorThresholds:      getSensorThresholds(cmd),
+		}
+		if cmd.Flags().Changed("dry-contact-alarm-state") {
+			state := device.EnvironmentComponentDryContactState(cmd.Flags().Lookup("dry-contact-alarm-state").Value.String())
+			r.DryContactAlarmState = &state
+		}
+		handleRequest(&r)
+	},
+}
+
+func getSensorThresholds(cmd *cobra.Command) map[string]monitoringplugin.Thresholds {
+	thresholds := make(map[string]monitoringplugin.Thresholds)
+	for _, flagName := range []string{"sensor-warning-min", "sensor-warning-max", "sensor-critical-min", "sensor-critical-max"} {
+		if !cmd.Flags().Changed(flagName) {
+			continue
+		}
+		flagValue, err := cmd.Flags().GetStringToString(flagName)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("flag '%s' is not a map", flagName)
+		}
+		for sensor, value := range flagValue {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("threshold '%s' of sensor '%s' is not a number", value, sensor)
+			}
+			t := thresholds[sensor]
+			switch flagName {
+			case "sensor-warning-min":
+				t.WarningMin = v
+			case "sensor-warning-max":
+				t.WarningMax = v
+			case "sensor-critical-min":
+				t.CriticalMin = v
+			case "sensor-critical-max":
+				t.CriticalMax = v
+			}
+			thresholds[sensor] = t
+		}
+	}
+	if len(thresholds) == 0 {
+		return nil
+	}
+	return thresholds
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(checkFirewallCMD)
+	checkCMD.AddCommand(checkFirewallCMD)
+
+	checkFirewallCMD.Flags().String("active-sessions-warning", "", "warning threshold for the count of active sessions in the nagios range syntax, e.g. '100000'")
+	checkFirewallCMD.Flags().String("active-sessions-critical", "", "critical threshold for the count of active sessions in the nagios range syntax, e.g. '200000'")
+}
+
+var checkFirewallCMD = &cobra.Command{
+	Use:   "firewall",
+	Short: "Check the sessions and vpn tunnels of a firewall",
+	Long: "Checks the count of active sessions of a firewall against the given thresholds and alarms if a vpn tunnel is down.\n\n" +
+		"The count of active sessions and of vpn tunnels which are up and down will be printed as performance data.",
+	Run: func(cmd *cobra.Command, args []string) {
+		r := request.CheckFirewallRequest{
+			CheckDeviceRequest:       getCheckDeviceRequest(args[0]),
+			ActiveSessionsThresholds: generateCheckThreshold(cmd, "active-sessions-warning", "active-sessions-critical", monitoringplugin.Thresholds{}),
+		}
+		handleRequest(&r)
+	},
+}
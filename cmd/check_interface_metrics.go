@@ -12,6 +12,16 @@ func init() {
 	checkCMD.AddCommand(checkInterfaceMetricsCMD)
 
 	checkInterfaceMetricsCMD.Flags().Bool("print-interfaces", false, "Print interfaces to plugin output")
+	checkInterfaceMetricsCMD.Flags().Bool("rate-mode", false, "Calculate traffic and error rates from the counters of the previous check")
+	checkInterfaceMetricsCMD.Flags().String("state-dir", "", "Directory in which the counters are persisted between checks in rate mode (required in rate mode, the API uses its own state directory)")
+	checkInterfaceMetricsCMD.Flags().StringToInt64("max-speed", nil, "Max speeds in bits per second of interfaces by ifName, which the utilization is calculated against in rate mode (e.g. 'ether1=2000000000')")
+	checkInterfaceMetricsCMD.Flags().Uint64("default-max-speed", 0, "Max speed in bits per second of all interfaces without a max speed, instead of the speed of the interface")
+	checkInterfaceMetricsCMD.Flags().Float64("utilization-warning-max", 0, "warning max threshold for the utilization of the interfaces in percent")
+	checkInterfaceMetricsCMD.Flags().Float64("utilization-critical-max", 0, "critical max threshold for the utilization of the interfaces in percent")
+	checkInterfaceMetricsCMD.Flags().String("utilization-warning", "", "warning threshold for the utilization of the interfaces in percent in the nagios range syntax, overrides utilization-warning-max")
+	checkInterfaceMetricsCMD.Flags().String("utilization-critical", "", "critical threshold for the utilization of the interfaces in percent in the nagios range syntax, overrides utilization-critical-max")
+	checkInterfaceMetricsCMD.Flags().Bool("flap-detection", false, "Warn about admin up interfaces that changed their state within the flap threshold")
+	checkInterfaceMetricsCMD.Flags().Uint64("flap-threshold", 300, "Minimum time in seconds since the last state change of an interface in flap detection")
 }
 
 var checkInterfaceMetricsCMD = &cobra.Command{
@@ -24,10 +34,64 @@ var checkInterfaceMetricsCMD = &cobra.Command{
 			log.Fatal().Err(err).Msg("print-interfaces needs to be a boolean")
 		}
 
+		rateMode, err := cmd.Flags().GetBool("rate-mode")
+		if err != nil {
+			log.Fatal().Err(err).Msg("rate-mode needs to be a boolean")
+		}
+		stateDir, err := cmd.Flags().GetString("state-dir")
+		if err != nil {
+			log.Fatal().Err(err).Msg("state-dir needs to be a string")
+		}
+
+		maxSpeedFlag, err := cmd.Flags().GetStringToInt64("max-speed")
+		if err != nil {
+			log.Fatal().Err(err).Msg("max-speed needs to be a list of ifName=bits per second")
+		}
+		var maxSpeeds map[string]uint64
+		for ifName, maxSpeed := range maxSpeedFlag {
+			if maxSpeed <= 0 {
+				log.Fatal().Msgf("max speed of interface '%s' needs to be positive", ifName)
+			}
+			if maxSpeeds == nil {
+				maxSpeeds = make(map[string]uint64)
+			}
+			maxSpeeds[ifName] = uint64(maxSpeed)
+		}
+
+		var defaultMaxSpeed *uint64
+		if cmd.Flags().Changed("default-max-speed") {
+			maxSpeed, err := cmd.Flags().GetUint64("default-max-speed")
+			if err != nil {
+				log.Fatal().Err(err).Msg("default-max-speed needs to be an unsigned integer")
+			}
+			defaultMaxSpeed = &maxSpeed
+		}
+
+		flapDetection, err := cmd.Flags().GetBool("flap-detection")
+		if err != nil {
+			log.Fatal().Err(err).Msg("flap-detection needs to be a boolean")
+		}
+
+		var flapThreshold *uint64
+		if cmd.Flags().Changed("flap-threshold") {
+			threshold, err := cmd.Flags().GetUint64("flap-threshold")
+			if err != nil {
+				log.Fatal().Err(err).Msg("flap-threshold needs to be an unsigned integer")
+			}
+			flapThreshold = &threshold
+		}
+
 		r := request.CheckInterfaceMetricsRequest{
-			PrintInterfaces:    printInterfaces,
-			InterfaceOptions:   getInterfaceOptions(),
-			CheckDeviceRequest: getCheckDeviceRequest(args[0]),
+			PrintInterfaces:       printInterfaces,
+			RateMode:              rateMode,
+			StateDir:              stateDir,
+			MaxSpeeds:             maxSpeeds,
+			DefaultMaxSpeed:       defaultMaxSpeed,
+			UtilizationThresholds: generateCheckThreshold(cmd, "utilization-warning", "utilization-critical", generateCheckThresholds(cmd, "", "utilization-warning-max", "", "utilization-critical-max", true)),
+			FlapDetection:         flapDetection,
+			FlapThreshold:         flapThreshold,
+			InterfaceOptions:      getInterfaceOptions(),
+			CheckDeviceRequest:    getCheckDeviceRequest(args[0]),
 		}
 
 		handleRequest(&r)
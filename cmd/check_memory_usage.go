@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/request"
 	"github.com/spf13/cobra"
 )
@@ -9,8 +10,8 @@ func init() {
 	addDeviceFlags(checkMemoryUsage)
 	checkCMD.AddCommand(checkMemoryUsage)
 
-	checkMemoryUsage.Flags().Float64("warning", 0, "warning threshold for memory usage")
-	checkMemoryUsage.Flags().Float64("critical", 0, "critical threshold for memory usage")
+	checkMemoryUsage.Flags().String("warning", "", "warning threshold for memory usage in the nagios range syntax, e.g. '80' or '@10:20'")
+	checkMemoryUsage.Flags().String("critical", "", "critical threshold for memory usage in the nagios range syntax, e.g. '80' or '@10:20'")
 }
 
 var checkMemoryUsage = &cobra.Command{
@@ -21,7 +22,7 @@ var checkMemoryUsage = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		r := request.CheckMemoryUsageRequest{
 			CheckDeviceRequest:    getCheckDeviceRequest(args[0]),
-			MemoryUsageThresholds: generateCheckThresholds(cmd, "", "warning", "", "critical", true),
+			MemoryUsageThresholds: generateCheckThreshold(cmd, "warning", "critical", monitoringplugin.Thresholds{}),
 		}
 		handleRequest(&r)
 	},
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(checkPOECMD)
+	checkCMD.AddCommand(checkPOECMD)
+
+	checkPOECMD.Flags().Float64("warning", 0, "warning threshold for poe power usage in percent")
+	checkPOECMD.Flags().Float64("critical", 0, "critical threshold for poe power usage in percent")
+}
+
+var checkPOECMD = &cobra.Command{
+	Use:   "poe",
+	Short: "Check the power over ethernet of a device",
+	Long: "Checks the power over ethernet of a device.\n\n" +
+		"Alarms if a port is in a faulty state or the power usage of a power sourcing equipment exceeds the thresholds.\n" +
+		"If no thresholds are given, the usage threshold configured on the device is used as warning threshold.\n" +
+		"The metrics will be printed as performance data.",
+	Run: func(cmd *cobra.Command, args []string) {
+		r := request.CheckPOERequest{
+			CheckDeviceRequest:   getCheckDeviceRequest(args[0]),
+			PowerUsageThresholds: generateCheckThresholds(cmd, "", "warning", "", "critical", true),
+		}
+		handleRequest(&r)
+	},
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(checkRoutesCMD)
+	checkCMD.AddCommand(checkRoutesCMD)
+
+	checkRoutesCMD.Flags().Float64("warning-min", 0, "warning min threshold for the count of routes")
+	checkRoutesCMD.Flags().Float64("warning-max", 0, "warning max threshold for the count of routes")
+	checkRoutesCMD.Flags().Float64("critical-min", 0, "critical min threshold for the count of routes")
+	checkRoutesCMD.Flags().Float64("critical-max", 0, "critical max threshold for the count of routes")
+}
+
+var checkRoutesCMD = &cobra.Command{
+	Use:   "routes",
+	Short: "Check the count of routes of a device",
+	Long: "Checks the count of routes of a device.\n\n" +
+		"The max thresholds can be used to detect route leaks, the min thresholds to detect lost routes.\n" +
+		"The count will be printed as performance data.",
+	Run: func(cmd *cobra.Command, args []string) {
+		r := request.CheckRoutesRequest{
+			CheckDeviceRequest:   getCheckDeviceRequest(args[0]),
+			RouteCountThresholds: generateCheckThresholds(cmd, "warning-min", "warning-max", "critical-min", "critical-max", false),
+		}
+		handleRequest(&r)
+	},
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/request"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"strconv"
+	"strings"
 )
 
 func init() {
@@ -11,17 +15,55 @@ func init() {
 
 	checkSBCCMD.Flags().Float64("system-health-score-warning", 0, "warning threshold for system health score")
 	checkSBCCMD.Flags().Float64("system-health-score-critical", 0, "critical threshold for system health score")
+	checkSBCCMD.Flags().StringToString("agent-status-state", nil, "monitoring states of agent statuses, which override the default mapping (e.g. '2=critical,4=ok')")
+	checkSBCCMD.Flags().String("ignore-agents", "", "regex for hostnames of agents whose status is not checked")
 }
 
 var checkSBCCMD = &cobra.Command{
 	Use:   "sbc",
 	Short: "Read out sbc specific metrics as performance data",
-	Long:  "Read out sbc specific metrics as performance data.",
+	Long: "Read out sbc specific metrics as performance data.\n\n" +
+		"Agents whose status is not in service result in a warning or critical status, which can be changed with --agent-status-state.",
 	Run: func(cmd *cobra.Command, args []string) {
 		r := request.CheckSBCRequest{
 			CheckDeviceRequest:          getCheckDeviceRequest(args[0]),
 			SystemHealthScoreThresholds: generateCheckThresholds(cmd, "system-health-score-warning", "", "system-health-score-critical", "", false),
+			AgentStatusStates:           getAgentStatusStates(cmd),
+		}
+		if cmd.Flags().Changed("ignore-agents") {
+			ignoreAgents := cmd.Flags().Lookup("ignore-agents").Value.String()
+			r.IgnoreAgents = &ignoreAgents
 		}
 		handleRequest(&r)
 	},
 }
+
+var monitoringStates = map[string]int{
+	"ok":       monitoringplugin.OK,
+	"warning":  monitoringplugin.WARNING,
+	"critical": monitoringplugin.CRITICAL,
+	"unknown":  monitoringplugin.UNKNOWN,
+}
+
+func getAgentStatusStates(cmd *cobra.Command) map[int]int {
+	flagValue, err := cmd.Flags().GetStringToString("agent-status-state")
+	if err != nil {
+		log.Fatal().Err(err).Msg("agent-status-state needs to be a list of status=state")
+	}
+	if len(flagValue) == 0 {
+		return nil
+	}
+	states := make(map[int]int)
+	for status, state := range flagValue {
+		s, err := strconv.Atoi(status)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("agent status '%s' is not a number", status)
+		}
+		monitoringState, ok := monitoringStates[strings.ToLower(state)]
+		if !ok {
+			log.Fatal().Msgf("monitoring state '%s' of agent status '%s' needs to be 'ok', 'warning', 'critical' or 'unknown'", state, status)
+		}
+		states[s] = monitoringState
+	}
+	return states
+}
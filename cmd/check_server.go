@@ -13,6 +13,10 @@ func init() {
 	checkServerCMD.Flags().Float64("procs-critical", 0, "critical threshold for procs count")
 	checkServerCMD.Flags().Float64("users-warning", 0, "warning threshold for users count")
 	checkServerCMD.Flags().Float64("users-critical", 0, "critical threshold for users count")
+	checkServerCMD.Flags().Float64("load-warning", 0, "warning threshold for the load averages")
+	checkServerCMD.Flags().Float64("load-critical", 0, "critical threshold for the load averages")
+	checkServerCMD.Flags().Float64("swap-usage-warning", 0, "warning threshold for swap usage in percent")
+	checkServerCMD.Flags().Float64("swap-usage-critical", 0, "critical threshold for swap usage in percent")
 }
 
 var checkServerCMD = &cobra.Command{
@@ -25,6 +29,8 @@ var checkServerCMD = &cobra.Command{
 			CheckDeviceRequest: getCheckDeviceRequest(args[0]),
 			UsersThreshold:     generateCheckThresholds(cmd, "", "users-warning", "", "users-critical", true),
 			ProcsThreshold:     generateCheckThresholds(cmd, "", "procs-warning", "", "procs-critical", true),
+			LoadThreshold:      generateCheckThresholds(cmd, "", "load-warning", "", "load-critical", true),
+			SwapUsageThreshold: generateCheckThresholds(cmd, "", "swap-usage-warning", "", "swap-usage-critical", true),
 		}
 		handleRequest(&r)
 	},
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/request"
 	"github.com/spf13/cobra"
 )
@@ -9,45 +10,65 @@ func init() {
 	addDeviceFlags(checkUPSCMD)
 	checkCMD.AddCommand(checkUPSCMD)
 
+	checkUPSCMD.Flags().String("batt-capacity-warning", "", "Warning threshold for battery capacity in the nagios range syntax, only checked if the mains voltage is applied or unknown")
+	checkUPSCMD.Flags().String("batt-capacity-critical", "", "Critical threshold for battery capacity in the nagios range syntax, only checked if the mains voltage is applied or unknown")
+
+	checkUPSCMD.Flags().String("batt-remaining-time-warning", "", "Warning threshold for battery remaining time in the nagios range syntax, only checked if the mains voltage is not applied or unknown")
+	checkUPSCMD.Flags().String("batt-remaining-time-critical", "", "Critical threshold for battery remaining time in the nagios range syntax, only checked if the mains voltage is not applied or unknown")
+
 	checkUPSCMD.Flags().Float64("batt-current-warning-min", 0, "Warning min threshold for battery current")
 	checkUPSCMD.Flags().Float64("batt-current-warning-max", 0, "Warning max threshold for battery current")
 	checkUPSCMD.Flags().Float64("batt-current-critical-min", 0, "Critical min threshold for battery current")
 	checkUPSCMD.Flags().Float64("batt-current-critical-max", 0, "Critical max threshold for battery current")
+	checkUPSCMD.Flags().String("batt-current-warning", "", "Warning threshold for battery current in the nagios range syntax, overrides the warning min and max")
+	checkUPSCMD.Flags().String("batt-current-critical", "", "Critical threshold for battery current in the nagios range syntax, overrides the critical min and max")
 
 	checkUPSCMD.Flags().Float64("batt-temperature-warning-min", 0, "Warning min threshold for battery temperature")
 	checkUPSCMD.Flags().Float64("batt-temperature-warning-max", 0, "Warning max threshold for battery temperature")
 	checkUPSCMD.Flags().Float64("batt-temperature-critical-min", 0, "Critical min threshold for battery temperature")
 	checkUPSCMD.Flags().Float64("batt-temperature-critical-max", 0, "Critical max threshold for battery temperature")
+	checkUPSCMD.Flags().String("batt-temperature-warning", "", "Warning threshold for battery temperature in the nagios range syntax, overrides the warning min and max")
+	checkUPSCMD.Flags().String("batt-temperature-critical", "", "Critical threshold for battery temperature in the nagios range syntax, overrides the critical min and max")
 
 	checkUPSCMD.Flags().Float64("current-load-warning-min", 0, "Warning min threshold for current load")
 	checkUPSCMD.Flags().Float64("current-load-warning-max", 0, "Warning max threshold for current load")
 	checkUPSCMD.Flags().Float64("current-load-critical-min", 0, "Critical min threshold for current load")
 	checkUPSCMD.Flags().Float64("current-load-critical-max", 0, "Critical max threshold for current load")
+	checkUPSCMD.Flags().String("current-load-warning", "", "Warning threshold for current load in the nagios range syntax, overrides the warning min and max")
+	checkUPSCMD.Flags().String("current-load-critical", "", "Critical threshold for current load in the nagios range syntax, overrides the critical min and max")
 
 	checkUPSCMD.Flags().Float64("rectifier-current-warning-min", 0, "Warning min threshold for rectifier current")
 	checkUPSCMD.Flags().Float64("rectifier-current-warning-max", 0, "Warning max threshold for rectifier current")
 	checkUPSCMD.Flags().Float64("rectifier-current-critical-min", 0, "Critical min threshold for rectifier current")
 	checkUPSCMD.Flags().Float64("rectifier-current-critical-max", 0, "Critical max threshold for rectifier current")
+	checkUPSCMD.Flags().String("rectifier-current-warning", "", "Warning threshold for rectifier current in the nagios range syntax, overrides the warning min and max")
+	checkUPSCMD.Flags().String("rectifier-current-critical", "", "Critical threshold for rectifier current in the nagios range syntax, overrides the critical min and max")
 
 	checkUPSCMD.Flags().Float64("system-voltage-warning-min", 0, "Warning min threshold for system voltage")
 	checkUPSCMD.Flags().Float64("system-voltage-warning-max", 0, "Warning max threshold for system voltage")
 	checkUPSCMD.Flags().Float64("system-voltage-critical-min", 0, "Critical min threshold for system voltage")
 	checkUPSCMD.Flags().Float64("system-voltage-critical-max", 0, "Critical max threshold for system voltage")
+	checkUPSCMD.Flags().String("system-voltage-warning", "", "Warning threshold for system voltage in the nagios range syntax, overrides the warning min and max")
+	checkUPSCMD.Flags().String("system-voltage-critical", "", "Critical threshold for system voltage in the nagios range syntax, overrides the critical min and max")
 }
 
 var checkUPSCMD = &cobra.Command{
 	Use:   "ups",
 	Short: "Checks whether a UPS device has its main voltage applied",
 	Long: "Checks whether a UPS device has its main voltage applied.\n\n" +
+		"If the mains voltage is not applied, the check is at least WARNING and the battery remaining time is checked. " +
+		"If it is applied, only the battery capacity is checked.\n\n" +
 		"All UPS statistics will be printed as performance data.",
 	Run: func(cmd *cobra.Command, args []string) {
 		r := request.CheckUPSRequest{
-			CheckDeviceRequest:           getCheckDeviceRequest(args[0]),
-			BatteryCurrentThresholds:     generateCheckThresholds(cmd, "batt-current-warning-min", "batt-current-warning-max", "batt-current-critical-min", "batt-current-critical-max", false),
-			BatteryTemperatureThresholds: generateCheckThresholds(cmd, "batt-temperature-warning-min", "batt-temperature-warning-max", "batt-temperature-critical-min", "batt-temperature-critical-max", false),
-			CurrentLoadThresholds:        generateCheckThresholds(cmd, "current-load-warning-min", "current-load-warning-max", "current-load-warning-max", "current-load-warning-max", false),
-			RectifierCurrentThresholds:   generateCheckThresholds(cmd, "rectifier-current-warning-min", "rectifier-current-warning-max", "rectifier-current-critical-min", "rectifier-current-critical-max", false),
-			SystemVoltageThresholds:      generateCheckThresholds(cmd, "system-voltage-warning-min", "system-voltage-warning-max", "system-voltage-critical-min", "system-voltage-critical-max", false),
+			CheckDeviceRequest:             getCheckDeviceRequest(args[0]),
+			BatteryCapacityThresholds:      generateCheckThreshold(cmd, "batt-capacity-warning", "batt-capacity-critical", monitoringplugin.Thresholds{}),
+			BatteryRemainingTimeThresholds: generateCheckThreshold(cmd, "batt-remaining-time-warning", "batt-remaining-time-critical", monitoringplugin.Thresholds{}),
+			BatteryCurrentThresholds:       generateCheckThreshold(cmd, "batt-current-warning", "batt-current-critical", generateCheckThresholds(cmd, "batt-current-warning-min", "batt-current-warning-max", "batt-current-critical-min", "batt-current-critical-max", false)),
+			BatteryTemperatureThresholds:   generateCheckThreshold(cmd, "batt-temperature-warning", "batt-temperature-critical", generateCheckThresholds(cmd, "batt-temperature-warning-min", "batt-temperature-warning-max", "batt-temperature-critical-min", "batt-temperature-critical-max", false)),
+			CurrentLoadThresholds:          generateCheckThreshold(cmd, "current-load-warning", "current-load-critical", generateCheckThresholds(cmd, "current-load-warning-min", "current-load-warning-max", "current-load-critical-min", "current-load-critical-max", false)),
+			RectifierCurrentThresholds:     generateCheckThreshold(cmd, "rectifier-current-warning", "rectifier-current-critical", generateCheckThresholds(cmd, "rectifier-current-warning-min", "rectifier-current-warning-max", "rectifier-current-critical-min", "rectifier-current-critical-max", false)),
+			SystemVoltageThresholds:        generateCheckThreshold(cmd, "system-voltage-warning", "system-voltage-critical", generateCheckThresholds(cmd, "system-voltage-warning-min", "system-voltage-warning-max", "system-voltage-critical-min", "system-voltage-critical-max", false)),
 		}
 		handleRequest(&r)
 	},
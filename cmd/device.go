@@ -10,5 +10,6 @@ import (
 func addBinarySpecificDeviceFlags(fs *flag.FlagSet) {
 	fs.StringSlice("snmp-community", defaultSNMPCommunity, "Community strings for SNMP to use")
 	fs.StringSlice("snmp-version", defaultSNMPVersion, "SNMP versions to use (1, 2c or 3)")
-	fs.IntSlice("snmp-port", defaultSNMPPort, "Ports for SNMP to use")
+	fs.IntSlice("snmp-port", defaultSNMPPort, "Ports for SNMP to use, tried in the given order")
+	fs.IntSlice("ssh-port", defaultSSHPort, "Ports for SSH to use, tried in the given order")
 }
@@ -10,14 +10,22 @@ import (
 var deviceFlagSet = buildDeviceFlagSet()
 
 var (
-	defaultRequestTimeout                 = 0
-	defaultSNMPCommunity                  = []string{"public"}
-	defaultSNMPVersion                    = []string{"2c", "1"}
-	defaultSNMPPort                       = []int{161}
-	defaultSNMPMaxRepetitions      uint32 = 0
-	defaultSNMPDiscoverParRequests        = 5
-	defaultSNMPDiscoverTimeout            = 2
-	defaultSNMPDiscoverRetries            = 0
+	defaultRequestTimeout                  = 0
+	defaultSNMPCommunity                   = []string{"public"}
+	defaultSNMPVersion                     = []string{"2c", "1"}
+	defaultSNMPPort                        = []int{161}
+	defaultSNMPMaxRepetitions       uint32 = 0
+	defaultSNMPDiscoverParRequests         = 5
+	defaultSNMPDiscoverTimeout             = 2
+	defaultSNMPDiscoverRetries             = 0
+	defaultSNMPWalkRetries                 = 0
+	defaultSNMPWalkRetryDelay              = 100
+	defaultSNMPEmptyValueRetries           = 0
+	defaultSNMPEmptyValueRetryDelay        = 1000
+	defaultSNMPMaxWalkRows                 = 0
+	defaultSSHPort                         = []int{22}
+	defaultBatchWorkers                    = 10
+	defaultBatchTimeout                    = 0
 )
 
 func setDeviceDefaults() {
@@ -28,6 +36,12 @@ func setDeviceDefaults() {
 	viper.SetDefault("device.snmp-discover-par-requests", defaultSNMPDiscoverParRequests)
 	viper.SetDefault("device.snmp-discover-timeout", defaultSNMPDiscoverTimeout)
 	viper.SetDefault("device.snmp-discover-retries", defaultSNMPDiscoverRetries)
+	viper.SetDefault("device.snmp-walk-retries", defaultSNMPWalkRetries)
+	viper.SetDefault("device.snmp-walk-retry-delay", defaultSNMPWalkRetryDelay)
+	viper.SetDefault("device.snmp-empty-value-retries", defaultSNMPEmptyValueRetries)
+	viper.SetDefault("device.snmp-empty-value-retry-delay", defaultSNMPEmptyValueRetryDelay)
+	viper.SetDefault("device.snmp-max-walk-rows", defaultSNMPMaxWalkRows)
+	viper.SetDefault("device.ssh-ports", defaultSSHPort)
 }
 
 func buildDeviceFlagSet() *flag.FlagSet {
@@ -39,26 +53,63 @@ func buildDeviceFlagSet() *flag.FlagSet {
 	fs.Int("snmp-discover-par-requests", defaultSNMPDiscoverParRequests, "The amount of parallel connection requests used while trying to get a valid SNMP connection")
 	fs.Int("snmp-discover-timeout", defaultSNMPDiscoverTimeout, "The timeout in seconds used while trying to get a valid SNMP connection")
 	fs.Int("snmp-discover-retries", defaultSNMPDiscoverRetries, "The retries used while trying to get a valid SNMP connection")
+	fs.Int("snmp-walk-retries", defaultSNMPWalkRetries, "The retries of a failed SNMP walk while reading out device class properties (at most 10)")
+	fs.Int("snmp-walk-retry-delay", defaultSNMPWalkRetryDelay, "The base delay in milliseconds before retrying a failed SNMP walk (doubled after every retry, at most 10 seconds)")
+	fs.Int("snmp-empty-value-retries", defaultSNMPEmptyValueRetries, "The retries of an identify property (e.g. vendor or model) if the device returns an empty value (at most 10)")
+	fs.Int("snmp-empty-value-retry-delay", defaultSNMPEmptyValueRetryDelay, "The delay in milliseconds before retrying an identify property with an empty value (at most 10 seconds)")
+	fs.Int("snmp-max-walk-rows", defaultSNMPMaxWalkRows, "The maximum amount of rows of an SNMP walk, the walk is stopped after this amount of rows (0 => unlimited)")
+	fs.Bool("snmp-writes-enabled", false, "Allow write operations like setting the admin status of an interface on the device")
+	fs.StringToString("component-timeout-weights", nil, "Weights for splitting the request timeout between components (e.g. 'ups=2'). Components without a weight have the weight 1")
+	fs.Bool("no-identify-cache", false, "Don't use the identify cache of the API for this request")
+	fs.Bool("snmp-trace", false, "Add a trace of all snmp requests sent to the device to the response")
 	fs.Uint32("snmp-max-repetitions", defaultSNMPMaxRepetitions, "The max repetitions of the SNMP connection. Overrides the device class settings if set")
+	fs.String("snmp-walk-mode", "", "The walk mode of the SNMP connection ('bulk' or 'getnext'). Overrides the device class settings if set")
 	fs.String("snmp-v3-level", "", "The level of the SNMP v3 connection ('noAuthNoPriv', 'authNoPriv' or 'authPriv')")
 	fs.String("snmp-v3-context", "", "The context name of the SNMP v3 connection")
 	fs.String("snmp-v3-user", "", "The username of the SNMP v3 connection")
 	fs.String("snmp-v3-auth-key", "", "The authentication passphrase of the SNMP v3 connection")
 	fs.String("snmp-v3-auth-proto", "", "The authentication protocol of the SNMP v3 connection (e.g. 'MD5' or 'SHA')")
 	fs.String("snmp-v3-priv-key", "", "The privacy passphrase of the SNMP v3 connection")
-	fs.String("snmp-v3-priv-proto", "", "The privacy protocol of the SNMP v3 connection (e.g. 'DES' or 'AES')")
+	fs.String("snmp-v3-priv-proto", "", "The privacy protocol of the SNMP v3 connection (e.g. 'DES', 'AES', 'AES192' or 'AES256')")
 	fs.IntSlice("http-port", nil, "Ports for HTTP to use")
 	fs.IntSlice("https-port", nil, "Ports for HTTPS to use")
 	fs.String("http-username", "", "Username for HTTP/HTTPS authorization")
 	fs.String("http-password", "", "Password for HTTP/HTTPS authorization")
+	fs.String("http-token", "", "Token for HTTP/HTTPS bearer authorization, it takes precedence over username and password")
+	fs.String("ssh-username", "", "Username for the SSH login. The SSH connection is only used if it is set")
+	fs.String("ssh-password", "", "Password for the SSH login")
+	fs.String("ssh-key-file", "", "File with the PEM encoded private key for the SSH login")
+	fs.String("ssh-host-key-fingerprint", "", "SHA256 fingerprint of the SSH host key of the device (e.g. 'SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s')")
+	fs.String("ssh-known-hosts-file", "", "known_hosts file which is used to verify the SSH host keys of the devices")
+	fs.Bool("ssh-insecure-host-key", false, "Don't verify the SSH host key of the device. The login credentials can be intercepted if the connection is not trusted")
+	fs.String("hosts-file", "", "File with one host per line. The request is processed for all hosts in one batch instead of a single host")
+	fs.Int("batch-workers", defaultBatchWorkers, "The amount of hosts of a batch which are processed concurrently")
+	fs.Int("batch-timeout", defaultBatchTimeout, "Overall timeout for a batch in seconds (0 => no timeout)")
 
 	return fs
 }
 
 func addDeviceFlags(cmd *cobra.Command) {
 	cmd.Flags().AddFlagSet(deviceFlagSet)
-	cmd.Args = cobra.ExactArgs(1)
+	cmd.Args = deviceArgs
 	cmd.Use += " [host]"
+
+	run := cmd.Run
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		if deviceFlagSet.Changed("hosts-file") {
+			runForHostsFile(cmd, run)
+			return
+		}
+		run(cmd, args)
+	}
+}
+
+// deviceArgs requires exactly one host, or no host at all if a hosts file is given.
+func deviceArgs(cmd *cobra.Command, args []string) error {
+	if deviceFlagSet.Changed("hosts-file") {
+		return cobra.NoArgs(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
 }
 
 func bindDeviceFlags(cmd *cobra.Command) error {
@@ -71,6 +122,51 @@ func bindDeviceFlags(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if x := cmd.Flags().Lookup("hosts-file"); x != nil {
+		err := viper.BindPFlag("request.hosts-file", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag hosts-file")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("batch-workers"); x != nil {
+		err := viper.BindPFlag("request.batch-workers", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag batch-workers")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("batch-timeout"); x != nil {
+		err := viper.BindPFlag("request.batch-timeout", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag batch-timeout")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("no-identify-cache"); x != nil {
+		err := viper.BindPFlag("request.no-identify-cache", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag no-identify-cache")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("snmp-trace"); x != nil {
+		err := viper.BindPFlag("request.snmp-trace", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-trace")
+			return err
+		}
+	}
 	if x := cmd.Flags().Lookup("snmp-max-repetitions"); x != nil {
 		err := viper.BindPFlag("device.snmp-max-repetitions", x)
 		if err != nil {
@@ -80,6 +176,15 @@ func bindDeviceFlags(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if x := cmd.Flags().Lookup("snmp-walk-mode"); x != nil {
+		err := viper.BindPFlag("device.snmp-walk-mode", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-walk-mode")
+			return err
+		}
+	}
 	if x := cmd.Flags().Lookup("snmp-discover-par-requests"); x != nil {
 		err := viper.BindPFlag("device.snmp-discover-par-requests", x)
 		if err != nil {
@@ -107,6 +212,60 @@ func bindDeviceFlags(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if x := cmd.Flags().Lookup("snmp-walk-retries"); x != nil {
+		err := viper.BindPFlag("device.snmp-walk-retries", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-walk-retries")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("snmp-walk-retry-delay"); x != nil {
+		err := viper.BindPFlag("device.snmp-walk-retry-delay", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-walk-retry-delay")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("snmp-empty-value-retries"); x != nil {
+		err := viper.BindPFlag("device.snmp-empty-value-retries", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-empty-value-retries")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("snmp-empty-value-retry-delay"); x != nil {
+		err := viper.BindPFlag("device.snmp-empty-value-retry-delay", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-empty-value-retry-delay")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("snmp-max-walk-rows"); x != nil {
+		err := viper.BindPFlag("device.snmp-max-walk-rows", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-max-walk-rows")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("snmp-writes-enabled"); x != nil {
+		err := viper.BindPFlag("device.snmp-writes-enabled", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-writes-enabled")
+			return err
+		}
+	}
 	if x := cmd.Flags().Lookup("snmp-community"); x != nil {
 		err := viper.BindPFlag("device.snmp-communities", x)
 		if err != nil {
@@ -233,5 +392,77 @@ func bindDeviceFlags(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if x := cmd.Flags().Lookup("http-token"); x != nil {
+		err := viper.BindPFlag("device.http-token", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag http-token")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-port"); x != nil {
+		err := viper.BindPFlag("device.ssh-ports", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-port")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-username"); x != nil {
+		err := viper.BindPFlag("device.ssh-username", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-username")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-password"); x != nil {
+		err := viper.BindPFlag("device.ssh-password", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-password")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-key-file"); x != nil {
+		err := viper.BindPFlag("device.ssh-key-file", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-key-file")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-host-key-fingerprint"); x != nil {
+		err := viper.BindPFlag("device.ssh-host-key-fingerprint", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-host-key-fingerprint")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-known-hosts-file"); x != nil {
+		err := viper.BindPFlag("device.ssh-known-hosts-file", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-known-hosts-file")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-insecure-host-key"); x != nil {
+		err := viper.BindPFlag("device.ssh-insecure-host-key", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-insecure-host-key")
+			return err
+		}
+	}
 	return nil
 }
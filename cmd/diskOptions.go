@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+var diskOptionsFlagSet = buildDiskOptionsFlagSet()
+
+func buildDiskOptionsFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("disk_options", flag.ContinueOnError)
+
+	fs.StringSlice("storage-include-filter", []string{}, "Only keep storages whose description matches one of the given regex")
+	fs.StringSlice("storage-exclude-filter", []string{}, "Filter out storages whose description matches the given regex")
+
+	return fs
+}
+
+func addDiskOptionsFlags(cmd *cobra.Command) {
+	cmd.Flags().AddFlagSet(diskOptionsFlagSet)
+}
+
+func getDiskOptions() request.DiskOptions {
+	storageIncludeFilter, err := diskOptionsFlagSet.GetStringSlice("storage-include-filter")
+	if err != nil {
+		log.Fatal().Err(err).Msg("storage-include-filter needs to be a string")
+	}
+	storageExcludeFilter, err := diskOptionsFlagSet.GetStringSlice("storage-exclude-filter")
+	if err != nil {
+		log.Fatal().Err(err).Msg("storage-exclude-filter needs to be a string")
+	}
+
+	return request.DiskOptions{
+		StorageIncludeFilter: storageIncludeFilter,
+		StorageExcludeFilter: storageExcludeFilter,
+	}
+}
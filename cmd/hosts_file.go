@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bufio"
+	"github.com/inexio/thola/internal/request"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+)
+
+// batchRequestCollector collects the requests of the commands instead of processing them, if it is set.
+var batchRequestCollector func(r request.Request)
+
+// runForHostsFile builds the request of the command for every host of the hosts file and processes them in one batch.
+func runForHostsFile(cmd *cobra.Command, run func(cmd *cobra.Command, args []string)) {
+	hosts, err := readHostsFile(viper.GetString("request.hosts-file"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to read hosts file")
+	}
+
+	var requests []request.Request
+	batchRequestCollector = func(r request.Request) {
+		requests = append(requests, r)
+	}
+	for _, host := range hosts {
+		run(cmd, []string{host})
+	}
+	batchRequestCollector = nil
+
+	batchTimeout := viper.GetInt("request.batch-timeout")
+	batchWorkers := viper.GetInt("request.batch-workers")
+	handleBatchRequest(requests, request.BatchOptions{
+		Workers: &batchWorkers,
+		Timeout: &batchTimeout,
+	})
+}
+
+// readHostsFile returns all hosts of the given file. Empty lines and lines starting with '#' are ignored.
+func readHostsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		host := strings.TrimSpace(scanner.Text())
+		if host == "" || strings.HasPrefix(host, "#") {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, errors.New("hosts file contains no hosts")
+	}
+	return hosts, nil
+}
+
+// batchOutputFormat returns the output format for batch responses. The check plugin format can only describe a single
+// check, so batches of checks are printed in the pretty format instead.
+func batchOutputFormat() string {
+	if format := viper.GetString("format"); format != "check-plugin" {
+		return format
+	}
+	return "pretty"
+}
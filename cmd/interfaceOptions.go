@@ -14,6 +14,7 @@ func buildInterfaceOptionsFlagSet() *flag.FlagSet {
 
 	fs.StringSlice("value", []string{}, "If set only the specified values will be read from the interfaces (e.g. 'ifDescr')")
 	fs.Bool("snmp-gets-instead-of-walk", false, "Use SNMP Gets instead of Walks")
+	fs.Bool("skip-vendor-enrichment", false, "Only read the interfaces of the device class without vendor-specific values (e.g. SAPs), which need extra requests")
 	fs.String("ifDescr-regex", "", "Apply a regex on the ifDescr of the interfaces. Use it together with the 'ifDescr-regex-replace' flag")
 	fs.String("ifDescr-regex-replace", "", "Apply a regex on the ifDescr of the interfaces. Use it together with the 'ifDescr-regex' flag")
 	fs.StringSlice("ifType-filter", []string{}, "Filter out interfaces which ifType equals the given types")
@@ -36,6 +37,10 @@ func getInterfaceOptions() request.InterfaceOptions {
 	if err != nil {
 		log.Fatal().Err(err).Msg("snmp-gets-instead-of-walk needs to be a boolean")
 	}
+	skipVendorEnrichment, err := interfaceOptionsFlagSet.GetBool("skip-vendor-enrichment")
+	if err != nil {
+		log.Fatal().Err(err).Msg("skip-vendor-enrichment needs to be a boolean")
+	}
 	ifDescrRegex, err := interfaceOptionsFlagSet.GetString("ifDescr-regex")
 	if err != nil {
 		log.Fatal().Err(err).Msg("ifDescr-regex needs to be a string")
@@ -65,5 +70,6 @@ func getInterfaceOptions() request.InterfaceOptions {
 		IfNameFilter:          ifNameFilter,
 		IfDescrFilter:         ifDescrFilter,
 		SNMPGetsInsteadOfWalk: snmpGetsInsteadOfWalk,
+		SkipVendorEnrichment:  skipVendorEnrichment,
 	}
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func init() {
+	addDeviceFlags(readComponentsCMD)
+	readCMD.AddCommand(readComponentsCMD)
+
+	readComponentsCMD.Flags().Bool("split-timeout", false, "Split the time until the timeout between the components, so that a slow component can't use up the time of the following components")
+
+	err := viper.BindPFlag("readComponents.split-timeout", readComponentsCMD.Flags().Lookup("split-timeout"))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+var readComponentsCMD = &cobra.Command{
+	Use:   "components",
+	Short: "Read out all available components of a device",
+	Long: "Read out all available components of a device.\n\n" +
+		"Components that can't be read are reported in the errors of the response, the other components are still returned.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadComponentsRequest{
+			SplitTimeout: viper.GetBool("readComponents.split-timeout"),
+			ReadRequest:  getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
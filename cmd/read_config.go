@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func init() {
+	addDeviceFlags(readConfigCMD)
+	readCMD.AddCommand(readConfigCMD)
+
+	readConfigCMD.Flags().String("protocol", "tftp", "Protocol which is used to copy the config (tftp, ftp, rcp, scp or sftp)")
+	readConfigCMD.Flags().String("server", "", "IP address of the server to which the config is copied")
+	readConfigCMD.Flags().String("path", "", "Path of the config file on the server")
+	readConfigCMD.Flags().String("username", "", "Username for the server")
+	readConfigCMD.Flags().String("password", "", "Password for the server")
+	readConfigCMD.Flags().Bool("allow-write", false, "Allow the snmp set requests that are needed to copy the config")
+
+	for _, flag := range []string{"protocol", "server", "path", "username", "password", "allow-write"} {
+		err := viper.BindPFlag("readConfig."+flag, readConfigCMD.Flags().Lookup(flag))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+var readConfigCMD = &cobra.Command{
+	Use:   "config",
+	Short: "Copy the configuration of a device to a server",
+	Long: "Copy the running configuration of a device to a server.\n\n" +
+		"The copy is triggered with snmp set requests, so a community with write access is needed,\n" +
+		"snmp writes have to be enabled with --snmp-writes-enabled and the write operations have to be\n" +
+		"allowed with --allow-write. Only some device classes support it.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadConfigRequest{
+			Destination: device.ConfigBackupDestination{
+				Protocol: viper.GetString("readConfig.protocol"),
+				Server:   viper.GetString("readConfig.server"),
+				Path:     viper.GetString("readConfig.path"),
+				Username: viper.GetString("readConfig.username"),
+				Password: viper.GetString("readConfig.password"),
+			},
+			AllowWrite:  viper.GetBool("readConfig.allow-write"),
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -7,6 +7,7 @@ import (
 
 func init() {
 	addDeviceFlags(readDiskCMD)
+	addDiskOptionsFlags(readDiskCMD)
 	readCMD.AddCommand(readDiskCMD)
 }
 
@@ -16,6 +17,7 @@ var readDiskCMD = &cobra.Command{
 	Long:  "Read out storage information of a device like types and used space of storages.",
 	Run: func(cmd *cobra.Command, args []string) {
 		request := request.ReadDiskRequest{
+			DiskOptions: getDiskOptions(),
 			ReadRequest: getReadRequest(args[0]),
 		}
 		handleRequest(&request)
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readEntity)
+	readCMD.AddCommand(readEntity)
+}
+
+var readEntity = &cobra.Command{
+	Use:   "entity",
+	Short: "Read out the physical entities of a device",
+	Long: "Read out the physical entities of a device, e.g. its chassis, slots, modules and sensors.\n\n" +
+		"The parent index of every entity can be used to rebuild the containment tree.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadEntityRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readFirewall)
+	readCMD.AddCommand(readFirewall)
+}
+
+var readFirewall = &cobra.Command{
+	Use:   "firewall",
+	Short: "Read out the sessions, vpn tunnels and licenses of a firewall",
+	Long:  "Read out the count of active sessions, the vpn tunnels with their status and the licenses with their expiry of a firewall.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadFirewallRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
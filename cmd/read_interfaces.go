@@ -3,12 +3,26 @@ package cmd
 import (
 	"github.com/inexio/thola/internal/request"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
 )
 
 func init() {
 	addDeviceFlags(readInterfacesCMD)
 	addInterfaceOptionsFlags(readInterfacesCMD)
 	readCMD.AddCommand(readInterfacesCMD)
+
+	readInterfacesCMD.Flags().Int("offset", 0, "Amount of interfaces to skip after filtering")
+	readInterfacesCMD.Flags().Int("limit", 0, "Maximum amount of interfaces to read out (0 => no limit)")
+
+	err := viper.BindPFlag("readInterfaces.offset", readInterfacesCMD.Flags().Lookup("offset"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = viper.BindPFlag("readInterfaces.limit", readInterfacesCMD.Flags().Lookup("limit"))
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 var readInterfacesCMD = &cobra.Command{
@@ -19,6 +33,8 @@ var readInterfacesCMD = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		request := request.ReadInterfacesRequest{
 			InterfaceOptions: getInterfaceOptions(),
+			Offset:           viper.GetInt("readInterfaces.offset"),
+			Limit:            viper.GetInt("readInterfaces.limit"),
 			ReadRequest:      getReadRequest(args[0]),
 		}
 		handleRequest(&request)
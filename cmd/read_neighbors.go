@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readNeighborsCMD)
+	readCMD.AddCommand(readNeighborsCMD)
+}
+
+var readNeighborsCMD = &cobra.Command{
+	Use:   "neighbors",
+	Short: "Read out the LLDP/CDP neighbors of a device",
+	Long:  "Read out the neighbors of a device discovered via LLDP or CDP.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadNeighborsRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func init() {
+	addDeviceFlags(readOIDCMD)
+	readCMD.AddCommand(readOIDCMD)
+
+	readOIDCMD.Flags().String("oid", "", "The OID which is walked")
+
+	err := viper.BindPFlag("readOID.oid", readOIDCMD.Flags().Lookup("oid"))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+var readOIDCMD = &cobra.Command{
+	Use:   "oid",
+	Short: "Read out the raw values of an OID",
+	Long: "Read out the raw values of an OID mapped by their index.\n\n" +
+		"The OID is walked without identifying the device, which can be used to debug device classes.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadOIDRequest{
+			OID:         network.OID(viper.GetString("readOID.oid")),
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
+)
+
+func init() {
+	addDeviceFlags(readRoutesCMD)
+	readCMD.AddCommand(readRoutesCMD)
+
+	readRoutesCMD.Flags().Bool("count-only", false, "Only read out the count of routes instead of the whole routing table")
+
+	err := viper.BindPFlag("readRoutes.count-only", readRoutesCMD.Flags().Lookup("count-only"))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+var readRoutesCMD = &cobra.Command{
+	Use:   "routes",
+	Short: "Read out the routing table of a device",
+	Long: "Read out the routing table of a device.\n\n" +
+		"Use --count-only to avoid reading out the whole routing table of devices with a lot of routes.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadRoutesRequest{
+			CountOnly:   viper.GetBool("readRoutes.count-only"),
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readVLANsCMD)
+	readCMD.AddCommand(readVLANsCMD)
+}
+
+var readVLANsCMD = &cobra.Command{
+	Use:   "vlans",
+	Short: "Read out the VLANs of a device",
+	Long:  "Read out the VLANs of a device with their IDs, names, states and port memberships.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadVLANsRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readWLAN)
+	readCMD.AddCommand(readWLAN)
+}
+
+var readWLAN = &cobra.Command{
+	Use:   "wlan",
+	Short: "Read out the access points of a wireless controller",
+	Long: "Read out the access points of a wireless controller with their model, status and count of clients.\n\n" +
+		"Access points which are not ready yet, e.g. because they are downloading their image, are reported with their status.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadWLANRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
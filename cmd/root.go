@@ -11,7 +11,6 @@ import (
 	"github.com/inexio/thola/internal/parser"
 	"github.com/inexio/thola/internal/request"
 	"github.com/pkg/errors"
-	"github.com/rs/xid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -29,7 +28,7 @@ func init() {
 
 	rootCMD.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "The location of the config file")
 	rootCMD.PersistentFlags().StringP("loglevel", "l", "error", "The loglevel")
-	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml' or 'pretty')")
+	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml', 'pretty' or 'prometheus')")
 	rootCMD.PersistentFlags().String("db-drivername", "built-in", "Database type for caching ('built-in', 'mysql' or 'redis' supported)")
 	rootCMD.PersistentFlags().String("db-duration", "60m", "Duration in which the cache stays valid")
 	rootCMD.PersistentFlags().String("sql-datasourcename", "", "Data sourcename if using a sql driver")
@@ -41,6 +40,8 @@ func init() {
 	rootCMD.PersistentFlags().Bool("db-rebuild", false, "Rebuild the cache DB")
 	rootCMD.PersistentFlags().Bool("no-cache", false, "Don't use a database cache")
 	rootCMD.PersistentFlags().Bool("ignore-db-failure", false, "Ignore the cache if the database fails")
+	rootCMD.PersistentFlags().Int("snmp-trace-max-entries", 1000, "The maximum number of snmp requests recorded in the snmp trace of a request (0 => no limit)")
+	rootCMD.PersistentFlags().String("device-class-dir", "", "Directory with the device classes, which replaces the built-in device classes (needs to contain a 'generic.yaml')")
 	rootCMD.Flags().BoolP("version", "v", false, "Prints the version of Thola")
 
 	err := viper.BindPFlag("config", rootCMD.PersistentFlags().Lookup("config"))
@@ -138,6 +139,22 @@ func init() {
 			Msg("Can't bind flag ignore-db-failure")
 		return
 	}
+
+	err = viper.BindPFlag("request.snmp-trace-max-entries", rootCMD.PersistentFlags().Lookup("snmp-trace-max-entries"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag snmp-trace-max-entries")
+		return
+	}
+
+	err = viper.BindPFlag("device-class-dir", rootCMD.PersistentFlags().Lookup("device-class-dir"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag device-class-dir")
+		return
+	}
 }
 
 func initConfig() {
@@ -173,7 +190,7 @@ var rootCMD = &cobra.Command{
 		if err != nil {
 			return errors.Wrap(err, "failed to bind device flags")
 		}
-		if !(viper.GetString("format") == "json" || viper.GetString("format") == "xml" || viper.GetString("format") == "pretty") {
+		if !(viper.GetString("format") == "json" || viper.GetString("format") == "xml" || viper.GetString("format") == "pretty" || viper.GetString("format") == "prometheus") {
 			return errors.New("invalid output format set")
 		}
 		loglevel, err := zerolog.ParseLevel(viper.GetString("loglevel"))
@@ -200,8 +217,12 @@ func Execute() {
 }
 
 func handleRequest(r request.Request) {
-	logger := log.With().Str("request_id", xid.New().String()).Logger()
-	ctx := logger.WithContext(context.Background())
+	if batchRequestCollector != nil {
+		batchRequestCollector(r)
+		return
+	}
+
+	ctx := request.NewContextWithRequestID(context.Background(), request.NewRequestID())
 
 	db, err := database.GetDB(ctx)
 	if err != nil {
@@ -231,3 +252,29 @@ func handleRequest(r request.Request) {
 	fmt.Printf("%s\n", b)
 	os.Exit(resp.GetExitCode())
 }
+
+func handleBatchRequest(requests []request.Request, options request.BatchOptions) {
+	ctx := request.NewContextWithRequestID(context.Background(), request.NewRequestID())
+
+	db, err := database.GetDB(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to get DB")
+		os.Exit(3)
+	}
+
+	resp, err := request.ProcessBatch(ctx, requests, options, request.ProcessRequest)
+	_ = db.CloseConnection(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("batch request failed")
+		os.Exit(3)
+	}
+
+	b, err := parser.Parse(resp, batchOutputFormat())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Request successful, but failed to parse response")
+		os.Exit(3)
+	}
+
+	fmt.Printf("%s\n", b)
+	os.Exit(resp.GetExitCode())
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/inexio/thola/internal/parser"
 	"github.com/inexio/thola/internal/request"
 	"github.com/pkg/errors"
-	"github.com/rs/xid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -22,7 +21,7 @@ func init() {
 	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
 
 	rootCMD.PersistentFlags().StringP("loglevel", "l", "error", "The loglevel")
-	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml' or 'pretty')")
+	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml', 'pretty' or 'prometheus')")
 	rootCMD.PersistentFlags().StringP("target-api", "t", "", "The URL of the target API")
 	rootCMD.PersistentFlags().String("target-api-username", "", "The username for authorization on the target API")
 	rootCMD.PersistentFlags().String("target-api-password", "", "The password for authorization on the target API")
@@ -111,7 +110,7 @@ var rootCMD = &cobra.Command{
 		if err != nil {
 			return errors.Wrap(err, "failed to bind device flags")
 		}
-		if !(viper.GetString("format") == "json" || viper.GetString("format") == "xml" || viper.GetString("format") == "pretty") {
+		if !(viper.GetString("format") == "json" || viper.GetString("format") == "xml" || viper.GetString("format") == "pretty" || viper.GetString("format") == "prometheus") {
 			return errors.New("invalid output format set")
 		}
 		if !(viper.GetString("target-api-format") == "json" || viper.GetString("target-api-format") == "xml") {
@@ -141,9 +140,12 @@ func Execute() {
 }
 
 func handleRequest(r request.Request) {
-	rid := xid.New().String()
-	logger := log.With().Str("request_id", rid).Logger()
-	ctx := logger.WithContext(request.NewContextWithRequestID(context.Background(), rid))
+	if batchRequestCollector != nil {
+		batchRequestCollector(r)
+		return
+	}
+
+	ctx := request.NewContextWithRequestID(context.Background(), request.NewRequestID())
 
 	log.Ctx(ctx).Debug().Msg("sending request")
 
@@ -164,3 +166,24 @@ func handleRequest(r request.Request) {
 	fmt.Printf("%s\n", b)
 	os.Exit(resp.GetExitCode())
 }
+
+func handleBatchRequest(requests []request.Request, options request.BatchOptions) {
+	ctx := request.NewContextWithRequestID(context.Background(), request.NewRequestID())
+
+	log.Ctx(ctx).Debug().Msg("sending batch request")
+
+	resp, err := request.ProcessBatch(ctx, requests, options, request.ProcessRequest)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("batch request failed")
+		os.Exit(3)
+	}
+
+	b, err := parser.Parse(resp, batchOutputFormat())
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Request successful, but failed to parse response")
+		os.Exit(3)
+	}
+
+	fmt.Printf("%s\n", b)
+	os.Exit(resp.GetExitCode())
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func init() {
+	rootCMD.AddCommand(schemaCMD)
+}
+
+var schemaCMD = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON schema of all components",
+	Long: "Print the JSON schema of the device and all components.\n\n" +
+		"The schema is generated from the current version of Thola and describes all fields that can be returned.",
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := device.JSONSchema()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to generate schema: %s\n", err)
+			os.Exit(3)
+		}
+		fmt.Printf("%s\n", b)
+	},
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/inexio/thola/internal/utility"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
+	"io/ioutil"
+	"strconv"
 )
 
 func getBaseRequest(host string) request.BaseRequest {
@@ -17,11 +19,13 @@ func getBaseRequest(host string) request.BaseRequest {
 	var nullString *string
 	timeout := viper.GetInt("request.timeout")
 	maxRepetitions := viper.GetUint32("device.snmp-max-repetitions")
+	walkMode := viper.GetString("device.snmp-walk-mode")
 	parallelRequests := viper.GetInt("device.snmp-discover-par-requests")
 	discoverTimeout := viper.GetInt("device.snmp-discover-timeout")
 	retries := viper.GetInt("device.snmp-discover-retries")
 	authUsername := viper.GetString("device.http-username")
 	authPassword := viper.GetString("device.http-password")
+	authToken := viper.GetString("device.http-token")
 	v3Level := viper.GetString("device.snmp-v3-level")
 	v3ContextName := viper.GetString("device.snmp-v3-context")
 	v3User := viper.GetString("device.snmp-v3-user")
@@ -29,8 +33,14 @@ func getBaseRequest(host string) request.BaseRequest {
 	v3AuthProto := viper.GetString("device.snmp-v3-auth-proto")
 	v3PrivKey := viper.GetString("device.snmp-v3-priv-key")
 	v3PrivProto := viper.GetString("device.snmp-v3-priv-proto")
+	sshUsername := viper.GetString("device.ssh-username")
+	sshPassword := viper.GetString("device.ssh-password")
+	sshHostKeyFingerprint := viper.GetString("device.ssh-host-key-fingerprint")
 	return request.BaseRequest{
-		Timeout: utility.IfThenElse(deviceFlagSet.Changed("timeout"), &timeout, nullInt).(*int),
+		Timeout:                 utility.IfThenElse(deviceFlagSet.Changed("timeout"), &timeout, nullInt).(*int),
+		ComponentTimeoutWeights: getComponentTimeoutWeights(),
+		NoIdentifyCache:         viper.GetBool("request.no-identify-cache"),
+		SNMPTrace:               viper.GetBool("request.snmp-trace"),
 		DeviceData: request.DeviceData{
 			IPAddress: host,
 			ConnectionData: network.ConnectionData{
@@ -39,6 +49,7 @@ func getBaseRequest(host string) request.BaseRequest {
 					Versions:                 utility.IfThenElse(deviceFlagSet.Changed("snmp-version"), viper.GetStringSlice("device.snmp-versions"), []string{}).([]string),
 					Ports:                    utility.IfThenElse(deviceFlagSet.Changed("snmp-port"), viper.GetIntSlice("device.snmp-ports"), []int{}).([]int),
 					MaxRepetitions:           utility.IfThenElse(deviceFlagSet.Changed("snmp-max-repetitions"), &maxRepetitions, nullUInt32).(*uint32),
+					WalkMode:                 utility.IfThenElse(deviceFlagSet.Changed("snmp-walk-mode"), &walkMode, nullString).(*string),
 					DiscoverParallelRequests: utility.IfThenElse(deviceFlagSet.Changed("snmp-discover-par-requests"), &parallelRequests, nullInt).(*int),
 					DiscoverTimeout:          utility.IfThenElse(deviceFlagSet.Changed("snmp-discover-timeout"), &discoverTimeout, nullInt).(*int),
 					DiscoverRetries:          utility.IfThenElse(deviceFlagSet.Changed("snmp-discover-retries"), &retries, nullInt).(*int),
@@ -57,12 +68,51 @@ func getBaseRequest(host string) request.BaseRequest {
 					HTTPSPorts:   utility.IfThenElse(deviceFlagSet.Changed("https-port"), viper.GetIntSlice("device.https-ports"), []int{}).([]int),
 					AuthUsername: utility.IfThenElse(deviceFlagSet.Changed("http-username"), &authUsername, nullString).(*string),
 					AuthPassword: utility.IfThenElse(deviceFlagSet.Changed("http-password"), &authPassword, nullString).(*string),
+					AuthToken:    utility.IfThenElse(deviceFlagSet.Changed("http-token"), &authToken, nullString).(*string),
+				},
+				SSH: &network.SSHConnectionData{
+					Ports:              utility.IfThenElse(deviceFlagSet.Changed("ssh-port"), viper.GetIntSlice("device.ssh-ports"), []int{}).([]int),
+					Username:           utility.IfThenElse(deviceFlagSet.Changed("ssh-username"), &sshUsername, nullString).(*string),
+					Password:           utility.IfThenElse(deviceFlagSet.Changed("ssh-password"), &sshPassword, nullString).(*string),
+					PrivateKey:         getSSHPrivateKey(),
+					HostKeyFingerprint: utility.IfThenElse(deviceFlagSet.Changed("ssh-host-key-fingerprint"), &sshHostKeyFingerprint, nullString).(*string),
 				},
 			},
 		},
 	}
 }
 
+func getComponentTimeoutWeights() map[string]float64 {
+	if !deviceFlagSet.Changed("component-timeout-weights") {
+		return nil
+	}
+	flagValue, err := deviceFlagSet.GetStringToString("component-timeout-weights")
+	if err != nil {
+		log.Fatal().Err(err).Msg("flag 'component-timeout-weights' is not a map")
+	}
+	weights := make(map[string]float64)
+	for comp, w := range flagValue {
+		weights[comp], err = strconv.ParseFloat(w, 64)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("component timeout weight '%s' of '%s' is not a number", w, comp)
+		}
+	}
+	return weights
+}
+
+func getSSHPrivateKey() *string {
+	if !deviceFlagSet.Changed("ssh-key-file") {
+		return nil
+	}
+	file := viper.GetString("device.ssh-key-file")
+	key, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("failed to read ssh key file '%s'", file)
+	}
+	keyString := string(key)
+	return &keyString
+}
+
 func handleError(ctx context.Context, err error, r request.Request) {
 	var v interface{}
 	res, err2 := r.HandlePreProcessError(err)
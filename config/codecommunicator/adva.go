@@ -28,6 +28,10 @@ func (c *advaCommunicator) GetInterfaces(ctx context.Context, filter ...grouppro
 		log.Ctx(ctx).Debug().Msg("filter matched on 'dwdm', skipping adva dwdm values")
 		return c.normalizeInterfaces(ctx, interfaces, filter)
 	}
+	if skipInterfaceEnrichment(ctx) {
+		log.Ctx(ctx).Debug().Msg("skipping adva dwdm values")
+		return c.normalizeInterfaces(ctx, interfaces, filter)
+	}
 	log.Ctx(ctx).Debug().Msg("reading adva dwdm values")
 
 	if err = c.getDWDMInterfaces(ctx, interfaces); err != nil {
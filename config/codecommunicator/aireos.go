@@ -0,0 +1,135 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"strings"
+)
+
+// oids of the bsnAPTable and bsnAPIfTable of the AIRESPACE-WIRELESS-MIB
+const (
+	bsnAPName            = "1.3.6.1.4.1.14179.2.2.1.1.3"
+	bsnAPOperationStatus = "1.3.6.1.4.1.14179.2.2.1.1.6"
+	bsnAPModel           = "1.3.6.1.4.1.14179.2.2.1.1.16"
+	bsnAPIfNoOfUsers     = "1.3.6.1.4.1.14179.2.2.2.1.15"
+)
+
+// bsnAPOperationStatuses maps the bsnAPOperationStatus to the access point status. Access points which are
+// downloading their image are not associated yet, but are not down either.
+var bsnAPOperationStatuses = map[string]device.AccessPointStatus{
+	"1": device.AccessPointStatusUp,          // associated
+	"2": device.AccessPointStatusDown,        // disassociating
+	"3": device.AccessPointStatusDownloading, // downloading
+}
+
+type aireosCommunicator struct {
+	iosCommunicator
+}
+
+// GetWLANComponentAccessPoints returns the access points of cisco wireless lan controllers. The access points are
+// indexed by their mac address, the client count of an access point is the sum of the clients of its radios.
+func (c *aireosCommunicator) GetWLANComponentAccessPoints(ctx context.Context) ([]device.AccessPoint, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	names, err := con.SNMP.SnmpClient.SNMPWalk(ctx, bsnAPName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read 'bsnAPName'")
+	}
+	if len(names) == 0 {
+		return nil, tholaerr.NewNotFoundError("no access points available")
+	}
+
+	var accessPoints []device.AccessPoint
+	indices := make(map[string]int)
+	for _, response := range names {
+		name, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'bsnAPName'")
+		}
+		index, err := response.GetOID().GetIndexAfterOID(bsnAPName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index of 'bsnAPName'")
+		}
+		n := name.String()
+		accessPoints = append(accessPoints, device.AccessPoint{Name: &n})
+		indices[index] = len(accessPoints) - 1
+	}
+
+	models, err := con.SNMP.SnmpClient.SNMPWalk(ctx, bsnAPModel)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'bsnAPModel'")
+	}
+	for _, response := range models {
+		i, ok := tableRowIndex(response, bsnAPModel, indices)
+		if !ok {
+			continue
+		}
+		model, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'bsnAPModel'")
+		}
+		m := model.String()
+		accessPoints[i].Model = &m
+	}
+
+	statuses, err := con.SNMP.SnmpClient.SNMPWalk(ctx, bsnAPOperationStatus)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'bsnAPOperationStatus'")
+	}
+	for _, response := range statuses {
+		i, ok := tableRowIndex(response, bsnAPOperationStatus, indices)
+		if !ok {
+			continue
+		}
+		value, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'bsnAPOperationStatus'")
+		}
+		status, ok := bsnAPOperationStatuses[value.String()]
+		if !ok {
+			status = device.AccessPointStatusUnknown
+		}
+		accessPoints[i].Status = &status
+	}
+
+	// the bsnAPIfTable is indexed by the mac address of the access point and the slot of the radio
+	users, err := con.SNMP.SnmpClient.SNMPWalk(ctx, bsnAPIfNoOfUsers)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'bsnApIfNoOfUsers'")
+	}
+	for _, response := range users {
+		index, err := response.GetOID().GetIndexAfterOID(bsnAPIfNoOfUsers)
+		if err != nil {
+			continue
+		}
+		lastDot := strings.LastIndex(index, ".")
+		if lastDot == -1 {
+			continue
+		}
+		i, ok := indices[index[:lastDot]]
+		if !ok {
+			continue
+		}
+		value, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'bsnApIfNoOfUsers'")
+		}
+		count, err := value.Int()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert 'bsnApIfNoOfUsers' to int")
+		}
+		if accessPoints[i].ClientCount == nil {
+			accessPoints[i].ClientCount = new(int)
+		}
+		*accessPoints[i].ClientCount += count
+	}
+
+	return accessPoints, nil
+}
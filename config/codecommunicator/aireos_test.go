@@ -0,0 +1,79 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAireosCommunicator_GetWLANComponentAccessPoints(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	ap1, ap2, ap3 := "0.12.41.1.2.3", "0.12.41.1.2.4", "0.12.41.1.2.5"
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(bsnAPName)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(bsnAPName+"."+ap1, gosnmp.OctetString, "AP-Floor1"),
+			network.NewSNMPResponse(bsnAPName+"."+ap2, gosnmp.OctetString, "AP-Floor2"),
+			network.NewSNMPResponse(bsnAPName+"."+ap3, gosnmp.OctetString, "AP-Floor3"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(bsnAPModel)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(bsnAPModel+"."+ap1, gosnmp.OctetString, "AIR-AP2802I-E-K9"),
+			network.NewSNMPResponse(bsnAPModel+"."+ap2, gosnmp.OctetString, "AIR-AP1852I-E-K9"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(bsnAPOperationStatus)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(bsnAPOperationStatus+"."+ap1, gosnmp.Integer, 1),
+			network.NewSNMPResponse(bsnAPOperationStatus+"."+ap2, gosnmp.Integer, 3),
+			network.NewSNMPResponse(bsnAPOperationStatus+"."+ap3, gosnmp.Integer, 2),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(bsnAPIfNoOfUsers)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(bsnAPIfNoOfUsers+"."+ap1+".0", gosnmp.Integer, 5),
+			network.NewSNMPResponse(bsnAPIfNoOfUsers+"."+ap1+".1", gosnmp.Integer, 12),
+			network.NewSNMPResponse(bsnAPIfNoOfUsers+"."+ap2+".0", gosnmp.Integer, 0),
+		}, nil)
+
+	sut := aireosCommunicator{iosCommunicator{codeCommunicator{}}}
+	res, err := sut.GetWLANComponentAccessPoints(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	name1, name2, name3 := "AP-Floor1", "AP-Floor2", "AP-Floor3"
+	model1, model2 := "AIR-AP2802I-E-K9", "AIR-AP1852I-E-K9"
+	up, downloading, down := device.AccessPointStatusUp, device.AccessPointStatusDownloading, device.AccessPointStatusDown
+	clients1, clients2 := 17, 0
+	assert.Equal(t, []device.AccessPoint{
+		{Name: &name1, Model: &model1, Status: &up, ClientCount: &clients1},
+		{Name: &name2, Model: &model2, Status: &downloading, ClientCount: &clients2},
+		{Name: &name3, Status: &down},
+	}, res)
+}
+
+func TestAireosCommunicator_GetWLANComponentAccessPoints_noAccessPoints(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(bsnAPName)).
+		Return([]network.SNMPResponse{}, nil)
+
+	sut := aireosCommunicator{iosCommunicator{codeCommunicator{}}}
+	_, err := sut.GetWLANComponentAccessPoints(ctx)
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
@@ -34,6 +34,10 @@ func (c *aviatCommunicator) GetInterfaces(ctx context.Context, filter ...grouppr
 		log.Ctx(ctx).Debug().Msg("filter matched on 'radio', skipping aviat radio values")
 		return filterInterfaces(ctx, interfaces, filter)
 	}
+	if skipInterfaceEnrichment(ctx) {
+		log.Ctx(ctx).Debug().Msg("skipping aviat radio values")
+		return filterInterfaces(ctx, interfaces, filter)
+	}
 	log.Ctx(ctx).Debug().Msg("reading aviat radio values")
 
 	return c.getRadioInterface(ctx, interfaces, filter)
@@ -298,7 +302,7 @@ func (c *aviatCommunicator) getRadioInterface(ctx context.Context, interfaces []
 	}
 
 	for i, interf := range interfaces {
-		if interf.IfType != nil && *interf.IfType == "radioMAC" {
+		if interf.IfTypeName != nil && *interf.IfTypeName == "radioMAC" {
 			interfaces[i].MaxSpeedIn = &maxCapacity
 			interfaces[i].MaxSpeedOut = &maxCapacity
 			interfaces[i].Radio = &device.RadioInterface{
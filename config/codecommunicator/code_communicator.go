@@ -6,6 +6,7 @@ import (
 	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 )
@@ -38,6 +39,8 @@ func GetCodeCommunicator(deviceClass communicator.Communicator, parentNetworkDev
 		return &ironwareCommunicator{base}, nil
 	case "ios":
 		return &iosCommunicator{base}, nil
+	case "ios/aireos":
+		return &aireosCommunicator{iosCommunicator{base}}, nil
 	case "ekinops":
 		return &ekinopsCommunicator{base}, nil
 	case "adva_fsp3kr7":
@@ -54,6 +57,8 @@ func GetCodeCommunicator(deviceClass communicator.Communicator, parentNetworkDev
 		return &fortigateCommunicator{base}, nil
 	case "linux":
 		return &linuxCommunicator{base}, nil
+	case "linux_ssh":
+		return &linuxSSHCommunicator{base}, nil
 	case "vmware-esxi":
 		return &vmwareESXiCommunicator{base}, nil
 	case "aruba":
@@ -82,6 +87,30 @@ func (c *codeCommunicator) GetOSVersion(_ context.Context) (string, error) {
 	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
+func (c *codeCommunicator) GetFirmwareVersion(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetBootloaderVersion(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetSysName(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetSysContact(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetSysLocation(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetSysDescr(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
 func (c *codeCommunicator) GetInterfaces(_ context.Context, _ ...groupproperty.Filter) ([]device.Interface, error) {
 	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
@@ -94,6 +123,10 @@ func (c *codeCommunicator) GetCPUComponentCPULoad(_ context.Context) ([]device.C
 	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
+func (c *codeCommunicator) GetCPUComponentDetailed(_ context.Context) ([]device.CPUCore, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
 func (c *codeCommunicator) GetMemoryComponentMemoryUsage(_ context.Context) ([]device.MemoryPool, error) {
 	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
@@ -106,6 +139,30 @@ func (c *codeCommunicator) GetServerComponentUsers(_ context.Context) (int, erro
 	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
+func (c *codeCommunicator) GetServerComponentUptime(_ context.Context) (uint32, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetServerComponentLoadAverage1(_ context.Context) (float64, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetServerComponentLoadAverage5(_ context.Context) (float64, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetServerComponentLoadAverage15(_ context.Context) (float64, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetServerComponentSwapTotal(_ context.Context) (uint64, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetServerComponentSwapUsed(_ context.Context) (uint64, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
 func (c *codeCommunicator) GetDiskComponentStorages(_ context.Context) ([]device.DiskComponentStorage, error) {
 	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
@@ -162,6 +219,18 @@ func (c *codeCommunicator) GetUPSComponentSystemVoltage(_ context.Context) (floa
 	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
+func (c *codeCommunicator) GetUPSComponentSelfTestResult(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetUPSComponentLastTestDate(_ context.Context) (string, error) {
+	return "", tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetUPSComponentBatteryStrings(_ context.Context) ([]device.UPSBatteryString, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
 func (c *codeCommunicator) GetSBCComponentGlobalCallPerSecond(_ context.Context) (int, error) {
 	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
@@ -202,6 +271,10 @@ func (c *codeCommunicator) GetHardwareHealthComponentVoltage(_ context.Context)
 	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
+func (c *codeCommunicator) GetHardwareHealthComponentHumidity(_ context.Context) ([]device.HardwareHealthComponentHumidity, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
 func (c *codeCommunicator) GetHardwareHealthComponentPowerSupply(_ context.Context) ([]device.HardwareHealthComponentPowerSupply, error) {
 	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
@@ -222,29 +295,94 @@ func (c *codeCommunicator) GetHighAvailabilityComponentNodes(_ context.Context)
 	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
-func filterInterfaces(ctx context.Context, interfaces []device.Interface, filter []groupproperty.Filter) ([]device.Interface, error) {
-	if len(filter) == 0 {
-		return interfaces, nil
-	}
+func (c *codeCommunicator) GetVLANComponentVLANs(_ context.Context) ([]device.VLAN, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
 
-	var propertyGroups groupproperty.PropertyGroups
-	err := propertyGroups.Encode(interfaces)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to encode interfaces to property groups")
-	}
+func (c *codeCommunicator) GetVLANComponentPortMembership(_ context.Context) (map[int][]int, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
 
-	for _, fil := range filter {
-		propertyGroups, err = fil.ApplyPropertyGroups(ctx, propertyGroups)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to apply filter on property groups")
-		}
-	}
+func (c *codeCommunicator) GetPOEComponentPSEs(_ context.Context) ([]device.POEComponentPSE, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetPOEComponentPorts(_ context.Context) ([]device.POEComponentPort, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetEnvironmentComponentTemperature(_ context.Context) ([]device.EnvironmentComponentTemperature, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetEnvironmentComponentHumidity(_ context.Context) ([]device.EnvironmentComponentHumidity, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetEnvironmentComponentDryContacts(_ context.Context) ([]device.EnvironmentComponentDryContact, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetNeighbors(_ context.Context) ([]device.Neighbor, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetRoutingTable(_ context.Context) ([]device.Route, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetCountRoutes(_ context.Context) (int, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
 
-	var res []device.Interface
-	err = propertyGroups.Decode(&res)
+func (c *codeCommunicator) GetEntityComponent(_ context.Context) (device.EntityComponent, error) {
+	return device.EntityComponent{}, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetWLANComponentAccessPoints(_ context.Context) ([]device.AccessPoint, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetWLANComponentClientCount(_ context.Context) (int, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetFirewallComponentActiveSessions(_ context.Context) (int, error) {
+	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetFirewallComponentVPNTunnels(_ context.Context) ([]device.FirewallVPNTunnel, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetFirewallComponentLicenses(_ context.Context) ([]device.FirewallLicense, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetConfigBackup(_ context.Context, _ device.ConfigBackupDestination) (device.ConfigBackup, error) {
+	return device.ConfigBackup{}, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) SetInterfaceAdminStatus(_ context.Context, _ uint64, _ bool) error {
+	return tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func filterInterfaces(ctx context.Context, interfaces []device.Interface, filter []groupproperty.Filter) ([]device.Interface, error) {
+	return communicator.FilterInterfaces(ctx, interfaces, filter...)
+}
+
+// skipInterfaceEnrichment returns whether the vendor-specific values of the interfaces, which need extra requests,
+// shouldn't be read.
+func skipInterfaceEnrichment(ctx context.Context) bool {
+	return communicator.SkipInterfaceEnrichmentFromContext(ctx)
+}
+
+// tableRowIndex returns the position of the row of a table response, the positions are mapped by the row index.
+func tableRowIndex(response network.SNMPResponse, column network.OID, indices map[string]int) (int, bool) {
+	index, err := response.GetOID().GetIndexAfterOID(column)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode property groups to interfaces")
+		return 0, false
 	}
-
-	return res, nil
+	i, ok := indices[index]
+	return i, ok
 }
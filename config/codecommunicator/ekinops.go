@@ -23,6 +23,7 @@ func (c *ekinopsCommunicator) GetInterfaces(ctx context.Context, filter ...group
 	}
 
 	con.SNMP.SnmpClient.UseCache(false)
+	ctx = network.NewContextWithSNMPWalkCacheBypass(ctx, true)
 
 	interfaces, err := c.deviceClass.GetInterfaces(ctx)
 	if err != nil {
@@ -106,9 +107,10 @@ func (c *ekinopsCommunicator) normalizeInterfaces(interfaces []device.Interface)
 		moduleName := strings.Split(*interf.IfDescr, "/")[3]
 
 		// change ifType of ports of slots > 0 to "opticalChannel" if ifType equals "other", but not OPM8 interfaces
-		if slotNumber != "0" && interf.IfType != nil && *interf.IfType == "other" && moduleName != "PM_OPM8" {
-			opticalChannel := "opticalChannel"
-			interf.IfType = &opticalChannel
+		if slotNumber != "0" && interf.IfTypeName != nil && *interf.IfTypeName == "other" && moduleName != "PM_OPM8" {
+			ifType, opticalChannel := "195", "opticalChannel"
+			interf.IfType = &ifType
+			interf.IfTypeName = &opticalChannel
 		}
 
 		// change subType of OPM8 ports
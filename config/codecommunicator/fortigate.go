@@ -5,11 +5,39 @@ import (
 	"fmt"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/value"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 	"regexp"
 )
 
+// oids of the FORTINET-FORTIGATE-MIB
+const (
+	fgSysCpuUsage = "1.3.6.1.4.1.12356.101.4.1.3.0"
+	fgSysMemUsage = "1.3.6.1.4.1.12356.101.4.1.4.0"
+	fgSysSesCount = "1.3.6.1.4.1.12356.101.4.1.8.0"
+
+	fgVdEntName     = "1.3.6.1.4.1.12356.101.3.2.1.1.2"
+	fgVdEntCpuUsage = "1.3.6.1.4.1.12356.101.3.2.1.1.5"
+	fgVdEntMemUsage = "1.3.6.1.4.1.12356.101.3.2.1.1.6"
+	fgVdEntSesCount = "1.3.6.1.4.1.12356.101.3.2.1.1.7"
+
+	fgVpnTunEntPhase1Name = "1.3.6.1.4.1.12356.101.12.2.2.1.2"
+	fgVpnTunEntPhase2Name = "1.3.6.1.4.1.12356.101.12.2.2.1.3"
+	fgVpnTunEntRemGwyIp   = "1.3.6.1.4.1.12356.101.12.2.2.1.4"
+	fgVpnTunEntStatus     = "1.3.6.1.4.1.12356.101.12.2.2.1.20"
+
+	fgLicContractDesc   = "1.3.6.1.4.1.12356.101.4.6.3.1.2.1.1"
+	fgLicContractExpiry = "1.3.6.1.4.1.12356.101.4.6.3.1.2.1.2"
+)
+
+// fgVpnTunEntStatuses maps the fgVpnTunEntStatus to the vpn tunnel status.
+var fgVpnTunEntStatuses = map[string]device.FirewallVPNTunnelStatus{
+	"1": device.FirewallVPNTunnelStatusDown,
+	"2": device.FirewallVPNTunnelStatusUp,
+}
+
 type fortigateCommunicator struct {
 	codeCommunicator
 }
@@ -380,3 +408,318 @@ func (c *fortigateCommunicator) getHighAvailabilityIndex(ctx context.Context) (s
 
 	return "", errors.New("failed to get ha index")
 }
+
+// fortigateVDOMValue is the value of a column of the fgVdTable for a single vdom.
+type fortigateVDOMValue struct {
+	Name  string
+	Value value.Value
+}
+
+// GetCPUComponentCPULoad returns the cpu load of fortigate devices. The system wide cpu usage is not available for
+// communities which are restricted to single vdoms, in this case the cpu usage of every vdom is returned with the
+// name of the vdom as label.
+func (c *fortigateCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
+	usage, err := c.getSystemValue(ctx, fgSysCpuUsage)
+	if err == nil {
+		load, err := usage.Float64()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert 'fgSysCpuUsage' to float64")
+		}
+		return []device.CPU{{Load: &load}}, nil
+	}
+	if !tholaerr.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	vdoms, err := c.getVDOMValues(ctx, fgVdEntCpuUsage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read cpu usage of vdoms")
+	}
+
+	var cpus []device.CPU
+	for _, vdom := range vdoms {
+		load, err := vdom.Value.Float64()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert 'fgVdEntCpuUsage' to float64")
+		}
+		name := vdom.Name
+		cpus = append(cpus, device.CPU{Label: &name, Load: &load})
+	}
+	return cpus, nil
+}
+
+// GetMemoryComponentMemoryUsage returns the memory usage of fortigate devices. Like the cpu load, the memory usage of
+// every vdom is returned if the system wide memory usage is not available.
+func (c *fortigateCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) ([]device.MemoryPool, error) {
+	usage, err := c.getSystemValue(ctx, fgSysMemUsage)
+	if err == nil {
+		u, err := usage.Float64()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert 'fgSysMemUsage' to float64")
+		}
+		return []device.MemoryPool{{Usage: &u}}, nil
+	}
+	if !tholaerr.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	vdoms, err := c.getVDOMValues(ctx, fgVdEntMemUsage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read memory usage of vdoms")
+	}
+
+	var pools []device.MemoryPool
+	for _, vdom := range vdoms {
+		u, err := vdom.Value.Float64()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert 'fgVdEntMemUsage' to float64")
+		}
+		name := vdom.Name
+		pools = append(pools, device.MemoryPool{Label: &name, Usage: &u})
+	}
+	return pools, nil
+}
+
+// GetFirewallComponentActiveSessions returns the count of active sessions of fortigate devices. If the system wide
+// count is not available, the sessions of all vdoms are summed up.
+func (c *fortigateCommunicator) GetFirewallComponentActiveSessions(ctx context.Context) (int, error) {
+	count, err := c.getSystemValue(ctx, fgSysSesCount)
+	if err == nil {
+		sessions, err := count.Int()
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to convert 'fgSysSesCount' to int")
+		}
+		return sessions, nil
+	}
+	if !tholaerr.IsNotFoundError(err) {
+		return 0, err
+	}
+
+	vdoms, err := c.getVDOMValues(ctx, fgVdEntSesCount)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read sessions of vdoms")
+	}
+
+	var sessions int
+	for _, vdom := range vdoms {
+		s, err := vdom.Value.Int()
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to convert 'fgVdEntSesCount' to int")
+		}
+		sessions += s
+	}
+	return sessions, nil
+}
+
+// GetFirewallComponentVPNTunnels returns the ipsec tunnels of fortigate devices. A tunnel is named after its phase 1
+// and phase 2, as a phase 1 can have multiple phase 2 selectors.
+func (c *fortigateCommunicator) GetFirewallComponentVPNTunnels(ctx context.Context) ([]device.FirewallVPNTunnel, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	phase1Names, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgVpnTunEntPhase1Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read 'fgVpnTunEntPhase1Name'")
+	}
+	if len(phase1Names) == 0 {
+		return nil, tholaerr.NewNotFoundError("no vpn tunnels available")
+	}
+
+	var tunnels []device.FirewallVPNTunnel
+	indices := make(map[string]int)
+	for _, response := range phase1Names {
+		name, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgVpnTunEntPhase1Name'")
+		}
+		index, err := response.GetOID().GetIndexAfterOID(fgVpnTunEntPhase1Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index of 'fgVpnTunEntPhase1Name'")
+		}
+		n := name.String()
+		tunnels = append(tunnels, device.FirewallVPNTunnel{Name: &n})
+		indices[index] = len(tunnels) - 1
+	}
+
+	phase2Names, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgVpnTunEntPhase2Name)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'fgVpnTunEntPhase2Name'")
+	}
+	for _, response := range phase2Names {
+		i, ok := tableRowIndex(response, fgVpnTunEntPhase2Name, indices)
+		if !ok {
+			continue
+		}
+		name, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgVpnTunEntPhase2Name'")
+		}
+		if name.IsEmpty() {
+			continue
+		}
+		n := *tunnels[i].Name + "/" + name.String()
+		tunnels[i].Name = &n
+	}
+
+	gateways, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgVpnTunEntRemGwyIp)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'fgVpnTunEntRemGwyIp'")
+	}
+	for _, response := range gateways {
+		i, ok := tableRowIndex(response, fgVpnTunEntRemGwyIp, indices)
+		if !ok {
+			continue
+		}
+		gateway, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgVpnTunEntRemGwyIp'")
+		}
+		g := gateway.String()
+		tunnels[i].RemoteGateway = &g
+	}
+
+	statuses, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgVpnTunEntStatus)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'fgVpnTunEntStatus'")
+	}
+	for _, response := range statuses {
+		i, ok := tableRowIndex(response, fgVpnTunEntStatus, indices)
+		if !ok {
+			continue
+		}
+		v, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgVpnTunEntStatus'")
+		}
+		status, ok := fgVpnTunEntStatuses[v.String()]
+		if !ok {
+			log.Ctx(ctx).Debug().Str("status", v.String()).Msg("unknown vpn tunnel status")
+			continue
+		}
+		tunnels[i].Status = &status
+	}
+
+	return tunnels, nil
+}
+
+// GetFirewallComponentLicenses returns the support contracts of fortigate devices with their expiry.
+func (c *fortigateCommunicator) GetFirewallComponentLicenses(ctx context.Context) ([]device.FirewallLicense, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	descriptions, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgLicContractDesc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read 'fgLicContractDesc'")
+	}
+	if len(descriptions) == 0 {
+		return nil, tholaerr.NewNotFoundError("no licenses available")
+	}
+
+	var licenses []device.FirewallLicense
+	indices := make(map[string]int)
+	for _, response := range descriptions {
+		description, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgLicContractDesc'")
+		}
+		index, err := response.GetOID().GetIndexAfterOID(fgLicContractDesc)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index of 'fgLicContractDesc'")
+		}
+		d := description.String()
+		licenses = append(licenses, device.FirewallLicense{Description: &d})
+		indices[index] = len(licenses) - 1
+	}
+
+	expiries, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgLicContractExpiry)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read 'fgLicContractExpiry'")
+	}
+	for _, response := range expiries {
+		i, ok := tableRowIndex(response, fgLicContractExpiry, indices)
+		if !ok {
+			continue
+		}
+		expiry, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgLicContractExpiry'")
+		}
+		e := expiry.String()
+		licenses[i].Expiry = &e
+	}
+
+	return licenses, nil
+}
+
+// getSystemValue reads a scalar of the system information of the device. It returns a not found error if the scalar
+// is not available, e.g. because the community is restricted to a vdom.
+func (c *fortigateCommunicator) getSystemValue(ctx context.Context, oid network.OID) (value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	res, err := con.SNMP.SnmpClient.SNMPGet(ctx, oid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read '%s'", oid)
+	}
+	if len(res) == 0 {
+		return nil, tholaerr.NewNotFoundError(fmt.Sprintf("no value available for '%s'", oid))
+	}
+	return res[0].GetValue()
+}
+
+// getVDOMValues returns the values of the given column of the fgVdTable together with the names of the vdoms.
+func (c *fortigateCommunicator) getVDOMValues(ctx context.Context, column network.OID) ([]fortigateVDOMValue, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	names, err := con.SNMP.SnmpClient.SNMPWalk(ctx, fgVdEntName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read 'fgVdEntName'")
+	}
+	vdomNames := make(map[string]string)
+	for _, response := range names {
+		name, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value of 'fgVdEntName'")
+		}
+		index, err := response.GetOID().GetIndexAfterOID(fgVdEntName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index of 'fgVdEntName'")
+		}
+		vdomNames[index] = name.String()
+	}
+
+	values, err := con.SNMP.SnmpClient.SNMPWalk(ctx, column)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read '%s'", column)
+	}
+	if len(values) == 0 {
+		return nil, tholaerr.NewNotFoundError("no vdoms available")
+	}
+
+	var res []fortigateVDOMValue
+	for _, response := range values {
+		v, err := response.GetValue()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get value of '%s'", column)
+		}
+		index, err := response.GetOID().GetIndexAfterOID(column)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get index of '%s'", column)
+		}
+		name, ok := vdomNames[index]
+		if !ok {
+			name = index
+		}
+		res = append(res, fortigateVDOMValue{Name: name, Value: v})
+	}
+	return res, nil
+}
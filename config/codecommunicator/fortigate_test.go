@@ -0,0 +1,176 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFortigateCommunicator_GetCPUComponentCPULoad(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", ctx, network.OID(fgSysCpuUsage)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgSysCpuUsage, gosnmp.Gauge32, uint(12)),
+		}, nil)
+
+	sut := fortigateCommunicator{codeCommunicator{}}
+	res, err := sut.GetCPUComponentCPULoad(ctx)
+	if assert.NoError(t, err) {
+		load := 12.0
+		assert.Equal(t, []device.CPU{{Load: &load}}, res)
+	}
+}
+
+// TestFortigateCommunicator_GetCPUComponentCPULoad_vdoms tests that the cpu usage of the vdoms is returned if the
+// system wide cpu usage is not available, e.g. for a community which is restricted to vdoms.
+func TestFortigateCommunicator_GetCPUComponentCPULoad_vdoms(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", ctx, network.OID(fgSysCpuUsage)).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")).
+		On("SNMPWalk", ctx, network.OID(fgVdEntName)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVdEntName+".1", gosnmp.OctetString, "root"),
+			network.NewSNMPResponse(fgVdEntName+".2", gosnmp.OctetString, "customer-a"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(fgVdEntCpuUsage)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVdEntCpuUsage+".1", gosnmp.Gauge32, uint(3)),
+			network.NewSNMPResponse(fgVdEntCpuUsage+".2", gosnmp.Gauge32, uint(7)),
+		}, nil)
+
+	sut := fortigateCommunicator{codeCommunicator{}}
+	res, err := sut.GetCPUComponentCPULoad(ctx)
+	if assert.NoError(t, err) {
+		root, customer := "root", "customer-a"
+		rootLoad, customerLoad := 3.0, 7.0
+		assert.Equal(t, []device.CPU{
+			{Label: &root, Load: &rootLoad},
+			{Label: &customer, Load: &customerLoad},
+		}, res)
+	}
+}
+
+func TestFortigateCommunicator_GetFirewallComponentActiveSessions_vdoms(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", ctx, network.OID(fgSysSesCount)).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")).
+		On("SNMPWalk", ctx, network.OID(fgVdEntName)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVdEntName+".1", gosnmp.OctetString, "root"),
+			network.NewSNMPResponse(fgVdEntName+".2", gosnmp.OctetString, "customer-a"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(fgVdEntSesCount)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVdEntSesCount+".1", gosnmp.Gauge32, uint(1200)),
+			network.NewSNMPResponse(fgVdEntSesCount+".2", gosnmp.Gauge32, uint(345)),
+		}, nil)
+
+	sut := fortigateCommunicator{codeCommunicator{}}
+	res, err := sut.GetFirewallComponentActiveSessions(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1545, res)
+	}
+}
+
+func TestFortigateCommunicator_GetFirewallComponentVPNTunnels(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(fgVpnTunEntPhase1Name)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVpnTunEntPhase1Name+".1", gosnmp.OctetString, "branch-office"),
+			network.NewSNMPResponse(fgVpnTunEntPhase1Name+".2", gosnmp.OctetString, "datacenter"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(fgVpnTunEntPhase2Name)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVpnTunEntPhase2Name+".1", gosnmp.OctetString, "lan"),
+			network.NewSNMPResponse(fgVpnTunEntPhase2Name+".2", gosnmp.OctetString, ""),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(fgVpnTunEntRemGwyIp)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVpnTunEntRemGwyIp+".1", gosnmp.IPAddress, "198.51.100.1"),
+			network.NewSNMPResponse(fgVpnTunEntRemGwyIp+".2", gosnmp.IPAddress, "203.0.113.7"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(fgVpnTunEntStatus)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgVpnTunEntStatus+".1", gosnmp.Integer, 2),
+			network.NewSNMPResponse(fgVpnTunEntStatus+".2", gosnmp.Integer, 1),
+		}, nil)
+
+	sut := fortigateCommunicator{codeCommunicator{}}
+	res, err := sut.GetFirewallComponentVPNTunnels(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	name1, name2 := "branch-office/lan", "datacenter"
+	gateway1, gateway2 := "198.51.100.1", "203.0.113.7"
+	up, down := device.FirewallVPNTunnelStatusUp, device.FirewallVPNTunnelStatusDown
+	assert.Equal(t, []device.FirewallVPNTunnel{
+		{Name: &name1, RemoteGateway: &gateway1, Status: &up},
+		{Name: &name2, RemoteGateway: &gateway2, Status: &down},
+	}, res)
+}
+
+func TestFortigateCommunicator_GetFirewallComponentLicenses(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(fgLicContractDesc)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgLicContractDesc+".1", gosnmp.OctetString, "FortiCare Support"),
+			network.NewSNMPResponse(fgLicContractDesc+".2", gosnmp.OctetString, "FortiGuard IPS"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(fgLicContractExpiry)).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(fgLicContractExpiry+".1", gosnmp.OctetString, "Wed Jan 14 00:00:00 2027"),
+		}, nil)
+
+	sut := fortigateCommunicator{codeCommunicator{}}
+	res, err := sut.GetFirewallComponentLicenses(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	description1, description2 := "FortiCare Support", "FortiGuard IPS"
+	expiry1 := "Wed Jan 14 00:00:00 2027"
+	assert.Equal(t, []device.FirewallLicense{
+		{Description: &description1, Expiry: &expiry1},
+		{Description: &description2},
+	}, res)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"strconv"
@@ -301,3 +302,127 @@ func (c *iosCommunicator) getChassisPowerSupply(ctx context.Context, id int) (de
 	}
 	return device.HardwareHealthComponentPowerSupply{}, errors.New("power supply not found")
 }
+
+// GetNeighbors returns the neighbors of ios devices. If no LLDP neighbors are available, CDP is used.
+func (c *iosCommunicator) GetNeighbors(ctx context.Context) ([]device.Neighbor, error) {
+	lldpNeighbors, lldpErr := c.deviceClass.GetNeighbors(ctx)
+	if lldpErr != nil && !tholaerr.IsComponentNotFoundError(lldpErr) {
+		return nil, lldpErr
+	}
+	if len(lldpNeighbors) > 0 {
+		return lldpNeighbors, nil
+	}
+
+	cdpNeighbors, err := c.getCDPNeighbors(ctx)
+	if err != nil {
+		if tholaerr.IsComponentNotFoundError(err) && lldpErr == nil {
+			return lldpNeighbors, nil
+		}
+		return nil, err
+	}
+
+	return cdpNeighbors, nil
+}
+
+func (c *iosCommunicator) getCDPNeighbors(ctx context.Context) ([]device.Neighbor, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	// cdpGlobalRun
+	_, err := con.SNMP.SnmpClient.SNMPGet(ctx, "1.3.6.1.4.1.9.9.23.1.3.1.0")
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			return nil, tholaerr.NewComponentNotFoundError("device supports neither LLDP-MIB nor CISCO-CDP-MIB")
+		}
+		return nil, errors.Wrap(err, "failed to get 'cdpGlobalRun'")
+	}
+
+	// index of the cdpCacheTable is cdpCacheIfIndex.cdpCacheDeviceIndex
+	deviceIDs, err := c.walkCDPCacheColumn(ctx, "1.3.6.1.4.1.9.9.23.1.2.1.1.6")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get 'cdpCacheDeviceId'")
+	}
+	devicePorts, err := c.walkCDPCacheColumn(ctx, "1.3.6.1.4.1.9.9.23.1.2.1.1.7")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get 'cdpCacheDevicePort'")
+	}
+
+	ifNames := make(map[string]string)
+	for _, oid := range []network.OID{"1.3.6.1.2.1.2.2.1.2", "1.3.6.1.2.1.31.1.1.1.1"} {
+		res, err := con.SNMP.SnmpClient.SNMPWalk(ctx, oid)
+		if err != nil {
+			continue
+		}
+		for _, r := range res {
+			val, err := r.GetValue()
+			if err != nil || val.IsEmpty() {
+				continue
+			}
+			// ifName is preferred over ifDescr if available
+			ifNames[r.GetOID().GetIndex()] = val.String()
+		}
+	}
+
+	var neighbors []device.Neighbor
+	for _, idx := range deviceIDs.indices {
+		protocol := "cdp"
+		hostname := deviceIDs.values[idx]
+		neighbor := device.Neighbor{
+			RemoteHostname: &hostname,
+			Protocol:       &protocol,
+		}
+
+		if port, ok := devicePorts.values[idx]; ok {
+			neighbor.RemotePort = &port
+		}
+
+		localInterface := strings.Split(idx, ".")[0]
+		if name, ok := ifNames[localInterface]; ok {
+			localInterface = name
+		}
+		neighbor.LocalInterface = &localInterface
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors, nil
+}
+
+type cdpCacheColumn struct {
+	indices []string
+	values  map[string]string
+}
+
+func (c *iosCommunicator) walkCDPCacheColumn(ctx context.Context, oid network.OID) (cdpCacheColumn, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return cdpCacheColumn{}, errors.New("no device connection available")
+	}
+
+	column := cdpCacheColumn{values: make(map[string]string)}
+
+	res, err := con.SNMP.SnmpClient.SNMPWalk(ctx, oid)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			return column, nil
+		}
+		return cdpCacheColumn{}, err
+	}
+
+	for _, r := range res {
+		val, err := r.GetValue()
+		if err != nil {
+			continue
+		}
+		idx, err := r.GetOID().GetIndexAfterOID(oid)
+		if err != nil {
+			return cdpCacheColumn{}, errors.Wrap(err, "failed to get index after oid")
+		}
+		column.indices = append(column.indices, idx)
+		column.values[idx] = val.String()
+	}
+
+	return column, nil
+}
@@ -0,0 +1,189 @@
+package codecommunicator
+
+import (
+	"context"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"math"
+	"math/rand"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ccCopyEntryOID is the ccCopyEntry of the CISCO-CONFIG-COPY-MIB.
+const ccCopyEntryOID = "1.3.6.1.4.1.9.9.96.1.1.1.1"
+
+// columns of the ccCopyEntry
+const (
+	ccCopyProtocol          = 2
+	ccCopySourceFileType    = 3
+	ccCopyDestFileType      = 4
+	ccCopyFileName          = 6
+	ccCopyUserName          = 7
+	ccCopyUserPassword      = 8
+	ccCopyState             = 10
+	ccCopyFailCause         = 13
+	ccCopyEntryRowStatus    = 14
+	ccCopyServerAddressType = 15
+	ccCopyServerAddressRev1 = 16
+)
+
+// values of the ccCopyEntry
+const (
+	ccCopyFileTypeNetwork      = 1
+	ccCopyFileTypeRunning      = 4
+	ccCopyRowStatusCreateAndGo = 4
+	ccCopyRowStatusDestroy     = 6
+)
+
+var ccCopyProtocols = map[string]int{
+	"tftp": 1,
+	"ftp":  2,
+	"rcp":  3,
+	"scp":  4,
+	"sftp": 5,
+}
+
+var ccCopyFailCauses = map[string]string{
+	"1": "unknown",
+	"2": "bad file name",
+	"3": "timeout",
+	"4": "no memory",
+	"5": "no config",
+	"6": "unsupported protocol",
+	"7": "some config apply failed",
+	"8": "system not ready",
+	"9": "request aborted",
+}
+
+// iosConfigCopyPollInterval is the interval in which the state of a config copy is polled.
+var iosConfigCopyPollInterval = time.Second
+
+// newCCCopyIndex returns a random index for a new ccCopyEntry, so that concurrent copies don't use the same entry.
+var newCCCopyIndex = func() int {
+	return int(rand.New(rand.NewSource(time.Now().UnixNano())).Int31n(math.MaxInt32)) + 1
+}
+
+// GetConfigBackup copies the running config of ios devices to the destination with the CISCO-CONFIG-COPY-MIB. The copy is
+// started with snmp set requests, so writes need to be enabled for the connection.
+func (c *iosCommunicator) GetConfigBackup(ctx context.Context, destination device.ConfigBackupDestination) (device.ConfigBackup, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return device.ConfigBackup{}, errors.New("no device connection available")
+	}
+	if !con.SNMPWritesEnabled() {
+		return device.ConfigBackup{}, tholaerr.NewPreConditionError("snmp writes are not enabled for this connection")
+	}
+
+	protocol, ok := ccCopyProtocols[destination.Protocol]
+	if !ok {
+		return device.ConfigBackup{}, fmt.Errorf("unsupported protocol '%s'", destination.Protocol)
+	}
+	ip := net.ParseIP(destination.Server)
+	if ip == nil {
+		return device.ConfigBackup{}, fmt.Errorf("server '%s' is not an ip address", destination.Server)
+	}
+	// ipv4(1), ipv6(2)
+	addressType, address := 1, []byte(ip.To4())
+	if address == nil {
+		addressType, address = 2, []byte(ip.To16())
+	}
+
+	index := strconv.Itoa(newCCCopyIndex())
+	column := func(column int) network.OID {
+		return network.OID(ccCopyEntryOID + "." + strconv.Itoa(column) + "." + index)
+	}
+
+	variables := []network.SNMPVariable{
+		{OID: column(ccCopyProtocol), Type: gosnmp.Integer, Value: protocol},
+		{OID: column(ccCopySourceFileType), Type: gosnmp.Integer, Value: ccCopyFileTypeRunning},
+		{OID: column(ccCopyDestFileType), Type: gosnmp.Integer, Value: ccCopyFileTypeNetwork},
+		{OID: column(ccCopyServerAddressType), Type: gosnmp.Integer, Value: addressType},
+		{OID: column(ccCopyServerAddressRev1), Type: gosnmp.OctetString, Value: address},
+		{OID: column(ccCopyFileName), Type: gosnmp.OctetString, Value: destination.Path},
+	}
+	if destination.Username != "" {
+		variables = append(variables, network.SNMPVariable{OID: column(ccCopyUserName), Type: gosnmp.OctetString, Value: destination.Username})
+	}
+	if destination.Password != "" {
+		variables = append(variables, network.SNMPVariable{OID: column(ccCopyUserPassword), Type: gosnmp.OctetString, Value: destination.Password})
+	}
+	variables = append(variables, network.SNMPVariable{OID: column(ccCopyEntryRowStatus), Type: gosnmp.Integer, Value: ccCopyRowStatusCreateAndGo})
+
+	if err := con.SNMP.SnmpClient.SNMPSet(ctx, variables...); err != nil {
+		return device.ConfigBackup{}, errors.Wrap(err, "failed to create 'ccCopyEntry'")
+	}
+	defer func() {
+		// the entry is also destroyed if the request was canceled, otherwise it stays on the device
+		cleanupCTX := log.Ctx(ctx).WithContext(context.Background())
+		err := con.SNMP.SnmpClient.SNMPSet(cleanupCTX, network.SNMPVariable{OID: column(ccCopyEntryRowStatus), Type: gosnmp.Integer, Value: ccCopyRowStatusDestroy})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("index", index).Msg("failed to destroy 'ccCopyEntry'")
+		}
+	}()
+
+	// the state changes while polling, so cached results must not be used
+	con.SNMP.SnmpClient.UseCache(false)
+
+	result := device.ConfigBackup{
+		Destination: destination.String(),
+	}
+	ticker := time.NewTicker(iosConfigCopyPollInterval)
+	defer ticker.Stop()
+	for {
+		state, err := c.getCCCopyColumn(ctx, column(ccCopyState))
+		if err != nil {
+			return device.ConfigBackup{}, errors.Wrap(err, "failed to get 'ccCopyState'")
+		}
+
+		switch state {
+		// successful
+		case "3":
+			result.Success = true
+			return result, nil
+		// failed
+		case "4":
+			failCause := "unknown"
+			cause, err := c.getCCCopyColumn(ctx, column(ccCopyFailCause))
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Msg("failed to get 'ccCopyFailCause'")
+			} else if description, ok := ccCopyFailCauses[cause]; ok {
+				failCause = description
+			}
+			result.FailCause = &failCause
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return device.ConfigBackup{}, errors.Wrap(ctx.Err(), "config copy did not finish")
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *iosCommunicator) getCCCopyColumn(ctx context.Context, oid network.OID) (string, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return "", errors.New("no device connection available")
+	}
+
+	res, err := con.SNMP.SnmpClient.SNMPGet(ctx, oid)
+	if err != nil {
+		return "", err
+	}
+	if len(res) != 1 {
+		return "", errors.New("unexpected amount of values")
+	}
+	val, err := res[0].GetValue()
+	if err != nil {
+		return "", err
+	}
+	return val.String(), nil
+}
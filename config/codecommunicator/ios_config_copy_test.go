@@ -0,0 +1,137 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestIosCommunicator_GetConfigBackup(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	writesEnabled := true
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{WritesEnabled: &writesEnabled},
+		},
+	})
+
+	newCCCopyIndex = func() int { return 42 }
+	iosConfigCopyPollInterval = time.Millisecond
+
+	snmpClient.
+		On("SNMPSet", mock.Anything,
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.2.42", Type: gosnmp.Integer, Value: 1},
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.3.42", Type: gosnmp.Integer, Value: 4},
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.4.42", Type: gosnmp.Integer, Value: 1},
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.15.42", Type: gosnmp.Integer, Value: 1},
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.16.42", Type: gosnmp.OctetString, Value: []byte{192, 0, 2, 10}},
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.6.42", Type: gosnmp.OctetString, Value: "router1.cfg"},
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.14.42", Type: gosnmp.Integer, Value: 4},
+		).
+		Return(nil).
+		On("SNMPSet", mock.Anything,
+			network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.14.42", Type: gosnmp.Integer, Value: 6},
+		).
+		Return(nil).
+		On("UseCache", false).
+		On("SNMPGet", ctx, network.OID("1.3.6.1.4.1.9.9.96.1.1.1.1.10.42")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.96.1.1.1.1.10.42", gosnmp.Integer, 2),
+		}, nil).Once().
+		On("SNMPGet", ctx, network.OID("1.3.6.1.4.1.9.9.96.1.1.1.1.10.42")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.96.1.1.1.1.10.42", gosnmp.Integer, 3),
+		}, nil).Once()
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	res, err := sut.GetConfigBackup(ctx, device.ConfigBackupDestination{
+		Protocol: "tftp",
+		Server:   "192.0.2.10",
+		Path:     "router1.cfg",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, device.ConfigBackup{
+			Success:     true,
+			Destination: "tftp://192.0.2.10/router1.cfg",
+		}, res)
+	}
+	snmpClient.AssertExpectations(t)
+}
+
+func TestIosCommunicator_GetConfigBackup_failed(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	writesEnabled := true
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{WritesEnabled: &writesEnabled},
+		},
+	})
+
+	newCCCopyIndex = func() int { return 42 }
+
+	snmpClient.
+		On("SNMPSet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		On("SNMPSet", mock.Anything, network.SNMPVariable{OID: "1.3.6.1.4.1.9.9.96.1.1.1.1.14.42", Type: gosnmp.Integer, Value: 6}).
+		Return(nil).
+		On("UseCache", false).
+		On("SNMPGet", ctx, network.OID("1.3.6.1.4.1.9.9.96.1.1.1.1.10.42")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.96.1.1.1.1.10.42", gosnmp.Integer, 4),
+		}, nil).
+		On("SNMPGet", ctx, network.OID("1.3.6.1.4.1.9.9.96.1.1.1.1.13.42")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.96.1.1.1.1.13.42", gosnmp.Integer, 3),
+		}, nil)
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	res, err := sut.GetConfigBackup(ctx, device.ConfigBackupDestination{
+		Protocol: "scp",
+		Server:   "2001:db8::10",
+		Path:     "/backups/router1.cfg",
+		Username: "backup",
+		Password: "secret",
+	})
+	if assert.NoError(t, err) {
+		failCause := "timeout"
+		assert.Equal(t, device.ConfigBackup{
+			Destination: "scp://[2001:db8::10]/backups/router1.cfg",
+			FailCause:   &failCause,
+		}, res)
+	}
+	snmpClient.AssertExpectations(t)
+
+	_, err = sut.GetConfigBackup(ctx, device.ConfigBackupDestination{Protocol: "http", Server: "192.0.2.10", Path: "router1.cfg"})
+	assert.Error(t, err, "unsupported protocol")
+	_, err = sut.GetConfigBackup(ctx, device.ConfigBackupDestination{Protocol: "tftp", Server: "backup.example.com", Path: "router1.cfg"})
+	assert.Error(t, err, "server has to be an ip address")
+}
+
+func TestIosCommunicator_GetConfigBackup_writesDisabled(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	_, err := sut.GetConfigBackup(ctx, device.ConfigBackupDestination{Protocol: "tftp", Server: "192.0.2.10", Path: "router1.cfg"})
+	assert.True(t, tholaerr.IsPreConditionError(err))
+	snmpClient.AssertNotCalled(t, "SNMPSet", mock.Anything, mock.Anything)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gosnmp/gosnmp"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -330,3 +331,80 @@ func TestIosCommunicator_GetCPUComponentCPULoad_prioritiseRevOID(t *testing.T) {
 		assert.Equal(t, expected, res)
 	}
 }
+
+//TestIosCommunicator_getCDPNeighbors: 2 CDP neighbors, local interfaces are resolved via ifName
+func TestIosCommunicator_getCDPNeighbors(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.3.1.0")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.3.1.0", gosnmp.Integer, 1),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.6")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.6.10101.1", gosnmp.OctetString, "switch-a"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.6.10102.5", gosnmp.OctetString, "switch-b"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.7")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.7.10101.1", gosnmp.OctetString, "GigabitEthernet0/1"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.2.2.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.10101", gosnmp.OctetString, "GigabitEthernet1/0/1"),
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.10102", gosnmp.OctetString, "GigabitEthernet1/0/2"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.31.1.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.31.1.1.1.1.10101", gosnmp.OctetString, "Gi1/0/1"),
+		}, nil)
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	protocol := "cdp"
+	hostnameA, portA, localA := "switch-a", "GigabitEthernet0/1", "Gi1/0/1"
+	hostnameB, localB := "switch-b", "GigabitEthernet1/0/2"
+	expected := []device.Neighbor{
+		{
+			LocalInterface: &localA,
+			RemoteHostname: &hostnameA,
+			RemotePort:     &portA,
+			Protocol:       &protocol,
+		},
+		{
+			LocalInterface: &localB,
+			RemoteHostname: &hostnameB,
+			Protocol:       &protocol,
+		},
+	}
+
+	res, err := sut.getCDPNeighbors(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+}
+
+//TestIosCommunicator_getCDPNeighbors_notSupported: device does not support the CISCO-CDP-MIB
+func TestIosCommunicator_getCDPNeighbors_notSupported(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.3.1.0")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	_, err := sut.getCDPNeighbors(ctx)
+	assert.True(t, tholaerr.IsComponentNotFoundError(err))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"regexp"
@@ -26,6 +27,10 @@ func (c *junosCommunicator) GetInterfaces(ctx context.Context, filter ...grouppr
 		log.Ctx(ctx).Debug().Msg("filter matched on 'vlan', skipping junos vlan values")
 		return interfaces, nil
 	}
+	if skipInterfaceEnrichment(ctx) {
+		log.Ctx(ctx).Debug().Msg("skipping junos vlan values")
+		return interfaces, nil
+	}
 	log.Ctx(ctx).Debug().Msg("reading junos vlan values")
 
 	interfacesWithVLANs, err := c.addVLANsNonELS(ctx, interfaces)
@@ -243,10 +248,12 @@ func (c *junosCommunicator) getPortIfIndexMapping(ctx context.Context) (map[stri
 	return portIfIndex, nil
 }
 
+// GetCPUComponentCPULoad returns the cpu load of the routing engines and FPCs of the jnxOperatingTable and of the SPUs
+// of SRX devices.
 func (c *junosCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
-	indices, err := c.getRoutingEngineIndices(ctx)
+	indices, err := c.getOperatingIndices(ctx, "(?i)engine|^fpc")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get routing indices")
+		return nil, errors.Wrap(err, "failed to get routing engine and fpc indices")
 	}
 
 	con, ok := network.DeviceConnectionFromContext(ctx)
@@ -296,6 +303,11 @@ type indexAndLabel struct {
 }
 
 func (c *junosCommunicator) getRoutingEngineIndices(ctx context.Context) ([]indexAndLabel, error) {
+	return c.getOperatingIndices(ctx, "(?i)engine")
+}
+
+// getOperatingIndices returns the indices of the jnxOperatingTable whose description matches the regex.
+func (c *junosCommunicator) getOperatingIndices(ctx context.Context, regex string) ([]indexAndLabel, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
 		return nil, errors.New("no device connection available")
@@ -314,7 +326,7 @@ func (c *junosCommunicator) getRoutingEngineIndices(ctx context.Context) ([]inde
 			return nil, errors.Wrap(err, "failed to get string value of snmp response")
 		}
 
-		if ok, err = regexp.MatchString("(?i)engine", res.String()); err == nil && ok {
+		if ok, err = regexp.MatchString(regex, res.String()); err == nil && ok {
 			indices = append(indices, indexAndLabel{
 				index: strings.TrimPrefix(response.GetOID().String(), jnxOperatingDescrOID.String()),
 				label: res.String(),
@@ -476,3 +488,135 @@ func (c *junosCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) (
 
 	return pools, nil
 }
+
+// GetHardwareHealthComponentEnvironmentMonitorState returns the state of the chassis alarms, which is critical if
+// there are red alarms and warning if there are yellow alarms.
+func (c *junosCommunicator) GetHardwareHealthComponentEnvironmentMonitorState(ctx context.Context) (device.HardwareHealthComponentState, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return "", errors.New("no device connection available")
+	}
+
+	for _, alarm := range []struct {
+		oid   network.OID
+		state device.HardwareHealthComponentState
+	}{
+		// jnxRedAlarmCount
+		{".1.3.6.1.4.1.2636.3.4.2.3.2.0", device.HardwareHealthComponentStateCritical},
+		// jnxYellowAlarmCount
+		{".1.3.6.1.4.1.2636.3.4.2.2.2.0", device.HardwareHealthComponentStateWarning},
+	} {
+		response, err := con.SNMP.SnmpClient.SNMPGet(ctx, alarm.oid)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get alarm count")
+		} else if len(response) != 1 {
+			return "", errors.New("invalid alarm count result")
+		}
+
+		res, err := response[0].GetValue()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get string value of alarm count")
+		}
+
+		count, err := res.Int()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to parse alarm count")
+		}
+		if count > 0 {
+			return alarm.state, nil
+		}
+	}
+	return device.HardwareHealthComponentStateNormal, nil
+}
+
+// GetHardwareHealthComponentPowerSupply returns the power supplies (PEMs) of the jnxOperatingTable.
+func (c *junosCommunicator) GetHardwareHealthComponentPowerSupply(ctx context.Context) ([]device.HardwareHealthComponentPowerSupply, error) {
+	states, err := c.getOperatingStates(ctx, "(?i)^(pem|power supply)")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get power supply states")
+	}
+
+	var powerSupplies []device.HardwareHealthComponentPowerSupply
+	for i := range states {
+		powerSupplies = append(powerSupplies, device.HardwareHealthComponentPowerSupply{
+			Description: &states[i].label,
+			State:       &states[i].state,
+		})
+	}
+	return powerSupplies, nil
+}
+
+// GetHardwareHealthComponentFans returns the fans of the jnxOperatingTable.
+func (c *junosCommunicator) GetHardwareHealthComponentFans(ctx context.Context) ([]device.HardwareHealthComponentFan, error) {
+	states, err := c.getOperatingStates(ctx, "(?i)fan")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get fan states")
+	}
+
+	var fans []device.HardwareHealthComponentFan
+	for i := range states {
+		fans = append(fans, device.HardwareHealthComponentFan{
+			Description: &states[i].label,
+			State:       &states[i].state,
+		})
+	}
+	return fans, nil
+}
+
+type labelAndState struct {
+	label string
+	state device.HardwareHealthComponentState
+}
+
+// getOperatingStates returns the jnxOperatingState of all entries of the jnxOperatingTable whose description matches
+// the regex.
+func (c *junosCommunicator) getOperatingStates(ctx context.Context, regex string) ([]labelAndState, error) {
+	indices, err := c.getOperatingIndices(ctx, regex)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 {
+		return nil, tholaerr.NewNotFoundError("no matching entries in jnxOperatingTable")
+	}
+
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	jnxOperatingStateOID := network.OID(".1.3.6.1.4.1.2636.3.1.13.1.6")
+	var states []labelAndState
+	for _, index := range indices {
+		response, err := con.SNMP.SnmpClient.SNMPGet(ctx, jnxOperatingStateOID.AddIndex(index.index))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get operating state")
+		} else if len(response) != 1 {
+			return nil, errors.New("invalid operating state result")
+		}
+
+		res, err := response[0].GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get string value of operating state")
+		}
+
+		states = append(states, labelAndState{
+			label: index.label,
+			state: junosOperatingState2HardwareHealthComponentState(res.String()),
+		})
+	}
+	return states, nil
+}
+
+// junosOperatingState2HardwareHealthComponentState maps the jnxOperatingState to a hardware health state.
+func junosOperatingState2HardwareHealthComponentState(state string) device.HardwareHealthComponentState {
+	switch state {
+	case "2", "3", "7": // running, ready, standby
+		return device.HardwareHealthComponentStateNormal
+	case "4", "5": // reset, runningAtFullSpeed
+		return device.HardwareHealthComponentStateWarning
+	case "6": // down
+		return device.HardwareHealthComponentStateCritical
+	default: // unknown
+		return device.HardwareHealthComponentStateUnknown
+	}
+}
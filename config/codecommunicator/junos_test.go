@@ -99,3 +99,127 @@ func TestJunosCommunicator_GetCPUComponentCPULoad(t *testing.T) {
 		assert.Equal(t, expected, res)
 	}
 }
+
+func TestJunosCommunicator_GetCPUComponentCPULoad_WithFPC(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.2636.3.1.13.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.7.1.0.0", gosnmp.OctetString, "FPC: MPC7E 3D 40XGE @ 0/*/*"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.8.1.1.0", gosnmp.OctetString, "PIC: 20x10GE SFPP @ 0/0/*"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.9.1.0.0", gosnmp.OctetString, "Routing Engine 0"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.9.2.0.0", gosnmp.OctetString, "Routing Engine 1"),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.2636.3.1.13.1.8.7.1.0.0")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.8.7.1.0.0", gosnmp.Gauge32, 12),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.2636.3.1.13.1.8.9.1.0.0")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.8.9.1.0.0", gosnmp.Gauge32, 5),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.2636.3.1.13.1.8.9.2.0.0")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.8.9.2.0.0", gosnmp.Gauge32, 0),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.2636.3.39.1.12.1.1.1.11")).
+		Return(nil, errors.New("No Such Object available on this agent at this OID"))
+
+	sut := junosCommunicator{codeCommunicator{}}
+	res, err := sut.GetCPUComponentCPULoad(ctx)
+
+	if assert.NoError(t, err) && assert.Len(t, res, 3) {
+		assert.Equal(t, "FPC: MPC7E 3D 40XGE @ 0/*/*", *res[0].Label)
+		assert.Equal(t, 12.0, *res[0].Load)
+		assert.Equal(t, "Routing Engine 0", *res[1].Label)
+		assert.Equal(t, 5.0, *res[1].Load)
+		assert.Equal(t, "Routing Engine 1", *res[2].Label)
+		assert.Equal(t, 0.0, *res[2].Load)
+	}
+}
+
+func TestJunosCommunicator_GetHardwareHealthComponentEnvironmentMonitorState(t *testing.T) {
+	for _, c := range []struct {
+		red, yellow int
+		expected    device.HardwareHealthComponentState
+	}{
+		{0, 0, device.HardwareHealthComponentStateNormal},
+		{0, 2, device.HardwareHealthComponentStateWarning},
+		{1, 2, device.HardwareHealthComponentStateCritical},
+	} {
+		var snmpClient network.MockSNMPClient
+		ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+			SNMP: &network.RequestDeviceConnectionSNMP{
+				SnmpClient: &snmpClient,
+			},
+		})
+
+		snmpClient.
+			On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.2636.3.4.2.3.2.0")).
+			Return([]network.SNMPResponse{
+				network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.4.2.3.2.0", gosnmp.Gauge32, c.red),
+			}, nil).
+			On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.2636.3.4.2.2.2.0")).
+			Return([]network.SNMPResponse{
+				network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.4.2.2.2.0", gosnmp.Gauge32, c.yellow),
+			}, nil)
+
+		sut := junosCommunicator{codeCommunicator{}}
+		res, err := sut.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, c.expected, res)
+		}
+	}
+}
+
+func TestJunosCommunicator_GetHardwareHealthComponentPowerSupplyAndFans(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.2636.3.1.13.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.2.1.0.0", gosnmp.OctetString, "PEM 0"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.2.2.0.0", gosnmp.OctetString, "PEM 1"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.4.1.1.0", gosnmp.OctetString, "Top Fan Tray Fan 1"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.2636.3.1.13.1.5.9.1.0.0", gosnmp.OctetString, "Routing Engine 0"),
+		}, nil)
+	for oid, state := range map[network.OID]int{
+		".1.3.6.1.4.1.2636.3.1.13.1.6.2.1.0.0": 2,
+		".1.3.6.1.4.1.2636.3.1.13.1.6.2.2.0.0": 6,
+		".1.3.6.1.4.1.2636.3.1.13.1.6.4.1.1.0": 5,
+	} {
+		snmpClient.
+			On("SNMPGet", ctx, oid).
+			Return([]network.SNMPResponse{
+				network.NewSNMPResponse(oid, gosnmp.Integer, state),
+			}, nil)
+	}
+
+	sut := junosCommunicator{codeCommunicator{}}
+
+	powerSupplies, err := sut.GetHardwareHealthComponentPowerSupply(ctx)
+	if assert.NoError(t, err) && assert.Len(t, powerSupplies, 2) {
+		assert.Equal(t, "PEM 0", *powerSupplies[0].Description)
+		assert.Equal(t, device.HardwareHealthComponentStateNormal, *powerSupplies[0].State)
+		assert.Equal(t, "PEM 1", *powerSupplies[1].Description)
+		assert.Equal(t, device.HardwareHealthComponentStateCritical, *powerSupplies[1].State)
+	}
+
+	fans, err := sut.GetHardwareHealthComponentFans(ctx)
+	if assert.NoError(t, err) && assert.Len(t, fans, 1) {
+		assert.Equal(t, "Top Fan Tray Fan 1", *fans[0].Description)
+		assert.Equal(t, device.HardwareHealthComponentStateWarning, *fans[0].State)
+	}
+}
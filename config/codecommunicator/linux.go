@@ -6,13 +6,14 @@ import (
 	"github.com/inexio/thola/internal/mapping"
 	"github.com/inexio/thola/internal/network"
 	"github.com/pkg/errors"
+	"strings"
 )
 
 type linuxCommunicator struct {
 	codeCommunicator
 }
 
-// GetDiskComponentStorages returns the cpu load of ios devices.
+// GetDiskComponentStorages returns the storages of linux devices.
 func (c *linuxCommunicator) GetDiskComponentStorages(ctx context.Context) ([]device.DiskComponentStorage, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
@@ -52,11 +53,17 @@ func (c *linuxCommunicator) GetDiskComponentStorages(ctx context.Context) ([]dev
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get mapped storage type")
 		}
-		if storageType == "Other" || storageType == "RAM" || storageType == "Virtual Memory" {
+		if storageType == "Other" {
 			continue
 		}
 		storage.Type = &storageType
 
+		storageTypeID := strings.ReplaceAll(strings.ToLower(storageType), " ", "_")
+		storage.StorageType = &storageTypeID
+
+		virtual := storageType == "RAM" || storageType == "Virtual Memory"
+		storage.Virtual = &virtual
+
 		descriptionValue, err := descriptionResponses[i].GetValue()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get value from snmp response")
@@ -72,6 +79,7 @@ func (c *linuxCommunicator) GetDiskComponentStorages(ctx context.Context) ([]dev
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to convert value to int")
 		}
+		storage.AllocationUnits = &storageUnit
 
 		availableValue, err := availableResponses[i].GetValue()
 		if err != nil {
@@ -83,6 +91,7 @@ func (c *linuxCommunicator) GetDiskComponentStorages(ctx context.Context) ([]dev
 		}
 		availableComputed := available * storageUnit
 		storage.Available = &availableComputed
+		storage.TotalBytes = &availableComputed
 
 		usedValue, err := usedResponses[i].GetValue()
 		if err != nil {
@@ -94,6 +103,7 @@ func (c *linuxCommunicator) GetDiskComponentStorages(ctx context.Context) ([]dev
 		}
 		usedComputed := used * storageUnit
 		storage.Used = &usedComputed
+		storage.UsedBytes = &usedComputed
 
 		res = append(res, storage)
 	}
@@ -0,0 +1,273 @@
+package codecommunicator
+
+import (
+	"bufio"
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// linuxSSHCommunicator reads out linux devices via the cli over ssh.
+type linuxSSHCommunicator struct {
+	codeCommunicator
+}
+
+// GetVendor returns the vendor of linux devices.
+func (c *linuxSSHCommunicator) GetVendor(ctx context.Context) (string, error) {
+	return runSSHCommandSingleLine(ctx, "cat /sys/class/dmi/id/sys_vendor")
+}
+
+// GetModel returns the model of linux devices.
+func (c *linuxSSHCommunicator) GetModel(ctx context.Context) (string, error) {
+	return runSSHCommandSingleLine(ctx, "cat /sys/class/dmi/id/product_name")
+}
+
+// GetOSVersion returns the kernel version of linux devices.
+func (c *linuxSSHCommunicator) GetOSVersion(ctx context.Context) (string, error) {
+	return runSSHCommandSingleLine(ctx, "uname -r")
+}
+
+// GetInterfaces returns the interfaces of linux devices.
+func (c *linuxSSHCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SSH == nil {
+		return nil, errors.New("ssh client is empty")
+	}
+
+	output, err := con.SSH.SSHClient.RunCommand(ctx, "ip -o link show")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read links")
+	}
+	interfaces, err := parseIPLinkOutput(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse links")
+	}
+
+	output, err = con.SSH.SSHClient.RunCommand(ctx, "cat /proc/net/dev")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read interface counters")
+	}
+	if err = addProcNetDevCounters(output, interfaces); err != nil {
+		return nil, errors.Wrap(err, "failed to parse interface counters")
+	}
+
+	// virtual interfaces have no speed, so the command fails for some of the interfaces
+	output, err = con.SSH.SSHClient.RunCommand(ctx, "grep -Hs . /sys/class/net/*/speed; true")
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read interface speeds")
+	} else {
+		addSysClassNetSpeeds(output, interfaces)
+	}
+
+	return filterInterfaces(ctx, interfaces, filter)
+}
+
+// GetCountInterfaces returns the count of interfaces of linux devices.
+func (c *linuxSSHCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
+	interfaces, err := c.GetInterfaces(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(interfaces), nil
+}
+
+func runSSHCommandSingleLine(ctx context.Context, command string) (string, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SSH == nil {
+		return "", errors.New("ssh client is empty")
+	}
+
+	output, err := con.SSH.SSHClient.RunCommand(ctx, command)
+	if err != nil {
+		if tholaerr.IsNetworkError(err) {
+			log.Ctx(ctx).Debug().Err(err).Str("command", command).Msg("ssh command failed")
+			return "", tholaerr.NewNotFoundError("command '" + command + "' failed")
+		}
+		return "", errors.Wrapf(err, "failed to run command '%s'", command)
+	}
+
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return "", tholaerr.NewNotFoundError("command '" + command + "' returned no output")
+	}
+	return output, nil
+}
+
+var ipLinkLineRegex = regexp.MustCompile(`^(\d+):\s+([^:@\s]+)(?:@[^:\s]+)?:\s+<([^>]*)>(.*)$`)
+
+// parseIPLinkOutput parses the output of "ip -o link show", which contains one line per interface, e.g.
+// "2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc fq_codel state UP mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:56 brd ff:ff:ff:ff:ff:ff"
+func parseIPLinkOutput(output string) ([]device.Interface, error) {
+	var interfaces []device.Interface
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		matches := ipLinkLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			return nil, errors.Errorf("invalid line '%s'", line)
+		}
+
+		ifIndex, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index in line '%s'", line)
+		}
+		name := matches[2]
+		interf := device.Interface{
+			IfIndex: &ifIndex,
+			IfDescr: &name,
+			IfName:  &name,
+		}
+
+		adminStatus := device.StatusDown
+		for _, flag := range strings.Split(matches[3], ",") {
+			if flag == "UP" {
+				adminStatus = device.StatusUp
+			}
+		}
+		interf.IfAdminStatus = &adminStatus
+
+		fields := strings.Fields(strings.ReplaceAll(matches[4], `\`, " "))
+		for i := 0; i+1 < len(fields); i++ {
+			switch field, next := fields[i], fields[i+1]; {
+			case field == "mtu":
+				if mtu, err := strconv.ParseUint(next, 10, 64); err == nil {
+					interf.IfMtu = &mtu
+				}
+			case field == "state":
+				operStatus := getIPLinkOperStatus(next)
+				interf.IfOperStatus = &operStatus
+			case strings.HasPrefix(field, "link/"):
+				if ifType, ok := ipLinkTypes[strings.TrimPrefix(field, "link/")]; ok {
+					interf.IfType, interf.IfTypeName = &ifType.number, &ifType.name
+				}
+				if field == "link/ether" {
+					mac := strings.ToUpper(next)
+					interf.IfPhysAddress = &mac
+					interf.MACAddress = &mac
+				}
+			}
+		}
+
+		interfaces = append(interfaces, interf)
+	}
+	return interfaces, nil
+}
+
+// ipLinkTypes maps the link types of "ip link" to the number and name of their ifTypes.
+var ipLinkTypes = map[string]struct {
+	number, name string
+}{
+	"ether":    {"6", "ethernetCsmacd"},
+	"loopback": {"24", "softwareLoopback"},
+	"ppp":      {"23", "ppp"},
+	"none":     {"131", "tunnel"},
+}
+
+// getIPLinkOperStatus returns the status of an operational state of "ip link". The states are the ones of RFC 2863.
+func getIPLinkOperStatus(state string) device.Status {
+	switch state {
+	case "UP":
+		return device.StatusUp
+	case "DOWN":
+		return device.StatusDown
+	case "TESTING":
+		return device.StatusTesting
+	case "DORMANT":
+		return device.StatusDormant
+	case "NOTPRESENT":
+		return device.StatusNotPresent
+	case "LOWERLAYERDOWN":
+		return device.StatusLowerLayerDown
+	default:
+		return device.StatusUnknown
+	}
+}
+
+// addProcNetDevCounters adds the counters of /proc/net/dev to the interfaces. The first two lines are the header,
+// every other line contains the name of an interface followed by 8 receive and 8 transmit counters.
+func addProcNetDevCounters(output string, interfaces []device.Interface) error {
+	counters := make(map[string][]uint64)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		sep := strings.Index(line, ":")
+		if sep == -1 || strings.Contains(line, "|") {
+			continue
+		}
+		fields := strings.Fields(line[sep+1:])
+		if len(fields) < 16 {
+			return errors.Errorf("invalid line '%s'", line)
+		}
+		values := make([]uint64, 16)
+		for i := range values {
+			var err error
+			values[i], err = strconv.ParseUint(fields[i], 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid counter in line '%s'", line)
+			}
+		}
+		counters[strings.TrimSpace(line[:sep])] = values
+	}
+
+	for i, interf := range interfaces {
+		values, ok := counters[*interf.IfName]
+		if !ok {
+			continue
+		}
+		interfaces[i].IfHCInOctets = &values[0]
+		interfaces[i].IfInErrors = &values[2]
+		interfaces[i].IfInDiscards = &values[3]
+		interfaces[i].IfHCInMulticastPkts = &values[7]
+		interfaces[i].IfHCOutOctets = &values[8]
+		interfaces[i].IfOutErrors = &values[10]
+		interfaces[i].IfOutDiscards = &values[11]
+	}
+	return nil
+}
+
+// addSysClassNetSpeeds adds the speeds of "grep -H . /sys/class/net/*/speed" to the interfaces. The speeds are given
+// in Mbit/s, unknown speeds are negative.
+func addSysClassNetSpeeds(output string, interfaces []device.Interface) {
+	speeds := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "/sys/class/net/")
+		sep := strings.Index(line, "/speed:")
+		if sep == -1 {
+			continue
+		}
+		speed, err := strconv.ParseUint(line[sep+len("/speed:"):], 10, 64)
+		if err != nil {
+			continue
+		}
+		speeds[line[:sep]] = speed
+	}
+
+	for i, interf := range interfaces {
+		speed, ok := speeds[*interf.IfName]
+		if !ok {
+			continue
+		}
+		highSpeed := speed
+		interfaces[i].IfHighSpeed = &highSpeed
+		if ifSpeed := speed * 1000000; ifSpeed <= 4294967295 {
+			interfaces[i].IfSpeed = &ifSpeed
+		} else {
+			maxSpeed := uint64(4294967295)
+			interfaces[i].IfSpeed = &maxSpeed
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testIPLinkOutput = `1: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 qdisc noqueue state UNKNOWN mode DEFAULT group default qlen 1000\    link/loopback 00:00:00:00:00:00 brd 00:00:00:00:00:00
+2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc fq_codel state UP mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:56 brd ff:ff:ff:ff:ff:ff
+3: eth0.10@eth0: <BROADCAST,MULTICAST> mtu 1500 qdisc noop state DOWN mode DEFAULT group default qlen 1000\    link/ether 52:54:00:12:34:56 brd ff:ff:ff:ff:ff:ff
+`
+
+const testProcNetDevOutput = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:    1000      10    0    0    0     0          0         0     1000      10    0    0    0     0       0          0
+  eth0: 5000000000 40000   1    2    0     0          0         3 6000000000   50000    4    5    0     0       0          0
+`
+
+const testSpeedOutput = `/sys/class/net/eth0/speed:10000
+/sys/class/net/eth0.10/speed:-1
+`
+
+func TestLinuxSSHCommunicator_GetInterfaces(t *testing.T) {
+	var sshClient network.MockSSHClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SSH: &network.RequestDeviceConnectionSSH{
+			SSHClient: &sshClient,
+		},
+	})
+	sshClient.
+		On("RunCommand", ctx, "ip -o link show").
+		Return(testIPLinkOutput, nil).
+		On("RunCommand", ctx, "cat /proc/net/dev").
+		Return(testProcNetDevOutput, nil).
+		On("RunCommand", ctx, "grep -Hs . /sys/class/net/*/speed; true").
+		Return(testSpeedOutput, nil)
+
+	sut := linuxSSHCommunicator{codeCommunicator{}}
+	res, err := sut.GetInterfaces(ctx)
+	if !assert.NoError(t, err) || !assert.Len(t, res, 3) {
+		return
+	}
+
+	lo, eth0, vlan := res[0], res[1], res[2]
+
+	assert.Equal(t, uint64(1), *lo.IfIndex)
+	assert.Equal(t, "lo", *lo.IfName)
+	assert.Equal(t, "24", *lo.IfType)
+	assert.Equal(t, "softwareLoopback", *lo.IfTypeName)
+	assert.Equal(t, device.StatusUp, *lo.IfAdminStatus)
+	assert.Equal(t, device.StatusUnknown, *lo.IfOperStatus)
+	assert.Nil(t, lo.MACAddress)
+	assert.Nil(t, lo.IfSpeed)
+
+	assert.Equal(t, "eth0", *eth0.IfDescr)
+	assert.Equal(t, "6", *eth0.IfType)
+	assert.Equal(t, "ethernetCsmacd", *eth0.IfTypeName)
+	assert.Equal(t, uint64(1500), *eth0.IfMtu)
+	assert.Equal(t, device.StatusUp, *eth0.IfOperStatus)
+	assert.Equal(t, "52:54:00:12:34:56", *eth0.MACAddress)
+	assert.Equal(t, uint64(5000000000), *eth0.IfHCInOctets)
+	assert.Equal(t, uint64(1), *eth0.IfInErrors)
+	assert.Equal(t, uint64(2), *eth0.IfInDiscards)
+	assert.Equal(t, uint64(3), *eth0.IfHCInMulticastPkts)
+	assert.Equal(t, uint64(6000000000), *eth0.IfHCOutOctets)
+	assert.Equal(t, uint64(4), *eth0.IfOutErrors)
+	assert.Equal(t, uint64(5), *eth0.IfOutDiscards)
+	assert.Equal(t, uint64(10000), *eth0.IfHighSpeed)
+	assert.Equal(t, uint64(4294967295), *eth0.IfSpeed)
+
+	assert.Equal(t, "eth0.10", *vlan.IfName)
+	assert.Equal(t, device.StatusDown, *vlan.IfAdminStatus)
+	assert.Equal(t, device.StatusDown, *vlan.IfOperStatus)
+	assert.Nil(t, vlan.IfHCInOctets)
+	assert.Nil(t, vlan.IfSpeed)
+}
+
+func TestLinuxSSHCommunicator_GetVendor(t *testing.T) {
+	var sshClient network.MockSSHClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SSH: &network.RequestDeviceConnectionSSH{
+			SSHClient: &sshClient,
+		},
+	})
+	sshClient.
+		On("RunCommand", ctx, "cat /sys/class/dmi/id/sys_vendor").
+		Return("QEMU\n", nil).
+		On("RunCommand", ctx, "cat /sys/class/dmi/id/product_name").
+		Return("", tholaerr.NewSSHError("command 'cat /sys/class/dmi/id/product_name' failed: Process exited with status 1"))
+
+	sut := linuxSSHCommunicator{codeCommunicator{}}
+	vendor, err := sut.GetVendor(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "QEMU", vendor)
+	}
+
+	_, err = sut.GetModel(ctx)
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
@@ -36,6 +36,11 @@ func (c *timosCommunicator) GetInterfaces(ctx context.Context, filter ...grouppr
 	// apply description mapping to the default interfaces
 	interfaces = normalizeTimosInterfaces(interfaces, indexDescriptions)
 
+	if skipInterfaceEnrichment(ctx) {
+		log.Ctx(ctx).Debug().Msg("skipping timos sap interfaces")
+		return filterInterfaces(ctx, interfaces, filter)
+	}
+
 	// get all sap interfaces
 	sapDescriptionsOID := network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5")
 	sapDescriptions, err := con.SNMP.SnmpClient.SNMPWalk(ctx, sapDescriptionsOID)
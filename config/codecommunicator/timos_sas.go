@@ -3,9 +3,9 @@ package codecommunicator
 import (
 	"context"
 	"github.com/inexio/thola/internal/device"
-	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/network"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 	"strconv"
 	"strings"
 )
@@ -14,13 +14,16 @@ type timosSASCommunicator struct {
 	codeCommunicator
 }
 
-// GetInterfaces returns the interfaces of a Nokia SAS-T device.
-func (c *timosSASCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
-	interfaces, err := c.parent.GetInterfaces(ctx)
-	if err != nil {
-		return nil, err
-	}
+// AugmentInterfaces adds the SAP counters to the interfaces of a Nokia SAS-T device.
+func (c *timosSASCommunicator) AugmentInterfaces(ctx context.Context, interfaces []device.Interface) ([]device.Interface, error) {
+	return c.addSAPCounters(ctx, interfaces)
+}
 
+// addSAPCounters adds the counters and the status of all SAP entries to the matching interfaces.
+// SAPs are created dynamically, so SAP entries that can't be resolved to an interface or whose counters are missing
+// are skipped. A SAP entry whose counters can't be read completely is still added with the counters that succeeded,
+// and its error is noted in the SAP. Only if the SAP entries can't be walked, an error is returned.
+func (c *timosSASCommunicator) addSAPCounters(ctx context.Context, interfaces []device.Interface) ([]device.Interface, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
 		return nil, errors.New("no device connection available")
@@ -36,41 +39,76 @@ func (c *timosSASCommunicator) GetInterfaces(ctx context.Context, filter ...grou
 	for _, response := range sapDescriptions {
 		// construct description
 		suffix := strings.Split(strings.TrimPrefix(response.GetOID().String(), sapDescriptionsOID.String()), ".")
+		if len(suffix) < 4 {
+			log.Ctx(ctx).Debug().Str("oid", response.GetOID().String()).Msg("skipping sap entry with invalid index")
+			continue
+		}
 		physIndex := suffix[2]
 		subID := suffix[3]
 
 		// construct index
 		subIndex, err := strconv.ParseUint(physIndex+subID, 0, 64)
 		if err != nil {
-			return nil, errors.Wrap(err, "couldn't get index from strings")
+			log.Ctx(ctx).Debug().Err(err).Str("oid", response.GetOID().String()).Msg("skipping sap entry, couldn't get index from strings")
+			continue
 		}
 
 		// search sap interface that matches given subIndex
 		i, err := getInterfaceBySubIndex(subIndex, interfaces)
 		if err != nil {
-			return nil, errors.Wrap(err, "couldn't get interface from index")
+			log.Ctx(ctx).Debug().Err(err).Uint64("sub_index", subIndex).Msg("skipping sap entry, couldn't get interface from index")
+			continue
 		}
 
+		var sap device.SAPInterface
+		var sapErrors []string
+
 		// retrieve inbound
 		inbound, err := getCounterFromSnmpGet(ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.").AddIndex(suffix[1]+"."+physIndex+"."+subID))
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to retrieve inbound counter")
+			sapErrors = append(sapErrors, errors.Wrap(err, "failed to retrieve inbound counter").Error())
+		} else {
+			sap.Inbound = &inbound
 		}
 
 		// retrieve outbound
 		outbound, err := getCounterFromSnmpGet(ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.").AddIndex(suffix[1]+"."+physIndex+"."+subID))
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to retrieve outbound counter")
+			sapErrors = append(sapErrors, errors.Wrap(err, "failed to retrieve outbound counter").Error())
+		} else {
+			sap.Outbound = &outbound
 		}
 
-		// append the sap struct to the interface
-		interfaces[i].SAP = &device.SAPInterface{
-			Inbound:  &inbound,
-			Outbound: &outbound,
+		// retrieve admin and oper status, they are left empty if the device doesn't report them
+		adminStatus, err := getStatusFromSnmpGet(ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.").AddIndex(suffix[1]+"."+physIndex+"."+subID))
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Uint64("sub_index", subIndex).Msg("failed to read sap admin status")
+		} else {
+			sap.AdminStatus = &adminStatus
 		}
+
+		operStatus, err := getStatusFromSnmpGet(ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.").AddIndex(suffix[1]+"."+physIndex+"."+subID))
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Uint64("sub_index", subIndex).Msg("failed to read sap oper status")
+		} else {
+			sap.OperStatus = &operStatus
+		}
+
+		if len(sapErrors) > 0 {
+			sapError := strings.Join(sapErrors, "; ")
+			if sap.Inbound == nil && sap.Outbound == nil {
+				log.Ctx(ctx).Debug().Str("error", sapError).Uint64("sub_index", subIndex).Msg("skipping sap entry, failed to read sap counters")
+				continue
+			}
+			sap.Error = &sapError
+			log.Ctx(ctx).Debug().Str("error", sapError).Uint64("sub_index", subIndex).Msg("failed to read sap counters")
+		}
+
+		// append the sap struct to the interface
+		interfaces[i].SAP = &sap
 	}
 
-	return filterInterfaces(ctx, interfaces, filter)
+	return interfaces, nil
 }
 
 // getInterfaceBySubIndex returns the index of the interface that has the given index.
@@ -0,0 +1,193 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTimosSASCommunicator_addSAPCounters_partial(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5.1.1.1", gosnmp.OctetString, "sap 1"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5.1.1.2", gosnmp.OctetString, "sap 2"),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.1", gosnmp.Counter64, uint64(100)),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.1.1", gosnmp.Counter64, uint64(200)),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.2", gosnmp.Counter64, uint64(300)),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.1.2")).
+		Return(nil, errors.New("request timeout")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.1.1", gosnmp.Integer, 1),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.1.1", gosnmp.Integer, 2),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.1.2")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.1.2")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+
+	ifIndex1, ifIndex2 := uint64(11), uint64(12)
+	interfaces := []device.Interface{
+		{IfIndex: &ifIndex1},
+		{IfIndex: &ifIndex2},
+	}
+
+	sut := timosSASCommunicator{codeCommunicator{}}
+	res, err := sut.addSAPCounters(ctx, interfaces)
+
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		if assert.NotNil(t, res[0].SAP) {
+			assert.Equal(t, uint64(100), *res[0].SAP.Inbound)
+			assert.Equal(t, uint64(200), *res[0].SAP.Outbound)
+			assert.Equal(t, device.StatusUp, *res[0].SAP.AdminStatus)
+			assert.Equal(t, device.StatusDown, *res[0].SAP.OperStatus)
+			assert.Nil(t, res[0].SAP.Error)
+		}
+		if assert.NotNil(t, res[1].SAP) {
+			assert.Equal(t, uint64(300), *res[1].SAP.Inbound)
+			assert.Nil(t, res[1].SAP.Outbound)
+			assert.Nil(t, res[1].SAP.AdminStatus)
+			assert.Nil(t, res[1].SAP.OperStatus)
+			assert.NotNil(t, res[1].SAP.Error)
+		}
+	}
+}
+
+func TestTimosSASCommunicator_addSAPCounters_allFailed(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5.1.1.1", gosnmp.OctetString, "sap 1"),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.1")).
+		Return(nil, errors.New("request timeout")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.1.1")).
+		Return(nil, errors.New("request timeout")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.1.1")).
+		Return(nil, errors.New("request timeout")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.1.1")).
+		Return(nil, errors.New("request timeout"))
+
+	ifIndex := uint64(11)
+	interfaces := []device.Interface{
+		{IfIndex: &ifIndex},
+	}
+
+	sut := timosSASCommunicator{codeCommunicator{}}
+	res, err := sut.addSAPCounters(ctx, interfaces)
+
+	if assert.NoError(t, err) && assert.Len(t, res, 1) {
+		assert.Nil(t, res[0].SAP)
+	}
+}
+
+func TestTimosSASCommunicator_addSAPCounters_unresolvable(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5.1.1.1", gosnmp.OctetString, "sap 1"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5.1.1.9", gosnmp.OctetString, "sap without interface"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5.1.2.1", gosnmp.OctetString, "sap without counters"),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.1.1", gosnmp.Counter64, uint64(100)),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.1.1", gosnmp.Counter64, uint64(200)),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.1.1", gosnmp.Integer, 1),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.1.1", gosnmp.Integer, 1),
+		}, nil).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.4.1.2.1")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Instance currently exists at this OID")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.6.2.2.2.8.1.1.1.6.1.2.1")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Instance currently exists at this OID")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.6.1.2.1")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Instance currently exists at this OID")).
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.7.1.2.1")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Instance currently exists at this OID"))
+
+	ifIndex1, ifIndex2 := uint64(11), uint64(21)
+	interfaces := []device.Interface{
+		{},
+		{IfIndex: &ifIndex1},
+		{IfIndex: &ifIndex2},
+	}
+
+	sut := timosSASCommunicator{codeCommunicator{}}
+	res, err := sut.addSAPCounters(ctx, interfaces)
+
+	if assert.NoError(t, err) && assert.Len(t, res, 3) {
+		assert.Nil(t, res[0].SAP)
+		if assert.NotNil(t, res[1].SAP) {
+			assert.Equal(t, uint64(100), *res[1].SAP.Inbound)
+			assert.Equal(t, uint64(200), *res[1].SAP.Outbound)
+		}
+		assert.Nil(t, res[2].SAP)
+	}
+}
+
+func TestTimosSASCommunicator_addSAPCounters_walkFailed(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.4.3.2.1.5")).
+		Return(nil, errors.New("request timeout"))
+
+	sut := timosSASCommunicator{codeCommunicator{}}
+	_, err := sut.addSAPCounters(ctx, nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/communicator"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/network"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type stubInterfacesCommunicator struct {
+	communicator.Communicator
+	interfaces []device.Interface
+}
+
+func (s stubInterfacesCommunicator) GetInterfaces(context.Context, ...groupproperty.Filter) ([]device.Interface, error) {
+	return s.interfaces, nil
+}
+
+func TestTimosCommunicator_GetInterfaces_skipEnrichment(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	ctx = communicator.NewContextWithSkipInterfaceEnrichment(ctx, true)
+
+	// the sap walk isn't mocked, so the test fails if it is done
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.6527.3.1.2.2.4.2.1.6.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.6527.3.1.2.2.4.2.1.6.1.1", gosnmp.OctetString, "1/1/1"),
+		}, nil)
+
+	ifIndex1, ifIndex2 := uint64(1), uint64(2)
+	ifDescr1, ifDescr2 := "Port 1/1/1", "system"
+	ifName1, ifName2 := "1/1/1", "system"
+	com := timosCommunicator{codeCommunicator{parent: stubInterfacesCommunicator{interfaces: []device.Interface{
+		{IfIndex: &ifIndex1, IfDescr: &ifDescr1, IfName: &ifName1},
+		{IfIndex: &ifIndex2, IfDescr: &ifDescr2, IfName: &ifName2},
+	}}}}
+
+	// the interfaces are still normalized and filtered
+	res, err := com.GetInterfaces(ctx, groupproperty.GetGroupFilter([]string{"ifDescr"}, "^system$"))
+	if assert.NoError(t, err) && assert.Len(t, res, 1) {
+		assert.Equal(t, ifIndex1, *res[0].IfIndex)
+		assert.Equal(t, "1/1/1", *res[0].IfDescr)
+	}
+	snmpClient.AssertExpectations(t)
+}
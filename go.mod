@@ -26,6 +26,7 @@ require (
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.7.0
 	github.com/ulule/limiter/v3 v3.5.0
+	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5
 	golang.org/x/text v0.3.7
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/inexio/thola/internal/component"
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/condition"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 )
 
@@ -19,15 +20,29 @@ type Communicator interface {
 	// HasComponent checks whether the specified component is available.
 	HasComponent(component component.Component) bool
 
+	// HasInvertedInterfaceCounters returns whether the device reports the in and out counters of its interfaces swapped.
+	HasInvertedInterfaceCounters() bool
+
 	// Match checks if the device matches the device class
 	Match(ctx context.Context) (bool, error)
 
+	// MatchWithConfidence checks if the device matches the device class and returns the ratio of passed to total match
+	// conditions of the device class in the same pass.
+	MatchWithConfidence(ctx context.Context) (bool, float64, error)
+
+	// ExplainMatch returns which match conditions of the device class passed or failed and the values observed on the device.
+	ExplainMatch(ctx context.Context) (condition.Explanation, error)
+
 	// UpdateConnection updates the device connection with class specific values
 	UpdateConnection(ctx context.Context) error
 
 	// GetIdentifyProperties returns the identify properties of a device like vendor, model...
 	GetIdentifyProperties(ctx context.Context) (device.Properties, error)
 
+	// GetIdentifyPropertiesStages returns the stages in which the identify properties are read, so that properties
+	// which are derived from other properties are read after them. The properties of a stage are read concurrently.
+	GetIdentifyPropertiesStages() [][]string
+
 	// GetUPSComponent returns the ups component of a device if available.
 	GetUPSComponent(ctx context.Context) (device.UPSComponent, error)
 
@@ -46,6 +61,21 @@ type Communicator interface {
 	// GetHighAvailabilityComponent returns the hardware health component of a device if available.
 	GetHighAvailabilityComponent(ctx context.Context) (device.HighAvailabilityComponent, error)
 
+	// GetVLANComponent returns the vlan component of a device if available.
+	GetVLANComponent(ctx context.Context) (device.VLANComponent, error)
+
+	// GetPOEComponent returns the power over ethernet component of a device if available.
+	GetPOEComponent(ctx context.Context) (device.POEComponent, error)
+
+	// GetEnvironmentComponent returns the environment sensor component of a device if available.
+	GetEnvironmentComponent(ctx context.Context) (device.EnvironmentComponent, error)
+
+	// GetWLANComponent returns the wlan component of a wireless controller if available.
+	GetWLANComponent(ctx context.Context) (device.WLANComponent, error)
+
+	// GetFirewallComponent returns the firewall component of a device if available.
+	GetFirewallComponent(ctx context.Context) (device.FirewallComponent, error)
+
 	Functions
 }
 
@@ -67,6 +97,24 @@ type Functions interface {
 	// GetOSVersion returns the os version of a device.
 	GetOSVersion(ctx context.Context) (string, error)
 
+	// GetFirmwareVersion returns the firmware version of a device.
+	GetFirmwareVersion(ctx context.Context) (string, error)
+
+	// GetBootloaderVersion returns the bootloader version of a device.
+	GetBootloaderVersion(ctx context.Context) (string, error)
+
+	// GetSysName returns the sysName of a device.
+	GetSysName(ctx context.Context) (string, error)
+
+	// GetSysContact returns the sysContact of a device.
+	GetSysContact(ctx context.Context) (string, error)
+
+	// GetSysLocation returns the sysLocation of a device.
+	GetSysLocation(ctx context.Context) (string, error)
+
+	// GetSysDescr returns the sysDescr of a device.
+	GetSysDescr(ctx context.Context) (string, error)
+
 	// GetInterfaces returns the interfaces of a device.
 	GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error)
 
@@ -81,12 +129,34 @@ type Functions interface {
 	availableDiskCommunicatorFunctions
 	availableHardwareHealthCommunicatorFunctions
 	availableHighAvailabilityCommunicatorFunctions
+	availableVLANCommunicatorFunctions
+	availablePOECommunicatorFunctions
+	availableNeighborsCommunicatorFunctions
+	availableRoutesCommunicatorFunctions
+	availableEnvironmentCommunicatorFunctions
+	availableEntityCommunicatorFunctions
+	availableConfigBackupCommunicatorFunctions
+	availableWLANCommunicatorFunctions
+	availableFirewallCommunicatorFunctions
+	availableRemediationCommunicatorFunctions
+}
+
+// InterfaceAugmenter can be implemented by code communicators which don't replace the interfaces of a device, but
+// only add data to them. The interfaces that are passed are the unfiltered interfaces read by the device class, or
+// by the parent device class for sub device classes.
+type InterfaceAugmenter interface {
+
+	// AugmentInterfaces adds data to the given interfaces of a device.
+	AugmentInterfaces(ctx context.Context, interfaces []device.Interface) ([]device.Interface, error)
 }
 
 type availableCPUCommunicatorFunctions interface {
 
 	// GetCPUComponentCPULoad returns the cpu load of the device.
 	GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error)
+
+	// GetCPUComponentDetailed returns the load and temperature per core of the device.
+	GetCPUComponentDetailed(ctx context.Context) ([]device.CPUCore, error)
 }
 
 type availableMemoryCommunicatorFunctions interface {
@@ -135,6 +205,15 @@ type availableUPSCommunicatorFunctions interface {
 
 	// GetUPSComponentSystemVoltage returns the system voltage of the ups device.
 	GetUPSComponentSystemVoltage(ctx context.Context) (float64, error)
+
+	// GetUPSComponentSelfTestResult returns the result of the last self-test of the ups device.
+	GetUPSComponentSelfTestResult(ctx context.Context) (string, error)
+
+	// GetUPSComponentLastTestDate returns the date of the last self-test of the ups device.
+	GetUPSComponentLastTestDate(ctx context.Context) (string, error)
+
+	// GetUPSComponentBatteryStrings returns the battery strings of the ups device.
+	GetUPSComponentBatteryStrings(ctx context.Context) ([]device.UPSBatteryString, error)
 }
 
 type availableServerCommunicatorFunctions interface {
@@ -144,6 +223,24 @@ type availableServerCommunicatorFunctions interface {
 
 	// GetServerComponentUsers returns the user count of the device.
 	GetServerComponentUsers(ctx context.Context) (int, error)
+
+	// GetServerComponentUptime returns the uptime of the device in seconds.
+	GetServerComponentUptime(ctx context.Context) (uint32, error)
+
+	// GetServerComponentLoadAverage1 returns the 1 minute load average of the device.
+	GetServerComponentLoadAverage1(ctx context.Context) (float64, error)
+
+	// GetServerComponentLoadAverage5 returns the 5 minute load average of the device.
+	GetServerComponentLoadAverage5(ctx context.Context) (float64, error)
+
+	// GetServerComponentLoadAverage15 returns the 15 minute load average of the device.
+	GetServerComponentLoadAverage15(ctx context.Context) (float64, error)
+
+	// GetServerComponentSwapTotal returns the total swap space in bytes of the device.
+	GetServerComponentSwapTotal(ctx context.Context) (uint64, error)
+
+	// GetServerComponentSwapUsed returns the used swap space in bytes of the device.
+	GetServerComponentSwapUsed(ctx context.Context) (uint64, error)
 }
 
 type availableSBCCommunicatorFunctions interface {
@@ -192,6 +289,9 @@ type availableHardwareHealthCommunicatorFunctions interface {
 
 	// GetHardwareHealthComponentVoltage returns the voltages of the device.
 	GetHardwareHealthComponentVoltage(context.Context) ([]device.HardwareHealthComponentVoltage, error)
+
+	// GetHardwareHealthComponentHumidity returns the humidity sensors of the device.
+	GetHardwareHealthComponentHumidity(context.Context) ([]device.HardwareHealthComponentHumidity, error)
 }
 
 type availableHighAvailabilityCommunicatorFunctions interface {
@@ -205,3 +305,89 @@ type availableHighAvailabilityCommunicatorFunctions interface {
 	// GetHighAvailabilityComponentNodes returns number of nodes in a HA setup.
 	GetHighAvailabilityComponentNodes(ctx context.Context) (int, error)
 }
+
+type availableVLANCommunicatorFunctions interface {
+
+	// GetVLANComponentVLANs returns the VLANs of the device.
+	GetVLANComponentVLANs(ctx context.Context) ([]device.VLAN, error)
+
+	// GetVLANComponentPortMembership returns the VLAN IDs per ifIndex of the device.
+	GetVLANComponentPortMembership(ctx context.Context) (map[int][]int, error)
+}
+
+type availablePOECommunicatorFunctions interface {
+
+	// GetPOEComponentPSEs returns the power sourcing equipments of the device.
+	GetPOEComponentPSEs(ctx context.Context) ([]device.POEComponentPSE, error)
+
+	// GetPOEComponentPorts returns the power over ethernet ports of the device.
+	GetPOEComponentPorts(ctx context.Context) ([]device.POEComponentPort, error)
+}
+
+type availableNeighborsCommunicatorFunctions interface {
+
+	// GetNeighbors returns the LLDP/CDP neighbors of the device.
+	GetNeighbors(ctx context.Context) ([]device.Neighbor, error)
+}
+
+type availableRoutesCommunicatorFunctions interface {
+
+	// GetRoutingTable returns the routing table of the device.
+	GetRoutingTable(ctx context.Context) ([]device.Route, error)
+
+	// GetCountRoutes returns the count of routes of the device without reading out the routing table.
+	GetCountRoutes(ctx context.Context) (int, error)
+}
+
+type availableEnvironmentCommunicatorFunctions interface {
+
+	// GetEnvironmentComponentTemperature returns the temperature sensors of the device.
+	GetEnvironmentComponentTemperature(ctx context.Context) ([]device.EnvironmentComponentTemperature, error)
+
+	// GetEnvironmentComponentHumidity returns the humidity sensors of the device.
+	GetEnvironmentComponentHumidity(ctx context.Context) ([]device.EnvironmentComponentHumidity, error)
+
+	// GetEnvironmentComponentDryContacts returns the dry contacts of the device.
+	GetEnvironmentComponentDryContacts(ctx context.Context) ([]device.EnvironmentComponentDryContact, error)
+}
+
+type availableEntityCommunicatorFunctions interface {
+
+	// GetEntityComponent returns the physical entities of the device.
+	GetEntityComponent(ctx context.Context) (device.EntityComponent, error)
+}
+
+type availableConfigBackupCommunicatorFunctions interface {
+
+	// GetConfigBackup copies the configuration of the device to the given destination. It performs write operations on
+	// the device.
+	GetConfigBackup(ctx context.Context, destination device.ConfigBackupDestination) (device.ConfigBackup, error)
+}
+
+type availableWLANCommunicatorFunctions interface {
+
+	// GetWLANComponentAccessPoints returns the access points which are managed by the device.
+	GetWLANComponentAccessPoints(ctx context.Context) ([]device.AccessPoint, error)
+
+	// GetWLANComponentClientCount returns the total count of clients of the device.
+	GetWLANComponentClientCount(ctx context.Context) (int, error)
+}
+
+type availableFirewallCommunicatorFunctions interface {
+
+	// GetFirewallComponentActiveSessions returns the count of active sessions of the device.
+	GetFirewallComponentActiveSessions(ctx context.Context) (int, error)
+
+	// GetFirewallComponentVPNTunnels returns the vpn tunnels of the device.
+	GetFirewallComponentVPNTunnels(ctx context.Context) ([]device.FirewallVPNTunnel, error)
+
+	// GetFirewallComponentLicenses returns the licenses of the device and their expiry.
+	GetFirewallComponentLicenses(ctx context.Context) ([]device.FirewallLicense, error)
+}
+
+type availableRemediationCommunicatorFunctions interface {
+
+	// SetInterfaceAdminStatus sets the admin status of the interface with the given ifIndex to up or down. It performs
+	// write operations on the device, which need to be enabled for the connection.
+	SetInterfaceAdminStatus(ctx context.Context, ifIndex uint64, up bool) error
+}
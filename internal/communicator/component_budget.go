@@ -0,0 +1,107 @@
+package communicator
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+type ctxKey byte
+
+const (
+	componentTimeoutWeightsKey ctxKey = iota + 1
+	diskStorageFilterKey
+	splitComponentsTimeoutKey
+	skipInterfaceEnrichmentKey
+)
+
+// NewContextWithComponentTimeoutWeights returns a new context with the weights that are used to split the remaining
+// request time between components. The keys are the component names, e.g. "hardware_health".
+// Components without a weight have the weight 1.
+func NewContextWithComponentTimeoutWeights(ctx context.Context, weights map[string]float64) context.Context {
+	return context.WithValue(ctx, componentTimeoutWeightsKey, weights)
+}
+
+// ComponentTimeoutWeightsFromContext gets the component timeout weights from the context.
+func ComponentTimeoutWeightsFromContext(ctx context.Context) (map[string]float64, bool) {
+	weights, ok := ctx.Value(componentTimeoutWeightsKey).(map[string]float64)
+	return weights, ok
+}
+
+// NewContextWithSplitComponentsTimeout returns a new context which makes GetComponents split the time that is left
+// until the deadline of the context between the components it reads.
+func NewContextWithSplitComponentsTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, splitComponentsTimeoutKey, true)
+}
+
+// SplitComponentsTimeoutFromContext returns whether GetComponents should split the remaining time between the components.
+func SplitComponentsTimeoutFromContext(ctx context.Context) bool {
+	split, _ := ctx.Value(splitComponentsTimeoutKey).(bool)
+	return split
+}
+
+// componentBudget splits the time that is left until the deadline of a request between whole components, e.g. "ups",
+// so that one component whose requests hang cannot use up the time of the following components.
+// Components that finish early leave their unused time to the components that are read after them.
+// The components have to be read one after another in the order they were passed to newComponentBudget.
+type componentBudget struct {
+	ctx       context.Context
+	weights   map[string]float64
+	remaining []string
+
+	current       string
+	currentCtx    context.Context
+	currentCancel context.CancelFunc
+}
+
+func newComponentBudget(ctx context.Context, components ...string) *componentBudget {
+	weights, _ := ComponentTimeoutWeightsFromContext(ctx)
+	return &componentBudget{
+		ctx:       ctx,
+		weights:   weights,
+		remaining: components,
+	}
+}
+
+func (b *componentBudget) weight(comp string) float64 {
+	if w, ok := b.weights[comp]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// start returns the context for reading the given component.
+// If the request has a deadline, the context gets the share of the remaining time that belongs to the component.
+func (b *componentBudget) start(comp string) context.Context {
+	for i, c := range b.remaining {
+		if c == comp {
+			b.remaining = append(b.remaining[:i:i], b.remaining[i+1:]...)
+			break
+		}
+	}
+	sum := b.weight(comp)
+	for _, c := range b.remaining {
+		sum += b.weight(c)
+	}
+
+	b.current = comp
+	b.currentCtx, b.currentCancel = b.ctx, func() {}
+	if deadline, ok := b.ctx.Deadline(); ok {
+		share := time.Duration(float64(time.Until(deadline)) * b.weight(comp) / sum)
+		b.currentCtx, b.currentCancel = context.WithTimeout(b.ctx, share)
+	}
+	return b.currentCtx
+}
+
+// finish releases the context of the current component. If the component ran out of its time budget while the request
+// is still running, a TimeoutError is returned instead of the original error.
+func (b *componentBudget) finish(err error) error {
+	defer b.currentCancel()
+	if err == nil || b.currentCtx.Err() != context.DeadlineExceeded || b.ctx.Err() != nil {
+		return err
+	}
+	log.Ctx(b.ctx).Warn().Err(err).Str("component", b.current).Msg("component exceeded its time budget")
+	return tholaerr.NewTimeoutError(fmt.Sprintf("%s exceeded its time budget", b.current))
+}
@@ -0,0 +1,56 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestComponentBudget_start(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer cancel()
+	ctx = NewContextWithComponentTimeoutWeights(ctx, map[string]float64{
+		"ups": 2,
+	})
+
+	budget := newComponentBudget(ctx, "ups", "server", "hardware_health")
+
+	upsCtx := budget.start("ups")
+	deadline, ok := upsCtx.Deadline()
+	if assert.True(t, ok) {
+		assert.InDelta(t, 2*time.Second, time.Until(deadline), float64(100*time.Millisecond))
+	}
+	assert.NoError(t, budget.finish(nil))
+
+	serverCtx := budget.start("server")
+	deadline, ok = serverCtx.Deadline()
+	if assert.True(t, ok) {
+		assert.InDelta(t, 2*time.Second, time.Until(deadline), float64(100*time.Millisecond))
+	}
+	assert.NoError(t, budget.finish(nil))
+}
+
+func TestComponentBudget_timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	budget := newComponentBudget(ctx, "ups", "server", "hardware_health", "disk")
+
+	upsCtx := budget.start("ups")
+	<-upsCtx.Done()
+	err := budget.finish(upsCtx.Err())
+	assert.True(t, tholaerr.IsTimeoutError(err))
+
+	budget.start("server")
+	assert.True(t, tholaerr.IsNotFoundError(budget.finish(tholaerr.NewNotFoundError("not found"))))
+}
+
+func TestComponentBudget_noDeadline(t *testing.T) {
+	budget := newComponentBudget(context.Background(), "disk")
+
+	_, ok := budget.start("disk").Deadline()
+	assert.False(t, ok)
+	assert.NoError(t, budget.finish(nil))
+}
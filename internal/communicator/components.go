@@ -0,0 +1,163 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/tholaerr"
+)
+
+// GetComponents reads all components that are available for the device.
+// A component that can't be read doesn't stop reading the other components. The errors of all failed components are
+// returned as a tholaerr.MultiError together with the components that could be read.
+// If the context was created with NewContextWithSplitComponentsTimeout, the time left until its deadline is split
+// between the components, see componentBudget.
+func GetComponents(ctx context.Context, com Communicator) (device.Components, error) {
+	var res device.Components
+
+	type componentReader struct {
+		component component.Component
+		read      func(ctx context.Context) error
+	}
+	var readers []componentReader
+	add := func(comp component.Component, readComponent func(ctx context.Context) error) {
+		if com.HasComponent(comp) {
+			readers = append(readers, componentReader{comp, readComponent})
+		}
+	}
+
+	add(component.Interfaces, func(ctx context.Context) error {
+		interfaces, err := com.GetInterfaces(ctx)
+		res.Interfaces = interfaces
+		return err
+	})
+	add(component.CPU, func(ctx context.Context) error {
+		cpus, err := com.GetCPUComponentCPULoad(ctx)
+		if err == nil {
+			res.CPU = &device.CPUComponent{CPUs: cpus}
+		}
+		return err
+	})
+	add(component.Memory, func(ctx context.Context) error {
+		pools, err := com.GetMemoryComponentMemoryUsage(ctx)
+		if err == nil {
+			res.Memory = &device.MemoryComponent{Pools: pools}
+		}
+		return err
+	})
+	add(component.Disk, func(ctx context.Context) error {
+		disk, err := com.GetDiskComponent(ctx)
+		if err == nil {
+			res.Disk = &disk
+		}
+		return err
+	})
+	add(component.UPS, func(ctx context.Context) error {
+		ups, err := com.GetUPSComponent(ctx)
+		if err == nil {
+			res.UPS = &ups
+		}
+		return err
+	})
+	add(component.Server, func(ctx context.Context) error {
+		server, err := com.GetServerComponent(ctx)
+		if err == nil {
+			res.Server = &server
+		}
+		return err
+	})
+	add(component.SBC, func(ctx context.Context) error {
+		sbc, err := com.GetSBCComponent(ctx)
+		if err == nil {
+			res.SBC = &sbc
+		}
+		return err
+	})
+	add(component.HardwareHealth, func(ctx context.Context) error {
+		hardwareHealth, err := com.GetHardwareHealthComponent(ctx)
+		if err == nil {
+			res.HardwareHealth = &hardwareHealth
+		}
+		return err
+	})
+	add(component.HighAvailability, func(ctx context.Context) error {
+		highAvailability, err := com.GetHighAvailabilityComponent(ctx)
+		if err == nil {
+			res.HighAvailability = &highAvailability
+		}
+		return err
+	})
+	add(component.VLAN, func(ctx context.Context) error {
+		vlan, err := com.GetVLANComponent(ctx)
+		if err == nil {
+			res.VLAN = &vlan
+		}
+		return err
+	})
+	add(component.POE, func(ctx context.Context) error {
+		poe, err := com.GetPOEComponent(ctx)
+		if err == nil {
+			res.POE = &poe
+		}
+		return err
+	})
+	add(component.Routes, func(ctx context.Context) error {
+		routes, err := com.GetRoutingTable(ctx)
+		res.Routes = routes
+		return err
+	})
+	add(component.Environment, func(ctx context.Context) error {
+		environment, err := com.GetEnvironmentComponent(ctx)
+		if err == nil {
+			res.Environment = &environment
+		}
+		return err
+	})
+	add(component.Entity, func(ctx context.Context) error {
+		entity, err := com.GetEntityComponent(ctx)
+		if err == nil {
+			res.Entity = &entity
+		}
+		return err
+	})
+	add(component.WLAN, func(ctx context.Context) error {
+		wlan, err := com.GetWLANComponent(ctx)
+		if err == nil {
+			res.WLAN = &wlan
+		}
+		return err
+	})
+	add(component.Firewall, func(ctx context.Context) error {
+		firewall, err := com.GetFirewallComponent(ctx)
+		if err == nil {
+			res.Firewall = &firewall
+		}
+		return err
+	})
+
+	var budget *componentBudget
+	if SplitComponentsTimeoutFromContext(ctx) {
+		var names []string
+		for _, r := range readers {
+			name, _ := r.component.ToString()
+			names = append(names, name)
+		}
+		budget = newComponentBudget(ctx, names...)
+	}
+
+	var errs tholaerr.MultiError
+	for _, r := range readers {
+		name, _ := r.component.ToString()
+		var err error
+		if budget != nil {
+			err = budget.finish(r.read(budget.start(name)))
+		} else {
+			err = r.read(ctx)
+		}
+		if err != nil {
+			errs.Add(name, err)
+		}
+	}
+
+	return res, errs.ErrorOrNil()
+}
@@ -0,0 +1,109 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type stubComponentsCommunicator struct {
+	stubDeviceClassCommunicator
+}
+
+func (s stubComponentsCommunicator) GetUPSComponent(context.Context) (device.UPSComponent, error) {
+	return device.UPSComponent{}, errors.Wrap(tholaerr.NewNotFoundError("no ups data available"), "error in device class communicator")
+}
+
+func TestGetComponents(t *testing.T) {
+	ifDescr := "eth0"
+	com := stubComponentsCommunicator{stubDeviceClassCommunicator{
+		components: map[component.Component]bool{
+			component.Interfaces: true,
+			component.CPU:        true,
+			component.UPS:        true,
+		},
+		cpuLoadErr: tholaerr.NewNotImplementedError("no detection is implemented for cpu load"),
+		interfaces: []device.Interface{{IfDescr: &ifDescr}},
+	}}
+
+	res, err := GetComponents(context.Background(), com)
+	assert.Equal(t, device.Components{Interfaces: []device.Interface{{IfDescr: &ifDescr}}}, res)
+
+	var multiErr *tholaerr.MultiError
+	if assert.True(t, errors.As(err, &multiErr)) && assert.Len(t, multiErr.Errors(), 2) {
+		cpuErr, upsErr := multiErr.Errors()[0], multiErr.Errors()[1]
+		assert.Equal(t, "cpu", cpuErr.Component)
+		assert.True(t, tholaerr.IsNotImplementedError(cpuErr))
+		assert.Equal(t, "ups", upsErr.Component)
+		assert.True(t, tholaerr.IsNotFoundError(upsErr))
+	}
+}
+
+func TestGetComponents_noErrors(t *testing.T) {
+	com := stubComponentsCommunicator{stubDeviceClassCommunicator{
+		components: map[component.Component]bool{component.Interfaces: true},
+	}}
+
+	_, err := GetComponents(context.Background(), com)
+	assert.NoError(t, err)
+}
+
+type stubSlowComponentsCommunicator struct {
+	stubDeviceClassCommunicator
+	serverDeadline time.Time
+}
+
+func (s *stubSlowComponentsCommunicator) GetUPSComponent(ctx context.Context) (device.UPSComponent, error) {
+	<-ctx.Done()
+	return device.UPSComponent{}, ctx.Err()
+}
+
+func (s *stubSlowComponentsCommunicator) GetServerComponent(ctx context.Context) (device.ServerComponent, error) {
+	s.serverDeadline, _ = ctx.Deadline()
+	return device.ServerComponent{}, nil
+}
+
+func TestGetComponents_splitTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx = NewContextWithSplitComponentsTimeout(ctx)
+	ctx = NewContextWithComponentTimeoutWeights(ctx, map[string]float64{
+		"server": 9,
+	})
+	deadline, _ := ctx.Deadline()
+
+	com := &stubSlowComponentsCommunicator{stubDeviceClassCommunicator: stubDeviceClassCommunicator{
+		components: map[component.Component]bool{
+			component.UPS:    true,
+			component.Server: true,
+		},
+	}}
+
+	res, err := GetComponents(ctx, com)
+	assert.NotNil(t, res.Server)
+
+	// the ups only gets a tenth of the time, so it runs out of its budget long before the request times out
+	var multiErr *tholaerr.MultiError
+	if assert.True(t, errors.As(err, &multiErr)) && assert.Len(t, multiErr.Errors(), 1) {
+		assert.Equal(t, "ups", multiErr.Errors()[0].Component)
+		assert.True(t, tholaerr.IsTimeoutError(multiErr.Errors()[0]))
+	}
+	assert.False(t, com.serverDeadline.IsZero())
+	assert.False(t, com.serverDeadline.After(deadline))
+	assert.NoError(t, ctx.Err())
+}
+
+func TestGetComponents_splitTimeoutNoDeadline(t *testing.T) {
+	com := &stubSlowComponentsCommunicator{stubDeviceClassCommunicator: stubDeviceClassCommunicator{
+		components: map[component.Component]bool{component.Server: true},
+	}}
+
+	_, err := GetComponents(NewContextWithSplitComponentsTimeout(context.Background()), com)
+	assert.NoError(t, err)
+	assert.True(t, com.serverDeadline.IsZero())
+}
@@ -5,37 +5,88 @@ import (
 	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/communicator/hierarchy"
 	"github.com/inexio/thola/internal/deviceclass"
+	"github.com/inexio/thola/internal/deviceclass/condition"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"os"
+	"sort"
 	"strings"
 	"sync"
 )
 
+// genericHierarchy holds the current hierarchy. A reload replaces the pointer, so requests that already retrieved the
+// hierarchy keep using the old one.
 var genericHierarchy struct {
-	hierarchy.Hierarchy
-	sync.Once
+	sync.Mutex
+	hierarchy *hierarchy.Hierarchy
 }
 
-func initHierarchy(ctx context.Context) error {
-	var err error
-	genericHierarchy.Do(func() {
-		genericHierarchy.Hierarchy, err = deviceclass.GetHierarchy()
+func getHierarchy(ctx context.Context) (*hierarchy.Hierarchy, error) {
+	genericHierarchy.Lock()
+	defer genericHierarchy.Unlock()
+
+	if genericHierarchy.hierarchy == nil {
+		hier, err := loadHierarchy()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build initial hierarchy")
+		}
+		genericHierarchy.hierarchy = &hier
 		log.Ctx(ctx).Debug().Msg("device configurations initialized")
-	})
+	}
+	return genericHierarchy.hierarchy, nil
+}
+
+// loadHierarchy reads in the device classes of the configured device class directory, or the embedded device classes
+// if no directory is configured.
+func loadHierarchy() (hierarchy.Hierarchy, error) {
+	var hier hierarchy.Hierarchy
+	var err error
+	if dir := viper.GetString("device-class-dir"); dir != "" {
+		hier, err = deviceclass.GetHierarchyFromFS(os.DirFS(dir), ".")
+	} else {
+		hier, err = deviceclass.GetHierarchy()
+	}
 	if err != nil {
-		return errors.Wrap(err, "failed to build initial hierarchy")
+		return hierarchy.Hierarchy{}, err
 	}
-	if genericHierarchy.NetworkDeviceCommunicator == nil {
-		return errors.New("hierarchy isn't initialized")
+	if hier.NetworkDeviceCommunicator == nil {
+		return hierarchy.Hierarchy{}, errors.New("hierarchy isn't initialized")
+	}
+	return hier, nil
+}
+
+// ValidateDeviceClasses validates the device classes of the configured device class directory, or the embedded device
+// classes if no directory is configured, without reading them in. If they are invalid, the cause of the returned error
+// is a *deviceclass.ValidationError.
+func ValidateDeviceClasses() error {
+	if dir := viper.GetString("device-class-dir"); dir != "" {
+		return deviceclass.ValidateFS(os.DirFS(dir), ".")
 	}
+	return deviceclass.Validate()
+}
+
+// ReloadHierarchy reads in the device classes again and replaces the current hierarchy. Requests that are already
+// running keep using the old hierarchy. If the device classes can't be read in, the current hierarchy stays active.
+func ReloadHierarchy(ctx context.Context) error {
+	hier, err := loadHierarchy()
+	if err != nil {
+		return errors.Wrap(err, "failed to reload hierarchy")
+	}
+
+	genericHierarchy.Lock()
+	genericHierarchy.hierarchy = &hier
+	genericHierarchy.Unlock()
+
+	log.Ctx(ctx).Info().Msg("device configurations reloaded")
 	return nil
 }
 
 // GetNetworkDeviceCommunicator returns the network device communicator for the given identifier
 func GetNetworkDeviceCommunicator(ctx context.Context, identifier string) (communicator.Communicator, error) {
-	err := initHierarchy(ctx)
+	generic, err := getHierarchy(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -46,11 +97,11 @@ func GetNetworkDeviceCommunicator(ctx context.Context, identifier string) (commu
 	configIdentifiers := strings.Split(identifier, "/")
 
 	if configIdentifiers[0] == "generic" {
-		return genericHierarchy.NetworkDeviceCommunicator, nil
+		return generic.NetworkDeviceCommunicator, nil
 	}
 
 	currentIdentifier = configIdentifiers[0]
-	hier, ok = genericHierarchy.Children[currentIdentifier]
+	hier, ok = generic.Children[currentIdentifier]
 	if !ok {
 		return nil, errors.New("hierarchy does not exist")
 	}
@@ -71,28 +122,45 @@ func GetNetworkDeviceCommunicator(ctx context.Context, identifier string) (commu
 
 // IdentifyNetworkDeviceCommunicator identifies a devices and creates a network device communicator.
 func IdentifyNetworkDeviceCommunicator(ctx context.Context) (communicator.Communicator, error) {
-	err := initHierarchy(ctx)
+	comm, _, err := IdentifyNetworkDeviceCommunicatorWithConfidence(ctx)
+	return comm, err
+}
+
+// IdentifyNetworkDeviceCommunicatorWithConfidence identifies a devices and creates a network device communicator.
+// It also returns the confidence of the match, which is the ratio of passed to total match conditions of the device class.
+func IdentifyNetworkDeviceCommunicatorWithConfidence(ctx context.Context) (communicator.Communicator, float64, error) {
+	generic, err := getHierarchy(ctx)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	setIdentifyConnectionSettings(ctx)
 
-	comm, err := identifyDeviceRecursive(ctx, genericHierarchy.Children, true)
+	comm, confidence, err := identifyDeviceRecursive(ctx, generic.Children, true)
 	if err != nil {
-		if tholaerr.IsNotFoundError(err) {
-			return genericHierarchy.NetworkDeviceCommunicator, nil
+		if !tholaerr.IsNotFoundError(err) {
+			return nil, 0, errors.Wrap(err, "error occurred while identifying device class")
+		}
+		comm = generic.NetworkDeviceCommunicator
+		_, confidence, err = comm.MatchWithConfidence(ctx)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get match confidence of device class: "+comm.GetIdentifier())
 		}
-		return nil, errors.Wrap(err, "error occurred while identifying device class")
 	}
 
-	return comm, nil
+	return comm, confidence, nil
 }
 
-func identifyDeviceRecursive(ctx context.Context, children map[string]hierarchy.Hierarchy, considerPriority bool) (communicator.Communicator, error) {
+// identifyDeviceRecursive returns the device class with the highest match confidence of the children and the
+// confidence. The match and the confidence are evaluated in the same pass for every device class.
+func identifyDeviceRecursive(ctx context.Context, children map[string]hierarchy.Hierarchy, considerPriority bool) (communicator.Communicator, float64, error) {
 	var tryToMatchLastDeviceClasses map[string]hierarchy.Hierarchy
+	var best *hierarchy.Hierarchy
+	var bestConfidence float64
+	var matchErr error
 
-	for n, hier := range children {
+	for _, n := range sortedHierarchyNames(children) {
+		hier := children[n]
 		if considerPriority && hier.TryToMatchLast {
 			if tryToMatchLastDeviceClasses == nil {
 				tryToMatchLastDeviceClasses = make(map[string]hierarchy.Hierarchy)
@@ -100,52 +168,132 @@ func identifyDeviceRecursive(ctx context.Context, children map[string]hierarchy.
 			tryToMatchLastDeviceClasses[n] = hier
 			continue
 		}
+		// no following device class can have a higher confidence, ties are broken by the device class name
+		if best != nil && bestConfidence >= 1 {
+			continue
+		}
 
 		logger := log.Ctx(ctx).With().Str("device_class", hier.NetworkDeviceCommunicator.GetIdentifier()).Logger()
-		ctx = logger.WithContext(ctx)
+		ctx := logger.WithContext(ctx)
 		log.Ctx(ctx).Debug().Msgf("starting class match (%s)", hier.NetworkDeviceCommunicator.GetIdentifier())
-		match, err := hier.NetworkDeviceCommunicator.Match(ctx)
+		match, confidence, err := hier.NetworkDeviceCommunicator.MatchWithConfidence(ctx)
 		if err != nil {
-			return nil, errors.Wrap(err, "error while trying to match device class: "+hier.NetworkDeviceCommunicator.GetIdentifier())
+			// an error only fails the identification if no other device class matches
+			log.Ctx(ctx).Debug().Err(err).Msg("error while trying to match device class")
+			if matchErr == nil {
+				matchErr = errors.Wrap(err, "error while trying to match device class: "+hier.NetworkDeviceCommunicator.GetIdentifier())
+			}
+			continue
 		}
 
 		if match {
-			log.Ctx(ctx).Debug().Msg("device class matched")
-			if hier.Children != nil {
-				subDeviceClass, err := identifyDeviceRecursive(ctx, hier.Children, true)
-				if err != nil {
-					if tholaerr.IsNotFoundError(err) {
-						return hier.NetworkDeviceCommunicator, nil
-					}
-					return nil, errors.Wrapf(err, "error occurred while trying to identify sub device class for device class '%s'", hier.NetworkDeviceCommunicator.GetIdentifier())
-				}
-				return subDeviceClass, nil
+			log.Ctx(ctx).Debug().Msgf("device class matched with confidence %.2f", confidence)
+			if best == nil || confidence > bestConfidence {
+				best = &hier
+				bestConfidence = confidence
 			}
-			return hier.NetworkDeviceCommunicator, nil
+			continue
 		}
 		log.Ctx(ctx).Debug().Msg("device class did not match")
 	}
+
+	if best != nil {
+		if best.Children != nil {
+			subDeviceClass, subConfidence, err := identifyDeviceRecursive(ctx, best.Children, true)
+			if err != nil {
+				if tholaerr.IsNotFoundError(err) {
+					return best.NetworkDeviceCommunicator, bestConfidence, nil
+				}
+				return nil, 0, errors.Wrapf(err, "error occurred while trying to identify sub device class for device class '%s'", best.NetworkDeviceCommunicator.GetIdentifier())
+			}
+			return subDeviceClass, subConfidence, nil
+		}
+		return best.NetworkDeviceCommunicator, bestConfidence, nil
+	}
+	if matchErr != nil {
+		return nil, 0, matchErr
+	}
+
 	if tryToMatchLastDeviceClasses != nil {
-		deviceClass, err := identifyDeviceRecursive(ctx, tryToMatchLastDeviceClasses, false)
+		deviceClass, confidence, err := identifyDeviceRecursive(ctx, tryToMatchLastDeviceClasses, false)
 		if err != nil {
 			if !tholaerr.IsNotFoundError(err) {
-				return nil, err
+				return nil, 0, err
 			}
 		} else {
-			return deviceClass, nil
+			return deviceClass, confidence, nil
 		}
 	}
 
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok {
-		return nil, errors.New("no connection data found in context")
+		return nil, 0, errors.New("no connection data found in context")
 	}
 
 	// return generic device class
-	if (con.SNMP == nil || !con.SNMP.SnmpClient.HasSuccessfulCachedRequest()) && (con.HTTP == nil || !con.HTTP.HTTPClient.HasSuccessfulCachedRequest()) {
-		return nil, errors.New("no network requests to device succeeded")
+	if (con.SNMP == nil || !con.SNMP.SnmpClient.HasSuccessfulCachedRequest()) && (con.HTTP == nil || !con.HTTP.HTTPClient.HasSuccessfulCachedRequest()) && (con.SSH == nil || !con.SSH.SSHClient.HasSuccessfulCachedRequest()) {
+		return nil, 0, errors.New("no network requests to device succeeded")
+	}
+	return nil, 0, tholaerr.NewNotFoundError("no device class matched")
+}
+
+func sortedHierarchyNames(children map[string]hierarchy.Hierarchy) []string {
+	names := make([]string, 0, len(children))
+	for n := range children {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DeviceClassExplanation describes how a device was matched against a device class and its sub device classes.
+type DeviceClassExplanation struct {
+	Class      string                   `yaml:"class" json:"class" xml:"class"`
+	Matched    bool                     `yaml:"matched" json:"matched" xml:"matched"`
+	Confidence float64                  `yaml:"confidence" json:"confidence" xml:"confidence"`
+	Condition  condition.Explanation    `yaml:"condition" json:"condition" xml:"condition"`
+	Children   []DeviceClassExplanation `yaml:"children,omitempty" json:"children,omitempty" xml:"children,omitempty"`
+}
+
+// ExplainIdentify runs the device class matching and returns for every candidate device class which match conditions
+// passed or failed, together with the values observed on the device.
+// In contrast to the identification, every device class of a level is evaluated, even if one already matched.
+// Sub device classes are candidates only if their parent device class matched.
+func ExplainIdentify(ctx context.Context) ([]DeviceClassExplanation, error) {
+	generic, err := getHierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	setIdentifyConnectionSettings(ctx)
+
+	return explainIdentifyRecursive(ctx, generic.Children)
+}
+
+func explainIdentifyRecursive(ctx context.Context, children map[string]hierarchy.Hierarchy) ([]DeviceClassExplanation, error) {
+	var res []DeviceClassExplanation
+	for _, n := range sortedHierarchyNames(children) {
+		hier := children[n]
+		explanation, err := hier.NetworkDeviceCommunicator.ExplainMatch(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to explain match of device class: "+hier.NetworkDeviceCommunicator.GetIdentifier())
+		}
+
+		classExplanation := DeviceClassExplanation{
+			Class:      hier.NetworkDeviceCommunicator.GetIdentifier(),
+			Matched:    explanation.Matched,
+			Confidence: explanation.Confidence(),
+			Condition:  explanation,
+		}
+		if explanation.Matched && hier.Children != nil {
+			classExplanation.Children, err = explainIdentifyRecursive(ctx, hier.Children)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, classExplanation)
 	}
-	return nil, tholaerr.NewNotFoundError("no device class matched")
+	return res, nil
 }
 
 // MatchDeviceClass checks if the device class in the context matches the given identifier.
@@ -0,0 +1,60 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"regexp"
+)
+
+// DiskStorageFilter filters the storages of the disk component by their description.
+type DiskStorageFilter struct {
+	// Include keeps only the storages whose description matches one of the regular expressions. All storages are kept
+	// if it is empty.
+	Include []*regexp.Regexp
+	// Exclude drops the storages whose description matches one of the regular expressions.
+	Exclude []*regexp.Regexp
+}
+
+// NewContextWithDiskStorageFilter returns a new context with the filter that is applied on the disk storages.
+func NewContextWithDiskStorageFilter(ctx context.Context, filter DiskStorageFilter) context.Context {
+	return context.WithValue(ctx, diskStorageFilterKey, filter)
+}
+
+// DiskStorageFilterFromContext gets the disk storage filter from the context.
+func DiskStorageFilterFromContext(ctx context.Context) (DiskStorageFilter, bool) {
+	filter, ok := ctx.Value(diskStorageFilterKey).(DiskStorageFilter)
+	return filter, ok
+}
+
+// filterDiskStorages applies the disk storage filter of the context on the storages.
+// Storages without a description don't match any regular expression.
+func filterDiskStorages(ctx context.Context, storages []device.DiskComponentStorage) []device.DiskComponentStorage {
+	filter, ok := DiskStorageFilterFromContext(ctx)
+	if !ok || (len(filter.Include) == 0 && len(filter.Exclude) == 0) {
+		return storages
+	}
+
+	res := make([]device.DiskComponentStorage, 0, len(storages))
+	for _, storage := range storages {
+		if len(filter.Include) > 0 && !matchesAnyRegex(filter.Include, storage.Description) {
+			continue
+		}
+		if matchesAnyRegex(filter.Exclude, storage.Description) {
+			continue
+		}
+		res = append(res, storage)
+	}
+	return res
+}
+
+func matchesAnyRegex(regexes []*regexp.Regexp, s *string) bool {
+	if s == nil {
+		return false
+	}
+	for _, regex := range regexes {
+		if regex.MatchString(*s) {
+			return true
+		}
+	}
+	return false
+}
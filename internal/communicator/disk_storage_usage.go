@@ -0,0 +1,54 @@
+package communicator
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"math"
+)
+
+// deriveDiskStoragesUsage completes the usage values of the storages, so that every storage has a comparable
+// percentage of used space. Whenever two of used, size and used percent are known, the third one is derived.
+// The size of a storage is either given in allocation units (available) or in bytes (total bytes).
+func deriveDiskStoragesUsage(storages []device.DiskComponentStorage) []device.DiskComponentStorage {
+	for i := range storages {
+		deriveDiskStorageUsage(&storages[i])
+	}
+	return storages
+}
+
+func deriveDiskStorageUsage(storage *device.DiskComponentStorage) {
+	if storage.UsedPercent == nil {
+		if percent, ok := usedPercent(storage.UsedBytes, storage.TotalBytes); ok {
+			storage.UsedPercent = &percent
+		} else if percent, ok := usedPercent(storage.Used, storage.Available); ok {
+			storage.UsedPercent = &percent
+		}
+	}
+	if storage.UsedPercent == nil {
+		return
+	}
+
+	storage.Used, storage.Available = deriveUsedOrSize(storage.Used, storage.Available, *storage.UsedPercent)
+	storage.UsedBytes, storage.TotalBytes = deriveUsedOrSize(storage.UsedBytes, storage.TotalBytes, *storage.UsedPercent)
+}
+
+// usedPercent returns the percentage of used of size. It returns false if one of them is unknown or the size is 0,
+// as the percentage of an empty storage is undefined.
+func usedPercent(used, size *uint64) (float64, bool) {
+	if used == nil || size == nil || *size == 0 {
+		return 0, false
+	}
+	return float64(*used) / float64(*size) * 100, true
+}
+
+// deriveUsedOrSize derives used or size from the other one and the used percent if exactly one of them is unknown.
+func deriveUsedOrSize(used, size *uint64, percent float64) (*uint64, *uint64) {
+	switch {
+	case used == nil && size != nil:
+		u := uint64(math.Round(float64(*size) * percent / 100))
+		used = &u
+	case used != nil && size == nil && percent > 0:
+		s := uint64(math.Round(float64(*used) * 100 / percent))
+		size = &s
+	}
+	return used, size
+}
@@ -0,0 +1,76 @@
+package communicator
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDeriveDiskStoragesUsage(t *testing.T) {
+	u := func(i uint64) *uint64 { return &i }
+	f := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name     string
+		storage  device.DiskComponentStorage
+		expected device.DiskComponentStorage
+	}{
+		{
+			name:     "used and available",
+			storage:  device.DiskComponentStorage{Used: u(25), Available: u(200)},
+			expected: device.DiskComponentStorage{Used: u(25), Available: u(200), UsedPercent: f(12.5)},
+		},
+		{
+			name:     "used and total bytes",
+			storage:  device.DiskComponentStorage{UsedBytes: u(512), TotalBytes: u(1024)},
+			expected: device.DiskComponentStorage{UsedBytes: u(512), TotalBytes: u(1024), UsedPercent: f(50)},
+		},
+		{
+			name:     "bytes are preferred",
+			storage:  device.DiskComponentStorage{Used: u(1), Available: u(4), UsedBytes: u(1), TotalBytes: u(2)},
+			expected: device.DiskComponentStorage{Used: u(1), Available: u(4), UsedBytes: u(1), TotalBytes: u(2), UsedPercent: f(50)},
+		},
+		{
+			name:     "percent is kept",
+			storage:  device.DiskComponentStorage{Used: u(25), Available: u(200), UsedPercent: f(13)},
+			expected: device.DiskComponentStorage{Used: u(25), Available: u(200), UsedPercent: f(13)},
+		},
+		{
+			name:     "percent and available",
+			storage:  device.DiskComponentStorage{Available: u(200), UsedPercent: f(12.5)},
+			expected: device.DiskComponentStorage{Used: u(25), Available: u(200), UsedPercent: f(12.5)},
+		},
+		{
+			name:     "percent and used bytes",
+			storage:  device.DiskComponentStorage{UsedBytes: u(256), UsedPercent: f(25)},
+			expected: device.DiskComponentStorage{UsedBytes: u(256), TotalBytes: u(1024), UsedPercent: f(25)},
+		},
+		{
+			name:     "size can't be derived from 0 percent",
+			storage:  device.DiskComponentStorage{Used: u(0), UsedPercent: f(0)},
+			expected: device.DiskComponentStorage{Used: u(0), UsedPercent: f(0)},
+		},
+		{
+			name:     "empty storage",
+			storage:  device.DiskComponentStorage{Used: u(0), Available: u(0)},
+			expected: device.DiskComponentStorage{Used: u(0), Available: u(0)},
+		},
+		{
+			name:     "only used",
+			storage:  device.DiskComponentStorage{Used: u(25)},
+			expected: device.DiskComponentStorage{Used: u(25)},
+		},
+		{
+			name:     "only percent",
+			storage:  device.DiskComponentStorage{UsedPercent: f(10)},
+			expected: device.DiskComponentStorage{UsedPercent: f(10)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := deriveDiskStoragesUsage([]device.DiskComponentStorage{test.storage})
+			assert.Equal(t, []device.DiskComponentStorage{test.expected}, res)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const (
+	// maxEmptyValueRetries is the maximum amount of retries of an empty value, regardless of the configuration.
+	maxEmptyValueRetries = 10
+	// maxEmptyValueRetryDelay is the maximum delay in milliseconds before retrying an empty value.
+	maxEmptyValueRetryDelay = 10000
+)
+
+// retryEmptyValue calls get until it returns a non-empty value or the empty value retries of the connection are used
+// up. Some agents transiently return empty values, e.g. while they are booting. Without retries set for the connection,
+// get is only called once.
+func retryEmptyValue(ctx context.Context, get func(context.Context) (string, error)) (string, error) {
+	var retries, delay int
+	if con, ok := network.DeviceConnectionFromContext(ctx); ok && con.RawConnectionData.SNMP != nil {
+		if con.RawConnectionData.SNMP.EmptyValueRetries != nil {
+			retries = *con.RawConnectionData.SNMP.EmptyValueRetries
+		}
+		if con.RawConnectionData.SNMP.EmptyValueRetryDelay != nil {
+			delay = *con.RawConnectionData.SNMP.EmptyValueRetryDelay
+		}
+	}
+	if retries > maxEmptyValueRetries {
+		retries = maxEmptyValueRetries
+	}
+	if delay > maxEmptyValueRetryDelay {
+		delay = maxEmptyValueRetryDelay
+	}
+
+	res, err := get(ctx)
+	for attempt := 1; attempt <= retries && isEmptyValue(res, err); attempt++ {
+		log.Ctx(ctx).Debug().Int("attempt", attempt).Int("delay", delay).Msg("value is empty, retrying")
+
+		timer := time.NewTimer(time.Duration(delay) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", errors.Wrap(ctx.Err(), "context done while waiting to retry empty value")
+		case <-timer.C:
+		}
+
+		res, err = get(ctx)
+	}
+	return res, err
+}
+
+// isEmptyValue returns whether a value is empty. Device classes report empty values as EmptyValueError, code
+// communicators may also return an empty string without error.
+func isEmptyValue(res string, err error) bool {
+	if err != nil {
+		return tholaerr.IsEmptyValueError(err)
+	}
+	return res == ""
+}
@@ -0,0 +1,137 @@
+package communicator
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// maxParallelIdentifyProperties is the maximum amount of identify properties that are read at the same time.
+const maxParallelIdentifyProperties = 4
+
+// identifyPropertyResult is the result of reading out an identify property.
+type identifyPropertyResult struct {
+	value string
+	err   error
+}
+
+// ReadIdentifyProperties reads the identify properties stage by stage. The properties of a stage are read concurrently,
+// as they don't depend on each other. Every property that was read is added to the device properties of the context,
+// so that the properties of the following stages can use it.
+func ReadIdentifyProperties(ctx context.Context, class string, stages [][]string, functions Functions) (device.Properties, error) {
+	getters := map[string]func(context.Context) (string, error){
+		"vendor":             functions.GetVendor,
+		"model":              functions.GetModel,
+		"model_series":       functions.GetModelSeries,
+		"serial_number":      functions.GetSerialNumber,
+		"os_version":         functions.GetOSVersion,
+		"firmware_version":   functions.GetFirmwareVersion,
+		"bootloader_version": functions.GetBootloaderVersion,
+		"sys_name":           functions.GetSysName,
+		"sys_contact":        functions.GetSysContact,
+		"sys_location":       functions.GetSysLocation,
+		"sys_descr":          functions.GetSysDescr,
+	}
+
+	dev := device.Device{
+		Class:      class,
+		Properties: device.Properties{},
+	}
+
+	for _, stage := range stages {
+		stageGetters := make([]func(context.Context) (string, error), len(stage))
+		for i, name := range stage {
+			get, ok := getters[name]
+			if !ok {
+				return device.Properties{}, fmt.Errorf("unknown identify property '%s'", name)
+			}
+			stageGetters[i] = get
+		}
+
+		results := readIdentifyPropertiesConcurrently(ctx, stageGetters)
+
+		// the results are processed in the order of the stage, so that the first error of the stage is returned
+		for i, name := range stage {
+			res, err := results[i].value, results[i].err
+			if err != nil {
+				if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+					return device.Properties{}, errors.Wrap(err, "error occurred during get "+strings.ReplaceAll(name, "_", " "))
+				}
+				continue
+			}
+
+			if err = dev.Properties.SetIdentifyProperty(name, res); err != nil {
+				return device.Properties{}, err
+			}
+		}
+		ctx = device.NewContextWithDeviceProperties(ctx, dev)
+	}
+
+	return dev.Properties, nil
+}
+
+// readIdentifyPropertiesConcurrently calls all getters and returns their results in the same order. The first worker
+// uses the connection of the context, every further worker uses another snmp session to the device. If no further
+// sessions can be opened, the getters are called one after another.
+func readIdentifyPropertiesConcurrently(ctx context.Context, getters []func(context.Context) (string, error)) []identifyPropertyResult {
+	results := make([]identifyPropertyResult, len(getters))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	work := func(workerCTX context.Context, disconnect func()) {
+		defer wg.Done()
+		if disconnect != nil {
+			defer disconnect()
+		}
+		for i := range jobs {
+			results[i] = readIdentifyProperty(workerCTX, getters[i])
+		}
+	}
+
+	// the sessions are opened before the first worker starts, as they copy the settings of the connection of the context
+	for i := 1; i < maxParallelIdentifyProperties && i < len(getters); i++ {
+		workerCTX, disconnect, err := network.NewContextWithSNMPSession(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Int("workers", i).Msg("failed to open another snmp session")
+			break
+		}
+		if disconnect == nil {
+			// the connection of the context can't be shared with further workers
+			break
+		}
+		wg.Add(1)
+		go work(workerCTX, disconnect)
+	}
+
+	wg.Add(1)
+	go work(ctx, nil)
+
+	for i := range getters {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// readIdentifyProperty calls the getter. A panic of the getter is returned as error, as the getter doesn't run in the
+// goroutine of the request, which recovers from panics.
+func readIdentifyProperty(ctx context.Context, get func(context.Context) (string, error)) (res identifyPropertyResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Ctx(ctx).Error().Str("stack", string(debug.Stack())).Msgf("reading identify property paniced: %v", r)
+			res = identifyPropertyResult{err: errors.New("thola paniced: " + fmt.Sprint(r))}
+		}
+	}()
+
+	value, err := get(ctx)
+	return identifyPropertyResult{value: value, err: err}
+}
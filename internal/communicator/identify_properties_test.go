@@ -0,0 +1,152 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// slowIdentifyFunctions returns fixed identify properties after a delay per property.
+type slowIdentifyFunctions struct {
+	Functions
+	delays map[string]time.Duration
+	errs   map[string]error
+}
+
+func (s slowIdentifyFunctions) get(ctx context.Context, name, value string) (string, error) {
+	time.Sleep(s.delays[name])
+	if err := s.errs[name]; err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+func (s slowIdentifyFunctions) GetVendor(ctx context.Context) (string, error) {
+	return s.get(ctx, "vendor", "Cisco")
+}
+
+func (s slowIdentifyFunctions) GetModel(ctx context.Context) (string, error) {
+	return s.get(ctx, "model", "C9300-48P")
+}
+
+// GetModelSeries derives the model series from the model of the context.
+func (s slowIdentifyFunctions) GetModelSeries(ctx context.Context) (string, error) {
+	properties, ok := device.DevicePropertiesFromContext(ctx)
+	if !ok || properties.Properties.Model == nil {
+		return "", tholaerr.NewNotFoundError("no model available")
+	}
+	return s.get(ctx, "model_series", "series of "+*properties.Properties.Model)
+}
+
+func (s slowIdentifyFunctions) GetSerialNumber(ctx context.Context) (string, error) {
+	return s.get(ctx, "serial_number", "FOC1234X0AB")
+}
+
+func (s slowIdentifyFunctions) GetOSVersion(ctx context.Context) (string, error) {
+	return s.get(ctx, "os_version", "17.3.4")
+}
+
+func newSNMPRecContext(t *testing.T) context.Context {
+	dir, err := ioutil.TempDir("", "thola-snmprec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	file := filepath.Join(dir, "public.snmprec")
+	if err := ioutil.WriteFile(file, []byte("1.3.6.1.2.1.1.1.0|4|test\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	client, err := network.NewSNMPRecClient(context.Background(), network.SNMPRecScheme+file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{SnmpClient: client},
+	})
+}
+
+// TestReadIdentifyProperties_concurrent tests that the properties of a stage are read concurrently. With a slow serial
+// lookup of 300ms and 100ms for every other property, reading the properties one after another takes 700ms, while the
+// stages take 400ms: the serial number in the first stage and the model series in the second stage.
+func TestReadIdentifyProperties_concurrent(t *testing.T) {
+	functions := slowIdentifyFunctions{
+		delays: map[string]time.Duration{
+			"vendor":        100 * time.Millisecond,
+			"model":         100 * time.Millisecond,
+			"model_series":  100 * time.Millisecond,
+			"serial_number": 300 * time.Millisecond,
+			"os_version":    100 * time.Millisecond,
+		},
+	}
+	stages := [][]string{{"vendor", "model", "serial_number", "os_version"}, {"model_series"}}
+
+	start := time.Now()
+	properties, err := ReadIdentifyProperties(newSNMPRecContext(t), "test", stages, functions)
+	elapsed := time.Since(start)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Cisco", *properties.Vendor)
+		assert.Equal(t, "C9300-48P", *properties.Model)
+		assert.Equal(t, "series of C9300-48P", *properties.ModelSeries)
+		assert.Equal(t, "FOC1234X0AB", *properties.SerialNumber)
+		assert.Equal(t, "17.3.4", *properties.OSVersion)
+	}
+	assert.True(t, elapsed < 600*time.Millisecond, "properties of a stage were not read concurrently")
+}
+
+func TestReadIdentifyProperties_errors(t *testing.T) {
+	functions := slowIdentifyFunctions{
+		errs: map[string]error{
+			"vendor":     tholaerr.NewNotFoundError("no vendor"),
+			"os_version": tholaerr.NewNotImplementedError("no os version"),
+		},
+	}
+	stages := [][]string{{"vendor", "model", "os_version"}, {"model_series"}}
+
+	// not found and not implemented properties are skipped
+	properties, err := ReadIdentifyProperties(newSNMPRecContext(t), "test", stages, functions)
+	if assert.NoError(t, err) {
+		assert.Nil(t, properties.Vendor)
+		assert.Nil(t, properties.OSVersion)
+		assert.Equal(t, "series of C9300-48P", *properties.ModelSeries)
+	}
+
+	// without a connection that supports further sessions the properties are read one after another
+	properties, err = ReadIdentifyProperties(context.Background(), "test", stages, functions)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "series of C9300-48P", *properties.ModelSeries)
+	}
+
+	functions.errs["model"] = errors.New("request timeout")
+	_, err = ReadIdentifyProperties(newSNMPRecContext(t), "test", stages, functions)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "error occurred during get model")
+	}
+}
+
+// panickingIdentifyFunctions panics while reading the serial number.
+type panickingIdentifyFunctions struct {
+	slowIdentifyFunctions
+}
+
+func (p panickingIdentifyFunctions) GetSerialNumber(ctx context.Context) (string, error) {
+	panic("index out of range")
+}
+
+func TestReadIdentifyProperties_panic(t *testing.T) {
+	stages := [][]string{{"vendor", "model", "serial_number", "os_version"}}
+
+	_, err := ReadIdentifyProperties(newSNMPRecContext(t), "test", stages, panickingIdentifyFunctions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "thola paniced: index out of range")
+	}
+}
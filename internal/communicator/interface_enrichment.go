@@ -0,0 +1,18 @@
+package communicator
+
+import "context"
+
+// NewContextWithSkipInterfaceEnrichment returns a new context which tells GetInterfaces whether to skip the
+// vendor-specific enrichment of the interfaces. If it is skipped, the interfaces are the ones read by the device class
+// (the values of the ifTable and ifXTable and the other values of the device class), normalized and filtered as usual,
+// but without the data that code communicators add with extra requests, like SAPs, VLANs or radio values.
+func NewContextWithSkipInterfaceEnrichment(ctx context.Context, skip bool) context.Context {
+	return context.WithValue(ctx, skipInterfaceEnrichmentKey, skip)
+}
+
+// SkipInterfaceEnrichmentFromContext returns whether the vendor-specific enrichment of the interfaces should be skipped.
+// Code communicators consult it before doing extra requests to add data to the interfaces.
+func SkipInterfaceEnrichmentFromContext(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipInterfaceEnrichmentKey).(bool)
+	return skip
+}
@@ -0,0 +1,79 @@
+package communicator
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"strconv"
+	"strings"
+)
+
+// ifStackStatusOID is the ifStackStatus column of the ifStackTable, which is indexed by the ifIndex of the higher
+// layer and the ifIndex of the lower layer interface.
+const ifStackStatusOID network.OID = "1.3.6.1.2.1.31.1.2.1.3"
+
+// addInterfaceParents sets the parent ifIndex of all interfaces that are stacked on exactly one other interface, like
+// subinterfaces on their physical port. Interfaces that are stacked on several interfaces, like aggregations, don't
+// get a parent. The stack is optional, so errors are only logged.
+func addInterfaceParents(ctx context.Context, interfaces []device.Interface) {
+	stack, err := readInterfaceStack(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read ifStackTable")
+		return
+	}
+
+	for i, interf := range interfaces {
+		if interf.IfIndex == nil {
+			continue
+		}
+		if lower := stack[*interf.IfIndex]; len(lower) == 1 {
+			parent := lower[0]
+			interfaces[i].ParentIfIndex = &parent
+		}
+	}
+}
+
+// readInterfaceStack returns the lower layer ifIndexes of the ifStackTable mapped by the ifIndex of the higher layer.
+// Entries that point to no interface (ifIndex 0) are skipped.
+func readInterfaceStack(ctx context.Context) (map[uint64][]uint64, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("snmp client is empty")
+	}
+
+	responses, err := network.SNMPWalkCached(ctx, con.SNMP.SnmpClient, ifStackStatusOID)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	stack := make(map[uint64][]uint64)
+	for _, response := range responses {
+		idx, err := response.GetOID().GetIndexAfterOID(ifStackStatusOID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index after oid")
+		}
+		parts := strings.Split(idx, ".")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid ifStackTable index '%s'", idx)
+		}
+		higher, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ifStackTable index '%s'", idx)
+		}
+		lower, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ifStackTable index '%s'", idx)
+		}
+		if higher == 0 || lower == 0 {
+			continue
+		}
+		stack[higher] = append(stack[higher], lower)
+	}
+	return stack, nil
+}
@@ -2,25 +2,30 @@ package communicator
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/thola/internal/component"
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/condition"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
-// CreateNetworkDeviceCommunicator creates a network device communicator which combines a device class communicator and code communicator
-func CreateNetworkDeviceCommunicator(deviceClassCommunicator Communicator, codeCommunicator Functions) Communicator {
+// CreateNetworkDeviceCommunicator creates a network device communicator which combines a device class communicator and code communicator.
+// The parent communicator is the communicator of the parent device class, it is nil for device classes without parent.
+func CreateNetworkDeviceCommunicator(deviceClassCommunicator Communicator, codeCommunicator Functions, parentCommunicator Communicator) Communicator {
 	return &networkDeviceCommunicator{
 		deviceClassCommunicator: deviceClassCommunicator,
 		codeCommunicator:        codeCommunicator,
+		parentCommunicator:      parentCommunicator,
 	}
 }
 
 type networkDeviceCommunicator struct {
 	deviceClassCommunicator Communicator
 	codeCommunicator        Functions
+	parentCommunicator      Communicator
 }
 
 func (c *networkDeviceCommunicator) GetIdentifier() string {
@@ -37,70 +42,33 @@ func (c *networkDeviceCommunicator) HasComponent(component component.Component)
 	return c.deviceClassCommunicator.HasComponent(component)
 }
 
+// HasInvertedInterfaceCounters returns whether the device reports the in and out counters of its interfaces swapped.
+func (c *networkDeviceCommunicator) HasInvertedInterfaceCounters() bool {
+	return c.deviceClassCommunicator.HasInvertedInterfaceCounters()
+}
+
 func (c *networkDeviceCommunicator) Match(ctx context.Context) (bool, error) {
 	return c.deviceClassCommunicator.Match(ctx)
 }
 
+func (c *networkDeviceCommunicator) MatchWithConfidence(ctx context.Context) (bool, float64, error) {
+	return c.deviceClassCommunicator.MatchWithConfidence(ctx)
+}
+
+func (c *networkDeviceCommunicator) ExplainMatch(ctx context.Context) (condition.Explanation, error) {
+	return c.deviceClassCommunicator.ExplainMatch(ctx)
+}
+
 func (c *networkDeviceCommunicator) UpdateConnection(ctx context.Context) error {
 	return c.deviceClassCommunicator.UpdateConnection(ctx)
 }
 
 func (c *networkDeviceCommunicator) GetIdentifyProperties(ctx context.Context) (device.Properties, error) {
-	dev := device.Device{
-		Class:      c.GetIdentifier(),
-		Properties: device.Properties{},
-	}
-
-	vendor, err := c.GetVendor(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get vendor")
-		}
-	} else {
-		dev.Properties.Vendor = &vendor
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
-	}
-
-	model, err := c.GetModel(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get model")
-		}
-	} else {
-		dev.Properties.Model = &model
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
-	}
-
-	modelSeries, err := c.GetModelSeries(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get model series")
-		}
-	} else {
-		dev.Properties.ModelSeries = &modelSeries
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
-	}
-
-	serialNumber, err := c.GetSerialNumber(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get serial number")
-		}
-	} else {
-		dev.Properties.SerialNumber = &serialNumber
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
-	}
-
-	osVersion, err := c.GetOSVersion(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get os version")
-		}
-	} else {
-		dev.Properties.OSVersion = &osVersion
-	}
+	return ReadIdentifyProperties(ctx, c.GetIdentifier(), c.GetIdentifyPropertiesStages(), c)
+}
 
-	return dev.Properties, nil
+func (c *networkDeviceCommunicator) GetIdentifyPropertiesStages() [][]string {
+	return c.deviceClassCommunicator.GetIdentifyPropertiesStages()
 }
 
 func (c *networkDeviceCommunicator) GetDiskComponent(ctx context.Context) (device.DiskComponent, error) {
@@ -247,6 +215,36 @@ func (c *networkDeviceCommunicator) GetUPSComponent(ctx context.Context) (device
 		empty = false
 	}
 
+	selfTestResult, err := c.GetUPSComponentSelfTestResult(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.UPSComponent{}, errors.Wrap(err, "error occurred during get self test result")
+		}
+	} else {
+		ups.SelfTestResult = &selfTestResult
+		empty = false
+	}
+
+	lastTestDate, err := c.GetUPSComponentLastTestDate(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.UPSComponent{}, errors.Wrap(err, "error occurred during get last test date")
+		}
+	} else {
+		ups.LastTestDate = &lastTestDate
+		empty = false
+	}
+
+	batteryStrings, err := c.GetUPSComponentBatteryStrings(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.UPSComponent{}, errors.Wrap(err, "error occurred during get battery strings")
+		}
+	} else if len(batteryStrings) > 0 {
+		ups.BatteryStrings = batteryStrings
+		empty = false
+	}
+
 	if empty {
 		return device.UPSComponent{}, tholaerr.NewNotFoundError("no ups data available")
 	}
@@ -282,6 +280,66 @@ func (c *networkDeviceCommunicator) GetServerComponent(ctx context.Context) (dev
 		empty = false
 	}
 
+	uptime, err := c.GetServerComponentUptime(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component uptime")
+		}
+	} else {
+		server.Uptime = &uptime
+		empty = false
+	}
+
+	loadAverage1, err := c.GetServerComponentLoadAverage1(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component load average 1")
+		}
+	} else {
+		server.LoadAverage1 = &loadAverage1
+		empty = false
+	}
+
+	loadAverage5, err := c.GetServerComponentLoadAverage5(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component load average 5")
+		}
+	} else {
+		server.LoadAverage5 = &loadAverage5
+		empty = false
+	}
+
+	loadAverage15, err := c.GetServerComponentLoadAverage15(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component load average 15")
+		}
+	} else {
+		server.LoadAverage15 = &loadAverage15
+		empty = false
+	}
+
+	swapTotal, err := c.GetServerComponentSwapTotal(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component swap total")
+		}
+	} else {
+		server.SwapTotal = &swapTotal
+		empty = false
+	}
+
+	swapUsed, err := c.GetServerComponentSwapUsed(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component swap used")
+		}
+	} else {
+		server.SwapUsed = &swapUsed
+		empty = false
+	}
+
 	if empty {
 		return device.ServerComponent{}, tholaerr.NewNotFoundError("no server data available")
 	}
@@ -392,6 +450,8 @@ func (c *networkDeviceCommunicator) GetSBCComponent(ctx context.Context) (device
 		return device.SBCComponent{}, tholaerr.NewNotFoundError("no sbc data available")
 	}
 
+	sbc.SessionUtilization = sbc.GetSessionUtilization()
+
 	return sbc, nil
 }
 
@@ -405,7 +465,13 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponent(ctx context.Conte
 	empty := true
 
 	state, err := c.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
-	if err != nil {
+	var unknownStateErr device.UnknownHardwareHealthStateError
+	if errors.As(err, &unknownStateErr) {
+		unknown := device.HardwareHealthComponentStateUnknown
+		hardwareHealth.EnvironmentMonitorState = &unknown
+		hardwareHealth.EnvironmentMonitorStateRaw = &unknownStateErr.Raw
+		empty = false
+	} else if err != nil {
 		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
 			return device.HardwareHealthComponent{}, errors.Wrap(err, "error occurred during get environment monitor states")
 		}
@@ -454,6 +520,16 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponent(ctx context.Conte
 		empty = false
 	}
 
+	humidity, err := c.GetHardwareHealthComponentHumidity(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.HardwareHealthComponent{}, errors.Wrap(err, "error occurred during get humidity")
+		}
+	} else {
+		hardwareHealth.Humidity = humidity
+		empty = false
+	}
+
 	if empty {
 		return device.HardwareHealthComponent{}, tholaerr.NewNotFoundError("no hardware health data available")
 	}
@@ -512,156 +588,965 @@ func (c *networkDeviceCommunicator) GetHighAvailabilityComponent(ctx context.Con
 	return ha, nil
 }
 
-func (c *networkDeviceCommunicator) GetVendor(ctx context.Context) (string, error) {
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetVendor(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
-		}
+func (c *networkDeviceCommunicator) GetVLANComponent(ctx context.Context) (device.VLANComponent, error) {
+	if !c.HasComponent(component.VLAN) {
+		return device.VLANComponent{}, tholaerr.NewComponentNotFoundError("no vlan component available for this device")
 	}
 
-	return c.deviceClassCommunicator.GetVendor(ctx)
-}
+	var vlan device.VLANComponent
 
-func (c *networkDeviceCommunicator) GetModel(ctx context.Context) (string, error) {
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetModel(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
+	empty := true
+
+	vlans, err := c.GetVLANComponentVLANs(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.VLANComponent{}, errors.Wrap(err, "error occurred during get vlan component vlans")
 		}
+	} else {
+		vlan.VLANs = vlans
+		empty = false
 	}
 
-	return c.deviceClassCommunicator.GetModel(ctx)
-}
-
-func (c *networkDeviceCommunicator) GetModelSeries(ctx context.Context) (string, error) {
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetModelSeries(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
+	portMembership, err := c.GetVLANComponentPortMembership(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.VLANComponent{}, errors.Wrap(err, "error occurred during get vlan component port membership")
 		}
+	} else {
+		vlan.PortMembership = device.NewVLANPortMemberships(portMembership)
+		empty = false
 	}
 
-	return c.deviceClassCommunicator.GetModelSeries(ctx)
-}
-
-func (c *networkDeviceCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSerialNumber(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
-		}
+	if empty {
+		return device.VLANComponent{}, tholaerr.NewComponentNotFoundError("no vlan data available, device does not support bridge mibs")
 	}
 
-	return c.deviceClassCommunicator.GetSerialNumber(ctx)
+	return vlan, nil
 }
 
-func (c *networkDeviceCommunicator) GetOSVersion(ctx context.Context) (string, error) {
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetOSVersion(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
-		}
+func (c *networkDeviceCommunicator) GetPOEComponent(ctx context.Context) (device.POEComponent, error) {
+	if !c.HasComponent(component.POE) {
+		return device.POEComponent{}, tholaerr.NewComponentNotFoundError("no poe component available for this device")
 	}
 
-	return c.deviceClassCommunicator.GetOSVersion(ctx)
-}
+	var poe device.POEComponent
 
-func (c *networkDeviceCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
-	if !c.HasComponent(component.Interfaces) {
-		return nil, tholaerr.NewComponentNotFoundError("no interface component available for this device")
+	empty := true
+
+	pses, err := c.GetPOEComponentPSEs(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.POEComponent{}, errors.Wrap(err, "error occurred during get poe component pses")
+		}
+	} else {
+		poe.PSEs = pses
+		empty = false
 	}
 
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetInterfaces(ctx, filter...)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return nil, errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
+	ports, err := c.GetPOEComponentPorts(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.POEComponent{}, errors.Wrap(err, "error occurred during get poe component ports")
 		}
+	} else {
+		poe.Ports = ports
+		empty = false
+	}
+
+	if empty {
+		return device.POEComponent{}, tholaerr.NewNotFoundError("no poe data available")
 	}
 
-	return c.deviceClassCommunicator.GetInterfaces(ctx, filter...)
+	return poe, nil
 }
 
-func (c *networkDeviceCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.Interfaces) {
-		return 0, tholaerr.NewComponentNotFoundError("no interface component available for this device")
+func (c *networkDeviceCommunicator) GetEnvironmentComponent(ctx context.Context) (device.EnvironmentComponent, error) {
+	if !c.HasComponent(component.Environment) {
+		return device.EnvironmentComponent{}, tholaerr.NewComponentNotFoundError("no environment component available for this device")
 	}
 
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetCountInterfaces(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
+	var environment device.EnvironmentComponent
+
+	empty := true
+
+	temperature, err := c.GetEnvironmentComponentTemperature(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.EnvironmentComponent{}, errors.Wrap(err, "error occurred during get environment component temperature")
 		}
+	} else {
+		environment.Temperature = temperature
+		empty = false
 	}
 
-	amount, err := c.deviceClassCommunicator.GetCountInterfaces(ctx)
+	humidity, err := c.GetEnvironmentComponentHumidity(ctx)
 	if err != nil {
-		log.Ctx(ctx).Debug().Msg("failed to get count interfaces, trying to get interfaces")
-		var interfaces []device.Interface
-		interfaces, err = c.GetInterfaces(ctx, groupproperty.GetExclusiveValueFilter([][]string{{"ifIndex"}, {"ifDescr"}}))
-		if err != nil {
-			return 0, errors.Wrap(err, "count interfaces failed")
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.EnvironmentComponent{}, errors.Wrap(err, "error occurred during get environment component humidity")
 		}
-		amount = len(interfaces)
+	} else {
+		environment.Humidity = humidity
+		empty = false
 	}
 
-	return amount, err
-}
-
-func (c *networkDeviceCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
-	if !c.HasComponent(component.CPU) {
-		return nil, tholaerr.NewComponentNotFoundError("no cpu component available for this device")
+	dryContacts, err := c.GetEnvironmentComponentDryContacts(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.EnvironmentComponent{}, errors.Wrap(err, "error occurred during get environment component dry contacts")
+		}
+	} else {
+		environment.DryContacts = dryContacts
+		empty = false
 	}
 
-	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetCPUComponentCPULoad(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return nil, errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
-		}
+	if empty {
+		return device.EnvironmentComponent{}, tholaerr.NewNotFoundError("no environment data available")
 	}
 
-	return c.deviceClassCommunicator.GetCPUComponentCPULoad(ctx)
+	return environment, nil
 }
 
-func (c *networkDeviceCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) ([]device.MemoryPool, error) {
+func (c *networkDeviceCommunicator) GetWLANComponent(ctx context.Context) (device.WLANComponent, error) {
+	if !c.HasComponent(component.WLAN) {
+		return device.WLANComponent{}, tholaerr.NewComponentNotFoundError("no wlan component available for this device")
+	}
+
+	var wlan device.WLANComponent
+
+	empty := true
+
+	accessPoints, err := c.GetWLANComponentAccessPoints(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.WLANComponent{}, errors.Wrap(err, "error occurred during get wlan component access points")
+		}
+	} else {
+		wlan.AccessPoints = accessPoints
+		empty = false
+	}
+
+	clientCount, err := c.GetWLANComponentClientCount(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.WLANComponent{}, errors.Wrap(err, "error occurred during get wlan component client count")
+		}
+		wlan.ClientCount = sumAccessPointClientCounts(accessPoints)
+	} else {
+		wlan.ClientCount = &clientCount
+		empty = false
+	}
+
+	if empty {
+		return device.WLANComponent{}, tholaerr.NewNotFoundError("no wlan data available")
+	}
+
+	return wlan, nil
+}
+
+// sumAccessPointClientCounts returns the total count of clients of the access points, or nil if no access point
+// reports its client count.
+func sumAccessPointClientCounts(accessPoints []device.AccessPoint) *int {
+	var sum *int
+	for _, accessPoint := range accessPoints {
+		if accessPoint.ClientCount == nil {
+			continue
+		}
+		if sum == nil {
+			sum = new(int)
+		}
+		*sum += *accessPoint.ClientCount
+	}
+	return sum
+}
+
+func (c *networkDeviceCommunicator) GetFirewallComponent(ctx context.Context) (device.FirewallComponent, error) {
+	if !c.HasComponent(component.Firewall) {
+		return device.FirewallComponent{}, tholaerr.NewComponentNotFoundError("no firewall component available for this device")
+	}
+
+	var firewall device.FirewallComponent
+
+	empty := true
+
+	activeSessions, err := c.GetFirewallComponentActiveSessions(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.FirewallComponent{}, errors.Wrap(err, "error occurred during get firewall component active sessions")
+		}
+	} else {
+		firewall.ActiveSessions = &activeSessions
+		empty = false
+	}
+
+	vpnTunnels, err := c.GetFirewallComponentVPNTunnels(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.FirewallComponent{}, errors.Wrap(err, "error occurred during get firewall component vpn tunnels")
+		}
+	} else {
+		firewall.VPNTunnels = vpnTunnels
+		empty = false
+	}
+
+	licenses, err := c.GetFirewallComponentLicenses(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.FirewallComponent{}, errors.Wrap(err, "error occurred during get firewall component licenses")
+		}
+	} else {
+		firewall.Licenses = licenses
+		empty = false
+	}
+
+	if empty {
+		return device.FirewallComponent{}, tholaerr.NewNotFoundError("no firewall data available")
+	}
+
+	return firewall, nil
+}
+
+func (c *networkDeviceCommunicator) GetVendor(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getVendor)
+}
+
+func (c *networkDeviceCommunicator) GetModel(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getModel)
+}
+
+func (c *networkDeviceCommunicator) GetModelSeries(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getModelSeries)
+}
+
+func (c *networkDeviceCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getSerialNumber)
+}
+
+func (c *networkDeviceCommunicator) GetOSVersion(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getOSVersion)
+}
+
+func (c *networkDeviceCommunicator) GetFirmwareVersion(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getFirmwareVersion)
+}
+
+func (c *networkDeviceCommunicator) GetBootloaderVersion(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getBootloaderVersion)
+}
+
+func (c *networkDeviceCommunicator) GetSysName(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getSysName)
+}
+
+func (c *networkDeviceCommunicator) GetSysContact(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getSysContact)
+}
+
+func (c *networkDeviceCommunicator) GetSysLocation(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getSysLocation)
+}
+
+func (c *networkDeviceCommunicator) GetSysDescr(ctx context.Context) (string, error) {
+	return retryEmptyValue(ctx, c.getSysDescr)
+}
+
+func (c *networkDeviceCommunicator) getVendor(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetVendor(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetVendor(ctx)
+}
+
+func (c *networkDeviceCommunicator) getModel(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetModel(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetModel(ctx)
+}
+
+func (c *networkDeviceCommunicator) getModelSeries(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetModelSeries(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetModelSeries(ctx)
+}
+
+func (c *networkDeviceCommunicator) getSerialNumber(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSerialNumber(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSerialNumber(ctx)
+}
+
+func (c *networkDeviceCommunicator) getOSVersion(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetOSVersion(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetOSVersion(ctx)
+}
+
+func (c *networkDeviceCommunicator) getFirmwareVersion(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetFirmwareVersion(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetFirmwareVersion(ctx)
+}
+
+func (c *networkDeviceCommunicator) getBootloaderVersion(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetBootloaderVersion(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetBootloaderVersion(ctx)
+}
+
+func (c *networkDeviceCommunicator) getSysName(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSysName(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSysName(ctx)
+}
+
+func (c *networkDeviceCommunicator) getSysContact(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSysContact(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSysContact(ctx)
+}
+
+func (c *networkDeviceCommunicator) getSysLocation(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSysLocation(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSysLocation(ctx)
+}
+
+func (c *networkDeviceCommunicator) getSysDescr(ctx context.Context) (string, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSysDescr(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSysDescr(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	if !c.HasComponent(component.Interfaces) {
+		return nil, tholaerr.NewComponentNotFoundError("no interface component available for this device")
+	}
+
+	interfaces, err := c.getInterfaces(ctx, filter...)
+	if err != nil {
+		return nil, err
+	}
+	markAggregations(interfaces)
+	if !groupproperty.CheckValueFiltersMatch(filter, []string{"parent_if_index"}) {
+		addInterfaceParents(ctx, interfaces)
+	}
+	return interfaces, nil
+}
+
+func (c *networkDeviceCommunicator) getInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetInterfaces(ctx, filter...)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+
+		if augmenter, ok := c.codeCommunicator.(InterfaceAugmenter); ok {
+			return c.getAugmentedInterfaces(ctx, augmenter, filter...)
+		}
+	}
+
+	return c.getDeviceClassInterfaces(ctx, filter...)
+}
+
+// getDeviceClassInterfaces reads the interfaces of the device class and swaps their in and out counters if the device
+// class has inverted counters. Interfaces of the parent communicator are already normalized by the parent.
+func (c *networkDeviceCommunicator) getDeviceClassInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	interfaces, err := c.deviceClassCommunicator.GetInterfaces(ctx, filter...)
+	if err != nil {
+		return nil, err
+	}
+	if c.deviceClassCommunicator.HasInvertedInterfaceCounters() {
+		invertInterfaceCounters(interfaces)
+	}
+	return interfaces, nil
+}
+
+// invertInterfaceCounters swaps all paired in and out counters of the interfaces.
+func invertInterfaceCounters(interfaces []device.Interface) {
+	for i := range interfaces {
+		interf := &interfaces[i]
+		interf.IfInOctets, interf.IfOutOctets = interf.IfOutOctets, interf.IfInOctets
+		interf.IfInUcastPkts, interf.IfOutUcastPkts = interf.IfOutUcastPkts, interf.IfInUcastPkts
+		interf.IfInNUcastPkts, interf.IfOutNUcastPkts = interf.IfOutNUcastPkts, interf.IfInNUcastPkts
+		interf.IfInDiscards, interf.IfOutDiscards = interf.IfOutDiscards, interf.IfInDiscards
+		interf.IfInErrors, interf.IfOutErrors = interf.IfOutErrors, interf.IfInErrors
+		interf.IfInMulticastPkts, interf.IfOutMulticastPkts = interf.IfOutMulticastPkts, interf.IfInMulticastPkts
+		interf.IfInBroadcastPkts, interf.IfOutBroadcastPkts = interf.IfOutBroadcastPkts, interf.IfInBroadcastPkts
+		interf.IfHCInOctets, interf.IfHCOutOctets = interf.IfHCOutOctets, interf.IfHCInOctets
+		interf.IfHCInUcastPkts, interf.IfHCOutUcastPkts = interf.IfHCOutUcastPkts, interf.IfHCInUcastPkts
+		interf.IfHCInMulticastPkts, interf.IfHCOutMulticastPkts = interf.IfHCOutMulticastPkts, interf.IfHCInMulticastPkts
+		interf.IfHCInBroadcastPkts, interf.IfHCOutBroadcastPkts = interf.IfHCOutBroadcastPkts, interf.IfHCInBroadcastPkts
+	}
+}
+
+// markAggregations marks all interfaces that are the aggregator of another interface or a LAG interface.
+// Interfaces that are their own aggregator, which is how some devices report ports that aren't part of a LAG, aren't
+// considered as members of an aggregation.
+func markAggregations(interfaces []device.Interface) {
+	aggregators := make(map[uint64]bool)
+	for i, interf := range interfaces {
+		if interf.AggregationID == nil {
+			continue
+		}
+		if interf.IfIndex != nil && uint64(*interf.AggregationID) == *interf.IfIndex {
+			interfaces[i].AggregationID = nil
+			continue
+		}
+		aggregators[uint64(*interf.AggregationID)] = true
+	}
+
+	for i, interf := range interfaces {
+		if (interf.IfIndex != nil && aggregators[*interf.IfIndex]) || (interf.IfTypeName != nil && *interf.IfTypeName == "ieee8023adLag") {
+			interfaces[i].IsAggregator = true
+		}
+	}
+}
+
+// getAugmentedInterfaces reads the interfaces without filters, lets the augmenter add its data and applies the filters
+// afterwards, so that filters also work on the added data. Sub device classes are augmented on top of the interfaces of
+// their parent device class. If the enrichment is skipped, the filters are passed on to the device class.
+func (c *networkDeviceCommunicator) getAugmentedInterfaces(ctx context.Context, augmenter InterfaceAugmenter, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	if SkipInterfaceEnrichmentFromContext(ctx) {
+		log.Ctx(ctx).Debug().Msg("skipping vendor-specific enrichment of the interfaces")
+		if c.parentCommunicator != nil {
+			return c.parentCommunicator.GetInterfaces(ctx, filter...)
+		}
+		return c.getDeviceClassInterfaces(ctx, filter...)
+	}
+
+	var interfaces []device.Interface
+	var err error
+	if c.parentCommunicator != nil {
+		interfaces, err = c.parentCommunicator.GetInterfaces(ctx)
+	} else {
+		interfaces, err = c.getDeviceClassInterfaces(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces, err = augmenter.AugmentInterfaces(ctx, interfaces)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in code communicator")
+	}
+
+	return FilterInterfaces(ctx, interfaces, filter...)
+}
+
+// FilterInterfaces applies the given filters on the interfaces.
+func FilterInterfaces(ctx context.Context, interfaces []device.Interface, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	if len(filter) == 0 {
+		return interfaces, nil
+	}
+
+	var propertyGroups groupproperty.PropertyGroups
+	err := propertyGroups.Encode(interfaces)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode interfaces to property groups")
+	}
+
+	for _, fil := range filter {
+		propertyGroups, err = fil.ApplyPropertyGroups(ctx, propertyGroups)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to apply filter on property groups")
+		}
+	}
+
+	var res []device.Interface
+	err = propertyGroups.Decode(&res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property groups to interfaces")
+	}
+
+	return res, nil
+}
+
+func (c *networkDeviceCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.Interfaces) {
+		return 0, tholaerr.NewComponentNotFoundError("no interface component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetCountInterfaces(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	amount, err := c.deviceClassCommunicator.GetCountInterfaces(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Msg("failed to get count interfaces, trying to get interfaces")
+		var interfaces []device.Interface
+		interfaces, err = c.GetInterfaces(ctx, groupproperty.GetExclusiveValueFilter([][]string{{"ifIndex"}, {"ifDescr"}}))
+		if err != nil {
+			return 0, errors.Wrap(err, "count interfaces failed")
+		}
+		amount = len(interfaces)
+	}
+
+	return amount, err
+}
+
+func (c *networkDeviceCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
+	if !c.HasComponent(component.CPU) {
+		return nil, tholaerr.NewComponentNotFoundError("no cpu component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetCPUComponentCPULoad(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetCPUComponentCPULoad(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetCPUComponentDetailed(ctx context.Context) ([]device.CPUCore, error) {
+	if !c.HasComponent(component.CPU) {
+		return nil, tholaerr.NewComponentNotFoundError("no cpu component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetCPUComponentDetailed(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	res, err := c.deviceClassCommunicator.GetCPUComponentDetailed(ctx)
+	if err == nil || !tholaerr.IsNotImplementedError(err) {
+		return res, err
+	}
+
+	// if no cores are defined, the cpu loads are used, labeled by their position
+	cpus, err := c.GetCPUComponentCPULoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cores []device.CPUCore
+	for i, cpu := range cpus {
+		core := device.CPUCore{
+			Description: fmt.Sprint(i),
+			Load:        cpu.Load,
+		}
+		if cpu.Label != nil && *cpu.Label != "" {
+			core.Description = *cpu.Label
+		}
+		cores = append(cores, core)
+	}
+	return cores, nil
+}
+
+func (c *networkDeviceCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) ([]device.MemoryPool, error) {
 	if !c.HasComponent(component.Memory) {
 		return nil, tholaerr.NewComponentNotFoundError("no memory component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetMemoryComponentMemoryUsage(ctx)
+		res, err := c.codeCommunicator.GetMemoryComponentMemoryUsage(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetMemoryComponentMemoryUsage(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetDiskComponentStorages(ctx context.Context) ([]device.DiskComponentStorage, error) {
+	if !c.HasComponent(component.Disk) {
+		return nil, tholaerr.NewComponentNotFoundError("no disk component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetDiskComponentStorages(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return deriveDiskStoragesUsage(filterDiskStorages(ctx, res)), nil
+		}
+	}
+
+	res, err := c.deviceClassCommunicator.GetDiskComponentStorages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deriveDiskStoragesUsage(filterDiskStorages(ctx, res)), nil
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentAlarmLowVoltageDisconnect(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentAlarmLowVoltageDisconnect(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentAlarmLowVoltageDisconnect(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryAmperage(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryAmperage(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentBatteryAmperage(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryCapacity(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryCapacity(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentBatteryCapacity(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryCurrent(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryCurrent(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentBatteryCurrent(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryRemainingTime(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryRemainingTime(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentBatteryRemainingTime(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryTemperature(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryTemperature(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentBatteryTemperature(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryVoltage(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryVoltage(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentBatteryVoltage(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentCurrentLoad(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentCurrentLoad(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentCurrentLoad(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentMainsVoltageApplied(ctx context.Context) (bool, error) {
+	if !c.HasComponent(component.UPS) {
+		return false, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentMainsVoltageApplied(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return false, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentMainsVoltageApplied(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentRectifierCurrent(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentRectifierCurrent(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentRectifierCurrent(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentSystemVoltage(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.UPS) {
+		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentSystemVoltage(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentSystemVoltage(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentSelfTestResult(ctx context.Context) (string, error) {
+	if !c.HasComponent(component.UPS) {
+		return "", tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentSelfTestResult(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentSelfTestResult(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentLastTestDate(ctx context.Context) (string, error) {
+	if !c.HasComponent(component.UPS) {
+		return "", tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentLastTestDate(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return "", errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetUPSComponentLastTestDate(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetUPSComponentBatteryStrings(ctx context.Context) ([]device.UPSBatteryString, error) {
+	if !c.HasComponent(component.UPS) {
+		return nil, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetUPSComponentBatteryStrings(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return nil, errors.Wrap(err, "error in code communicator")
@@ -671,35 +1556,282 @@ func (c *networkDeviceCommunicator) GetMemoryComponentMemoryUsage(ctx context.Co
 		}
 	}
 
-	return c.deviceClassCommunicator.GetMemoryComponentMemoryUsage(ctx)
+	return c.deviceClassCommunicator.GetUPSComponentBatteryStrings(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetDiskComponentStorages(ctx context.Context) ([]device.DiskComponentStorage, error) {
-	if !c.HasComponent(component.Disk) {
-		return nil, tholaerr.NewComponentNotFoundError("no disk component available for this device")
+func (c *networkDeviceCommunicator) GetSBCComponentAgents(ctx context.Context) ([]device.SBCComponentAgent, error) {
+	if !c.HasComponent(component.SBC) {
+		return nil, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentAgents(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentAgents(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentRealms(ctx context.Context) ([]device.SBCComponentRealm, error) {
+	if !c.HasComponent(component.SBC) {
+		return nil, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentRealms(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentRealms(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentGlobalCallPerSecond(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentGlobalCallPerSecond(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentGlobalCallPerSecond(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentGlobalConcurrentSessions(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentGlobalConcurrentSessions(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentGlobalConcurrentSessions(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentActiveLocalContacts(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentActiveLocalContacts(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentActiveLocalContacts(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentTranscodingCapacity(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentTranscodingCapacity(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentTranscodingCapacity(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentLicenseCapacity(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentLicenseCapacity(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentLicenseCapacity(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentSystemRedundancy(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentSystemRedundancy(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentSystemRedundancy(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetSBCComponentSystemHealthScore(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.SBC) {
+		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetSBCComponentSystemHealthScore(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetSBCComponentSystemHealthScore(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetServerComponentProcs(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetServerComponentProcs(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetServerComponentProcs(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetServerComponentUsers(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetServerComponentUsers(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetServerComponentUsers(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetServerComponentUptime(ctx context.Context) (uint32, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetServerComponentUptime(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetServerComponentUptime(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetServerComponentLoadAverage1(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
+	}
+
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetServerComponentLoadAverage1(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				return 0, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetServerComponentLoadAverage1(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetServerComponentLoadAverage5(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetDiskComponentStorages(ctx)
+		res, err := c.codeCommunicator.GetServerComponentLoadAverage5(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return nil, errors.Wrap(err, "error in code communicator")
+				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetDiskComponentStorages(ctx)
+	return c.deviceClassCommunicator.GetServerComponentLoadAverage5(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentAlarmLowVoltageDisconnect(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetServerComponentLoadAverage15(ctx context.Context) (float64, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentAlarmLowVoltageDisconnect(ctx)
+		res, err := c.codeCommunicator.GetServerComponentLoadAverage15(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return 0, errors.Wrap(err, "error in code communicator")
@@ -709,16 +1841,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentAlarmLowVoltageDisconnect(ctx
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentAlarmLowVoltageDisconnect(ctx)
+	return c.deviceClassCommunicator.GetServerComponentLoadAverage15(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentBatteryAmperage(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetServerComponentSwapTotal(ctx context.Context) (uint64, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentBatteryAmperage(ctx)
+		res, err := c.codeCommunicator.GetServerComponentSwapTotal(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return 0, errors.Wrap(err, "error in code communicator")
@@ -728,16 +1860,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryAmperage(ctx context.C
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentBatteryAmperage(ctx)
+	return c.deviceClassCommunicator.GetServerComponentSwapTotal(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentBatteryCapacity(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetServerComponentSwapUsed(ctx context.Context) (uint64, error) {
+	if !c.HasComponent(component.Server) {
+		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentBatteryCapacity(ctx)
+		res, err := c.codeCommunicator.GetServerComponentSwapUsed(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return 0, errors.Wrap(err, "error in code communicator")
@@ -747,187 +1879,187 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryCapacity(ctx context.C
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentBatteryCapacity(ctx)
+	return c.deviceClassCommunicator.GetServerComponentSwapUsed(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentBatteryCurrent(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHardwareHealthComponentEnvironmentMonitorState(ctx context.Context) (device.HardwareHealthComponentState, error) {
+	if !c.HasComponent(component.HardwareHealth) {
+		return "", tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentBatteryCurrent(ctx)
+		res, err := c.codeCommunicator.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentBatteryCurrent(ctx)
+	return c.deviceClassCommunicator.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentBatteryRemainingTime(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHardwareHealthComponentFans(ctx context.Context) ([]device.HardwareHealthComponentFan, error) {
+	if !c.HasComponent(component.HardwareHealth) {
+		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentBatteryRemainingTime(ctx)
+		res, err := c.codeCommunicator.GetHardwareHealthComponentFans(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentBatteryRemainingTime(ctx)
+	return c.deviceClassCommunicator.GetHardwareHealthComponentFans(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentBatteryTemperature(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHardwareHealthComponentPowerSupply(ctx context.Context) ([]device.HardwareHealthComponentPowerSupply, error) {
+	if !c.HasComponent(component.HardwareHealth) {
+		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentBatteryTemperature(ctx)
+		res, err := c.codeCommunicator.GetHardwareHealthComponentPowerSupply(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentBatteryTemperature(ctx)
+	return c.deviceClassCommunicator.GetHardwareHealthComponentPowerSupply(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentBatteryVoltage(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHardwareHealthComponentTemperature(ctx context.Context) ([]device.HardwareHealthComponentTemperature, error) {
+	if !c.HasComponent(component.HardwareHealth) {
+		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentBatteryVoltage(ctx)
+		res, err := c.codeCommunicator.GetHardwareHealthComponentTemperature(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentBatteryVoltage(ctx)
+	return c.deviceClassCommunicator.GetHardwareHealthComponentTemperature(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentCurrentLoad(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHardwareHealthComponentVoltage(ctx context.Context) ([]device.HardwareHealthComponentVoltage, error) {
+	if !c.HasComponent(component.HardwareHealth) {
+		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentCurrentLoad(ctx)
+		res, err := c.codeCommunicator.GetHardwareHealthComponentVoltage(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentCurrentLoad(ctx)
+	return c.deviceClassCommunicator.GetHardwareHealthComponentVoltage(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentMainsVoltageApplied(ctx context.Context) (bool, error) {
-	if !c.HasComponent(component.UPS) {
-		return false, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHardwareHealthComponentHumidity(ctx context.Context) ([]device.HardwareHealthComponentHumidity, error) {
+	if !c.HasComponent(component.HardwareHealth) {
+		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentMainsVoltageApplied(ctx)
+		res, err := c.codeCommunicator.GetHardwareHealthComponentHumidity(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return false, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentMainsVoltageApplied(ctx)
+	return c.deviceClassCommunicator.GetHardwareHealthComponentHumidity(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentRectifierCurrent(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHighAvailabilityComponentState(ctx context.Context) (device.HighAvailabilityComponentState, error) {
+	if !c.HasComponent(component.HighAvailability) {
+		return "", tholaerr.NewComponentNotFoundError("no ha component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentRectifierCurrent(ctx)
+		res, err := c.codeCommunicator.GetHighAvailabilityComponentState(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentRectifierCurrent(ctx)
+	return c.deviceClassCommunicator.GetHighAvailabilityComponentState(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetUPSComponentSystemVoltage(ctx context.Context) (float64, error) {
-	if !c.HasComponent(component.UPS) {
-		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
+func (c *networkDeviceCommunicator) GetHighAvailabilityComponentRole(ctx context.Context) (string, error) {
+	if !c.HasComponent(component.HighAvailability) {
+		return "", tholaerr.NewComponentNotFoundError("no ha component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetUPSComponentSystemVoltage(ctx)
+		res, err := c.codeCommunicator.GetHighAvailabilityComponentRole(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetUPSComponentSystemVoltage(ctx)
+	return c.deviceClassCommunicator.GetHighAvailabilityComponentRole(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentAgents(ctx context.Context) ([]device.SBCComponentAgent, error) {
-	if !c.HasComponent(component.SBC) {
-		return nil, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetHighAvailabilityComponentNodes(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.HighAvailability) {
+		return 0, tholaerr.NewComponentNotFoundError("no ha component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentAgents(ctx)
+		res, err := c.codeCommunicator.GetHighAvailabilityComponentNodes(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return nil, errors.Wrap(err, "error in code communicator")
+				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentAgents(ctx)
+	return c.deviceClassCommunicator.GetHighAvailabilityComponentNodes(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentRealms(ctx context.Context) ([]device.SBCComponentRealm, error) {
-	if !c.HasComponent(component.SBC) {
-		return nil, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetVLANComponentVLANs(ctx context.Context) ([]device.VLAN, error) {
+	if !c.HasComponent(component.VLAN) {
+		return nil, tholaerr.NewComponentNotFoundError("no vlan component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentRealms(ctx)
+		res, err := c.codeCommunicator.GetVLANComponentVLANs(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return nil, errors.Wrap(err, "error in code communicator")
@@ -937,168 +2069,164 @@ func (c *networkDeviceCommunicator) GetSBCComponentRealms(ctx context.Context) (
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentRealms(ctx)
+	return c.deviceClassCommunicator.GetVLANComponentVLANs(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentGlobalCallPerSecond(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetVLANComponentPortMembership(ctx context.Context) (map[int][]int, error) {
+	if !c.HasComponent(component.VLAN) {
+		return nil, tholaerr.NewComponentNotFoundError("no vlan component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentGlobalCallPerSecond(ctx)
+		res, err := c.codeCommunicator.GetVLANComponentPortMembership(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentGlobalCallPerSecond(ctx)
+	return c.deviceClassCommunicator.GetVLANComponentPortMembership(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentGlobalConcurrentSessions(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetPOEComponentPSEs(ctx context.Context) ([]device.POEComponentPSE, error) {
+	if !c.HasComponent(component.POE) {
+		return nil, tholaerr.NewComponentNotFoundError("no poe component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentGlobalConcurrentSessions(ctx)
+		res, err := c.codeCommunicator.GetPOEComponentPSEs(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentGlobalConcurrentSessions(ctx)
+	return c.deviceClassCommunicator.GetPOEComponentPSEs(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentActiveLocalContacts(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetPOEComponentPorts(ctx context.Context) ([]device.POEComponentPort, error) {
+	if !c.HasComponent(component.POE) {
+		return nil, tholaerr.NewComponentNotFoundError("no poe component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentActiveLocalContacts(ctx)
+		res, err := c.codeCommunicator.GetPOEComponentPorts(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentActiveLocalContacts(ctx)
+	return c.deviceClassCommunicator.GetPOEComponentPorts(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentTranscodingCapacity(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetEnvironmentComponentTemperature(ctx context.Context) ([]device.EnvironmentComponentTemperature, error) {
+	if !c.HasComponent(component.Environment) {
+		return nil, tholaerr.NewComponentNotFoundError("no environment component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentTranscodingCapacity(ctx)
+		res, err := c.codeCommunicator.GetEnvironmentComponentTemperature(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentTranscodingCapacity(ctx)
+	return c.deviceClassCommunicator.GetEnvironmentComponentTemperature(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentLicenseCapacity(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetEnvironmentComponentHumidity(ctx context.Context) ([]device.EnvironmentComponentHumidity, error) {
+	if !c.HasComponent(component.Environment) {
+		return nil, tholaerr.NewComponentNotFoundError("no environment component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentLicenseCapacity(ctx)
+		res, err := c.codeCommunicator.GetEnvironmentComponentHumidity(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentLicenseCapacity(ctx)
+	return c.deviceClassCommunicator.GetEnvironmentComponentHumidity(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentSystemRedundancy(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
+func (c *networkDeviceCommunicator) GetEnvironmentComponentDryContacts(ctx context.Context) ([]device.EnvironmentComponentDryContact, error) {
+	if !c.HasComponent(component.Environment) {
+		return nil, tholaerr.NewComponentNotFoundError("no environment component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentSystemRedundancy(ctx)
+		res, err := c.codeCommunicator.GetEnvironmentComponentDryContacts(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentSystemRedundancy(ctx)
+	return c.deviceClassCommunicator.GetEnvironmentComponentDryContacts(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetSBCComponentSystemHealthScore(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.SBC) {
-		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
-	}
-
+func (c *networkDeviceCommunicator) GetNeighbors(ctx context.Context) ([]device.Neighbor, error) {
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetSBCComponentSystemHealthScore(ctx)
+		res, err := c.codeCommunicator.GetNeighbors(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetSBCComponentSystemHealthScore(ctx)
+	return c.deviceClassCommunicator.GetNeighbors(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetServerComponentProcs(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.Server) {
-		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
+func (c *networkDeviceCommunicator) GetRoutingTable(ctx context.Context) ([]device.Route, error) {
+	if !c.HasComponent(component.Routes) {
+		return nil, tholaerr.NewComponentNotFoundError("no routes component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetServerComponentProcs(ctx)
+		res, err := c.codeCommunicator.GetRoutingTable(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetServerComponentProcs(ctx)
+	return c.deviceClassCommunicator.GetRoutingTable(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetServerComponentUsers(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.Server) {
-		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
+func (c *networkDeviceCommunicator) GetCountRoutes(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.Routes) {
+		return 0, tholaerr.NewComponentNotFoundError("no routes component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetServerComponentUsers(ctx)
+		res, err := c.codeCommunicator.GetCountRoutes(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return 0, errors.Wrap(err, "error in code communicator")
@@ -1108,35 +2236,35 @@ func (c *networkDeviceCommunicator) GetServerComponentUsers(ctx context.Context)
 		}
 	}
 
-	return c.deviceClassCommunicator.GetServerComponentUsers(ctx)
+	return c.deviceClassCommunicator.GetCountRoutes(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHardwareHealthComponentEnvironmentMonitorState(ctx context.Context) (device.HardwareHealthComponentState, error) {
-	if !c.HasComponent(component.HardwareHealth) {
-		return "", tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
+func (c *networkDeviceCommunicator) GetEntityComponent(ctx context.Context) (device.EntityComponent, error) {
+	if !c.HasComponent(component.Entity) {
+		return device.EntityComponent{}, tholaerr.NewComponentNotFoundError("no entity component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
+		res, err := c.codeCommunicator.GetEntityComponent(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
+				return device.EntityComponent{}, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
+	return c.deviceClassCommunicator.GetEntityComponent(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHardwareHealthComponentFans(ctx context.Context) ([]device.HardwareHealthComponentFan, error) {
-	if !c.HasComponent(component.HardwareHealth) {
-		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
+func (c *networkDeviceCommunicator) GetWLANComponentAccessPoints(ctx context.Context) ([]device.AccessPoint, error) {
+	if !c.HasComponent(component.WLAN) {
+		return nil, tholaerr.NewComponentNotFoundError("no wlan component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHardwareHealthComponentFans(ctx)
+		res, err := c.codeCommunicator.GetWLANComponentAccessPoints(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return nil, errors.Wrap(err, "error in code communicator")
@@ -1146,54 +2274,54 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentFans(ctx context.C
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHardwareHealthComponentFans(ctx)
+	return c.deviceClassCommunicator.GetWLANComponentAccessPoints(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHardwareHealthComponentPowerSupply(ctx context.Context) ([]device.HardwareHealthComponentPowerSupply, error) {
-	if !c.HasComponent(component.HardwareHealth) {
-		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
+func (c *networkDeviceCommunicator) GetWLANComponentClientCount(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.WLAN) {
+		return 0, tholaerr.NewComponentNotFoundError("no wlan component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHardwareHealthComponentPowerSupply(ctx)
+		res, err := c.codeCommunicator.GetWLANComponentClientCount(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return nil, errors.Wrap(err, "error in code communicator")
+				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHardwareHealthComponentPowerSupply(ctx)
+	return c.deviceClassCommunicator.GetWLANComponentClientCount(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHardwareHealthComponentTemperature(ctx context.Context) ([]device.HardwareHealthComponentTemperature, error) {
-	if !c.HasComponent(component.HardwareHealth) {
-		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
+func (c *networkDeviceCommunicator) GetFirewallComponentActiveSessions(ctx context.Context) (int, error) {
+	if !c.HasComponent(component.Firewall) {
+		return 0, tholaerr.NewComponentNotFoundError("no firewall component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHardwareHealthComponentTemperature(ctx)
+		res, err := c.codeCommunicator.GetFirewallComponentActiveSessions(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return nil, errors.Wrap(err, "error in code communicator")
+				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHardwareHealthComponentTemperature(ctx)
+	return c.deviceClassCommunicator.GetFirewallComponentActiveSessions(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHardwareHealthComponentVoltage(ctx context.Context) ([]device.HardwareHealthComponentVoltage, error) {
-	if !c.HasComponent(component.HardwareHealth) {
-		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
+func (c *networkDeviceCommunicator) GetFirewallComponentVPNTunnels(ctx context.Context) ([]device.FirewallVPNTunnel, error) {
+	if !c.HasComponent(component.Firewall) {
+		return nil, tholaerr.NewComponentNotFoundError("no firewall component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHardwareHealthComponentVoltage(ctx)
+		res, err := c.codeCommunicator.GetFirewallComponentVPNTunnels(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
 				return nil, errors.Wrap(err, "error in code communicator")
@@ -1203,62 +2331,53 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentVoltage(ctx contex
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHardwareHealthComponentVoltage(ctx)
+	return c.deviceClassCommunicator.GetFirewallComponentVPNTunnels(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHighAvailabilityComponentState(ctx context.Context) (device.HighAvailabilityComponentState, error) {
-	if !c.HasComponent(component.HighAvailability) {
-		return "", tholaerr.NewComponentNotFoundError("no ha component available for this device")
+func (c *networkDeviceCommunicator) GetFirewallComponentLicenses(ctx context.Context) ([]device.FirewallLicense, error) {
+	if !c.HasComponent(component.Firewall) {
+		return nil, tholaerr.NewComponentNotFoundError("no firewall component available for this device")
 	}
 
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHighAvailabilityComponentState(ctx)
+		res, err := c.codeCommunicator.GetFirewallComponentLicenses(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
+				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHighAvailabilityComponentState(ctx)
+	return c.deviceClassCommunicator.GetFirewallComponentLicenses(ctx)
 }
 
-func (c *networkDeviceCommunicator) GetHighAvailabilityComponentRole(ctx context.Context) (string, error) {
-	if !c.HasComponent(component.HighAvailability) {
-		return "", tholaerr.NewComponentNotFoundError("no ha component available for this device")
-	}
-
+func (c *networkDeviceCommunicator) GetConfigBackup(ctx context.Context, destination device.ConfigBackupDestination) (device.ConfigBackup, error) {
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHighAvailabilityComponentRole(ctx)
+		res, err := c.codeCommunicator.GetConfigBackup(ctx, destination)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
-				return "", errors.Wrap(err, "error in code communicator")
+				return device.ConfigBackup{}, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
 			return res, nil
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHighAvailabilityComponentRole(ctx)
+	return c.deviceClassCommunicator.GetConfigBackup(ctx, destination)
 }
 
-func (c *networkDeviceCommunicator) GetHighAvailabilityComponentNodes(ctx context.Context) (int, error) {
-	if !c.HasComponent(component.HighAvailability) {
-		return 0, tholaerr.NewComponentNotFoundError("no ha component available for this device")
-	}
-
+func (c *networkDeviceCommunicator) SetInterfaceAdminStatus(ctx context.Context, ifIndex uint64, up bool) error {
 	if c.codeCommunicator != nil {
-		res, err := c.codeCommunicator.GetHighAvailabilityComponentNodes(ctx)
-		if err != nil {
-			if !tholaerr.IsNotImplementedError(err) {
-				return 0, errors.Wrap(err, "error in code communicator")
-			}
-		} else {
-			return res, nil
+		err := c.codeCommunicator.SetInterfaceAdminStatus(ctx, ifIndex, up)
+		if err == nil {
+			return nil
+		}
+		if !tholaerr.IsNotImplementedError(err) {
+			return errors.Wrap(err, "error in code communicator")
 		}
 	}
 
-	return c.deviceClassCommunicator.GetHighAvailabilityComponentNodes(ctx)
+	return c.deviceClassCommunicator.SetInterfaceAdminStatus(ctx, ifIndex, up)
 }
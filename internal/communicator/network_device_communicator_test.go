@@ -0,0 +1,427 @@
+package communicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+type stubDeviceClassCommunicator struct {
+	Communicator
+	components map[component.Component]bool
+	cpuLoadErr error
+	interfaces []device.Interface
+	storages   []device.DiskComponentStorage
+	inverted   bool
+}
+
+func (s stubDeviceClassCommunicator) HasComponent(c component.Component) bool {
+	return s.components[c]
+}
+
+func (s stubDeviceClassCommunicator) HasInvertedInterfaceCounters() bool {
+	return s.inverted
+}
+
+func (s stubDeviceClassCommunicator) GetCPUComponentCPULoad(context.Context) ([]device.CPU, error) {
+	return nil, s.cpuLoadErr
+}
+
+func (s stubDeviceClassCommunicator) GetInterfaces(context.Context, ...groupproperty.Filter) ([]device.Interface, error) {
+	return s.interfaces, nil
+}
+
+func (s stubDeviceClassCommunicator) GetDiskComponentStorages(context.Context) ([]device.DiskComponentStorage, error) {
+	return s.storages, nil
+}
+
+type stubCodeCommunicator struct {
+	Functions
+	cpuLoadErr error
+}
+
+func (s stubCodeCommunicator) GetCPUComponentCPULoad(context.Context) ([]device.CPU, error) {
+	return nil, s.cpuLoadErr
+}
+
+func (s stubCodeCommunicator) GetDiskComponentStorages(context.Context) ([]device.DiskComponentStorage, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (s stubCodeCommunicator) GetInterfaces(context.Context, ...groupproperty.Filter) ([]device.Interface, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+type stubAugmentingCodeCommunicator struct {
+	stubCodeCommunicator
+}
+
+func (s stubAugmentingCodeCommunicator) AugmentInterfaces(_ context.Context, interfaces []device.Interface) ([]device.Interface, error) {
+	for i := range interfaces {
+		alias := "augmented"
+		interfaces[i].IfAlias = &alias
+	}
+	return interfaces, nil
+}
+
+func TestNetworkDeviceCommunicator_GetInterfaces_augmenter(t *testing.T) {
+	ifIndex1, ifIndex2 := uint64(1), uint64(2)
+	ifDescr1, ifDescr2 := "eth0", "lo"
+	classInterfaces := func() []device.Interface {
+		return []device.Interface{
+			{IfIndex: &ifIndex1, IfDescr: &ifDescr1},
+			{IfIndex: &ifIndex2, IfDescr: &ifDescr2},
+		}
+	}
+	components := map[component.Component]bool{component.Interfaces: true}
+
+	com := CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components, interfaces: classInterfaces()},
+		stubAugmentingCodeCommunicator{},
+		nil,
+	)
+	res, err := com.GetInterfaces(context.Background(), groupproperty.GetGroupFilter([]string{"ifDescr"}, "^lo$"))
+	if assert.NoError(t, err) && assert.Len(t, res, 1) {
+		assert.Equal(t, ifDescr1, *res[0].IfDescr)
+		if assert.NotNil(t, res[0].IfAlias) {
+			assert.Equal(t, "augmented", *res[0].IfAlias)
+		}
+	}
+
+	// sub device classes are augmented on top of their parent's interfaces
+	com = CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components},
+		stubAugmentingCodeCommunicator{},
+		stubDeviceClassCommunicator{components: components, interfaces: classInterfaces()},
+	)
+	res, err = com.GetInterfaces(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		for _, i := range res {
+			if assert.NotNil(t, i.IfAlias) {
+				assert.Equal(t, "augmented", *i.IfAlias)
+			}
+		}
+	}
+
+	// the augmenter isn't called if the enrichment is skipped, the filters are passed to the device class
+	com = CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components, interfaces: classInterfaces()},
+		stubAugmentingCodeCommunicator{},
+		nil,
+	)
+	res, err = com.GetInterfaces(NewContextWithSkipInterfaceEnrichment(context.Background(), true))
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		for _, i := range res {
+			assert.Nil(t, i.IfAlias)
+		}
+	}
+
+	// without augmenter the device class interfaces are returned unchanged
+	com = CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components, interfaces: classInterfaces()},
+		stubCodeCommunicator{},
+		nil,
+	)
+	res, err = com.GetInterfaces(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Nil(t, res[0].IfAlias)
+	}
+}
+
+func TestNetworkDeviceCommunicator_GetInterfaces_invertedCounters(t *testing.T) {
+	in, out := uint64(100), uint64(200)
+	hcIn, hcOut := uint64(1000), uint64(2000)
+	inErrors, outErrors := uint64(1), uint64(2)
+	classInterfaces := func() []device.Interface {
+		return []device.Interface{
+			{IfInOctets: &in, IfOutOctets: &out, IfHCInOctets: &hcIn, IfHCOutOctets: &hcOut, IfInErrors: &inErrors, IfOutErrors: &outErrors},
+			{IfInOctets: &in},
+		}
+	}
+	components := map[component.Component]bool{component.Interfaces: true}
+
+	com := CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components, interfaces: classInterfaces()},
+		stubCodeCommunicator{},
+		nil,
+	)
+	res, err := com.GetInterfaces(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Equal(t, in, *res[0].IfInOctets)
+		assert.Equal(t, out, *res[0].IfOutOctets)
+		assert.Equal(t, in, *res[1].IfInOctets)
+		assert.Nil(t, res[1].IfOutOctets)
+	}
+
+	com = CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components, interfaces: classInterfaces(), inverted: true},
+		stubCodeCommunicator{},
+		nil,
+	)
+	res, err = com.GetInterfaces(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Equal(t, out, *res[0].IfInOctets)
+		assert.Equal(t, in, *res[0].IfOutOctets)
+		assert.Equal(t, hcOut, *res[0].IfHCInOctets)
+		assert.Equal(t, hcIn, *res[0].IfHCOutOctets)
+		assert.Equal(t, outErrors, *res[0].IfInErrors)
+		assert.Equal(t, inErrors, *res[0].IfOutErrors)
+		assert.Nil(t, res[1].IfInOctets)
+		assert.Equal(t, in, *res[1].IfOutOctets)
+	}
+}
+
+func TestNetworkDeviceCommunicator_GetInterfaces_aggregations(t *testing.T) {
+	ifIndex1, ifIndex2, ifIndex3, ifIndex4 := uint64(1), uint64(2), uint64(3), uint64(4)
+	aggregator, self := 3, 4
+	components := map[component.Component]bool{component.Interfaces: true}
+
+	com := CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{components: components, interfaces: []device.Interface{
+			{IfIndex: &ifIndex1, AggregationID: &aggregator},
+			{IfIndex: &ifIndex2, AggregationID: &aggregator},
+			{IfIndex: &ifIndex3},
+			{IfIndex: &ifIndex4, AggregationID: &self},
+		}},
+		nil,
+		nil,
+	)
+	res, err := com.GetInterfaces(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, res, 4) {
+		assert.False(t, res[0].IsAggregator)
+		assert.True(t, res[2].IsAggregator)
+		assert.Nil(t, res[3].AggregationID)
+		assert.False(t, res[3].IsAggregator)
+		assert.Equal(t, map[uint64][]uint64{3: {1, 2}}, device.GroupAggregations(res))
+	}
+}
+
+func TestNetworkDeviceCommunicator_GetDiskComponentStorages_filter(t *testing.T) {
+	descriptions := []string{"/", "/boot", "/dev/shm", "Virtual Memory", "Physical Memory"}
+	var storages []device.DiskComponentStorage
+	for i := range descriptions {
+		storages = append(storages, device.DiskComponentStorage{Description: &descriptions[i]})
+	}
+	storages = append(storages, device.DiskComponentStorage{})
+
+	com := CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{
+			components: map[component.Component]bool{component.Disk: true},
+			storages:   storages,
+		},
+		stubCodeCommunicator{},
+		nil,
+	)
+
+	cases := map[string]struct {
+		filter   DiskStorageFilter
+		expected []string
+	}{
+		"include only": {
+			filter:   DiskStorageFilter{Include: []*regexp.Regexp{regexp.MustCompile("^/")}},
+			expected: []string{"/", "/boot", "/dev/shm"},
+		},
+		"exclude only": {
+			filter:   DiskStorageFilter{Exclude: []*regexp.Regexp{regexp.MustCompile("Memory$"), regexp.MustCompile("^/dev")}},
+			expected: []string{"/", "/boot", ""},
+		},
+		"include and exclude": {
+			filter: DiskStorageFilter{
+				Include: []*regexp.Regexp{regexp.MustCompile("^/")},
+				Exclude: []*regexp.Regexp{regexp.MustCompile("^/dev")},
+			},
+			expected: []string{"/", "/boot"},
+		},
+	}
+
+	for name, c := range cases {
+		res, err := com.GetDiskComponentStorages(NewContextWithDiskStorageFilter(context.Background(), c.filter))
+		if assert.NoError(t, err, name) {
+			var actual []string
+			for _, storage := range res {
+				if storage.Description == nil {
+					actual = append(actual, "")
+				} else {
+					actual = append(actual, *storage.Description)
+				}
+			}
+			assert.Equal(t, c.expected, actual, name)
+		}
+	}
+
+	// without a filter all storages are returned
+	res, err := com.GetDiskComponentStorages(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, storages, res)
+	}
+}
+
+func TestNetworkDeviceCommunicator_errorCodes(t *testing.T) {
+	cases := map[string]struct {
+		components        map[component.Component]bool
+		codeErr, classErr error
+		expected          tholaerr.ErrorCode
+	}{
+		"component not available": {
+			expected: tholaerr.ErrorCodeComponentNotFound,
+		},
+		"not implemented in any communicator": {
+			components: map[component.Component]bool{component.CPU: true},
+			codeErr:    tholaerr.NewNotImplementedError("function is not implemented for this communicator"),
+			classErr:   tholaerr.NewNotImplementedError("no detection is implemented for cpu load"),
+			expected:   tholaerr.ErrorCodeNotImplemented,
+		},
+		"snmp timeout in device class": {
+			components: map[component.Component]bool{component.CPU: true},
+			codeErr:    tholaerr.NewNotImplementedError("function is not implemented for this communicator"),
+			classErr:   errors.Wrap(tholaerr.NewSNMPTimeoutError("request timeout (after 1 retries)"), "error during snmpget"),
+			expected:   tholaerr.ErrorCodeSNMPTimeout,
+		},
+		"parse error in code communicator": {
+			components: map[component.Component]bool{component.CPU: true},
+			codeErr:    tholaerr.NewParseError("strconv.ParseFloat: parsing \"n/a\": invalid syntax"),
+			expected:   tholaerr.ErrorCodeParseError,
+		},
+		"auth failure in code communicator": {
+			components: map[component.Component]bool{component.CPU: true},
+			codeErr:    tholaerr.NewAuthError("snmpv3 authentication failed: wrong digest"),
+			expected:   tholaerr.ErrorCodeAuthFailed,
+		},
+	}
+
+	for name, c := range cases {
+		com := CreateNetworkDeviceCommunicator(
+			stubDeviceClassCommunicator{components: c.components, cpuLoadErr: c.classErr},
+			stubCodeCommunicator{cpuLoadErr: c.codeErr},
+			nil,
+		)
+		_, err := com.GetCPUComponentCPULoad(context.Background())
+		if assert.Error(t, err, name) {
+			assert.Equal(t, c.expected, tholaerr.Code(errors.Wrap(err, "failed to get cpu load")), name)
+		}
+	}
+}
+
+func TestNetworkDeviceCommunicator_GetInterfaces_parents(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPWalk", ctx, ifStackStatusOID).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.31.1.2.1.3.0.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.31.1.2.1.3.1.0", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.31.1.2.1.3.100.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.31.1.2.1.3.200.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.31.1.2.1.3.300.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.31.1.2.1.3.300.2", gosnmp.Integer, 1),
+		}, nil)
+
+	ifIndex1, ifIndex2, ifIndex100, ifIndex200, ifIndex300 := uint64(1), uint64(2), uint64(100), uint64(200), uint64(300)
+	com := CreateNetworkDeviceCommunicator(
+		stubDeviceClassCommunicator{
+			components: map[component.Component]bool{component.Interfaces: true},
+			interfaces: []device.Interface{
+				{IfIndex: &ifIndex1},
+				{IfIndex: &ifIndex2},
+				{IfIndex: &ifIndex100},
+				{IfIndex: &ifIndex200},
+				{IfIndex: &ifIndex300},
+			},
+		},
+		nil,
+		nil,
+	)
+	res, err := com.GetInterfaces(ctx)
+	if assert.NoError(t, err) && assert.Len(t, res, 5) {
+		assert.Nil(t, res[0].ParentIfIndex)
+		assert.Nil(t, res[1].ParentIfIndex)
+		assert.Equal(t, &ifIndex1, res[2].ParentIfIndex)
+		assert.Equal(t, &ifIndex1, res[3].ParentIfIndex)
+		assert.Nil(t, res[4].ParentIfIndex, "interfaces stacked on several interfaces have no parent")
+	}
+}
+
+type emptyOnceDeviceClassCommunicator struct {
+	Communicator
+	calls int
+}
+
+func (s *emptyOnceDeviceClassCommunicator) GetVendor(context.Context) (string, error) {
+	s.calls++
+	if s.calls == 1 {
+		return "", tholaerr.NewEmptyValueError("vendor is empty")
+	}
+	return "Mikrotik", nil
+}
+
+func (s *emptyOnceDeviceClassCommunicator) GetModel(context.Context) (string, error) {
+	s.calls++
+	return "CHR", nil
+}
+
+func TestNetworkDeviceCommunicator_GetVendor_emptyValueRetries(t *testing.T) {
+	retries, delay := 2, 1
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				EmptyValueRetries:    &retries,
+				EmptyValueRetryDelay: &delay,
+			},
+		},
+	})
+
+	classCommunicator := emptyOnceDeviceClassCommunicator{}
+	vendor, err := CreateNetworkDeviceCommunicator(&classCommunicator, nil, nil).GetVendor(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Mikrotik", vendor)
+		assert.Equal(t, 2, classCommunicator.calls)
+	}
+
+	// non-empty values are returned immediately
+	classCommunicator = emptyOnceDeviceClassCommunicator{}
+	model, err := CreateNetworkDeviceCommunicator(&classCommunicator, nil, nil).GetModel(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "CHR", model)
+		assert.Equal(t, 1, classCommunicator.calls)
+	}
+
+	// without retries set for the connection, empty values are only read once
+	classCommunicator = emptyOnceDeviceClassCommunicator{}
+	_, err = CreateNetworkDeviceCommunicator(&classCommunicator, nil, nil).GetVendor(context.Background())
+	assert.True(t, tholaerr.IsEmptyValueError(err))
+	assert.True(t, tholaerr.IsNotFoundError(err))
+	assert.Equal(t, 1, classCommunicator.calls)
+
+	// the retries are capped
+	retries = 1000
+	calls := 0
+	_, err = retryEmptyValue(ctx, func(context.Context) (string, error) {
+		calls++
+		return "", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, maxEmptyValueRetries+1, calls)
+}
+
+func TestSumAccessPointClientCounts(t *testing.T) {
+	five, seven := 5, 7
+	sum := sumAccessPointClientCounts([]device.AccessPoint{{ClientCount: &five}, {}, {ClientCount: &seven}})
+	if assert.NotNil(t, sum) {
+		assert.Equal(t, 12, *sum)
+	}
+
+	assert.Nil(t, sumAccessPointClientCounts([]device.AccessPoint{{}, {}}))
+	assert.Nil(t, sumAccessPointClientCounts(nil))
+}
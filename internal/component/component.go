@@ -19,6 +19,13 @@ const (
 	Disk
 	HardwareHealth
 	HighAvailability
+	VLAN
+	POE
+	Routes
+	Environment
+	Entity
+	WLAN
+	Firewall
 )
 
 // CreateComponent creates a component.
@@ -42,6 +49,20 @@ func CreateComponent(component string) (Component, error) {
 		return HardwareHealth, nil
 	case "high_availability":
 		return HighAvailability, nil
+	case "vlan":
+		return VLAN, nil
+	case "poe":
+		return POE, nil
+	case "routes":
+		return Routes, nil
+	case "environment":
+		return Environment, nil
+	case "entity":
+		return Entity, nil
+	case "wlan":
+		return WLAN, nil
+	case "firewall":
+		return Firewall, nil
 	default:
 		return 0, fmt.Errorf("invalid component type: %s", component)
 	}
@@ -71,6 +92,20 @@ func (d *Component) ToString() (string, error) {
 		return "hardware_health", nil
 	case HighAvailability:
 		return "high_availability", nil
+	case VLAN:
+		return "vlan", nil
+	case POE:
+		return "poe", nil
+	case Routes:
+		return "routes", nil
+	case Environment:
+		return "environment", nil
+	case Entity:
+		return "entity", nil
+	case WLAN:
+		return "wlan", nil
+	case Firewall:
+		return "firewall", nil
 	default:
 		return "", errors.New("unknown component")
 	}
@@ -2,9 +2,14 @@ package device
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type ctxKey int
@@ -69,6 +74,81 @@ type Properties struct {
 	//
 	// example: 6.44.6
 	OSVersion *string `yaml:"os_version" json:"os_version" xml:"os_version"`
+	// FirmwareVersion of the device, if it is versioned separately from the os.
+	//
+	// example: 2.1.4
+	FirmwareVersion *string `yaml:"firmware_version" json:"firmware_version" xml:"firmware_version"`
+	// BootloaderVersion of the device, e.g. the version of the ROMMON or U-Boot.
+	//
+	// example: 15.1(1r)S
+	BootloaderVersion *string `yaml:"bootloader_version" json:"bootloader_version" xml:"bootloader_version"`
+	// SysName of the device (SNMPv2-MIB::sysName).
+	//
+	// example: core-router-1
+	SysName *string `yaml:"sys_name" json:"sys_name" xml:"sys_name"`
+	// SysContact of the device (SNMPv2-MIB::sysContact).
+	//
+	// example: noc@example.com
+	SysContact *string `yaml:"sys_contact" json:"sys_contact" xml:"sys_contact"`
+	// SysLocation of the device (SNMPv2-MIB::sysLocation).
+	//
+	// example: Rack 4, Room 2
+	SysLocation *string `yaml:"sys_location" json:"sys_location" xml:"sys_location"`
+	// SysDescr of the device (SNMPv2-MIB::sysDescr).
+	//
+	// example: RouterOS CHR
+	SysDescr *string `yaml:"sys_descr" json:"sys_descr" xml:"sys_descr"`
+}
+
+// IdentifyProperties are the names of all identify properties in the order they are read by default.
+var IdentifyProperties = []string{"vendor", "model", "model_series", "serial_number", "os_version", "firmware_version", "bootloader_version", "sys_name", "sys_contact", "sys_location", "sys_descr"}
+
+// identifyProperty returns a pointer to the field of the identify property with the given name.
+func (p *Properties) identifyProperty(name string) (**string, error) {
+	switch name {
+	case "vendor":
+		return &p.Vendor, nil
+	case "model":
+		return &p.Model, nil
+	case "model_series":
+		return &p.ModelSeries, nil
+	case "serial_number":
+		return &p.SerialNumber, nil
+	case "os_version":
+		return &p.OSVersion, nil
+	case "firmware_version":
+		return &p.FirmwareVersion, nil
+	case "bootloader_version":
+		return &p.BootloaderVersion, nil
+	case "sys_name":
+		return &p.SysName, nil
+	case "sys_contact":
+		return &p.SysContact, nil
+	case "sys_location":
+		return &p.SysLocation, nil
+	case "sys_descr":
+		return &p.SysDescr, nil
+	}
+	return nil, fmt.Errorf("unknown identify property '%s'", name)
+}
+
+// GetIdentifyProperty returns the identify property with the given name, nil if it is not set.
+func (p *Properties) GetIdentifyProperty(name string) (*string, error) {
+	field, err := p.identifyProperty(name)
+	if err != nil {
+		return nil, err
+	}
+	return *field, nil
+}
+
+// SetIdentifyProperty sets the identify property with the given name.
+func (p *Properties) SetIdentifyProperty(name, value string) error {
+	field, err := p.identifyProperty(name)
+	if err != nil {
+		return err
+	}
+	*field = &value
+	return nil
 }
 
 // Interface
@@ -80,6 +160,7 @@ type Interface struct {
 	IfIndex              *uint64 `yaml:"ifIndex" json:"ifIndex" xml:"ifIndex" mapstructure:"ifIndex"`
 	IfDescr              *string `yaml:"ifDescr" json:"ifDescr" xml:"ifDescr" mapstructure:"ifDescr"`
 	IfType               *string `yaml:"ifType" json:"ifType" xml:"ifType" mapstructure:"ifType"`
+	IfTypeName           *string `yaml:"ifTypeName" json:"ifTypeName" xml:"ifTypeName" mapstructure:"ifTypeName"`
 	IfMtu                *uint64 `yaml:"ifMtu" json:"ifMtu" xml:"ifMtu" mapstructure:"ifMtu"`
 	IfSpeed              *uint64 `yaml:"ifSpeed" json:"ifSpeed" xml:"ifSpeed" mapstructure:"ifSpeed"`
 	IfPhysAddress        *string `yaml:"ifPhysAddress" json:"ifPhysAddress" xml:"ifPhysAddress" mapstructure:"ifPhysAddress"`
@@ -119,6 +200,19 @@ type Interface struct {
 	MaxSpeedIn  *uint64 `yaml:"max_speed_in" json:"max_speed_in" xml:"max_speed_in" mapstructure:"max_speed_in"`
 	MaxSpeedOut *uint64 `yaml:"max_speed_out" json:"max_speed_out" xml:"max_speed_out" mapstructure:"max_speed_out"`
 
+	// MACAddress is the ifPhysAddress, if it is a MAC address.
+	MACAddress *string `yaml:"mac_address" json:"mac_address" xml:"mac_address" mapstructure:"mac_address"`
+
+	// CountersAre64Bit is true if the octet counters contain the values of the 64-bit high capacity counters.
+	CountersAre64Bit bool `yaml:"counters_are_64_bit" json:"counters_are_64_bit" xml:"counters_are_64_bit" mapstructure:"counters_are_64_bit"`
+
+	// AggregationID is the ifIndex of the aggregator (e.g. a port-channel) the interface is a member of.
+	AggregationID *int `yaml:"aggregation_id,omitempty" json:"aggregation_id,omitempty" xml:"aggregation_id,omitempty" mapstructure:"aggregation_id"`
+	// IsAggregator is true if the interface aggregates other interfaces.
+	IsAggregator bool `yaml:"is_aggregator,omitempty" json:"is_aggregator,omitempty" xml:"is_aggregator,omitempty" mapstructure:"is_aggregator"`
+	// ParentIfIndex is the ifIndex of the interface a subinterface is stacked on, derived from the ifStackTable.
+	ParentIfIndex *uint64 `yaml:"parent_if_index,omitempty" json:"parent_if_index,omitempty" xml:"parent_if_index,omitempty" mapstructure:"parent_if_index"`
+
 	// SubType is not set per default and cannot be read out through a device class.
 	// It is used to internally specify a port type, without changing the actual ifType.
 	SubType *string `yaml:"-" json:"-" xml:"-"`
@@ -131,6 +225,13 @@ type Interface struct {
 	OpticalOPM         *OpticalOPMInterface         `yaml:"optical_opm,omitempty" json:"optical_opm,omitempty" xml:"optical_opm,omitempty" mapstructure:"optical_opm,omitempty"`
 	SAP                *SAPInterface                `yaml:"sap,omitempty" json:"sap,omitempty" xml:"sap,omitempty" mapstructure:"sap,omitempty"`
 	VLAN               *VLANInformation             `yaml:"vlan,omitempty" json:"vlan,omitempty" xml:"vlan,omitempty" mapstructure:"vlan,omitempty"`
+
+	// IPAddresses is empty if the addresses were read, but none is bound to the interface.
+	IPAddresses []IPAddress `yaml:"ip_addresses,omitempty" json:"ip_addresses,omitempty" xml:"ip_addresses,omitempty" mapstructure:"ip_addresses,omitempty"`
+
+	// IPv4Stats and IPv6Stats are nil if the device doesn't provide the ipIfStatsTable for the address family.
+	IPv4Stats *InterfaceIPStats `yaml:"ipv4_stats,omitempty" json:"ipv4_stats,omitempty" xml:"ipv4_stats,omitempty" mapstructure:"ipv4_stats,omitempty"`
+	IPv6Stats *InterfaceIPStats `yaml:"ipv6_stats,omitempty" json:"ipv6_stats,omitempty" xml:"ipv6_stats,omitempty" mapstructure:"ipv6_stats,omitempty"`
 }
 
 //
@@ -264,6 +365,11 @@ type OpticalChannel struct {
 type SAPInterface struct {
 	Inbound  *uint64 `yaml:"inbound" json:"inbound" xml:"inbound" mapstructure:"inbound"`
 	Outbound *uint64 `yaml:"outbound" json:"outbound" xml:"outbound" mapstructure:"outbound"`
+	// AdminStatus and OperStatus are not set if the device doesn't report a status for the SAP.
+	AdminStatus *Status `yaml:"admin_status,omitempty" json:"admin_status,omitempty" xml:"admin_status,omitempty" mapstructure:"admin_status,omitempty"`
+	OperStatus  *Status `yaml:"oper_status,omitempty" json:"oper_status,omitempty" xml:"oper_status,omitempty" mapstructure:"oper_status,omitempty"`
+	// Error is set if not all counters of the SAP could be read, the successfully read counters are still set.
+	Error *string `yaml:"error,omitempty" json:"error,omitempty" xml:"error,omitempty" mapstructure:"error,omitempty"`
 }
 
 // VLANInformation
@@ -273,6 +379,9 @@ type SAPInterface struct {
 // swagger:model
 type VLANInformation struct {
 	VLANs []VLAN `yaml:"vlans" json:"vlans" xml:"vlans" mapstructure:"vlans"`
+	// UntaggedVLANs and TaggedVLANs contain the IDs of the VLANs the interface is an untagged or tagged member of.
+	UntaggedVLANs []int `yaml:"untagged_vlans,omitempty" json:"untagged_vlans,omitempty" xml:"untagged_vlans,omitempty" mapstructure:"untagged_vlans,omitempty"`
+	TaggedVLANs   []int `yaml:"tagged_vlans,omitempty" json:"tagged_vlans,omitempty" xml:"tagged_vlans,omitempty" mapstructure:"tagged_vlans,omitempty"`
 }
 
 // VLAN
@@ -281,14 +390,149 @@ type VLANInformation struct {
 //
 // swagger:model
 type VLAN struct {
+	ID     *int    `yaml:"id" json:"id" xml:"id" mapstructure:"id"`
 	Name   *string `yaml:"name" json:"name" xml:"name" mapstructure:"name"`
 	Status *string `yaml:"status" json:"status" xml:"status" mapstructure:"status"`
 }
 
+// IPAddress
+//
+// IPAddress represents an IPv4 or IPv6 address bound to an interface.
+//
+// swagger:model
+type IPAddress struct {
+	Address string `yaml:"address" json:"address" xml:"address" mapstructure:"address"`
+	// PrefixLength is 0 if the device doesn't report the prefix of the address.
+	PrefixLength int  `yaml:"prefix_length" json:"prefix_length" xml:"prefix_length" mapstructure:"prefix_length"`
+	LinkLocal    bool `yaml:"link_local" json:"link_local" xml:"link_local" mapstructure:"link_local"`
+}
+
+// InterfaceIPStats
+//
+// InterfaceIPStats represents the IP traffic counters of an interface for one address family.
+//
+// swagger:model
+type InterfaceIPStats struct {
+	InOctets   *uint64 `yaml:"in_octets,omitempty" json:"in_octets,omitempty" xml:"in_octets,omitempty" mapstructure:"in_octets"`
+	OutOctets  *uint64 `yaml:"out_octets,omitempty" json:"out_octets,omitempty" xml:"out_octets,omitempty" mapstructure:"out_octets"`
+	InPackets  *uint64 `yaml:"in_packets,omitempty" json:"in_packets,omitempty" xml:"in_packets,omitempty" mapstructure:"in_packets"`
+	OutPackets *uint64 `yaml:"out_packets,omitempty" json:"out_packets,omitempty" xml:"out_packets,omitempty" mapstructure:"out_packets"`
+}
+
+// Neighbor
+//
+// Neighbor represents a neighbor device discovered via LLDP or CDP.
+//
+// swagger:model
+type Neighbor struct {
+	LocalInterface  *string `yaml:"local_interface" json:"local_interface" xml:"local_interface" mapstructure:"local_interface"`
+	RemoteHostname  *string `yaml:"remote_hostname" json:"remote_hostname" xml:"remote_hostname" mapstructure:"remote_hostname"`
+	RemotePort      *string `yaml:"remote_port" json:"remote_port" xml:"remote_port" mapstructure:"remote_port"`
+	RemoteChassisID *string `yaml:"remote_chassis_id" json:"remote_chassis_id" xml:"remote_chassis_id" mapstructure:"remote_chassis_id"`
+	Protocol        *string `yaml:"protocol" json:"protocol" xml:"protocol" mapstructure:"protocol"`
+}
+
+// Route
+//
+// Route represents one entry of the routing table of a device.
+//
+// swagger:model
+type Route struct {
+	Destination  *string `yaml:"destination" json:"destination" xml:"destination" mapstructure:"destination"`
+	PrefixLength *int    `yaml:"prefix_length" json:"prefix_length" xml:"prefix_length" mapstructure:"prefix_length"`
+	NextHop      *string `yaml:"next_hop" json:"next_hop" xml:"next_hop" mapstructure:"next_hop"`
+	IfIndex      *uint64 `yaml:"ifIndex" json:"ifIndex" xml:"ifIndex" mapstructure:"ifIndex"`
+	Type         *string `yaml:"type" json:"type" xml:"type" mapstructure:"type"`
+	Protocol     *string `yaml:"protocol" json:"protocol" xml:"protocol" mapstructure:"protocol"`
+	Metric       *int    `yaml:"metric" json:"metric" xml:"metric" mapstructure:"metric"`
+}
+
+// ConfigBackupDestination
+//
+// ConfigBackupDestination is the server to which the configuration of a device is copied.
+//
+// swagger:model
+type ConfigBackupDestination struct {
+	// The protocol which is used to copy the configuration.
+	//
+	// enum: tftp,ftp,rcp,scp,sftp
+	// example: tftp
+	Protocol string `yaml:"protocol" json:"protocol" xml:"protocol"`
+
+	// The ip address of the server.
+	//
+	// example: 192.0.2.10
+	Server string `yaml:"server" json:"server" xml:"server"`
+
+	// The path of the file on the server.
+	//
+	// example: backups/router1.cfg
+	Path string `yaml:"path" json:"path" xml:"path"`
+
+	// The username for the server, if the protocol needs one.
+	Username string `yaml:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+
+	// The password for the server, if the protocol needs one.
+	Password string `yaml:"password,omitempty" json:"password,omitempty" xml:"password,omitempty"`
+}
+
+// String returns the destination as url without credentials.
+func (d ConfigBackupDestination) String() string {
+	server := d.Server
+	if strings.Contains(server, ":") {
+		// ipv6 address
+		server = "[" + server + "]"
+	}
+	return d.Protocol + "://" + server + "/" + strings.TrimPrefix(d.Path, "/")
+}
+
+// ConfigBackup
+//
+// ConfigBackup is the result of copying the configuration of a device to a server.
+//
+// swagger:model
+type ConfigBackup struct {
+	// Whether the configuration was copied successfully.
+	Success bool `yaml:"success" json:"success" xml:"success"`
+
+	// The destination to which the configuration was copied.
+	//
+	// example: tftp://192.0.2.10/backups/router1.cfg
+	Destination string `yaml:"destination" json:"destination" xml:"destination"`
+
+	// The cause of the failure reported by the device.
+	FailCause *string `yaml:"fail_cause,omitempty" json:"fail_cause,omitempty" xml:"fail_cause,omitempty"`
+}
+
 //
 // Special device components are defined here.
 //
 
+// Components
+//
+// Components contains all components of a device.
+// Components which are not available for the device or couldn't be read are empty.
+//
+// swagger:model
+type Components struct {
+	Interfaces       []Interface                `yaml:"interfaces,omitempty" json:"interfaces,omitempty" xml:"interfaces,omitempty"`
+	CPU              *CPUComponent              `yaml:"cpu,omitempty" json:"cpu,omitempty" xml:"cpu,omitempty"`
+	Memory           *MemoryComponent           `yaml:"memory,omitempty" json:"memory,omitempty" xml:"memory,omitempty"`
+	Disk             *DiskComponent             `yaml:"disk,omitempty" json:"disk,omitempty" xml:"disk,omitempty"`
+	UPS              *UPSComponent              `yaml:"ups,omitempty" json:"ups,omitempty" xml:"ups,omitempty"`
+	Server           *ServerComponent           `yaml:"server,omitempty" json:"server,omitempty" xml:"server,omitempty"`
+	SBC              *SBCComponent              `yaml:"sbc,omitempty" json:"sbc,omitempty" xml:"sbc,omitempty"`
+	HardwareHealth   *HardwareHealthComponent   `yaml:"hardware_health,omitempty" json:"hardware_health,omitempty" xml:"hardware_health,omitempty"`
+	HighAvailability *HighAvailabilityComponent `yaml:"high_availability,omitempty" json:"high_availability,omitempty" xml:"high_availability,omitempty"`
+	VLAN             *VLANComponent             `yaml:"vlan,omitempty" json:"vlan,omitempty" xml:"vlan,omitempty"`
+	POE              *POEComponent              `yaml:"poe,omitempty" json:"poe,omitempty" xml:"poe,omitempty"`
+	Routes           []Route                    `yaml:"routes,omitempty" json:"routes,omitempty" xml:"routes,omitempty"`
+	Environment      *EnvironmentComponent      `yaml:"environment,omitempty" json:"environment,omitempty" xml:"environment,omitempty"`
+	Entity           *EntityComponent           `yaml:"entity,omitempty" json:"entity,omitempty" xml:"entity,omitempty"`
+	WLAN             *WLANComponent             `yaml:"wlan,omitempty" json:"wlan,omitempty" xml:"wlan,omitempty"`
+	Firewall         *FirewallComponent         `yaml:"firewall,omitempty" json:"firewall,omitempty" xml:"firewall,omitempty"`
+}
+
 // CPUComponent
 //
 // CPUComponent represents a CPU component.
@@ -308,6 +552,17 @@ type CPU struct {
 	Load  *float64 `yaml:"load" json:"load" xml:"load" mapstructure:"load"`
 }
 
+// CPUCore
+//
+// CPUCore contains the load and temperature of a single core or CPU.
+//
+// swagger:model
+type CPUCore struct {
+	Description string   `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	Load        *float64 `yaml:"load" json:"load" xml:"load" mapstructure:"load"`
+	Temperature *float64 `yaml:"temperature" json:"temperature" xml:"temperature" mapstructure:"temperature"`
+}
+
 // MemoryComponent
 //
 // MemoryComponent represents a Memory component
@@ -343,10 +598,18 @@ type DiskComponent struct {
 //
 // swagger:model
 type DiskComponentStorage struct {
-	Type        *string `yaml:"type" json:"type" xml:"type" mapstructure:"type"`
-	Description *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
-	Available   *uint64 `yaml:"available" json:"available" xml:"available" mapstructure:"available"`
-	Used        *uint64 `yaml:"used" json:"used" xml:"used" mapstructure:"used"`
+	Type            *string `yaml:"type" json:"type" xml:"type" mapstructure:"type"`
+	Description     *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	Available       *uint64 `yaml:"available" json:"available" xml:"available" mapstructure:"available"`
+	Used            *uint64 `yaml:"used" json:"used" xml:"used" mapstructure:"used"`
+	StorageType     *string `yaml:"storage_type" json:"storage_type" xml:"storage_type" mapstructure:"storage_type"`
+	AllocationUnits *uint64 `yaml:"allocation_units" json:"allocation_units" xml:"allocation_units" mapstructure:"allocation_units"`
+	TotalBytes      *uint64 `yaml:"total_bytes" json:"total_bytes" xml:"total_bytes" mapstructure:"total_bytes"`
+	UsedBytes       *uint64 `yaml:"used_bytes" json:"used_bytes" xml:"used_bytes" mapstructure:"used_bytes"`
+	// UsedPercent is the used space in percent of the size of the storage.
+	UsedPercent *float64 `yaml:"used_percent" json:"used_percent" xml:"used_percent" mapstructure:"used_percent"`
+	// Virtual is set for storages which are not backed by a disk, like RAM or virtual memory.
+	Virtual *bool `yaml:"virtual" json:"virtual" xml:"virtual" mapstructure:"virtual"`
 }
 
 // UPSComponent
@@ -366,6 +629,28 @@ type UPSComponent struct {
 	MainsVoltageApplied       *bool    `yaml:"mains_voltage_applied" json:"mains_voltage_applied" xml:"mains_voltage_applied" mapstructure:"mains_voltage_applied"`
 	RectifierCurrent          *float64 `yaml:"rectifier_current" json:"rectifier_current" xml:"rectifier_current" mapstructure:"rectifier_current"`
 	SystemVoltage             *float64 `yaml:"system_voltage" json:"system_voltage" xml:"system_voltage" mapstructure:"system_voltage"`
+	// SelfTestResult is the result of the last self-test, e.g. "donePass" or "noTestsInitiated".
+	SelfTestResult *string `yaml:"self_test_result" json:"self_test_result" xml:"self_test_result" mapstructure:"self_test_result"`
+	// LastTestDate is the date of the last self-test in RFC 3339 format.
+	LastTestDate *string `yaml:"last_test_date" json:"last_test_date" xml:"last_test_date" mapstructure:"last_test_date"`
+	// BatteryStrings are the battery strings of the ups. A ups with a single battery string has one entry.
+	BatteryStrings []UPSBatteryString `yaml:"battery_strings" json:"battery_strings" xml:"battery_strings" mapstructure:"battery_strings"`
+}
+
+// UPSBatteryString
+//
+// UPSBatteryString represents a battery string of a ups.
+//
+// swagger:model
+type UPSBatteryString struct {
+	// Index is the index of the battery string, starting at 1.
+	Index int `yaml:"index" json:"index" xml:"index" mapstructure:"index"`
+	// Voltage is the voltage of the battery string in volts.
+	Voltage *float64 `yaml:"voltage" json:"voltage" xml:"voltage" mapstructure:"voltage"`
+	// Temperature is the temperature of the battery string in degrees celsius.
+	Temperature *float64 `yaml:"temperature" json:"temperature" xml:"temperature" mapstructure:"temperature"`
+	// Status is the status of the battery string, e.g. "batteryNormal" or "batteryLow".
+	Status *string `yaml:"status" json:"status" xml:"status" mapstructure:"status"`
 }
 
 // ServerComponent
@@ -376,6 +661,15 @@ type UPSComponent struct {
 type ServerComponent struct {
 	Procs *int `yaml:"procs" json:"procs" xml:"procs" mapstructure:"procs"`
 	Users *int `yaml:"users" json:"users" xml:"users" mapstructure:"users"`
+	// Uptime is the time since the last boot in seconds.
+	Uptime *uint32 `yaml:"uptime" json:"uptime" xml:"uptime" mapstructure:"uptime"`
+	// LoadAverage1, LoadAverage5 and LoadAverage15 are the load averages of the last 1, 5 and 15 minutes.
+	LoadAverage1  *float64 `yaml:"load_average_1" json:"load_average_1" xml:"load_average_1" mapstructure:"load_average_1"`
+	LoadAverage5  *float64 `yaml:"load_average_5" json:"load_average_5" xml:"load_average_5" mapstructure:"load_average_5"`
+	LoadAverage15 *float64 `yaml:"load_average_15" json:"load_average_15" xml:"load_average_15" mapstructure:"load_average_15"`
+	// SwapTotal and SwapUsed are the total and used swap space in bytes.
+	SwapTotal *uint64 `yaml:"swap_total" json:"swap_total" xml:"swap_total" mapstructure:"swap_total"`
+	SwapUsed  *uint64 `yaml:"swap_used" json:"swap_used" xml:"swap_used" mapstructure:"swap_used"`
 }
 
 // SBCComponent
@@ -391,10 +685,21 @@ type SBCComponent struct {
 	ActiveLocalContacts      *int                `yaml:"active_local_contacts" json:"active_local_contacts" xml:"active_local_contacts" mapstructure:"active_local_contacts"`
 	TranscodingCapacity      *int                `yaml:"transcoding_capacity" json:"transcoding_capacity" xml:"transcoding_capacity" mapstructure:"transcoding_capacity"`
 	LicenseCapacity          *int                `yaml:"license_capacity" json:"license_capacity" xml:"license_capacity" mapstructure:"license_capacity"`
+	SessionUtilization       *float64            `yaml:"session_utilization" json:"session_utilization" xml:"session_utilization" mapstructure:"session_utilization"`
 	SystemRedundancy         *int                `yaml:"system_redundancy" json:"system_redundancy" xml:"system_redundancy" mapstructure:"system_redundancy"`
 	SystemHealthScore        *int                `yaml:"system_health_score" json:"system_health_score" xml:"system_health_score" mapstructure:"system_health_score"`
 }
 
+// GetSessionUtilization returns the global concurrent sessions in percent of the license capacity.
+// It returns nil if one of them is missing or the license capacity is zero.
+func (s SBCComponent) GetSessionUtilization() *float64 {
+	if s.GlobalConcurrentSessions == nil || s.LicenseCapacity == nil || *s.LicenseCapacity == 0 {
+		return nil
+	}
+	utilization := float64(*s.GlobalConcurrentSessions) / float64(*s.LicenseCapacity) * 100
+	return &utilization
+}
+
 // SBCComponentAgent
 //
 // SBCComponentAgent contains information per agent. (Voice)
@@ -407,7 +712,10 @@ type SBCComponentAgent struct {
 	CurrentActiveSessionsOutbound *int    `yaml:"current_active_sessions_outbound" json:"current_active_sessions_outbound" xml:"current_active_sessions_outbound" mapstructure:"current_active_sessions_outbound"`
 	CurrentSessionRateOutbound    *int    `yaml:"current_session_rate_outbound" json:"current_session_rate_outbound" xml:"current_session_rate_outbound" mapstructure:"current_session_rate_outbound"`
 	PeriodASR                     *int    `yaml:"period_asr" json:"period_asr" xml:"period_asr" mapstructure:"period_asr"`
+	RegisteredEndpoints           *int    `yaml:"registered_endpoints" json:"registered_endpoints" xml:"registered_endpoints" mapstructure:"registered_endpoints"`
 	Status                        *int    `yaml:"status" json:"status" xml:"status" mapstructure:"status"`
+	// StatusName is the name of the status, e.g. "inService" or "outOfService".
+	StatusName *string `yaml:"status_name" json:"status_name" xml:"status_name" mapstructure:"status_name"`
 }
 
 // SBCComponentRealm
@@ -432,11 +740,14 @@ type SBCComponentRealm struct {
 //
 // swagger:model
 type HardwareHealthComponent struct {
-	EnvironmentMonitorState *HardwareHealthComponentState        `yaml:"environment_monitor_state" json:"environment_monitor_state" xml:"environment_monitor_state" mapstructure:"environment_monitor_state"`
-	Fans                    []HardwareHealthComponentFan         `yaml:"fans" json:"fans" xml:"fans" mapstructure:"fans"`
-	PowerSupply             []HardwareHealthComponentPowerSupply `yaml:"power_supply" json:"power_supply" xml:"power_supply" mapstructure:"power_supply"`
-	Temperature             []HardwareHealthComponentTemperature `yaml:"temperature" json:"temperature" xml:"temperature" mapstructure:"temperature"`
-	Voltage                 []HardwareHealthComponentVoltage     `yaml:"voltage" json:"voltage" xml:"voltage" mapstructure:"voltage"`
+	EnvironmentMonitorState *HardwareHealthComponentState `yaml:"environment_monitor_state" json:"environment_monitor_state" xml:"environment_monitor_state" mapstructure:"environment_monitor_state"`
+	// EnvironmentMonitorStateRaw is the raw value the device reported if it could not be mapped to a state.
+	EnvironmentMonitorStateRaw *string                              `yaml:"environment_monitor_state_raw,omitempty" json:"environment_monitor_state_raw,omitempty" xml:"environment_monitor_state_raw,omitempty" mapstructure:"environment_monitor_state_raw"`
+	Fans                       []HardwareHealthComponentFan         `yaml:"fans" json:"fans" xml:"fans" mapstructure:"fans"`
+	PowerSupply                []HardwareHealthComponentPowerSupply `yaml:"power_supply" json:"power_supply" xml:"power_supply" mapstructure:"power_supply"`
+	Temperature                []HardwareHealthComponentTemperature `yaml:"temperature" json:"temperature" xml:"temperature" mapstructure:"temperature"`
+	Voltage                    []HardwareHealthComponentVoltage     `yaml:"voltage" json:"voltage" xml:"voltage" mapstructure:"voltage"`
+	Humidity                   []HardwareHealthComponentHumidity    `yaml:"humidity" json:"humidity" xml:"humidity" mapstructure:"humidity"`
 }
 
 // HardwareHealthComponentFan
@@ -447,6 +758,8 @@ type HardwareHealthComponent struct {
 type HardwareHealthComponentFan struct {
 	Description *string                       `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
 	State       *HardwareHealthComponentState `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
+	SpeedRPM    *int                          `yaml:"speed_rpm" json:"speed_rpm" xml:"speed_rpm" mapstructure:"speed_rpm"`
+	MaxSpeedRPM *int                          `yaml:"max_speed_rpm" json:"max_speed_rpm" xml:"max_speed_rpm" mapstructure:"max_speed_rpm"`
 }
 
 // HardwareHealthComponentTemperature
@@ -471,6 +784,17 @@ type HardwareHealthComponentVoltage struct {
 	State       *HardwareHealthComponentState `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
 }
 
+// HardwareHealthComponentHumidity
+//
+// HardwareHealthComponentHumidity represents one humidity sensor of a device.
+//
+// swagger:model
+type HardwareHealthComponentHumidity struct {
+	Description *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	// Value is the relative humidity in percent.
+	Value *float64 `yaml:"value" json:"value" xml:"value" mapstructure:"value"`
+}
+
 // HardwareHealthComponentPowerSupply
 //
 // HardwareHealthComponentPowerSupply represents one power supply of a device.
@@ -479,6 +803,10 @@ type HardwareHealthComponentVoltage struct {
 type HardwareHealthComponentPowerSupply struct {
 	Description *string                       `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
 	State       *HardwareHealthComponentState `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
+	// InputVoltage is the input voltage in volts, OutputWattage the current output and Capacity the maximum output in watts.
+	InputVoltage  *float64 `yaml:"input_voltage" json:"input_voltage" xml:"input_voltage" mapstructure:"input_voltage"`
+	OutputWattage *float64 `yaml:"output_wattage" json:"output_wattage" xml:"output_wattage" mapstructure:"output_wattage"`
+	Capacity      *float64 `yaml:"capacity" json:"capacity" xml:"capacity" mapstructure:"capacity"`
 }
 
 type HardwareHealthComponentState string
@@ -516,6 +844,285 @@ func (h HardwareHealthComponentState) GetInt() (int, error) {
 	return 7, fmt.Errorf("invalid hardware health state '%s'", h)
 }
 
+// GetMonitoringState returns the monitoring plugin state that corresponds to the hardware health state.
+func (h HardwareHealthComponentState) GetMonitoringState() int {
+	switch h {
+	case HardwareHealthComponentStateNormal, HardwareHealthComponentStateNotPresent:
+		return monitoringplugin.OK
+	case HardwareHealthComponentStateWarning, HardwareHealthComponentStateInitial:
+		return monitoringplugin.WARNING
+	case HardwareHealthComponentStateCritical, HardwareHealthComponentStateShutdown, HardwareHealthComponentStateNotFunctioning:
+		return monitoringplugin.CRITICAL
+	}
+	return monitoringplugin.UNKNOWN
+}
+
+// UnknownHardwareHealthStateError is returned if a device reports a value that is not mapped to a hardware health state.
+type UnknownHardwareHealthStateError struct {
+	Raw string
+}
+
+func (e UnknownHardwareHealthStateError) Error() string {
+	return fmt.Sprintf("unknown hardware health state '%s'", e.Raw)
+}
+
+// VLANComponent
+//
+// VLANComponent represents the VLANs of a device and their port memberships.
+//
+// swagger:model
+type VLANComponent struct {
+	VLANs []VLAN `yaml:"vlans" json:"vlans" xml:"vlans" mapstructure:"vlans"`
+	// PortMembership contains the IDs of the VLANs every port is a member of, sorted by the ifIndex of the ports.
+	PortMembership []VLANPortMembership `yaml:"port_membership" json:"port_membership" xml:"port_membership" mapstructure:"port_membership"`
+}
+
+// VLANPortMembership
+//
+// VLANPortMembership contains the IDs of the VLANs a port is a member of.
+//
+// swagger:model
+type VLANPortMembership struct {
+	IfIndex int   `yaml:"ifIndex" json:"ifIndex" xml:"ifIndex" mapstructure:"ifIndex"`
+	VLANIDs []int `yaml:"vlan_ids" json:"vlan_ids" xml:"vlan_ids" mapstructure:"vlan_ids"`
+}
+
+// NewVLANPortMemberships converts the VLAN IDs mapped by the ifIndex of the ports to port memberships sorted by ifIndex.
+func NewVLANPortMemberships(vlanIDs map[int][]int) []VLANPortMembership {
+	res := make([]VLANPortMembership, 0, len(vlanIDs))
+	for ifIndex, ids := range vlanIDs {
+		res = append(res, VLANPortMembership{IfIndex: ifIndex, VLANIDs: ids})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].IfIndex < res[j].IfIndex
+	})
+	return res
+}
+
+// POEComponent
+//
+// POEComponent represents the power over ethernet information of a device.
+//
+// swagger:model
+type POEComponent struct {
+	PSEs  []POEComponentPSE  `yaml:"pses" json:"pses" xml:"pses" mapstructure:"pses"`
+	Ports []POEComponentPort `yaml:"ports" json:"ports" xml:"ports" mapstructure:"ports"`
+}
+
+// POEComponentPSE
+//
+// POEComponentPSE represents one power sourcing equipment of a device.
+//
+// swagger:model
+type POEComponentPSE struct {
+	Description *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	// Power is the nominal power of the PSE in watts.
+	Power *float64 `yaml:"power" json:"power" xml:"power" mapstructure:"power"`
+	// ConsumptionPower is the power consumed by the PSE in watts.
+	ConsumptionPower *float64               `yaml:"consumption_power" json:"consumption_power" xml:"consumption_power" mapstructure:"consumption_power"`
+	UsageThreshold   *float64               `yaml:"usage_threshold" json:"usage_threshold" xml:"usage_threshold" mapstructure:"usage_threshold"`
+	OperStatus       *POEComponentPSEStatus `yaml:"oper_status" json:"oper_status" xml:"oper_status" mapstructure:"oper_status"`
+}
+
+// POEComponentPort
+//
+// POEComponentPort represents one power over ethernet port of a device.
+//
+// swagger:model
+type POEComponentPort struct {
+	Description     *string                          `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	AdminEnable     *bool                            `yaml:"admin_enable" json:"admin_enable" xml:"admin_enable" mapstructure:"admin_enable"`
+	DetectionStatus *POEComponentPortDetectionStatus `yaml:"detection_status" json:"detection_status" xml:"detection_status" mapstructure:"detection_status"`
+	PowerClass      *string                          `yaml:"power_class" json:"power_class" xml:"power_class" mapstructure:"power_class"`
+	// Power is the power delivered by the port in watts.
+	Power *float64 `yaml:"power" json:"power" xml:"power" mapstructure:"power"`
+	// PowerAllocated is the power allocated to the port in watts.
+	PowerAllocated *float64 `yaml:"power_allocated" json:"power_allocated" xml:"power_allocated" mapstructure:"power_allocated"`
+}
+
+type POEComponentPSEStatus string
+
+const (
+	POEComponentPSEStatusOn     POEComponentPSEStatus = "on"
+	POEComponentPSEStatusOff    POEComponentPSEStatus = "off"
+	POEComponentPSEStatusFaulty POEComponentPSEStatus = "faulty"
+)
+
+type POEComponentPortDetectionStatus string
+
+const (
+	POEComponentPortDetectionStatusDisabled        POEComponentPortDetectionStatus = "disabled"
+	POEComponentPortDetectionStatusSearching       POEComponentPortDetectionStatus = "searching"
+	POEComponentPortDetectionStatusDeliveringPower POEComponentPortDetectionStatus = "delivering_power"
+	POEComponentPortDetectionStatusFault           POEComponentPortDetectionStatus = "fault"
+	POEComponentPortDetectionStatusTest            POEComponentPortDetectionStatus = "test"
+	POEComponentPortDetectionStatusOtherFault      POEComponentPortDetectionStatus = "other_fault"
+)
+
+// IsFaulty returns whether the detection status reports a faulty port.
+func (s POEComponentPortDetectionStatus) IsFaulty() bool {
+	return s == POEComponentPortDetectionStatusFault || s == POEComponentPortDetectionStatusOtherFault
+}
+
+// EnvironmentComponent
+//
+// EnvironmentComponent represents the environment sensors of a device, e.g. the external probes of a sensor box.
+//
+// swagger:model
+type EnvironmentComponent struct {
+	Temperature []EnvironmentComponentTemperature `yaml:"temperature" json:"temperature" xml:"temperature" mapstructure:"temperature"`
+	Humidity    []EnvironmentComponentHumidity    `yaml:"humidity" json:"humidity" xml:"humidity" mapstructure:"humidity"`
+	DryContacts []EnvironmentComponentDryContact  `yaml:"dry_contacts" json:"dry_contacts" xml:"dry_contacts" mapstructure:"dry_contacts"`
+}
+
+// EnvironmentComponentTemperature
+//
+// EnvironmentComponentTemperature represents one temperature sensor of a device.
+//
+// swagger:model
+type EnvironmentComponentTemperature struct {
+	Description *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	// Temperature is the temperature in degrees celsius.
+	Temperature *float64 `yaml:"temperature" json:"temperature" xml:"temperature" mapstructure:"temperature"`
+}
+
+// EnvironmentComponentHumidity
+//
+// EnvironmentComponentHumidity represents one humidity sensor of a device.
+//
+// swagger:model
+type EnvironmentComponentHumidity struct {
+	Description *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	// Humidity is the relative humidity in percent.
+	Humidity *float64 `yaml:"humidity" json:"humidity" xml:"humidity" mapstructure:"humidity"`
+}
+
+// EnvironmentComponentDryContact
+//
+// EnvironmentComponentDryContact represents one dry contact of a device, e.g. a door contact.
+//
+// swagger:model
+type EnvironmentComponentDryContact struct {
+	Description *string                              `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	State       *EnvironmentComponentDryContactState `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
+}
+
+type EnvironmentComponentDryContactState string
+
+const (
+	EnvironmentComponentDryContactStateOpen   EnvironmentComponentDryContactState = "open"
+	EnvironmentComponentDryContactStateClosed EnvironmentComponentDryContactState = "closed"
+)
+
+// Validate checks if the dry contact state is valid.
+func (s EnvironmentComponentDryContactState) Validate() error {
+	if s != EnvironmentComponentDryContactStateOpen && s != EnvironmentComponentDryContactStateClosed {
+		return fmt.Errorf("invalid dry contact state '%s', only 'open' and 'closed' are possible", s)
+	}
+	return nil
+}
+
+// EntityComponent
+//
+// EntityComponent represents the physical entities of a device, e.g. its chassis, slots, modules and sensors.
+//
+// swagger:model
+type EntityComponent struct {
+	Entities []PhysicalEntity `yaml:"entities" json:"entities" xml:"entities" mapstructure:"entities"`
+}
+
+// PhysicalEntity
+//
+// PhysicalEntity represents one entry of the entPhysicalTable of the ENTITY-MIB.
+//
+// swagger:model
+type PhysicalEntity struct {
+	Index int `yaml:"index" json:"index" xml:"index" mapstructure:"index"`
+	// ParentIndex is the index of the entity which contains this entity, 0 if it isn't contained in another entity.
+	ParentIndex  int     `yaml:"parent_index" json:"parent_index" xml:"parent_index" mapstructure:"parent_index"`
+	Class        *string `yaml:"class,omitempty" json:"class,omitempty" xml:"class,omitempty" mapstructure:"class"`
+	Name         *string `yaml:"name,omitempty" json:"name,omitempty" xml:"name,omitempty" mapstructure:"name"`
+	SerialNumber *string `yaml:"serial_number,omitempty" json:"serial_number,omitempty" xml:"serial_number,omitempty" mapstructure:"serial_number"`
+	FirmwareRev  *string `yaml:"firmware_rev,omitempty" json:"firmware_rev,omitempty" xml:"firmware_rev,omitempty" mapstructure:"firmware_rev"`
+	ModelName    *string `yaml:"model_name,omitempty" json:"model_name,omitempty" xml:"model_name,omitempty" mapstructure:"model_name"`
+}
+
+// WLANComponent
+//
+// WLANComponent represents the access points of a wireless controller and their clients.
+//
+// swagger:model
+type WLANComponent struct {
+	AccessPoints []AccessPoint `yaml:"access_points" json:"access_points" xml:"access_points" mapstructure:"access_points"`
+	// ClientCount is the total count of clients which are associated to the access points of the controller.
+	ClientCount *int `yaml:"client_count" json:"client_count" xml:"client_count" mapstructure:"client_count"`
+}
+
+// AccessPoint
+//
+// AccessPoint represents an access point which is managed by a wireless controller.
+//
+// swagger:model
+type AccessPoint struct {
+	Name        *string            `yaml:"name" json:"name" xml:"name" mapstructure:"name"`
+	Model       *string            `yaml:"model" json:"model" xml:"model" mapstructure:"model"`
+	Status      *AccessPointStatus `yaml:"status" json:"status" xml:"status" mapstructure:"status"`
+	ClientCount *int               `yaml:"client_count" json:"client_count" xml:"client_count" mapstructure:"client_count"`
+}
+
+// AccessPointStatus is the status of an access point. Access points which are not yet ready, e.g. because they are
+// still downloading their image from the controller, have their own status and are not reported as down.
+type AccessPointStatus string
+
+const (
+	AccessPointStatusUp          AccessPointStatus = "up"
+	AccessPointStatusDown        AccessPointStatus = "down"
+	AccessPointStatusDownloading AccessPointStatus = "downloading"
+	AccessPointStatusJoining     AccessPointStatus = "joining"
+	AccessPointStatusUnknown     AccessPointStatus = "unknown"
+)
+
+// FirewallComponent
+//
+// FirewallComponent represents the sessions, vpn tunnels and licenses of a firewall.
+//
+// swagger:model
+type FirewallComponent struct {
+	ActiveSessions *int                `yaml:"active_sessions" json:"active_sessions" xml:"active_sessions" mapstructure:"active_sessions"`
+	VPNTunnels     []FirewallVPNTunnel `yaml:"vpn_tunnels" json:"vpn_tunnels" xml:"vpn_tunnels" mapstructure:"vpn_tunnels"`
+	Licenses       []FirewallLicense   `yaml:"licenses" json:"licenses" xml:"licenses" mapstructure:"licenses"`
+}
+
+// FirewallVPNTunnel
+//
+// FirewallVPNTunnel represents a vpn tunnel of a firewall.
+//
+// swagger:model
+type FirewallVPNTunnel struct {
+	Name          *string                  `yaml:"name" json:"name" xml:"name" mapstructure:"name"`
+	RemoteGateway *string                  `yaml:"remote_gateway" json:"remote_gateway" xml:"remote_gateway" mapstructure:"remote_gateway"`
+	Status        *FirewallVPNTunnelStatus `yaml:"status" json:"status" xml:"status" mapstructure:"status"`
+}
+
+// FirewallVPNTunnelStatus is the status of a vpn tunnel.
+type FirewallVPNTunnelStatus string
+
+const (
+	FirewallVPNTunnelStatusUp   FirewallVPNTunnelStatus = "up"
+	FirewallVPNTunnelStatusDown FirewallVPNTunnelStatus = "down"
+)
+
+// FirewallLicense
+//
+// FirewallLicense represents a license or support contract of a firewall.
+//
+// swagger:model
+type FirewallLicense struct {
+	Description *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	// Expiry is the expiry date of the license as reported by the device.
+	Expiry *string `yaml:"expiry" json:"expiry" xml:"expiry" mapstructure:"expiry"`
+}
+
 // HighAvailabilityComponent
 //
 // HighAvailabilityComponent represents high availability information of a device.
@@ -557,6 +1164,23 @@ type Rate struct {
 	Value float64 `yaml:"value" json:"value" xml:"value" mapstructure:"value"`
 }
 
+// GroupAggregations returns the ifIndexes of the member interfaces mapped by the ifIndex of their aggregator.
+func GroupAggregations(interfaces []Interface) map[uint64][]uint64 {
+	aggregations := make(map[uint64][]uint64)
+	for _, interf := range interfaces {
+		if interf.IsAggregator && interf.IfIndex != nil {
+			if _, ok := aggregations[*interf.IfIndex]; !ok {
+				aggregations[*interf.IfIndex] = nil
+			}
+		}
+		if interf.AggregationID != nil && interf.IfIndex != nil {
+			aggregator := uint64(*interf.AggregationID)
+			aggregations[aggregator] = append(aggregations[aggregator], *interf.IfIndex)
+		}
+	}
+	return aggregations
+}
+
 // NewContextWithDeviceProperties returns a new context with the device properties.
 func NewContextWithDeviceProperties(ctx context.Context, properties Device) context.Context {
 	return context.WithValue(ctx, devicePropertiesKey, properties)
@@ -611,3 +1235,61 @@ func (s Status) ToStatusCode() (int, error) {
 		return 0, errors.New("invalid status")
 	}
 }
+
+// statuses are all statuses in the order of their codes.
+var statuses = []Status{StatusUp, StatusDown, StatusTesting, StatusUnknown, StatusDormant, StatusNotPresent, StatusLowerLayerDown}
+
+// statusLabelRegex matches statuses which contain their label and code, like snmpwalk prints them, e.g. "up(1)".
+var statusLabelRegex = regexp.MustCompile(`^[A-Za-z]+\((\d+)\)$`)
+
+// ParseStatus returns the Status of a status code or of a label as it is commonly reported by devices. Labels are
+// compared case-insensitive and without separators, so e.g. "1", "UP", "up(1)", "lower-layer-down" and
+// "not present" are accepted.
+func ParseStatus(s string) (Status, error) {
+	s = strings.TrimSpace(s)
+	if match := statusLabelRegex.FindStringSubmatch(s); match != nil {
+		s = match[1]
+	}
+	if code, err := strconv.Atoi(s); err == nil {
+		return GetStatus(code)
+	}
+
+	label := strings.NewReplacer("-", "", "_", "", " ", "").Replace(s)
+	for _, status := range statuses {
+		if strings.EqualFold(string(status), label) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status '%s'", s)
+}
+
+// Normalize returns the status as one of the status constants. Statuses which cannot be parsed are returned as they
+// are.
+func (s Status) Normalize() Status {
+	status, err := ParseStatus(string(s))
+	if err != nil {
+		return s
+	}
+	return status
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts status codes as well as labels and normalizes them, the
+// status is still marshalled as its label.
+func (s *Status) UnmarshalJSON(b []byte) error {
+	var code int
+	if err := json.Unmarshal(b, &code); err == nil {
+		status, err := GetStatus(code)
+		if err != nil {
+			return err
+		}
+		*s = status
+		return nil
+	}
+
+	var label string
+	if err := json.Unmarshal(b, &label); err != nil {
+		return err
+	}
+	*s = Status(label).Normalize()
+	return nil
+}
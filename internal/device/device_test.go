@@ -0,0 +1,117 @@
+package device
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSBCComponent_GetSessionUtilization(t *testing.T) {
+	sessions, capacity, zero := 250, 1000, 0
+
+	utilization := SBCComponent{GlobalConcurrentSessions: &sessions, LicenseCapacity: &capacity}.GetSessionUtilization()
+	if assert.NotNil(t, utilization) {
+		assert.Equal(t, 25.0, *utilization)
+	}
+
+	assert.Nil(t, SBCComponent{GlobalConcurrentSessions: &sessions}.GetSessionUtilization())
+	assert.Nil(t, SBCComponent{LicenseCapacity: &capacity}.GetSessionUtilization())
+	assert.Nil(t, SBCComponent{GlobalConcurrentSessions: &sessions, LicenseCapacity: &zero}.GetSessionUtilization())
+}
+
+func TestParseStatus(t *testing.T) {
+	cases := map[string]Status{
+		"1":                StatusUp,
+		"2":                StatusDown,
+		"7":                StatusLowerLayerDown,
+		"up":               StatusUp,
+		"DOWN":             StatusDown,
+		" Testing ":        StatusTesting,
+		"dormant(5)":       StatusDormant,
+		"not present":      StatusNotPresent,
+		"notPresent":       StatusNotPresent,
+		"lower-layer-down": StatusLowerLayerDown,
+		"lower_layer_down": StatusLowerLayerDown,
+		"LOWERLAYERDOWN":   StatusLowerLayerDown,
+	}
+	for s, expected := range cases {
+		status, err := ParseStatus(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, expected, status, s)
+		}
+	}
+
+	for _, s := range []string{"", "0", "8", "upp", "up(8)"} {
+		_, err := ParseStatus(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestStatus_codeRoundTrip(t *testing.T) {
+	for _, status := range statuses {
+		code, err := status.ToStatusCode()
+		if assert.NoError(t, err) {
+			res, err := GetStatus(code)
+			if assert.NoError(t, err) {
+				assert.Equal(t, status, res)
+			}
+		}
+	}
+}
+
+func TestStatus_JSON(t *testing.T) {
+	for _, status := range statuses {
+		b, err := json.Marshal(Interface{IfOperStatus: &status})
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Contains(t, string(b), `"ifOperStatus":"`+string(status)+`"`)
+
+		var interf Interface
+		if assert.NoError(t, json.Unmarshal(b, &interf)) && assert.NotNil(t, interf.IfOperStatus) {
+			assert.Equal(t, status, *interf.IfOperStatus)
+		}
+	}
+
+	var interf Interface
+	if assert.NoError(t, json.Unmarshal([]byte(`{"ifAdminStatus":1,"ifOperStatus":"lowerLayerDown(7)"}`), &interf)) {
+		assert.Equal(t, StatusUp, *interf.IfAdminStatus)
+		assert.Equal(t, StatusLowerLayerDown, *interf.IfOperStatus)
+	}
+
+	// unknown labels are kept for backward compatibility
+	var status Status
+	if assert.NoError(t, json.Unmarshal([]byte(`"custom"`), &status)) {
+		assert.Equal(t, Status("custom"), status)
+	}
+	assert.Error(t, json.Unmarshal([]byte(`8`), &status))
+}
+
+func TestHardwareHealthComponentState_GetMonitoringState(t *testing.T) {
+	assert.Equal(t, monitoringplugin.OK, HardwareHealthComponentStateNormal.GetMonitoringState())
+	assert.Equal(t, monitoringplugin.WARNING, HardwareHealthComponentStateWarning.GetMonitoringState())
+	assert.Equal(t, monitoringplugin.CRITICAL, HardwareHealthComponentStateCritical.GetMonitoringState())
+	assert.Equal(t, monitoringplugin.CRITICAL, HardwareHealthComponentStateShutdown.GetMonitoringState())
+	assert.Equal(t, monitoringplugin.UNKNOWN, HardwareHealthComponentStateUnknown.GetMonitoringState())
+	assert.Equal(t, monitoringplugin.UNKNOWN, HardwareHealthComponentState("ok").GetMonitoringState())
+}
+
+func TestVLANComponent_xml(t *testing.T) {
+	vlan := VLANComponent{
+		PortMembership: NewVLANPortMemberships(map[int][]int{
+			2: {10, 20},
+			1: {10},
+		}),
+	}
+	assert.Equal(t, []VLANPortMembership{
+		{IfIndex: 1, VLANIDs: []int{10}},
+		{IfIndex: 2, VLANIDs: []int{10, 20}},
+	}, vlan.PortMembership)
+
+	b, err := xml.Marshal(vlan)
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(b), "<port_membership><ifIndex>1</ifIndex><vlan_ids>10</vlan_ids></port_membership>")
+	}
+}
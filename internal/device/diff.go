@@ -0,0 +1,235 @@
+package device
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Snapshot is the state of a device at one point in time, e.g. the results of an identify and a read interfaces
+// request.
+type Snapshot struct {
+	Device     `yaml:",inline"`
+	Interfaces []Interface `yaml:"interfaces" json:"interfaces" xml:"interfaces"`
+}
+
+// DiffOptions are the options of Diff.
+type DiffOptions struct {
+	// IncludeVolatile also compares the volatile values of the interfaces, which change all the time, like counters and
+	// measured power levels.
+	IncludeVolatile bool
+}
+
+// Delta is the difference between two snapshots of a device.
+type Delta struct {
+	// Properties contains the class and the identify properties that changed.
+	Properties []Change `yaml:"properties,omitempty" json:"properties,omitempty" xml:"properties,omitempty"`
+	// AddedInterfaces contains the interfaces whose ifIndex is only part of the new snapshot.
+	AddedInterfaces []Interface `yaml:"added_interfaces,omitempty" json:"added_interfaces,omitempty" xml:"added_interfaces,omitempty"`
+	// RemovedInterfaces contains the interfaces whose ifIndex is only part of the old snapshot.
+	RemovedInterfaces []Interface `yaml:"removed_interfaces,omitempty" json:"removed_interfaces,omitempty" xml:"removed_interfaces,omitempty"`
+	// ChangedInterfaces contains the changed values of the interfaces which are part of both snapshots.
+	ChangedInterfaces []InterfaceChange `yaml:"changed_interfaces,omitempty" json:"changed_interfaces,omitempty" xml:"changed_interfaces,omitempty"`
+}
+
+// Change is a changed value. Old or New is nil if the value was added or removed.
+type Change struct {
+	// Name of the value, values of nested interface types are separated by "/", e.g. "ethernet_like/dot3StatsFCSErrors".
+	Name string      `yaml:"name" json:"name" xml:"name"`
+	Old  interface{} `yaml:"old" json:"old" xml:"old"`
+	New  interface{} `yaml:"new" json:"new" xml:"new"`
+}
+
+// InterfaceChange contains the changed values of an interface.
+type InterfaceChange struct {
+	IfIndex uint64   `yaml:"ifIndex" json:"ifIndex" xml:"ifIndex"`
+	Changes []Change `yaml:"changes" json:"changes" xml:"changes"`
+}
+
+// volatileInterfaceValues are the values of an interface that are ignored by Diff by default. Nested values are
+// ignored completely if their parent is part of the list.
+var volatileInterfaceValues = []string{
+	"ifLastChange",
+	"ifInOctets", "ifInUcastPkts", "ifInNUcastPkts", "ifInDiscards", "ifInErrors", "ifInUnknownProtos",
+	"ifOutOctets", "ifOutUcastPkts", "ifOutNUcastPkts", "ifOutDiscards", "ifOutErrors", "ifOutQLen",
+	"ifInMulticastPkts", "ifInBroadcastPkts", "ifOutMulticastPkts", "ifOutBroadcastPkts",
+	"ifHCInOctets", "ifHCInUcastPkts", "ifHCInMulticastPkts", "ifHCInBroadcastPkts",
+	"ifHCOutOctets", "ifHCOutUcastPkts", "ifHCOutMulticastPkts", "ifHCOutBroadcastPkts",
+	"ethernet_like", "ipv4_stats", "ipv6_stats",
+	"sap/inbound", "sap/outbound", "sap/error",
+	"radio/level_in", "radio/level_out", "radio/channels",
+	"dwdm/rx_power", "dwdm/tx_power", "dwdm/corrected_fec", "dwdm/uncorrected_fec", "dwdm/channels",
+	"optical_transponder/rx_power", "optical_transponder/tx_power",
+	"optical_transponder/corrected_fec", "optical_transponder/uncorrected_fec",
+	"optical_amplifier/rx_power", "optical_amplifier/tx_power", "optical_amplifier/gain",
+	"optical_opm/rx_power", "optical_opm/channels",
+}
+
+// Diff returns the changes between an old and a new snapshot of a device. The properties are compared one by one,
+// interfaces are matched by their ifIndex. Interfaces without an ifIndex can't be matched and are ignored.
+func Diff(old, new Snapshot, options DiffOptions) (Delta, error) {
+	var delta Delta
+
+	if old.Class != new.Class {
+		delta.Properties = append(delta.Properties, Change{Name: "class", Old: old.Class, New: new.Class})
+	}
+	for _, name := range IdentifyProperties {
+		oldValue, err := old.Properties.GetIdentifyProperty(name)
+		if err != nil {
+			return Delta{}, err
+		}
+		newValue, err := new.Properties.GetIdentifyProperty(name)
+		if err != nil {
+			return Delta{}, err
+		}
+		if change, ok := diffStrings(name, oldValue, newValue); ok {
+			delta.Properties = append(delta.Properties, change)
+		}
+	}
+
+	oldInterfaces := interfacesByIfIndex(old.Interfaces)
+	newInterfaces := interfacesByIfIndex(new.Interfaces)
+
+	for _, ifIndex := range sortedIfIndices(newInterfaces) {
+		if _, ok := oldInterfaces[ifIndex]; !ok {
+			delta.AddedInterfaces = append(delta.AddedInterfaces, newInterfaces[ifIndex])
+		}
+	}
+	for _, ifIndex := range sortedIfIndices(oldInterfaces) {
+		newInterface, ok := newInterfaces[ifIndex]
+		if !ok {
+			delta.RemovedInterfaces = append(delta.RemovedInterfaces, oldInterfaces[ifIndex])
+			continue
+		}
+		changes, err := diffInterfaces(oldInterfaces[ifIndex], newInterface, options)
+		if err != nil {
+			return Delta{}, err
+		}
+		if len(changes) > 0 {
+			delta.ChangedInterfaces = append(delta.ChangedInterfaces, InterfaceChange{IfIndex: ifIndex, Changes: changes})
+		}
+	}
+
+	return delta, nil
+}
+
+// IsEmpty returns whether nothing changed.
+func (d *Delta) IsEmpty() bool {
+	return len(d.Properties) == 0 && len(d.AddedInterfaces) == 0 && len(d.RemovedInterfaces) == 0 && len(d.ChangedInterfaces) == 0
+}
+
+func diffStrings(name string, old, new *string) (Change, bool) {
+	switch {
+	case old == nil && new == nil:
+		return Change{}, false
+	case old == nil:
+		return Change{Name: name, New: *new}, true
+	case new == nil:
+		return Change{Name: name, Old: *old}, true
+	case *old != *new:
+		return Change{Name: name, Old: *old, New: *new}, true
+	}
+	return Change{}, false
+}
+
+func interfacesByIfIndex(interfaces []Interface) map[uint64]Interface {
+	res := make(map[uint64]Interface)
+	for _, interf := range interfaces {
+		if interf.IfIndex != nil {
+			res[*interf.IfIndex] = interf
+		}
+	}
+	return res
+}
+
+func sortedIfIndices(interfaces map[uint64]Interface) []uint64 {
+	var res []uint64
+	for ifIndex := range interfaces {
+		res = append(res, ifIndex)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+	return res
+}
+
+// diffInterfaces compares all values of the interfaces by their names in the json encoding.
+func diffInterfaces(old, new Interface, options DiffOptions) ([]Change, error) {
+	oldValues, err := flattenInterface(old)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := flattenInterface(new)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{})
+	for name := range oldValues {
+		names[name] = struct{}{}
+	}
+	for name := range newValues {
+		names[name] = struct{}{}
+	}
+
+	var changes []Change
+	for name := range names {
+		if !options.IncludeVolatile && isVolatileInterfaceValue(name) {
+			continue
+		}
+		oldValue, newValue := oldValues[name], newValues[name]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, Change{Name: name, Old: oldValue, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+// flattenInterface returns all values of the interface which are set mapped by their name. Numbers are kept as
+// json.Number, so that large counters don't lose their precision.
+func flattenInterface(interf Interface) (map[string]interface{}, error) {
+	b, err := json.Marshal(interf)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]interface{})
+	flattenValues("", m, res)
+	return res, nil
+}
+
+func flattenValues(prefix string, m map[string]interface{}, res map[string]interface{}) {
+	for key, v := range m {
+		name := key
+		if prefix != "" {
+			name = prefix + "/" + key
+		}
+		switch val := v.(type) {
+		case nil:
+		case map[string]interface{}:
+			flattenValues(name, val, res)
+		default:
+			res[name] = val
+		}
+	}
+}
+
+func isVolatileInterfaceValue(name string) bool {
+	for _, volatile := range volatileInterfaceValues {
+		if name == volatile || strings.HasPrefix(name, volatile+"/") {
+			return true
+		}
+	}
+	return false
+}
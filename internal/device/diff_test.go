@@ -0,0 +1,91 @@
+package device
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newDiffTestInterface(ifIndex uint64, ifDescr string, ifHCInOctets uint64) Interface {
+	status := StatusUp
+	return Interface{IfIndex: &ifIndex, IfDescr: &ifDescr, IfOperStatus: &status, IfHCInOctets: &ifHCInOctets}
+}
+
+func TestDiff_addedInterface(t *testing.T) {
+	old := Snapshot{Interfaces: []Interface{newDiffTestInterface(1, "eth0", 100)}}
+	current := Snapshot{Interfaces: []Interface{newDiffTestInterface(2, "eth1", 0), newDiffTestInterface(1, "eth0", 200)}}
+
+	delta, err := Diff(old, current, DiffOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Interface{newDiffTestInterface(2, "eth1", 0)}, delta.AddedInterfaces)
+		assert.Empty(t, delta.RemovedInterfaces)
+		// the counter is volatile
+		assert.Empty(t, delta.ChangedInterfaces)
+		assert.Empty(t, delta.Properties)
+	}
+}
+
+func TestDiff_removedInterface(t *testing.T) {
+	ifDescr := "no ifIndex"
+	old := Snapshot{Interfaces: []Interface{newDiffTestInterface(1, "eth0", 100), newDiffTestInterface(2, "eth1", 100)}}
+	// interfaces without ifIndex can't be matched
+	current := Snapshot{Interfaces: []Interface{newDiffTestInterface(1, "eth0", 100), {IfDescr: &ifDescr}}}
+
+	delta, err := Diff(old, current, DiffOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Interface{newDiffTestInterface(2, "eth1", 100)}, delta.RemovedInterfaces)
+		assert.Empty(t, delta.AddedInterfaces)
+		assert.Empty(t, delta.ChangedInterfaces)
+	}
+}
+
+func TestDiff_propertyChange(t *testing.T) {
+	serial, osVersion, newOSVersion, model := "ABC123", "15.2", "15.4", "C2960"
+	old := Snapshot{
+		Device: Device{Class: "ios", Properties: Properties{SerialNumber: &serial, OSVersion: &osVersion}},
+	}
+	current := Snapshot{
+		Device: Device{Class: "ios", Properties: Properties{OSVersion: &newOSVersion, Model: &model}},
+	}
+
+	delta, err := Diff(old, current, DiffOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Change{
+			{Name: "model", New: "C2960"},
+			{Name: "serial_number", Old: "ABC123"},
+			{Name: "os_version", Old: "15.2", New: "15.4"},
+		}, delta.Properties)
+	}
+
+	delta, err = Diff(old, old, DiffOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, delta.IsEmpty())
+	}
+}
+
+func TestDiff_interfaceChange(t *testing.T) {
+	oldInterface := newDiffTestInterface(1, "eth0", 100)
+	newInterface := newDiffTestInterface(1, "eth0", 18446744073709551615)
+	down := StatusDown
+	newInterface.IfOperStatus = &down
+	newInterface.EthernetLike = &EthernetLikeInterface{}
+
+	old := Snapshot{Interfaces: []Interface{oldInterface}}
+	current := Snapshot{Interfaces: []Interface{newInterface}}
+
+	delta, err := Diff(old, current, DiffOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []InterfaceChange{{IfIndex: 1, Changes: []Change{
+			{Name: "ifOperStatus", Old: "up", New: "down"},
+		}}}, delta.ChangedInterfaces)
+	}
+
+	// volatile values are compared if they are requested
+	delta, err = Diff(old, current, DiffOptions{IncludeVolatile: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []InterfaceChange{{IfIndex: 1, Changes: []Change{
+			{Name: "ifHCInOctets", Old: json.Number("100"), New: json.Number("18446744073709551615")},
+			{Name: "ifOperStatus", Old: "up", New: "down"},
+		}}}, delta.ChangedInterfaces)
+	}
+}
@@ -0,0 +1,119 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/rs/zerolog/log"
+	"strconv"
+)
+
+// HealthStatus is the overall health of a device on a common scale for all vendors.
+type HealthStatus string
+
+const (
+	HealthStatusOK       HealthStatus = "ok"
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusFailed   HealthStatus = "failed"
+)
+
+// severity returns the severity of the health status, a higher value is worse.
+func (h HealthStatus) severity() int {
+	switch h {
+	case HealthStatusDegraded:
+		return 1
+	case HealthStatusFailed:
+		return 2
+	}
+	return 0
+}
+
+// HealthSummary
+//
+// HealthSummary is the overall health of a device which is derived from its health related components.
+//
+// swagger:model
+type HealthSummary struct {
+	Status HealthStatus `yaml:"status" json:"status" xml:"status"`
+	// Reasons describe everything that degrades the health of the device, e.g. "fan 2 is critical".
+	Reasons []string `yaml:"reasons,omitempty" json:"reasons,omitempty" xml:"reasons,omitempty"`
+}
+
+// add adds a reason to the summary and raises its status if the status of the reason is worse.
+func (s *HealthSummary) add(status HealthStatus, reason string) {
+	if status == HealthStatusOK {
+		return
+	}
+	if status.severity() > s.Status.severity() {
+		s.Status = status
+	}
+	s.Reasons = append(s.Reasons, reason)
+}
+
+// addHardwareHealthState adds the state of a part of the hardware health. Unknown states don't contribute to the
+// summary, as they don't say anything about the health of the part.
+func (s *HealthSummary) addHardwareHealthState(ctx context.Context, part string, state *HardwareHealthComponentState) {
+	if state == nil {
+		return
+	}
+	var status HealthStatus
+	switch state.GetMonitoringState() {
+	case monitoringplugin.OK:
+		status = HealthStatusOK
+	case monitoringplugin.WARNING:
+		status = HealthStatusDegraded
+	case monitoringplugin.CRITICAL:
+		status = HealthStatusFailed
+	default:
+		log.Ctx(ctx).Debug().Str("part", part).Str("state", string(*state)).Msg("state is unknown, ignoring it for the health summary")
+		return
+	}
+	s.add(status, fmt.Sprintf("%s is %s", part, *state))
+}
+
+// GetHealthSummary returns the overall health of the device, derived from the hardware health and ups components
+// that were already read. Components that are not present don't contribute to the summary, so a device without any
+// health related components is ok.
+func (c *Components) GetHealthSummary(ctx context.Context) HealthSummary {
+	summary := HealthSummary{Status: HealthStatusOK}
+
+	if hardwareHealth := c.HardwareHealth; hardwareHealth != nil {
+		summary.addHardwareHealthState(ctx, "environment monitor", hardwareHealth.EnvironmentMonitorState)
+		for i, fan := range hardwareHealth.Fans {
+			summary.addHardwareHealthState(ctx, "fan "+partName(fan.Description, i), fan.State)
+		}
+		for i, powerSupply := range hardwareHealth.PowerSupply {
+			summary.addHardwareHealthState(ctx, "power supply "+partName(powerSupply.Description, i), powerSupply.State)
+		}
+		for i, temperature := range hardwareHealth.Temperature {
+			summary.addHardwareHealthState(ctx, "temperature sensor "+partName(temperature.Description, i), temperature.State)
+		}
+		for i, voltage := range hardwareHealth.Voltage {
+			summary.addHardwareHealthState(ctx, "voltage sensor "+partName(voltage.Description, i), voltage.State)
+		}
+	}
+
+	if ups := c.UPS; ups != nil {
+		if ups.MainsVoltageApplied != nil && !*ups.MainsVoltageApplied {
+			summary.add(HealthStatusDegraded, "ups is running on battery")
+		}
+		if ups.SelfTestResult != nil {
+			switch *ups.SelfTestResult {
+			case "doneWarning":
+				summary.add(HealthStatusDegraded, "ups self-test finished with a warning")
+			case "doneError":
+				summary.add(HealthStatusFailed, "ups self-test failed")
+			}
+		}
+	}
+
+	return summary
+}
+
+// partName returns the description of a part, or its position in the list of parts if it has no description.
+func partName(description *string, i int) string {
+	if description != nil && *description != "" {
+		return *description
+	}
+	return strconv.Itoa(i + 1)
+}
@@ -0,0 +1,39 @@
+package device
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestComponents_GetHealthSummary(t *testing.T) {
+	normal, warning, critical, unknown := HardwareHealthComponentStateNormal, HardwareHealthComponentStateWarning,
+		HardwareHealthComponentStateCritical, HardwareHealthComponentStateUnknown
+	psu := "PSU A"
+
+	// components which are not present don't contribute
+	var components Components
+	assert.Equal(t, HealthSummary{Status: HealthStatusOK}, components.GetHealthSummary(context.Background()))
+
+	components.HardwareHealth = &HardwareHealthComponent{
+		EnvironmentMonitorState: &normal,
+		Fans:                    []HardwareHealthComponentFan{{State: &normal}, {State: &critical}, {State: &unknown}},
+		PowerSupply:             []HardwareHealthComponentPowerSupply{{Description: &psu, State: &warning}},
+	}
+	assert.Equal(t, HealthSummary{
+		Status:  HealthStatusFailed,
+		Reasons: []string{"fan 2 is critical", "power supply PSU A is warning"},
+	}, components.GetHealthSummary(context.Background()))
+
+	applied, notApplied := true, false
+	doneWarning := "doneWarning"
+	components = Components{UPS: &UPSComponent{MainsVoltageApplied: &notApplied, SelfTestResult: &doneWarning}}
+	assert.Equal(t, HealthSummary{
+		Status:  HealthStatusDegraded,
+		Reasons: []string{"ups is running on battery", "ups self-test finished with a warning"},
+	}, components.GetHealthSummary(context.Background()))
+
+	donePass := "donePass"
+	components = Components{UPS: &UPSComponent{MainsVoltageApplied: &applied, SelfTestResult: &donePass}}
+	assert.Equal(t, HealthSummary{Status: HealthStatusOK}, components.GetHealthSummary(context.Background()))
+}
@@ -0,0 +1,201 @@
+package device
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"reflect"
+	"strings"
+)
+
+// jsonSchemaDraft is the JSON schema version of the generated schema.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schemaComponents are the top level types which are described by the JSON schema, mapped by their key in the schema.
+var schemaComponents = map[string]interface{}{
+	"device":            Device{},
+	"interfaces":        []Interface{},
+	"neighbors":         []Neighbor{},
+	"routes":            []Route{},
+	"cpu":               CPUComponent{},
+	"memory":            MemoryComponent{},
+	"disk":              DiskComponent{},
+	"ups":               UPSComponent{},
+	"server":            ServerComponent{},
+	"sbc":               SBCComponent{},
+	"hardware_health":   HardwareHealthComponent{},
+	"high_availability": HighAvailabilityComponent{},
+	"vlan":              VLANComponent{},
+	"poe":               POEComponent{},
+	"environment":       EnvironmentComponent{},
+	"entity":            EntityComponent{},
+	"wlan":              WLANComponent{},
+	"firewall":          FirewallComponent{},
+}
+
+// JSONSchema returns a JSON schema which describes the device and all of its components.
+// The schema is generated from the component structs, so new fields are part of it automatically.
+// All struct types are listed in the definitions of the schema. Optional fields are not required and may be null.
+func JSONSchema() ([]byte, error) {
+	g := schemaGenerator{
+		definitions: make(map[string]interface{}),
+	}
+
+	properties := make(map[string]interface{})
+	for name, component := range schemaComponents {
+		schema, err := g.typeSchema(reflect.TypeOf(component))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate schema for component '%s'", name)
+		}
+		properties[name] = schema
+	}
+
+	// json.Marshal sorts map keys, so the output is stable
+	res, err := json.MarshalIndent(map[string]interface{}{
+		"$schema":              jsonSchemaDraft,
+		"title":                "Thola",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+		"definitions":          g.definitions,
+	}, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal json schema")
+	}
+	return res, nil
+}
+
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+// typeSchema returns the schema of the given type. Struct types are added to the definitions and referenced.
+func (g *schemaGenerator) typeSchema(t reflect.Type) (map[string]interface{}, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(schema), nil
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := g.definitions[name]; !ok {
+			// add placeholder first, so that recursive types don't loop forever
+			g.definitions[name] = nil
+			schema, err := g.structSchema(t)
+			if err != nil {
+				return nil, err
+			}
+			g.definitions[name] = schema
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + name}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": items}, nil
+	case reflect.Map:
+		values, err := g.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]interface{}{"type": []string{"object", "null"}, "additionalProperties": values}
+		switch t.Key().Kind() {
+		case reflect.String:
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// integer keys are encoded as strings
+			schema["propertyNames"] = map[string]interface{}{"pattern": "^-?[0-9]+$"}
+		default:
+			return nil, fmt.Errorf("unsupported map key type '%s'", t.Key())
+		}
+		return schema, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	}
+	return nil, fmt.Errorf("unsupported type '%s'", t)
+}
+
+// structSchema returns the schema of the given struct type based on the json tags of its fields.
+func (g *schemaGenerator) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	err := g.addStructFields(t, properties, &required)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
+
+func (g *schemaGenerator) addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" || field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		// embedded structs without a json name are inlined
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := g.addStructFields(field.Type, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := g.typeSchema(field.Type)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate schema for field '%s' of '%s'", field.Name, t.Name())
+		}
+		properties[name] = schema
+
+		omitEmpty := len(tag) > 1 && tag[1] == "omitempty"
+		if field.Type.Kind() != reflect.Ptr && !omitEmpty {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// nullable returns a schema which also allows null values in addition to the given schema.
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if types, ok := schema["type"].([]string); ok {
+		for _, typ := range types {
+			if typ == "null" {
+				return schema
+			}
+		}
+	}
+	if typ, ok := schema["type"].(string); ok {
+		res := make(map[string]interface{})
+		for k, v := range schema {
+			res[k] = v
+		}
+		res["type"] = []string{typ, "null"}
+		return res
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
@@ -0,0 +1,211 @@
+package device
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestJSONSchema_stable(t *testing.T) {
+	first, err := JSONSchema()
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := JSONSchema()
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(first), string(second))
+	}
+}
+
+func TestJSONSchema_validate(t *testing.T) {
+	schema := readJSONSchema(t)
+
+	vendor, model := "Cisco", "C9300"
+	ifIndex, ifInOctets := uint64(1), uint64(1024)
+	ifName, status := "Gi0/1", StatusUp
+	cpuLabel, cpuLoad := "0", 12.5
+	batteryAmperage := 1.5
+	mainsVoltageApplied := true
+	sample := map[string]interface{}{
+		"device": Device{
+			Class: "ios",
+			Properties: Properties{
+				Vendor: &vendor,
+				Model:  &model,
+			},
+		},
+		"interfaces": []Interface{
+			{
+				IfIndex:      &ifIndex,
+				IfName:       &ifName,
+				IfOperStatus: &status,
+				IfInOctets:   &ifInOctets,
+				SAP: &SAPInterface{
+					Inbound:    &ifInOctets,
+					OperStatus: &status,
+				},
+			},
+		},
+		"cpu": CPUComponent{
+			CPUs: []CPU{{Label: &cpuLabel, Load: &cpuLoad}},
+		},
+		"ups": UPSComponent{
+			BatteryAmperage:     &batteryAmperage,
+			MainsVoltageApplied: &mainsVoltageApplied,
+		},
+		"vlan": VLANComponent{},
+	}
+
+	assert.NoError(t, validateJSONSchema(schema, schema, toJSONValue(t, sample), "#"))
+
+	invalid := toJSONValue(t, map[string]interface{}{
+		"cpu": map[string]interface{}{
+			"cpus":    []interface{}{map[string]interface{}{"load": "high"}},
+			"unknown": 1,
+		},
+	})
+	assert.Error(t, validateJSONSchema(schema, schema, invalid, "#"))
+}
+
+func readJSONSchema(t *testing.T) map[string]interface{} {
+	b, err := JSONSchema()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var schema map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal(b, &schema)) {
+		t.FailNow()
+	}
+	return schema
+}
+
+func toJSONValue(t *testing.T, i interface{}) interface{} {
+	b, err := json.Marshal(i)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	var res interface{}
+	if !assert.NoError(t, json.Unmarshal(b, &res)) {
+		t.FailNow()
+	}
+	return res
+}
+
+// validateJSONSchema validates the value against the subset of JSON schema keywords used by JSONSchema().
+func validateJSONSchema(root, schema map[string]interface{}, v interface{}, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := regexp.MustCompile(`^#/definitions/(.+)$`).FindStringSubmatch(ref)
+		if name == nil {
+			return fmt.Errorf("%s: invalid reference '%s'", path, ref)
+		}
+		definition, ok := root["definitions"].(map[string]interface{})[name[1]].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: unknown reference '%s'", path, ref)
+		}
+		return validateJSONSchema(root, definition, v, path)
+	}
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for _, s := range anyOf {
+			if validateJSONSchema(root, s.(map[string]interface{}), v, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: value doesn't match any schema", path)
+	}
+
+	if typ, ok := schema["type"]; ok {
+		var types []interface{}
+		if s, ok := typ.(string); ok {
+			types = []interface{}{s}
+		} else {
+			types = typ.([]interface{})
+		}
+		var matches bool
+		for _, t := range types {
+			if jsonValueHasType(v, t.(string)) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return fmt.Errorf("%s: value '%v' is not of type %v", path, v, types)
+		}
+	}
+
+	switch val := v.(type) {
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && val < min {
+			return fmt.Errorf("%s: value %v is less than %v", path, val, min)
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range val {
+				if err := validateJSONSchema(root, items, item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				if _, ok := val[r.(string)]; !ok {
+					return fmt.Errorf("%s: required property '%s' is missing", path, r)
+				}
+			}
+		}
+		for k, item := range val {
+			if names, ok := schema["propertyNames"].(map[string]interface{}); ok {
+				if !regexp.MustCompile(names["pattern"].(string)).MatchString(k) {
+					return fmt.Errorf("%s: invalid property name '%s'", path, k)
+				}
+			}
+			if s, ok := properties[k].(map[string]interface{}); ok {
+				if err := validateJSONSchema(root, s, item, path+"/"+k); err != nil {
+					return err
+				}
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%s: unknown property '%s'", path, k)
+				}
+			case map[string]interface{}:
+				if err := validateJSONSchema(root, additional, item, path+"/"+k); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func jsonValueHasType(v interface{}, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == float64(int64(f))
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+	return false
+}
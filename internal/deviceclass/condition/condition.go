@@ -7,6 +7,7 @@ import (
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
+	"github.com/inexio/thola/internal/value"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -75,6 +76,31 @@ func Interface2Condition(i interface{}, task RelatedTask) (Condition, error) {
 		}
 		return &condition, nil
 	}
+	if stringType == "HttpGetJSON" {
+		var condition httpJSONCondition
+		err := mapstructure.Decode(i, &condition)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode condition")
+		}
+		err = condition.validate()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid http json condition")
+		}
+		return &condition, nil
+	}
+	//SSH
+	if stringType == "SSHCommandOutput" {
+		var condition sshCondition
+		err := mapstructure.Decode(i, &condition)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode condition")
+		}
+		err = condition.validate()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ssh condition")
+		}
+		return &condition, nil
+	}
 
 	if stringType == "Vendor" {
 		if task <= PropertyVendor {
@@ -181,10 +207,21 @@ func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
 		ctx = logger.WithContext(ctx)
 	}
 
+	val, ok, err := s.observe(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return MatchStrings(ctx, val, s.MatchMode, s.Value...)
+}
+
+// observe returns the value of the device that is matched by the condition.
+// If the value is not available, false is returned.
+func (s *snmpCondition) observe(ctx context.Context) (string, bool, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
 		log.Ctx(ctx).Debug().Bool("condition_matched", false).Msg("no snmp connection data available")
-		return false, nil
+		return "", false, nil
 	}
 	var val string
 	var err error
@@ -194,43 +231,43 @@ func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msg("sysDescription is not available for snmp agent")
-				return false, nil
+				return "", false, nil
 			}
-			return false, errors.Wrap(err, "failed to get SysDescription")
+			return "", false, errors.Wrap(err, "failed to get SysDescription")
 		}
 	} else if s.Type == "SysObjectID" {
 		val, err = con.SNMP.GetSysObjectID(ctx)
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msg("sysObjectID is not available for snmp agent")
-				return false, nil
+				return "", false, nil
 			}
-			return false, errors.Wrap(err, "failed to get SysObjectID")
+			return "", false, errors.Wrap(err, "failed to get SysObjectID")
 		}
 	} else if s.Type == "snmpget" {
-		response, err := con.SNMP.SnmpClient.SNMPGet(ctx, s.OID)
+		response, err := con.SNMP.SnmpClient.SNMPGet(s.NewContext(ctx), s.OID)
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msg("snmpget returned no result")
-				return false, nil
+				return "", false, nil
 			}
 			log.Ctx(ctx).Error().Err(err).Msg("error during snmpget")
-			return false, errors.Wrap(err, "snmpget request returned an error")
+			return "", false, errors.Wrap(err, "snmpget request returned an error")
 		}
 		value, err := response[0].GetValueBySNMPGetConfiguration(s.SNMPGetConfiguration)
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
-				return false, nil
+				return "", false, nil
 			}
-			return false, err
+			return "", false, err
 		}
 		val = value.String()
 
 	} else {
-		return false, errors.New("invalid condition type")
+		return "", false, errors.New("invalid condition type")
 	}
 
-	return MatchStrings(ctx, val, s.MatchMode, s.Value...)
+	return val, true, nil
 }
 
 func (s *snmpCondition) validate() error {
@@ -252,6 +289,10 @@ func (s *snmpCondition) validate() error {
 		if err != nil {
 			return errors.New("invalid oid")
 		}
+		err = s.ValidateOperationSettings()
+		if err != nil {
+			return errors.Wrap(err, "invalid snmpget configuration")
+		}
 	} else {
 		// if snmp condition is not "snmpget", SNMPGetConfiguration needs to be a zero value
 		if s.SNMPGetConfiguration != (network.SNMPGetConfiguration{}) {
@@ -334,20 +375,155 @@ func (s *httpCondition) validate() error {
 	return nil
 }
 
+// httpJSONCondition is a condition based on a value of the JSON response of a http(s) request.
+type httpJSONCondition struct {
+	singleCondition `mapstructure:",squash"`
+	URI             string
+	JSONPath        string `mapstructure:"json_path"`
+}
+
+func (s *httpJSONCondition) Check(ctx context.Context) (bool, error) {
+	logger := log.Ctx(ctx).With().Str("condition", "http").Str("condition_type", s.Type).Str("match_mode", string(s.MatchMode)).Str("uri", s.URI).Str("json_path", s.JSONPath).Logger()
+	ctx = logger.WithContext(ctx)
+
+	val, ok, err := s.observe(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return MatchStrings(ctx, val, s.MatchMode, s.Value...)
+}
+
+// observe returns the value at the json path of the response. If the device has no such JSON endpoint or the value is
+// not part of the response, false is returned.
+func (s *httpJSONCondition) observe(ctx context.Context) (string, bool, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.HTTP == nil {
+		log.Ctx(ctx).Debug().Bool("condition_matched", false).Msg("no http connection data available")
+		return "", false, nil
+	}
+
+	body, err := con.HTTP.GetJSON(ctx, s.URI)
+	if err != nil {
+		// devices of other vendors are expected to not have the endpoint
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get json")
+		return "", false, nil
+	}
+	val, err := network.JSONPathValue(body, s.JSONPath)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("json path is not part of the response")
+		return "", false, nil
+	}
+	return value.New(val).String(), true, nil
+}
+
+func (s *httpJSONCondition) ContainsUniqueRequest() bool {
+	return true
+}
+
+func (s *httpJSONCondition) validate() error {
+	err := s.MatchMode.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid matchmode")
+	}
+	if s.Type != "HttpGetJSON" {
+		return errors.New("invalid condition type for http json condition (type = " + s.Type + ")")
+	}
+	if s.URI == "" {
+		return errors.New("uri is missing (type = HttpGetJSON)")
+	}
+	if err := network.ValidateJSONPath(s.JSONPath); err != nil {
+		return errors.Wrap(err, "invalid json_path")
+	}
+	if len(s.Value) == 0 {
+		return errors.New("no values defined")
+	}
+
+	return nil
+}
+
+// sshCondition is a condition based on the output of a cli command which is run via ssh.
+type sshCondition struct {
+	singleCondition `mapstructure:",squash"`
+	Command         string
+}
+
+func (s *sshCondition) Check(ctx context.Context) (bool, error) {
+	logger := log.Ctx(ctx).With().Str("condition", "ssh").Str("condition_type", s.Type).Str("match_mode", string(s.MatchMode)).Str("command", s.Command).Logger()
+	ctx = logger.WithContext(ctx)
+
+	val, ok, err := s.observe(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return MatchStrings(ctx, val, s.MatchMode, s.Value...)
+}
+
+// observe returns the output of the command. If the command cannot be run, false is returned.
+func (s *sshCondition) observe(ctx context.Context) (string, bool, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SSH == nil {
+		log.Ctx(ctx).Debug().Bool("condition_matched", false).Msg("no ssh connection data available")
+		return "", false, nil
+	}
+
+	output, err := con.SSH.SSHClient.RunCommand(ctx, s.Command)
+	if err != nil {
+		// devices of other vendors are expected to reject the command
+		if tholaerr.IsNetworkError(err) {
+			log.Ctx(ctx).Debug().Err(err).Msg("ssh command failed")
+			return "", false, nil
+		}
+		return "", false, errors.Wrap(err, "non-network error during ssh command")
+	}
+	return output, true, nil
+}
+
+func (s *sshCondition) ContainsUniqueRequest() bool {
+	return true
+}
+
+func (s *sshCondition) validate() error {
+	err := s.MatchMode.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid matchmode")
+	}
+	if s.Type != "SSHCommandOutput" {
+		return errors.New("invalid condition type for ssh condition (type = " + s.Type + ")")
+	}
+	if s.Command == "" {
+		return errors.New("command is missing (type = SSHCommandOutput)")
+	}
+	if len(s.Value) == 0 {
+		return errors.New("no values defined")
+	}
+
+	return nil
+}
+
 // vendorCondition is a condition based on a vendor.
 type vendorCondition struct {
 	singleCondition `mapstructure:",squash"`
 }
 
 func (m *vendorCondition) Check(ctx context.Context) (bool, error) {
+	val, _, err := m.observe(ctx)
+	if err != nil {
+		return false, err
+	}
+	return MatchStrings(ctx, val, m.MatchMode, m.Value...)
+}
+
+func (m *vendorCondition) observe(ctx context.Context) (string, bool, error) {
 	properties, ok := device.DevicePropertiesFromContext(ctx)
 	if !ok {
-		return false, errors.New("no properties found in context")
+		return "", false, errors.New("no properties found in context")
 	}
 	if properties.Properties.Vendor == nil {
-		return false, tholaerr.NewPreConditionError("vendor has not yet been determined")
+		return "", false, tholaerr.NewPreConditionError("vendor has not yet been determined")
 	}
-	return MatchStrings(ctx, *properties.Properties.Vendor, m.MatchMode, m.Value...)
+	return *properties.Properties.Vendor, true, nil
 }
 
 func (m *vendorCondition) ContainsUniqueRequest() bool {
@@ -360,14 +536,22 @@ type modelCondition struct {
 }
 
 func (m *modelCondition) Check(ctx context.Context) (bool, error) {
+	val, _, err := m.observe(ctx)
+	if err != nil {
+		return false, err
+	}
+	return MatchStrings(ctx, val, m.MatchMode, m.Value...)
+}
+
+func (m *modelCondition) observe(ctx context.Context) (string, bool, error) {
 	properties, ok := device.DevicePropertiesFromContext(ctx)
 	if !ok {
-		return false, errors.New("no properties found in context")
+		return "", false, errors.New("no properties found in context")
 	}
 	if properties.Properties.Model == nil {
-		return false, tholaerr.NewPreConditionError("model has not yet been determined")
+		return "", false, tholaerr.NewPreConditionError("model has not yet been determined")
 	}
-	return MatchStrings(ctx, *properties.Properties.Model, m.MatchMode, m.Value...)
+	return *properties.Properties.Model, true, nil
 }
 
 func (m *modelCondition) ContainsUniqueRequest() bool {
@@ -380,14 +564,22 @@ type modelSeriesCondition struct {
 }
 
 func (m *modelSeriesCondition) Check(ctx context.Context) (bool, error) {
+	val, _, err := m.observe(ctx)
+	if err != nil {
+		return false, err
+	}
+	return MatchStrings(ctx, val, m.MatchMode, m.Value...)
+}
+
+func (m *modelSeriesCondition) observe(ctx context.Context) (string, bool, error) {
 	properties, ok := device.DevicePropertiesFromContext(ctx)
 	if !ok {
-		return false, errors.New("no properties found in context")
+		return "", false, errors.New("no properties found in context")
 	}
 	if properties.Properties.ModelSeries == nil {
-		return false, tholaerr.NewPreConditionError("model series has not yet been determined")
+		return "", false, tholaerr.NewPreConditionError("model series has not yet been determined")
 	}
-	return MatchStrings(ctx, *properties.Properties.ModelSeries, m.MatchMode, m.Value...)
+	return *properties.Properties.ModelSeries, true, nil
 }
 
 func (m *modelSeriesCondition) ContainsUniqueRequest() bool {
@@ -409,6 +601,61 @@ func GetAlwaysTrueCondition() Condition {
 	return &alwaysTrueCondition{}
 }
 
+// WeakMatchConfidence is the confidence of a condition which does not contain any checks.
+const WeakMatchConfidence = 0.1
+
+// MatchWithConfidence checks the condition and returns the ratio of passed checks to the total amount of checks of
+// the condition in the same pass. In contrast to Check, condition sets are evaluated completely, so every check
+// contributes to the confidence. Errors of checks which Check would have skipped only count as failed checks, so the
+// match result is the same as the one of Check.
+func MatchWithConfidence(ctx context.Context, c Condition) (bool, float64, error) {
+	match, passed, total, err := matchAndCountPassedChecks(ctx, c)
+	if err != nil {
+		return false, 0, err
+	}
+	if total == 0 {
+		return match, WeakMatchConfidence, nil
+	}
+	return match, float64(passed) / float64(total), nil
+}
+
+func matchAndCountPassedChecks(ctx context.Context, c Condition) (bool, int, int, error) {
+	switch cond := c.(type) {
+	case *alwaysTrueCondition:
+		return true, 0, 0, nil
+	case *multipleConditions:
+		match := cond.LogicalOperator == "AND"
+		var passed, total int
+		for _, condition := range cond.Conditions {
+			// the result of the set is already decided if an OR condition matched or an AND condition did not match
+			decided := match == (cond.LogicalOperator == "OR")
+			m, p, t, err := matchAndCountPassedChecks(ctx, condition)
+			if err != nil {
+				if !decided {
+					return false, 0, 0, err
+				}
+				log.Ctx(ctx).Debug().Err(err).Msg("error during match condition after the condition set was decided")
+				m, p, t = false, 0, 1
+			}
+			if !decided {
+				match = m
+			}
+			passed += p
+			total += t
+		}
+		return match, passed, total, nil
+	}
+
+	match, err := c.Check(ctx)
+	if err != nil {
+		return false, 0, 0, errors.Wrap(err, "error during match condition")
+	}
+	if match {
+		return true, 1, 1, nil
+	}
+	return false, 0, 1, nil
+}
+
 type yamlConditionSet struct {
 	LogicalOperator LogicalOperator `mapstructure:"logical_operator"`
 	Conditions      []interface{}
@@ -0,0 +1,269 @@
+package condition
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+type staticCondition bool
+
+func (s staticCondition) Check(_ context.Context) (bool, error) {
+	return bool(s), nil
+}
+
+func (s staticCondition) ContainsUniqueRequest() bool {
+	return false
+}
+
+type errorCondition struct{}
+
+func (errorCondition) Check(_ context.Context) (bool, error) {
+	return false, errors.New("request timeout")
+}
+
+func (errorCondition) ContainsUniqueRequest() bool {
+	return false
+}
+
+func TestMatchWithConfidence(t *testing.T) {
+	cond := multipleConditions{
+		LogicalOperator: "OR",
+		Conditions: []Condition{
+			staticCondition(true),
+			&multipleConditions{
+				LogicalOperator: "AND",
+				Conditions:      []Condition{staticCondition(true), staticCondition(false)},
+			},
+			staticCondition(false),
+		},
+	}
+
+	match, confidence, err := MatchWithConfidence(context.Background(), &cond)
+	if assert.NoError(t, err) {
+		assert.True(t, match)
+		assert.Equal(t, 0.5, confidence)
+	}
+
+	cond.LogicalOperator = "AND"
+	match, confidence, err = MatchWithConfidence(context.Background(), &cond)
+	if assert.NoError(t, err) {
+		assert.False(t, match)
+		assert.Equal(t, 0.5, confidence)
+	}
+}
+
+func TestMatchWithConfidence_error(t *testing.T) {
+	// errors after the condition set is decided don't change the result of Check
+	cond := multipleConditions{
+		LogicalOperator: "OR",
+		Conditions:      []Condition{staticCondition(true), errorCondition{}},
+	}
+	match, confidence, err := MatchWithConfidence(context.Background(), &cond)
+	if assert.NoError(t, err) {
+		assert.True(t, match)
+		assert.Equal(t, 0.5, confidence)
+	}
+
+	cond.Conditions = []Condition{errorCondition{}, staticCondition(true)}
+	_, _, err = MatchWithConfidence(context.Background(), &cond)
+	assert.Error(t, err)
+}
+
+func TestMatchWithConfidence_noConditions(t *testing.T) {
+	match, confidence, err := MatchWithConfidence(context.Background(), GetAlwaysTrueCondition())
+	if assert.NoError(t, err) {
+		assert.True(t, match)
+		assert.Equal(t, WeakMatchConfidence, confidence)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	vendor := "Cisco"
+	ctx := device.NewContextWithDeviceProperties(context.Background(), device.Device{
+		Properties: device.Properties{
+			Vendor: &vendor,
+		},
+	})
+
+	cond := multipleConditions{
+		LogicalOperator: "OR",
+		Conditions: []Condition{
+			&vendorCondition{singleCondition{Type: "Vendor", MatchMode: "equals", Value: []string{"Juniper"}}},
+			&multipleConditions{
+				LogicalOperator: "AND",
+				Conditions: []Condition{
+					&vendorCondition{singleCondition{Type: "Vendor", MatchMode: "startsWith", Value: []string{"Cis"}}},
+					&modelCondition{singleCondition{Type: "Model", MatchMode: "equals", Value: []string{"C9300"}}},
+				},
+			},
+			staticCondition(true),
+		},
+	}
+
+	explanation := Explain(ctx, &cond)
+	assert.True(t, explanation.Matched)
+	assert.Equal(t, "conditionSet", explanation.Type)
+	assert.Equal(t, 0.5, explanation.Confidence())
+
+	// conditions after a failed or matched condition are evaluated as well
+	if assert.Len(t, explanation.Conditions, 3) {
+		assert.False(t, explanation.Conditions[0].Matched)
+		assert.Equal(t, "Vendor", explanation.Conditions[0].Type)
+		if assert.NotNil(t, explanation.Conditions[0].ObservedValue) {
+			assert.Equal(t, "Cisco", *explanation.Conditions[0].ObservedValue)
+		}
+
+		set := explanation.Conditions[1]
+		assert.False(t, set.Matched)
+		if assert.Len(t, set.Conditions, 2) {
+			assert.True(t, set.Conditions[0].Matched)
+			assert.False(t, set.Conditions[1].Matched)
+			assert.Nil(t, set.Conditions[1].ObservedValue)
+			assert.NotEmpty(t, set.Conditions[1].Error)
+		}
+
+		assert.True(t, explanation.Conditions[2].Matched)
+	}
+}
+
+func TestSSHCondition(t *testing.T) {
+	var sshClient network.MockSSHClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SSH: &network.RequestDeviceConnectionSSH{
+			SSHClient: &sshClient,
+		},
+	})
+	sshClient.
+		On("RunCommand", ctx, "uname -s").
+		Return("Linux\n", nil).
+		On("RunCommand", ctx, "show version").
+		Return("", tholaerr.NewSSHError("command 'show version' failed: Process exited with status 127"))
+
+	cond, err := Interface2Condition(map[interface{}]interface{}{
+		"type":       "SSHCommandOutput",
+		"command":    "uname -s",
+		"match_mode": "regex",
+		"values":     []interface{}{"^Linux"},
+	}, ClassifyDevice)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, cond.ContainsUniqueRequest())
+
+	matched, err := cond.Check(ctx)
+	if assert.NoError(t, err) {
+		assert.True(t, matched)
+	}
+
+	explanation := Explain(ctx, cond)
+	assert.True(t, explanation.Matched)
+	assert.Equal(t, "uname -s", explanation.Command)
+
+	// devices that reject the command don't match
+	cond = &sshCondition{singleCondition{Type: "SSHCommandOutput", MatchMode: "contains", Value: []string{"Version"}}, "show version"}
+	matched, err = cond.Check(ctx)
+	if assert.NoError(t, err) {
+		assert.False(t, matched)
+	}
+
+	// no ssh connection
+	matched, err = cond.Check(context.Background())
+	if assert.NoError(t, err) {
+		assert.False(t, matched)
+	}
+
+	_, err = Interface2Condition(map[interface{}]interface{}{
+		"type":       "SSHCommandOutput",
+		"match_mode": "regex",
+		"values":     []interface{}{"^Linux"},
+	}, ClassifyDevice)
+	assert.Error(t, err)
+}
+
+func TestHTTPJSONCondition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/monitor/system/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": {"model_name": "FortiGate", "model": "FGT60F"}, "version": "v7.0.12"}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := network.NewHTTPClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		HTTP: &network.RequestDeviceConnectionHTTP{
+			HTTPClient:     client,
+			ConnectionData: &network.HTTPConnectionData{HTTPPorts: []int{port}},
+		},
+	})
+
+	cond, err := Interface2Condition(map[interface{}]interface{}{
+		"type":       "HttpGetJSON",
+		"uri":        "/api/v2/monitor/system/status",
+		"json_path":  "results.model_name",
+		"match_mode": "equals",
+		"values":     []interface{}{"FortiGate"},
+	}, ClassifyDevice)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, cond.ContainsUniqueRequest())
+
+	matched, err := cond.Check(ctx)
+	if assert.NoError(t, err) {
+		assert.True(t, matched)
+	}
+
+	explanation := Explain(ctx, cond)
+	assert.True(t, explanation.Matched)
+	assert.Equal(t, "results.model_name", explanation.JSONPath)
+	if assert.NotNil(t, explanation.ObservedValue) {
+		assert.Equal(t, "FortiGate", *explanation.ObservedValue)
+	}
+
+	// devices without the endpoint or the value don't match
+	for _, c := range []*httpJSONCondition{
+		{singleCondition{Type: "HttpGetJSON", MatchMode: "equals", Value: []string{"FortiGate"}}, "/api/status", "results.model_name"},
+		{singleCondition{Type: "HttpGetJSON", MatchMode: "equals", Value: []string{"FortiGate"}}, "/api/v2/monitor/system/status", "results.name"},
+	} {
+		matched, err = c.Check(ctx)
+		if assert.NoError(t, err) {
+			assert.False(t, matched)
+		}
+	}
+
+	// no http connection
+	matched, err = cond.Check(context.Background())
+	if assert.NoError(t, err) {
+		assert.False(t, matched)
+	}
+
+	_, err = Interface2Condition(map[interface{}]interface{}{
+		"type":       "HttpGetJSON",
+		"json_path":  "results.model_name",
+		"match_mode": "equals",
+		"values":     []interface{}{"FortiGate"},
+	}, ClassifyDevice)
+	assert.Error(t, err)
+}
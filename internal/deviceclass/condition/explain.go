@@ -0,0 +1,133 @@
+package condition
+
+import (
+	"context"
+)
+
+// Explanation describes how a condition was evaluated for a device.
+type Explanation struct {
+	Type            string        `yaml:"type" json:"type" xml:"type"`
+	LogicalOperator string        `yaml:"logical_operator,omitempty" json:"logical_operator,omitempty" xml:"logical_operator,omitempty"`
+	MatchMode       string        `yaml:"match_mode,omitempty" json:"match_mode,omitempty" xml:"match_mode,omitempty"`
+	OID             string        `yaml:"oid,omitempty" json:"oid,omitempty" xml:"oid,omitempty"`
+	URI             string        `yaml:"uri,omitempty" json:"uri,omitempty" xml:"uri,omitempty"`
+	JSONPath        string        `yaml:"json_path,omitempty" json:"json_path,omitempty" xml:"json_path,omitempty"`
+	Command         string        `yaml:"command,omitempty" json:"command,omitempty" xml:"command,omitempty"`
+	Values          []string      `yaml:"values,omitempty" json:"values,omitempty" xml:"values,omitempty"`
+	ObservedValue   *string       `yaml:"observed_value,omitempty" json:"observed_value,omitempty" xml:"observed_value,omitempty"`
+	Matched         bool          `yaml:"matched" json:"matched" xml:"matched"`
+	Error           string        `yaml:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Conditions      []Explanation `yaml:"conditions,omitempty" json:"conditions,omitempty" xml:"conditions,omitempty"`
+}
+
+// observer is implemented by conditions which match a single value of the device.
+type observer interface {
+	observe(ctx context.Context) (string, bool, error)
+}
+
+// Explain evaluates the condition and returns which parts of it passed or failed together with the observed values.
+// In contrast to Check, condition sets are evaluated completely and errors of single conditions are part of the
+// explanation, so that the full picture is available.
+func Explain(ctx context.Context, c Condition) Explanation {
+	switch cond := c.(type) {
+	case *alwaysTrueCondition:
+		return Explanation{
+			Type:    "alwaysTrue",
+			Matched: true,
+		}
+	case *multipleConditions:
+		explanation := Explanation{
+			Type:            "conditionSet",
+			LogicalOperator: string(cond.LogicalOperator),
+			Matched:         cond.LogicalOperator == "AND",
+		}
+		for _, condition := range cond.Conditions {
+			sub := Explain(ctx, condition)
+			if cond.LogicalOperator == "AND" {
+				explanation.Matched = explanation.Matched && sub.Matched
+			} else {
+				explanation.Matched = explanation.Matched || sub.Matched
+			}
+			explanation.Conditions = append(explanation.Conditions, sub)
+		}
+		return explanation
+	}
+
+	var single *singleCondition
+	var explanation Explanation
+	switch cond := c.(type) {
+	case *snmpCondition:
+		single = &cond.singleCondition
+		explanation.OID = string(cond.OID)
+	case *httpCondition:
+		single = &cond.singleCondition
+		explanation.URI = cond.URI
+	case *httpJSONCondition:
+		single = &cond.singleCondition
+		explanation.URI = cond.URI
+		explanation.JSONPath = cond.JSONPath
+	case *sshCondition:
+		single = &cond.singleCondition
+		explanation.Command = cond.Command
+	case *vendorCondition:
+		single = &cond.singleCondition
+	case *modelCondition:
+		single = &cond.singleCondition
+	case *modelSeriesCondition:
+		single = &cond.singleCondition
+	}
+	if single != nil {
+		explanation.Type = single.Type
+		explanation.MatchMode = string(single.MatchMode)
+		explanation.Values = single.Value
+	}
+
+	var match bool
+	var err error
+	if o, ok := c.(observer); ok && single != nil {
+		var val string
+		var available bool
+		val, available, err = o.observe(ctx)
+		if err == nil && available {
+			explanation.ObservedValue = &val
+			match, err = MatchStrings(ctx, val, single.MatchMode, single.Value...)
+		}
+	} else {
+		match, err = c.Check(ctx)
+	}
+	if err != nil {
+		explanation.Error = err.Error()
+		return explanation
+	}
+	explanation.Matched = match
+	return explanation
+}
+
+// Confidence returns the ratio of passed to total checks of the explanation.
+// It is the same value that MatchWithConfidence returns for the explained condition.
+func (e *Explanation) Confidence() float64 {
+	passed, total := e.countPassedChecks()
+	if total == 0 {
+		return WeakMatchConfidence
+	}
+	return float64(passed) / float64(total)
+}
+
+func (e *Explanation) countPassedChecks() (int, int) {
+	switch e.Type {
+	case "alwaysTrue":
+		return 0, 0
+	case "conditionSet":
+		var passed, total int
+		for _, condition := range e.Conditions {
+			p, t := condition.countPassedChecks()
+			passed += p
+			total += t
+		}
+		return passed, total
+	}
+	if e.Matched {
+		return 1, 1
+	}
+	return 0, 1
+}
@@ -4,22 +4,26 @@ package deviceclass
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/thola/config"
 	"github.com/inexio/thola/config/codecommunicator"
 	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/communicator/hierarchy"
 	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/condition"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 	"io/fs"
 	"io/ioutil"
-	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -36,15 +40,33 @@ type deviceClass struct {
 // deviceClassIdentify represents the identify part of a device class.
 type deviceClassIdentify struct {
 	properties deviceClassIdentifyProperties
+	// stages are the stages in which the identify properties need to be read, so that properties which read other
+	// properties are read after them.
+	stages [][]string
 }
 
 // deviceClassIdentifyProperties represents the identify properties part of a device class.
 type deviceClassIdentifyProperties struct {
-	vendor       property.Reader
-	model        property.Reader
-	modelSeries  property.Reader
-	serialNumber property.Reader
-	osVersion    property.Reader
+	vendor            property.Reader
+	model             property.Reader
+	modelSeries       property.Reader
+	serialNumber      property.Reader
+	osVersion         property.Reader
+	firmwareVersion   property.Reader
+	bootloaderVersion property.Reader
+	sysName           property.Reader
+	sysContact        property.Reader
+	sysLocation       property.Reader
+	sysDescr          property.Reader
+	// modelSeriesFromModel derives the model series from the model if the model series could not be found.
+	modelSeriesFromModel []modelSeriesRule
+}
+
+// modelSeriesRule derives the model series from a model which matches the regex. Capture groups of the regex can be
+// used in the model series, e.g. "Catalyst $1".
+type modelSeriesRule struct {
+	regex       *regexp.Regexp
+	modelSeries string
 }
 
 // deviceClassComponents represents the components part of a device class.
@@ -58,6 +80,11 @@ type deviceClassComponents struct {
 	disk             *deviceClassComponentsDisk
 	hardwareHealth   *deviceClassComponentsHardwareHealth
 	highAvailability *deviceClassComponentsHighAvailability
+	poe              *deviceClassComponentsPOE
+	vlan             *deviceClassComponentsVLAN
+	environment      *deviceClassComponentsEnvironment
+	wlan             *deviceClassComponentsWLAN
+	firewall         *deviceClassComponentsFirewall
 }
 
 // deviceClassComponentsUPS represents the ups components part of a device class.
@@ -73,11 +100,15 @@ type deviceClassComponentsUPS struct {
 	mainsVoltageApplied       property.Reader
 	rectifierCurrent          property.Reader
 	systemVoltage             property.Reader
+	selfTestResult            property.Reader
+	lastTestDate              property.Reader
+	batteryStrings            groupproperty.Reader
 }
 
 // deviceClassComponentsCPU represents the cpu components part of a device class.
 type deviceClassComponentsCPU struct {
 	properties groupproperty.Reader
+	cores      groupproperty.Reader
 }
 
 // deviceClassComponentsMemory represents the memory components part of a device class.
@@ -100,8 +131,14 @@ type deviceClassComponentsSBC struct {
 
 // deviceClassComponentsServer represents the server components part of a device class.
 type deviceClassComponentsServer struct {
-	procs property.Reader
-	users property.Reader
+	procs         property.Reader
+	users         property.Reader
+	uptime        property.Reader
+	loadAverage1  property.Reader
+	loadAverage5  property.Reader
+	loadAverage15 property.Reader
+	swapTotal     property.Reader
+	swapUsed      property.Reader
 }
 
 // deviceClassComponentsDisk represents the disk component part of a device class.
@@ -116,6 +153,9 @@ type deviceClassComponentsHardwareHealth struct {
 	powerSupply             groupproperty.Reader
 	temperature             groupproperty.Reader
 	voltage                 groupproperty.Reader
+
+	// environmentMonitorStates maps the raw environment monitor state values of a device to hardware health states.
+	environmentMonitorStates map[string]device.HardwareHealthComponentState
 }
 
 // deviceClassComponentsHighAvailability represents the high availability part of a device class.
@@ -125,10 +165,44 @@ type deviceClassComponentsHighAvailability struct {
 	nodes property.Reader
 }
 
+// deviceClassComponentsPOE represents the power over ethernet part of a device class.
+type deviceClassComponentsPOE struct {
+	pses  groupproperty.Reader
+	ports groupproperty.Reader
+}
+
+// deviceClassComponentsEnvironment represents the environment sensor part of a device class.
+type deviceClassComponentsEnvironment struct {
+	temperature groupproperty.Reader
+	humidity    groupproperty.Reader
+	dryContacts groupproperty.Reader
+	// sensors reads a table that contains sensors of different types, which are distinguished by their type value
+	sensors groupproperty.Reader
+}
+
+// deviceClassComponentsWLAN represents the wlan part of a device class.
+type deviceClassComponentsWLAN struct {
+	accessPoints groupproperty.Reader
+	clientCount  property.Reader
+}
+
+// deviceClassComponentsFirewall represents the firewall part of a device class.
+type deviceClassComponentsFirewall struct {
+	activeSessions property.Reader
+	vpnTunnels     groupproperty.Reader
+	licenses       groupproperty.Reader
+}
+
+// deviceClassComponentsVLAN represents the vlan part of a device class.
+type deviceClassComponentsVLAN struct {
+	vlans groupproperty.Reader
+}
+
 // deviceClassConfig represents the config part of a device class.
 type deviceClassConfig struct {
-	snmp       deviceClassSNMP
-	components map[component.Component]bool
+	snmp           deviceClassSNMP
+	components     map[component.Component]bool
+	invertCounters bool
 }
 
 // deviceClassComponentsInterfaces represents the interface properties part of a device class.
@@ -141,6 +215,7 @@ type deviceClassComponentsInterfaces struct {
 type deviceClassSNMP struct {
 	MaxRepetitions uint32 `yaml:"max_repetitions"`
 	MaxOids        int    `yaml:"max_oids"`
+	WalkMode       string `yaml:"walk_mode"`
 }
 
 // yamlDeviceClass represents the structure and the parts of a yaml device class.
@@ -168,21 +243,41 @@ type yamlDeviceClassComponents struct {
 	Disk             *yamlComponentsDiskProperties           `yaml:"disk"`
 	HardwareHealth   *yamlComponentsHardwareHealthProperties `yaml:"hardware_health"`
 	HighAvailability *yamlComponentsHighAvailability         `yaml:"high_availability"`
+	POE              *yamlComponentsPOEProperties            `yaml:"poe"`
+	VLAN             *yamlComponentsVLANProperties           `yaml:"vlan"`
+	Environment      *yamlComponentsEnvironmentProperties    `yaml:"environment"`
+	WLAN             *yamlComponentsWLANProperties           `yaml:"wlan"`
+	Firewall         *yamlComponentsFirewallProperties       `yaml:"firewall"`
 }
 
 // yamlDeviceClassConfig represents the config part of a yaml device class.
 type yamlDeviceClassConfig struct {
-	SNMP       deviceClassSNMP `yaml:"snmp"`
-	Components map[string]bool `yaml:"components"`
+	SNMP           deviceClassSNMP `yaml:"snmp"`
+	Components     map[string]bool `yaml:"components"`
+	InvertCounters *bool           `yaml:"invert_counters"`
 }
 
 // yamlDeviceClassIdentifyProperties represents the identify properties of a yaml device class.
 type yamlDeviceClassIdentifyProperties struct {
-	Vendor       []interface{} `yaml:"vendor"`
-	Model        []interface{} `yaml:"model"`
-	ModelSeries  []interface{} `yaml:"model_series"`
-	SerialNumber []interface{} `yaml:"serial_number"`
-	OSVersion    []interface{} `yaml:"os_version"`
+	Vendor            []interface{} `yaml:"vendor"`
+	Model             []interface{} `yaml:"model"`
+	ModelSeries       []interface{} `yaml:"model_series"`
+	SerialNumber      []interface{} `yaml:"serial_number"`
+	OSVersion         []interface{} `yaml:"os_version"`
+	FirmwareVersion   []interface{} `yaml:"firmware_version"`
+	BootloaderVersion []interface{} `yaml:"bootloader_version"`
+	SysName           []interface{} `yaml:"sys_name"`
+	SysContact        []interface{} `yaml:"sys_contact"`
+	SysLocation       []interface{} `yaml:"sys_location"`
+	SysDescr          []interface{} `yaml:"sys_descr"`
+
+	ModelSeriesFromModel []yamlModelSeriesRule `yaml:"model_series_from_model"`
+}
+
+// yamlModelSeriesRule represents a rule of a yaml device class which derives the model series from the model.
+type yamlModelSeriesRule struct {
+	Regex       string `yaml:"regex"`
+	ModelSeries string `yaml:"model_series"`
 }
 
 //
@@ -202,11 +297,15 @@ type yamlComponentsUPSProperties struct {
 	MainsVoltageApplied       []interface{} `yaml:"mains_voltage_applied"`
 	RectifierCurrent          []interface{} `yaml:"rectifier_current"`
 	SystemVoltage             []interface{} `yaml:"system_voltage"`
+	SelfTestResult            []interface{} `yaml:"self_test_result"`
+	LastTestDate              []interface{} `yaml:"last_test_date"`
+	BatteryStrings            interface{}   `yaml:"battery_strings"`
 }
 
 // yamlComponentsCPUProperties represents the specific properties of cpu components of a yaml device class.
 type yamlComponentsCPUProperties struct {
 	Properties interface{} `yaml:"properties"`
+	Cores      interface{} `yaml:"cores"`
 }
 
 // yamlComponentsMemoryProperties represents the specific properties of memory components of a yaml device class.
@@ -229,8 +328,14 @@ type yamlComponentsSBCProperties struct {
 
 // yamlComponentsServerProperties represents the specific properties of server components of a yaml device class.
 type yamlComponentsServerProperties struct {
-	Procs []interface{} `yaml:"procs"`
-	Users []interface{} `yaml:"users"`
+	Procs         []interface{} `yaml:"procs"`
+	Users         []interface{} `yaml:"users"`
+	Uptime        []interface{} `yaml:"uptime"`
+	LoadAverage1  []interface{} `yaml:"load_average_1"`
+	LoadAverage5  []interface{} `yaml:"load_average_5"`
+	LoadAverage15 []interface{} `yaml:"load_average_15"`
+	SwapTotal     []interface{} `yaml:"swap_total"`
+	SwapUsed      []interface{} `yaml:"swap_used"`
 }
 
 // yamlComponentsDiskProperties represents the specific properties of disk components of a yaml device class.
@@ -245,6 +350,9 @@ type yamlComponentsHardwareHealthProperties struct {
 	PowerSupply             interface{}   `yaml:"power_supply"`
 	Temperature             interface{}   `yaml:"temperature"`
 	Voltage                 interface{}   `yaml:"voltage"`
+
+	// EnvironmentMonitorStates declares which raw values of the environment monitor state mean which state.
+	EnvironmentMonitorStates map[device.HardwareHealthComponentState][]int `yaml:"environment_monitor_states"`
 }
 
 // yamlComponentsHa represents the specific properties of HA components of a yaml device class.
@@ -254,6 +362,38 @@ type yamlComponentsHighAvailability struct {
 	Nodes []interface{}
 }
 
+// yamlComponentsPOEProperties represents the specific properties of power over ethernet components of a yaml device class.
+type yamlComponentsPOEProperties struct {
+	PSEs  interface{} `yaml:"pses"`
+	Ports interface{} `yaml:"ports"`
+}
+
+// yamlComponentsEnvironmentProperties represents the specific properties of environment components of a yaml device class.
+type yamlComponentsEnvironmentProperties struct {
+	Temperature interface{} `yaml:"temperature"`
+	Humidity    interface{} `yaml:"humidity"`
+	DryContacts interface{} `yaml:"dry_contacts"`
+	Sensors     interface{} `yaml:"sensors"`
+}
+
+// yamlComponentsWLANProperties represents the specific properties of wlan components of a yaml device class.
+type yamlComponentsWLANProperties struct {
+	AccessPoints interface{}   `yaml:"access_points"`
+	ClientCount  []interface{} `yaml:"client_count"`
+}
+
+// yamlComponentsFirewallProperties represents the specific properties of firewall components of a yaml device class.
+type yamlComponentsFirewallProperties struct {
+	ActiveSessions []interface{} `yaml:"active_sessions"`
+	VPNTunnels     interface{}   `yaml:"vpn_tunnels"`
+	Licenses       interface{}   `yaml:"licenses"`
+}
+
+// yamlComponentsVLANProperties represents the specific properties of vlan components of a yaml device class.
+type yamlComponentsVLANProperties struct {
+	VLANs interface{} `yaml:"vlans"`
+}
+
 //
 // Here are definitions of interfaces of yaml device classes.
 //
@@ -265,19 +405,67 @@ type yamlComponentsInterfaces struct {
 
 // GetHierarchy returns the hierarchy of device classes merged with their corresponding code communicator.
 func GetHierarchy() (hierarchy.Hierarchy, error) {
-	genericDeviceClassDir := "deviceclass"
-	genericDeviceClassFile, err := config.FileSystem.Open(filepath.Join(genericDeviceClassDir, "generic.yaml"))
+	return GetHierarchyFromFS(config.FileSystem, "deviceclass")
+}
+
+// GetHierarchyFromFS returns the hierarchy of the device classes in the given directory of the file system. The
+// directory needs to have the same structure as the embedded "deviceclass" directory, starting with a "generic.yaml".
+// The device classes are validated before, if they are invalid the cause of the returned error is a *ValidationError
+// with all problems. If a device class file can't be read in anyway, the cause of the returned error is a *FileError.
+func GetHierarchyFromFS(fsys fs.FS, genericDeviceClassDir string) (hierarchy.Hierarchy, error) {
+	if err := ValidateFS(fsys, genericDeviceClassDir); err != nil {
+		return hierarchy.Hierarchy{}, err
+	}
+	genericDeviceClassFile, err := fsys.Open(filepath.Join(genericDeviceClassDir, "generic.yaml"))
 	if err != nil {
 		return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to open generic device class file")
 	}
-	hier, err := yamlFile2Hierarchy(genericDeviceClassFile, genericDeviceClassDir, nil, nil)
+	hier, err := yamlFile2Hierarchy(fsys, genericDeviceClassFile, genericDeviceClassDir, nil, nil)
 	if err != nil {
 		return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to read in generic device class")
 	}
 	return hier, nil
 }
 
-func yamlFile2Hierarchy(file fs.File, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (hierarchy.Hierarchy, error) {
+// FileError is returned if a device class file can't be read in. Line is 0 if the position of the error
+// in the file is unknown. Path is the yaml path of the error in the file, e.g. "components.cpu.properties", and
+// empty if it is unknown.
+type FileError struct {
+	File    string
+	Line    int
+	Path    string
+	Message string
+}
+
+func (e *FileError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	if e.Path != "" {
+		location += ": " + e.Path
+	}
+	return location + ": " + e.Message
+}
+
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+):`)
+
+// newFileError creates a new FileError. The line is taken from the error message, which is only possible for errors
+// of the yaml parser.
+func newFileError(file string, err error) *FileError {
+	fileErr := FileError{
+		File:    file,
+		Message: err.Error(),
+	}
+	if matches := yamlErrorLineRegex.FindStringSubmatch(err.Error()); matches != nil {
+		fileErr.Line, _ = strconv.Atoi(matches[1])
+	}
+	return &fileErr
+}
+
+func yamlFile2Hierarchy(fsys fs.FS, file fs.File, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (hierarchy.Hierarchy, error) {
+	defer file.Close()
+
 	//get file info
 	fileInfo, err := file.Stat()
 	if err != nil {
@@ -287,6 +475,7 @@ func yamlFile2Hierarchy(file fs.File, directory string, parentDeviceClass *devic
 	if !strings.HasSuffix(fileInfo.Name(), ".yaml") {
 		return hierarchy.Hierarchy{}, errors.New("only yaml files are allowed for this function")
 	}
+	fullPathToFile := filepath.Join(directory, fileInfo.Name())
 
 	contents, err := ioutil.ReadAll(file)
 	if err != nil {
@@ -295,12 +484,12 @@ func yamlFile2Hierarchy(file fs.File, directory string, parentDeviceClass *devic
 	var deviceClassYaml yamlDeviceClass
 	err = yaml.Unmarshal(contents, &deviceClassYaml)
 	if err != nil {
-		return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to unmarshal config file")
+		return hierarchy.Hierarchy{}, errors.Wrap(newFileError(fullPathToFile, err), "failed to unmarshal config file")
 	}
 
 	devClass, err := deviceClassYaml.convert(parentDeviceClass)
 	if err != nil {
-		return hierarchy.Hierarchy{}, errors.Wrapf(err, "failed to convert yamlData to deviceClass for device class '%s'", deviceClassYaml.Name)
+		return hierarchy.Hierarchy{}, errors.Wrapf(newFileError(fullPathToFile, err), "failed to convert yamlData to deviceClass for device class '%s'", deviceClassYaml.Name)
 	}
 
 	networkDeviceCommunicator, err := createNetworkDeviceCommunicator(&devClass, parentCommunicator)
@@ -315,13 +504,13 @@ func yamlFile2Hierarchy(file fs.File, directory string, parentDeviceClass *devic
 
 	// check for sub device classes
 	subDirPath := filepath.Join(directory, devClass.name)
-	subDir, err := config.FileSystem.ReadDir(subDirPath)
+	subDir, err := fs.ReadDir(fsys, subDirPath)
 	if err != nil {
-		if !os.IsNotExist(err) {
+		if !errors.Is(err, fs.ErrNotExist) {
 			return hierarchy.Hierarchy{}, errors.Wrap(err, "an unexpected error occurred while trying to open sub device class directory")
 		}
 	} else {
-		subHierarchies, err := readDeviceClassDirectory(subDir, subDirPath, &devClass, networkDeviceCommunicator)
+		subHierarchies, err := readDeviceClassDirectory(fsys, subDir, subDirPath, &devClass, networkDeviceCommunicator)
 		if err != nil {
 			return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to read sub device classes")
 		}
@@ -337,10 +526,10 @@ func createNetworkDeviceCommunicator(devClass *deviceClass, parentCommunicator c
 	if err != nil && !tholaerr.IsNotFoundError(err) {
 		return nil, errors.Wrap(err, "failed to get code communicator")
 	}
-	return communicator.CreateNetworkDeviceCommunicator(&(deviceClassCommunicator{devClass}), codeCommunicator), nil
+	return communicator.CreateNetworkDeviceCommunicator(&(deviceClassCommunicator{devClass}), codeCommunicator, parentCommunicator), nil
 }
 
-func readDeviceClassDirectory(dir []fs.DirEntry, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (map[string]hierarchy.Hierarchy, error) {
+func readDeviceClassDirectory(fsys fs.FS, dir []fs.DirEntry, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (map[string]hierarchy.Hierarchy, error) {
 	deviceClasses := make(map[string]hierarchy.Hierarchy)
 	for _, dirEntry := range dir {
 		// directories will be ignored here, sub device classes dirs will be called when
@@ -358,11 +547,11 @@ func readDeviceClassDirectory(dir []fs.DirEntry, directory string, parentDeviceC
 			return nil, errors.New("only yaml config files are allowed in device class directories")
 		}
 		fullPathToFile := filepath.Join(directory, fileInfo.Name())
-		file, err := config.FileSystem.Open(fullPathToFile)
+		file, err := fsys.Open(fullPathToFile)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to open file "+fullPathToFile)
 		}
-		hier, err := yamlFile2Hierarchy(file, directory, parentDeviceClass, parentCommunicator)
+		hier, err := yamlFile2Hierarchy(fsys, file, directory, parentDeviceClass, parentCommunicator)
 		if err != nil {
 			return nil, errors.Wrapf(err, "an error occurred while trying to read in yaml config file %s", fileInfo.Name())
 		}
@@ -382,11 +571,26 @@ func (d *deviceClass) matchDevice(ctx context.Context) (bool, error) {
 	return d.match.Check(ctx)
 }
 
+// matchWithConfidence checks if data in context matches the device class and returns how confident it matches.
+func (d *deviceClass) matchWithConfidence(ctx context.Context) (bool, float64, error) {
+	return condition.MatchWithConfidence(ctx, d.match)
+}
+
+// explainMatch returns how the data in context was matched against the device class.
+func (d *deviceClass) explainMatch(ctx context.Context) condition.Explanation {
+	return condition.Explain(ctx, d.match)
+}
+
 // getAvailableComponents returns the available components.
 func (d *deviceClass) getAvailableComponents() map[component.Component]bool {
 	return d.config.components
 }
 
+// hasInvertedInterfaceCounters returns whether the in and out counters of the interfaces are swapped on the device.
+func (d *deviceClass) hasInvertedInterfaceCounters() bool {
+	return d.config.invertCounters
+}
+
 func (y *yamlDeviceClass) convert(parent *deviceClass) (deviceClass, error) {
 	err := y.validate()
 	if err != nil {
@@ -404,9 +608,11 @@ func (y *yamlDeviceClass) convert(parent *deviceClass) (deviceClass, error) {
 	devClass.name += y.Name
 	if y.Name == "generic" {
 		devClass.match = condition.GetAlwaysTrueCondition()
-		devClass.identify = deviceClassIdentify{
-			properties: deviceClassIdentifyProperties{},
+		identify, err := y.Identify.convert(deviceClassIdentify{})
+		if err != nil {
+			return deviceClass{}, errors.Wrap(err, "failed to convert identify")
 		}
+		devClass.identify = identify
 	} else {
 		cond, err := condition.Interface2Condition(y.Match, condition.ClassifyDevice)
 		if err != nil {
@@ -460,6 +666,11 @@ func (y *yamlDeviceClassIdentify) convert(parentIdentify deviceClassIdentify) (d
 	}
 	identify.properties = prop
 
+	identify.stages, err = prop.stages()
+	if err != nil {
+		return deviceClassIdentify{}, errors.Wrap(err, "failed to determine stages of identify properties")
+	}
+
 	return identify, nil
 }
 
@@ -538,6 +749,46 @@ func (y *yamlDeviceClassComponents) convert(parentComponents deviceClassComponen
 		components.highAvailability = &ha
 	}
 
+	if y.POE != nil {
+		poe, err := y.POE.convert(parentComponents.poe)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml poe properties")
+		}
+		components.poe = &poe
+	}
+
+	if y.VLAN != nil {
+		vlan, err := y.VLAN.convert(parentComponents.vlan)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml vlan properties")
+		}
+		components.vlan = &vlan
+	}
+
+	if y.Environment != nil {
+		environment, err := y.Environment.convert(parentComponents.environment)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml environment properties")
+		}
+		components.environment = &environment
+	}
+
+	if y.WLAN != nil {
+		wlan, err := y.WLAN.convert(parentComponents.wlan)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml wlan properties")
+		}
+		components.wlan = &wlan
+	}
+
+	if y.Firewall != nil {
+		firewall, err := y.Firewall.convert(parentComponents.firewall)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml firewall properties")
+		}
+		components.firewall = &firewall
+	}
+
 	return components, nil
 }
 
@@ -607,9 +858,159 @@ func (y *yamlDeviceClassIdentifyProperties) convert(parentProperties deviceClass
 			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert osVersion property to property reader")
 		}
 	}
+	if y.FirmwareVersion != nil {
+		prop.firmwareVersion, err = property.InterfaceSlice2Reader(y.FirmwareVersion, condition.PropertyDefault, prop.firmwareVersion)
+		if err != nil {
+			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert firmwareVersion property to property reader")
+		}
+	}
+	if y.BootloaderVersion != nil {
+		prop.bootloaderVersion, err = property.InterfaceSlice2Reader(y.BootloaderVersion, condition.PropertyDefault, prop.bootloaderVersion)
+		if err != nil {
+			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert bootloaderVersion property to property reader")
+		}
+	}
+	if y.SysName != nil {
+		prop.sysName, err = property.InterfaceSlice2Reader(y.SysName, condition.PropertyDefault, prop.sysName)
+		if err != nil {
+			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert sysName property to property reader")
+		}
+	}
+	if y.SysContact != nil {
+		prop.sysContact, err = property.InterfaceSlice2Reader(y.SysContact, condition.PropertyDefault, prop.sysContact)
+		if err != nil {
+			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert sysContact property to property reader")
+		}
+	}
+	if y.SysLocation != nil {
+		prop.sysLocation, err = property.InterfaceSlice2Reader(y.SysLocation, condition.PropertyDefault, prop.sysLocation)
+		if err != nil {
+			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert sysLocation property to property reader")
+		}
+	}
+	if y.SysDescr != nil {
+		prop.sysDescr, err = property.InterfaceSlice2Reader(y.SysDescr, condition.PropertyDefault, prop.sysDescr)
+		if err != nil {
+			return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert sysDescr property to property reader")
+		}
+	}
+	if y.ModelSeriesFromModel != nil {
+		prop.modelSeriesFromModel = nil
+		for _, rule := range y.ModelSeriesFromModel {
+			r, err := rule.convert()
+			if err != nil {
+				return deviceClassIdentifyProperties{}, errors.Wrap(err, "failed to convert model series from model rule")
+			}
+			prop.modelSeriesFromModel = append(prop.modelSeriesFromModel, r)
+		}
+	}
 	return prop, nil
 }
 
+func (y *yamlModelSeriesRule) convert() (modelSeriesRule, error) {
+	if y.Regex == "" {
+		return modelSeriesRule{}, errors.New("regex is missing")
+	}
+	if y.ModelSeries == "" {
+		return modelSeriesRule{}, errors.New("model series is missing")
+	}
+	regex, err := regexp.Compile(y.Regex)
+	if err != nil {
+		return modelSeriesRule{}, errors.Wrap(err, "regex compile failed")
+	}
+	return modelSeriesRule{
+		regex:       regex,
+		modelSeries: y.ModelSeries,
+	}, nil
+}
+
+// deriveModelSeries returns the model series of the first rule that matches the model.
+func (d *deviceClassIdentifyProperties) deriveModelSeries(model string) (string, bool) {
+	for _, rule := range d.modelSeriesFromModel {
+		match := rule.regex.FindStringSubmatchIndex(model)
+		if match == nil {
+			continue
+		}
+		modelSeries := strings.TrimSpace(string(rule.regex.ExpandString(nil, rule.modelSeries, model, match)))
+		if modelSeries != "" {
+			return modelSeries, true
+		}
+	}
+	return "", false
+}
+
+// readers returns the property readers mapped by the name of their identify property.
+func (d *deviceClassIdentifyProperties) readers() map[string]property.Reader {
+	return map[string]property.Reader{
+		"vendor":             d.vendor,
+		"model":              d.model,
+		"model_series":       d.modelSeries,
+		"serial_number":      d.serialNumber,
+		"os_version":         d.osVersion,
+		"firmware_version":   d.firmwareVersion,
+		"bootloader_version": d.bootloaderVersion,
+		"sys_name":           d.sysName,
+		"sys_contact":        d.sysContact,
+		"sys_location":       d.sysLocation,
+		"sys_descr":          d.sysDescr,
+	}
+}
+
+// stages returns the identify properties in the stages in which they need to be read, so that every property is read
+// in a later stage than the properties it depends on. The properties of a stage don't depend on each other and can be
+// read concurrently. Within a stage, the default order of device.IdentifyProperties is kept.
+// An error is returned if the properties depend on each other in a cycle.
+func (d *deviceClassIdentifyProperties) stages() ([][]string, error) {
+	dependencies := make(map[string][]string)
+	for name, reader := range d.readers() {
+		if reader != nil {
+			dependencies[name] = property.Dependencies(reader)
+		}
+	}
+	if len(d.modelSeriesFromModel) > 0 {
+		dependencies["model_series"] = append(dependencies["model_series"], "model")
+	}
+
+	// the stage of a property is the length of the longest path of dependencies starting at it
+	levels := make(map[string]int)
+	var resolve func(name string, path []string) (int, error)
+	resolve = func(name string, path []string) (int, error) {
+		if level, ok := levels[name]; ok {
+			return level, nil
+		}
+		for i, p := range path {
+			if p == name {
+				return 0, fmt.Errorf("cyclic dependency between identify properties: %s", strings.Join(append(path[i:], name), " -> "))
+			}
+		}
+		level := 0
+		for _, dependency := range dependencies[name] {
+			l, err := resolve(dependency, append(path, name))
+			if err != nil {
+				return 0, err
+			}
+			if l+1 > level {
+				level = l + 1
+			}
+		}
+		levels[name] = level
+		return level, nil
+	}
+
+	var stages [][]string
+	for _, name := range device.IdentifyProperties {
+		level, err := resolve(name, nil)
+		if err != nil {
+			return nil, err
+		}
+		for len(stages) <= level {
+			stages = append(stages, nil)
+		}
+		stages[level] = append(stages[level], name)
+	}
+	return stages, nil
+}
+
 func (y *yamlDeviceClassConfig) convert(parentConfig deviceClassConfig) (deviceClassConfig, error) {
 	err := y.validate()
 	if err != nil {
@@ -623,6 +1024,12 @@ func (y *yamlDeviceClassConfig) convert(parentConfig deviceClassConfig) (deviceC
 		cfg.snmp.MaxRepetitions = parentConfig.snmp.MaxRepetitions
 	}
 	cfg.snmp.MaxOids = utility.IfThenElseInt(y.SNMP.MaxOids != 0, y.SNMP.MaxOids, parentConfig.snmp.MaxOids)
+	cfg.snmp.WalkMode = utility.IfThenElseString(y.SNMP.WalkMode != "", y.SNMP.WalkMode, parentConfig.snmp.WalkMode)
+
+	cfg.invertCounters = parentConfig.invertCounters
+	if y.InvertCounters != nil {
+		cfg.invertCounters = *y.InvertCounters
+	}
 
 	components := make(map[component.Component]bool)
 	for k, v := range parentConfig.components {
@@ -646,6 +1053,9 @@ func (y *yamlDeviceClassConfig) validate() error {
 	if y.SNMP.MaxOids < 0 {
 		return errors.New("invalid snmp max oids")
 	}
+	if err := network.ValidateSNMPWalkMode(y.SNMP.WalkMode); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -722,6 +1132,24 @@ func (y *yamlComponentsUPSProperties) convert(parentComponent *deviceClassCompon
 			return deviceClassComponentsUPS{}, errors.Wrap(err, "failed to convert system voltage property to property reader")
 		}
 	}
+	if y.SelfTestResult != nil {
+		prop.selfTestResult, err = property.InterfaceSlice2Reader(y.SelfTestResult, condition.PropertyDefault, prop.selfTestResult)
+		if err != nil {
+			return deviceClassComponentsUPS{}, errors.Wrap(err, "failed to convert self test result property to property reader")
+		}
+	}
+	if y.LastTestDate != nil {
+		prop.lastTestDate, err = property.InterfaceSlice2Reader(y.LastTestDate, condition.PropertyDefault, prop.lastTestDate)
+		if err != nil {
+			return deviceClassComponentsUPS{}, errors.Wrap(err, "failed to convert last test date property to property reader")
+		}
+	}
+	if y.BatteryStrings != nil {
+		prop.batteryStrings, err = groupproperty.Interface2Reader(y.BatteryStrings, prop.batteryStrings)
+		if err != nil {
+			return deviceClassComponentsUPS{}, errors.Wrap(err, "failed to convert battery strings property to group property reader")
+		}
+	}
 	return prop, nil
 }
 
@@ -739,6 +1167,12 @@ func (y *yamlComponentsCPUProperties) convert(parentComponent *deviceClassCompon
 			return deviceClassComponentsCPU{}, errors.Wrap(err, "failed to convert load property to property reader")
 		}
 	}
+	if y.Cores != nil {
+		prop.cores, err = groupproperty.Interface2Reader(y.Cores, prop.cores)
+		if err != nil {
+			return deviceClassComponentsCPU{}, errors.Wrap(err, "failed to convert cores property to property reader")
+		}
+	}
 	return prop, nil
 }
 
@@ -774,11 +1208,47 @@ func (y *yamlComponentsServerProperties) convert(parentComponent *deviceClassCom
 		}
 	}
 	if y.Users != nil {
-		prop.users, err = property.InterfaceSlice2Reader(y.Users, condition.PropertyDefault, prop.procs)
+		prop.users, err = property.InterfaceSlice2Reader(y.Users, condition.PropertyDefault, prop.users)
 		if err != nil {
 			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert users property to property reader")
 		}
 	}
+	if y.Uptime != nil {
+		prop.uptime, err = property.InterfaceSlice2Reader(y.Uptime, condition.PropertyDefault, prop.uptime)
+		if err != nil {
+			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert uptime property to property reader")
+		}
+	}
+	if y.LoadAverage1 != nil {
+		prop.loadAverage1, err = property.InterfaceSlice2Reader(y.LoadAverage1, condition.PropertyDefault, prop.loadAverage1)
+		if err != nil {
+			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert load average 1 property to property reader")
+		}
+	}
+	if y.LoadAverage5 != nil {
+		prop.loadAverage5, err = property.InterfaceSlice2Reader(y.LoadAverage5, condition.PropertyDefault, prop.loadAverage5)
+		if err != nil {
+			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert load average 5 property to property reader")
+		}
+	}
+	if y.LoadAverage15 != nil {
+		prop.loadAverage15, err = property.InterfaceSlice2Reader(y.LoadAverage15, condition.PropertyDefault, prop.loadAverage15)
+		if err != nil {
+			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert load average 15 property to property reader")
+		}
+	}
+	if y.SwapTotal != nil {
+		prop.swapTotal, err = property.InterfaceSlice2Reader(y.SwapTotal, condition.PropertyDefault, prop.swapTotal)
+		if err != nil {
+			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert swap total property to property reader")
+		}
+	}
+	if y.SwapUsed != nil {
+		prop.swapUsed, err = property.InterfaceSlice2Reader(y.SwapUsed, condition.PropertyDefault, prop.swapUsed)
+		if err != nil {
+			return deviceClassComponentsServer{}, errors.Wrap(err, "failed to convert swap used property to property reader")
+		}
+	}
 	return prop, nil
 }
 
@@ -903,6 +1373,21 @@ func (y *yamlComponentsHardwareHealthProperties) convert(parentHardwareHealth *d
 			return deviceClassComponentsHardwareHealth{}, errors.Wrap(err, "failed to convert environment monitor state property to property reader")
 		}
 	}
+	if y.EnvironmentMonitorStates != nil {
+		prop.environmentMonitorStates = make(map[string]device.HardwareHealthComponentState)
+		for state, values := range y.EnvironmentMonitorStates {
+			if _, err := state.GetInt(); err != nil {
+				return deviceClassComponentsHardwareHealth{}, errors.Wrap(err, "invalid environment monitor state mapping")
+			}
+			for _, value := range values {
+				v := strconv.Itoa(value)
+				if mapped, ok := prop.environmentMonitorStates[v]; ok {
+					return deviceClassComponentsHardwareHealth{}, fmt.Errorf("environment monitor state value '%s' is mapped to both '%s' and '%s'", v, mapped, state)
+				}
+				prop.environmentMonitorStates[v] = state
+			}
+		}
+	}
 
 	return prop, nil
 }
@@ -938,3 +1423,135 @@ func (y *yamlComponentsHighAvailability) convert(parentHA *deviceClassComponents
 
 	return prop, nil
 }
+
+func (y *yamlComponentsPOEProperties) convert(parentPOE *deviceClassComponentsPOE) (deviceClassComponentsPOE, error) {
+	var prop deviceClassComponentsPOE
+	var err error
+
+	if parentPOE != nil {
+		prop = *parentPOE
+	}
+
+	if y.PSEs != nil {
+		prop.pses, err = groupproperty.Interface2Reader(y.PSEs, prop.pses)
+		if err != nil {
+			return deviceClassComponentsPOE{}, errors.Wrap(err, "failed to convert pses property to group property reader")
+		}
+	}
+	if y.Ports != nil {
+		prop.ports, err = groupproperty.Interface2Reader(y.Ports, prop.ports)
+		if err != nil {
+			return deviceClassComponentsPOE{}, errors.Wrap(err, "failed to convert ports property to group property reader")
+		}
+	}
+
+	return prop, nil
+}
+
+func (y *yamlComponentsEnvironmentProperties) convert(parentEnvironment *deviceClassComponentsEnvironment) (deviceClassComponentsEnvironment, error) {
+	var prop deviceClassComponentsEnvironment
+	var err error
+
+	if parentEnvironment != nil {
+		prop = *parentEnvironment
+	}
+
+	if y.Temperature != nil {
+		prop.temperature, err = groupproperty.Interface2Reader(y.Temperature, prop.temperature)
+		if err != nil {
+			return deviceClassComponentsEnvironment{}, errors.Wrap(err, "failed to convert temperature property to group property reader")
+		}
+	}
+	if y.Humidity != nil {
+		prop.humidity, err = groupproperty.Interface2Reader(y.Humidity, prop.humidity)
+		if err != nil {
+			return deviceClassComponentsEnvironment{}, errors.Wrap(err, "failed to convert humidity property to group property reader")
+		}
+	}
+	if y.DryContacts != nil {
+		prop.dryContacts, err = groupproperty.Interface2Reader(y.DryContacts, prop.dryContacts)
+		if err != nil {
+			return deviceClassComponentsEnvironment{}, errors.Wrap(err, "failed to convert dry contacts property to group property reader")
+		}
+	}
+	if y.Sensors != nil {
+		prop.sensors, err = groupproperty.Interface2Reader(y.Sensors, prop.sensors)
+		if err != nil {
+			return deviceClassComponentsEnvironment{}, errors.Wrap(err, "failed to convert sensors property to group property reader")
+		}
+	}
+
+	return prop, nil
+}
+
+func (y *yamlComponentsWLANProperties) convert(parentWLAN *deviceClassComponentsWLAN) (deviceClassComponentsWLAN, error) {
+	var prop deviceClassComponentsWLAN
+	var err error
+
+	if parentWLAN != nil {
+		prop = *parentWLAN
+	}
+
+	if y.AccessPoints != nil {
+		prop.accessPoints, err = groupproperty.Interface2Reader(y.AccessPoints, prop.accessPoints)
+		if err != nil {
+			return deviceClassComponentsWLAN{}, errors.Wrap(err, "failed to convert access points property to group property reader")
+		}
+	}
+	if y.ClientCount != nil {
+		prop.clientCount, err = property.InterfaceSlice2Reader(y.ClientCount, condition.PropertyDefault, prop.clientCount)
+		if err != nil {
+			return deviceClassComponentsWLAN{}, errors.Wrap(err, "failed to convert client count property to property reader")
+		}
+	}
+
+	return prop, nil
+}
+
+func (y *yamlComponentsFirewallProperties) convert(parentFirewall *deviceClassComponentsFirewall) (deviceClassComponentsFirewall, error) {
+	var prop deviceClassComponentsFirewall
+	var err error
+
+	if parentFirewall != nil {
+		prop = *parentFirewall
+	}
+
+	if y.ActiveSessions != nil {
+		prop.activeSessions, err = property.InterfaceSlice2Reader(y.ActiveSessions, condition.PropertyDefault, prop.activeSessions)
+		if err != nil {
+			return deviceClassComponentsFirewall{}, errors.Wrap(err, "failed to convert active sessions property to property reader")
+		}
+	}
+	if y.VPNTunnels != nil {
+		prop.vpnTunnels, err = groupproperty.Interface2Reader(y.VPNTunnels, prop.vpnTunnels)
+		if err != nil {
+			return deviceClassComponentsFirewall{}, errors.Wrap(err, "failed to convert vpn tunnels property to group property reader")
+		}
+	}
+	if y.Licenses != nil {
+		prop.licenses, err = groupproperty.Interface2Reader(y.Licenses, prop.licenses)
+		if err != nil {
+			return deviceClassComponentsFirewall{}, errors.Wrap(err, "failed to convert licenses property to group property reader")
+		}
+	}
+
+	return prop, nil
+}
+
+func (y *yamlComponentsVLANProperties) convert(parentVLAN *deviceClassComponentsVLAN) (deviceClassComponentsVLAN, error) {
+	var prop deviceClassComponentsVLAN
+	var err error
+
+	if parentVLAN != nil {
+		prop = *parentVLAN
+	}
+
+	if y.VLANs != nil {
+		prop.vlans, err = groupproperty.Interface2Reader(y.VLANs, prop.vlans)
+		if err != nil {
+			return deviceClassComponentsVLAN{}, errors.Wrap(err, "failed to convert vlans property to group property reader")
+		}
+	}
+
+	return prop, nil
+}
@@ -2,17 +2,28 @@ package deviceclass
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/component"
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/condition"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/mapping"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"math"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 type deviceClassCommunicator struct {
@@ -46,10 +57,22 @@ func (o *deviceClassCommunicator) HasComponent(component component.Component) bo
 	return false
 }
 
+func (o *deviceClassCommunicator) HasInvertedInterfaceCounters() bool {
+	return o.hasInvertedInterfaceCounters()
+}
+
 func (o *deviceClassCommunicator) Match(ctx context.Context) (bool, error) {
 	return o.matchDevice(ctx)
 }
 
+func (o *deviceClassCommunicator) MatchWithConfidence(ctx context.Context) (bool, float64, error) {
+	return o.matchWithConfidence(ctx)
+}
+
+func (o *deviceClassCommunicator) ExplainMatch(ctx context.Context) (condition.Explanation, error) {
+	return o.explainMatch(ctx), nil
+}
+
 func (o *deviceClassCommunicator) UpdateConnection(ctx context.Context) error {
 	if conn, ok := network.DeviceConnectionFromContext(ctx); ok {
 		if conn.SNMP != nil && conn.SNMP.SnmpClient != nil {
@@ -58,6 +81,9 @@ func (o *deviceClassCommunicator) UpdateConnection(ctx context.Context) error {
 				conn.SNMP.SnmpClient.SetMaxRepetitions(o.deviceClass.config.snmp.MaxRepetitions)
 			}
 
+			log.Ctx(ctx).Debug().Str("walk_mode", o.deviceClass.config.snmp.WalkMode).Msg("set snmp walk mode of device class")
+			conn.SNMP.WalkMode = network.SNMPWalkMode(o.deviceClass.config.snmp.WalkMode)
+
 			if conn.SNMP.SnmpClient.GetVersion() != "1" {
 				log.Ctx(ctx).Debug().Int("max_oids", o.deviceClass.config.snmp.MaxOids).Msg("set snmp max oids of device class")
 				err := conn.SNMP.SnmpClient.SetMaxOIDs(o.deviceClass.config.snmp.MaxOids)
@@ -71,87 +97,77 @@ func (o *deviceClassCommunicator) UpdateConnection(ctx context.Context) error {
 }
 
 func (o *deviceClassCommunicator) GetIdentifyProperties(ctx context.Context) (device.Properties, error) {
-	dev := device.Device{
-		Class:      o.GetIdentifier(),
-		Properties: device.Properties{},
-	}
+	return communicator.ReadIdentifyProperties(ctx, o.GetIdentifier(), o.GetIdentifyPropertiesStages(), o)
+}
 
-	vendor, err := o.GetVendor(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get vendor")
-		}
-	} else {
-		dev.Properties.Vendor = &vendor
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
+// GetIdentifyPropertiesStages returns the stages in which the identify properties of the device class need to be read.
+func (o *deviceClassCommunicator) GetIdentifyPropertiesStages() [][]string {
+	if o.identify.stages == nil {
+		return [][]string{device.IdentifyProperties}
 	}
+	return o.identify.stages
+}
 
-	model, err := o.GetModel(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get model")
-		}
-	} else {
-		dev.Properties.Model = &model
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
+func (o *deviceClassCommunicator) GetDiskComponent(ctx context.Context) (device.DiskComponent, error) {
+	if !o.HasComponent(component.Disk) {
+		return device.DiskComponent{}, tholaerr.NewComponentNotFoundError("no disk component available for this device")
 	}
 
-	modelSeries, err := o.GetModelSeries(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get model series")
-		}
-	} else {
-		dev.Properties.ModelSeries = &modelSeries
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
-	}
+	var disk device.DiskComponent
 
-	serialNumber, err := o.GetSerialNumber(ctx)
+	empty := true
+
+	storages, err := o.GetDiskComponentStorages(ctx)
 	if err != nil {
 		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get serial number")
+			return device.DiskComponent{}, errors.Wrap(err, "error occurred during get disk component storages")
 		}
 	} else {
-		dev.Properties.SerialNumber = &serialNumber
-		ctx = device.NewContextWithDeviceProperties(ctx, dev)
+		disk.Storages = storages
+		empty = false
 	}
 
-	osVersion, err := o.GetOSVersion(ctx)
-	if err != nil {
-		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.Properties{}, errors.Wrap(err, "error occurred during get os version")
-		}
-	} else {
-		dev.Properties.OSVersion = &osVersion
+	if empty {
+		return device.DiskComponent{}, tholaerr.NewNotFoundError("no disk data available")
 	}
 
-	return dev.Properties, nil
+	return disk, nil
 }
 
-func (o *deviceClassCommunicator) GetDiskComponent(ctx context.Context) (device.DiskComponent, error) {
-	if !o.HasComponent(component.Disk) {
-		return device.DiskComponent{}, tholaerr.NewComponentNotFoundError("no disk component available for this device")
+func (o *deviceClassCommunicator) GetPOEComponent(ctx context.Context) (device.POEComponent, error) {
+	if !o.HasComponent(component.POE) {
+		return device.POEComponent{}, tholaerr.NewComponentNotFoundError("no poe component available for this device")
 	}
 
-	var disk device.DiskComponent
+	var poe device.POEComponent
 
 	empty := true
 
-	storages, err := o.GetDiskComponentStorages(ctx)
+	pses, err := o.GetPOEComponentPSEs(ctx)
 	if err != nil {
 		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
-			return device.DiskComponent{}, errors.Wrap(err, "error occurred during get disk component storages")
+			return device.POEComponent{}, errors.Wrap(err, "error occurred during get poe component pses")
 		}
 	} else {
-		disk.Storages = storages
+		poe.PSEs = pses
+		empty = false
+	}
+
+	ports, err := o.GetPOEComponentPorts(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.POEComponent{}, errors.Wrap(err, "error occurred during get poe component ports")
+		}
+	} else {
+		poe.Ports = ports
 		empty = false
 	}
 
 	if empty {
-		return device.DiskComponent{}, tholaerr.NewNotFoundError("no disk data available")
+		return device.POEComponent{}, tholaerr.NewNotFoundError("no poe data available")
 	}
 
-	return disk, nil
+	return poe, nil
 }
 
 func (o *deviceClassCommunicator) GetUPSComponent(ctx context.Context) (device.UPSComponent, error) {
@@ -272,6 +288,36 @@ func (o *deviceClassCommunicator) GetUPSComponent(ctx context.Context) (device.U
 		empty = false
 	}
 
+	selfTestResult, err := o.GetUPSComponentSelfTestResult(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.UPSComponent{}, errors.Wrap(err, "error occurred during get self test result")
+		}
+	} else {
+		ups.SelfTestResult = &selfTestResult
+		empty = false
+	}
+
+	lastTestDate, err := o.GetUPSComponentLastTestDate(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.UPSComponent{}, errors.Wrap(err, "error occurred during get last test date")
+		}
+	} else {
+		ups.LastTestDate = &lastTestDate
+		empty = false
+	}
+
+	batteryStrings, err := o.GetUPSComponentBatteryStrings(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.UPSComponent{}, errors.Wrap(err, "error occurred during get battery strings")
+		}
+	} else if len(batteryStrings) > 0 {
+		ups.BatteryStrings = batteryStrings
+		empty = false
+	}
+
 	if empty {
 		return device.UPSComponent{}, tholaerr.NewNotFoundError("no ups data available")
 	}
@@ -307,6 +353,66 @@ func (o *deviceClassCommunicator) GetServerComponent(ctx context.Context) (devic
 		empty = false
 	}
 
+	uptime, err := o.GetServerComponentUptime(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component uptime")
+		}
+	} else {
+		server.Uptime = &uptime
+		empty = false
+	}
+
+	loadAverage1, err := o.GetServerComponentLoadAverage1(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component load average 1")
+		}
+	} else {
+		server.LoadAverage1 = &loadAverage1
+		empty = false
+	}
+
+	loadAverage5, err := o.GetServerComponentLoadAverage5(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component load average 5")
+		}
+	} else {
+		server.LoadAverage5 = &loadAverage5
+		empty = false
+	}
+
+	loadAverage15, err := o.GetServerComponentLoadAverage15(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component load average 15")
+		}
+	} else {
+		server.LoadAverage15 = &loadAverage15
+		empty = false
+	}
+
+	swapTotal, err := o.GetServerComponentSwapTotal(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component swap total")
+		}
+	} else {
+		server.SwapTotal = &swapTotal
+		empty = false
+	}
+
+	swapUsed, err := o.GetServerComponentSwapUsed(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.ServerComponent{}, errors.Wrap(err, "error occurred during get server component swap used")
+		}
+	} else {
+		server.SwapUsed = &swapUsed
+		empty = false
+	}
+
 	if empty {
 		return device.ServerComponent{}, tholaerr.NewNotFoundError("no server data available")
 	}
@@ -417,6 +523,8 @@ func (o *deviceClassCommunicator) GetSBCComponent(ctx context.Context) (device.S
 		return device.SBCComponent{}, tholaerr.NewNotFoundError("no sbc data available")
 	}
 
+	sbc.SessionUtilization = sbc.GetSessionUtilization()
+
 	return sbc, nil
 }
 
@@ -430,7 +538,13 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponent(ctx context.Context
 	empty := true
 
 	state, err := o.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
-	if err != nil {
+	var unknownStateErr device.UnknownHardwareHealthStateError
+	if errors.As(err, &unknownStateErr) {
+		unknown := device.HardwareHealthComponentStateUnknown
+		hardwareHealth.EnvironmentMonitorState = &unknown
+		hardwareHealth.EnvironmentMonitorStateRaw = &unknownStateErr.Raw
+		empty = false
+	} else if err != nil {
 		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
 			return device.HardwareHealthComponent{}, errors.Wrap(err, "error occurred during get environment monitor states")
 		}
@@ -459,6 +573,16 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponent(ctx context.Context
 		empty = false
 	}
 
+	humidity, err := o.GetHardwareHealthComponentHumidity(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.HardwareHealthComponent{}, errors.Wrap(err, "error occurred during get humidity")
+		}
+	} else {
+		hardwareHealth.Humidity = humidity
+		empty = false
+	}
+
 	if empty {
 		return device.HardwareHealthComponent{}, tholaerr.NewNotFoundError("no sbc data available")
 	}
@@ -517,144 +641,702 @@ func (o *deviceClassCommunicator) GetHighAvailabilityComponent(ctx context.Conte
 	return ha, nil
 }
 
-func (o *deviceClassCommunicator) GetVendor(ctx context.Context) (string, error) {
-	if o.identify.properties.vendor == nil {
-		log.Ctx(ctx).Debug().Str("property", "vendor").Str("device_class", o.name).Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
+func (o *deviceClassCommunicator) GetVLANComponent(ctx context.Context) (device.VLANComponent, error) {
+	if !o.HasComponent(component.VLAN) {
+		return device.VLANComponent{}, tholaerr.NewComponentNotFoundError("no vlan component available for this device")
 	}
-	logger := log.Ctx(ctx).With().Str("property", "vendor").Logger()
-	ctx = logger.WithContext(ctx)
-	vendor, err := o.identify.properties.vendor.GetProperty(ctx)
+
+	var vlan device.VLANComponent
+
+	empty := true
+
+	vlans, err := o.GetVLANComponentVLANs(ctx)
 	if err != nil {
-		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get vendor")
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.VLANComponent{}, errors.Wrap(err, "error occurred during get vlan component vlans")
+		}
+	} else {
+		vlan.VLANs = vlans
+		empty = false
+	}
+
+	portMembership, err := o.GetVLANComponentPortMembership(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.VLANComponent{}, errors.Wrap(err, "error occurred during get vlan component port membership")
+		}
+	} else {
+		vlan.PortMembership = device.NewVLANPortMemberships(portMembership)
+		empty = false
 	}
 
-	return strings.TrimSpace(vendor.String()), nil
+	if empty {
+		return device.VLANComponent{}, tholaerr.NewComponentNotFoundError("no vlan data available, device does not support bridge mibs")
+	}
+
+	return vlan, nil
+}
+
+func (o *deviceClassCommunicator) GetVendor(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.vendor, "vendor")
 }
 
 func (o *deviceClassCommunicator) GetModel(ctx context.Context) (string, error) {
-	if o.identify.properties.model == nil {
-		log.Ctx(ctx).Debug().Str("property", "model").Str("device_class", o.name).Msg("no detection information available")
+	return o.getStringProperty(ctx, o.identify.properties.model, "model")
+}
+
+// GetModelSeries reads the model series. If the model series is not found, it is derived from the model with the
+// model series rules of the device class.
+func (o *deviceClassCommunicator) GetModelSeries(ctx context.Context) (string, error) {
+	modelSeries, err := o.getStringProperty(ctx, o.identify.properties.modelSeries, "model_series")
+	if err == nil || len(o.identify.properties.modelSeriesFromModel) == 0 {
+		return modelSeries, err
+	}
+	if (!tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err)) || property.NetworkError(err) != nil {
+		return "", err
+	}
+
+	var model string
+	if properties, ok := device.DevicePropertiesFromContext(ctx); ok && properties.Properties.Model != nil {
+		model = *properties.Properties.Model
+	} else {
+		model, err = o.GetModel(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get model to derive model series")
+		}
+	}
+
+	modelSeries, ok := o.identify.properties.deriveModelSeries(model)
+	if !ok {
+		return "", tholaerr.NewNotFoundError("model series not found and no rule matched the model '" + model + "'")
+	}
+	log.Ctx(ctx).Debug().Str("model", model).Str("model_series", modelSeries).Msg("derived model series from model")
+	return modelSeries, nil
+}
+
+func (o *deviceClassCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.serialNumber, "serial_number")
+}
+
+func (o *deviceClassCommunicator) GetOSVersion(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.osVersion, "osVersion")
+}
+
+func (o *deviceClassCommunicator) GetFirmwareVersion(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.firmwareVersion, "firmwareVersion")
+}
+
+func (o *deviceClassCommunicator) GetBootloaderVersion(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.bootloaderVersion, "bootloaderVersion")
+}
+
+func (o *deviceClassCommunicator) GetSysName(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.sysName, "sysName")
+}
+
+func (o *deviceClassCommunicator) GetSysContact(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.sysContact, "sysContact")
+}
+
+func (o *deviceClassCommunicator) GetSysLocation(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.sysLocation, "sysLocation")
+}
+
+func (o *deviceClassCommunicator) GetSysDescr(ctx context.Context) (string, error) {
+	return o.getStringProperty(ctx, o.identify.properties.sysDescr, "sysDescr")
+}
+
+// getStringProperty reads one of the string identify properties. Empty values are reported as EmptyValueError, which
+// is also a NotFoundError, so that callers can retry them if the agent is not ready yet.
+func (o *deviceClassCommunicator) getStringProperty(ctx context.Context, reader property.Reader, name string) (string, error) {
+	if reader == nil {
+		log.Ctx(ctx).Debug().Str("property", name).Str("device_class", o.name).Msg("no detection information available")
 		return "", tholaerr.NewNotImplementedError("no detection information available")
 	}
-	logger := log.Ctx(ctx).With().Str("property", "model").Logger()
+	logger := log.Ctx(ctx).With().Str("property", name).Logger()
 	ctx = logger.WithContext(ctx)
-	model, err := o.identify.properties.model.GetProperty(ctx)
+	res, err := reader.GetProperty(ctx)
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get model")
+		return "", errors.Wrapf(err, "failed to get %s", name)
 	}
 
-	return strings.TrimSpace(model.String()), nil
+	val := strings.TrimSpace(res.String())
+	if val == "" {
+		return "", tholaerr.NewEmptyValueError(name + " is empty")
+	}
+	return val, nil
 }
 
-func (o *deviceClassCommunicator) GetModelSeries(ctx context.Context) (string, error) {
-	if o.identify.properties.modelSeries == nil {
-		log.Ctx(ctx).Debug().Str("property", "model_series").Str("device_class", o.name).Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
+func (o *deviceClassCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	if o.components.interfaces == nil || o.components.interfaces.properties == nil {
+		log.Ctx(ctx).Debug().Str("property", "interfaces").Str("device_class", o.name).Msg("no interface information available")
+		return nil, tholaerr.NewNotImplementedError("not implemented")
+	}
+
+	interfacesRaw, indices, err := o.components.interfaces.properties.GetProperty(ctx, filter...)
+	if err != nil {
+		return nil, err
+	}
+
+	var interfaces []device.Interface
+
+	err = interfacesRaw.Decode(&interfaces)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode raw interfaces into interface structs")
+	}
+
+	err = normalizeInterfaces(interfaces, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.HasComponent(component.VLAN) && !groupproperty.CheckValueFiltersMatch(filter, []string{"vlan"}) {
+		addInterfaceVLANMembership(ctx, interfaces)
 	}
-	logger := log.Ctx(ctx).With().Str("property", "model_series").Logger()
+
+	if !groupproperty.CheckValueFiltersMatch(filter, []string{"ip_addresses"}) {
+		addInterfaceIPAddresses(ctx, interfaces)
+	}
+
+	ipv4Stats := !groupproperty.CheckValueFiltersMatch(filter, []string{"ipv4_stats"})
+	ipv6Stats := !groupproperty.CheckValueFiltersMatch(filter, []string{"ipv6_stats"})
+	if ipv4Stats || ipv6Stats {
+		addInterfaceIPStats(ctx, interfaces, ipv4Stats, ipv6Stats)
+	}
+
+	return interfaces, nil
+}
+
+// normalizeInterfaces sets missing ifIndices from the SNMP indices, normalizes the ifSpeed and the admin and oper
+// status, and sets the octet counters from the 64-bit high capacity counters if available.
+func normalizeInterfaces(interfaces []device.Interface, indices []value.Value) error {
+	for i, interf := range interfaces {
+		if interf.IfIndex == nil {
+			ifIndex, err := indices[i].UInt64()
+			if err != nil {
+				return errors.Wrap(err, "failed to get ifIndex from SNMP index")
+			}
+			interfaces[i].IfIndex = &ifIndex
+		}
+		interfaces[i].IfTypeName = normalizeIfTypeName(interf.IfType)
+		interfaces[i].IfSpeed = normalizeIfSpeed(interf.IfSpeed, interf.IfHighSpeed)
+		interfaces[i].IfAdminStatus = normalizeStatus(interf.IfAdminStatus)
+		interfaces[i].IfOperStatus = normalizeStatus(interf.IfOperStatus)
+
+		inOctets, inHC := selectHCCounter(interf.IfHCInOctets, interf.IfInOctets)
+		outOctets, outHC := selectHCCounter(interf.IfHCOutOctets, interf.IfOutOctets)
+		interfaces[i].IfInOctets = inOctets
+		interfaces[i].IfOutOctets = outOctets
+		// the counters are only marked as 64-bit if none of them is a 32-bit counter
+		interfaces[i].CountersAre64Bit = (inHC || outHC) && (inHC || inOctets == nil) && (outHC || outOctets == nil)
+	}
+	return nil
+}
+
+// normalizeIfTypeName returns the name of an ifType in the IANAifType registry. ifTypes which are not in the registry
+// are named "unknown(<ifType>)". If the device class already returns a name instead of the number, it is kept.
+func normalizeIfTypeName(ifType *string) *string {
+	if ifType == nil {
+		return nil
+	}
+	name := *ifType
+	if _, err := strconv.ParseUint(*ifType, 10, 64); err != nil {
+		return &name
+	}
+	name, err := mapping.GetMappedValue("ifType.yaml", *ifType)
+	if err != nil {
+		name = fmt.Sprintf("unknown(%s)", *ifType)
+	}
+	return &name
+}
+
+// normalizeIfSpeed returns the speed of an interface in bit/s.
+// ifSpeed is saturated at MaxUint32 for interfaces faster than 4.2 Gbit/s and some devices report 0 for LAGs, so
+// the ifHighSpeed (Mbit/s) is used in these cases. If the ifSpeed is saturated and no ifHighSpeed is available,
+// the speed is unknown and nil is returned.
+func normalizeIfSpeed(ifSpeed, ifHighSpeed *uint64) *uint64 {
+	if ifSpeed != nil && *ifSpeed != 0 && *ifSpeed != math.MaxUint32 {
+		return ifSpeed
+	}
+	if ifHighSpeed != nil && *ifHighSpeed > 0 {
+		speed := *ifHighSpeed * 1000000
+		return &speed
+	}
+	if ifSpeed != nil && *ifSpeed == math.MaxUint32 {
+		return nil
+	}
+	return ifSpeed
+}
+
+// normalizeStatus converts status codes and differently spelled labels, which device classes may return, into the
+// status constants.
+func normalizeStatus(status *device.Status) *device.Status {
+	if status == nil {
+		return nil
+	}
+	normalized := status.Normalize()
+	return &normalized
+}
+
+// selectHCCounter returns the 64-bit counter if it is available, otherwise the 32-bit counter.
+// Some devices return 0 for high capacity counters they don't support, so a 64-bit counter which is 0 is only used
+// if the 32-bit counter is not available or also 0.
+func selectHCCounter(hcCounter, counter *uint64) (*uint64, bool) {
+	if hcCounter != nil && (*hcCounter != 0 || counter == nil || *counter == 0) {
+		return hcCounter, true
+	}
+	return counter, false
+}
+
+// GetCountInterfaces returns the count of interfaces of the device. The count property of the device class, which is
+// ifNumber by default, is read first. If it is not available, the count of ifIndex values is used, so that the whole
+// interface table doesn't need to be read.
+func (o *deviceClassCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
+	if o.components.interfaces == nil {
+		log.Ctx(ctx).Debug().Str("property", "countInterfaces").Str("device_class", o.name).Msg("no interface count information available")
+		return 0, tholaerr.NewNotImplementedError("not implemented")
+	}
+
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		log.Ctx(ctx).Debug().Msg("snmp client is empty")
+		return 0, errors.New("snmp client is empty")
+	}
+
+	if o.components.interfaces.count != nil {
+		res, err := o.components.interfaces.count.GetProperty(ctx)
+		if err == nil {
+			if responseInt, err := res.Int(); err == nil {
+				return responseInt, nil
+			}
+			log.Ctx(ctx).Debug().Str("response", res.String()).Msg("could not parse interfaces count to int, counting ifIndex values")
+		} else {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to get interfaces count, counting ifIndex values")
+		}
+	}
+
+	res, err := network.SNMPWalkCachedWithMode(ctx, con.SNMP.SnmpClient, "1.3.6.1.2.1.2.2.1.1", con.GetSNMPWalkMode())
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to walk ifIndex")
+	}
+	if len(res) == 0 {
+		return 0, errors.New("no ifIndex values found")
+	}
+	return len(res), nil
+}
+
+// GetVLANComponentVLANs returns the VLANs of the device.
+// If the device class doesn't define the vlans, they are read out of the Q-BRIDGE-MIB.
+func (o *deviceClassCommunicator) GetVLANComponentVLANs(ctx context.Context) ([]device.VLAN, error) {
+	if o.components.vlan == nil || o.components.vlan.vlans == nil {
+		return getQBridgeVLANs(ctx)
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "VLANComponentVLANs").Logger()
 	ctx = logger.WithContext(ctx)
-	modelSeries, err := o.identify.properties.modelSeries.GetProperty(ctx)
+	res, indices, err := o.components.vlan.vlans.GetProperty(ctx)
 	if err != nil {
-		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get model_series")
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var vlans []device.VLAN
+	err = mapstructure.WeakDecode(res, &vlans)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into vlan struct")
+	}
+
+	// most vlan tables are indexed by the vlan id, so the index is used if no id was read out
+	for i, vlan := range vlans {
+		if vlan.ID == nil && i < len(indices) {
+			id, err := getVLANIDFromIndex(indices[i].String())
+			if err != nil {
+				return nil, err
+			}
+			vlans[i].ID = &id
+		}
+	}
+	return vlans, nil
+}
+
+// getQBridgeVLANs returns the VLANs of the device, read out of the Q-BRIDGE-MIB.
+func getQBridgeVLANs(ctx context.Context) ([]device.VLAN, error) {
+	// index of dot1qVlanCurrentTable is dot1qVlanTimeMark.dot1qVlanIndex
+	currentVLANs, err := walkSNMPColumn(ctx, "1.3.6.1.2.1.17.7.1.4.2.1.4", true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read dot1qVlanCurrentEgressPorts")
+	}
+	staticNames, err := walkSNMPColumn(ctx, "1.3.6.1.2.1.17.7.1.4.3.1.1", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read dot1qVlanStaticName")
+	}
+	if len(currentVLANs) == 0 && len(staticNames) == 0 {
+		return nil, tholaerr.NewNotFoundError("no vlans found in Q-BRIDGE-MIB")
+	}
+	statuses, err := walkSNMPColumn(ctx, "1.3.6.1.2.1.17.7.1.4.2.1.6", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read dot1qVlanStatus")
+	}
+
+	vlans := make(map[int]device.VLAN)
+	for idx := range currentVLANs {
+		id, err := getVLANIDFromIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		vlan := device.VLAN{ID: &id}
+		if status, ok := qBridgeVLANStatus[statuses[idx]]; ok {
+			vlan.Status = &status
+		}
+		vlans[id] = vlan
+	}
+	for idx, name := range staticNames {
+		id, err := getVLANIDFromIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		name := name
+		vlan := vlans[id]
+		vlan.ID = &id
+		vlan.Name = &name
+		vlans[id] = vlan
+	}
+
+	res := make([]device.VLAN, 0, len(vlans))
+	for _, vlan := range vlans {
+		res = append(res, vlan)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return *res[i].ID < *res[j].ID
+	})
+	return res, nil
+}
+
+// qBridgeVLANStatus maps the values of dot1qVlanStatus to vlan states.
+var qBridgeVLANStatus = map[string]string{
+	"1": "other",
+	"2": "permanent",
+	"3": "dynamicGvrp",
+}
+
+// GetVLANComponentPortMembership returns the VLAN IDs per ifIndex, read out of the Q-BRIDGE-MIB.
+func (o *deviceClassCommunicator) GetVLANComponentPortMembership(ctx context.Context) (map[int][]int, error) {
+	res, err := getQBridgePortMembership(ctx, "1.3.6.1.2.1.17.7.1.4.2.1.4", "dot1qVlanCurrentEgressPorts")
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, tholaerr.NewNotFoundError("no vlan port membership found in Q-BRIDGE-MIB")
+	}
+	return res, nil
+}
+
+// getQBridgePortMembership returns the VLAN IDs per ifIndex, read out of the given port list column of the
+// dot1qVlanCurrentTable.
+func getQBridgePortMembership(ctx context.Context, oid network.OID, name string) (map[int][]int, error) {
+	portLists, err := walkSNMPColumn(ctx, oid, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", name)
+	}
+	if len(portLists) == 0 {
+		return nil, nil
+	}
+
+	// the port lists contain bridge port numbers, which need to be mapped to ifIndices
+	basePortIfIndices, err := walkSNMPColumn(ctx, "1.3.6.1.2.1.17.1.4.1.2", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read dot1dBasePortIfIndex")
+	}
+
+	res := make(map[int][]int)
+	for idx, raw := range portLists {
+		vlanID, err := getVLANIDFromIndex(idx)
+		if err != nil {
+			return nil, err
+		}
+		portList, err := hex.DecodeString(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s is not a valid hex string", name)
+		}
+		for _, port := range decodePortList(portList) {
+			ifIndex := port
+			if i, ok := basePortIfIndices[fmt.Sprint(port)]; ok {
+				ifIndex, err = strconv.Atoi(i)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to parse dot1dBasePortIfIndex")
+				}
+			}
+			res[ifIndex] = append(res[ifIndex], vlanID)
+		}
+	}
+
+	for _, vlanIDs := range res {
+		sort.Ints(vlanIDs)
+	}
+	return res, nil
+}
+
+// addInterfaceVLANMembership adds the tagged and untagged VLANs of the Q-BRIDGE-MIB to the interfaces.
+// The VLAN membership is optional, so errors are only logged.
+func addInterfaceVLANMembership(ctx context.Context, interfaces []device.Interface) {
+	egress, err := getQBridgePortMembership(ctx, "1.3.6.1.2.1.17.7.1.4.2.1.4", "dot1qVlanCurrentEgressPorts")
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read vlan port membership")
+		return
+	}
+	if len(egress) == 0 {
+		return
+	}
+	untagged, err := getQBridgePortMembership(ctx, "1.3.6.1.2.1.17.7.1.4.2.1.5", "dot1qVlanCurrentUntaggedPorts")
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read untagged vlan port membership")
+		return
+	}
+
+	for i, interf := range interfaces {
+		if interf.IfIndex == nil {
+			continue
+		}
+		ifIndex := int(*interf.IfIndex)
+		vlanIDs, ok := egress[ifIndex]
+		if !ok {
+			continue
+		}
+
+		untaggedVLANs := make(map[int]bool)
+		for _, id := range untagged[ifIndex] {
+			untaggedVLANs[id] = true
+		}
+
+		if interfaces[i].VLAN == nil {
+			interfaces[i].VLAN = &device.VLANInformation{}
+		}
+		for _, id := range vlanIDs {
+			if untaggedVLANs[id] {
+				interfaces[i].VLAN.UntaggedVLANs = append(interfaces[i].VLAN.UntaggedVLANs, id)
+			} else {
+				interfaces[i].VLAN.TaggedVLANs = append(interfaces[i].VLAN.TaggedVLANs, id)
+			}
+		}
+	}
+}
+
+// getVLANIDFromIndex returns the VLAN ID, which is the last part of the index of Q-BRIDGE-MIB vlan tables.
+func getVLANIDFromIndex(idx string) (int, error) {
+	idxParts := strings.Split(idx, ".")
+	id, err := strconv.Atoi(idxParts[len(idxParts)-1])
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid vlan index '%s'", idx)
+	}
+	return id, nil
+}
+
+// decodePortList decodes a Q-BRIDGE-MIB PortList into port numbers.
+// Each octet represents eight ports, the most significant bit of the first octet is port 1.
+func decodePortList(portList []byte) []int {
+	var ports []int
+	for i, octet := range portList {
+		for bit := 0; bit < 8; bit++ {
+			if octet&(0x80>>bit) != 0 {
+				ports = append(ports, i*8+bit+1)
+			}
+		}
 	}
+	return ports
+}
 
-	return strings.TrimSpace(modelSeries.String()), nil
+// GetRoutingTable returns the routing table of the device, read out of the IP-FORWARD-MIB.
+func (o *deviceClassCommunicator) GetRoutingTable(ctx context.Context) ([]device.Route, error) {
+	return getIPForwardRoutingTable(ctx)
 }
 
-func (o *deviceClassCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
-	if o.identify.properties.serialNumber == nil {
-		log.Ctx(ctx).Debug().Str("property", "serial_number").Str("device_class", o.name).Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "serial_number").Logger()
-	ctx = logger.WithContext(ctx)
-	serialNumber, err := o.identify.properties.serialNumber.GetProperty(ctx)
+// GetCountRoutes returns the count of routes of the device, read out of the IP-FORWARD-MIB.
+func (o *deviceClassCommunicator) GetCountRoutes(ctx context.Context) (int, error) {
+	return getIPForwardRouteCount(ctx)
+}
+
+// GetEntityComponent returns the physical entities of the device, read out of the ENTITY-MIB.
+func (o *deviceClassCommunicator) GetEntityComponent(ctx context.Context) (device.EntityComponent, error) {
+	entities, err := readEntityPhysicalTable(ctx)
 	if err != nil {
-		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get serial_number")
+		return device.EntityComponent{}, err
 	}
+	if len(entities) == 0 {
+		return device.EntityComponent{}, tholaerr.NewNotFoundError("no physical entities available")
+	}
+	return device.EntityComponent{Entities: entities}, nil
+}
 
-	return strings.TrimSpace(serialNumber.String()), nil
+// GetConfigBackup is not supported by device classes, as copying the configuration of a device is vendor specific
+// and needs write operations. It has to be implemented by a code communicator.
+func (o *deviceClassCommunicator) GetConfigBackup(_ context.Context, _ device.ConfigBackupDestination) (device.ConfigBackup, error) {
+	return device.ConfigBackup{}, tholaerr.NewNotImplementedError("config backup is not supported for this device class")
 }
 
-func (o *deviceClassCommunicator) GetOSVersion(ctx context.Context) (string, error) {
-	if o.identify.properties.osVersion == nil {
-		log.Ctx(ctx).Debug().Str("property", "osVersion").Str("device_class", o.name).Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
+// ifAdminStatusOID is the ifAdminStatus of the ifTable of the IF-MIB.
+const ifAdminStatusOID = "1.3.6.1.2.1.2.2.1.7"
+
+// SetInterfaceAdminStatus sets the ifAdminStatus of an interface. The write needs to be enabled for the connection, so
+// that it can't be performed accidentally. The status is read back after the write, as some devices accept the set
+// request without applying it.
+func (o *deviceClassCommunicator) SetInterfaceAdminStatus(ctx context.Context, ifIndex uint64, up bool) error {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return errors.New("no device connection available")
 	}
-	logger := log.Ctx(ctx).With().Str("property", "osVersion").Logger()
-	ctx = logger.WithContext(ctx)
-	version, err := o.identify.properties.osVersion.GetProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get osVersion")
+	if !con.SNMPWritesEnabled() {
+		return tholaerr.NewPreConditionError("snmp writes are not enabled for this connection")
 	}
 
-	return strings.TrimSpace(version.String()), nil
-}
+	status := device.StatusDown
+	if up {
+		status = device.StatusUp
+	}
+	code, err := status.ToStatusCode()
+	if err != nil {
+		return errors.Wrap(err, "failed to get status code")
+	}
+	oid := network.OID(ifAdminStatusOID).AddIndex(strconv.FormatUint(ifIndex, 10))
 
-func (o *deviceClassCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
-	if o.components.interfaces == nil || o.components.interfaces.properties == nil {
-		log.Ctx(ctx).Debug().Str("property", "interfaces").Str("device_class", o.name).Msg("no interface information available")
-		return nil, tholaerr.NewNotImplementedError("not implemented")
+	log.Ctx(ctx).Info().Uint64("ifIndex", ifIndex).Str("status", string(status)).Msg("setting ifAdminStatus")
+	err = con.SNMP.SnmpClient.SNMPSet(ctx, network.SNMPVariable{OID: oid, Type: gosnmp.Integer, Value: code})
+	if err != nil {
+		return errors.Wrap(err, "failed to set ifAdminStatus")
 	}
 
-	interfacesRaw, indices, err := o.components.interfaces.properties.GetProperty(ctx, filter...)
+	response, err := con.SNMP.SnmpClient.SNMPGet(ctx, oid)
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "failed to read back ifAdminStatus")
+	}
+	if len(response) != 1 {
+		return errors.New("failed to read back ifAdminStatus: invalid response")
+	}
+	value, err := response[0].GetValue()
+	if err != nil {
+		return errors.Wrap(err, "failed to get value of ifAdminStatus")
 	}
+	current, err := value.Int()
+	if err != nil {
+		return errors.Wrap(err, "failed to convert ifAdminStatus to int")
+	}
+	if current != code {
+		return fmt.Errorf("ifAdminStatus of interface %d is %d after the write, expected %d (%s)", ifIndex, current, code, status)
+	}
+	return nil
+}
 
-	var interfaces []device.Interface
+// GetNeighbors returns the neighbors of the device, read out of the LLDP-MIB.
+func (o *deviceClassCommunicator) GetNeighbors(ctx context.Context) ([]device.Neighbor, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		log.Ctx(ctx).Debug().Msg("snmp client is empty")
+		return nil, errors.New("snmp client is empty")
+	}
 
-	err = interfacesRaw.Decode(&interfaces)
+	// an empty lldpRemTable is a valid result, so lldpLocChassisId is used to check if LLDP is supported at all
+	_, err := con.SNMP.SnmpClient.SNMPGet(ctx, "1.0.8802.1.1.2.1.3.2.0")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode raw interfaces into interface structs")
+		if tholaerr.IsNotFoundError(err) {
+			log.Ctx(ctx).Debug().Err(err).Msg("device does not support LLDP-MIB")
+			return nil, tholaerr.NewComponentNotFoundError("device does not support LLDP-MIB")
+		}
+		return nil, errors.Wrap(err, "failed to get lldpLocChassisId")
 	}
 
-	// normalize interfaces
-	for i, interf := range interfaces {
-		if interf.IfIndex == nil {
-			ifIndex, err := indices[i].UInt64()
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to get ifIndex from SNMP index")
+	localPorts := make(map[string]string)
+	for _, oid := range []network.OID{"1.0.8802.1.1.2.1.3.7.1.3", "1.0.8802.1.1.2.1.3.7.1.4"} {
+		res, err := network.SNMPWalkCached(ctx, con.SNMP.SnmpClient, oid)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				continue
 			}
-			interfaces[i].IfIndex = &ifIndex
+			return nil, errors.Wrap(err, "failed to read lldpLocPortTable")
 		}
-		if interf.IfSpeed != nil && interf.IfHighSpeed != nil && *interf.IfSpeed == math.MaxUint32 {
-			ifSpeed := *interf.IfHighSpeed * 1000000
-			interfaces[i].IfSpeed = &ifSpeed
+		for _, r := range res {
+			val, err := r.GetValue()
+			if err != nil || val.IsEmpty() {
+				continue
+			}
+			// lldpLocPortDesc is preferred over lldpLocPortId if available
+			localPorts[r.GetOID().GetIndex()] = val.String()
 		}
 	}
 
-	return interfaces, nil
-}
+	var indices []string
+	neighbors := make(map[string]*device.Neighbor)
+	getNeighbor := func(idx string) *device.Neighbor {
+		if n, ok := neighbors[idx]; ok {
+			return n
+		}
+		protocol := "lldp"
+		n := &device.Neighbor{Protocol: &protocol}
+		neighbors[idx] = n
+		indices = append(indices, idx)
+		return n
+	}
 
-func (o *deviceClassCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
-	if o.components.interfaces == nil || o.components.interfaces.count == nil {
-		log.Ctx(ctx).Debug().Str("property", "countInterfaces").Str("device_class", o.name).Msg("no interface count information available")
-		return 0, tholaerr.NewNotImplementedError("not implemented")
+	chassisIDSubtypes, err := walkSNMPColumn(ctx, "1.0.8802.1.1.2.1.4.1.1.4", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lldpRemChassisIdSubtype")
+	}
+	portIDSubtypes, err := walkSNMPColumn(ctx, "1.0.8802.1.1.2.1.4.1.1.6", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lldpRemPortIdSubtype")
 	}
 
-	con, ok := network.DeviceConnectionFromContext(ctx)
-	if !ok || con.SNMP == nil {
-		log.Ctx(ctx).Debug().Msg("snmp client is empty")
-		return 0, errors.New("snmp client is empty")
+	// lldpRemChassisId and lldpRemPortId are read raw, because they may contain mac addresses
+	chassisIDs, err := walkSNMPColumn(ctx, "1.0.8802.1.1.2.1.4.1.1.5", true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lldpRemChassisId")
+	}
+	for idx, raw := range chassisIDs {
+		chassisID, err := decodeLLDPID(raw, chassisIDSubtypes[idx] == "4")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode lldpRemChassisId")
+		}
+		getNeighbor(idx).RemoteChassisID = &chassisID
+	}
+
+	portIDs, err := walkSNMPColumn(ctx, "1.0.8802.1.1.2.1.4.1.1.7", true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read lldpRemPortId")
+	}
+	for idx, raw := range portIDs {
+		portID, err := decodeLLDPID(raw, portIDSubtypes[idx] == "3")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode lldpRemPortId")
+		}
+		getNeighbor(idx).RemotePort = &portID
 	}
 
-	res, err := o.components.interfaces.count.GetProperty(ctx)
+	sysNames, err := walkSNMPColumn(ctx, "1.0.8802.1.1.2.1.4.1.1.9", false)
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to get interfaces count")
+		return nil, errors.Wrap(err, "failed to read lldpRemSysName")
+	}
+	for idx, sysName := range sysNames {
+		sysName := sysName
+		getNeighbor(idx).RemoteHostname = &sysName
 	}
 
-	if responseInt, err := res.Int(); err == nil {
-		return responseInt, nil
+	sort.Slice(indices, func(i, j int) bool {
+		cmp, err := network.OID(indices[i]).Cmp(network.OID(indices[j]))
+		return err == nil && cmp < 0
+	})
+
+	res := make([]device.Neighbor, 0, len(indices))
+	for _, idx := range indices {
+		n := neighbors[idx]
+
+		// index of lldpRemTable is lldpRemTimeMark.lldpRemLocalPortNum.lldpRemIndex
+		if idxParts := strings.Split(idx, "."); len(idxParts) == 3 {
+			localPort := idxParts[1]
+			if name, ok := localPorts[localPort]; ok {
+				localPort = name
+			}
+			n.LocalInterface = &localPort
+		}
+		res = append(res, *n)
 	}
 
-	return 0, errors.New("could not parse response to int")
+	return res, nil
 }
 
 func (o *deviceClassCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
@@ -677,6 +1359,39 @@ func (o *deviceClassCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([
 	return cpus, nil
 }
 
+// GetCPUComponentDetailed returns the load and temperature per core of the device.
+// The values of a core are joined by their index, which is also used as description if none was read out.
+func (o *deviceClassCommunicator) GetCPUComponentDetailed(ctx context.Context) ([]device.CPUCore, error) {
+	if o.components.cpu == nil || o.components.cpu.cores == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "CPUComponentDetailed").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "CPUComponentDetailed").Logger()
+	ctx = logger.WithContext(ctx)
+	res, indices, err := o.components.cpu.cores.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var cores []device.CPUCore
+	err = mapstructure.WeakDecode(res, &cores)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into cpu core struct")
+	}
+
+	var filtered []device.CPUCore
+	for i, core := range cores {
+		// description tables often contain more entries than the cpu tables, so entries without values are skipped
+		if core.Load == nil && core.Temperature == nil {
+			continue
+		}
+		if core.Description == "" && i < len(indices) {
+			core.Description = indices[i].String()
+		}
+		filtered = append(filtered, core)
+	}
+	return filtered, nil
+}
+
 func (o *deviceClassCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) ([]device.MemoryPool, error) {
 	if o.components.memory == nil || o.components.memory.usage == nil {
 		log.Ctx(ctx).Debug().Str("property", "MemoryComponentMemoryUsage").Str("device_class", o.name).Msg("no detection information available")
@@ -926,6 +1641,70 @@ func (o *deviceClassCommunicator) GetUPSComponentSystemVoltage(ctx context.Conte
 	return result, nil
 }
 
+func (o *deviceClassCommunicator) GetUPSComponentSelfTestResult(ctx context.Context) (string, error) {
+	if o.components.ups == nil || o.components.ups.selfTestResult == nil {
+		log.Ctx(ctx).Debug().Str("property", "UPSComponentSelfTestResult").Str("device_class", o.name).Msg("no detection information available")
+		return "", tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "UPSComponentSelfTestResult").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.ups.selfTestResult.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return "", errors.Wrap(err, "failed to get UPSComponentSelfTestResult")
+	}
+	return res.String(), nil
+}
+
+func (o *deviceClassCommunicator) GetUPSComponentLastTestDate(ctx context.Context) (string, error) {
+	if o.components.ups == nil || o.components.ups.lastTestDate == nil {
+		log.Ctx(ctx).Debug().Str("property", "UPSComponentLastTestDate").Str("device_class", o.name).Msg("no detection information available")
+		return "", tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "UPSComponentLastTestDate").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.ups.lastTestDate.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return "", errors.Wrap(err, "failed to get UPSComponentLastTestDate")
+	}
+	return res.String(), nil
+}
+
+func (o *deviceClassCommunicator) GetUPSComponentBatteryStrings(ctx context.Context) ([]device.UPSBatteryString, error) {
+	if o.components.ups == nil || o.components.ups.batteryStrings == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "UPSComponentBatteryStrings").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "UPSComponentBatteryStrings").Logger()
+	ctx = logger.WithContext(ctx)
+	res, indices, err := o.components.ups.batteryStrings.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var batteryStrings []device.UPSBatteryString
+	err = mapstructure.WeakDecode(res, &batteryStrings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into battery string struct")
+	}
+
+	// the index of a battery string is the index of its table row. Battery strings that are read from scalar objects,
+	// like the battery group of the UPS-MIB, have the row index 0 and are numbered by their position instead.
+	for i := range batteryStrings {
+		if batteryStrings[i].Index > 0 {
+			continue
+		}
+		if i < len(indices) {
+			if index, err := indices[i].Int(); err == nil && index > 0 {
+				batteryStrings[i].Index = index
+				continue
+			}
+		}
+		batteryStrings[i].Index = i + 1
+	}
+	return batteryStrings, nil
+}
+
 func (o *deviceClassCommunicator) GetSBCComponentAgents(ctx context.Context) ([]device.SBCComponentAgent, error) {
 	if o.components.sbc == nil || o.components.sbc.agents == nil {
 		log.Ctx(ctx).Debug().Str("groupProperty", "SBCComponentAgents").Str("device_class", o.name).Msg("no detection information available")
@@ -1135,6 +1914,120 @@ func (o *deviceClassCommunicator) GetServerComponentUsers(ctx context.Context) (
 	return r, nil
 }
 
+func (o *deviceClassCommunicator) GetServerComponentUptime(ctx context.Context) (uint32, error) {
+	if o.components.server == nil || o.components.server.uptime == nil {
+		log.Ctx(ctx).Debug().Str("property", "ServerComponentUptime").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "ServerComponentUptime").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.server.uptime.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get ServerComponentUptime")
+	}
+	r, err := res.UInt64()
+	if err != nil || r > math.MaxUint32 {
+		return 0, errors.Errorf("failed to convert value '%s' to uint32", res.String())
+	}
+	return uint32(r), nil
+}
+
+func (o *deviceClassCommunicator) GetServerComponentLoadAverage1(ctx context.Context) (float64, error) {
+	if o.components.server == nil || o.components.server.loadAverage1 == nil {
+		log.Ctx(ctx).Debug().Str("property", "ServerComponentLoadAverage1").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "ServerComponentLoadAverage1").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.server.loadAverage1.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get ServerComponentLoadAverage1")
+	}
+	r, err := res.Float64()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert value '%s' to float64", res.String())
+	}
+	return r, nil
+}
+
+func (o *deviceClassCommunicator) GetServerComponentLoadAverage5(ctx context.Context) (float64, error) {
+	if o.components.server == nil || o.components.server.loadAverage5 == nil {
+		log.Ctx(ctx).Debug().Str("property", "ServerComponentLoadAverage5").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "ServerComponentLoadAverage5").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.server.loadAverage5.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get ServerComponentLoadAverage5")
+	}
+	r, err := res.Float64()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert value '%s' to float64", res.String())
+	}
+	return r, nil
+}
+
+func (o *deviceClassCommunicator) GetServerComponentLoadAverage15(ctx context.Context) (float64, error) {
+	if o.components.server == nil || o.components.server.loadAverage15 == nil {
+		log.Ctx(ctx).Debug().Str("property", "ServerComponentLoadAverage15").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "ServerComponentLoadAverage15").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.server.loadAverage15.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get ServerComponentLoadAverage15")
+	}
+	r, err := res.Float64()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert value '%s' to float64", res.String())
+	}
+	return r, nil
+}
+
+func (o *deviceClassCommunicator) GetServerComponentSwapTotal(ctx context.Context) (uint64, error) {
+	if o.components.server == nil || o.components.server.swapTotal == nil {
+		log.Ctx(ctx).Debug().Str("property", "ServerComponentSwapTotal").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "ServerComponentSwapTotal").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.server.swapTotal.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get ServerComponentSwapTotal")
+	}
+	r, err := res.UInt64()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert value '%s' to uint64", res.String())
+	}
+	return r, nil
+}
+
+func (o *deviceClassCommunicator) GetServerComponentSwapUsed(ctx context.Context) (uint64, error) {
+	if o.components.server == nil || o.components.server.swapUsed == nil {
+		log.Ctx(ctx).Debug().Str("property", "ServerComponentSwapUsed").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "ServerComponentSwapUsed").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.server.swapUsed.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get ServerComponentSwapUsed")
+	}
+	r, err := res.UInt64()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert value '%s' to uint64", res.String())
+	}
+	return r, nil
+}
+
 func (o *deviceClassCommunicator) GetHardwareHealthComponentEnvironmentMonitorState(ctx context.Context) (device.HardwareHealthComponentState, error) {
 	if o.components.hardwareHealth == nil || o.components.hardwareHealth.environmentMonitorState == nil {
 		log.Ctx(ctx).Debug().Str("property", "HardwareHealthComponentEnvironmentMonitorState").Str("device_class", o.name).Msg("no detection information available")
@@ -1148,13 +2041,57 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponentEnvironmentMonitorSt
 		return "", errors.Wrap(err, "failed to get HardwareHealthComponentEnvironmentMonitorState")
 	}
 
+	if state, ok := o.components.hardwareHealth.environmentMonitorStates[res.String()]; ok {
+		return state, nil
+	}
+
+	// values that are not declared by the device class may already be mapped by operators
 	state := device.HardwareHealthComponentState(res.String())
 	if _, err := state.GetInt(); err != nil {
-		return "", fmt.Errorf("read out invalid hardware health component state '%s'", state)
+		log.Ctx(ctx).Debug().Str("value", res.String()).Msg("read out unknown hardware health component state")
+		return "", device.UnknownHardwareHealthStateError{Raw: res.String()}
 	}
 	return state, nil
 }
 
+func (o *deviceClassCommunicator) GetPOEComponentPSEs(ctx context.Context) ([]device.POEComponentPSE, error) {
+	if o.components.poe == nil || o.components.poe.pses == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "POEComponentPSEs").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "POEComponentPSEs").Logger()
+	ctx = logger.WithContext(ctx)
+	res, _, err := o.components.poe.pses.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var pses []device.POEComponentPSE
+	err = mapstructure.WeakDecode(res, &pses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into pse struct")
+	}
+	return pses, nil
+}
+
+func (o *deviceClassCommunicator) GetPOEComponentPorts(ctx context.Context) ([]device.POEComponentPort, error) {
+	if o.components.poe == nil || o.components.poe.ports == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "POEComponentPorts").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "POEComponentPorts").Logger()
+	ctx = logger.WithContext(ctx)
+	res, _, err := o.components.poe.ports.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var ports []device.POEComponentPort
+	err = mapstructure.WeakDecode(res, &ports)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into poe port struct")
+	}
+	return ports, nil
+}
+
 func (o *deviceClassCommunicator) GetHardwareHealthComponentFans(ctx context.Context) ([]device.HardwareHealthComponentFan, error) {
 	if o.components.hardwareHealth == nil || o.components.hardwareHealth.fans == nil {
 		log.Ctx(ctx).Debug().Str("groupProperty", "HardwareHealthComponentFans").Str("device_class", o.name).Msg("no detection information available")
@@ -1162,7 +2099,7 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponentFans(ctx context.Con
 	}
 	logger := log.Ctx(ctx).With().Str("groupProperty", "HardwareHealthComponentFans").Logger()
 	ctx = logger.WithContext(ctx)
-	res, _, err := o.components.hardwareHealth.fans.GetProperty(ctx)
+	res, indices, err := o.components.hardwareHealth.fans.GetProperty(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get property")
 	}
@@ -1171,9 +2108,27 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponentFans(ctx context.Con
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode property into fan struct")
 	}
+
+	// the speed is optional, so it is only looked up if it is not mapped by the device class
+	if fanSpeedMissing(fans) {
+		err = addEntitySensorFanSpeeds(ctx, fans, indices)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to read fan speeds of ENTITY-SENSOR-MIB")
+		}
+	}
 	return fans, nil
 }
 
+// fanSpeedMissing checks if any fan lacks its speed.
+func fanSpeedMissing(fans []device.HardwareHealthComponentFan) bool {
+	for _, fan := range fans {
+		if fan.SpeedRPM == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *deviceClassCommunicator) GetHardwareHealthComponentPowerSupply(ctx context.Context) ([]device.HardwareHealthComponentPowerSupply, error) {
 	if o.components.hardwareHealth == nil || o.components.hardwareHealth.powerSupply == nil {
 		log.Ctx(ctx).Debug().Str("groupProperty", "HardwareHealthComponentPowerSupply").Str("device_class", o.name).Msg("no detection information available")
@@ -1190,9 +2145,27 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponentPowerSupply(ctx cont
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode property into power supply struct")
 	}
+
+	// the electrical readings are optional, so they are only looked up if they are not mapped by the device class
+	if powerSupplyReadingsMissing(powerSupply) {
+		err = addEntitySensorPowerSupplyReadings(ctx, powerSupply)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to read power supply readings of ENTITY-SENSOR-MIB")
+		}
+	}
 	return powerSupply, nil
 }
 
+// powerSupplyReadingsMissing checks if any power supply with a description lacks its input voltage or output wattage.
+func powerSupplyReadingsMissing(powerSupplies []device.HardwareHealthComponentPowerSupply) bool {
+	for _, powerSupply := range powerSupplies {
+		if powerSupply.Description != nil && (powerSupply.InputVoltage == nil || powerSupply.OutputWattage == nil) {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *deviceClassCommunicator) GetHardwareHealthComponentTemperature(ctx context.Context) ([]device.HardwareHealthComponentTemperature, error) {
 	if o.components.hardwareHealth == nil || o.components.hardwareHealth.temperature == nil {
 		log.Ctx(ctx).Debug().Str("groupProperty", "HardwareHealthComponentFans").Str("device_class", o.name).Msg("no detection information available")
@@ -1231,6 +2204,26 @@ func (o *deviceClassCommunicator) GetHardwareHealthComponentVoltage(ctx context.
 	return voltage, nil
 }
 
+func (o *deviceClassCommunicator) GetHardwareHealthComponentHumidity(ctx context.Context) ([]device.HardwareHealthComponentHumidity, error) {
+	sensors, err := readEntitySensors(ctx, entitySensorTypePercentRH)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read entity sensors")
+	}
+	if len(sensors) == 0 {
+		return nil, tholaerr.NewNotFoundError("no humidity sensors found in ENTITY-SENSOR-MIB")
+	}
+
+	var humidity []device.HardwareHealthComponentHumidity
+	for _, sensor := range sensors {
+		v := sensor.value
+		humidity = append(humidity, device.HardwareHealthComponentHumidity{
+			Description: sensor.description,
+			Value:       &v,
+		})
+	}
+	return humidity, nil
+}
+
 func (o *deviceClassCommunicator) GetHighAvailabilityComponentState(ctx context.Context) (device.HighAvailabilityComponentState, error) {
 	if o.components.highAvailability == nil || o.components.highAvailability.state == nil {
 		log.Ctx(ctx).Debug().Str("property", "HighAvailabilityComponentState").Str("device_class", o.name).Msg("no detection information available")
@@ -1287,3 +2280,48 @@ func (o *deviceClassCommunicator) GetHighAvailabilityComponentNodes(ctx context.
 
 	return v, nil
 }
+
+// walkSNMPColumn walks a column of a table, e.g. of the neighbor or VLAN tables, and returns the values mapped by their
+// index.
+func walkSNMPColumn(ctx context.Context, oid network.OID, raw bool) (map[string]string, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("snmp client is empty")
+	}
+
+	res := make(map[string]string)
+	responses, err := network.SNMPWalkCached(ctx, con.SNMP.SnmpClient, oid)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	for _, r := range responses {
+		val, err := r.GetValueBySNMPGetConfiguration(network.SNMPGetConfiguration{OID: oid, UseRawResult: raw})
+		if err != nil || val.IsEmpty() {
+			continue
+		}
+		idx, err := r.GetOID().GetIndexAfterOID(oid)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index after oid")
+		}
+		res[idx] = val.String()
+	}
+	return res, nil
+}
+
+// decodeLLDPID decodes a raw (hex) LLDP chassis or port ID either into a mac address or into a readable string.
+func decodeLLDPID(raw string, isMACAddress bool) (string, error) {
+	b, err := hex.DecodeString(raw)
+	if err != nil {
+		return "", errors.Wrap(err, "value is not a valid hex string")
+	}
+	if isMACAddress && len(b) == 6 {
+		return strings.ToUpper(net.HardwareAddr(b).String()), nil
+	}
+	return strings.TrimFunc(string(b), func(r rune) bool {
+		return !unicode.IsGraphic(r)
+	}), nil
+}
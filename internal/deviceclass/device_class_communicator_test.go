@@ -0,0 +1,1652 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDeviceClassCommunicator_GetVLANComponentPortMembership(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.17.7.1.4.2.1.4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.2.1.4.0.1", gosnmp.OctetString, string([]byte{0xC0, 0x01})),
+			network.NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.2.1.4.0.100", gosnmp.OctetString, string([]byte{0x40, 0x00})),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.17.1.4.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.17.1.4.1.2.1", gosnmp.Integer, 10001),
+			network.NewSNMPResponse("1.3.6.1.2.1.17.1.4.1.2.2", gosnmp.Integer, 10002),
+		}, nil)
+
+	var o deviceClassCommunicator
+	res, err := o.GetVLANComponentPortMembership(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[int][]int{
+			10001: {1},
+			10002: {1, 100},
+			16:    {1},
+		}, res)
+	}
+}
+
+func TestDeviceClassCommunicator_GetVLANComponentVLANs(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	// vtpVlanTable is indexed by managementDomainIndex.vtpVlanIndex
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID(".1.3.6.1.4.1.9.9.46.1.3.1.1.4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.46.1.3.1.1.4.1.1", gosnmp.OctetString, "default"),
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.46.1.3.1.1.4.1.100", gosnmp.OctetString, "servers"),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID(".1.3.6.1.4.1.9.9.46.1.3.1.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.100", gosnmp.Integer, 2),
+		}, nil)
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	ios, ok := h.Children["ios"]
+	if !assert.True(t, ok, "ios device class not found") {
+		return
+	}
+
+	res, err := ios.NetworkDeviceCommunicator.GetVLANComponentVLANs(ctx)
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Equal(t, 1, *res[0].ID)
+		assert.Equal(t, "default", *res[0].Name)
+		assert.Equal(t, "operational", *res[0].Status)
+		assert.Equal(t, 100, *res[1].ID)
+		assert.Equal(t, "servers", *res[1].Name)
+		assert.Equal(t, "suspended", *res[1].Status)
+	}
+}
+
+func TestDeviceClassCommunicator_GetVLANComponent_noBridgeMIB(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, mock.Anything).
+		Return(nil, tholaerr.NewNotFoundError("no such object"))
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = h.NetworkDeviceCommunicator.GetVLANComponent(ctx)
+	assert.True(t, tholaerr.IsComponentNotFoundError(err), "expected component not found error, got: %v", err)
+}
+
+func TestAddInterfaceVLANMembership(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.17.7.1.4.2.1.4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.2.1.4.0.1", gosnmp.OctetString, string([]byte{0xC0})),
+			network.NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.2.1.4.0.100", gosnmp.OctetString, string([]byte{0x40})),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.17.7.1.4.2.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.2.1.5.0.1", gosnmp.OctetString, string([]byte{0xC0})),
+			network.NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.2.1.5.0.100", gosnmp.OctetString, string([]byte{0x00})),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.17.1.4.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.17.1.4.1.2.1", gosnmp.Integer, 10001),
+			network.NewSNMPResponse("1.3.6.1.2.1.17.1.4.1.2.2", gosnmp.Integer, 10002),
+		}, nil)
+
+	ifIndex1, ifIndex2, ifIndex3 := uint64(10001), uint64(10002), uint64(10003)
+	interfaces := []device.Interface{{IfIndex: &ifIndex1}, {IfIndex: &ifIndex2}, {IfIndex: &ifIndex3}}
+	addInterfaceVLANMembership(ctx, interfaces)
+
+	if assert.NotNil(t, interfaces[0].VLAN) {
+		assert.Equal(t, []int{1}, interfaces[0].VLAN.UntaggedVLANs)
+		assert.Nil(t, interfaces[0].VLAN.TaggedVLANs)
+	}
+	if assert.NotNil(t, interfaces[1].VLAN) {
+		assert.Equal(t, []int{1}, interfaces[1].VLAN.UntaggedVLANs)
+		assert.Equal(t, []int{100}, interfaces[1].VLAN.TaggedVLANs)
+	}
+	assert.Nil(t, interfaces[2].VLAN)
+}
+
+func TestDecodePortList(t *testing.T) {
+	assert.Equal(t, []int{1, 8, 9, 24}, decodePortList([]byte{0x81, 0x80, 0x01}))
+	assert.Nil(t, decodePortList([]byte{0x00, 0x00}))
+}
+
+func TestDeviceClassCommunicator_GetPOEComponent(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		"1.3.6.1.2.1.105.1.3.1.1.2": {network.NewSNMPResponse("1.3.6.1.2.1.105.1.3.1.1.2.1", gosnmp.Gauge32, uint(370))},
+		"1.3.6.1.2.1.105.1.3.1.1.3": {network.NewSNMPResponse("1.3.6.1.2.1.105.1.3.1.1.3.1", gosnmp.Integer, 1)},
+		"1.3.6.1.2.1.105.1.3.1.1.4": {network.NewSNMPResponse("1.3.6.1.2.1.105.1.3.1.1.4.1", gosnmp.Gauge32, uint(42))},
+		"1.3.6.1.2.1.105.1.3.1.1.5": {network.NewSNMPResponse("1.3.6.1.2.1.105.1.3.1.1.5.1", gosnmp.Integer, 80)},
+		"1.3.6.1.2.1.105.1.1.1.9": {
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.9.1.1", gosnmp.OctetString, "Gi1/0/1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.9.1.2", gosnmp.OctetString, "Gi1/0/2"),
+		},
+		"1.3.6.1.2.1.105.1.1.1.3": {
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.3.1.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.3.1.2", gosnmp.Integer, 1),
+		},
+		"1.3.6.1.2.1.105.1.1.1.6": {
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.6.1.1", gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.6.1.2", gosnmp.Integer, 4),
+		},
+		"1.3.6.1.2.1.105.1.1.1.10": {
+			network.NewSNMPResponse("1.3.6.1.2.1.105.1.1.1.10.1.1", gosnmp.Integer, 4),
+		},
+		"1.3.6.1.4.1.9.9.402.1.2.1.9": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.402.1.2.1.9.1.1", gosnmp.Gauge32, uint(6500)),
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.402.1.2.1.9.1.2", gosnmp.Gauge32, uint(0)),
+		},
+		"1.3.6.1.4.1.9.9.402.1.2.1.7": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.402.1.2.1.7.1.1", gosnmp.Gauge32, uint(15400)),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	ios, ok := h.Children["ios"]
+	if !assert.True(t, ok, "ios device class not found") {
+		return
+	}
+
+	res, err := ios.NetworkDeviceCommunicator.GetPOEComponent(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, res.PSEs, 1) {
+		assert.Equal(t, 370.0, *res.PSEs[0].Power)
+		assert.Equal(t, 42.0, *res.PSEs[0].ConsumptionPower)
+		assert.Equal(t, 80.0, *res.PSEs[0].UsageThreshold)
+		assert.Equal(t, device.POEComponentPSEStatusOn, *res.PSEs[0].OperStatus)
+	}
+	if assert.Len(t, res.Ports, 2) {
+		assert.Equal(t, "Gi1/0/1", *res.Ports[0].Description)
+		assert.True(t, *res.Ports[0].AdminEnable)
+		assert.Equal(t, device.POEComponentPortDetectionStatusDeliveringPower, *res.Ports[0].DetectionStatus)
+		assert.Equal(t, "class3", *res.Ports[0].PowerClass)
+		assert.Equal(t, 6.5, *res.Ports[0].Power)
+		assert.Equal(t, 15.4, *res.Ports[0].PowerAllocated)
+
+		assert.Equal(t, "Gi1/0/2", *res.Ports[1].Description)
+		assert.True(t, res.Ports[1].DetectionStatus.IsFaulty())
+		assert.Nil(t, res.Ports[1].PowerClass)
+		assert.Nil(t, res.Ports[1].PowerAllocated)
+	}
+}
+
+func TestDeviceClassCommunicator_GetServerComponent(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.25.1.6.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.2.1.25.1.6.0", gosnmp.Gauge32, uint(120))}, nil).
+		On("SNMPGet", mock.Anything, network.OID("1.3.6.1.2.1.25.1.5.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse("1.3.6.1.2.1.25.1.5.0", gosnmp.Gauge32, uint(2))}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.25.1.1.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.2.1.25.1.1.0", gosnmp.TimeTicks, uint32(44568999))}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.2021.10.1.3.1")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.2021.10.1.3.1", gosnmp.OctetString, "0.15")}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.2021.10.1.3.2")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.2021.10.1.3.2", gosnmp.OctetString, "0.10")}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.2021.10.1.3.3")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.2021.10.1.3.3", gosnmp.OctetString, "0.05")}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.2021.4.3.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.2021.4.3.0", gosnmp.Integer, 2097148)}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.2021.4.4.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.2021.4.4.0", gosnmp.Integer, 1048572)}, nil)
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	linux, ok := h.Children["linux"]
+	if !assert.True(t, ok, "linux device class not found") {
+		return
+	}
+
+	res, err := linux.NetworkDeviceCommunicator.GetServerComponent(ctx)
+	if assert.NoError(t, err) {
+		procs, users, uptime := 120, 2, uint32(445690)
+		load1, load5, load15 := 0.15, 0.1, 0.05
+		swapTotal, swapUsed := uint64(2147479552), uint64(1073741824)
+		assert.Equal(t, device.ServerComponent{
+			Procs:         &procs,
+			Users:         &users,
+			Uptime:        &uptime,
+			LoadAverage1:  &load1,
+			LoadAverage5:  &load5,
+			LoadAverage15: &load15,
+			SwapTotal:     &swapTotal,
+			SwapUsed:      &swapUsed,
+		}, res)
+	}
+
+	// devices without the UCD-SNMP-MIB still return the other values
+	var snmpClient2 network.MockSNMPClient
+	ctx = network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient2,
+		},
+	})
+	notFound := tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")
+	snmpClient2.
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.25.1.6.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.2.1.25.1.6.0", gosnmp.Gauge32, uint(120))}, nil).
+		On("SNMPGet", mock.Anything, network.OID("1.3.6.1.2.1.25.1.5.0")).
+		Return(nil, notFound).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.25.1.1.0")).
+		Return(nil, notFound).
+		On("SNMPGet", mock.Anything, mock.Anything).
+		Return(nil, notFound)
+
+	res, err = linux.NetworkDeviceCommunicator.GetServerComponent(ctx)
+	if assert.NoError(t, err) {
+		procs := 120
+		assert.Equal(t, device.ServerComponent{Procs: &procs}, res)
+	}
+}
+
+func TestDeviceClassCommunicator_GetIdentifyProperties_firmwareAndBootloaderVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"generic.yaml": {Data: []byte("name: generic\n")},
+		"generic/both.yaml": {Data: []byte(`name: both
+
+match:
+  type: SysDescription
+  match_mode: startsWith
+  values:
+    - Both
+
+identify:
+  properties:
+    firmware_version:
+      - detection: snmpget
+        oid: .1.3.6.1.4.1.99999.1.1.0
+    bootloader_version:
+      - detection: snmpget
+        oid: .1.3.6.1.4.1.99999.1.2.0
+`)},
+		"generic/firmware.yaml": {Data: []byte(`name: firmware
+
+match:
+  type: SysDescription
+  match_mode: startsWith
+  values:
+    - Firmware
+
+identify:
+  properties:
+    firmware_version:
+      - detection: snmpget
+        oid: .1.3.6.1.4.1.99999.1.1.0
+`)},
+	}
+	h, err := GetHierarchyFromFS(fsys, ".")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.99999.1.1.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.99999.1.1.0", gosnmp.OctetString, "2.1.4")}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.4.1.99999.1.2.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.4.1.99999.1.2.0", gosnmp.OctetString, " 15.1(1r)S ")}, nil)
+
+	properties, err := h.Children["both"].NetworkDeviceCommunicator.GetIdentifyProperties(ctx)
+	if assert.NoError(t, err) {
+		if assert.NotNil(t, properties.FirmwareVersion) {
+			assert.Equal(t, "2.1.4", *properties.FirmwareVersion)
+		}
+		if assert.NotNil(t, properties.BootloaderVersion) {
+			assert.Equal(t, "15.1(1r)S", *properties.BootloaderVersion)
+		}
+		assert.Nil(t, properties.OSVersion)
+	}
+
+	// devices that only expose one of the versions only populate that one
+	properties, err = h.Children["firmware"].NetworkDeviceCommunicator.GetIdentifyProperties(ctx)
+	if assert.NoError(t, err) {
+		if assert.NotNil(t, properties.FirmwareVersion) {
+			assert.Equal(t, "2.1.4", *properties.FirmwareVersion)
+		}
+		assert.Nil(t, properties.BootloaderVersion)
+	}
+}
+
+func TestDeviceClassCommunicator_GetUPSComponentSelfTest(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.33.1.7.3.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.2.1.33.1.7.3.0", gosnmp.Integer, 6)}, nil).
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.33.1.7.5.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.2.1.33.1.7.5.0", gosnmp.TimeTicks, uint32(0))}, nil)
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	upsMIB, ok := h.Children["ups-mib"]
+	if !assert.True(t, ok, "ups-mib device class not found") {
+		return
+	}
+
+	// devices that never ran a self-test report it as result, but have no date of the last test
+	res, err := upsMIB.NetworkDeviceCommunicator.GetUPSComponentSelfTestResult(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "noTestsInitiated", res)
+	}
+	_, err = upsMIB.NetworkDeviceCommunicator.GetUPSComponentLastTestDate(ctx)
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestDeviceClassCommunicator_GetUPSComponentBatteryStrings(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.33.1.2.1")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse("1.3.6.1.2.1.33.1.2.1.0", gosnmp.Integer, 3)}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.33.1.2.5")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse("1.3.6.1.2.1.33.1.2.5.0", gosnmp.Integer, 545)}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.33.1.2.7")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse("1.3.6.1.2.1.33.1.2.7.0", gosnmp.Integer, 24)}, nil)
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	upsMIB, ok := h.Children["ups-mib"]
+	if !assert.True(t, ok, "ups-mib device class not found") {
+		return
+	}
+
+	// the scalar battery group of the UPS-MIB is a single battery string
+	res, err := upsMIB.NetworkDeviceCommunicator.GetUPSComponentBatteryStrings(ctx)
+	if assert.NoError(t, err) && assert.Len(t, res, 1) {
+		assert.Equal(t, 1, res[0].Index)
+		assert.Equal(t, "batteryLow", *res[0].Status)
+		assert.Equal(t, 54.5, *res[0].Voltage)
+		assert.Equal(t, 24.0, *res[0].Temperature)
+	}
+}
+
+func TestDeviceClassCommunicator_GetHardwareHealthComponentHumidity(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.99.1.1.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.10", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.11", gosnmp.Integer, 8),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.12", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.2", gosnmp.Integer, 9),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.99.1.1.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.10", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.11", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.12", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.2", gosnmp.Integer, 8),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.99.1.1.1.3")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.10", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.11", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.12", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.2", gosnmp.Integer, 0),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.99.1.1.1.4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.10", gosnmp.Integer, 455),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.11", gosnmp.Integer, 23),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.12", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.2", gosnmp.Integer, 52000),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.99.1.1.1.5")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.10", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.11", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.12", gosnmp.Integer, 2),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.2", gosnmp.Integer, 1),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.2.1.47.1.1.1.1.7")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.10", gosnmp.OctetString, "Inlet Humidity"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.11", gosnmp.OctetString, "Inlet Temperature"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.12", gosnmp.OctetString, "Outlet Humidity"),
+		}, nil)
+
+	var o deviceClassCommunicator
+	res, err := o.GetHardwareHealthComponentHumidity(ctx)
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Nil(t, res[0].Description)
+		assert.Equal(t, 52.0, *res[0].Value)
+		assert.Equal(t, "Inlet Humidity", *res[1].Description)
+		assert.Equal(t, 45.5, *res[1].Value)
+	}
+}
+
+func TestDeviceClassCommunicator_GetHardwareHealthComponentEnvironmentMonitorState(t *testing.T) {
+	stateOID := network.OID("1.3.6.1.4.1.9148.3.3.1.1.0")
+	y := yamlComponentsHardwareHealthProperties{
+		EnvironmentMonitorState: []interface{}{
+			map[interface{}]interface{}{"detection": "snmpget", "oid": stateOID.String()},
+		},
+		EnvironmentMonitorStates: map[device.HardwareHealthComponentState][]int{
+			device.HardwareHealthComponentStateNormal:   {0},
+			device.HardwareHealthComponentStateWarning:  {1, 2},
+			device.HardwareHealthComponentStateCritical: {3},
+		},
+	}
+	hardwareHealth, err := y.convert(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	o := deviceClassCommunicator{&deviceClass{
+		config: deviceClassConfig{
+			components: map[component.Component]bool{component.HardwareHealth: true},
+		},
+		components: deviceClassComponents{hardwareHealth: &hardwareHealth},
+	}}
+
+	cases := map[int]device.HardwareHealthComponentState{
+		0: device.HardwareHealthComponentStateNormal,
+		1: device.HardwareHealthComponentStateWarning,
+		2: device.HardwareHealthComponentStateWarning,
+		3: device.HardwareHealthComponentStateCritical,
+		7: device.HardwareHealthComponentStateUnknown,
+	}
+	for value, expected := range cases {
+		var snmpClient network.MockSNMPClient
+		snmpClient.
+			On("SNMPGet", mock.Anything, stateOID).
+			Return([]network.SNMPResponse{network.NewSNMPResponse(stateOID.String(), gosnmp.Integer, value)}, nil)
+		snmpClient.
+			On("SNMPWalk", mock.Anything, mock.Anything).
+			Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+		ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+			SNMP: &network.RequestDeviceConnectionSNMP{
+				SnmpClient: &snmpClient,
+			},
+		})
+
+		res, err := o.GetHardwareHealthComponent(ctx)
+		if assert.NoError(t, err, "value %d", value) && assert.NotNil(t, res.EnvironmentMonitorState, "value %d", value) {
+			assert.Equal(t, expected, *res.EnvironmentMonitorState, "value %d", value)
+			if expected == device.HardwareHealthComponentStateUnknown {
+				if assert.NotNil(t, res.EnvironmentMonitorStateRaw) {
+					assert.Equal(t, "7", *res.EnvironmentMonitorStateRaw)
+				}
+			} else {
+				assert.Nil(t, res.EnvironmentMonitorStateRaw, "value %d", value)
+			}
+		}
+	}
+}
+
+func TestYamlComponentsHardwareHealthProperties_convert_environmentMonitorStates(t *testing.T) {
+	y := yamlComponentsHardwareHealthProperties{
+		EnvironmentMonitorStates: map[device.HardwareHealthComponentState][]int{
+			device.HardwareHealthComponentStateNormal: {1},
+			"ok": {2},
+		},
+	}
+	_, err := y.convert(nil)
+	assert.Error(t, err, "invalid states must not be accepted")
+
+	y.EnvironmentMonitorStates = map[device.HardwareHealthComponentState][]int{
+		device.HardwareHealthComponentStateNormal:  {1},
+		device.HardwareHealthComponentStateWarning: {1},
+	}
+	_, err = y.convert(nil)
+	assert.Error(t, err, "values must not be mapped to multiple states")
+
+	// the mapping is inherited from the parent device class
+	y.EnvironmentMonitorStates = map[device.HardwareHealthComponentState][]int{
+		device.HardwareHealthComponentStateNormal: {1},
+	}
+	parent, err := y.convert(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	child, err := (&yamlComponentsHardwareHealthProperties{}).convert(&parent)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]device.HardwareHealthComponentState{"1": device.HardwareHealthComponentStateNormal}, child.environmentMonitorStates)
+	}
+}
+
+func TestDecodeEntitySensorValue(t *testing.T) {
+	assert.Equal(t, 45.5, decodeEntitySensorValue(455, 9, 1))
+	assert.Equal(t, 52.0, decodeEntitySensorValue(52000, 8, 0))
+	assert.Equal(t, 3000.0, decodeEntitySensorValue(3, 10, 0))
+	assert.Equal(t, 1200.0, decodeEntitySensorValue(12, 9, -2))
+}
+
+func TestNormalizeInterfaces_hcCounters(t *testing.T) {
+	ifIndex := uint64(1)
+	in, out := uint64(100), uint64(200)
+	hcIn, hcOut := uint64(5000000000), uint64(6000000000)
+	zero := uint64(0)
+
+	cases := []struct {
+		name          string
+		interf        device.Interface
+		expectedIn    *uint64
+		expectedOut   *uint64
+		expected64Bit bool
+	}{
+		{
+			name:          "hc present",
+			interf:        device.Interface{IfIndex: &ifIndex, IfInOctets: &in, IfOutOctets: &out, IfHCInOctets: &hcIn, IfHCOutOctets: &hcOut},
+			expectedIn:    &hcIn,
+			expectedOut:   &hcOut,
+			expected64Bit: true,
+		},
+		{
+			name:        "hc absent",
+			interf:      device.Interface{IfIndex: &ifIndex, IfInOctets: &in, IfOutOctets: &out},
+			expectedIn:  &in,
+			expectedOut: &out,
+		},
+		{
+			name:        "hc zero but present",
+			interf:      device.Interface{IfIndex: &ifIndex, IfInOctets: &in, IfOutOctets: &out, IfHCInOctets: &zero, IfHCOutOctets: &zero},
+			expectedIn:  &in,
+			expectedOut: &out,
+		},
+		{
+			name:          "hc zero without 32-bit counters",
+			interf:        device.Interface{IfIndex: &ifIndex, IfHCInOctets: &zero, IfHCOutOctets: &zero},
+			expectedIn:    &zero,
+			expectedOut:   &zero,
+			expected64Bit: true,
+		},
+	}
+
+	for _, c := range cases {
+		interfaces := []device.Interface{c.interf}
+		if assert.NoError(t, normalizeInterfaces(interfaces, nil), c.name) {
+			assert.Equal(t, c.expectedIn, interfaces[0].IfInOctets, c.name)
+			assert.Equal(t, c.expectedOut, interfaces[0].IfOutOctets, c.name)
+			assert.Equal(t, c.expected64Bit, interfaces[0].CountersAre64Bit, c.name)
+		}
+	}
+}
+
+func TestNormalizeInterfaces_ifSpeed(t *testing.T) {
+	ifIndex := uint64(1)
+	saturated, zero, gigabit := uint64(math.MaxUint32), uint64(0), uint64(1000000000)
+	highSpeed100G, highSpeedLAG, highSpeedGigabit := uint64(100000), uint64(20000), uint64(1000)
+	expected100G, expectedLAG := uint64(100000000000), uint64(20000000000)
+
+	cases := []struct {
+		name        string
+		ifSpeed     *uint64
+		ifHighSpeed *uint64
+		expected    *uint64
+	}{
+		{name: "100G interface", ifSpeed: &saturated, ifHighSpeed: &highSpeed100G, expected: &expected100G},
+		{name: "100G interface without ifHighSpeed", ifSpeed: &saturated, expected: nil},
+		{name: "100G interface with zero ifHighSpeed", ifSpeed: &saturated, ifHighSpeed: &zero, expected: nil},
+		{name: "LAG with zero ifSpeed", ifSpeed: &zero, ifHighSpeed: &highSpeedLAG, expected: &expectedLAG},
+		{name: "LAG without ifSpeed", ifHighSpeed: &highSpeedLAG, expected: &expectedLAG},
+		{name: "loopback", ifSpeed: &zero, ifHighSpeed: &zero, expected: &zero},
+		{name: "loopback without ifHighSpeed", ifSpeed: &zero, expected: &zero},
+		{name: "gigabit interface", ifSpeed: &gigabit, ifHighSpeed: &highSpeedGigabit, expected: &gigabit},
+	}
+
+	for _, c := range cases {
+		interfaces := []device.Interface{{IfIndex: &ifIndex, IfSpeed: c.ifSpeed, IfHighSpeed: c.ifHighSpeed}}
+		if assert.NoError(t, normalizeInterfaces(interfaces, nil), c.name) {
+			assert.Equal(t, c.expected, interfaces[0].IfSpeed, c.name)
+		}
+	}
+}
+
+func TestNormalizeInterfaces_status(t *testing.T) {
+	ifIndex := uint64(1)
+	cases := []struct {
+		adminStatus, operStatus device.Status
+		expectedAdmin           device.Status
+		expectedOper            device.Status
+	}{
+		{adminStatus: "1", operStatus: "7", expectedAdmin: device.StatusUp, expectedOper: device.StatusLowerLayerDown},
+		{adminStatus: "UP", operStatus: "down(2)", expectedAdmin: device.StatusUp, expectedOper: device.StatusDown},
+		{adminStatus: "up", operStatus: "not present", expectedAdmin: device.StatusUp, expectedOper: device.StatusNotPresent},
+		{adminStatus: "2", operStatus: "custom", expectedAdmin: device.StatusDown, expectedOper: "custom"},
+	}
+
+	for _, c := range cases {
+		adminStatus, operStatus := c.adminStatus, c.operStatus
+		interfaces := []device.Interface{{IfIndex: &ifIndex, IfAdminStatus: &adminStatus, IfOperStatus: &operStatus}}
+		if assert.NoError(t, normalizeInterfaces(interfaces, nil)) {
+			assert.Equal(t, c.expectedAdmin, *interfaces[0].IfAdminStatus)
+			assert.Equal(t, c.expectedOper, *interfaces[0].IfOperStatus)
+		}
+	}
+
+	interfaces := []device.Interface{{IfIndex: &ifIndex}}
+	if assert.NoError(t, normalizeInterfaces(interfaces, nil)) {
+		assert.Nil(t, interfaces[0].IfAdminStatus)
+		assert.Nil(t, interfaces[0].IfOperStatus)
+	}
+}
+
+func TestNormalizeInterfaces_ifTypeName(t *testing.T) {
+	ifIndex := uint64(1)
+	cases := map[string]string{
+		"6":              "ethernetCsmacd",
+		"24":             "softwareLoopback",
+		"53":             "propVirtual",
+		"161":            "ieee8023adLag",
+		"9999":           "unknown(9999)",
+		"ethernetCsmacd": "ethernetCsmacd",
+	}
+
+	for ifType, expected := range cases {
+		ifType := ifType
+		interfaces := []device.Interface{{IfIndex: &ifIndex, IfType: &ifType}}
+		if assert.NoError(t, normalizeInterfaces(interfaces, nil), ifType) && assert.NotNil(t, interfaces[0].IfTypeName, ifType) {
+			assert.Equal(t, ifType, *interfaces[0].IfType, ifType)
+			assert.Equal(t, expected, *interfaces[0].IfTypeName, ifType)
+		}
+	}
+
+	interfaces := []device.Interface{{IfIndex: &ifIndex}}
+	if assert.NoError(t, normalizeInterfaces(interfaces, nil)) {
+		assert.Nil(t, interfaces[0].IfTypeName)
+	}
+}
+
+func TestDeviceClassCommunicator_GetCPUComponentDetailed(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID(".1.3.6.1.2.1.25.3.2.1.3")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.25.3.2.1.3.196608", gosnmp.OctetString, "GenuineIntel: Intel(R) Xeon(R) CPU"),
+			network.NewSNMPResponse(".1.3.6.1.2.1.25.3.2.1.3.262145", gosnmp.OctetString, "network interface eth0"),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID(".1.3.6.1.2.1.25.3.3.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.25.3.3.1.2.196608", gosnmp.Integer, 12),
+			network.NewSNMPResponse(".1.3.6.1.2.1.25.3.3.1.2.196609", gosnmp.Integer, 34),
+		}, nil)
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	linux, ok := h.Children["linux"]
+	if !assert.True(t, ok, "linux device class not found") {
+		return
+	}
+
+	res, err := linux.NetworkDeviceCommunicator.GetCPUComponentDetailed(ctx)
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Equal(t, "GenuineIntel: Intel(R) Xeon(R) CPU", res[0].Description)
+		assert.Equal(t, 12.0, *res[0].Load)
+		assert.Equal(t, "196609", res[1].Description)
+		assert.Equal(t, 34.0, *res[1].Load)
+	}
+}
+
+func TestAddEntitySensorPowerSupplyReadings(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		// entPhySensorType
+		"1.3.6.1.2.1.99.1.1.1.1": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.101", gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.102", gosnmp.Integer, 6),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.103", gosnmp.Integer, 4),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.300", gosnmp.Integer, 4),
+		},
+		// entPhySensorScale
+		"1.3.6.1.2.1.99.1.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.101", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.102", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.103", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.300", gosnmp.Integer, 9),
+		},
+		// entPhySensorPrecision
+		"1.3.6.1.2.1.99.1.1.1.3": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.101", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.102", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.103", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.300", gosnmp.Integer, 0),
+		},
+		// entPhySensorValue
+		"1.3.6.1.2.1.99.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.101", gosnmp.Integer, 230),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.102", gosnmp.Integer, 1525),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.103", gosnmp.Integer, 12),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.300", gosnmp.Integer, 5),
+		},
+		// entPhySensorOperStatus
+		"1.3.6.1.2.1.99.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.101", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.102", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.103", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.300", gosnmp.Integer, 1),
+		},
+		// entPhysicalDescr
+		"1.3.6.1.2.1.47.1.1.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.100", gosnmp.OctetString, "Power Supply 1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.200", gosnmp.OctetString, "Power Supply 2"),
+		},
+		// entPhysicalContainedIn
+		"1.3.6.1.2.1.47.1.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.1", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.100", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.101", gosnmp.Integer, 100),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.102", gosnmp.Integer, 100),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.103", gosnmp.Integer, 100),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.200", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.300", gosnmp.Integer, 1),
+		},
+		// entPhysicalClass
+		"1.3.6.1.2.1.47.1.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.1", gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.100", gosnmp.Integer, 6),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.101", gosnmp.Integer, 8),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.102", gosnmp.Integer, 8),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.103", gosnmp.Integer, 8),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.200", gosnmp.Integer, 6),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.300", gosnmp.Integer, 8),
+		},
+		// entPhysicalName
+		"1.3.6.1.2.1.47.1.1.1.1.7": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.100", gosnmp.OctetString, "PS1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.101", gosnmp.OctetString, "PS1 Input Voltage"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.102", gosnmp.OctetString, "PS1 Output Power"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.103", gosnmp.OctetString, "PS1 Output Voltage"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.200", gosnmp.OctetString, "PS2"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.300", gosnmp.OctetString, "Chassis Voltage"),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	ps1, ps2 := "PS1", "Power Supply 2"
+	powerSupplies := []device.HardwareHealthComponentPowerSupply{{Description: &ps1}, {Description: &ps2}}
+	err := addEntitySensorPowerSupplyReadings(ctx, powerSupplies)
+	if assert.NoError(t, err) {
+		if assert.NotNil(t, powerSupplies[0].InputVoltage) {
+			assert.Equal(t, 230.0, *powerSupplies[0].InputVoltage)
+		}
+		if assert.NotNil(t, powerSupplies[0].OutputWattage) {
+			assert.Equal(t, 152.5, *powerSupplies[0].OutputWattage)
+		}
+		assert.Nil(t, powerSupplies[0].Capacity)
+		assert.Nil(t, powerSupplies[1].InputVoltage)
+		assert.Nil(t, powerSupplies[1].OutputWattage)
+	}
+}
+
+func TestAddEntitySensorFanSpeeds(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		// entPhySensorType
+		"1.3.6.1.2.1.99.1.1.1.1": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.11", gosnmp.Integer, 10),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.31", gosnmp.Integer, 10),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.40", gosnmp.Integer, 8),
+		},
+		// entPhySensorScale
+		"1.3.6.1.2.1.99.1.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.11", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.2.31", gosnmp.Integer, 9),
+		},
+		// entPhySensorPrecision
+		"1.3.6.1.2.1.99.1.1.1.3": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.11", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.3.31", gosnmp.Integer, 0),
+		},
+		// entPhySensorValue
+		"1.3.6.1.2.1.99.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.11", gosnmp.Integer, 5200),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.4.31", gosnmp.Integer, 7800),
+		},
+		// entPhySensorOperStatus
+		"1.3.6.1.2.1.99.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.11", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.5.31", gosnmp.Integer, 1),
+		},
+		// entPhysicalDescr
+		"1.3.6.1.2.1.47.1.1.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.10", gosnmp.OctetString, "Chassis Fan 1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.20", gosnmp.OctetString, "Chassis Fan 2"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.30", gosnmp.OctetString, "Fan Tray"),
+		},
+		// entPhysicalContainedIn
+		"1.3.6.1.2.1.47.1.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.10", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.11", gosnmp.Integer, 10),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.20", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.30", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.31", gosnmp.Integer, 30),
+		},
+		// entPhysicalClass
+		"1.3.6.1.2.1.47.1.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.1", gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.10", gosnmp.Integer, 7),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.11", gosnmp.Integer, 8),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.20", gosnmp.Integer, 7),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.30", gosnmp.Integer, 7),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.31", gosnmp.Integer, 8),
+		},
+		// entPhysicalName
+		"1.3.6.1.2.1.47.1.1.1.1.7": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.10", gosnmp.OctetString, "Fan 1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.11", gosnmp.OctetString, "Fan 1 Speed"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.20", gosnmp.OctetString, "Fan 2"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.30", gosnmp.OctetString, "FT"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.31", gosnmp.OctetString, "FT Speed"),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	fan1, fan2, fan3 := "Fan 1", "Fan 2", "Fan Tray 1"
+	maxSpeed := 9000
+	fans := []device.HardwareHealthComponentFan{{Description: &fan1, MaxSpeedRPM: &maxSpeed}, {Description: &fan2}, {Description: &fan3}}
+	indices := []value.Value{value.New("1"), value.New("2"), value.New("30")}
+	err := addEntitySensorFanSpeeds(ctx, fans, indices)
+	if assert.NoError(t, err) {
+		// matched by the name of the fan entity
+		if assert.NotNil(t, fans[0].SpeedRPM) {
+			assert.Equal(t, 5200, *fans[0].SpeedRPM)
+		}
+		assert.Equal(t, 9000, *fans[0].MaxSpeedRPM)
+
+		// fan without rpm sensor
+		assert.Nil(t, fans[1].SpeedRPM)
+		assert.Nil(t, fans[1].MaxSpeedRPM)
+
+		// matched by the entPhysicalIndex of the fan entity
+		if assert.NotNil(t, fans[2].SpeedRPM) {
+			assert.Equal(t, 7800, *fans[2].SpeedRPM)
+		}
+	}
+}
+
+func TestAddEntitySensorFanSpeeds_ciscoEntitySensor(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		// entPhySensorType, only temperature sensors
+		"1.3.6.1.2.1.99.1.1.1.1": {
+			network.NewSNMPResponse("1.3.6.1.2.1.99.1.1.1.1.40", gosnmp.Integer, 8),
+		},
+		// entSensorType
+		"1.3.6.1.4.1.9.9.91.1.1.1.1.1": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.1.11", gosnmp.Integer, 10),
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.1.21", gosnmp.Integer, 10),
+		},
+		// entSensorScale
+		"1.3.6.1.4.1.9.9.91.1.1.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.2.11", gosnmp.Integer, 9),
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.2.21", gosnmp.Integer, 9),
+		},
+		// entSensorPrecision
+		"1.3.6.1.4.1.9.9.91.1.1.1.1.3": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.3.11", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.3.21", gosnmp.Integer, 0),
+		},
+		// entSensorValue
+		"1.3.6.1.4.1.9.9.91.1.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.4.11", gosnmp.Integer, 4320),
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.4.21", gosnmp.Integer, 0),
+		},
+		// entSensorStatus, the sensor of the second fan is unavailable
+		"1.3.6.1.4.1.9.9.91.1.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.5.11", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.4.1.9.9.91.1.1.1.1.5.21", gosnmp.Integer, 2),
+		},
+		// entPhysicalDescr
+		"1.3.6.1.2.1.47.1.1.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.10", gosnmp.OctetString, "Switch 1 - FAN - T1 1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.2.20", gosnmp.OctetString, "Switch 1 - FAN - T1 2"),
+		},
+		// entPhysicalContainedIn
+		"1.3.6.1.2.1.47.1.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.11", gosnmp.Integer, 10),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.21", gosnmp.Integer, 20),
+		},
+		// entPhysicalClass
+		"1.3.6.1.2.1.47.1.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.10", gosnmp.Integer, 7),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.11", gosnmp.Integer, 8),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.20", gosnmp.Integer, 7),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.21", gosnmp.Integer, 8),
+		},
+		// entPhysicalName
+		"1.3.6.1.2.1.47.1.1.1.1.7": {},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	// the descriptions of the CISCO-ENVMON-MIB fan table match the entPhysicalDescr of the fans
+	fan1, fan2 := "Switch 1 - FAN - T1 1", "Switch 1 - FAN - T1 2"
+	fans := []device.HardwareHealthComponentFan{{Description: &fan1}, {Description: &fan2}}
+	indices := []value.Value{value.New("1011"), value.New("1012")}
+	err := addEntitySensorFanSpeeds(ctx, fans, indices)
+	if assert.NoError(t, err) {
+		if assert.NotNil(t, fans[0].SpeedRPM) {
+			assert.Equal(t, 4320, *fans[0].SpeedRPM)
+		}
+		assert.Nil(t, fans[1].SpeedRPM)
+	}
+}
+
+func TestDeviceClassCommunicator_GetRoutingTable(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	ipv4Route := network.OID("1.4.10.0.0.0.8.2.0.0.1.4.192.0.2.1")
+	ipv6Route := network.OID("2.16.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.2.0.0.0.0")
+	walks := map[string][]network.SNMPResponse{
+		// inetCidrRouteIfIndex
+		"1.3.6.1.2.1.4.24.7.1.7": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.7."+ipv4Route, gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.7."+ipv6Route, gosnmp.Integer, 0),
+		},
+		// inetCidrRouteType
+		"1.3.6.1.2.1.4.24.7.1.8": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.8."+ipv4Route, gosnmp.Integer, 4),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.8."+ipv6Route, gosnmp.Integer, 5),
+		},
+		// inetCidrRouteProto
+		"1.3.6.1.2.1.4.24.7.1.9": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.9."+ipv4Route, gosnmp.Integer, 13),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.9."+ipv6Route, gosnmp.Integer, 3),
+		},
+		// inetCidrRouteMetric1
+		"1.3.6.1.2.1.4.24.7.1.12": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.12."+ipv4Route, gosnmp.Integer, 20),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.24.7.1.12."+ipv6Route, gosnmp.Integer, -1),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	sut := deviceClassCommunicator{}
+	routes, err := sut.GetRoutingTable(ctx)
+	if assert.NoError(t, err) {
+		destination, prefixLength, nextHop, ifIndex, routeType, protocol, metric := "10.0.0.0", 8, "192.0.2.1", uint64(3), "remote", "ospf", 20
+		destination6, prefixLength6, routeType6, protocol6 := "::", 0, "blackhole", "netmgmt"
+		assert.Equal(t, []device.Route{
+			{
+				Destination:  &destination,
+				PrefixLength: &prefixLength,
+				NextHop:      &nextHop,
+				IfIndex:      &ifIndex,
+				Type:         &routeType,
+				Protocol:     &protocol,
+				Metric:       &metric,
+			},
+			{
+				Destination:  &destination6,
+				PrefixLength: &prefixLength6,
+				Type:         &routeType6,
+				Protocol:     &protocol6,
+			},
+		}, routes)
+	}
+}
+
+func TestDeviceClassCommunicator_GetCountRoutes(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	// inetCidrRouteNumber is not supported, so ipCidrRouteNumber is used
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID("1.3.6.1.2.1.4.24.6.0")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID("1.3.6.1.2.1.4.24.3.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse("1.3.6.1.2.1.4.24.3.0", gosnmp.Gauge32, uint(812345))}, nil)
+
+	sut := deviceClassCommunicator{}
+	count, err := sut.GetCountRoutes(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 812345, count)
+	}
+	snmpClient.AssertNotCalled(t, "SNMPWalk", mock.Anything, mock.Anything)
+}
+
+func TestDeviceClassCommunicator_GetEntityComponent(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		// entPhysicalContainedIn
+		"1.3.6.1.2.1.47.1.1.1.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.1", gosnmp.Integer, 0),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.2", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.4.10", gosnmp.Integer, 2),
+		},
+		// entPhysicalClass
+		"1.3.6.1.2.1.47.1.1.1.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.1", gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.2", gosnmp.Integer, 5),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.5.10", gosnmp.Integer, 9),
+		},
+		// entPhysicalName
+		"1.3.6.1.2.1.47.1.1.1.1.7": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.1", gosnmp.OctetString, "Chassis"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.2", gosnmp.OctetString, "Slot 1"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.7.10", gosnmp.OctetString, "Linecard 1"),
+		},
+		// entPhysicalFirmwareRev
+		"1.3.6.1.2.1.47.1.1.1.1.9": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.9.10", gosnmp.OctetString, "1.2.3"),
+		},
+		// entPhysicalSerialNum
+		"1.3.6.1.2.1.47.1.1.1.1.11": {
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.11.1", gosnmp.OctetString, "FOX1234"),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.11.2", gosnmp.OctetString, ""),
+			network.NewSNMPResponse("1.3.6.1.2.1.47.1.1.1.1.11.10", gosnmp.OctetString, "FOX5678"),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+	// entPhysicalModelName
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.47.1.1.1.1.13")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+
+	sut := deviceClassCommunicator{}
+	entity, err := sut.GetEntityComponent(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	chassis, container, module := "chassis", "container", "module"
+	chassisName, slotName, linecardName := "Chassis", "Slot 1", "Linecard 1"
+	chassisSerial, linecardSerial, linecardFirmware := "FOX1234", "FOX5678", "1.2.3"
+	assert.Equal(t, []device.PhysicalEntity{
+		{Index: 1, ParentIndex: 0, Class: &chassis, Name: &chassisName, SerialNumber: &chassisSerial},
+		{Index: 2, ParentIndex: 1, Class: &container, Name: &slotName},
+		{Index: 10, ParentIndex: 2, Class: &module, Name: &linecardName, SerialNumber: &linecardSerial, FirmwareRev: &linecardFirmware},
+	}, entity.Entities)
+}
+
+func TestDeviceClassCommunicator_GetCountInterfaces(t *testing.T) {
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.2.1.0")).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(".1.3.6.1.2.1.2.1.0", gosnmp.Integer, 24)}, nil)
+
+	count, err := h.NetworkDeviceCommunicator.GetCountInterfaces(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 24, count)
+	}
+	snmpClient.AssertNumberOfCalls(t, "SNMPGet", 1)
+	snmpClient.AssertNotCalled(t, "SNMPWalk", mock.Anything, mock.Anything)
+
+	// ifNumber is not supported, so only the ifIndex values are walked
+	snmpClient = network.MockSNMPClient{}
+	ctx = network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPGet", mock.Anything, network.OID(".1.3.6.1.2.1.2.1.0")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.3.6.1.2.1.2.2.1.1.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.2.2.1.1.2", gosnmp.Integer, 2),
+			network.NewSNMPResponse("1.3.6.1.2.1.2.2.1.1.10", gosnmp.Integer, 10),
+		}, nil)
+
+	count, err = h.NetworkDeviceCommunicator.GetCountInterfaces(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, count)
+	}
+	snmpClient.AssertNumberOfCalls(t, "SNMPGet", 1)
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 1)
+}
+
+func TestParseIPCidrRouteIndex(t *testing.T) {
+	destination, prefixLength, nextHop, err := parseIPCidrRouteIndex("172.16.0.0.255.255.240.0.0.192.0.2.254")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "172.16.0.0", destination)
+		assert.Equal(t, 20, prefixLength)
+		assert.Equal(t, "192.0.2.254", nextHop)
+	}
+
+	destination, prefixLength, nextHop, err = parseIPCidrRouteIndex("0.0.0.0.0.0.0.0.0.0.0.0.0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0.0.0.0", destination)
+		assert.Equal(t, 0, prefixLength)
+		assert.Equal(t, "", nextHop)
+	}
+
+	_, _, _, err = parseIPCidrRouteIndex("10.0.0.0.255")
+	assert.Error(t, err)
+}
+
+func TestAddInterfaceIPAddresses(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	ipv4 := network.OID("1.4.192.0.2.1")
+	ipv4Broadcast := network.OID("1.4.192.0.2.255")
+	ipv6 := network.OID("2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.1")
+	ipv6LinkLocal := network.OID("4.20.254.128.0.0.0.0.0.0.2.0.0.255.254.0.0.1.0.0.0.2")
+	walks := map[string][]network.SNMPResponse{
+		// ipAddressIfIndex
+		"1.3.6.1.2.1.4.34.1.3": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.3."+ipv4, gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.3."+ipv4Broadcast, gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.3."+ipv6, gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.3."+ipv6LinkLocal, gosnmp.Integer, 2),
+		},
+		// ipAddressType
+		"1.3.6.1.2.1.4.34.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.4."+ipv4, gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.4."+ipv4Broadcast, gosnmp.Integer, 3),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.4."+ipv6, gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.4."+ipv6LinkLocal, gosnmp.Integer, 1),
+		},
+		// ipAddressPrefix
+		"1.3.6.1.2.1.4.34.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.5."+ipv4, gosnmp.ObjectIdentifier, ".1.3.6.1.2.1.4.32.1.5.1.1.4.192.0.2.0.24"),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.5."+ipv6, gosnmp.ObjectIdentifier, ".1.3.6.1.2.1.4.32.1.5.1.2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.0.64"),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.34.1.5."+ipv6LinkLocal, gosnmp.ObjectIdentifier, ".0.0"),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	ifIndex1, ifIndex2, ifIndex3 := uint64(1), uint64(2), uint64(3)
+	interfaces := []device.Interface{{IfIndex: &ifIndex1}, {IfIndex: &ifIndex2}, {IfIndex: &ifIndex3}}
+	addInterfaceIPAddresses(ctx, interfaces)
+
+	assert.Equal(t, []device.IPAddress{
+		{Address: "192.0.2.1", PrefixLength: 24},
+		{Address: "2001:db8::1", PrefixLength: 64},
+	}, interfaces[0].IPAddresses)
+	assert.Equal(t, []device.IPAddress{
+		{Address: "fe80::200:ff:fe00:1", LinkLocal: true},
+	}, interfaces[1].IPAddresses)
+	assert.Equal(t, []device.IPAddress{}, interfaces[2].IPAddresses)
+}
+
+func TestAddInterfaceIPAddresses_ipAddrTable(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.4.34.1.3")).
+		Return(nil, tholaerr.NewNotFoundError("no such object"))
+	walks := map[string][]network.SNMPResponse{
+		// ipAdEntIfIndex
+		"1.3.6.1.2.1.4.20.1.2": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.20.1.2.169.254.0.1", gosnmp.Integer, 2),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.20.1.2.192.0.2.1", gosnmp.Integer, 2),
+		},
+		// ipAdEntNetMask
+		"1.3.6.1.2.1.4.20.1.3": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.20.1.3.169.254.0.1", gosnmp.IPAddress, "255.255.0.0"),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.20.1.3.192.0.2.1", gosnmp.IPAddress, "255.255.255.192"),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	ifIndex1, ifIndex2 := uint64(1), uint64(2)
+	interfaces := []device.Interface{{IfIndex: &ifIndex1}, {IfIndex: &ifIndex2}}
+	addInterfaceIPAddresses(ctx, interfaces)
+
+	assert.Equal(t, []device.IPAddress{}, interfaces[0].IPAddresses)
+	assert.Equal(t, []device.IPAddress{
+		{Address: "169.254.0.1", PrefixLength: 16, LinkLocal: true},
+		{Address: "192.0.2.1", PrefixLength: 26},
+	}, interfaces[1].IPAddresses)
+}
+
+func TestAddInterfaceIPStats(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		// ipIfStatsInReceives
+		"1.3.6.1.2.1.4.31.3.1.3": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.3.1.1", gosnmp.Counter32, 10),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.3.2.1", gosnmp.Counter32, 20),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.3.2.2", gosnmp.Counter32, 30),
+		},
+		// ipIfStatsHCInReceives
+		"1.3.6.1.2.1.4.31.3.1.4": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.4.1.1", gosnmp.Counter64, 10),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.4.2.1", gosnmp.Counter64, 20),
+		},
+		// ipIfStatsInOctets
+		"1.3.6.1.2.1.4.31.3.1.5": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.5.1.1", gosnmp.Counter32, 1000),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.5.2.1", gosnmp.Counter32, 2000),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.5.2.2", gosnmp.Counter32, 3000),
+		},
+		// ipIfStatsHCInOctets
+		"1.3.6.1.2.1.4.31.3.1.6": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.6.1.1", gosnmp.Counter64, 5000000000),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.6.2.1", gosnmp.Counter64, 2000),
+		},
+		// ipIfStatsOutOctets
+		"1.3.6.1.2.1.4.31.3.1.32": {
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.32.1.1", gosnmp.Counter32, 100),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.32.2.1", gosnmp.Counter32, 200),
+			network.NewSNMPResponse("1.3.6.1.2.1.4.31.3.1.32.2.2", gosnmp.Counter32, 300),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+	snmpClient.
+		On("SNMPWalk", mock.Anything, mock.Anything).
+		Return(nil, tholaerr.NewNotFoundError("no such object"))
+
+	ifIndex1, ifIndex2, ifIndex3 := uint64(1), uint64(2), uint64(3)
+	interfaces := []device.Interface{{IfIndex: &ifIndex1}, {IfIndex: &ifIndex2}, {IfIndex: &ifIndex3}}
+	addInterfaceIPStats(ctx, interfaces, true, true)
+
+	ten, twenty, thirty := uint64(10), uint64(20), uint64(30)
+	hcInOctets, inOctetsIPv6, inOctetsIPv6Second := uint64(5000000000), uint64(2000), uint64(3000)
+	out1, out2, out3 := uint64(100), uint64(200), uint64(300)
+	assert.Equal(t, &device.InterfaceIPStats{InOctets: &hcInOctets, OutOctets: &out1, InPackets: &ten}, interfaces[0].IPv4Stats)
+	assert.Equal(t, &device.InterfaceIPStats{InOctets: &inOctetsIPv6, OutOctets: &out2, InPackets: &twenty}, interfaces[0].IPv6Stats)
+	assert.Nil(t, interfaces[1].IPv4Stats)
+	assert.Equal(t, &device.InterfaceIPStats{InOctets: &inOctetsIPv6Second, OutOctets: &out3, InPackets: &thirty}, interfaces[1].IPv6Stats)
+	assert.Nil(t, interfaces[2].IPv4Stats)
+	assert.Nil(t, interfaces[2].IPv6Stats)
+
+	interfaces = []device.Interface{{IfIndex: &ifIndex1}}
+	addInterfaceIPStats(ctx, interfaces, false, true)
+	assert.Nil(t, interfaces[0].IPv4Stats)
+	assert.NotNil(t, interfaces[0].IPv6Stats)
+}
+
+func TestDeviceClassCommunicator_GetEnvironmentComponent(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	walks := map[string][]network.SNMPResponse{
+		"1.3.6.1.4.1.21796.4.9.3.1.2": {
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.2.1", gosnmp.OctetString, "Rack 1"),
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.2.2", gosnmp.OctetString, "Rack 1 Humidity"),
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.2.3", gosnmp.OctetString, "Outside"),
+		},
+		"1.3.6.1.4.1.21796.4.9.3.1.7": {
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.7.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.7.2", gosnmp.Integer, 4),
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.7.3", gosnmp.Integer, 2),
+		},
+		"1.3.6.1.4.1.21796.4.9.3.1.5": {
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.5.1", gosnmp.Integer, 235),
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.5.2", gosnmp.Integer, 412),
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.3.1.5.3", gosnmp.Integer, 680),
+		},
+		"1.3.6.1.4.1.21796.4.9.1.1.3": {
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.1.1.3.1", gosnmp.OctetString, "Door"),
+		},
+		"1.3.6.1.4.1.21796.4.9.1.1.2": {
+			network.NewSNMPResponse("1.3.6.1.4.1.21796.4.9.1.1.2.1", gosnmp.Integer, 0),
+		},
+	}
+	for oid, responses := range walks {
+		snmpClient.
+			On("SNMPWalk", mock.Anything, network.OID(oid)).
+			Return(responses, nil)
+	}
+
+	h, err := GetHierarchy()
+	if !assert.NoError(t, err) {
+		return
+	}
+	ste2, ok := h.Children["hwg-ste2"]
+	if !assert.True(t, ok, "hwg-ste2 device class not found") {
+		return
+	}
+
+	res, err := ste2.NetworkDeviceCommunicator.GetEnvironmentComponent(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the sensor in fahrenheit has an unknown type and is skipped
+	if assert.Len(t, res.Temperature, 1) {
+		assert.Equal(t, "Rack 1", *res.Temperature[0].Description)
+		assert.Equal(t, 23.5, *res.Temperature[0].Temperature)
+	}
+	if assert.Len(t, res.Humidity, 1) {
+		assert.Equal(t, "Rack 1 Humidity", *res.Humidity[0].Description)
+		assert.Equal(t, 41.2, *res.Humidity[0].Humidity)
+	}
+	if assert.Len(t, res.DryContacts, 1) {
+		assert.Equal(t, "Door", *res.DryContacts[0].Description)
+		assert.Equal(t, device.EnvironmentComponentDryContactStateOpen, *res.DryContacts[0].State)
+	}
+}
+
+func TestDeviceClassCommunicator_GetModelSeries_fromModel(t *testing.T) {
+	modelSeriesOID := network.OID("1.3.6.1.4.1.9.9.999.1.0")
+	y := yamlDeviceClassIdentifyProperties{
+		ModelSeries: []interface{}{
+			map[interface{}]interface{}{"detection": "snmpget", "oid": modelSeriesOID.String()},
+		},
+		ModelSeriesFromModel: []yamlModelSeriesRule{
+			{Regex: `^C(9\d{3})`, ModelSeries: "Catalyst $1"},
+			{Regex: `^WS-C(\d{4})`, ModelSeries: "Catalyst $1"},
+			{Regex: `^ISR(\d)\d{3}`, ModelSeries: "ISR ${1}000"},
+			{Regex: `^N(\d)K-`, ModelSeries: "Nexus ${1}000"},
+		},
+	}
+	properties, err := y.convert(deviceClassIdentifyProperties{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	o := deviceClassCommunicator{&deviceClass{identify: deviceClassIdentify{properties: properties}}}
+
+	cases := map[string]string{
+		"C9300-48P":        "Catalyst 9300",
+		"C9500-24Y4C":      "Catalyst 9500",
+		"WS-C2960X-48TS-L": "Catalyst 2960",
+		"ISR4331/K9":       "ISR 4000",
+		"N9K-C93180YC-FX":  "Nexus 9000",
+		"N3K-C3048TP-1GE":  "Nexus 3000",
+		"ASR1001-X":        "",
+		"CISCO2911/K9":     "",
+		"AIR-AP1852I-E-K9": "",
+		"C8200-1N-4T":      "",
+		"WS-C3850-24XS-S":  "Catalyst 3850",
+	}
+	for model, expected := range cases {
+		t.Run(model, func(t *testing.T) {
+			var snmpClient network.MockSNMPClient
+			snmpClient.
+				On("SNMPGet", mock.Anything, modelSeriesOID).
+				Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+			ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+				SNMP: &network.RequestDeviceConnectionSNMP{
+					SnmpClient: &snmpClient,
+				},
+			})
+			ctx = device.NewContextWithDeviceProperties(ctx, device.Device{Properties: device.Properties{Model: &model}})
+
+			res, err := o.GetModelSeries(ctx)
+			if expected == "" {
+				assert.True(t, tholaerr.IsNotFoundError(err), "expected not found error, got: %v", err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, res)
+			}
+		})
+	}
+
+	// the model series of the device is preferred
+	var snmpClient network.MockSNMPClient
+	snmpClient.
+		On("SNMPGet", mock.Anything, modelSeriesOID).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(modelSeriesOID.String(), gosnmp.OctetString, "Catalyst 9000")}, nil)
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	model := "C9300-48P"
+	ctx = device.NewContextWithDeviceProperties(ctx, device.Device{Properties: device.Properties{Model: &model}})
+	res, err := o.GetModelSeries(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Catalyst 9000", res)
+	}
+
+	// the model series is not derived if the device could not be reached
+	snmpClient = network.MockSNMPClient{}
+	snmpClient.
+		On("SNMPGet", mock.Anything, modelSeriesOID).
+		Return(nil, tholaerr.NewSNMPTimeoutError("request timeout (after 1 retries)"))
+	ctx = network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	ctx = device.NewContextWithDeviceProperties(ctx, device.Device{Properties: device.Properties{Model: &model}})
+	_, err = o.GetModelSeries(ctx)
+	if assert.Error(t, err) {
+		assert.True(t, tholaerr.IsSNMPTimeoutError(property.NetworkError(err)))
+	}
+}
+
+func TestDeviceClassCommunicator_SetInterfaceAdminStatus(t *testing.T) {
+	oid := network.OID("1.3.6.1.2.1.2.2.1.7.5")
+	newContext := func(snmpClient *network.MockSNMPClient, writesEnabled bool) context.Context {
+		return network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+			SNMP: &network.RequestDeviceConnectionSNMP{
+				SnmpClient: snmpClient,
+			},
+			RawConnectionData: network.ConnectionData{
+				SNMP: &network.SNMPConnectionData{WritesEnabled: &writesEnabled},
+			},
+		})
+	}
+	var o deviceClassCommunicator
+
+	// writes need to be enabled explicitly
+	var disabledClient network.MockSNMPClient
+	err := o.SetInterfaceAdminStatus(newContext(&disabledClient, false), 5, false)
+	assert.True(t, tholaerr.IsPreConditionError(err), "expected precondition error, got: %v", err)
+	disabledClient.AssertNotCalled(t, "SNMPSet")
+
+	// the admin status is read back after the write
+	adminStatus := 1
+	var snmpClient network.MockSNMPClient
+	snmpClient.
+		On("SNMPSet", mock.Anything, network.SNMPVariable{OID: oid, Type: gosnmp.Integer, Value: 2}).
+		Run(func(args mock.Arguments) {
+			adminStatus = args.Get(1).(network.SNMPVariable).Value.(int)
+		}).
+		Return(nil)
+	snmpClient.
+		On("SNMPGet", mock.Anything, oid).
+		Return(func(context.Context, ...network.OID) []network.SNMPResponse {
+			return []network.SNMPResponse{network.NewSNMPResponse(oid.String(), gosnmp.Integer, adminStatus)}
+		}, nil)
+	err = o.SetInterfaceAdminStatus(newContext(&snmpClient, true), 5, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, adminStatus)
+		snmpClient.AssertExpectations(t)
+	}
+
+	// the write fails if the device didn't apply it
+	var ignoringClient network.MockSNMPClient
+	ignoringClient.
+		On("SNMPSet", mock.Anything, network.SNMPVariable{OID: oid, Type: gosnmp.Integer, Value: 1}).
+		Return(nil)
+	ignoringClient.
+		On("SNMPGet", mock.Anything, oid).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(oid.String(), gosnmp.Integer, 2)}, nil)
+	err = o.SetInterfaceAdminStatus(newContext(&ignoringClient, true), 5, true)
+	assert.Error(t, err)
+}
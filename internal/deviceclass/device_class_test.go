@@ -1,11 +1,106 @@
 package deviceclass
 
 import (
+	"github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"testing/fstest"
 )
 
 func TestDeviceClass_GetHierarchy(t *testing.T) {
 	_, err := GetHierarchy()
 	assert.NoError(t, err, "hierarchy building failed")
 }
+
+func TestDeviceClass_GetHierarchyFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"generic.yaml": {Data: []byte("name: generic\n")},
+		"generic/test.yaml": {Data: []byte(`name: test
+
+match:
+  logical_operator: OR
+  conditions:
+    - type: SysDescription
+      match_mode: startsWith
+      values:
+        - Test
+`)},
+	}
+
+	hier, err := GetHierarchyFromFS(fsys, ".")
+	if assert.NoError(t, err) {
+		assert.Contains(t, hier.Children, "test")
+	}
+
+	// yaml errors are reported with file and line
+	fsys["generic/test.yaml"] = &fstest.MapFile{Data: []byte("name: test\n\nconfig:\n  components: abc\n")}
+	_, err = GetHierarchyFromFS(fsys, ".")
+	validationErr, ok := errors.Cause(err).(*ValidationError)
+	if assert.True(t, ok, "error is no validation error") && assert.Len(t, validationErr.Problems, 1) {
+		assert.Equal(t, "generic/test.yaml", validationErr.Problems[0].File)
+		assert.Equal(t, 4, validationErr.Problems[0].Line)
+	}
+
+	// errors of the device class definition are reported with the file and the yaml path
+	fsys["generic/test.yaml"] = &fstest.MapFile{Data: []byte("name: test\nmatch:\n  logical_operator: XOR\n")}
+	_, err = GetHierarchyFromFS(fsys, ".")
+	validationErr, ok = errors.Cause(err).(*ValidationError)
+	if assert.True(t, ok, "error is no validation error") && assert.Len(t, validationErr.Problems, 1) {
+		assert.Equal(t, "generic/test.yaml", validationErr.Problems[0].File)
+		assert.Equal(t, "match", validationErr.Problems[0].Path)
+	}
+}
+
+func TestDeviceClassIdentifyProperties_stages(t *testing.T) {
+	propertyReader := func(name string) property.Reader {
+		reader, err := property.InterfaceSlice2Reader([]interface{}{
+			map[interface{}]interface{}{"detection": "property", "property": name},
+		}, condition.PropertyDefault, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reader
+	}
+
+	prop := deviceClassIdentifyProperties{
+		vendor: propertyReader("model"),
+		model:  propertyReader("sys_descr"),
+	}
+	stages, err := prop.stages()
+	if assert.NoError(t, err) {
+		assert.Equal(t, [][]string{
+			{"model_series", "serial_number", "os_version", "firmware_version", "bootloader_version", "sys_name", "sys_contact", "sys_location", "sys_descr"},
+			{"model"},
+			{"vendor"},
+		}, stages)
+	}
+
+	// model series which are derived from the model are read after the model
+	derived := deviceClassIdentifyProperties{
+		modelSeriesFromModel: []modelSeriesRule{{}},
+	}
+	stages, err = derived.stages()
+	if assert.NoError(t, err) {
+		assert.Equal(t, [][]string{
+			{"vendor", "model", "serial_number", "os_version", "firmware_version", "bootloader_version", "sys_name", "sys_contact", "sys_location", "sys_descr"},
+			{"model_series"},
+		}, stages)
+	}
+
+	derived = deviceClassIdentifyProperties{
+		model:                propertyReader("model_series"),
+		modelSeriesFromModel: []modelSeriesRule{{}},
+	}
+	_, err = derived.stages()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "model -> model_series -> model")
+	}
+
+	prop.sysDescr = propertyReader("vendor")
+	_, err = prop.stages()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "vendor -> model -> sys_descr -> vendor")
+	}
+}
@@ -0,0 +1,95 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+)
+
+// columns of the entPhysicalTable of the ENTITY-MIB which are only needed for the entity component
+const (
+	entPhysicalFirmwareRevOID network.OID = "1.3.6.1.2.1.47.1.1.1.1.9"
+	entPhysicalSerialNumOID   network.OID = "1.3.6.1.2.1.47.1.1.1.1.11"
+	entPhysicalModelNameOID   network.OID = "1.3.6.1.2.1.47.1.1.1.1.13"
+)
+
+// entityClasses maps the values of PhysicalClass to their names.
+var entityClasses = map[string]string{
+	"1":  "other",
+	"2":  "unknown",
+	"3":  "chassis",
+	"4":  "backplane",
+	"5":  "container",
+	"6":  "powerSupply",
+	"7":  "fan",
+	"8":  "sensor",
+	"9":  "module",
+	"10": "port",
+	"11": "stack",
+	"12": "cpu",
+	"13": "energyObject",
+	"14": "battery",
+	"15": "storageDrive",
+}
+
+// readEntityPhysicalTable reads all entries of the entPhysicalTable sorted by their entPhysicalIndex. Entries are also
+// returned if some of their columns are empty, so that the containment tree stays complete.
+func readEntityPhysicalTable(ctx context.Context) ([]device.PhysicalEntity, error) {
+	classes, err := walkSNMPColumn(ctx, entPhysicalClassOID, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read oid '%s'", entPhysicalClassOID)
+	}
+	if len(classes) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[network.OID]map[string]string)
+	for _, oid := range []network.OID{entPhysicalContainedInOID, entPhysicalNameOID, entPhysicalFirmwareRevOID, entPhysicalSerialNumOID, entPhysicalModelNameOID} {
+		columns[oid], err = walkSNMPColumn(ctx, oid, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read oid '%s'", oid)
+		}
+	}
+
+	var entities []device.PhysicalEntity
+	for idx, class := range classes {
+		index, err := strconv.Atoi(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entPhysicalIndex '%s'", idx)
+		}
+
+		entity := device.PhysicalEntity{
+			Index:        index,
+			Name:         nonEmptyString(columns[entPhysicalNameOID][idx]),
+			SerialNumber: nonEmptyString(columns[entPhysicalSerialNumOID][idx]),
+			FirmwareRev:  nonEmptyString(columns[entPhysicalFirmwareRevOID][idx]),
+			ModelName:    nonEmptyString(columns[entPhysicalModelNameOID][idx]),
+		}
+		if name, ok := entityClasses[class]; ok {
+			entity.Class = &name
+		}
+		if parent, ok := columns[entPhysicalContainedInOID][idx]; ok {
+			entity.ParentIndex, err = strconv.Atoi(parent)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid entPhysicalContainedIn '%s' of entity '%s'", parent, idx)
+			}
+		}
+		entities = append(entities, entity)
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Index < entities[j].Index
+	})
+	return entities, nil
+}
+
+// nonEmptyString returns nil for empty strings.
+func nonEmptyString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
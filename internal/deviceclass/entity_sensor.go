@@ -0,0 +1,331 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/value"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// columns of the entPhySensorTable of the ENTITY-SENSOR-MIB
+const (
+	entPhySensorTypeOID       network.OID = "1.3.6.1.2.1.99.1.1.1.1"
+	entPhySensorScaleOID      network.OID = "1.3.6.1.2.1.99.1.1.1.2"
+	entPhySensorPrecisionOID  network.OID = "1.3.6.1.2.1.99.1.1.1.3"
+	entPhySensorValueOID      network.OID = "1.3.6.1.2.1.99.1.1.1.4"
+	entPhySensorOperStatusOID network.OID = "1.3.6.1.2.1.99.1.1.1.5"
+
+	entSensorTypeOID      network.OID = "1.3.6.1.4.1.9.9.91.1.1.1.1.1"
+	entSensorScaleOID     network.OID = "1.3.6.1.4.1.9.9.91.1.1.1.1.2"
+	entSensorPrecisionOID network.OID = "1.3.6.1.4.1.9.9.91.1.1.1.1.3"
+	entSensorValueOID     network.OID = "1.3.6.1.4.1.9.9.91.1.1.1.1.4"
+	entSensorStatusOID    network.OID = "1.3.6.1.4.1.9.9.91.1.1.1.1.5"
+
+	entPhysicalDescrOID       network.OID = "1.3.6.1.2.1.47.1.1.1.1.2"
+	entPhysicalContainedInOID network.OID = "1.3.6.1.2.1.47.1.1.1.1.4"
+	entPhysicalClassOID       network.OID = "1.3.6.1.2.1.47.1.1.1.1.5"
+	entPhysicalNameOID        network.OID = "1.3.6.1.2.1.47.1.1.1.1.7"
+)
+
+// entPhySensorType values
+const (
+	entitySensorTypeVoltsAC   = 3
+	entitySensorTypeVoltsDC   = 4
+	entitySensorTypeWatts     = 6
+	entitySensorTypePercentRH = 9
+	entitySensorTypeRPM       = 10
+)
+
+// entPhysicalClass values
+const (
+	entityClassPowerSupply = "6"
+	entityClassFan         = "7"
+)
+
+// entityContainmentMaxDepth limits how far the containment tree is followed up from a sensor to the entity it measures.
+const entityContainmentMaxDepth = 5
+
+const (
+	entitySensorScaleUnits        = 9
+	entitySensorStatusUnavailable = "2"
+)
+
+// entitySensorTable contains the columns of a sensor table which is indexed by entPhysicalIndex.
+type entitySensorTable struct {
+	name                                                     string
+	typeOID, scaleOID, precisionOID, valueOID, operStatusOID network.OID
+}
+
+// entitySensorTables are the supported sensor tables in the order they are tried. The CISCO-ENTITY-SENSOR-MIB uses the
+// same types, scales and statuses as the ENTITY-SENSOR-MIB, but is the only sensor table of many Cisco devices.
+var entitySensorTables = []entitySensorTable{
+	{"entPhySensor", entPhySensorTypeOID, entPhySensorScaleOID, entPhySensorPrecisionOID, entPhySensorValueOID, entPhySensorOperStatusOID},
+	{"entSensor", entSensorTypeOID, entSensorScaleOID, entSensorPrecisionOID, entSensorValueOID, entSensorStatusOID},
+}
+
+// entitySensor is one sensor of the ENTITY-SENSOR-MIB with its value already converted to the base unit.
+type entitySensor struct {
+	index       string
+	sensorType  int
+	description *string
+	value       float64
+}
+
+// readEntitySensors reads all available sensors of the given entPhySensorTypes of the first sensor table
+// which has sensors of these types. Sensors reporting the status unavailable are dropped.
+func readEntitySensors(ctx context.Context, sensorTypes ...int) ([]entitySensor, error) {
+	for _, table := range entitySensorTables {
+		sensors, err := readEntitySensorTable(ctx, table, sensorTypes)
+		if err != nil {
+			return nil, err
+		}
+		if len(sensors) > 0 {
+			return sensors, nil
+		}
+	}
+	return nil, nil
+}
+
+func readEntitySensorTable(ctx context.Context, table entitySensorTable, sensorTypes []int) ([]entitySensor, error) {
+	types, err := walkSNMPColumn(ctx, table.typeOID, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %sType", table.name)
+	}
+
+	var indices []int
+	for idx, t := range types {
+		if !containsSensorType(sensorTypes, t) {
+			continue
+		}
+		i, err := strconv.Atoi(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entPhysicalIndex '%s'", idx)
+		}
+		indices = append(indices, i)
+	}
+	if len(indices) == 0 {
+		return nil, nil
+	}
+	sort.Ints(indices)
+
+	columns := make(map[network.OID]map[string]string)
+	for _, oid := range []network.OID{table.scaleOID, table.precisionOID, table.valueOID, table.operStatusOID, entPhysicalNameOID} {
+		columns[oid], err = walkSNMPColumn(ctx, oid, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read oid '%s'", oid)
+		}
+	}
+
+	var sensors []entitySensor
+	for _, i := range indices {
+		idx := strconv.Itoa(i)
+		if columns[table.operStatusOID][idx] == entitySensorStatusUnavailable {
+			continue
+		}
+		rawValue, ok := columns[table.valueOID][idx]
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %sValue '%s'", table.name, rawValue)
+		}
+
+		scale := entitySensorScaleUnits
+		if s, ok := columns[table.scaleOID][idx]; ok {
+			scale, err = strconv.Atoi(s)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %sScale '%s'", table.name, s)
+			}
+		}
+		var precision int
+		if p, ok := columns[table.precisionOID][idx]; ok {
+			precision, err = strconv.Atoi(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %sPrecision '%s'", table.name, p)
+			}
+		}
+
+		sensorType, err := strconv.Atoi(types[idx])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %sType '%s'", table.name, types[idx])
+		}
+
+		sensor := entitySensor{
+			index:      idx,
+			sensorType: sensorType,
+			value:      decodeEntitySensorValue(v, scale, precision),
+		}
+		if name, ok := columns[entPhysicalNameOID][idx]; ok && name != "" {
+			sensor.description = &name
+		}
+		sensors = append(sensors, sensor)
+	}
+	return sensors, nil
+}
+
+// decodeEntitySensorValue converts a raw entPhySensorValue into the base unit of the sensor.
+// The scale is an SI prefix where 9 means units and each step is a factor of 1000,
+// the precision is the number of decimal places of the raw value.
+func decodeEntitySensorValue(value int64, scale, precision int) float64 {
+	exp := (scale-entitySensorScaleUnits)*3 - precision
+	if exp < 0 {
+		return float64(value) / math.Pow10(-exp)
+	}
+	return float64(value) * math.Pow10(exp)
+}
+
+func containsSensorType(sensorTypes []int, sensorType string) bool {
+	for _, t := range sensorTypes {
+		if strconv.Itoa(t) == sensorType {
+			return true
+		}
+	}
+	return false
+}
+
+// addEntitySensorPowerSupplyReadings adds the input voltage and output wattage of the ENTITY-SENSOR-MIB to the power
+// supplies. Sensors are assigned to the power supply entity that contains them, which is matched to a power supply
+// by its name or description. Readings that are already set are not overwritten.
+func addEntitySensorPowerSupplyReadings(ctx context.Context, powerSupplies []device.HardwareHealthComponentPowerSupply) error {
+	sensors, err := readEntitySensors(ctx, entitySensorTypeVoltsAC, entitySensorTypeVoltsDC, entitySensorTypeWatts)
+	if err != nil {
+		return err
+	}
+	if len(sensors) == 0 {
+		return nil
+	}
+
+	columns, err := readEntityPhysicalColumns(ctx)
+	if err != nil {
+		return err
+	}
+
+	descriptions := make([]*string, len(powerSupplies))
+	for i, powerSupply := range powerSupplies {
+		descriptions[i] = powerSupply.Description
+	}
+
+	for _, sensor := range sensors {
+		supply := findEntityOfClass(sensor.index, entityClassPowerSupply, columns)
+		if supply == "" {
+			continue
+		}
+		i, ok := matchEntityDescription(descriptions, columns[entPhysicalNameOID][supply], columns[entPhysicalDescrOID][supply])
+		if !ok {
+			continue
+		}
+
+		// power supplies may have sensors for both input and output, which are told apart by their names
+		v := sensor.value
+		var name string
+		if sensor.description != nil {
+			name = strings.ToLower(*sensor.description)
+		}
+		switch sensor.sensorType {
+		case entitySensorTypeVoltsAC, entitySensorTypeVoltsDC:
+			if powerSupplies[i].InputVoltage == nil && !strings.Contains(name, "output") {
+				powerSupplies[i].InputVoltage = &v
+			}
+		case entitySensorTypeWatts:
+			if powerSupplies[i].OutputWattage == nil && !strings.Contains(name, "input") {
+				powerSupplies[i].OutputWattage = &v
+			}
+		}
+	}
+	return nil
+}
+
+// addEntitySensorFanSpeeds adds the speed of the RPM sensors of the ENTITY-SENSOR-MIB, or the CISCO-ENTITY-SENSOR-MIB
+// if the former has none, to the fans.
+// Sensors are assigned to the fan entity that contains them. The fan entity is joined to a fan by its name or
+// description, or otherwise by its entPhysicalIndex for fans that are indexed by entPhysicalIndex.
+// Speeds that are already set are not overwritten.
+func addEntitySensorFanSpeeds(ctx context.Context, fans []device.HardwareHealthComponentFan, indices []value.Value) error {
+	sensors, err := readEntitySensors(ctx, entitySensorTypeRPM)
+	if err != nil {
+		return err
+	}
+	if len(sensors) == 0 {
+		return nil
+	}
+
+	columns, err := readEntityPhysicalColumns(ctx)
+	if err != nil {
+		return err
+	}
+
+	fanIndices := make(map[string]int)
+	for i, index := range indices {
+		if i < len(fans) {
+			fanIndices[index.String()] = i
+		}
+	}
+	descriptions := make([]*string, len(fans))
+	for i, fan := range fans {
+		descriptions[i] = fan.Description
+	}
+
+	for _, sensor := range sensors {
+		fanEntity := findEntityOfClass(sensor.index, entityClassFan, columns)
+		if fanEntity == "" {
+			continue
+		}
+		i, ok := matchEntityDescription(descriptions, columns[entPhysicalNameOID][fanEntity], columns[entPhysicalDescrOID][fanEntity])
+		if !ok {
+			i, ok = fanIndices[fanEntity]
+			if !ok {
+				continue
+			}
+		}
+		if fans[i].SpeedRPM == nil {
+			speed := int(math.Round(sensor.value))
+			fans[i].SpeedRPM = &speed
+		}
+	}
+	return nil
+}
+
+// readEntityPhysicalColumns reads the columns of the entPhysicalTable which are needed to assign sensors to entities.
+func readEntityPhysicalColumns(ctx context.Context) (map[network.OID]map[string]string, error) {
+	columns := make(map[network.OID]map[string]string)
+	for _, oid := range []network.OID{entPhysicalContainedInOID, entPhysicalClassOID, entPhysicalDescrOID, entPhysicalNameOID} {
+		var err error
+		columns[oid], err = walkSNMPColumn(ctx, oid, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read oid '%s'", oid)
+		}
+	}
+	return columns, nil
+}
+
+// findEntityOfClass returns the entPhysicalIndex of the entity of the given class that contains the given entity.
+func findEntityOfClass(index, class string, columns map[network.OID]map[string]string) string {
+	for depth := 0; depth < entityContainmentMaxDepth && index != "" && index != "0"; depth++ {
+		if columns[entPhysicalClassOID][index] == class {
+			return index
+		}
+		index = columns[entPhysicalContainedInOID][index]
+	}
+	return ""
+}
+
+// matchEntityDescription returns the position of the description that matches the name or description of an entity.
+func matchEntityDescription(descriptions []*string, names ...string) (int, bool) {
+	for i, description := range descriptions {
+		if description == nil {
+			continue
+		}
+		for _, name := range names {
+			if name != "" && strings.EqualFold(strings.TrimSpace(*description), strings.TrimSpace(name)) {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,198 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// types of the sensors in a mixed environment sensor table
+const (
+	environmentSensorTypeTemperature = "temperature"
+	environmentSensorTypeHumidity    = "humidity"
+	environmentSensorTypeDryContact  = "dry_contact"
+)
+
+// environmentSensor is one entry of a table that contains environment sensors of different types.
+// Value is used by temperature and humidity sensors, State by dry contacts.
+type environmentSensor struct {
+	Type        *string                                     `mapstructure:"type"`
+	Description *string                                     `mapstructure:"description"`
+	Value       *float64                                    `mapstructure:"value"`
+	State       *device.EnvironmentComponentDryContactState `mapstructure:"state"`
+}
+
+func (o *deviceClassCommunicator) GetEnvironmentComponent(ctx context.Context) (device.EnvironmentComponent, error) {
+	if !o.HasComponent(component.Environment) {
+		return device.EnvironmentComponent{}, tholaerr.NewComponentNotFoundError("no environment component available for this device")
+	}
+
+	var environment device.EnvironmentComponent
+
+	empty := true
+
+	temperature, err := o.GetEnvironmentComponentTemperature(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.EnvironmentComponent{}, errors.Wrap(err, "error occurred during get environment component temperature")
+		}
+	} else {
+		environment.Temperature = temperature
+		empty = false
+	}
+
+	humidity, err := o.GetEnvironmentComponentHumidity(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.EnvironmentComponent{}, errors.Wrap(err, "error occurred during get environment component humidity")
+		}
+	} else {
+		environment.Humidity = humidity
+		empty = false
+	}
+
+	dryContacts, err := o.GetEnvironmentComponentDryContacts(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.EnvironmentComponent{}, errors.Wrap(err, "error occurred during get environment component dry contacts")
+		}
+	} else {
+		environment.DryContacts = dryContacts
+		empty = false
+	}
+
+	if empty {
+		return device.EnvironmentComponent{}, tholaerr.NewNotFoundError("no environment data available")
+	}
+
+	return environment, nil
+}
+
+func (o *deviceClassCommunicator) GetEnvironmentComponentTemperature(ctx context.Context) ([]device.EnvironmentComponentTemperature, error) {
+	logger := log.Ctx(ctx).With().Str("groupProperty", "EnvironmentComponentTemperature").Logger()
+	ctx = logger.WithContext(ctx)
+
+	var temperature []device.EnvironmentComponentTemperature
+	sensors, err := o.readEnvironmentSensors(ctx, environmentSensorTypeTemperature, func(e *deviceClassComponentsEnvironment) groupproperty.Reader {
+		return e.temperature
+	}, &temperature)
+	if err != nil {
+		return nil, err
+	}
+	for _, sensor := range sensors {
+		temperature = append(temperature, device.EnvironmentComponentTemperature{
+			Description: sensor.Description,
+			Temperature: sensor.Value,
+		})
+	}
+	if len(temperature) == 0 {
+		return nil, tholaerr.NewNotFoundError("no temperature sensors available")
+	}
+	return temperature, nil
+}
+
+func (o *deviceClassCommunicator) GetEnvironmentComponentHumidity(ctx context.Context) ([]device.EnvironmentComponentHumidity, error) {
+	logger := log.Ctx(ctx).With().Str("groupProperty", "EnvironmentComponentHumidity").Logger()
+	ctx = logger.WithContext(ctx)
+
+	var humidity []device.EnvironmentComponentHumidity
+	sensors, err := o.readEnvironmentSensors(ctx, environmentSensorTypeHumidity, func(e *deviceClassComponentsEnvironment) groupproperty.Reader {
+		return e.humidity
+	}, &humidity)
+	if err != nil {
+		return nil, err
+	}
+	for _, sensor := range sensors {
+		humidity = append(humidity, device.EnvironmentComponentHumidity{
+			Description: sensor.Description,
+			Humidity:    sensor.Value,
+		})
+	}
+	if len(humidity) == 0 {
+		return nil, tholaerr.NewNotFoundError("no humidity sensors available")
+	}
+	return humidity, nil
+}
+
+func (o *deviceClassCommunicator) GetEnvironmentComponentDryContacts(ctx context.Context) ([]device.EnvironmentComponentDryContact, error) {
+	logger := log.Ctx(ctx).With().Str("groupProperty", "EnvironmentComponentDryContacts").Logger()
+	ctx = logger.WithContext(ctx)
+
+	var dryContacts []device.EnvironmentComponentDryContact
+	sensors, err := o.readEnvironmentSensors(ctx, environmentSensorTypeDryContact, func(e *deviceClassComponentsEnvironment) groupproperty.Reader {
+		return e.dryContacts
+	}, &dryContacts)
+	if err != nil {
+		return nil, err
+	}
+	for _, sensor := range sensors {
+		dryContacts = append(dryContacts, device.EnvironmentComponentDryContact{
+			Description: sensor.Description,
+			State:       sensor.State,
+		})
+	}
+	if len(dryContacts) == 0 {
+		return nil, tholaerr.NewNotFoundError("no dry contacts available")
+	}
+	return dryContacts, nil
+}
+
+// readEnvironmentSensors decodes the values of the typed reader of the environment component into destination and
+// returns the sensors of the given type from the mixed sensor table.
+func (o *deviceClassCommunicator) readEnvironmentSensors(ctx context.Context, sensorType string, typedReader func(*deviceClassComponentsEnvironment) groupproperty.Reader, destination interface{}) ([]environmentSensor, error) {
+	if o.components.environment == nil || (typedReader(o.components.environment) == nil && o.components.environment.sensors == nil) {
+		log.Ctx(ctx).Debug().Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+
+	if reader := typedReader(o.components.environment); reader != nil {
+		res, _, err := reader.GetProperty(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get property")
+		}
+		err = mapstructure.WeakDecode(res, destination)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode property into %s struct", sensorType)
+		}
+	}
+
+	if o.components.environment.sensors == nil {
+		return nil, nil
+	}
+
+	res, _, err := o.components.environment.sensors.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sensors property")
+	}
+	var sensors []environmentSensor
+	err = mapstructure.WeakDecode(res, &sensors)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into sensor struct")
+	}
+
+	var typedSensors []environmentSensor
+	for _, sensor := range sensors {
+		if sensor.Type == nil || !isKnownEnvironmentSensorType(*sensor.Type) {
+			log.Ctx(ctx).Trace().Interface("sensor_type", sensor.Type).Interface("description", sensor.Description).Msg("skipping sensor of unknown type")
+			continue
+		}
+		if *sensor.Type == sensorType {
+			typedSensors = append(typedSensors, sensor)
+		}
+	}
+	return typedSensors, nil
+}
+
+func isKnownEnvironmentSensorType(sensorType string) bool {
+	switch sensorType {
+	case environmentSensorTypeTemperature, environmentSensorTypeHumidity, environmentSensorTypeDryContact:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,124 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+func (o *deviceClassCommunicator) GetFirewallComponent(ctx context.Context) (device.FirewallComponent, error) {
+	if !o.HasComponent(component.Firewall) {
+		return device.FirewallComponent{}, tholaerr.NewComponentNotFoundError("no firewall component available for this device")
+	}
+
+	var firewall device.FirewallComponent
+
+	empty := true
+
+	activeSessions, err := o.GetFirewallComponentActiveSessions(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.FirewallComponent{}, errors.Wrap(err, "error occurred during get firewall component active sessions")
+		}
+	} else {
+		firewall.ActiveSessions = &activeSessions
+		empty = false
+	}
+
+	vpnTunnels, err := o.GetFirewallComponentVPNTunnels(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.FirewallComponent{}, errors.Wrap(err, "error occurred during get firewall component vpn tunnels")
+		}
+	} else {
+		firewall.VPNTunnels = vpnTunnels
+		empty = false
+	}
+
+	licenses, err := o.GetFirewallComponentLicenses(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.FirewallComponent{}, errors.Wrap(err, "error occurred during get firewall component licenses")
+		}
+	} else {
+		firewall.Licenses = licenses
+		empty = false
+	}
+
+	if empty {
+		return device.FirewallComponent{}, tholaerr.NewNotFoundError("no firewall data available")
+	}
+
+	return firewall, nil
+}
+
+func (o *deviceClassCommunicator) GetFirewallComponentActiveSessions(ctx context.Context) (int, error) {
+	if o.components.firewall == nil || o.components.firewall.activeSessions == nil {
+		log.Ctx(ctx).Debug().Str("property", "FirewallComponentActiveSessions").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "FirewallComponentActiveSessions").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.firewall.activeSessions.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get FirewallComponentActiveSessions")
+	}
+	result, err := res.Int()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert result '%v' to int", res)
+	}
+	return result, nil
+}
+
+func (o *deviceClassCommunicator) GetFirewallComponentVPNTunnels(ctx context.Context) ([]device.FirewallVPNTunnel, error) {
+	if o.components.firewall == nil || o.components.firewall.vpnTunnels == nil {
+		log.Ctx(ctx).Debug().Str("property", "FirewallComponentVPNTunnels").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "FirewallComponentVPNTunnels").Logger()
+	ctx = logger.WithContext(ctx)
+
+	res, _, err := o.components.firewall.vpnTunnels.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+
+	var vpnTunnels []device.FirewallVPNTunnel
+	err = mapstructure.WeakDecode(res, &vpnTunnels)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into vpn tunnel struct")
+	}
+	if len(vpnTunnels) == 0 {
+		return nil, tholaerr.NewNotFoundError("no vpn tunnels available")
+	}
+	return vpnTunnels, nil
+}
+
+func (o *deviceClassCommunicator) GetFirewallComponentLicenses(ctx context.Context) ([]device.FirewallLicense, error) {
+	if o.components.firewall == nil || o.components.firewall.licenses == nil {
+		log.Ctx(ctx).Debug().Str("property", "FirewallComponentLicenses").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "FirewallComponentLicenses").Logger()
+	ctx = logger.WithContext(ctx)
+
+	res, _, err := o.components.firewall.licenses.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+
+	var licenses []device.FirewallLicense
+	err = mapstructure.WeakDecode(res, &licenses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into license struct")
+	}
+	if len(licenses) == 0 {
+		return nil, tholaerr.NewNotFoundError("no licenses available")
+	}
+	return licenses, nil
+}
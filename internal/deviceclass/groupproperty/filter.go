@@ -219,9 +219,29 @@ func (g *valueFilter) applySNMP(ctx context.Context, reader snmpReader) (snmpRea
 	if err != nil {
 		return snmpReader{}, err
 	}
+	if len(g.value) == 1 {
+		reader.indexFields = filterIndexFields(reader.indexFields, func(field string) bool {
+			return field != g.value[0]
+		})
+	}
 	return reader, nil
 }
 
+// filterIndexFields returns a copy of the index fields, where the fields that are not kept are empty. The fields are
+// not removed, as every sub-identifier of the index needs a field.
+func filterIndexFields(indexFields []string, keep func(string) bool) []string {
+	if len(indexFields) == 0 {
+		return indexFields
+	}
+	res := make([]string, len(indexFields))
+	for i, field := range indexFields {
+		if keep(field) {
+			res[i] = field
+		}
+	}
+	return res
+}
+
 type exclusiveValueFilter struct {
 	values [][]string
 }
@@ -295,6 +315,15 @@ func (g *exclusiveValueFilter) applySNMP(ctx context.Context, reader snmpReader)
 		}
 	}
 
+	res.indexFields = filterIndexFields(reader.indexFields, func(field string) bool {
+		for _, k := range g.values {
+			if len(k) == 1 && k[0] == field {
+				return true
+			}
+		}
+		return false
+	})
+
 	return res, nil
 }
 
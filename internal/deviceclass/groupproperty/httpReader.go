@@ -0,0 +1,303 @@
+package groupproperty
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	relatedTask "github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"sort"
+	"strconv"
+	"text/template"
+)
+
+// httpReader reads property groups from a JSON endpoint of the device. Every element of the items is one group, the
+// values of a group are read from the element with json paths.
+type httpReader struct {
+	// path is executed with the device properties of the context, e.g. "/api/{{ .Properties.Model }}/interfaces"
+	path *template.Template
+	// items is the json path to the array or object whose elements are the groups, empty for the root of the response
+	items string
+	// index is the json path to the index inside an element. If it is empty, the index is the position of the element
+	// starting with 1, the elements of an object are ordered by their keys.
+	index   string
+	values  httpValues
+	filters []Filter
+}
+
+// httpValues maps labels to either a single json path or nested values.
+type httpValues map[string]httpValue
+
+type httpValue struct {
+	path      string
+	operators property.Operators
+	values    httpValues
+}
+
+type yamlHTTPReader struct {
+	Path   string
+	Items  string
+	Index  string
+	Values map[interface{}]interface{}
+}
+
+func interface2HTTPReader(m map[interface{}]interface{}) (*httpReader, error) {
+	var y yamlHTTPReader
+	if err := mapstructure.Decode(m, &y); err != nil {
+		return nil, errors.Wrap(err, "failed to decode http group property reader")
+	}
+	if y.Path == "" {
+		return nil, errors.New("path is missing")
+	}
+	path, err := template.New("path").Option("missingkey=error").Parse(y.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "path is no valid template")
+	}
+	if err := network.ValidateJSONPath(y.Items); err != nil {
+		return nil, errors.Wrap(err, "items is invalid")
+	}
+	if err := network.ValidateJSONPath(y.Index); err != nil {
+		return nil, errors.Wrap(err, "index is invalid")
+	}
+	if y.Values == nil {
+		return nil, errors.New("values are missing")
+	}
+	values, err := interface2HTTPValues(y.Values)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse values")
+	}
+
+	return &httpReader{
+		path:   path,
+		items:  y.Items,
+		index:  y.Index,
+		values: values,
+	}, nil
+}
+
+func interface2HTTPValues(values map[interface{}]interface{}) (httpValues, error) {
+	res := make(httpValues)
+	for label, data := range values {
+		labelString, ok := label.(string)
+		if !ok {
+			return nil, errors.New("key of http property reader must be a string")
+		}
+		dataMap, ok := data.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("value data of %s needs to be a map", labelString)
+		}
+
+		if v, ok := dataMap["values"]; ok {
+			if len(dataMap) != 1 {
+				return nil, fmt.Errorf("value %s with subvalues has to many keys", labelString)
+			}
+			subValues, ok := v.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("values of %s needs to be a map", labelString)
+			}
+			sub, err := interface2HTTPValues(subValues)
+			if err != nil {
+				return nil, err
+			}
+			res[labelString] = httpValue{values: sub}
+			continue
+		}
+
+		var y struct {
+			Path      string
+			Operators []interface{}
+		}
+		if err := mapstructure.Decode(dataMap, &y); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode value %s", labelString)
+		}
+		if y.Path == "" {
+			return nil, fmt.Errorf("path of value %s is missing", labelString)
+		}
+		if err := network.ValidateJSONPath(y.Path); err != nil {
+			return nil, errors.Wrapf(err, "path of value %s is invalid", labelString)
+		}
+		val := httpValue{path: y.Path}
+		if y.Operators != nil {
+			operators, err := property.InterfaceSlice2Operators(y.Operators, relatedTask.PropertyDefault)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read operators of value %s", labelString)
+			}
+			val.operators = operators
+		}
+		res[labelString] = val
+	}
+	return res, nil
+}
+
+// merge returns the values with the values of overwrite, nested values are merged recursively.
+func (h httpValues) merge(overwrite httpValues) httpValues {
+	res := make(httpValues)
+	for label, v := range h {
+		res[label] = v
+	}
+	for label, v := range overwrite {
+		if orig, ok := h[label]; ok && orig.values != nil && v.values != nil {
+			v.values = orig.values.merge(v.values)
+		}
+		res[label] = v
+	}
+	return res
+}
+
+func (h httpReader) getProperty(ctx context.Context) (PropertyGroups, []value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.HTTP == nil {
+		return nil, nil, errors.New("no http connection available")
+	}
+
+	path, err := h.executePath(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := log.Ctx(ctx).With().Str("path", path).Logger()
+	ctx = logger.WithContext(ctx)
+
+	body, err := con.HTTP.GetJSON(ctx, path)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get json")
+	}
+	items, err := network.JSONPathValue(body, h.items)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			return nil, nil, tholaerr.NewComponentNotFoundError(err.Error())
+		}
+		return nil, nil, errors.Wrap(err, "failed to get items")
+	}
+
+	var elements []interface{}
+	var indices []string
+	switch i := items.(type) {
+	case []interface{}:
+		for k, element := range i {
+			elements = append(elements, element)
+			indices = append(indices, strconv.Itoa(k+1))
+		}
+	case map[string]interface{}:
+		var keys []string
+		for key := range i {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for k, key := range keys {
+			elements = append(elements, i[key])
+			indices = append(indices, strconv.Itoa(k+1))
+		}
+	default:
+		return nil, nil, fmt.Errorf("items need to be an array or an object, got %T", items)
+	}
+
+	var res PropertyGroups
+	var resIndices []value.Value
+	for k, element := range elements {
+		idx := indices[k]
+		if h.index != "" {
+			v, err := network.JSONPathValue(element, h.index)
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Msgf("skipping element %s, it has no index", idx)
+				continue
+			}
+			idx = value.New(v).String()
+		}
+
+		group, err := h.values.read(ctx, element)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read values of index '%s'", idx)
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		groups := PropertyGroups{group}
+		for _, filter := range h.filters {
+			groups, err = filter.ApplyPropertyGroups(ctx, groups)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to apply filter")
+			}
+		}
+		if len(groups) == 0 {
+			continue
+		}
+		res = append(res, groups[0])
+		resIndices = append(resIndices, value.New(idx))
+	}
+	return res, resIndices, nil
+}
+
+// applyFilter adds the filter to the reader, the filters are applied to every group after it was read.
+func (h httpReader) applyFilter(_ context.Context, filter Filter) (reader, error) {
+	h.filters = append(append([]Filter{}, h.filters...), filter)
+	return h, nil
+}
+
+func (h httpReader) executePath(ctx context.Context) (string, error) {
+	properties, _ := device.DevicePropertiesFromContext(ctx)
+	var buf bytes.Buffer
+	if err := h.path.Execute(&buf, properties); err != nil {
+		return "", errors.Wrap(err, "failed to execute path template")
+	}
+	return buf.String(), nil
+}
+
+// read reads the values of the group from the element. Values which are not part of the element are skipped, values
+// whose operators reference other values of the group are read after all other values.
+func (h httpValues) read(ctx context.Context, element interface{}) (propertyGroup, error) {
+	group := make(propertyGroup)
+
+	var labels, groupDependentLabels []string
+	for label, v := range h {
+		if v.values == nil && v.operators.UsesGroupValues() {
+			groupDependentLabels = append(groupDependentLabels, label)
+		} else {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	sort.Strings(groupDependentLabels)
+
+	for _, label := range append(labels, groupDependentLabels...) {
+		v := h[label]
+		if v.values != nil {
+			sub, err := v.values.read(ctx, element)
+			if err != nil {
+				return nil, err
+			}
+			if len(sub) > 0 {
+				group[label] = sub
+			}
+			continue
+		}
+
+		raw, err := network.JSONPathValue(element, v.path)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get value '%s'", label)
+		}
+		if raw == nil {
+			continue
+		}
+		res, err := v.operators.Apply(property.NewContextWithGroupValues(ctx, map[string]interface{}(group)), value.New(raw))
+		if err != nil {
+			if tholaerr.IsDidNotMatchError(err) || tholaerr.IsNotFoundError(err) {
+				log.Ctx(ctx).Debug().Err(err).Msgf("skipping value '%s', because it couldn't be normalized", label)
+				continue
+			}
+			return nil, errors.Wrapf(err, "value '%s' couldn't be normalized (value: %v)", label, raw)
+		}
+		group[label] = res
+	}
+	return group, nil
+}
@@ -0,0 +1,187 @@
+package groupproperty
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/value"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// newTestHTTPContext returns a context with an http connection to a server which answers requests for the paths of
+// the responses.
+func newTestHTTPContext(t *testing.T, responses map[string]string) context.Context {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := network.NewHTTPClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		HTTP: &network.RequestDeviceConnectionHTTP{
+			HTTPClient:     client,
+			ConnectionData: &network.HTTPConnectionData{HTTPPorts: []int{port}},
+		},
+	})
+}
+
+func TestHTTPReader_GetProperty(t *testing.T) {
+	ctx := newTestHTTPContext(t, map[string]string{
+		"/api/FGT60F/interface": `{"results": {
+			"wan1": {"name": "wan1", "link": true, "rx_bytes": 18446744073709551615, "stats": {"errors": 2}},
+			"port1": {"name": "port1", "link": false, "rx_bytes": 100, "stats": {"errors": 0}},
+			"port2": {"link": false}
+		}}`,
+	})
+	model := "FGT60F"
+	ctx = device.NewContextWithDeviceProperties(ctx, device.Device{Properties: device.Properties{Model: &model}})
+
+	var properties interface{}
+	err := yaml.Unmarshal([]byte(`
+detection: http
+path: "/api/{{ .Properties.Model }}/interface"
+items: results
+values:
+  ifDescr:
+    path: name
+  ifOperStatus:
+    path: link
+    operators:
+      - type: modify
+        modify_method: map
+        mappings:
+          "true": "up"
+          "false": "down"
+  ifHCInOctets:
+    path: rx_bytes
+  stats:
+    values:
+      errors:
+        path: stats.errors
+`), &properties)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := Interface2Reader(properties, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	groups, indices, err := reader.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, PropertyGroups{
+			{"ifDescr": value.New("port1"), "ifOperStatus": value.New("down"), "ifHCInOctets": value.New("100"), "stats": propertyGroup{"errors": value.New("0")}},
+			{"ifOperStatus": value.New("down")},
+			{"ifDescr": value.New("wan1"), "ifOperStatus": value.New("up"), "ifHCInOctets": value.New("18446744073709551615"), "stats": propertyGroup{"errors": value.New("2")}},
+		}, groups)
+		assert.Equal(t, []value.Value{value.New("1"), value.New("2"), value.New("3")}, indices)
+	}
+
+	// groups without the filter key are kept
+	groups, indices, err = reader.GetProperty(ctx, GetGroupFilter([]string{"ifDescr"}, "^port"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, PropertyGroups{
+			{"ifOperStatus": value.New("down")},
+			{"ifDescr": value.New("wan1"), "ifOperStatus": value.New("up"), "ifHCInOctets": value.New("18446744073709551615"), "stats": propertyGroup{"errors": value.New("2")}},
+		}, groups)
+		assert.Equal(t, []value.Value{value.New("2"), value.New("3")}, indices)
+	}
+
+	// the index is read from the elements if an index path is given
+	var indexProperties interface{}
+	err = yaml.Unmarshal([]byte(`
+detection: http
+path: "/api/FGT60F/interface"
+items: results
+index: name
+values:
+  ifDescr:
+    path: name
+`), &indexProperties)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err = Interface2Reader(indexProperties, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	groups, indices, err = reader.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, PropertyGroups{{"ifDescr": value.New("port1")}, {"ifDescr": value.New("wan1")}}, groups)
+		assert.Equal(t, []value.Value{value.New("port1"), value.New("wan1")}, indices)
+	}
+}
+
+func TestInterface2Reader_http(t *testing.T) {
+	var parent, child interface{}
+	err := yaml.Unmarshal([]byte(`
+detection: http
+path: /api/interface
+values:
+  ifDescr:
+    path: name
+  ifAlias:
+    path: alias
+`), &parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = yaml.Unmarshal([]byte(`
+detection: http
+path: /api/v2/interface
+values:
+  ifAlias:
+    path: description
+`), &child)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parentReader, err := Interface2Reader(parent, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	reader, err := Interface2Reader(child, parentReader)
+	if assert.NoError(t, err) {
+		values := reader.(*baseReader).reader.(*httpReader).values
+		assert.Equal(t, "name", values["ifDescr"].path)
+		assert.Equal(t, "description", values["ifAlias"].path)
+	}
+
+	for _, invalid := range []string{
+		"detection: http\nvalues:\n  ifDescr:\n    path: name\n",
+		"detection: http\npath: /api\n",
+		"detection: http\npath: /api/{{ .Properties\nvalues:\n  ifDescr:\n    path: name\n",
+		"detection: http\npath: /api\nvalues:\n  ifDescr:\n    path: name..first\n",
+		"detection: http\npath: /api\nvalues:\n  ifDescr:\n    oid: 1.3.6.1\n",
+	} {
+		var properties interface{}
+		if err := yaml.Unmarshal([]byte(invalid), &properties); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Interface2Reader(properties, nil)
+		assert.Error(t, err, invalid)
+	}
+}
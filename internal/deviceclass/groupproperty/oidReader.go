@@ -11,6 +11,10 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 )
 
 //go:generate go run github.com/vektra/mockery/v2 --name=OIDReader --inpackage
@@ -35,13 +39,33 @@ func Interface2OIDReader(i interface{}) (OIDReader, error) {
 		}
 
 		if v, ok := dataMap["values"]; ok {
-			if len(dataMap) != 1 {
+			mapping, hasMapping := dataMap["indices_mapping"]
+			if len(dataMap) != 1 && !(len(dataMap) == 2 && hasMapping) {
 				return nil, errors.New("value with subvalues has to many keys")
 			}
 			reader, err := Interface2OIDReader(v)
 			if err != nil {
 				return nil, err
 			}
+			if hasMapping {
+				var mappingOID yamlComponentsOID
+				err := mapstructure.Decode(mapping, &mappingOID)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to decode indices mapping of %s", valString)
+				}
+				err = mappingOID.validate()
+				if err != nil {
+					return nil, errors.Wrapf(err, "indices mapping of %s is invalid", valString)
+				}
+				mappingReader, err := mappingOID.convert()
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to convert indices mapping")
+				}
+				reader = &indicesMappedOIDReader{
+					reader:         reader,
+					indicesMapping: &mappingReader,
+				}
+			}
 			result[valString] = reader
 			continue
 		}
@@ -80,25 +104,61 @@ type OIDReader interface {
 type deviceClassOIDs map[string]OIDReader
 
 func (d *deviceClassOIDs) readOID(ctx context.Context, indices []string, skipEmpty bool) (map[string]interface{}, error) {
+	return d.readOIDWithIndexFields(ctx, indices, skipEmpty, nil)
+}
+
+// readOIDWithIndexFields reads the oids like readOID and splits the index of every group into the given index fields,
+// which are added to the group. They are added before the values whose operators reference other values of the group
+// are read, so that these operators can use them as well.
+func (d *deviceClassOIDs) readOIDWithIndexFields(ctx context.Context, indices []string, skipEmpty bool, indexFields []string) (map[string]interface{}, error) {
 	result := make(map[string]map[string]interface{})
+
+	// values whose operators reference other values of the group are read after all other values
+	var labels, groupDependentLabels []string
 	for label, reader := range *d {
-		res, err := reader.readOID(ctx, indices, skipEmpty)
+		if oid, ok := reader.(*deviceClassOID); ok && oid.operators.UsesGroupValues() {
+			groupDependentLabels = append(groupDependentLabels, label)
+			continue
+		}
+		labels = append(labels, label)
+	}
+
+	sort.Strings(labels)
+	duplicates := d.duplicateLabels(labels)
+	for _, label := range labels {
+		if _, ok := duplicates[label]; ok {
+			continue
+		}
+		err := d.readLabel(ctx, label, indices, skipEmpty, result)
 		if err != nil {
-			if tholaerr.IsNotFoundError(err) || tholaerr.IsComponentNotFoundError(err) {
-				log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value '%s'", label)
-				continue
-			}
-			return nil, errors.Wrapf(err, "failed to get value '%s'", label)
+			return nil, err
 		}
-		for ifIndex, v := range res {
-			// ifIndex was not known before, so create a new group
-			if _, ok := result[ifIndex]; !ok {
-				result[ifIndex] = make(map[string]interface{})
+	}
+	for label, original := range duplicates {
+		for _, group := range result {
+			if v, ok := group[original]; ok {
+				group[label] = v
 			}
-			result[ifIndex][label] = v
 		}
 	}
 
+	if err := addIndexFields(result, indexFields); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(groupDependentLabels)
+	for _, label := range groupDependentLabels {
+		err := d.readLabel(newContextWithGroups(ctx, result), label, indices, skipEmpty, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// groups that were only created by values which reference other values
+	if err := addIndexFields(result, indexFields); err != nil {
+		return nil, err
+	}
+
 	r := make(map[string]interface{})
 	for k, v := range result {
 		r[k] = v
@@ -107,6 +167,85 @@ func (d *deviceClassOIDs) readOID(ctx context.Context, indices []string, skipEmp
 	return r, nil
 }
 
+// readLabel reads the values of the given label and adds them to the groups in result.
+func (d *deviceClassOIDs) readLabel(ctx context.Context, label string, indices []string, skipEmpty bool, result map[string]map[string]interface{}) error {
+	res, err := (*d)[label].readOID(ctx, indices, skipEmpty)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) || tholaerr.IsComponentNotFoundError(err) {
+			log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value '%s'", label)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get value '%s'", label)
+	}
+	for ifIndex, v := range res {
+		// ifIndex was not known before, so create a new group
+		if _, ok := result[ifIndex]; !ok {
+			result[ifIndex] = make(map[string]interface{})
+		}
+		result[ifIndex][label] = v
+	}
+	return nil
+}
+
+// duplicateLabels returns the labels whose oid is read exactly the same way as the oid of a previous label, mapped to
+// that label. Their values are the same, so the oid only needs to be read once.
+func (d *deviceClassOIDs) duplicateLabels(labels []string) map[string]string {
+	duplicates := make(map[string]string)
+	var originals []string
+	for _, label := range labels {
+		oid, ok := (*d)[label].(*deviceClassOID)
+		if !ok {
+			continue
+		}
+		duplicate := false
+		for _, original := range originals {
+			if reflect.DeepEqual(oid, (*d)[original]) {
+				duplicates[label] = original
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			originals = append(originals, label)
+		}
+	}
+	return duplicates
+}
+
+// addIndexFields splits the index of every group into its sub-identifiers and adds them as the given index fields.
+// Empty index fields are skipped.
+func addIndexFields(groups map[string]map[string]interface{}, indexFields []string) error {
+	if len(indexFields) == 0 {
+		return nil
+	}
+	for index, group := range groups {
+		parts := strings.Split(index, ".")
+		if len(parts) != len(indexFields) {
+			return fmt.Errorf("index '%s' has %d sub-identifiers, but %d index fields are declared (%s)", index, len(parts), len(indexFields), strings.Join(indexFields, ", "))
+		}
+		for i, field := range indexFields {
+			if field != "" {
+				group[field] = value.New(parts[i])
+			}
+		}
+	}
+	return nil
+}
+
+type ctxKey byte
+
+const groupsKey ctxKey = iota + 1
+
+// newContextWithGroups returns a new context with the already read groups, mapped by their index.
+func newContextWithGroups(ctx context.Context, groups map[string]map[string]interface{}) context.Context {
+	return context.WithValue(ctx, groupsKey, groups)
+}
+
+func groupsFromContext(ctx context.Context) (map[string]map[string]interface{}, bool) {
+	groups, ok := ctx.Value(groupsKey).(map[string]map[string]interface{})
+	return groups, ok
+}
+
 func (d *deviceClassOIDs) merge(overwrite deviceClassOIDs) deviceClassOIDs {
 	devClassOIDsNew := make(deviceClassOIDs)
 	for k, v := range *d {
@@ -121,6 +260,21 @@ func (d *deviceClassOIDs) merge(overwrite deviceClassOIDs) deviceClassOIDs {
 				devClassOIDsNew[k] = &mergedOIDs
 				continue
 			}
+
+			// timeout and retries overrides of the parent oid are kept, if the oid doesn't set its own
+			oidOld, oldIsOID := reader.(*deviceClassOID)
+			oidOverwrite, overwriteIsOID := v.(*deviceClassOID)
+			if oldIsOID && overwriteIsOID && (oidOld.Timeout != nil || oidOld.Retries != nil) {
+				mergedOID := *oidOverwrite
+				if mergedOID.Timeout == nil {
+					mergedOID.Timeout = oidOld.Timeout
+				}
+				if mergedOID.Retries == nil {
+					mergedOID.Retries = oidOld.Retries
+				}
+				devClassOIDsNew[k] = &mergedOID
+				continue
+			}
 		}
 		devClassOIDsNew[k] = v
 	}
@@ -133,13 +287,15 @@ type deviceClassOID struct {
 	network.SNMPGetConfiguration
 	operators      property.Operators
 	indicesMapping OIDReader
+	// maxRepetitions of the snmp bulk walk of the oid, 0 means the max repetitions of the connection are used
+	maxRepetitions uint32
 }
 
 func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpty bool) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
 	logger := log.Ctx(ctx).With().Str("oid", d.OID.String()).Logger()
-	ctx = logger.WithContext(ctx)
+	ctx = d.SNMPGetConfiguration.NewContext(logger.WithContext(ctx))
 
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
@@ -154,32 +310,10 @@ func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpt
 
 		//change requested indices if necessary
 		if d.indicesMapping != nil {
-			mappingIndices, err := d.indicesMapping.readOID(ctx, nil, true)
+			indices, err = mapRequestedIndices(ctx, d.indicesMapping, indices)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to read indices")
-			}
-
-			indexRelIndex := make(map[string]string)
-			for relIndex, index := range mappingIndices {
-				indexValue, ok := index.(value.Value)
-				if !ok {
-					return nil, errors.New("index mapping oid didn't return a result of type 'value'")
-				}
-				indexString := indexValue.String()
-				if idx, ok := indexRelIndex[indexString]; ok {
-					return nil, fmt.Errorf("index mapping resulted in duplicate index mapping on '%s'", idx)
-				}
-				indexRelIndex[indexString] = relIndex
-			}
-
-			var newIndices []string
-			for _, index := range indices {
-				if relIndex, ok := indexRelIndex[index]; ok {
-					newIndices = append(newIndices, relIndex)
-				}
+				return nil, err
 			}
-
-			indices = newIndices
 		}
 
 		var oids []network.OID
@@ -188,7 +322,12 @@ func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpt
 		}
 		snmpResponse, err = con.SNMP.SnmpClient.SNMPGet(ctx, oids...)
 	} else {
-		snmpResponse, err = con.SNMP.SnmpClient.SNMPWalk(ctx, d.OID)
+		snmpResponse, err = d.walk(ctx, con)
+		// clients which can't stop a walk early, e.g. for recorded snmp data, return all rows
+		if maxRows := con.GetSNMPMaxWalkRows(); err == nil && maxRows > 0 && len(snmpResponse) > maxRows {
+			log.Ctx(ctx).Warn().Int("max_walk_rows", maxRows).Int("rows", len(snmpResponse)).Msg("snmp walk returned more rows than allowed, ignoring the remaining rows")
+			snmpResponse = snmpResponse[:maxRows]
+		}
 	}
 	if err != nil {
 		if tholaerr.IsNotFoundError(err) {
@@ -198,6 +337,15 @@ func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpt
 		return nil, errors.Wrap(err, "failed to get oid value")
 	}
 
+	groups, hasGroups := groupsFromContext(ctx)
+	var groupIndices map[string]string
+	if hasGroups && d.indicesMapping != nil {
+		groupIndices, err = readIndicesMapping(ctx, d.indicesMapping)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read mapping indices")
+		}
+	}
+
 	for _, response := range snmpResponse {
 		logger := log.Ctx(ctx).With().Str("oid", response.GetOID().String()).Logger()
 		ctx = logger.WithContext(ctx)
@@ -208,49 +356,154 @@ func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpt
 			continue
 		}
 		if !res.IsEmpty() || !skipEmpty {
-			resNormalized, err := d.operators.Apply(ctx, res)
+			idx, err := response.GetOID().GetIndexAfterOID(d.OID)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get index after oid")
+			}
+
+			operatorCtx := ctx
+			if hasGroups {
+				groupIdx := idx
+				if mappedIdx, ok := groupIndices[idx]; ok {
+					groupIdx = mappedIdx
+				}
+				operatorCtx = property.NewContextWithGroupValues(ctx, groups[groupIdx])
+			}
+
+			resNormalized, err := d.operators.Apply(operatorCtx, res)
 			if err != nil {
 				if tholaerr.IsDidNotMatchError(err) {
 					continue
 				}
+				if tholaerr.IsValueUnavailableError(err) {
+					log.Ctx(ctx).Debug().Err(err).Msgf("skipping response, because its value is unavailable (response: %s)", res)
+					continue
+				}
 				log.Ctx(ctx).Debug().Err(err).Msgf("response couldn't be normalized (response: %s)", res)
 				return nil, errors.Wrapf(err, "response couldn't be normalized (response: %s)", res)
 			}
-			idx, err := response.GetOID().GetIndexAfterOID(d.OID)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to get index after oid")
-			}
 			result[idx] = resNormalized
 		}
 	}
 
 	//change indices if necessary
 	if d.indicesMapping != nil {
-		mappingIndices, err := d.indicesMapping.readOID(ctx, nil, true)
+		result, err = mapResultIndices(ctx, d.indicesMapping, result)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to read mapping indices")
+			return nil, err
 		}
-		mappedResult := make(map[string]interface{})
+	}
+	return result, nil
+}
 
-		for k, v := range result {
-			mappedIdx, ok := mappingIndices[k]
-			if !ok {
-				continue
-			}
-			idx, ok := mappedIdx.(value.Value)
-			if !ok {
-				return nil, errors.New("index mapping oid didn't return a result of type 'value'")
-			}
+// ReadOID walks the oid with the snmp connection of the context and returns the raw values mapped by their index.
+// The indices are parsed the same way as for oids of device classes. If the oid is not available on the device, a
+// NotFoundError is returned, so that it can be told apart from transport failures.
+func ReadOID(ctx context.Context, oid network.OID) (map[string]value.Value, error) {
+	if err := oid.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid oid")
+	}
 
-			if _, ok := mappedResult[idx.String()]; ok {
-				return nil, fmt.Errorf("index mapping resulted in duplicate index '%d'", idx)
-			}
+	d := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: oid,
+		},
+	}
+	res, err := d.readOID(ctx, nil, false)
+	if err != nil {
+		return nil, err
+	}
 
-			mappedResult[idx.String()] = v
+	values := make(map[string]value.Value, len(res))
+	for idx, v := range res {
+		values[idx] = v.(value.Value)
+	}
+	return values, nil
+}
+
+// walk walks the oid with the max repetitions of the oid, if they are set. Max repetitions that are set for the
+// connection take precedence, so that they can still be tuned per device.
+func (d *deviceClassOID) walk(ctx context.Context, con *network.RequestDeviceConnection) ([]network.SNMPResponse, error) {
+	connectionSetsMaxRepetitions := con.RawConnectionData.SNMP != nil && con.RawConnectionData.SNMP.MaxRepetitions != nil && *con.RawConnectionData.SNMP.MaxRepetitions != 0
+	if d.maxRepetitions != 0 && !connectionSetsMaxRepetitions {
+		maxRepetitions := con.SNMP.SnmpClient.GetMaxRepetitions()
+		log.Ctx(ctx).Debug().Uint32("max_repetitions", d.maxRepetitions).Msg("set snmp max repetitions of oid")
+		con.SNMP.SnmpClient.SetMaxRepetitions(d.maxRepetitions)
+		defer con.SNMP.SnmpClient.SetMaxRepetitions(maxRepetitions)
+	}
+	return snmpWalkWithRetry(ctx, con, d.OID)
+}
+
+const (
+	// maxSNMPWalkRetries is the maximum amount of retries of a failed snmp walk, regardless of the configuration.
+	maxSNMPWalkRetries = 10
+	// maxSNMPWalkRetryBackoff is the maximum delay before a retry of a failed snmp walk.
+	maxSNMPWalkRetryBackoff = 10 * time.Second
+)
+
+// snmpWalkWithRetry retries failed snmp walks with exponential backoff according to the retry policy of the connection.
+// Errors that a retry can't fix are not retried, e.g. NotFound errors, which indicate that the oid is not available on
+// the device.
+func snmpWalkWithRetry(ctx context.Context, con *network.RequestDeviceConnection, oid network.OID) ([]network.SNMPResponse, error) {
+	mode := con.GetSNMPWalkMode()
+	var retries, delay int
+	if con.RawConnectionData.SNMP != nil {
+		if con.RawConnectionData.SNMP.WalkRetries != nil {
+			retries = *con.RawConnectionData.SNMP.WalkRetries
+		}
+		if con.RawConnectionData.SNMP.WalkRetryDelay != nil {
+			delay = *con.RawConnectionData.SNMP.WalkRetryDelay
 		}
-		result = mappedResult
 	}
-	return result, nil
+	if retries > maxSNMPWalkRetries {
+		retries = maxSNMPWalkRetries
+	}
+
+	res, err := network.SNMPWalkCachedWithMode(ctx, con.SNMP.SnmpClient, oid, mode)
+	for attempt := 1; attempt <= retries && err != nil && isRetryableSNMPWalkError(ctx, err); attempt++ {
+		backoff := snmpWalkRetryBackoff(delay, attempt)
+		log.Ctx(ctx).Debug().Err(err).Int("attempt", attempt).Dur("backoff", backoff).Msg("snmp walk failed, retrying")
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Wrap(ctx.Err(), "context done while waiting to retry snmp walk")
+		case <-timer.C:
+		}
+
+		// the failed walk is cached by the snmp client, so the cached error has to be ignored for the retry
+		res, err = network.SNMPWalkCachedWithMode(network.NewContextWithSNMPWalkErrorCacheBypass(ctx), con.SNMP.SnmpClient, oid, mode)
+	}
+	return res, err
+}
+
+// isRetryableSNMPWalkError returns whether a retry of a walk which failed with the given error can succeed.
+func isRetryableSNMPWalkError(ctx context.Context, err error) bool {
+	return ctx.Err() == nil &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded) &&
+		!tholaerr.IsNotFoundError(err) &&
+		!tholaerr.IsAuthError(err)
+}
+
+// snmpWalkRetryBackoff returns the delay before the given retry. The delay in milliseconds is doubled after every
+// retry, up to maxSNMPWalkRetryBackoff.
+func snmpWalkRetryBackoff(delay, attempt int) time.Duration {
+	if delay <= 0 {
+		return 0
+	}
+	if delay >= int(maxSNMPWalkRetryBackoff/time.Millisecond) {
+		return maxSNMPWalkRetryBackoff
+	}
+	backoff := time.Duration(delay) * time.Millisecond
+	for i := 1; i < attempt && backoff < maxSNMPWalkRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxSNMPWalkRetryBackoff {
+		return maxSNMPWalkRetryBackoff
+	}
+	return backoff
 }
 
 type emptyOIDReader struct{}
@@ -259,18 +512,110 @@ func (n *emptyOIDReader) readOID(context.Context, []string, bool) (map[string]in
 	return nil, tholaerr.NewComponentNotFoundError("oid is ignored")
 }
 
+// indicesMappedOIDReader joins the values of a reader whose table is indexed differently than the group
+// by translating its indices via an intermediate OID.
+type indicesMappedOIDReader struct {
+	reader         OIDReader
+	indicesMapping OIDReader
+}
+
+func (i *indicesMappedOIDReader) readOID(ctx context.Context, indices []string, skipEmpty bool) (map[string]interface{}, error) {
+	if len(indices) > 0 {
+		var err error
+		indices, err = mapRequestedIndices(ctx, i.indicesMapping, indices)
+		if err != nil {
+			return nil, err
+		}
+		if len(indices) == 0 {
+			return nil, tholaerr.NewNotFoundError("none of the requested indices is contained in the index mapping")
+		}
+	}
+
+	result, err := i.reader.readOID(ctx, indices, skipEmpty)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapResultIndices(ctx, i.indicesMapping, result)
+}
+
+// readIndicesMapping reads the given indices mapping and returns the group index for each index of the mapped table.
+func readIndicesMapping(ctx context.Context, indicesMapping OIDReader) (map[string]string, error) {
+	mappingIndices, err := indicesMapping.readOID(ctx, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string)
+	for relIndex, index := range mappingIndices {
+		indexValue, ok := index.(value.Value)
+		if !ok {
+			return nil, errors.New("index mapping oid didn't return a result of type 'value'")
+		}
+		res[relIndex] = indexValue.String()
+	}
+	return res, nil
+}
+
+// mapRequestedIndices translates the requested group indices to the indices of the mapped table.
+func mapRequestedIndices(ctx context.Context, indicesMapping OIDReader, indices []string) ([]string, error) {
+	mappingIndices, err := readIndicesMapping(ctx, indicesMapping)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read indices")
+	}
+
+	indexRelIndex := make(map[string]string)
+	for relIndex, index := range mappingIndices {
+		if idx, ok := indexRelIndex[index]; ok {
+			return nil, fmt.Errorf("index mapping resulted in duplicate index mapping on '%s'", idx)
+		}
+		indexRelIndex[index] = relIndex
+	}
+
+	var newIndices []string
+	for _, index := range indices {
+		if relIndex, ok := indexRelIndex[index]; ok {
+			newIndices = append(newIndices, relIndex)
+		}
+	}
+	return newIndices, nil
+}
+
+// mapResultIndices translates the indices of the mapped table in result to the group indices.
+// Values whose index is not contained in the mapping are dropped.
+func mapResultIndices(ctx context.Context, indicesMapping OIDReader, result map[string]interface{}) (map[string]interface{}, error) {
+	mappingIndices, err := readIndicesMapping(ctx, indicesMapping)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read mapping indices")
+	}
+
+	mappedResult := make(map[string]interface{})
+	for k, v := range result {
+		idx, ok := mappingIndices[k]
+		if !ok {
+			continue
+		}
+
+		if _, ok := mappedResult[idx]; ok {
+			return nil, fmt.Errorf("index mapping resulted in duplicate index '%s'", idx)
+		}
+
+		mappedResult[idx] = v
+	}
+	return mappedResult, nil
+}
+
 type yamlComponentsOID struct {
 	network.SNMPGetConfiguration `mapstructure:",squash"`
 	Operators                    []interface{}
 	IndicesMapping               *yamlComponentsOID `mapstructure:"indices_mapping"`
+	MaxRepetitions               uint32             `mapstructure:"max_repetitions"`
 }
 
 func (y *yamlComponentsOID) convert() (deviceClassOID, error) {
 	res := deviceClassOID{
-		SNMPGetConfiguration: network.SNMPGetConfiguration{
-			OID:          y.OID,
-			UseRawResult: y.UseRawResult,
-		},
+		SNMPGetConfiguration: y.SNMPGetConfiguration,
+		maxRepetitions:       y.MaxRepetitions,
 	}
 
 	if y.IndicesMapping != nil {
@@ -296,5 +641,8 @@ func (y *yamlComponentsOID) validate() error {
 	if err := y.OID.Validate(); err != nil {
 		return errors.Wrap(err, "oid is invalid")
 	}
-	return nil
+	if y.UseRawResult && y.UseHexString {
+		return errors.New("use_raw_result and use_hex_string can't be used together")
+	}
+	return y.ValidateOperationSettings()
 }
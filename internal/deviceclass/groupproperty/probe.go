@@ -0,0 +1,110 @@
+package groupproperty
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"sort"
+	"sync"
+)
+
+// maxParallelProbes is the maximum amount of oids that are probed at the same time.
+const maxParallelProbes = 4
+
+// OIDProbe is the result of probing an oid on a device.
+type OIDProbe struct {
+	OID network.OID
+	// Exists is true if the device returned values for the oid.
+	Exists bool
+	// Count is the amount of values that were returned for the oid.
+	Count int
+	// SampleIndex is the index of the sample value.
+	SampleIndex string
+	// Sample is the value of the lowest index.
+	Sample value.Value
+	// Err is set if the oid couldn't be probed, e.g. because the device didn't answer. Oids that don't exist on the
+	// device are no error.
+	Err error
+}
+
+// ProbeOIDs walks every oid with the snmp connection of the context and reports which of them exist on the device,
+// together with a sample value. This helps to find out which oids can be used in a device class for a device.
+// The oids are probed in parallel, each worker uses its own session to the device.
+func ProbeOIDs(ctx context.Context, oids []string) ([]OIDProbe, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil || con.SNMP.SnmpClient == nil {
+		return nil, errors.New("no snmp connection available")
+	}
+
+	res := make([]OIDProbe, len(oids))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < maxParallelProbes && i < len(oids); i++ {
+		workerCTX, disconnect, err := network.NewContextWithSNMPSession(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Int("workers", i).Msg("failed to open another snmp session")
+			if i > 0 {
+				break
+			}
+			workerCTX, disconnect = ctx, nil
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if disconnect != nil {
+				defer disconnect()
+			}
+			for j := range jobs {
+				res[j] = probeOID(workerCTX, network.OID(oids[j]))
+			}
+		}()
+
+		if disconnect == nil {
+			// the connection of the context can't be shared with further workers
+			break
+		}
+	}
+
+	for i := range oids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return res, nil
+}
+
+func probeOID(ctx context.Context, oid network.OID) OIDProbe {
+	probe := OIDProbe{
+		OID: oid,
+	}
+
+	values, err := ReadOID(ctx, oid)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) {
+			probe.Err = err
+		}
+		return probe
+	}
+
+	probe.Exists = len(values) > 0
+	probe.Count = len(values)
+	indices := make([]string, 0, len(values))
+	for idx := range values {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		cmp, err := network.OID(indices[i]).Cmp(network.OID(indices[j]))
+		return err == nil && cmp < 0
+	})
+	if len(indices) > 0 {
+		probe.SampleIndex = indices[0]
+		probe.Sample = values[indices[0]]
+	}
+	return probe
+}
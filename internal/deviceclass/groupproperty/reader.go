@@ -8,6 +8,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
+	"sort"
 )
 
 func Interface2Reader(i interface{}, parentReader Reader) (Reader, error) {
@@ -55,6 +56,22 @@ func Interface2Reader(i interface{}, parentReader Reader) (Reader, error) {
 			return nil, errors.New("oid reader is no list of oids")
 		}
 
+		var indexFields []string
+		if fields, ok := m["index_fields"]; ok {
+			indexFields, err = interface2IndexFields(fields, *devClassOIDs)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid index_fields")
+			}
+		}
+
+		var rowFil *rowFilter
+		if r, ok := m["row_filter"]; ok {
+			rowFil, err = interface2RowFilter(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		inheritValuesFromParent := true
 		if b, ok := m["inherit_values"]; ok {
 			bb, ok := b.(bool)
@@ -87,19 +104,86 @@ func Interface2Reader(i interface{}, parentReader Reader) (Reader, error) {
 			if index == nil {
 				index = parentSNMPReader.index
 			}
+			if indexFields == nil {
+				indexFields = parentSNMPReader.indexFields
+			}
+			if rowFil == nil {
+				rowFil = parentSNMPReader.rowFilter
+			}
 		}
 
 		return &baseReader{
 			reader: &snmpReader{
-				index: index,
-				oids:  devClassOIDs,
+				index:       index,
+				indexFields: indexFields,
+				rowFilter:   rowFil,
+				oids:        devClassOIDs,
 			},
 		}, nil
+	case "http":
+		reader, err := interface2HTTPReader(m)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse http reader")
+		}
+
+		inheritValuesFromParent := true
+		if b, ok := m["inherit_values"]; ok {
+			bb, ok := b.(bool)
+			if !ok {
+				return nil, errors.New("inherit_values needs to be a boolean")
+			}
+			inheritValuesFromParent = bb
+		}
+
+		//overwrite parent
+		if inheritValuesFromParent && parentReader != nil {
+			parentBaseReader, ok := parentReader.(*baseReader)
+			if !ok {
+				return nil, errors.New("parent group property reader is not of type base group property reader")
+			}
+
+			parentHTTPReader, ok := parentBaseReader.reader.(*httpReader)
+			if !ok {
+				return nil, errors.New("can't merge HTTP group property reader with property reader of different type")
+			}
+			reader.values = parentHTTPReader.values.merge(reader.values)
+		}
+
+		return &baseReader{
+			reader: reader,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown detection type '%s'", stringDetection)
 	}
 }
 
+// interface2IndexFields converts the index field names of a group property. The names must not be used by a value of
+// the group, as the index fields are added to every group.
+func interface2IndexFields(i interface{}, oids deviceClassOIDs) ([]string, error) {
+	fields, ok := i.([]interface{})
+	if !ok || len(fields) == 0 {
+		return nil, errors.New("index_fields needs to be a non-empty list of field names")
+	}
+
+	res := make([]string, len(fields))
+	for k, field := range fields {
+		name, ok := field.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("index field %d needs to be a non-empty string", k)
+		}
+		for _, other := range res[:k] {
+			if other == name {
+				return nil, fmt.Errorf("index field '%s' is declared twice", name)
+			}
+		}
+		if _, ok := oids[name]; ok {
+			return nil, fmt.Errorf("index field '%s' is already used by a value", name)
+		}
+		res[k] = name
+	}
+	return res, nil
+}
+
 type propertyGroup map[string]interface{}
 
 func (g *propertyGroup) decode(destination interface{}) error {
@@ -166,7 +250,12 @@ type reader interface {
 }
 
 type snmpReader struct {
-	index           OIDReader
+	index OIDReader
+	// indexFields are the names of the sub-identifiers of the index, which are added to every group. Fields that were
+	// filtered out are empty.
+	indexFields []string
+	// rowFilter drops rows before their values are read, the values of the remaining rows are read with snmp gets.
+	rowFilter       *rowFilter
 	wantedIndices   map[string]struct{}
 	filteredIndices map[string]struct{}
 	oids            OIDReader
@@ -180,12 +269,22 @@ func (s snmpReader) getProperty(ctx context.Context) (PropertyGroups, []value.Va
 		log.Ctx(ctx).Debug().Msg("SNMPGetsInsteadOfWalk not found in context, using walks")
 	}
 
-	if useSNMPGetsInsteadOfWalk {
+	rowIndices, useRowFilter, err := s.getRowFilterIndices(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to apply row filter")
+	}
+	if useRowFilter {
+		if len(rowIndices) == 0 {
+			return nil, nil, nil
+		}
+		// the filtered indices are already removed from the row indices
+		useSNMPGetsInsteadOfWalk = true
+		wantedIndices = rowIndices
+	} else if useSNMPGetsInsteadOfWalk {
 		var indices map[string]struct{}
 		if len(s.wantedIndices) > 0 {
 			indices = s.wantedIndices
 		} else {
-			var err error
 			indices, err = s.getIndices(ctx)
 			if err != nil {
 				return nil, nil, errors.Wrap(err, "failed to get indices")
@@ -197,7 +296,16 @@ func (s snmpReader) getProperty(ctx context.Context) (PropertyGroups, []value.Va
 		}
 	}
 
-	groups, err := s.oids.readOID(ctx, wantedIndices, true)
+	var groups map[string]interface{}
+	if len(s.indexFields) > 0 {
+		oids, ok := s.oids.(*deviceClassOIDs)
+		if !ok {
+			return nil, nil, errors.New("index fields can only be added to a list of oids")
+		}
+		groups, err = oids.readOIDWithIndexFields(ctx, wantedIndices, true, s.indexFields)
+	} else {
+		groups, err = s.oids.readOID(ctx, wantedIndices, true)
+	}
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to read oids")
 	}
@@ -247,6 +355,33 @@ func (s snmpReader) applyFilter(ctx context.Context, filter Filter) (reader, err
 	return filter.applySNMP(ctx, s)
 }
 
+// getRowFilterIndices returns the indices of the rows that are neither dropped by the row filter nor by the filters of
+// the reader. False is returned if the reader has no row filter or the filter column isn't available.
+func (s snmpReader) getRowFilterIndices(ctx context.Context) ([]string, bool, error) {
+	if s.rowFilter == nil {
+		return nil, false, nil
+	}
+	indices, ok, err := s.rowFilter.getIndices(ctx)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	var res []string
+	for index := range indices {
+		if _, ok := s.filteredIndices[index]; ok {
+			continue
+		}
+		if len(s.wantedIndices) > 0 {
+			if _, ok := s.wantedIndices[index]; !ok {
+				continue
+			}
+		}
+		res = append(res, index)
+	}
+	sort.Strings(res)
+	return res, true, nil
+}
+
 func (s snmpReader) getIndices(ctx context.Context) (map[string]struct{}, error) {
 	if s.index == nil {
 		return nil, errors.New("indices reader is empty")
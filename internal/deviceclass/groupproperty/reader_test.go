@@ -1,14 +1,24 @@
 package groupproperty
 
 import (
+	"bytes"
 	"context"
 	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/deviceclass/property"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
 	"github.com/inexio/thola/internal/value"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGroupProperty_merge(t *testing.T) {
@@ -83,6 +93,50 @@ func TestDeviceClassOID_readOID(t *testing.T) {
 	}
 }
 
+func TestDeviceClassOID_readOID_walkMode(t *testing.T) {
+	bulk := string(network.SNMPWalkModeBulk)
+	cases := map[string]struct {
+		deviceClassMode network.SNMPWalkMode
+		connectionMode  *string
+		method          string
+	}{
+		"default":                      {method: "SNMPWalk"},
+		"getnext in device class":      {deviceClassMode: network.SNMPWalkModeGetNext, method: "SNMPWalkGetNext"},
+		"connection overrides getnext": {deviceClassMode: network.SNMPWalkModeGetNext, connectionMode: &bulk, method: "SNMPWalk"},
+	}
+
+	for name, c := range cases {
+		var snmpClient network.MockSNMPClient
+		ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+			RawConnectionData: network.ConnectionData{
+				SNMP: &network.SNMPConnectionData{WalkMode: c.connectionMode},
+			},
+			SNMP: &network.RequestDeviceConnectionSNMP{
+				SnmpClient: &snmpClient,
+				WalkMode:   c.deviceClassMode,
+			},
+		})
+
+		snmpClient.
+			On(c.method, ctx, network.OID("1")).
+			Return([]network.SNMPResponse{
+				network.NewSNMPResponse("1.1", gosnmp.OctetString, "Port 1"),
+			}, nil)
+
+		sut := deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{
+				OID: "1",
+			},
+		}
+
+		res, err := sut.readOID(ctx, nil, false)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, map[string]interface{}{"1": value.New("Port 1")}, res, name)
+		}
+		snmpClient.AssertExpectations(t)
+	}
+}
+
 // TestDeviceClassOID_readOID_skipEmpty tests deviceClassOID.readOid(...) without indices and skipEmpty = true
 func TestDeviceClassOID_readOID_skipEmpty(t *testing.T) {
 	var snmpClient network.MockSNMPClient
@@ -293,6 +347,230 @@ func TestDeviceClassOID_readOID_indicesMappingWithIndices(t *testing.T) {
 	}
 }
 
+// TestDeviceClassOID_readOID_retry tests deviceClassOID.readOid(...) with a snmp walk that succeeds on the third attempt
+func TestDeviceClassOID_readOID_retry(t *testing.T) {
+	retries, delay := 2, 1
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				WalkRetries:    &retries,
+				WalkRetryDelay: &delay,
+			},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	// retries bypass the cached walk errors of the client
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return(nil, errors.New("request timeout")).
+		Twice().
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.OctetString, "Port 1"),
+		}, nil).
+		Once()
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: "1",
+		},
+	}
+
+	expected := map[string]interface{}{
+		"1": value.New("Port 1"),
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 3)
+}
+
+// TestDeviceClassOID_readOID_retryNotFound tests that deviceClassOID.readOid(...) does not retry a snmp walk which returned a not found error
+func TestDeviceClassOID_readOID_retryNotFound(t *testing.T) {
+	retries, delay := 2, 1
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				WalkRetries:    &retries,
+				WalkRetryDelay: &delay,
+			},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: "1",
+		},
+	}
+
+	_, err := sut.readOID(ctx, nil, false)
+	assert.True(t, tholaerr.IsNotFoundError(err))
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 1)
+}
+
+// TestDeviceClassOID_readOID_retryContextCanceled tests that deviceClassOID.readOid(...) stops retrying if the context is done
+func TestDeviceClassOID_readOID_retryContextCanceled(t *testing.T) {
+	retries, delay := 5, 10000
+	var snmpClient network.MockSNMPClient
+	ctx, cancel := context.WithCancel(network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				WalkRetries:    &retries,
+				WalkRetryDelay: &delay,
+			},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	}))
+	cancel()
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return(nil, errors.New("request timeout"))
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: "1",
+		},
+	}
+
+	_, err := sut.readOID(ctx, nil, false)
+	assert.Error(t, err)
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 1)
+}
+
+// TestDeviceClassOID_readOID_maxRepetitions tests that deviceClassOID.readOid(...) walks with the max repetitions of the oid
+// and restores the max repetitions of the client afterwards
+func TestDeviceClassOID_readOID_maxRepetitions(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	maxRepetitions := uint32(10)
+	var walkMaxRepetitions uint32
+	snmpClient.
+		On("GetMaxRepetitions").
+		Return(func() uint32 { return maxRepetitions }).
+		On("SetMaxRepetitions", mock.Anything).
+		Run(func(args mock.Arguments) { maxRepetitions = args.Get(0).(uint32) }).
+		On("SNMPWalk", ctx, network.OID("1")).
+		Run(func(mock.Arguments) { walkMaxRepetitions = maxRepetitions }).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.OctetString, "Port 1"),
+		}, nil)
+
+	sut, err := Interface2OIDReader(map[interface{}]interface{}{
+		"ifDescr": map[interface{}]interface{}{
+			"oid":             "1",
+			"max_repetitions": 50,
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{
+			"1": map[string]interface{}{
+				"ifDescr": value.New("Port 1"),
+			},
+		}, res)
+	}
+	assert.Equal(t, uint32(50), walkMaxRepetitions)
+	assert.Equal(t, uint32(10), maxRepetitions)
+}
+
+// TestDeviceClassOID_readOID_maxRepetitionsOfConnection tests that the max repetitions of the connection take precedence
+// over the max repetitions of the oid
+func TestDeviceClassOID_readOID_maxRepetitionsOfConnection(t *testing.T) {
+	connectionMaxRepetitions := uint32(5)
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				MaxRepetitions: &connectionMaxRepetitions,
+			},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.OctetString, "Port 1"),
+		}, nil)
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: "1",
+		},
+		maxRepetitions: 50,
+	}
+
+	_, err := sut.readOID(ctx, nil, false)
+	assert.NoError(t, err)
+	snmpClient.AssertNotCalled(t, "SetMaxRepetitions", mock.Anything)
+}
+
+// TestDeviceClassOID_readOID_maxWalkRows tests that deviceClassOID.readOid(...) only uses the max walk rows of the
+// connection if the device returns an excessive walk
+func TestDeviceClassOID_readOID_maxWalkRows(t *testing.T) {
+	maxWalkRows := 10
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				MaxWalkRows: &maxWalkRows,
+			},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	var responses []network.SNMPResponse
+	for i := 1; i <= 100000; i++ {
+		responses = append(responses, network.NewSNMPResponse(network.OID("1").AddIndex(strconv.Itoa(i)), gosnmp.Integer, i))
+	}
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1")).
+		Return(responses, nil)
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: "1",
+		},
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Len(t, res, maxWalkRows)
+		assert.Equal(t, value.New(10), res["10"])
+		assert.NotContains(t, res, "11")
+	}
+}
+
 // TestDeviceClassOIDs_readOID tests deviceClassOIDs.readOid(...)
 func TestDeviceClassOIDs_readOID(t *testing.T) {
 	var ifIndexOidReader MockOIDReader
@@ -681,3 +959,733 @@ func TestSNMPReader_getProperty_getsInsteadOfWalkFilter(t *testing.T) {
 		assert.Equal(t, expectedIndices, indices)
 	}
 }
+
+// TestDeviceClassOIDs_readOID_calculateWithGroupValue tests deviceClassOIDs.readOID(...) with a calculate operator referencing another value of the group
+func TestDeviceClassOIDs_readOID_calculateWithGroupValue(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.Integer, 100),
+			network.NewSNMPResponse("1.2", gosnmp.Integer, 50),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.1", gosnmp.Integer, 25),
+			network.NewSNMPResponse("2.2", gosnmp.Integer, 0),
+		}, nil)
+
+	usedOperators, err := property.InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "calculate",
+			"operation":     "-",
+			"property":      "free",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+	ratioOperators, err := property.InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "calculate",
+			"operation":     "/",
+			"property":      "free",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sut := deviceClassOIDs{
+		"total": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		},
+		"free": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "2"},
+		},
+		"used": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+			operators:            usedOperators,
+		},
+		"ratio": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+			operators:            ratioOperators,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"1": map[string]interface{}{
+			"total": value.New(100),
+			"free":  value.New(25),
+			"used":  value.New(75),
+			"ratio": value.New(4),
+		},
+		"2": map[string]interface{}{
+			"total": value.New(50),
+			"free":  value.New(0),
+			"used":  value.New(50),
+		},
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+}
+
+// TestDeviceClassOID_readOID_mappingMismatch tests that deviceClassOID.readOID(...) fails if a value is not found in a mapping
+func TestDeviceClassOID_readOID_mappingMismatch(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("1.2", gosnmp.Integer, 3),
+		}, nil)
+
+	operators, err := property.InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "map",
+			"mappings": map[interface{}]interface{}{
+				1: "up",
+				2: "down",
+			},
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		operators:            operators,
+	}
+
+	_, err = sut.readOID(ctx, nil, false)
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+// TestDeviceClassOIDs_readOID_walkCache tests that deviceClassOIDs.readOID(...) walks every oid only once when a walk cache is available
+func TestDeviceClassOIDs_readOID_walkCache(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	ctx = network.NewContextWithSNMPWalkCache(ctx)
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.Integer, 10),
+			network.NewSNMPResponse("1.2", gosnmp.Integer, 20),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("2")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+
+	sut := deviceClassOIDs{
+		"value": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		},
+		"sameValue": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		},
+		"missing": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "2"},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"1": map[string]interface{}{
+			"value":     value.New(10),
+			"sameValue": value.New(10),
+		},
+		"2": map[string]interface{}{
+			"value":     value.New(20),
+			"sameValue": value.New(20),
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err := sut.readOID(ctx, nil, false)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, res)
+		}
+	}
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 2)
+
+	// identical oids are only read once even if the cache is bypassed
+	_, err := sut.readOID(network.NewContextWithSNMPWalkCacheBypass(ctx, true), nil, false)
+	assert.NoError(t, err)
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 4)
+}
+
+// TestDeviceClassOIDs_readOID_duplicateOIDs tests that deviceClassOIDs.readOID(...) reads oids that are read exactly
+// the same way for multiple values only once
+func TestDeviceClassOIDs_readOID_duplicateOIDs(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.OctetString, "Port 1"),
+			network.NewSNMPResponse("1.2", gosnmp.OctetString, "Port 2"),
+		}, nil)
+
+	sut := deviceClassOIDs{
+		"ifDescr": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		},
+		"ifAlias": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		},
+		"ifName": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"},
+		},
+		"ifDescrRaw": &deviceClassOID{
+			SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1", UseRawResult: true},
+		},
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		for _, idx := range []string{"1", "2"} {
+			group := res[idx].(map[string]interface{})
+			assert.Len(t, group, 4)
+			assert.Equal(t, value.New("Port "+idx), group["ifDescr"])
+			assert.Equal(t, group["ifDescr"], group["ifAlias"])
+			assert.Equal(t, group["ifDescr"], group["ifName"])
+		}
+	}
+	// the raw result is read differently, so it needs its own walk
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 2)
+}
+
+// TestDeviceClassOIDs_readOID_tableJoin tests deviceClassOIDs.readOID(...) with values of a second table that is joined via an indices mapping
+func TestDeviceClassOIDs_readOID_tableJoin(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.OctetString, "Port 1"),
+			network.NewSNMPResponse("1.2", gosnmp.OctetString, "Port 2"),
+		}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.101", gosnmp.Integer, 2),
+			network.NewSNMPResponse("2.102", gosnmp.Integer, 1),
+			network.NewSNMPResponse("2.103", gosnmp.Integer, 3),
+		}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("3")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("3.101", gosnmp.Integer, 5800),
+			network.NewSNMPResponse("3.102", gosnmp.Integer, 2400),
+			network.NewSNMPResponse("3.104", gosnmp.Integer, 5000),
+		}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("4.101", gosnmp.Integer, -40),
+			network.NewSNMPResponse("4.102", gosnmp.Integer, -55),
+		}, nil)
+
+	sut, err := Interface2OIDReader(map[interface{}]interface{}{
+		"ifDescr": map[interface{}]interface{}{
+			"oid": "1",
+		},
+		"radio": map[interface{}]interface{}{
+			"indices_mapping": map[interface{}]interface{}{
+				"oid": "2",
+			},
+			"values": map[interface{}]interface{}{
+				"frequency": map[interface{}]interface{}{
+					"oid": "3",
+				},
+				"level": map[interface{}]interface{}{
+					"oid": "4",
+				},
+			},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	expected := map[string]interface{}{
+		"1": map[string]interface{}{
+			"ifDescr": value.New("Port 1"),
+			"radio": map[string]interface{}{
+				"frequency": value.New(2400),
+				"level":     value.New(-55),
+			},
+		},
+		"2": map[string]interface{}{
+			"ifDescr": value.New("Port 2"),
+			"radio": map[string]interface{}{
+				"frequency": value.New(5800),
+				"level":     value.New(-40),
+			},
+		},
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+}
+
+// TestReadOID tests that ReadOID(...) returns the raw values mapped by their index and tells absent oids apart from
+// transport failures
+func TestReadOID(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.1", gosnmp.OctetString, "Port 1"),
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.2", gosnmp.OctetString, ""),
+		}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.3")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")).
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.4")).
+		Return(nil, errors.New("request timeout"))
+
+	res, err := ReadOID(ctx, "1.3.6.1.2.1.2.2.1.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]value.Value{
+			"1": value.New("Port 1"),
+			"2": value.New(""),
+		}, res)
+	}
+
+	_, err = ReadOID(ctx, "1.3.6.1.2.1.2.2.1.3")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+
+	_, err = ReadOID(ctx, "1.3.6.1.2.1.2.2.1.4")
+	if assert.Error(t, err) {
+		assert.False(t, tholaerr.IsNotFoundError(err))
+	}
+
+	_, err = ReadOID(ctx, "ifDescr")
+	assert.Error(t, err)
+}
+
+func TestDeviceClassOID_readOID_operationSettings(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	timeout, retries := 30, 5
+	snmpClient.
+		On("SNMPWalk", mock.MatchedBy(func(ctx context.Context) bool {
+			settings, ok := network.SNMPOperationSettingsFromContext(ctx)
+			return ok && settings.Timeout == &timeout && settings.Retries == nil
+		}), network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.1", gosnmp.OctetString, "Sensor 1"),
+		}, nil).
+		On("SNMPWalk", mock.MatchedBy(func(ctx context.Context) bool {
+			settings, ok := network.SNMPOperationSettingsFromContext(ctx)
+			// the overrides only apply to the single snmp operations, the deadline of the context is kept
+			ctxDeadline, hasDeadline := ctx.Deadline()
+			return ok && settings.Timeout == &timeout && settings.Retries == &retries && hasDeadline && ctxDeadline.Equal(deadline)
+		}), network.OID("2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.1", gosnmp.OctetString, "Sensor 2"),
+		}, nil).
+		On("SNMPWalk", mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := network.SNMPOperationSettingsFromContext(ctx)
+			return !ok
+		}), network.OID("3")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("3.1", gosnmp.OctetString, "Sensor 3"),
+		}, nil)
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID:     "1",
+			Timeout: &timeout,
+		},
+	}
+
+	res, err := sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"1": value.New("Sensor 1")}, res)
+	}
+
+	sut = deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID:     "2",
+			Timeout: &timeout,
+			Retries: &retries,
+		},
+	}
+	res, err = sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"1": value.New("Sensor 2")}, res)
+	}
+
+	// oids without overrides use the settings of the snmp session
+	sut = deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "3"}}
+	res, err = sut.readOID(ctx, nil, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"1": value.New("Sensor 3")}, res)
+	}
+	snmpClient.AssertExpectations(t)
+}
+
+func TestDeviceClassOID_readOID_requestIDLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).With().Str("request_id", "check-42").Logger()
+
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(logger.WithContext(context.Background()), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return(nil, tholaerr.NewSNMPError("request timeout"))
+
+	sut := deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1"}}
+	_, err := sut.readOID(ctx, nil, false)
+	assert.Error(t, err)
+
+	// every log line of the oid carries the request id of the context
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.NotEmpty(t, lines) {
+		for _, line := range lines {
+			assert.Contains(t, line, `"request_id":"check-42"`)
+			assert.Contains(t, line, `"oid":"1"`)
+		}
+	}
+}
+
+func TestDeviceClassOIDs_merge_operationSettings(t *testing.T) {
+	timeout, retries, childRetries := 30, 2, 0
+	parent := deviceClassOIDs{
+		"temperature": &deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "1", Timeout: &timeout, Retries: &retries}},
+		"description": &deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "2"}},
+	}
+	child := deviceClassOIDs{
+		"temperature": &deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "3", Retries: &childRetries}},
+		"description": &deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "4"}},
+	}
+
+	merged := parent.merge(child)
+	assert.Equal(t, &deviceClassOID{SNMPGetConfiguration: network.SNMPGetConfiguration{OID: "3", Timeout: &timeout, Retries: &childRetries}}, merged["temperature"])
+	assert.Equal(t, child["description"], merged["description"])
+
+	// the oids of the child aren't modified by the merge
+	assert.Nil(t, child["temperature"].(*deviceClassOID).Timeout)
+}
+
+func TestProbeOIDs(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.10", gosnmp.OctetString, "Port 10"),
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.9", gosnmp.OctetString, "Port 9"),
+			network.NewSNMPResponse(".1.3.6.1.2.1.2.2.1.2.2", gosnmp.OctetString, "Port 2"),
+		}, nil).
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.3")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")).
+		On("SNMPWalk", mock.Anything, network.OID("1.3.6.1.2.1.2.2.1.4")).
+		Return(nil, errors.New("request timeout"))
+
+	res, err := ProbeOIDs(ctx, []string{"1.3.6.1.2.1.2.2.1.2", "1.3.6.1.2.1.2.2.1.3", "1.3.6.1.2.1.2.2.1.4", "ifDescr"})
+	if assert.NoError(t, err) && assert.Len(t, res, 4) {
+		assert.Equal(t, OIDProbe{
+			OID:         "1.3.6.1.2.1.2.2.1.2",
+			Exists:      true,
+			Count:       3,
+			SampleIndex: "2",
+			Sample:      value.New("Port 2"),
+		}, res[0], "the sample is the value of the numerically lowest index")
+		assert.Equal(t, OIDProbe{OID: "1.3.6.1.2.1.2.2.1.3"}, res[1], "oids that don't exist are no error")
+		assert.False(t, res[2].Exists)
+		assert.Error(t, res[2].Err, "transport errors are reported")
+		assert.Error(t, res[3].Err, "invalid oids are reported")
+	}
+
+	_, err = ProbeOIDs(context.Background(), []string{"1.3.6.1.2.1.2.2.1.2"})
+	assert.Error(t, err)
+}
+
+func TestInterface2Reader_indexFields(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("1.10.1", gosnmp.OctetString, "SAP 1"),
+			network.NewSNMPResponse("1.20.2", gosnmp.OctetString, "SAP 2"),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", mock.Anything, network.OID("2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.10", gosnmp.OctetString, "Service 10"),
+		}, nil)
+
+	groupProperties := func(oid string, indexFields ...interface{}) map[interface{}]interface{} {
+		return map[interface{}]interface{}{
+			"detection": "snmpwalk",
+			"values": map[interface{}]interface{}{
+				"description": map[interface{}]interface{}{
+					"oid": oid,
+				},
+			},
+			"index_fields": indexFields,
+		}
+	}
+
+	reader, err := Interface2Reader(groupProperties("1", "svc_id", "port_id"), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	res, _, err := reader.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, PropertyGroups{
+			propertyGroup{
+				"description": value.New("SAP 1"),
+				"svc_id":      value.New("10"),
+				"port_id":     value.New("1"),
+			},
+			propertyGroup{
+				"description": value.New("SAP 2"),
+				"svc_id":      value.New("20"),
+				"port_id":     value.New("2"),
+			},
+		}, res)
+	}
+
+	// filtered index fields are not added
+	res, _, err = reader.GetProperty(ctx, GetValueFilter([]string{"port_id"}))
+	if assert.NoError(t, err) && assert.Len(t, res, 2) {
+		assert.Equal(t, propertyGroup{
+			"description": value.New("SAP 1"),
+			"svc_id":      value.New("10"),
+		}, res[0])
+	}
+
+	// the amount of index fields has to match the index
+	reader, err = Interface2Reader(groupProperties("2", "svc_id", "port_id"), nil)
+	if assert.NoError(t, err) {
+		_, _, err = reader.GetProperty(ctx)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "index '10' has 1 sub-identifiers, but 2 index fields are declared (svc_id, port_id)")
+		}
+	}
+
+	_, err = Interface2Reader(groupProperties("1", "svc_id", "svc_id"), nil)
+	assert.Error(t, err, "duplicate index fields")
+	_, err = Interface2Reader(groupProperties("1", "description", "port_id"), nil)
+	assert.Error(t, err, "index fields must not overwrite values")
+}
+
+func TestInterface2Reader_rowFilter(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("9")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("9.1", gosnmp.Integer, 1),
+			network.NewSNMPResponse("9.2", gosnmp.Integer, 2),
+			network.NewSNMPResponse("9.3", gosnmp.Integer, 3),
+			network.NewSNMPResponse("9.4", gosnmp.Integer, 1),
+		}, nil).
+		On("SNMPGet", ctx, network.OID("2.1"), network.OID("2.4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.1", gosnmp.OctetString, "Port 1"),
+			network.NewSNMPResponse("2.4", gosnmp.OctetString, "Port 4"),
+		}, nil).
+		On("SNMPGet", ctx, network.OID("2.4"), network.OID("2.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.1", gosnmp.OctetString, "Port 1"),
+			network.NewSNMPResponse("2.4", gosnmp.OctetString, "Port 4"),
+		}, nil).
+		On("SNMPGet", ctx, network.OID("2.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.2", gosnmp.OctetString, "Port 2"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("8")).
+		Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")).
+		On("SNMPWalk", ctx, network.OID("2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse("2.1", gosnmp.OctetString, "Port 1"),
+			network.NewSNMPResponse("2.2", gosnmp.OctetString, "Port 2"),
+		}, nil)
+
+	groupProperties := func(rowFilter interface{}) map[interface{}]interface{} {
+		return map[interface{}]interface{}{
+			"detection": "snmpwalk",
+			"values": map[interface{}]interface{}{
+				"ifDescr": map[interface{}]interface{}{
+					"oid": "2",
+				},
+			},
+			"row_filter": rowFilter,
+		}
+	}
+
+	// notInService and notReady rows are skipped by default, only the remaining rows are read
+	reader, err := Interface2Reader(groupProperties(map[interface{}]interface{}{
+		"oid": "9",
+	}), nil)
+	if assert.NoError(t, err) {
+		res, _, err := reader.GetProperty(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, PropertyGroups{
+				propertyGroup{"ifDescr": value.New("Port 1")},
+				propertyGroup{"ifDescr": value.New("Port 4")},
+			}, res)
+		}
+		snmpClient.AssertNotCalled(t, "SNMPWalk", ctx, network.OID("2"))
+	}
+
+	reader, err = Interface2Reader(groupProperties(map[interface{}]interface{}{
+		"oid":         "9",
+		"skip_values": []interface{}{1, 3},
+	}), nil)
+	if assert.NoError(t, err) {
+		res, _, err := reader.GetProperty(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, PropertyGroups{
+				propertyGroup{"ifDescr": value.New("Port 2")},
+			}, res)
+		}
+	}
+
+	// the row filter is inherited by child readers
+	child, err := Interface2Reader(map[interface{}]interface{}{
+		"detection": "snmpwalk",
+		"values": map[interface{}]interface{}{
+			"ifAlias": map[interface{}]interface{}{
+				"oid": "7",
+			},
+		},
+	}, reader)
+	if assert.NoError(t, err) {
+		childSNMPReader := child.(*baseReader).reader.(*snmpReader)
+		assert.Equal(t, reader.(*baseReader).reader.(*snmpReader).rowFilter, childSNMPReader.rowFilter)
+	}
+
+	// all rows are read if the filter column isn't available
+	reader, err = Interface2Reader(groupProperties(map[interface{}]interface{}{
+		"oid": "8",
+	}), nil)
+	if assert.NoError(t, err) {
+		res, _, err := reader.GetProperty(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, PropertyGroups{
+				propertyGroup{"ifDescr": value.New("Port 1")},
+				propertyGroup{"ifDescr": value.New("Port 2")},
+			}, res)
+		}
+	}
+
+	_, err = Interface2Reader(groupProperties(map[interface{}]interface{}{
+		"oid":         "9",
+		"skip_values": []interface{}{},
+	}), nil)
+	assert.Error(t, err, "empty skip values")
+	_, err = Interface2Reader(groupProperties("9"), nil)
+	assert.Error(t, err, "row filter is not a map")
+}
+
+// TestDeviceClassOID_readOID_retryAuthError tests that deviceClassOID.readOid(...) does not retry a snmp walk which failed with an auth error
+func TestDeviceClassOID_readOID_retryAuthError(t *testing.T) {
+	retries, delay := 2, 1
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{
+				WalkRetries:    &retries,
+				WalkRetryDelay: &delay,
+			},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1")).
+		Return(nil, errors.Wrap(tholaerr.NewAuthError("wrong digest"), "error during snmpwalk"))
+
+	sut := deviceClassOID{
+		SNMPGetConfiguration: network.SNMPGetConfiguration{
+			OID: "1",
+		},
+	}
+
+	_, err := sut.readOID(ctx, nil, false)
+	assert.True(t, tholaerr.IsAuthError(err))
+	snmpClient.AssertNumberOfCalls(t, "SNMPWalk", 1)
+}
+
+func TestSNMPWalkRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), snmpWalkRetryBackoff(0, 3))
+	assert.Equal(t, 100*time.Millisecond, snmpWalkRetryBackoff(100, 1))
+	assert.Equal(t, 400*time.Millisecond, snmpWalkRetryBackoff(100, 3))
+	// the backoff doesn't overflow with many retries or a large delay
+	assert.Equal(t, maxSNMPWalkRetryBackoff, snmpWalkRetryBackoff(100, 100))
+	assert.Equal(t, maxSNMPWalkRetryBackoff, snmpWalkRetryBackoff(math.MaxInt32, 1))
+}
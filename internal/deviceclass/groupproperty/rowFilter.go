@@ -0,0 +1,84 @@
+package groupproperty
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// defaultRowFilterSkipValues are the RowStatus values (SNMPv2-TC) of rows which are not ready to be used,
+// notInService(2) and notReady(3).
+var defaultRowFilterSkipValues = []string{"2", "3"}
+
+// rowFilter drops the rows of a table whose filter column, e.g. a RowStatus column, has one of the skip values. The
+// column is read before the values of the group, so that the values are only read for the remaining rows.
+type rowFilter struct {
+	oid        *deviceClassOID
+	skipValues map[string]struct{}
+}
+
+type yamlRowFilter struct {
+	yamlComponentsOID `mapstructure:",squash"`
+	SkipValues        []interface{} `mapstructure:"skip_values"`
+}
+
+func interface2RowFilter(i interface{}) (*rowFilter, error) {
+	if _, ok := i.(map[interface{}]interface{}); !ok {
+		return nil, errors.New("row_filter needs to be a map")
+	}
+	var y yamlRowFilter
+	if err := mapstructure.Decode(i, &y); err != nil {
+		return nil, errors.Wrap(err, "failed to decode row_filter")
+	}
+	if err := y.validate(); err != nil {
+		return nil, errors.Wrap(err, "row_filter is invalid")
+	}
+	oid, err := y.convert()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert row_filter oid")
+	}
+
+	res := rowFilter{
+		oid:        &oid,
+		skipValues: make(map[string]struct{}),
+	}
+	if y.SkipValues == nil {
+		for _, v := range defaultRowFilterSkipValues {
+			res.skipValues[v] = struct{}{}
+		}
+	}
+	for _, v := range y.SkipValues {
+		res.skipValues[value.New(v).String()] = struct{}{}
+	}
+	if len(res.skipValues) == 0 {
+		return nil, errors.New("skip_values of row_filter must not be empty")
+	}
+	return &res, nil
+}
+
+// getIndices returns the indices of the rows which are not skipped. False is returned if the filter column isn't
+// available, then all rows need to be read.
+func (r *rowFilter) getIndices(ctx context.Context) (map[string]struct{}, bool, error) {
+	results, err := r.oid.readOID(ctx, nil, false)
+	if err != nil && !tholaerr.IsNotFoundError(err) {
+		return nil, false, errors.Wrap(err, "failed to read row filter oid")
+	}
+	if len(results) == 0 {
+		log.Ctx(ctx).Debug().Err(err).Str("oid", r.oid.OID.String()).Msg("row filter oid is not available, reading all rows")
+		return nil, false, nil
+	}
+
+	res := make(map[string]struct{})
+	for index, result := range results {
+		v := value.New(result).String()
+		if _, ok := r.skipValues[v]; ok {
+			log.Ctx(ctx).Debug().Str("row_filter_value", v).Msgf("row filter matched on index '%s', skipping row", index)
+			continue
+		}
+		res[index] = struct{}{}
+	}
+	return res, true, nil
+}
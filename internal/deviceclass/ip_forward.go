@@ -0,0 +1,304 @@
+package deviceclass
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// scalars and columns of the IP-FORWARD-MIB
+const (
+	inetCidrRouteNumberOID network.OID = "1.3.6.1.2.1.4.24.6.0"
+	ipCidrRouteNumberOID   network.OID = "1.3.6.1.2.1.4.24.3.0"
+
+	inetCidrRouteIfIndexOID network.OID = "1.3.6.1.2.1.4.24.7.1.7"
+	inetCidrRouteTypeOID    network.OID = "1.3.6.1.2.1.4.24.7.1.8"
+	inetCidrRouteProtoOID   network.OID = "1.3.6.1.2.1.4.24.7.1.9"
+	inetCidrRouteMetric1OID network.OID = "1.3.6.1.2.1.4.24.7.1.12"
+
+	ipCidrRouteIfIndexOID network.OID = "1.3.6.1.2.1.4.24.4.1.5"
+	ipCidrRouteTypeOID    network.OID = "1.3.6.1.2.1.4.24.4.1.6"
+	ipCidrRouteProtoOID   network.OID = "1.3.6.1.2.1.4.24.4.1.7"
+	ipCidrRouteMetric1OID network.OID = "1.3.6.1.2.1.4.24.4.1.11"
+)
+
+// ipRouteTypes maps the values of inetCidrRouteType and ipCidrRouteType to their names.
+var ipRouteTypes = map[string]string{
+	"1": "other",
+	"2": "reject",
+	"3": "local",
+	"4": "remote",
+	"5": "blackhole",
+}
+
+// ipRouteProtocols maps the values of IANAipRouteProtocol to their names.
+var ipRouteProtocols = map[string]string{
+	"1":  "other",
+	"2":  "local",
+	"3":  "netmgmt",
+	"4":  "icmp",
+	"5":  "egp",
+	"6":  "ggp",
+	"7":  "hello",
+	"8":  "rip",
+	"9":  "isIs",
+	"10": "esIs",
+	"11": "ciscoIgrp",
+	"12": "bbnSpfIgp",
+	"13": "ospf",
+	"14": "bgp",
+	"15": "idpr",
+	"16": "ciscoEigrp",
+	"17": "dvmrp",
+	"18": "rpl",
+	"19": "dhcp",
+	"20": "ttdp",
+}
+
+// ipRouteIndexParser parses the index of a route table into the destination, prefix length and next hop of the route.
+type ipRouteIndexParser func(index string) (string, int, string, error)
+
+// getIPForwardRouteCount returns the number of routes of the IP-FORWARD-MIB. inetCidrRouteNumber is preferred over
+// ipCidrRouteNumber, as it also counts IPv6 routes.
+func getIPForwardRouteCount(ctx context.Context) (int, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return 0, errors.New("snmp client is empty")
+	}
+
+	for _, oid := range []network.OID{inetCidrRouteNumberOID, ipCidrRouteNumberOID} {
+		res, err := con.SNMP.SnmpClient.SNMPGet(ctx, oid)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				continue
+			}
+			return 0, errors.Wrapf(err, "failed to get oid '%s'", oid)
+		}
+		if len(res) == 0 {
+			continue
+		}
+		val, err := res[0].GetValue()
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				continue
+			}
+			return 0, errors.Wrapf(err, "failed to get value of oid '%s'", oid)
+		}
+		count, err := val.Int()
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid route count '%s'", val.String())
+		}
+		return count, nil
+	}
+	return 0, tholaerr.NewNotFoundError("no route count found in IP-FORWARD-MIB")
+}
+
+// getIPForwardRoutingTable returns the routing table of the IP-FORWARD-MIB. The inetCidrRouteTable is preferred over
+// the ipCidrRouteTable, as it also contains IPv6 routes.
+func getIPForwardRoutingTable(ctx context.Context) ([]device.Route, error) {
+	routes, err := readIPRouteTable(ctx, parseInetCidrRouteIndex,
+		inetCidrRouteIfIndexOID, inetCidrRouteTypeOID, inetCidrRouteProtoOID, inetCidrRouteMetric1OID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read inetCidrRouteTable")
+	}
+	if len(routes) > 0 {
+		return routes, nil
+	}
+
+	routes, err = readIPRouteTable(ctx, parseIPCidrRouteIndex,
+		ipCidrRouteIfIndexOID, ipCidrRouteTypeOID, ipCidrRouteProtoOID, ipCidrRouteMetric1OID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ipCidrRouteTable")
+	}
+	if len(routes) > 0 {
+		return routes, nil
+	}
+
+	return nil, tholaerr.NewNotFoundError("no routing table found in IP-FORWARD-MIB")
+}
+
+// readIPRouteTable reads the routes of a route table. The ifIndex column is required for every route,
+// the other columns are optional.
+func readIPRouteTable(ctx context.Context, parseIndex ipRouteIndexParser, ifIndexOID, typeOID, protoOID, metricOID network.OID) ([]device.Route, error) {
+	ifIndices, err := walkSNMPColumn(ctx, ifIndexOID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(ifIndices) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[network.OID]map[string]string)
+	for _, oid := range []network.OID{typeOID, protoOID, metricOID} {
+		columns[oid], err = walkSNMPColumn(ctx, oid, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read oid '%s'", oid)
+		}
+	}
+
+	indices := make([]string, 0, len(ifIndices))
+	for idx := range ifIndices {
+		indices = append(indices, idx)
+	}
+	sort.Strings(indices)
+
+	var routes []device.Route
+	for _, idx := range indices {
+		destination, prefixLength, nextHop, err := parseIndex(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid route index '%s'", idx)
+		}
+		route := device.Route{
+			Destination:  &destination,
+			PrefixLength: &prefixLength,
+		}
+		if nextHop != "" {
+			route.NextHop = &nextHop
+		}
+		if ifIndex, err := strconv.ParseUint(ifIndices[idx], 10, 64); err == nil && ifIndex != 0 {
+			route.IfIndex = &ifIndex
+		}
+		if t, ok := ipRouteTypes[columns[typeOID][idx]]; ok {
+			route.Type = &t
+		}
+		if p, ok := ipRouteProtocols[columns[protoOID][idx]]; ok {
+			route.Protocol = &p
+		}
+		// a metric of -1 means that the metric is not used
+		if metric, err := strconv.Atoi(columns[metricOID][idx]); err == nil && metric >= 0 {
+			route.Metric = &metric
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// parseInetCidrRouteIndex parses an index of the inetCidrRouteTable, which consists of the destination type,
+// the length prefixed destination, the prefix length, the length prefixed policy, the next hop type and the
+// length prefixed next hop.
+func parseInetCidrRouteIndex(index string) (string, int, string, error) {
+	parts, err := parseOIDIndex(index)
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	pos := 1 // inetCidrRouteDestType
+	destination, pos, err := parseLengthPrefixedAddress(parts, pos)
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "invalid destination")
+	}
+	if pos >= len(parts) {
+		return "", 0, "", errors.New("missing prefix length")
+	}
+	prefixLength := parts[pos]
+	pos++
+
+	// inetCidrRoutePolicy
+	if pos >= len(parts) {
+		return "", 0, "", errors.New("missing policy")
+	}
+	pos += parts[pos] + 1
+
+	pos++ // inetCidrRouteNextHopType
+	nextHop, _, err := parseLengthPrefixedAddress(parts, pos)
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "invalid next hop")
+	}
+	if nextHop == "0.0.0.0" || nextHop == "::" {
+		nextHop = ""
+	}
+	return destination, prefixLength, nextHop, nil
+}
+
+// parseIPCidrRouteIndex parses an index of the ipCidrRouteTable, which consists of the destination, the mask,
+// the TOS and the next hop.
+func parseIPCidrRouteIndex(index string) (string, int, string, error) {
+	parts, err := parseOIDIndex(index)
+	if err != nil {
+		return "", 0, "", err
+	}
+	if len(parts) != 13 {
+		return "", 0, "", fmt.Errorf("index has %d instead of 13 parts", len(parts))
+	}
+
+	destination, err := ipFromOIDIndex(parts[0:4])
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "invalid destination")
+	}
+	mask, err := ipFromOIDIndex(parts[4:8])
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "invalid mask")
+	}
+	prefixLength, _ := net.IPMask(mask.To4()).Size()
+	nextHop, err := ipFromOIDIndex(parts[9:13])
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "invalid next hop")
+	}
+
+	var nextHopString string
+	if !nextHop.IsUnspecified() {
+		nextHopString = nextHop.String()
+	}
+	return destination.String(), prefixLength, nextHopString, nil
+}
+
+// parseLengthPrefixedAddress parses an InetAddress at the given position of an index and returns it together with the
+// position after the address. Zone indices of scoped addresses are ignored.
+func parseLengthPrefixedAddress(parts []int, pos int) (string, int, error) {
+	if pos >= len(parts) {
+		return "", 0, errors.New("missing address length")
+	}
+	length := parts[pos]
+	pos++
+	if pos+length > len(parts) {
+		return "", 0, fmt.Errorf("address length %d exceeds index", length)
+	}
+	address := parts[pos : pos+length]
+	pos += length
+
+	switch length {
+	case 0:
+		return "", pos, nil
+	case net.IPv4len, net.IPv4len + 4:
+		address = address[:net.IPv4len]
+	case net.IPv6len, net.IPv6len + 4:
+		address = address[:net.IPv6len]
+	default:
+		return "", 0, fmt.Errorf("invalid address length %d", length)
+	}
+	ip, err := ipFromOIDIndex(address)
+	if err != nil {
+		return "", 0, err
+	}
+	return ip.String(), pos, nil
+}
+
+func ipFromOIDIndex(parts []int) (net.IP, error) {
+	ip := make(net.IP, len(parts))
+	for i, p := range parts {
+		if p < 0 || p > 255 {
+			return nil, fmt.Errorf("invalid address byte %d", p)
+		}
+		ip[i] = byte(p)
+	}
+	return ip, nil
+}
+
+func parseOIDIndex(index string) ([]int, error) {
+	var parts []int
+	for _, s := range strings.Split(strings.Trim(index, "."), ".") {
+		p, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index part '%s'", s)
+		}
+		parts = append(parts, p)
+	}
+	return parts, nil
+}
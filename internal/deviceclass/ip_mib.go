@@ -0,0 +1,280 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// columns of the IP-MIB
+const (
+	ipAddressIfIndexOID network.OID = "1.3.6.1.2.1.4.34.1.3"
+	ipAddressTypeOID    network.OID = "1.3.6.1.2.1.4.34.1.4"
+	ipAddressPrefixOID  network.OID = "1.3.6.1.2.1.4.34.1.5"
+
+	ipAdEntIfIndexOID network.OID = "1.3.6.1.2.1.4.20.1.2"
+	ipAdEntNetMaskOID network.OID = "1.3.6.1.2.1.4.20.1.3"
+
+	ipIfStatsInReceivesOID     network.OID = "1.3.6.1.2.1.4.31.3.1.3"
+	ipIfStatsHCInReceivesOID   network.OID = "1.3.6.1.2.1.4.31.3.1.4"
+	ipIfStatsInOctetsOID       network.OID = "1.3.6.1.2.1.4.31.3.1.5"
+	ipIfStatsHCInOctetsOID     network.OID = "1.3.6.1.2.1.4.31.3.1.6"
+	ipIfStatsOutTransmitsOID   network.OID = "1.3.6.1.2.1.4.31.3.1.30"
+	ipIfStatsHCOutTransmitsOID network.OID = "1.3.6.1.2.1.4.31.3.1.31"
+	ipIfStatsOutOctetsOID      network.OID = "1.3.6.1.2.1.4.31.3.1.32"
+	ipIfStatsHCOutOctetsOID    network.OID = "1.3.6.1.2.1.4.31.3.1.33"
+)
+
+// ip versions of the ipIfStatsTable index
+const (
+	ipVersionIPv4 = 1
+	ipVersionIPv6 = 2
+)
+
+// ipAddressPrefixEntryOID is the ipAddressPrefixEntry, which the ipAddressPrefix column points to.
+const ipAddressPrefixEntryOID = "1.3.6.1.2.1.4.32.1."
+
+// ipAddressTypeBroadcast is the ipAddressType of broadcast addresses.
+const ipAddressTypeBroadcast = "3"
+
+// addInterfaceIPAddresses adds the IP addresses of the IP-MIB to the interfaces. Interfaces without addresses get an
+// empty slice. The IP addresses are optional, so errors are only logged.
+func addInterfaceIPAddresses(ctx context.Context, interfaces []device.Interface) {
+	addresses, err := getIPAddresses(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read ip addresses")
+		return
+	}
+
+	for i, interf := range interfaces {
+		if interf.IfIndex == nil {
+			continue
+		}
+		interfaces[i].IPAddresses = addresses[*interf.IfIndex]
+		if interfaces[i].IPAddresses == nil {
+			interfaces[i].IPAddresses = []device.IPAddress{}
+		}
+	}
+}
+
+// getIPAddresses returns the IP addresses of the IP-MIB mapped by the ifIndex of their interface. The ipAddressTable
+// is preferred over the legacy ipAddrTable, as it also contains IPv6 addresses.
+func getIPAddresses(ctx context.Context) (map[uint64][]device.IPAddress, error) {
+	addresses, err := readIPAddressTable(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ipAddressTable")
+	}
+	if len(addresses) > 0 {
+		return addresses, nil
+	}
+
+	addresses, err = readIPAddrTable(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ipAddrTable")
+	}
+	return addresses, nil
+}
+
+// readIPAddressTable reads the addresses of the ipAddressTable. Broadcast addresses are skipped.
+func readIPAddressTable(ctx context.Context) (map[uint64][]device.IPAddress, error) {
+	ifIndices, err := walkSNMPColumn(ctx, ipAddressIfIndexOID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(ifIndices) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[network.OID]map[string]string)
+	for _, oid := range []network.OID{ipAddressTypeOID, ipAddressPrefixOID} {
+		columns[oid], err = walkSNMPColumn(ctx, oid, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read oid '%s'", oid)
+		}
+	}
+
+	res := make(map[uint64][]device.IPAddress)
+	for _, idx := range sortedKeys(ifIndices) {
+		if columns[ipAddressTypeOID][idx] == ipAddressTypeBroadcast {
+			continue
+		}
+		ifIndex, err := strconv.ParseUint(ifIndices[idx], 10, 64)
+		if err != nil || ifIndex == 0 {
+			continue
+		}
+
+		// the index consists of the address type and the length prefixed address
+		parts, err := parseOIDIndex(idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address index '%s'", idx)
+		}
+		address, _, err := parseLengthPrefixedAddress(parts, 1)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address index '%s'", idx)
+		}
+		ip := net.ParseIP(address)
+		if ip == nil {
+			continue
+		}
+
+		res[ifIndex] = append(res[ifIndex], newIPAddress(ip, parseIPAddressPrefix(columns[ipAddressPrefixOID][idx])))
+	}
+	return res, nil
+}
+
+// readIPAddrTable reads the IPv4 addresses of the legacy ipAddrTable.
+func readIPAddrTable(ctx context.Context) (map[uint64][]device.IPAddress, error) {
+	ifIndices, err := walkSNMPColumn(ctx, ipAdEntIfIndexOID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(ifIndices) == 0 {
+		return nil, nil
+	}
+
+	masks, err := walkSNMPColumn(ctx, ipAdEntNetMaskOID, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read oid '%s'", ipAdEntNetMaskOID)
+	}
+
+	res := make(map[uint64][]device.IPAddress)
+	for _, idx := range sortedKeys(ifIndices) {
+		ifIndex, err := strconv.ParseUint(ifIndices[idx], 10, 64)
+		if err != nil || ifIndex == 0 {
+			continue
+		}
+
+		// the index is the address itself
+		ip := net.ParseIP(idx)
+		if ip == nil {
+			return nil, errors.Errorf("invalid address index '%s'", idx)
+		}
+
+		var prefixLength int
+		if mask := net.ParseIP(masks[idx]).To4(); mask != nil {
+			prefixLength, _ = net.IPMask(mask).Size()
+		}
+
+		res[ifIndex] = append(res[ifIndex], newIPAddress(ip, prefixLength))
+	}
+	return res, nil
+}
+
+// parseIPAddressPrefix returns the prefix length of a row pointer to the ipAddressPrefixTable, whose last index part
+// is the prefix length. 0 is returned if the pointer doesn't point to the ipAddressPrefixTable, e.g. zeroDotZero.
+func parseIPAddressPrefix(pointer string) int {
+	pointer = strings.TrimPrefix(pointer, ".")
+	if !strings.HasPrefix(pointer, ipAddressPrefixEntryOID) {
+		return 0
+	}
+	prefixLength, err := strconv.Atoi(pointer[strings.LastIndex(pointer, ".")+1:])
+	if err != nil || prefixLength < 0 || prefixLength > 128 {
+		return 0
+	}
+	return prefixLength
+}
+
+// addInterfaceIPStats adds the IPv4 and/or IPv6 counters of the ipIfStatsTable to the interfaces. Interfaces without
+// counters for an address family keep nil. The counters are optional, so errors are only logged.
+func addInterfaceIPStats(ctx context.Context, interfaces []device.Interface, ipv4, ipv6 bool) {
+	stats, err := readIPIfStatsTable(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read ipIfStatsTable")
+		return
+	}
+
+	for i, interf := range interfaces {
+		if interf.IfIndex == nil {
+			continue
+		}
+		if ipv4 {
+			interfaces[i].IPv4Stats = stats[ipIfStatsIndex{ipVersionIPv4, *interf.IfIndex}]
+		}
+		if ipv6 {
+			interfaces[i].IPv6Stats = stats[ipIfStatsIndex{ipVersionIPv6, *interf.IfIndex}]
+		}
+	}
+}
+
+// ipIfStatsIndex is the index of the ipIfStatsTable, which consists of the ip version and the ifIndex.
+type ipIfStatsIndex struct {
+	ipVersion int
+	ifIndex   uint64
+}
+
+// readIPIfStatsTable reads the counters of the ipIfStatsTable. The 64-bit high capacity counters are preferred if
+// available.
+func readIPIfStatsTable(ctx context.Context) (map[ipIfStatsIndex]*device.InterfaceIPStats, error) {
+	receives, err := walkSNMPColumn(ctx, ipIfStatsInReceivesOID, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(receives) == 0 {
+		return nil, nil
+	}
+
+	columns := map[network.OID]map[string]string{ipIfStatsInReceivesOID: receives}
+	for _, oid := range []network.OID{ipIfStatsHCInReceivesOID, ipIfStatsInOctetsOID, ipIfStatsHCInOctetsOID,
+		ipIfStatsOutTransmitsOID, ipIfStatsHCOutTransmitsOID, ipIfStatsOutOctetsOID, ipIfStatsHCOutOctetsOID} {
+		columns[oid], err = walkSNMPColumn(ctx, oid, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read oid '%s'", oid)
+		}
+	}
+
+	counter := func(hcOID, oid network.OID, idx string) *uint64 {
+		res, _ := selectHCCounter(parseCounter(columns[hcOID][idx]), parseCounter(columns[oid][idx]))
+		return res
+	}
+
+	res := make(map[ipIfStatsIndex]*device.InterfaceIPStats)
+	for idx := range receives {
+		parts, err := parseOIDIndex(idx)
+		if err != nil || len(parts) != 2 {
+			return nil, errors.Errorf("invalid ipIfStatsTable index '%s'", idx)
+		}
+		if parts[0] != ipVersionIPv4 && parts[0] != ipVersionIPv6 || parts[1] <= 0 {
+			continue
+		}
+
+		res[ipIfStatsIndex{parts[0], uint64(parts[1])}] = &device.InterfaceIPStats{
+			InOctets:   counter(ipIfStatsHCInOctetsOID, ipIfStatsInOctetsOID, idx),
+			OutOctets:  counter(ipIfStatsHCOutOctetsOID, ipIfStatsOutOctetsOID, idx),
+			InPackets:  counter(ipIfStatsHCInReceivesOID, ipIfStatsInReceivesOID, idx),
+			OutPackets: counter(ipIfStatsHCOutTransmitsOID, ipIfStatsOutTransmitsOID, idx),
+		}
+	}
+	return res, nil
+}
+
+// parseCounter returns nil if the value is missing or not a counter.
+func parseCounter(s string) *uint64 {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func newIPAddress(ip net.IP, prefixLength int) device.IPAddress {
+	return device.IPAddress{
+		Address:      ip.String(),
+		PrefixLength: prefixLength,
+		LinkLocal:    ip.IsLinkLocalUnicast(),
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,20 @@
+package property
+
+import "context"
+
+type ctxKey byte
+
+const (
+	groupValuesKey ctxKey = iota + 1
+)
+
+// NewContextWithGroupValues returns a new context with the already read values of the current group
+func NewContextWithGroupValues(ctx context.Context, values map[string]interface{}) context.Context {
+	return context.WithValue(ctx, groupValuesKey, values)
+}
+
+// GroupValuesFromContext gets the already read values of the current group from the context
+func GroupValuesFromContext(ctx context.Context) (map[string]interface{}, bool) {
+	values, ok := ctx.Value(groupValuesKey).(map[string]interface{})
+	return values, ok
+}
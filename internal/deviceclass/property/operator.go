@@ -12,9 +12,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
+	"math/big"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Operators, error) {
@@ -105,6 +108,21 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 					return nil, errors.Wrap(err, "failed to create new regex submatch modifier")
 				}
 				modifier.operator = mod
+			case "regexCapture":
+				regexString, ok := m["regex"].(string)
+				if !ok {
+					return nil, errors.New("regex is missing in regexCapture modify operator, or is not a string")
+				}
+				var fallback *string
+				if fallbackInterface, ok := m["fallback"]; ok {
+					fallbackString := fmt.Sprint(fallbackInterface)
+					fallback = &fallbackString
+				}
+				mod, err := newRegexCaptureModifier(regexString, m["group"], fallback)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to create new regex capture modifier")
+				}
+				modifier.operator = mod
 			case "regexReplace":
 				replace, ok := m["replace"]
 				if !ok {
@@ -133,6 +151,18 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 			case "toLowerCase":
 				var toLowerCaseModifier toLowerCaseModifier
 				modifier.operator = &toLowerCaseModifier
+			case "hexToMAC":
+				var hexToMACModifier hexToMACModifier
+				modifier.operator = &hexToMACModifier
+			case "hexToASCII":
+				var hexToASCIIModifier hexToASCIIModifier
+				modifier.operator = &hexToASCIIModifier
+			case "timeStampToDate":
+				var timeStampToDateModifier timeStampToDateModifier
+				modifier.operator = &timeStampToDateModifier
+			case "timeStampToDuration":
+				var timeStampToDurationModifier timeStampToDurationModifier
+				modifier.operator = &timeStampToDurationModifier
 			case "overwrite":
 				overwriteString, ok := m["value"].(string)
 				if !ok {
@@ -192,6 +222,14 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 				var mapModifier mapModifier
 				mapModifier.ignoreOnMismatch = ignoreOnMismatch
 
+				if defaultInterface, ok := m["default"]; ok {
+					if ignoreOnMismatch {
+						return nil, errors.New("default and ignore_on_mismatch can't be used together in map modifier")
+					}
+					defaultValue := value.New(defaultInterface)
+					mapModifier.defaultValue = &defaultValue
+				}
+
 				mappings, ok := mappingsInterface.(map[interface{}]interface{})
 				if !ok {
 					file, ok := mappingsInterface.(string)
@@ -215,6 +253,7 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 				if len(mapModifier.mappings) == 0 {
 					return nil, errors.New("mappings is empty")
 				}
+				mapModifier.numericMappings = numericMappings(mapModifier.mappings)
 				modifier.operator = &mapModifier
 			case "add":
 				valueReaderInterface := m["value"]
@@ -275,6 +314,54 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 
 				divideModifier.value = valueReader
 				modifier.operator = &divideModifier
+			case "calculate":
+				var calculateModifier calculateModifier
+
+				operation, ok := m["operation"].(string)
+				if !ok {
+					return nil, errors.New("operation is missing in calculate modify operator, or is not a string")
+				}
+				switch operation {
+				case "+", "-", "*", "/":
+					calculateModifier.operation = operation
+				default:
+					return nil, fmt.Errorf("invalid operation '%s' in calculate modify operator, only '+', '-', '*' and '/' are possible", operation)
+				}
+
+				valueInterface, hasValue := m["value"]
+				propertyInterface, hasProperty := m["property"]
+				if hasValue == hasProperty {
+					return nil, errors.New("calculate modify operator needs either a value or a property")
+				}
+				if hasValue {
+					constant, err := toDecimal(value.New(valueInterface))
+					if err != nil {
+						return nil, errors.Wrap(err, "value in calculate modify operator is not a number")
+					}
+					calculateModifier.value = &constant
+				} else {
+					calculateModifier.property, ok = propertyInterface.(string)
+					if !ok || calculateModifier.property == "" {
+						return nil, errors.New("property in calculate modify operator needs to be a non-empty string")
+					}
+				}
+
+				calculateModifier.precision = 2
+				if precisionInterface, ok := m["precision"]; ok {
+					if precisionInt, ok := precisionInterface.(int); ok {
+						calculateModifier.precision = int32(precisionInt)
+					} else {
+						return nil, errors.New("precision needs to be an integer")
+					}
+				}
+
+				modifier.operator = &calculateModifier
+			case "convertUnit":
+				convertUnitModifier, err := newConvertUnitModifier(m)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to create new convert unit modifier")
+				}
+				modifier.operator = convertUnitModifier
 			default:
 				return nil, fmt.Errorf("invalid modify method '%s'", modifyMethod)
 			}
@@ -385,6 +472,19 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 
 type Operators []operator
 
+// UsesGroupValues returns whether any of the operators references other values of the same group.
+// These operators can only be applied when the other values of the group are available in the context.
+func (o *Operators) UsesGroupValues() bool {
+	for _, op := range *o {
+		if adapter, ok := op.(*modifyOperatorAdapter); ok {
+			if calc, ok := adapter.operator.(*calculateModifier); ok && calc.property != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (o *Operators) Apply(ctx context.Context, v value.Value) (value.Value, error) {
 	for _, operator := range *o {
 		x, err := operator.operate(ctx, v)
@@ -527,6 +627,130 @@ func (m *divideNumberModifier) modify(ctx context.Context, v value.Value) (value
 	return value.New(result), nil
 }
 
+// calculateModifier calculates the current value with either a constant or another value of the same group.
+type calculateModifier struct {
+	operation string
+	value     *decimal.Decimal
+	property  string
+	precision int32
+}
+
+func (m *calculateModifier) modify(ctx context.Context, v value.Value) (value.Value, error) {
+	a, err := toDecimal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert current value to decimal number")
+	}
+
+	var b decimal.Decimal
+	if m.value != nil {
+		b = *m.value
+	} else {
+		groupValues, ok := GroupValuesFromContext(ctx)
+		if !ok {
+			return nil, errors.New("no group values found in context")
+		}
+		propertyValue, ok := groupValues[m.property].(value.Value)
+		if !ok {
+			return nil, tholaerr.NewValueUnavailableError(fmt.Sprintf("property '%s' not found in group", m.property))
+		}
+		b, err = toDecimal(propertyValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot convert property '%s' to decimal number", m.property)
+		}
+	}
+
+	var result decimal.Decimal
+	switch m.operation {
+	case "+":
+		result = a.Add(b)
+	case "-":
+		result = a.Sub(b)
+	case "*":
+		result = a.Mul(b)
+	case "/":
+		if b.IsZero() {
+			return nil, tholaerr.NewValueUnavailableError("divisor is zero, division by zero not possible")
+		}
+		result = a.DivRound(b, m.precision)
+	default:
+		return nil, fmt.Errorf("invalid operation '%s'", m.operation)
+	}
+	return value.New(result), nil
+}
+
+// convertUnitModifier converts a value into another unit by calculating value * factor / divisor + offset,
+// e.g. tenths of a degree into degrees (divisor 10) or kbit/s into bit/s (factor 1000).
+type convertUnitModifier struct {
+	factor    decimal.Decimal
+	divisor   decimal.Decimal
+	offset    decimal.Decimal
+	precision *int32
+}
+
+func newConvertUnitModifier(m map[interface{}]interface{}) (*convertUnitModifier, error) {
+	c := convertUnitModifier{
+		factor:  decimal.NewFromInt(1),
+		divisor: decimal.NewFromInt(1),
+	}
+
+	_, hasFactor := m["factor"]
+	_, hasDivisor := m["divisor"]
+	_, hasOffset := m["offset"]
+	if !hasFactor && !hasDivisor && !hasOffset {
+		return nil, errors.New("convert unit modify operator needs a factor, a divisor or an offset")
+	}
+
+	for key, d := range map[string]*decimal.Decimal{"factor": &c.factor, "divisor": &c.divisor, "offset": &c.offset} {
+		if i, ok := m[key]; ok {
+			var err error
+			*d, err = toDecimal(value.New(i))
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s in convert unit modify operator is not a number", key)
+			}
+		}
+	}
+	if c.divisor.IsZero() {
+		return nil, errors.New("divisor in convert unit modify operator must not be zero")
+	}
+
+	if precisionInterface, ok := m["precision"]; ok {
+		precisionInt, ok := precisionInterface.(int)
+		if !ok {
+			return nil, errors.New("precision needs to be an integer")
+		}
+		precision := int32(precisionInt)
+		c.precision = &precision
+	}
+
+	return &c, nil
+}
+
+func (m *convertUnitModifier) modify(_ context.Context, v value.Value) (value.Value, error) {
+	d, err := toDecimal(v)
+	if err != nil {
+		return nil, tholaerr.NewParseError(fmt.Sprintf("cannot convert unit of value '%s', it is not a number", v.String()))
+	}
+
+	// the division is done last, so that the offset isn't affected by its rounding
+	result := d.Mul(m.factor).Add(m.offset.Mul(m.divisor)).Div(m.divisor)
+	if m.precision != nil {
+		result = result.Round(*m.precision)
+	}
+	return value.New(result), nil
+}
+
+// toDecimal converts a numeric value into a decimal number.
+func toDecimal(v value.Value) (decimal.Decimal, error) {
+	if d, err := decimal.NewFromString(v.String()); err == nil {
+		return d, nil
+	}
+	f, err := v.Float64()
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return decimal.NewFromFloat(f), nil
+}
+
 func getCalculationOperators(ctx context.Context, v value.Value, value Reader) (decimal.Decimal, decimal.Decimal, error) {
 	a, err := decimal.NewFromString(v.String())
 	if err != nil {
@@ -578,6 +802,93 @@ func (o *toLowerCaseModifier) modify(_ context.Context, v value.Value) (value.Va
 	return value.New(strings.ToLower(v.String())), nil
 }
 
+type hexToMACModifier struct{}
+
+// modify converts a hex string to a MAC address. Values that are no MAC addresses, e.g. empty values or the longer
+// hardware addresses of some tunnel interfaces, don't match.
+func (o *hexToMACModifier) modify(_ context.Context, v value.Value) (value.Value, error) {
+	mac, err := value.HexToMAC(v)
+	if err != nil {
+		if tholaerr.IsParseError(err) {
+			return nil, tholaerr.NewDidNotMatchError(err.Error())
+		}
+		return nil, err
+	}
+	return mac, nil
+}
+
+type hexToASCIIModifier struct{}
+
+func (o *hexToASCIIModifier) modify(_ context.Context, v value.Value) (value.Value, error) {
+	return value.HexToASCII(v)
+}
+
+// sysUpTimeOID is the oid of the sysUpTime, which is the reference of all TimeStamp values.
+var sysUpTimeOID = network.OID(".1.3.6.1.2.1.1.3.0")
+
+// timeNow returns the current time, it is replaced in tests.
+var timeNow = time.Now
+
+type timeStampToDateModifier struct{}
+
+// modify converts a TimeStamp, which is the value of the sysUpTime at the time an event occurred, to a date in
+// RFC 3339 format. A TimeStamp of zero means that the event didn't occur since the last restart of the agent.
+func (o *timeStampToDateModifier) modify(ctx context.Context, v value.Value) (value.Value, error) {
+	age, err := timeStampAge(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	date := timeNow().UTC().Add(-age).Truncate(time.Second)
+	return value.New(date.Format(time.RFC3339)), nil
+}
+
+type timeStampToDurationModifier struct{}
+
+// modify converts a TimeStamp, which is the value of the sysUpTime at the time an event occurred, to the seconds
+// since the event. A TimeStamp of zero means that the event didn't occur since the last restart of the agent.
+func (o *timeStampToDurationModifier) modify(ctx context.Context, v value.Value) (value.Value, error) {
+	age, err := timeStampAge(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	return value.New(uint64(age / time.Second)), nil
+}
+
+// timeStampAge returns the time since the given TimeStamp by comparing it to the current sysUpTime. If the TimeStamp
+// is newer than the sysUpTime, e.g. because the sysUpTime wrapped, the age is unknown and a NotFoundError is returned.
+func timeStampAge(ctx context.Context, v value.Value) (time.Duration, error) {
+	timeStamp, err := v.UInt64()
+	if err != nil {
+		return 0, errors.Wrap(err, "timestamp is not an unsigned integer")
+	}
+	if timeStamp == 0 {
+		return 0, tholaerr.NewNotFoundError("event did not occur since the last restart of the agent")
+	}
+
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil || con.SNMP.SnmpClient == nil {
+		return 0, errors.New("no snmp connection available, cannot read sysUpTime")
+	}
+	res, err := con.SNMP.SnmpClient.SNMPGet(ctx, sysUpTimeOID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read sysUpTime")
+	}
+	val, err := res[0].GetValue()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get sysUpTime value")
+	}
+	sysUpTime, err := val.UInt64()
+	if err != nil {
+		return 0, errors.Wrap(err, "sysUpTime is not an unsigned integer")
+	}
+	if timeStamp > sysUpTime {
+		return 0, tholaerr.NewNotFoundError("timestamp is newer than the sysUpTime")
+	}
+
+	// both values are given in hundredths of a second
+	return time.Duration(sysUpTime-timeStamp) * 10 * time.Millisecond, nil
+}
+
 type overwriteModifier struct {
 	overwriteString string
 }
@@ -632,6 +943,58 @@ func (o *regexSubmatchModifier) returnOnError() bool {
 	return o.returnOnMismatch
 }
 
+// regexCaptureModifier replaces the value with a capture group of a regex. The group is either the index or the name
+// of the capture group, by default the first capture group is used. If the regex doesn't match, the fallback is
+// returned, or the original value if there is no fallback.
+type regexCaptureModifier struct {
+	regex    *regexp.Regexp
+	group    int
+	fallback *string
+}
+
+func newRegexCaptureModifier(regex string, group interface{}, fallback *string) (*regexCaptureModifier, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, errors.Wrap(err, "regex compile failed")
+	}
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("regex '%s' has no capture group", regex)
+	}
+
+	r := regexCaptureModifier{
+		regex:    re,
+		group:    1,
+		fallback: fallback,
+	}
+	switch g := group.(type) {
+	case nil:
+		// the first capture group is used
+	case int:
+		if g < 1 || g > re.NumSubexp() {
+			return nil, fmt.Errorf("regex '%s' has no capture group %d", regex, g)
+		}
+		r.group = g
+	case string:
+		if r.group = re.SubexpIndex(g); r.group == -1 {
+			return nil, fmt.Errorf("regex '%s' has no capture group named '%s'", regex, g)
+		}
+	default:
+		return nil, errors.New("group needs to be the index or the name of a capture group")
+	}
+	return &r, nil
+}
+
+func (o *regexCaptureModifier) modify(_ context.Context, v value.Value) (value.Value, error) {
+	subMatches := o.regex.FindStringSubmatch(v.String())
+	if subMatches == nil {
+		if o.fallback != nil {
+			return value.New(*o.fallback), nil
+		}
+		return v, nil
+	}
+	return value.New(subMatches[o.group]), nil
+}
+
 type regexReplaceModifier struct {
 	regex   *regexp.Regexp
 	replace string
@@ -669,17 +1032,61 @@ func (r *insertReadValueModifier) modify(ctx context.Context, v value.Value) (va
 
 type mapModifier struct {
 	ignoreOnMismatch bool
+	defaultValue     *value.Value
 	mappings         map[string]string
+
+	// numericMappings contains the mappings with numeric keys, mapped by the canonical form of the key
+	numericMappings map[string]string
+}
+
+// numericMappings returns the mappings with numeric keys mapped by the canonical form of the key.
+// If multiple keys have the same numeric value (e.g. "1" and "1.0"), the first key in sort order is used.
+func numericMappings(mappings map[string]string) map[string]string {
+	keys := make([]string, 0, len(mappings))
+	for k := range mappings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make(map[string]string)
+	for _, k := range keys {
+		canonical, ok := canonicalNumber(k)
+		if !ok {
+			continue
+		}
+		if _, ok := res[canonical]; !ok {
+			res[canonical] = mappings[k]
+		}
+	}
+	return res
+}
+
+// canonicalNumber returns the canonical form of a numeric string, so that e.g. "2.0" and "2" have the same form.
+func canonicalNumber(s string) (string, bool) {
+	var f big.Float
+	if _, _, err := f.Parse(s, 10); err != nil {
+		return "", false
+	}
+	return f.Text('g', -1), true
 }
 
 func (r *mapModifier) modify(_ context.Context, v value.Value) (value.Value, error) {
 	if val, ok := r.mappings[v.String()]; ok {
 		return value.New(val), nil
 	}
+	// numeric values are compared by their value, so that e.g. "2.0" matches the key "2"
+	if canonical, ok := canonicalNumber(v.String()); ok {
+		if val, ok := r.numericMappings[canonical]; ok {
+			return value.New(val), nil
+		}
+	}
+	if r.defaultValue != nil {
+		return *r.defaultValue, nil
+	}
 	if r.ignoreOnMismatch {
 		return nil, nil
 	}
-	return nil, tholaerr.NewNotFoundError("string not found in mapping")
+	return nil, tholaerr.NewNotFoundError(fmt.Sprintf("value '%s' not found in mapping", v.String()))
 }
 
 type genericStringSwitch struct {
@@ -742,7 +1149,7 @@ func (w *snmpWalkCountStringSwitchValueGetter) getSwitchValue(ctx context.Contex
 	}
 	var i int
 
-	res, err := con.SNMP.SnmpClient.SNMPWalk(ctx, w.oid)
+	res, err := network.SNMPWalkCached(ctx, con.SNMP.SnmpClient, w.oid)
 	if err != nil {
 		return nil, errors.Wrap(err, "snmpwalk failed")
 	}
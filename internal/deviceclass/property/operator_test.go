@@ -0,0 +1,415 @@
+package property
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMapModifier(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "map",
+			"mappings": map[interface{}]interface{}{
+				1: "ok",
+				2: "failed",
+			},
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := operators.Apply(context.Background(), value.New(2))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("failed"), res)
+	}
+
+	res, err = operators.Apply(context.Background(), value.New("1.0"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("ok"), res)
+	}
+
+	_, err = operators.Apply(context.Background(), value.New(3))
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestMapModifier_numericKeys(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "map",
+			"mappings": map[interface{}]interface{}{
+				"1.0": "first",
+				"1":   "second",
+				"01":  "third",
+			},
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 0; i < 10; i++ {
+		res, err := operators.Apply(context.Background(), value.New("1.00"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, value.New("third"), res)
+		}
+	}
+
+	res, err := operators.Apply(context.Background(), value.New("1.0"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("first"), res)
+	}
+}
+
+func TestMapModifier_default(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "map",
+			"mappings": map[interface{}]interface{}{
+				1: "ok",
+			},
+			"default": "unknown",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := operators.Apply(context.Background(), value.New(3))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("unknown"), res)
+	}
+}
+
+func TestCalculateModifier(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "calculate",
+			"operation":     "/",
+			"value":         10,
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for input, expected := range map[interface{}]string{
+		235:    "23.5",
+		235.5:  "23.55",
+		"-120": "-12",
+	} {
+		res, err := operators.Apply(context.Background(), value.New(input))
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, res.String())
+		}
+	}
+}
+
+func TestCalculateModifier_property(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "calculate",
+			"operation":     "-",
+			"property":      "free",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, operators.UsesGroupValues())
+
+	ctx := NewContextWithGroupValues(context.Background(), map[string]interface{}{
+		"free": value.New("25.5"),
+	})
+	res, err := operators.Apply(ctx, value.New(100))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "74.5", res.String())
+	}
+
+	_, err = operators.Apply(NewContextWithGroupValues(context.Background(), map[string]interface{}{}), value.New(100))
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestCalculateModifier_divisionByZero(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "calculate",
+			"operation":     "/",
+			"value":         "0",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = operators.Apply(context.Background(), value.New(5))
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestHexToMACModifier(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "hexToMAC",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := operators.Apply(context.Background(), value.New("000C29ABCDEF"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("00:0C:29:AB:CD:EF"), res)
+	}
+
+	// hardware addresses that are no mac addresses don't match
+	_, err = operators.Apply(context.Background(), value.New("000C29ABCDEF0001"))
+	assert.True(t, tholaerr.IsDidNotMatchError(err))
+
+	_, err = operators.Apply(context.Background(), value.New(""))
+	assert.True(t, tholaerr.IsDidNotMatchError(err))
+}
+
+func TestHexToASCIIModifier(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "hexToASCII",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := operators.Apply(context.Background(), value.New("46:44:4F:32:31:33:34:30:41:42:43"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("FDO21340ABC"), res)
+	}
+}
+
+func TestTimeStampToDateModifier(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPGet", ctx, sysUpTimeOID).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(sysUpTimeOID, gosnmp.TimeTicks, uint32(1000000))}, nil)
+
+	now := timeNow
+	defer func() { timeNow = now }()
+	timeNow = func() time.Time {
+		return time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "timeStampToDate",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := operators.Apply(ctx, value.New(uint32(640000)))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New("2021-09-01T11:00:00Z"), res)
+	}
+
+	// a timestamp of zero means that the event didn't occur
+	_, err = operators.Apply(ctx, value.New(uint32(0)))
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestTimeStampToDurationModifier(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPGet", ctx, sysUpTimeOID).
+		Return([]network.SNMPResponse{network.NewSNMPResponse(sysUpTimeOID, gosnmp.TimeTicks, uint32(1000000))}, nil)
+
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "timeStampToDuration",
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := operators.Apply(ctx, value.New(uint32(970000)))
+	if assert.NoError(t, err) {
+		assert.Equal(t, value.New(uint64(300)), res)
+	}
+
+	// a timestamp newer than the sysUpTime (e.g. after the sysUpTime wrapped) has no known age
+	_, err = operators.Apply(ctx, value.New(uint32(1000100)))
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestConvertUnitModifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator map[interface{}]interface{}
+		in       value.Value
+		expected value.Value
+	}{
+		{
+			name:     "multiply",
+			operator: map[interface{}]interface{}{"factor": 1000},
+			in:       value.New(1250),
+			expected: value.New("1250000"),
+		},
+		{
+			name:     "divide",
+			operator: map[interface{}]interface{}{"divisor": 10},
+			in:       value.New("235"),
+			expected: value.New("23.5"),
+		},
+		{
+			name:     "offset",
+			operator: map[interface{}]interface{}{"factor": 9, "divisor": 5, "offset": 32},
+			in:       value.New("25"),
+			expected: value.New("77"),
+		},
+		{
+			name:     "precision",
+			operator: map[interface{}]interface{}{"divisor": 3, "precision": 2},
+			in:       value.New(10),
+			expected: value.New("3.33"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.operator["type"] = "modify"
+			test.operator["modify_method"] = "convertUnit"
+			operators, err := InterfaceSlice2Operators([]interface{}{test.operator}, condition.PropertyDefault)
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := operators.Apply(context.Background(), test.in)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected.String(), res.String())
+			}
+		})
+	}
+}
+
+func TestConvertUnitModifier_errors(t *testing.T) {
+	operators, err := InterfaceSlice2Operators([]interface{}{
+		map[interface{}]interface{}{
+			"type":          "modify",
+			"modify_method": "convertUnit",
+			"divisor":       10,
+		},
+	}, condition.PropertyDefault)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = operators.Apply(context.Background(), value.New("n/a"))
+	assert.True(t, tholaerr.IsParseError(err))
+
+	for _, invalid := range []map[interface{}]interface{}{
+		{},
+		{"divisor": 0},
+		{"factor": "ten"},
+		{"factor": 10, "precision": "2"},
+	} {
+		invalid["type"] = "modify"
+		invalid["modify_method"] = "convertUnit"
+		_, err = InterfaceSlice2Operators([]interface{}{invalid}, condition.PropertyDefault)
+		assert.Error(t, err, "%v", invalid)
+	}
+}
+
+func TestRegexCaptureModifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator map[interface{}]interface{}
+		in       string
+		expected string
+	}{
+		{
+			name:     "capture",
+			operator: map[interface{}]interface{}{"regex": `^Cisco (\S+) Series`},
+			in:       "Cisco C9300-48P Series Switch",
+			expected: "C9300-48P",
+		},
+		{
+			name:     "group index",
+			operator: map[interface{}]interface{}{"regex": `^(\S+) (\S+)`, "group": 2},
+			in:       "Cisco C9300-48P Series Switch",
+			expected: "C9300-48P",
+		},
+		{
+			name:     "named group",
+			operator: map[interface{}]interface{}{"regex": `Version (?P<version>[\d.]+)`, "group": "version"},
+			in:       "Linux server 5.4.0, Version 5.4.0.1",
+			expected: "5.4.0.1",
+		},
+		{
+			name:     "no match",
+			operator: map[interface{}]interface{}{"regex": `^Cisco (\S+) Series`},
+			in:       "Juniper MX480",
+			expected: "Juniper MX480",
+		},
+		{
+			name:     "no match fallback",
+			operator: map[interface{}]interface{}{"regex": `^Cisco (\S+) Series`, "fallback": "unknown"},
+			in:       "Juniper MX480",
+			expected: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.operator["type"] = "modify"
+			test.operator["modify_method"] = "regexCapture"
+			operators, err := InterfaceSlice2Operators([]interface{}{test.operator}, condition.PropertyDefault)
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := operators.Apply(context.Background(), value.New(test.in))
+			if assert.NoError(t, err) {
+				assert.Equal(t, value.New(test.expected), res)
+			}
+		})
+	}
+}
+
+func TestRegexCaptureModifier_invalid(t *testing.T) {
+	for _, invalid := range []map[interface{}]interface{}{
+		{},
+		{"regex": `(unclosed`},
+		{"regex": `no group`},
+		{"regex": `(a)(b)`, "group": 3},
+		{"regex": `(?P<a>a)`, "group": "b"},
+	} {
+		invalid["type"] = "modify"
+		invalid["modify_method"] = "regexCapture"
+		_, err := InterfaceSlice2Operators([]interface{}{invalid}, condition.PropertyDefault)
+		assert.Error(t, err, "%v", invalid)
+	}
+}
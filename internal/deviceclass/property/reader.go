@@ -48,6 +48,19 @@ func interface2PReader(i interface{}, task condition.RelatedTask) (Reader, error
 			return nil, errors.Wrap(err, "failed to decode constant reader")
 		}
 		basePropReader.reader = &pr
+	case "http":
+		var pr httpGetReader
+		err := mapstructure.Decode(i, &pr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode http reader")
+		}
+		if pr.URI == "" {
+			return nil, errors.New("uri is missing in http property reader")
+		}
+		if err := network.ValidateJSONPath(pr.JSONPath); err != nil {
+			return nil, errors.Wrap(err, "invalid json_path in http property reader")
+		}
+		basePropReader.reader = &pr
 	case "constant":
 		v, ok := m["value"]
 		if !ok {
@@ -106,6 +119,16 @@ func interface2PReader(i interface{}, task condition.RelatedTask) (Reader, error
 			return nil, errors.Wrap(err, "failed to decode model series Reader")
 		}
 		basePropReader.reader = &pr
+	case "property":
+		var pr identifyPropertyReader
+		err := mapstructure.Decode(i, &pr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode property reader")
+		}
+		if _, err := (&device.Properties{}).GetIdentifyProperty(pr.Property); err != nil {
+			return nil, errors.Wrap(err, "invalid property in property reader")
+		}
+		basePropReader.reader = &pr
 
 	default:
 		return nil, errors.New("invalid detection type " + stringDetection)
@@ -135,17 +158,56 @@ type Reader interface {
 	GetProperty(ctx context.Context) (value.Value, error)
 }
 
+// dependentReader is implemented by readers that read other identify properties.
+type dependentReader interface {
+	dependencies() []string
+}
+
+// Dependencies returns the names of the identify properties the reader reads.
+func Dependencies(r Reader) []string {
+	if d, ok := r.(dependentReader); ok {
+		return d.dependencies()
+	}
+	return nil
+}
+
 type readerSet []Reader
 
 func (p *readerSet) GetProperty(ctx context.Context) (value.Value, error) {
 	log.Ctx(ctx).Debug().Msg("starting with property reader set")
+	var networkErr error
 	for _, reader := range *p {
 		property, err := reader.GetProperty(ctx)
 		if err == nil {
 			return property, nil
 		}
+		if networkErr == nil {
+			networkErr = NetworkError(err)
+		}
+	}
+	return nil, readerSetError{
+		NotFoundError: tholaerr.NewNotFoundError("failed to read out property").(tholaerr.NotFoundError),
+		networkErr:    networkErr,
+	}
+}
+
+// readerSetError is returned if no reader of a reader set could read out the property. It is a NotFoundError, but
+// keeps the network error of a failed reader, so that a missing property can be told apart from an unreachable device.
+type readerSetError struct {
+	tholaerr.NotFoundError
+	networkErr error
+}
+
+// NetworkError returns the network error which caused the reader error, or nil if the property was not read out
+// because of other reasons, e.g. because it does not exist on the device.
+func NetworkError(err error) error {
+	if tholaerr.IsNetworkError(err) {
+		return err
+	}
+	if e, ok := errors.Cause(err).(readerSetError); ok {
+		return e.networkErr
 	}
-	return nil, tholaerr.NewNotFoundError("failed to read out property")
+	return nil
 }
 
 type baseReader struct {
@@ -154,6 +216,14 @@ type baseReader struct {
 	preCondition condition.Condition
 }
 
+func (p *readerSet) dependencies() []string {
+	var res []string
+	for _, reader := range *p {
+		res = append(res, Dependencies(reader)...)
+	}
+	return res
+}
+
 func (b *baseReader) GetProperty(ctx context.Context) (value.Value, error) {
 	if b.preCondition != nil {
 		conditionsMatched, err := b.preCondition.Check(ctx)
@@ -179,6 +249,10 @@ func (b *baseReader) GetProperty(ctx context.Context) (value.Value, error) {
 	return v, nil
 }
 
+func (b *baseReader) dependencies() []string {
+	return Dependencies(b.reader)
+}
+
 func (b *baseReader) applyOperators(ctx context.Context, v value.Value) (value.Value, error) {
 	return b.operators.Apply(ctx, v)
 }
@@ -238,23 +312,43 @@ func (s *snmpGetReader) GetProperty(ctx context.Context) (value.Value, error) {
 	if !ok || con.SNMP == nil || con.SNMP.SnmpClient == nil {
 		return nil, errors.New("No SNMP Data available!")
 	}
-	result, err := con.SNMP.SnmpClient.SNMPGet(ctx, s.OID)
+	result, err := con.SNMP.SnmpClient.SNMPGet(s.NewContext(ctx), s.OID)
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "snmpget").Msg("snmpget on oid " + s.OID.String() + " failed")
 		return nil, errors.Wrap(err, "snmpget failed")
 	}
 
-	var val interface{}
-	if s.UseRawResult {
-		val, err = result[0].GetValueRaw()
-	} else {
-		val, err = result[0].GetValue()
-	}
+	val, err := result[0].GetValueBySNMPGetConfiguration(s.SNMPGetConfiguration)
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "snmpget").Msg("snmpget failed")
 		return nil, err
 	}
 	log.Ctx(ctx).Debug().Str("property_reader", "snmpget").Msg("snmpget successful")
+	return val, nil
+}
+
+// httpGetReader reads a value of the JSON response of a http(s) request.
+type httpGetReader struct {
+	URI      string
+	JSONPath string `mapstructure:"json_path"`
+}
+
+func (h *httpGetReader) GetProperty(ctx context.Context) (value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.HTTP == nil {
+		return nil, errors.New("no http connection available")
+	}
+	body, err := con.HTTP.GetJSON(ctx, h.URI)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "http").Msg("http request on uri " + h.URI + " failed")
+		return nil, errors.Wrap(err, "http request failed")
+	}
+	val, err := network.JSONPathValue(body, h.JSONPath)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "http").Msg("json path " + h.JSONPath + " is not part of the response")
+		return nil, err
+	}
+	log.Ctx(ctx).Debug().Str("property_reader", "http").Msg("http request successful")
 	return value.New(val), nil
 }
 
@@ -273,6 +367,10 @@ func (v *vendorReader) GetProperty(ctx context.Context) (value.Value, error) {
 	return value.New(*properties.Properties.Vendor), nil
 }
 
+func (v *vendorReader) dependencies() []string {
+	return []string{"vendor"}
+}
+
 type modelReader struct{}
 
 func (m *modelReader) GetProperty(ctx context.Context) (value.Value, error) {
@@ -288,6 +386,10 @@ func (m *modelReader) GetProperty(ctx context.Context) (value.Value, error) {
 	return value.New(*properties.Properties.Model), nil
 }
 
+func (m *modelReader) dependencies() []string {
+	return []string{"model"}
+}
+
 type modelSeriesReader struct{}
 
 func (m *modelSeriesReader) GetProperty(ctx context.Context) (value.Value, error) {
@@ -302,3 +404,34 @@ func (m *modelSeriesReader) GetProperty(ctx context.Context) (value.Value, error
 	}
 	return value.New(*properties.Properties.ModelSeries), nil
 }
+
+func (m *modelSeriesReader) dependencies() []string {
+	return []string{"model_series"}
+}
+
+// identifyPropertyReader reads an identify property which has already been determined, so that operators can be
+// applied to it instead of reading it again from the device.
+type identifyPropertyReader struct {
+	Property string `mapstructure:"property"`
+}
+
+func (p *identifyPropertyReader) GetProperty(ctx context.Context) (value.Value, error) {
+	properties, ok := device.DevicePropertiesFromContext(ctx)
+	if !ok {
+		return nil, errors.New("no properties found in context")
+	}
+
+	prop, err := properties.Properties.GetIdentifyProperty(p.Property)
+	if err != nil {
+		return nil, err
+	}
+	if prop == nil {
+		log.Ctx(ctx).Debug().Str("property_reader", "property").Msg(p.Property + " has not yet been determined")
+		return nil, tholaerr.NewPreConditionError(p.Property + " has not yet been determined")
+	}
+	return value.New(*prop), nil
+}
+
+func (p *identifyPropertyReader) dependencies() []string {
+	return []string{p.Property}
+}
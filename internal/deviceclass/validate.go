@@ -0,0 +1,528 @@
+package deviceclass
+
+import (
+	"fmt"
+	"github.com/inexio/thola/config"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError is returned if device class files are invalid. It contains all problems that were found, so that
+// they can be fixed at once.
+type ValidationError struct {
+	Problems []*FileError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("device classes are invalid (problems: %d)", len(e.Problems)))
+	for _, problem := range e.Problems {
+		b.WriteString("\n" + problem.Error())
+	}
+	return b.String()
+}
+
+// Validate validates the embedded device classes.
+func Validate() error {
+	return ValidateFS(config.FileSystem, "deviceclass")
+}
+
+// ValidateFS validates the device classes in the given directory of the file system without creating communicators
+// for them. Referenced operators, oids, components and condition types are checked, as well as the inheritance of the
+// device classes. If the device classes are invalid, a *ValidationError with all problems is returned.
+func ValidateFS(fsys fs.FS, genericDeviceClassDir string) error {
+	v := validator{fsys: fsys}
+	v.validateDeviceClassFile(filepath.Join(genericDeviceClassDir, "generic.yaml"), nil, "")
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// validator collects the problems of the device class files.
+type validator struct {
+	fsys     fs.FS
+	problems []*FileError
+}
+
+// validateDeviceClassFile validates a device class file and the device classes in its sub directory. The device class
+// is also converted with its parent, so that problems which only occur together with the parent are found. parent is
+// nil for the generic device class, which has no parent name, and for device classes whose parent is invalid. The
+// name of the device class is returned, it is empty if the file couldn't be read in.
+func (v *validator) validateDeviceClassFile(file string, parent *deviceClass, parentName string) string {
+	fv := fileValidator{validator: v, file: file}
+	contents, err := fs.ReadFile(v.fsys, file)
+	if err != nil {
+		fv.report("", errors.Wrap(err, "failed to read file"))
+		return ""
+	}
+
+	var deviceClassYaml yamlDeviceClass
+	if err = yaml.Unmarshal(contents, &deviceClassYaml); err != nil {
+		fv.reportYAMLError(err)
+		return ""
+	}
+	var raw map[interface{}]interface{}
+	if err = yaml.Unmarshal(contents, &raw); err != nil {
+		fv.reportYAMLError(err)
+		return ""
+	}
+
+	problems := len(v.problems)
+	fv.validateDeviceClass(raw)
+
+	generic := parentName == ""
+	var devClass *deviceClass
+	if len(v.problems) == problems && (generic || parent != nil) {
+		d, err := deviceClassYaml.convert(parent)
+		if err != nil {
+			fv.report("", err)
+		} else {
+			devClass = &d
+		}
+	}
+
+	name := deviceClassYaml.Name
+	if name == "" || strings.Contains(name, "/") {
+		return ""
+	}
+
+	// the full name of the device class is built the same way as during the conversion
+	fullName := name
+	if !generic && parentName != "generic" {
+		fullName = parentName + "/" + name
+	}
+	v.validateDirectory(filepath.Join(filepath.Dir(file), fullName), devClass, fullName)
+	return name
+}
+
+// validateDirectory validates the sub device classes in the directory of a device class.
+func (v *validator) validateDirectory(dir string, parent *deviceClass, parentName string) {
+	entries, err := fs.ReadDir(v.fsys, dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			v.problems = append(v.problems, &FileError{File: dir, Message: errors.Wrap(err, "failed to read sub device class directory").Error()})
+		}
+		return
+	}
+
+	deviceClassFiles := make(map[string]string)
+	subDirectories := make(map[string]bool)
+	var directories []string
+	for _, entry := range entries {
+		file := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			directories = append(directories, entry.Name())
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), ".yaml") {
+			v.problems = append(v.problems, &FileError{File: file, Message: "only yaml config files are allowed in device class directories"})
+			continue
+		}
+
+		name := v.validateDeviceClassFile(file, parent, parentName)
+		if name == "" {
+			continue
+		}
+		if other, ok := deviceClassFiles[name]; ok {
+			v.problems = append(v.problems, &FileError{File: file, Path: "name", Message: fmt.Sprintf("device class '%s' is already defined in %s", name, other)})
+			continue
+		}
+		deviceClassFiles[name] = file
+
+		subDirectory := name
+		if parentName != "generic" {
+			subDirectory = parentName + "/" + name
+		}
+		subDirectories[subDirectory] = true
+	}
+
+	for _, directory := range directories {
+		if !subDirectories[directory] {
+			v.problems = append(v.problems, &FileError{
+				File:    filepath.Join(dir, directory),
+				Message: fmt.Sprintf("there is no device class '%s' in %s that the directory belongs to, its device classes are never read in", directory, dir),
+			})
+		}
+	}
+}
+
+// fileValidator validates the contents of a single device class file.
+type fileValidator struct {
+	*validator
+	file string
+}
+
+func (v *fileValidator) report(path string, err error) {
+	v.problems = append(v.problems, &FileError{File: v.file, Path: path, Message: err.Error()})
+}
+
+func (v *fileValidator) reportf(path, format string, args ...interface{}) {
+	v.report(path, fmt.Errorf(format, args...))
+}
+
+// reportYAMLError reports an error of the yaml parser. Type errors contain a message per wrong value, which are reported
+// separately with their line.
+func (v *fileValidator) reportYAMLError(err error) {
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		for _, msg := range typeErr.Errors {
+			v.problems = append(v.problems, newFileError(v.file, errors.New(msg)))
+		}
+		return
+	}
+	v.problems = append(v.problems, newFileError(v.file, err))
+}
+
+func (v *fileValidator) validateDeviceClass(m map[interface{}]interface{}) {
+	v.validateKeys("", m, reflect.TypeOf(yamlDeviceClass{}), "key")
+
+	name, _ := m["name"].(string)
+	if name == "" {
+		v.reportf("name", "device class name is empty")
+	} else if strings.Contains(name, "/") {
+		v.reportf("name", "device class name cannot contain '/'")
+	}
+
+	if match, ok := m["match"]; ok && match != nil {
+		v.validateCondition("match", match, condition.ClassifyDevice)
+	} else if name != "generic" {
+		v.reportf("match", "device class conditions are missing")
+	}
+
+	if identify, ok := m["identify"].(map[interface{}]interface{}); ok {
+		v.validateKeys("identify", identify, reflect.TypeOf(yamlDeviceClassIdentify{}), "key")
+		if properties, ok := identify["properties"].(map[interface{}]interface{}); ok {
+			v.validateIdentifyProperties("identify.properties", properties)
+		}
+	}
+
+	if cfg, ok := m["config"].(map[interface{}]interface{}); ok {
+		v.validateConfig("config", cfg)
+	}
+
+	if components, ok := m["components"].(map[interface{}]interface{}); ok {
+		v.validateComponents("components", components, reflect.TypeOf(yamlDeviceClassComponents{}), "component")
+	}
+}
+
+// identifyPropertyTasks contains the related tasks of the identify properties that can't use all conditions and
+// property readers, as they are read before the properties they would need.
+var identifyPropertyTasks = map[string]condition.RelatedTask{
+	"vendor":       condition.PropertyVendor,
+	"model":        condition.PropertyModel,
+	"model_series": condition.PropertyModelSeries,
+}
+
+func (v *fileValidator) validateIdentifyProperties(path string, properties map[interface{}]interface{}) {
+	v.validateKeys(path, properties, reflect.TypeOf(yamlDeviceClassIdentifyProperties{}), "identify property")
+
+	for _, key := range sortedKeys(properties) {
+		propertyPath := joinPath(path, key)
+		if key == "model_series_from_model" {
+			rules, _ := properties[key].([]interface{})
+			for i, rule := range rules {
+				ruleMap, _ := rule.(map[interface{}]interface{})
+				if regex, ok := ruleMap["regex"].(string); ok {
+					if _, err := regexp.Compile(regex); err != nil {
+						v.report(fmt.Sprintf("%s[%d].regex", propertyPath, i), errors.Wrap(err, "invalid regex"))
+					}
+				}
+			}
+			continue
+		}
+
+		task, ok := identifyPropertyTasks[key]
+		if !ok {
+			task = condition.PropertyDefault
+		}
+		v.validatePropertyReader(propertyPath, properties[key], task)
+	}
+}
+
+func (v *fileValidator) validateConfig(path string, cfg map[interface{}]interface{}) {
+	v.validateKeys(path, cfg, reflect.TypeOf(yamlDeviceClassConfig{}), "key")
+
+	if snmp, ok := cfg["snmp"].(map[interface{}]interface{}); ok {
+		snmpPath := joinPath(path, "snmp")
+		v.validateKeys(snmpPath, snmp, reflect.TypeOf(deviceClassSNMP{}), "key")
+		if maxOids, ok := snmp["max_oids"].(int); ok && maxOids < 0 {
+			v.reportf(joinPath(snmpPath, "max_oids"), "invalid snmp max oids")
+		}
+		if walkMode, ok := snmp["walk_mode"].(string); ok {
+			if err := network.ValidateSNMPWalkMode(walkMode); err != nil {
+				v.report(joinPath(snmpPath, "walk_mode"), err)
+			}
+		}
+	}
+
+	if components, ok := cfg["components"].(map[interface{}]interface{}); ok {
+		for _, key := range sortedKeys(components) {
+			if _, err := component.CreateComponent(key); err != nil {
+				v.report(joinPath(path, "components."+key), err)
+			}
+		}
+	}
+}
+
+// validateComponents validates the keys of a yaml map against the fields of the yaml struct it is read into. Fields
+// which are lists are property readers, fields of an arbitrary type are group property readers.
+func (v *fileValidator) validateComponents(path string, m map[interface{}]interface{}, t reflect.Type, kind string) {
+	fields := v.validateKeys(path, m, t, kind)
+
+	for _, key := range sortedKeys(m) {
+		fieldType, ok := fields[key]
+		if !ok || m[key] == nil {
+			continue
+		}
+		keyPath := joinPath(path, key)
+
+		switch {
+		case fieldType == reflect.TypeOf([]interface{}{}):
+			v.validatePropertyReader(keyPath, m[key], condition.PropertyDefault)
+		case fieldType.Kind() == reflect.Interface:
+			v.validateGroupPropertyReader(keyPath, m[key])
+		case fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct:
+			if sub, ok := m[key].(map[interface{}]interface{}); ok {
+				v.validateComponents(keyPath, sub, fieldType.Elem(), "key")
+			}
+		}
+	}
+}
+
+// validateKeys reports all keys of the yaml map which are no field of the yaml struct it is read into. The yaml names
+// of the fields are returned with their type.
+func (v *fileValidator) validateKeys(path string, m map[interface{}]interface{}, t reflect.Type, kind string) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+
+	for _, key := range sortedKeys(m) {
+		if _, ok := fields[key]; !ok {
+			v.reportf(joinPath(path, key), "unknown %s '%s'", kind, key)
+		}
+	}
+	return fields
+}
+
+// validateCondition validates a condition. Condition sets are validated condition by condition, so that the path of
+// the invalid condition is reported.
+func (v *fileValidator) validateCondition(path string, i interface{}, task condition.RelatedTask) {
+	m, ok := i.(map[interface{}]interface{})
+	if !ok {
+		v.reportf(path, "condition needs to be a map")
+		return
+	}
+
+	if _, ok := m["type"]; !ok {
+		if conditions, ok := m["conditions"]; ok {
+			logicalOperator, hasLogicalOperator := m["logical_operator"]
+			if len(m) > 2 || (len(m) == 2 && !hasLogicalOperator) {
+				v.reportf(path, "no condition type set and attributes do not match conditionSet")
+				return
+			}
+			if !hasLogicalOperator {
+				v.reportf(joinPath(path, "logical_operator"), "logical operator is missing")
+			} else if logicalOperator != "AND" && logicalOperator != "OR" {
+				v.reportf(joinPath(path, "logical_operator"), "unknown logical operator '%v'", logicalOperator)
+			}
+
+			conditionList, ok := conditions.([]interface{})
+			if !ok || len(conditionList) == 0 {
+				v.reportf(joinPath(path, "conditions"), "conditions need to be a non-empty list")
+				return
+			}
+			// conditions of condition sets are always converted for the device classification
+			for k, c := range conditionList {
+				v.validateCondition(fmt.Sprintf("%s.conditions[%d]", path, k), c, condition.ClassifyDevice)
+			}
+			return
+		}
+	}
+
+	if _, err := condition.Interface2Condition(i, task); err != nil {
+		v.report(path, err)
+	}
+}
+
+// validatePropertyReader validates the readers of a property. The oids, operators and pre conditions are validated on
+// their own, so that their path is reported. If they are valid, the reader is converted to find the remaining problems.
+func (v *fileValidator) validatePropertyReader(path string, i interface{}, task condition.RelatedTask) {
+	readers, ok := i.([]interface{})
+	if !ok {
+		v.reportf(path, "property readers need to be a list")
+		return
+	}
+
+	for k, reader := range readers {
+		readerPath := fmt.Sprintf("%s[%d]", path, k)
+		problems := len(v.problems)
+
+		m, ok := reader.(map[interface{}]interface{})
+		if !ok {
+			v.reportf(readerPath, "property reader needs to be a map")
+			continue
+		}
+		if detection, _ := m["detection"].(string); detection == "snmpget" {
+			v.validateOID(joinPath(readerPath, "oid"), m["oid"])
+		}
+		if operators, ok := m["operators"]; ok {
+			v.validateOperators(joinPath(readerPath, "operators"), operators, task)
+		}
+		if preCondition, ok := m["pre_condition"]; ok {
+			v.validateCondition(joinPath(readerPath, "pre_condition"), preCondition, task)
+		}
+
+		if len(v.problems) == problems {
+			if _, err := property.InterfaceSlice2Reader([]interface{}{reader}, task, nil); err != nil {
+				v.report(readerPath, err)
+			}
+		}
+	}
+}
+
+// validateGroupPropertyReader validates a group property reader. The oids and operators of the values are validated
+// on their own, so that their path is reported. If they are valid, the reader is converted to find the remaining
+// problems.
+func (v *fileValidator) validateGroupPropertyReader(path string, i interface{}) {
+	m, ok := i.(map[interface{}]interface{})
+	if !ok {
+		v.reportf(path, "group property reader needs to be a map")
+		return
+	}
+	problems := len(v.problems)
+
+	if m["detection"] == "http" {
+		if values, ok := m["values"].(map[interface{}]interface{}); ok {
+			v.validateHTTPGroupPropertyValues(joinPath(path, "values"), values)
+		}
+	} else {
+		if index, ok := m["index"]; ok {
+			v.validateOID(joinPath(path, "index"), index)
+		}
+		if rowFilter, ok := m["row_filter"].(map[interface{}]interface{}); ok {
+			v.validateGroupPropertyValue(joinPath(path, "row_filter"), rowFilter)
+		}
+		if values, ok := m["values"].(map[interface{}]interface{}); ok {
+			v.validateGroupPropertyValues(joinPath(path, "values"), values)
+		}
+	}
+
+	if len(v.problems) == problems {
+		if _, err := groupproperty.Interface2Reader(i, nil); err != nil {
+			v.report(path, err)
+		}
+	}
+}
+
+func (v *fileValidator) validateGroupPropertyValues(path string, values map[interface{}]interface{}) {
+	for _, key := range sortedKeys(values) {
+		valuePath := joinPath(path, key)
+		data, ok := values[key].(map[interface{}]interface{})
+		if !ok {
+			v.reportf(valuePath, "value data needs to be a map")
+			continue
+		}
+
+		if indicesMapping, ok := data["indices_mapping"].(map[interface{}]interface{}); ok {
+			v.validateGroupPropertyValue(joinPath(valuePath, "indices_mapping"), indicesMapping)
+		}
+		if subValues, ok := data["values"]; ok {
+			if subValuesMap, ok := subValues.(map[interface{}]interface{}); ok {
+				v.validateGroupPropertyValues(joinPath(valuePath, "values"), subValuesMap)
+			} else {
+				v.reportf(joinPath(valuePath, "values"), "values needs to be a map")
+			}
+			continue
+		}
+		if ignore, _ := data["ignore"].(bool); ignore {
+			continue
+		}
+		v.validateGroupPropertyValue(valuePath, data)
+	}
+}
+
+func (v *fileValidator) validateGroupPropertyValue(path string, data map[interface{}]interface{}) {
+	v.validateOID(joinPath(path, "oid"), data["oid"])
+	if operators, ok := data["operators"]; ok {
+		v.validateOperators(joinPath(path, "operators"), operators, condition.PropertyDefault)
+	}
+}
+
+// validateHTTPGroupPropertyValues validates the operators of the values of an http group property reader, the json
+// paths are validated when the reader is converted.
+func (v *fileValidator) validateHTTPGroupPropertyValues(path string, values map[interface{}]interface{}) {
+	for _, key := range sortedKeys(values) {
+		valuePath := joinPath(path, key)
+		data, ok := values[key].(map[interface{}]interface{})
+		if !ok {
+			v.reportf(valuePath, "value data needs to be a map")
+			continue
+		}
+		if subValues, ok := data["values"].(map[interface{}]interface{}); ok {
+			v.validateHTTPGroupPropertyValues(joinPath(valuePath, "values"), subValues)
+			continue
+		}
+		if operators, ok := data["operators"]; ok {
+			v.validateOperators(joinPath(valuePath, "operators"), operators, condition.PropertyDefault)
+		}
+	}
+}
+
+// validateOperators validates the operators one by one, so that the path of the invalid operator is reported.
+func (v *fileValidator) validateOperators(path string, i interface{}, task condition.RelatedTask) {
+	operators, ok := i.([]interface{})
+	if !ok {
+		v.reportf(path, "operators has to be an array")
+		return
+	}
+	for k, operator := range operators {
+		if _, err := property.InterfaceSlice2Operators([]interface{}{operator}, task); err != nil {
+			v.report(fmt.Sprintf("%s[%d]", path, k), err)
+		}
+	}
+}
+
+func (v *fileValidator) validateOID(path string, i interface{}) {
+	if i == nil {
+		v.reportf(path, "oid is missing")
+		return
+	}
+	oid, ok := i.(string)
+	if !ok || network.OID(oid).Validate() != nil {
+		v.reportf(path, "invalid oid '%v'", i)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// sortedKeys returns the keys of a yaml map in sorted order, so that problems are always reported in the same order.
+func sortedKeys(m map[interface{}]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, fmt.Sprint(k))
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,100 @@
+package deviceclass
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate())
+}
+
+func TestValidateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"generic.yaml": {Data: []byte("name: generic\n")},
+		"generic/test.yaml": {Data: []byte(`name: test
+
+match:
+  logical_operator: AND
+  conditions:
+    - type: SysDescription
+      match_mode: startsWith
+      values:
+        - Test
+    - type: SysObjectIDD
+      match_mode: startsWith
+      values:
+        - .1.3.6.1.4.1.9.
+
+identify:
+  properties:
+    vendor:
+      - detection: constant
+        value: Test
+        operators:
+          - type: modify
+            modify_method: toUpperCas
+
+config:
+  components:
+    cpu: true
+    gpu: true
+
+components:
+  cpu:
+    properties:
+      detection: snmpwalk
+      values:
+        load:
+          oid: 1.3.6..1
+  fan:
+    speed: 1
+`)},
+		"generic/orphan/sub.yaml": {Data: []byte("name: sub\n")},
+	}
+
+	err := ValidateFS(fsys, ".")
+	validationErr, ok := errors.Cause(err).(*ValidationError)
+	if !assert.True(t, ok, "error is no validation error") {
+		return
+	}
+
+	var locations []string
+	for _, problem := range validationErr.Problems {
+		locations = append(locations, problem.File+": "+problem.Path)
+	}
+	assert.Equal(t, []string{
+		"generic/test.yaml: match.conditions[1]",
+		"generic/test.yaml: identify.properties.vendor[0].operators[0]",
+		"generic/test.yaml: config.components.gpu",
+		"generic/test.yaml: components.fan",
+		"generic/test.yaml: components.cpu.properties.values.load.oid",
+		"generic/orphan: ",
+	}, locations)
+
+	assert.Contains(t, validationErr.Problems[0].Message, "invalid condition type 'SysObjectIDD'")
+	assert.Equal(t, "generic/test.yaml: components.fan: unknown component 'fan'", validationErr.Problems[3].Error())
+	assert.Equal(t, "generic/test.yaml: components.cpu.properties.values.load.oid: invalid oid '1.3.6..1'", validationErr.Problems[4].Error())
+
+	// sub device classes belong to the device class with the name of their directory
+	fsys = fstest.MapFS{
+		"generic.yaml": {Data: []byte("name: generic\n")},
+		"generic/test.yaml": {Data: []byte(`name: test
+match:
+  type: SysDescription
+  match_mode: startsWith
+  values:
+    - Test
+`)},
+		"generic/test/sub.yaml": {Data: []byte(`name: sub
+match:
+  type: SysDescription
+  match_mode: startsWith
+  values:
+    - Test Sub
+`)},
+	}
+	assert.NoError(t, ValidateFS(fsys, "."))
+}
@@ -0,0 +1,90 @@
+package deviceclass
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+func (o *deviceClassCommunicator) GetWLANComponent(ctx context.Context) (device.WLANComponent, error) {
+	if !o.HasComponent(component.WLAN) {
+		return device.WLANComponent{}, tholaerr.NewComponentNotFoundError("no wlan component available for this device")
+	}
+
+	var wlan device.WLANComponent
+
+	empty := true
+
+	accessPoints, err := o.GetWLANComponentAccessPoints(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.WLANComponent{}, errors.Wrap(err, "error occurred during get wlan component access points")
+		}
+	} else {
+		wlan.AccessPoints = accessPoints
+		empty = false
+	}
+
+	clientCount, err := o.GetWLANComponentClientCount(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.WLANComponent{}, errors.Wrap(err, "error occurred during get wlan component client count")
+		}
+	} else {
+		wlan.ClientCount = &clientCount
+		empty = false
+	}
+
+	if empty {
+		return device.WLANComponent{}, tholaerr.NewNotFoundError("no wlan data available")
+	}
+
+	return wlan, nil
+}
+
+func (o *deviceClassCommunicator) GetWLANComponentAccessPoints(ctx context.Context) ([]device.AccessPoint, error) {
+	if o.components.wlan == nil || o.components.wlan.accessPoints == nil {
+		log.Ctx(ctx).Debug().Str("property", "WLANComponentAccessPoints").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "WLANComponentAccessPoints").Logger()
+	ctx = logger.WithContext(ctx)
+
+	res, _, err := o.components.wlan.accessPoints.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+
+	var accessPoints []device.AccessPoint
+	err = mapstructure.WeakDecode(res, &accessPoints)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into access point struct")
+	}
+	if len(accessPoints) == 0 {
+		return nil, tholaerr.NewNotFoundError("no access points available")
+	}
+	return accessPoints, nil
+}
+
+func (o *deviceClassCommunicator) GetWLANComponentClientCount(ctx context.Context) (int, error) {
+	if o.components.wlan == nil || o.components.wlan.clientCount == nil {
+		log.Ctx(ctx).Debug().Str("property", "WLANComponentClientCount").Str("device_class", o.name).Msg("no detection information available")
+		return 0, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("property", "WLANComponentClientCount").Logger()
+	ctx = logger.WithContext(ctx)
+	res, err := o.components.wlan.clientCount.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get property")
+		return 0, errors.Wrap(err, "failed to get WLANComponentClientCount")
+	}
+	result, err := res.Int()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to convert result '%v' to int", res)
+	}
+	return result, nil
+}
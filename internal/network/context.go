@@ -1,12 +1,22 @@
 package network
 
-import "context"
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
 
 type ctxKey byte
 
 const (
 	requestDeviceConnectionKey ctxKey = iota + 1
 	snmpGetsInsteadOfWalk
+	snmpWalkCacheKey
+	snmpWalkCacheBypassKey
+	snmpTraceKey
+	snmpOperationSettingsKey
+	snmpWalkErrorCacheBypassKey
 )
 
 // NewContextWithDeviceConnection returns a new context with the device connection
@@ -20,6 +30,38 @@ func DeviceConnectionFromContext(ctx context.Context) (*RequestDeviceConnection,
 	return con, ok
 }
 
+// NewContextWithSNMPSession returns a context with a new snmp session to the device of the connection of the context
+// and a function that closes the session, so that requests can be sent in parallel to the requests of the given
+// context. If the client of the connection can't open new sessions, the context is returned as it is and the returned
+// function is nil.
+func NewContextWithSNMPSession(ctx context.Context) (context.Context, func(), error) {
+	con, ok := DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil || con.SNMP.SnmpClient == nil {
+		return nil, nil, errors.New("no snmp connection available")
+	}
+	session, err := NewSNMPSession(con.SNMP.SnmpClient)
+	if err != nil {
+		if tholaerr.IsNotImplementedError(err) {
+			return ctx, nil, nil
+		}
+		return nil, nil, errors.Wrap(err, "failed to open snmp session")
+	}
+	if session == con.SNMP.SnmpClient {
+		// the client is safe for concurrent use
+		return ctx, func() {}, nil
+	}
+
+	sessionCon := *con
+	snmp := *con.SNMP
+	snmp.SnmpClient = session
+	sessionCon.SNMP = &snmp
+	return NewContextWithDeviceConnection(ctx, &sessionCon), func() {
+		if err := session.Disconnect(); err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to close snmp session")
+		}
+	}, nil
+}
+
 // NewContextWithSNMPGetsInsteadOfWalk returns a new context with the request
 func NewContextWithSNMPGetsInsteadOfWalk(ctx context.Context, b bool) context.Context {
 	return context.WithValue(ctx, snmpGetsInsteadOfWalk, b)
@@ -30,3 +72,33 @@ func SNMPGetsInsteadOfWalkFromContext(ctx context.Context) (bool, bool) {
 	con, ok := ctx.Value(snmpGetsInsteadOfWalk).(bool)
 	return con, ok
 }
+
+// NewContextWithSNMPWalkErrorCacheBypass returns a new context whose snmp walks ignore walk errors that are cached
+// by the snmp client, so that failed walks can be retried.
+func NewContextWithSNMPWalkErrorCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, snmpWalkErrorCacheBypassKey, true)
+}
+
+func snmpWalkErrorCacheBypassFromContext(ctx context.Context) bool {
+	bypass, ok := ctx.Value(snmpWalkErrorCacheBypassKey).(bool)
+	return ok && bypass
+}
+
+// SNMPOperationSettings overrides the timeout and retries of the snmp session for single snmp operations.
+type SNMPOperationSettings struct {
+	// Timeout in seconds
+	Timeout *int
+	Retries *int
+}
+
+// NewContextWithSNMPOperationSettings returns a new context with timeout and retries overrides for the snmp operations
+// that are sent with it.
+func NewContextWithSNMPOperationSettings(ctx context.Context, settings SNMPOperationSettings) context.Context {
+	return context.WithValue(ctx, snmpOperationSettingsKey, settings)
+}
+
+// SNMPOperationSettingsFromContext gets the snmp operation settings from the context
+func SNMPOperationSettingsFromContext(ctx context.Context) (SNMPOperationSettings, bool) {
+	settings, ok := ctx.Value(snmpOperationSettingsKey).(SNMPOperationSettings)
+	return settings, ok
+}
@@ -30,6 +30,8 @@ type HTTPClient struct {
 	username string
 	password string
 
+	token string
+
 	useCache bool
 
 	cache requestCache
@@ -80,6 +82,15 @@ func (h *HTTPClient) SetUsernameAndPassword(username, password string) error {
 	return nil
 }
 
+// SetAuthToken sets a token which is sent as bearer authorization. It takes precedence over username and password.
+func (h *HTTPClient) SetAuthToken(token string) error {
+	if token == "" {
+		return errors.New("invalid token")
+	}
+	h.token = token
+	return nil
+}
+
 // UseHTTPS turns on HTTPS.
 func (h *HTTPClient) UseHTTPS(useHTTPS bool) {
 	h.useHTTPS = useHTTPS
@@ -130,8 +141,24 @@ func (h *HTTPClient) HasSuccessfulCachedRequest() bool {
 
 // Request sends an http request.
 func (h *HTTPClient) Request(ctx context.Context, method, path, body string, header, queryParams map[string]string) (*resty.Response, error) {
+	return h.request(ctx, h.useHTTPS, h.port, method, path, body, header, queryParams)
+}
+
+// RequestPort sends an http request to the given port, with HTTPS or plain HTTP. Unlike SetPort and UseHTTPS it doesn't
+// change the client, so requests to different ports can be sent concurrently.
+func (h *HTTPClient) RequestPort(ctx context.Context, useHTTPS bool, port int, method, path, body string, header, queryParams map[string]string) (*resty.Response, error) {
+	return h.request(ctx, useHTTPS, &port, method, path, body, header, queryParams)
+}
+
+// HasCredentials returns whether the client sends a token or username and password.
+func (h *HTTPClient) HasCredentials() bool {
+	return h.token != "" || h.useAuth
+}
+
+func (h *HTTPClient) request(ctx context.Context, useHTTPS bool, port *int, method, path, body string, header, queryParams map[string]string) (*resty.Response, error) {
+	cacheKey := getRequestCacheKey(useHTTPS, port, path)
 	if h.useCache && method == http.MethodGet {
-		x, err := h.cache.get(h.getRequestCacheKey(path))
+		x, err := h.cache.get(cacheKey)
 		if err == nil {
 			res, ok := x.res.(*resty.Response)
 			if !ok {
@@ -157,19 +184,17 @@ func (h *HTTPClient) Request(ctx context.Context, method, path, body string, hea
 		request.SetBody(body)
 	}
 
-	if h.useAuth {
+	if h.token != "" {
+		request.SetAuthToken(h.token)
+	} else if h.useAuth {
 		request.SetBasicAuth(h.username, h.password)
 	}
 
 	var response *resty.Response
 
-	URLStr := "https"
-	if !h.useHTTPS {
-		URLStr = "http"
-	}
-	URLStr += "://" + h.host
-	if h.port != nil {
-		URLStr += ":" + strconv.Itoa(*h.port)
+	URLStr := protocolString(useHTTPS) + "://" + h.host
+	if port != nil {
+		URLStr += ":" + strconv.Itoa(*port)
 	}
 	URLStr += "/"
 	URL, err := url.Parse(URLStr)
@@ -192,7 +217,7 @@ func (h *HTTPClient) Request(ctx context.Context, method, path, body string, hea
 	}
 	// save cache
 	if h.useCache && method == http.MethodGet {
-		h.cache.add(h.getRequestCacheKey(path), response, err)
+		h.cache.add(cacheKey, response, err)
 	}
 	if err != nil {
 		return nil, tholaerr.NewHTTPError(err.Error())
@@ -200,13 +225,20 @@ func (h *HTTPClient) Request(ctx context.Context, method, path, body string, hea
 	return response, nil
 }
 
-func (h *HTTPClient) getRequestCacheKey(path string) string {
-	return fmt.Sprintf("%s:%d:%s", h.GetProtocolString(), h.port, path)
+func getRequestCacheKey(useHTTPS bool, port *int, path string) string {
+	if port == nil {
+		return fmt.Sprintf("%s::%s", protocolString(useHTTPS), path)
+	}
+	return fmt.Sprintf("%s:%d:%s", protocolString(useHTTPS), *port, path)
 }
 
 // GetProtocolString returns the protocol as a string.
 func (h *HTTPClient) GetProtocolString() string {
-	if h.useHTTPS {
+	return protocolString(h.useHTTPS)
+}
+
+func protocolString(useHTTPS bool) string {
+	if useHTTPS {
 		return "https"
 	}
 	return "http"
@@ -0,0 +1,105 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/utility"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetJSON sends a GET request for the path to the device and returns the decoded JSON body. The HTTPS ports of the
+// connection data are tried before the HTTP ports, the first port that responds is used. If the client sends
+// credentials, only HTTPS is used, so that they aren't sent in cleartext. Numbers are decoded as json.Number so that
+// large counters keep their precision.
+// The port is passed with every request instead of being set on the client, as the client is shared by concurrent
+// readers of the connection.
+func (r *RequestDeviceConnectionHTTP) GetJSON(ctx context.Context, path string) (interface{}, error) {
+	protocols := []bool{true, false}
+	if r.HTTPClient.HasCredentials() {
+		protocols = []bool{true}
+	}
+
+	var lastErr error
+	for _, useHTTPS := range protocols {
+		for _, port := range utility.IfThenElse(useHTTPS, r.ConnectionData.HTTPSPorts, r.ConnectionData.HTTPPorts).([]int) {
+			res, err := r.HTTPClient.RequestPort(ctx, useHTTPS, port, http.MethodGet, path, "", nil, nil)
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Str("protocol", protocolString(useHTTPS)).Int("port", port).Msg("http(s) request returned error")
+				if tholaerr.IsNetworkError(err) {
+					lastErr = err
+					continue
+				}
+				return nil, errors.Wrap(err, "non-network error during http(s) request")
+			}
+			if res.IsError() {
+				return nil, fmt.Errorf("request for '%s' returned status %d", path, res.StatusCode())
+			}
+
+			decoder := json.NewDecoder(bytes.NewReader(res.Body()))
+			decoder.UseNumber()
+			var body interface{}
+			if err := decoder.Decode(&body); err != nil {
+				return nil, errors.Wrapf(err, "response for '%s' is no valid json", path)
+			}
+			return body, nil
+		}
+	}
+	if lastErr == nil {
+		if r.HTTPClient.HasCredentials() {
+			return nil, errors.New("no https ports available, credentials are not sent over http")
+		}
+		return nil, errors.New("no http(s) ports available")
+	}
+	return nil, lastErr
+}
+
+// JSONPathValue returns the value at the path in decoded JSON data. The path consists of object keys and array
+// indices which are separated by dots, e.g. "results.0.name". An empty path returns the data itself.
+func JSONPathValue(data interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return data, nil
+	}
+	current := data
+	for _, key := range strings.Split(path, ".") {
+		switch c := current.(type) {
+		case map[string]interface{}:
+			v, ok := c[key]
+			if !ok {
+				return nil, tholaerr.NewNotFoundError(fmt.Sprintf("key '%s' of path '%s' not found", key, path))
+			}
+			current = v
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("key '%s' of path '%s' is no array index", key, path)
+			}
+			if i < 0 || i >= len(c) {
+				return nil, tholaerr.NewNotFoundError(fmt.Sprintf("index %d of path '%s' is out of range", i, path))
+			}
+			current = c[i]
+		default:
+			return nil, tholaerr.NewNotFoundError(fmt.Sprintf("key '%s' of path '%s' not found", key, path))
+		}
+	}
+	return current, nil
+}
+
+// ValidateJSONPath checks whether the path is a valid path for JSONPathValue.
+func ValidateJSONPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			return fmt.Errorf("invalid json path '%s'", path)
+		}
+	}
+	return nil
+}
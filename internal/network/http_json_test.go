@@ -0,0 +1,133 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestRequestDeviceConnectionHTTP_GetJSON(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/status":
+			_, _ = w.Write([]byte(`{"results": {"model": "FGT60F", "uptime": 18446744073709551615}}`))
+		case "/api/broken":
+			_, _ = w.Write([]byte(`{"results":`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.InsecureSSLCert(true)
+	con := RequestDeviceConnectionHTTP{
+		HTTPClient:     client,
+		ConnectionData: &HTTPConnectionData{HTTPSPorts: []int{testServerPort(t, server)}},
+	}
+
+	_, err = con.GetJSON(context.Background(), "/api/status")
+	assert.EqualError(t, err, "request for '/api/status' returned status 401")
+
+	assert.NoError(t, client.SetAuthToken("secret"))
+	client.UseCache(false)
+
+	body, err := con.GetJSON(context.Background(), "/api/status")
+	if assert.NoError(t, err) {
+		// large numbers keep their precision
+		assert.Equal(t, map[string]interface{}{
+			"results": map[string]interface{}{"model": "FGT60F", "uptime": json.Number("18446744073709551615")},
+		}, body)
+	}
+
+	_, err = con.GetJSON(context.Background(), "/api/broken")
+	assert.Error(t, err)
+
+	_, err = con.GetJSON(context.Background(), "/api/unknown")
+	assert.EqualError(t, err, "request for '/api/unknown' returned status 404")
+}
+
+func TestRequestDeviceConnectionHTTP_GetJSON_noHTTPWithCredentials(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	con := RequestDeviceConnectionHTTP{
+		HTTPClient:     client,
+		ConnectionData: &HTTPConnectionData{HTTPPorts: []int{testServerPort(t, server)}},
+	}
+
+	_, err = con.GetJSON(context.Background(), "/api/status")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	// the token must not be sent in cleartext
+	assert.NoError(t, client.SetAuthToken("secret"))
+	client.UseCache(false)
+	_, err = con.GetJSON(context.Background(), "/api/status")
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, "http", client.GetProtocolString(), "GetJSON must not change the client")
+}
+
+func testServerPort(t *testing.T, server *httptest.Server) int {
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func TestJSONPathValue(t *testing.T) {
+	data := map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"name": "port1"},
+			map[string]interface{}{"name": "port2"},
+		},
+	}
+
+	v, err := JSONPathValue(data, "results.1.name")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "port2", v)
+	}
+	v, err = JSONPathValue(data, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, data, v)
+	}
+
+	_, err = JSONPathValue(data, "results.2.name")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+	_, err = JSONPathValue(data, "results.0.speed")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+	_, err = JSONPathValue(data, "results.0.name.first")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+	_, err = JSONPathValue(data, "results.first")
+	assert.EqualError(t, err, "key 'first' of path 'results.first' is no array index")
+
+	assert.NoError(t, ValidateJSONPath("results.0.name"))
+	assert.Error(t, ValidateJSONPath("results..name"))
+}
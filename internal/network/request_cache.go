@@ -20,6 +20,13 @@ func newRequestCache() requestCache {
 	}
 }
 
+// reset removes all cached requests.
+func (r *requestCache) reset() {
+	r.Lock()
+	defer r.Unlock()
+	r.cache = make(map[string]cachedRequestResult)
+}
+
 func (r *requestCache) add(identifier string, result interface{}, err error) {
 	r.Lock()
 	defer r.Unlock()
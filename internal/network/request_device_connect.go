@@ -11,6 +11,33 @@ type RequestDeviceConnection struct {
 	RawConnectionData ConnectionData
 	HTTP              *RequestDeviceConnectionHTTP
 	SNMP              *RequestDeviceConnectionSNMP
+	SSH               *RequestDeviceConnectionSSH
+}
+
+// GetSNMPWalkMode returns the walk mode of the connection. A walk mode that is set in the connection data takes
+// precedence over the walk mode of the device class.
+func (r *RequestDeviceConnection) GetSNMPWalkMode() SNMPWalkMode {
+	if r.RawConnectionData.SNMP != nil && r.RawConnectionData.SNMP.WalkMode != nil && *r.RawConnectionData.SNMP.WalkMode != "" {
+		return SNMPWalkMode(*r.RawConnectionData.SNMP.WalkMode)
+	}
+	if r.SNMP == nil {
+		return ""
+	}
+	return r.SNMP.WalkMode
+}
+
+// GetSNMPMaxWalkRows returns the maximum amount of rows of a snmp walk, 0 means that walks are not limited.
+func (r *RequestDeviceConnection) GetSNMPMaxWalkRows() int {
+	if r.RawConnectionData.SNMP == nil || r.RawConnectionData.SNMP.MaxWalkRows == nil || *r.RawConnectionData.SNMP.MaxWalkRows < 0 {
+		return 0
+	}
+	return *r.RawConnectionData.SNMP.MaxWalkRows
+}
+
+// SNMPWritesEnabled returns whether snmp set requests that change the state of the device are allowed. Writes need
+// to be enabled explicitly in the configuration.
+func (r *RequestDeviceConnection) SNMPWritesEnabled() bool {
+	return r.RawConnectionData.SNMP != nil && r.RawConnectionData.SNMP.WritesEnabled != nil && *r.RawConnectionData.SNMP.WritesEnabled
 }
 
 // RequestDeviceConnectionHTTP represents the http request device connection
@@ -23,6 +50,14 @@ type RequestDeviceConnectionHTTP struct {
 type RequestDeviceConnectionSNMP struct {
 	SnmpClient SNMPClient
 	CommonOIDs CommonOIDs
+	// WalkMode is the walk mode of the device class, the walk mode of the connection data takes precedence
+	WalkMode SNMPWalkMode
+}
+
+// RequestDeviceConnectionSSH represents the ssh request device connection
+type RequestDeviceConnectionSSH struct {
+	SSHClient      SSHClient
+	ConnectionData *SSHConnectionData
 }
 
 // CommonOIDs represents the common oids
@@ -92,6 +127,7 @@ func (r *RequestDeviceConnection) GetIdealConnectionData() ConnectionData {
 		connectionData.HTTP = &HTTPConnectionData{
 			AuthUsername: utility.IfThenElse(r.HTTP.HTTPClient.username == "", null, &r.HTTP.HTTPClient.username).(*string),
 			AuthPassword: utility.IfThenElse(r.HTTP.HTTPClient.password == "", null, &r.HTTP.HTTPClient.password).(*string),
+			AuthToken:    utility.IfThenElse(r.HTTP.HTTPClient.token == "", null, &r.HTTP.HTTPClient.token).(*string),
 		}
 
 		if r.HTTP.HTTPClient.useHTTPS {
@@ -101,12 +137,71 @@ func (r *RequestDeviceConnection) GetIdealConnectionData() ConnectionData {
 		}
 	}
 
+	if r.SSH != nil {
+		connectionData.SSH = &SSHConnectionData{
+			Ports: []int{r.SSH.SSHClient.GetPort()},
+		}
+		if r.SSH.ConnectionData != nil {
+			connectionData.SSH.Username = r.SSH.ConnectionData.Username
+			connectionData.SSH.Password = r.SSH.ConnectionData.Password
+			connectionData.SSH.PrivateKey = r.SSH.ConnectionData.PrivateKey
+			connectionData.SSH.HostKeyFingerprint = r.SSH.ConnectionData.HostKeyFingerprint
+		}
+	}
+
 	return connectionData
 }
 
+// SNMPConnectionInfo
+//
+// SNMPConnectionInfo describes the SNMP connection which was established to a device.
+//
+// swagger:model
+type SNMPConnectionInfo struct {
+	// The SNMP version of the connection.
+	//
+	// example: 2c
+	Version string `yaml:"version" json:"version" xml:"version"`
+	// The SNMP community of the connection, only set for SNMP v1 and v2c.
+	//
+	// example: public
+	Community string `yaml:"community,omitempty" json:"community,omitempty" xml:"community,omitempty"`
+	// The SNMP v3 user of the connection, only set for SNMP v3.
+	//
+	// example: user
+	User string `yaml:"user,omitempty" json:"user,omitempty" xml:"user,omitempty"`
+	// The SNMP port of the connection.
+	//
+	// example: 161
+	Port int `yaml:"port" json:"port" xml:"port"`
+}
+
+// GetSNMPConnectionInfo returns the version, community and port of the snmp connection.
+// It returns nil if there is no snmp connection.
+func (r *RequestDeviceConnection) GetSNMPConnectionInfo() *SNMPConnectionInfo {
+	if r.SNMP == nil || r.SNMP.SnmpClient == nil {
+		return nil
+	}
+	info := SNMPConnectionInfo{
+		Version: r.SNMP.SnmpClient.GetVersion(),
+		Port:    r.SNMP.SnmpClient.GetPort(),
+	}
+	if info.Version == "3" {
+		if user := r.SNMP.SnmpClient.GetV3User(); user != nil {
+			info.User = *user
+		}
+	} else {
+		info.Community = r.SNMP.SnmpClient.GetCommunity()
+	}
+	return &info
+}
+
 // CloseConnections closes the connection to the device
 func (r *RequestDeviceConnection) CloseConnections() {
 	if r.SNMP != nil && r.SNMP.SnmpClient != nil {
 		_ = r.SNMP.SnmpClient.Disconnect()
 	}
+	if r.SSH != nil && r.SSH.SSHClient != nil {
+		_ = r.SSH.SSHClient.Disconnect()
+	}
 }
@@ -15,18 +15,41 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 )
 
 //go:generate go run github.com/vektra/mockery/v2 --name=SNMPClient --inpackage
 
+// SNMPWalkMode describes which requests are used for snmp walks.
+type SNMPWalkMode string
+
+// All snmp walk modes. The empty walk mode is the same as the bulk walk mode.
+const (
+	// SNMPWalkModeBulk uses getbulk requests for snmp walks, if the snmp version supports them.
+	SNMPWalkModeBulk SNMPWalkMode = "bulk"
+	// SNMPWalkModeGetNext only uses getnext requests for snmp walks, which is needed for agents that break on getbulk.
+	SNMPWalkModeGetNext SNMPWalkMode = "getnext"
+)
+
+// ValidateSNMPWalkMode checks if the given snmp walk mode is valid. An empty walk mode is valid.
+func ValidateSNMPWalkMode(mode string) error {
+	switch SNMPWalkMode(mode) {
+	case "", SNMPWalkModeBulk, SNMPWalkModeGetNext:
+		return nil
+	}
+	return fmt.Errorf("invalid snmp walk mode '%s'", mode)
+}
+
 // SNMPClient is used to communicate via snmp.
 type SNMPClient interface {
 	Disconnect() error
 
 	SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, error)
 	SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, error)
+	SNMPWalkGetNext(ctx context.Context, oid OID) ([]SNMPResponse, error)
+	SNMPSet(ctx context.Context, variables ...SNMPVariable) error
 
 	UseCache(b bool)
 	HasSuccessfulCachedRequest() bool
@@ -50,6 +73,9 @@ type SNMPClient interface {
 }
 
 type snmpClient struct {
+	// mu guards the session, as it can only send one request at a time and the context and the operation settings of
+	// a request are set on the session itself.
+	mu        sync.Mutex
 	client    *gosnmp.GoSNMP
 	useCache  bool
 	getCache  requestCache
@@ -60,9 +86,11 @@ type snmpClientCreation struct {
 	client  SNMPClient
 	version string
 	err     error
+	rank    int
 }
 
 type snmpClientCreationData struct {
+	rank        int
 	ipAddress   string
 	snmpVersion string
 	community   string
@@ -78,58 +106,39 @@ func NewSNMPClientByConnectionData(ctx context.Context, ipAddress string, data *
 		return nil, errors.New("snmp connection data is nil")
 	}
 
+	if IsSNMPRecAddress(ipAddress) {
+		return NewSNMPRecClient(ctx, ipAddress, data.Communities)
+	}
+
 	if data.DiscoverParallelRequests == nil || data.DiscoverTimeout == nil || data.DiscoverRetries == nil || *data.DiscoverParallelRequests <= 0 || *data.DiscoverTimeout <= 0 {
 		return nil, tholaerr.NewPreConditionError("invalid connection preferences")
 	}
 
-	var v2cAvailable, v3Available bool
+	// v3 is added as a candidate if v3 credentials are given, even if it is not part of the versions
+	versions := data.Versions
+	if !utility.StringSliceContains(versions, "3") && !isEmptyString(data.V3Data.Level) && !isEmptyString(data.V3Data.User) {
+		versions = append([]string{"3"}, versions...)
+	}
 
 	// validate snmp version
-	for _, v := range data.Versions {
+	for _, v := range versions {
 		version, err := getGoSNMPVersion(v)
 		if err != nil {
 			return nil, err
 		}
 		if version == gosnmp.Version3 {
-			v3Available = true
-		}
-		if version == gosnmp.Version2c {
-			v2cAvailable = true
+			if err := ValidateSNMPv3ConnectionData(data.V3Data); err != nil {
+				return nil, tholaerr.NewPreConditionError(err.Error())
+			}
 		}
 	}
 
-	amount := len(data.Ports) * len(data.Versions) * len(data.Communities)
+	candidates := snmpClientCandidates(ipAddress, data, versions)
+	amount := len(candidates)
 	in := make(chan snmpClientCreationData, amount)
 	out := make(chan snmpClientCreation, amount)
-	amount = 0
-
-	for _, port := range data.Ports {
-		for _, version := range data.Versions {
-			// v3 has no community set
-			if version == "3" {
-				in <- snmpClientCreationData{
-					ipAddress:   ipAddress,
-					snmpVersion: version,
-					port:        port,
-					timeout:     *data.DiscoverTimeout,
-					retries:     *data.DiscoverRetries,
-					v3Data:      data.V3Data,
-				}
-				amount++
-			} else {
-				for _, community := range data.Communities {
-					in <- snmpClientCreationData{
-						ipAddress:   ipAddress,
-						snmpVersion: version,
-						community:   community,
-						port:        port,
-						timeout:     *data.DiscoverTimeout,
-						retries:     *data.DiscoverRetries,
-					}
-					amount++
-				}
-			}
-		}
+	for _, candidate := range candidates {
+		in <- candidate
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -141,10 +150,15 @@ func NewSNMPClientByConnectionData(ctx context.Context, ipAddress string, data *
 
 	var criticalError error
 	var successfulClient SNMPClient
+	var timeouts int
+	selector := newSNMPClientSelector(amount)
 
-	for i := 0; i < amount; i++ {
+	for i := 0; i < amount && successfulClient == nil; i++ {
 		res := <-out
 		if res.err != nil {
+			if tholaerr.IsSNMPTimeoutError(res.err) {
+				timeouts++
+			}
 			if !tholaerr.IsNetworkError(res.err) {
 				s := "non network error occurred during NewSNMPClient"
 				log.Ctx(ctx).Error().Err(res.err).Msg(s)
@@ -152,31 +166,11 @@ func NewSNMPClientByConnectionData(ctx context.Context, ipAddress string, data *
 					criticalError = errors.Wrap(res.err, s)
 				}
 			}
-			continue
-		}
-		if res.version == "3" {
-			successfulClient = res.client
-			break
-		}
-		if res.version == "2c" {
-			if !v3Available {
-				successfulClient = res.client
-				break
-			}
-			if successfulClient == nil || successfulClient.GetVersion() == "1" {
-				successfulClient = res.client
-			}
-		}
-		if res.version == "1" {
-			if !v3Available && !v2cAvailable {
-				successfulClient = res.client
-				break
-			}
-			if successfulClient == nil {
-				successfulClient = res.client
-			}
 		}
+		successfulClient = selector.add(res)
 	}
+	selector.disconnectOthers(successfulClient)
+
 	if successfulClient != nil {
 		if data.MaxRepetitions != nil {
 			log.Ctx(ctx).Debug().Msg("set snmp max repetitions of connection data")
@@ -187,9 +181,88 @@ func NewSNMPClientByConnectionData(ctx context.Context, ipAddress string, data *
 	if criticalError != nil {
 		return nil, criticalError
 	}
+	if amount > 0 && timeouts == amount {
+		return nil, tholaerr.NewSNMPTimeoutError("cannot connect with any of the given connection data")
+	}
 	return nil, tholaerr.NewSNMPError("cannot connect with any of the given connection data")
 }
 
+// snmpVersionPreference contains the snmp versions ordered by preference.
+var snmpVersionPreference = []string{"3", "2c", "1"}
+
+// snmpClientCandidates returns all connection attempts for the given connection data ordered by preference.
+// The ports are tried in the given order, on every port the snmp versions are ordered from v3 to v1 and
+// the communities are tried in the given order.
+func snmpClientCandidates(ipAddress string, data *SNMPConnectionData, versions []string) []snmpClientCreationData {
+	var candidates []snmpClientCreationData
+	for _, port := range data.Ports {
+		for _, version := range snmpVersionPreference {
+			if !utility.StringSliceContains(versions, version) {
+				continue
+			}
+			// v3 has no community set
+			if version == "3" {
+				candidates = append(candidates, snmpClientCreationData{
+					rank:        len(candidates),
+					ipAddress:   ipAddress,
+					snmpVersion: version,
+					port:        port,
+					timeout:     *data.DiscoverTimeout,
+					retries:     *data.DiscoverRetries,
+					v3Data:      data.V3Data,
+				})
+				continue
+			}
+			for _, community := range data.Communities {
+				candidates = append(candidates, snmpClientCreationData{
+					rank:        len(candidates),
+					ipAddress:   ipAddress,
+					snmpVersion: version,
+					community:   community,
+					port:        port,
+					timeout:     *data.DiscoverTimeout,
+					retries:     *data.DiscoverRetries,
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// snmpClientSelector selects the successful connection attempt with the best rank. As the attempts run concurrently,
+// a successful attempt is only selected once all attempts with a better rank have failed.
+type snmpClientSelector struct {
+	results []*snmpClientCreation
+	next    int
+}
+
+func newSNMPClientSelector(amount int) *snmpClientSelector {
+	return &snmpClientSelector{
+		results: make([]*snmpClientCreation, amount),
+	}
+}
+
+// add adds the result of a connection attempt and returns the selected client as soon as it is known.
+func (s *snmpClientSelector) add(res snmpClientCreation) SNMPClient {
+	s.results[res.rank] = &res
+	for s.next < len(s.results) && s.results[s.next] != nil && s.results[s.next].err != nil {
+		s.next++
+	}
+	if s.next < len(s.results) && s.results[s.next] != nil {
+		return s.results[s.next].client
+	}
+	return nil
+}
+
+// disconnectOthers disconnects all successfully created clients except the given one.
+func (s *snmpClientSelector) disconnectOthers(selected SNMPClient) {
+	for _, res := range s.results {
+		if res != nil && res.err == nil && res.client != nil && res.client != selected {
+			_ = res.client.Disconnect()
+		}
+	}
+}
+
 func createNewSNMPClientConcurrent(ctx context.Context, in chan snmpClientCreationData, out chan snmpClientCreation) {
 	for {
 		select {
@@ -205,7 +278,7 @@ func createNewSNMPClientConcurrent(ctx context.Context, in chan snmpClientCreati
 				} else {
 					client, err = NewSNMPClient(ctx, data.ipAddress, data.snmpVersion, data.community, data.port, data.timeout, data.retries)
 				}
-				out <- snmpClientCreation{client, data.snmpVersion, err}
+				out <- snmpClientCreation{client, data.snmpVersion, err, data.rank}
 			default:
 				return
 			}
@@ -237,6 +310,10 @@ func NewSNMPClient(ctx context.Context, ipAddress, snmpVersion, community string
 
 // NewSNMPv3Client creates a new SNMP v3 Client.
 func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retries int, v3Data SNMPv3ConnectionData) (SNMPClient, error) {
+	if err := ValidateSNMPv3ConnectionData(v3Data); err != nil {
+		return nil, tholaerr.NewPreConditionError(err.Error())
+	}
+
 	client := &gosnmp.GoSNMP{
 		Context:       ctx,
 		Target:        ipAddress,
@@ -253,20 +330,35 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 		client.ContextName = *v3Data.ContextName
 	}
 
+	securityParameters, msgFlags, err := getUsmSecurityParameters(v3Data)
+	if err != nil {
+		return nil, err
+	}
+	client.MsgFlags = msgFlags
+	client.SecurityParameters = securityParameters
+
+	return newSNMPClientTestConnection(client)
+}
+
+// getUsmSecurityParameters returns the user security model parameters and the message flags for the level of the
+// snmp v3 data. The data has to be validated before.
+func getUsmSecurityParameters(v3Data SNMPv3ConnectionData) (*gosnmp.UsmSecurityParameters, gosnmp.SnmpV3MsgFlags, error) {
+	var params *gosnmp.UsmSecurityParameters
+	var msgFlags gosnmp.SnmpV3MsgFlags
 	switch *v3Data.Level {
 	case "noAuthNoPriv":
-		client.MsgFlags = gosnmp.NoAuthNoPriv
-		client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+		msgFlags = gosnmp.NoAuthNoPriv
+		params = &gosnmp.UsmSecurityParameters{
 			UserName: *v3Data.User,
 		}
 	case "authNoPriv":
 		authProtocol, err := getGoSNMPV3AuthProtocol(*v3Data.AuthProtocol)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
-		client.MsgFlags = gosnmp.AuthNoPriv
-		client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+		msgFlags = gosnmp.AuthNoPriv
+		params = &gosnmp.UsmSecurityParameters{
 			UserName:                 *v3Data.User,
 			AuthenticationProtocol:   authProtocol,
 			AuthenticationPassphrase: *v3Data.AuthKey,
@@ -274,16 +366,16 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 	case "authPriv":
 		authProtocol, err := getGoSNMPV3AuthProtocol(*v3Data.AuthProtocol)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		privProtocol, err := getGoSNMPV3PrivProtocol(*v3Data.PrivProtocol)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
-		client.MsgFlags = gosnmp.AuthPriv
-		client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+		msgFlags = gosnmp.AuthPriv
+		params = &gosnmp.UsmSecurityParameters{
 			UserName:                 *v3Data.User,
 			AuthenticationProtocol:   authProtocol,
 			AuthenticationPassphrase: *v3Data.AuthKey,
@@ -292,7 +384,7 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 		}
 	}
 
-	return newSNMPClientTestConnection(client)
+	return params, msgFlags, nil
 }
 
 func newSNMPClientTestConnection(client *gosnmp.GoSNMP) (*snmpClient, error) {
@@ -302,10 +394,16 @@ func newSNMPClientTestConnection(client *gosnmp.GoSNMP) (*snmpClient, error) {
 	}
 
 	oids := []string{".0.0"}
-	_, err = client.GetNext(oids)
+	res, err := client.GetNext(oids)
 	if err != nil {
+		if err := convertSNMPError(err); tholaerr.IsSNMPTimeoutError(err) || tholaerr.IsAuthError(err) {
+			return nil, err
+		}
 		return nil, tholaerr.NewSNMPError(err.Error())
 	}
+	if err := checkSNMPReport(res); err != nil {
+		return nil, err
+	}
 
 	client.Retries = gosnmp.Default.Retries
 	client.Timeout = gosnmp.Default.Timeout
@@ -331,6 +429,56 @@ func getGoSNMPVersion(version string) (gosnmp.SnmpVersion, error) {
 	}
 }
 
+// ValidateSNMPv3ConnectionData checks the SNMP v3 connection data for missing or inconsistent parameters.
+// Empty strings are treated like parameters that are not set.
+func ValidateSNMPv3ConnectionData(data SNMPv3ConnectionData) error {
+	if isEmptyString(data.Level) {
+		return errors.New("no SNMP v3 level provided")
+	}
+	if isEmptyString(data.User) {
+		return errors.New("no SNMP v3 username provided")
+	}
+	if !isEmptyString(data.PrivKey) && isEmptyString(data.AuthKey) {
+		return errors.New("SNMP v3 priv key provided without auth key")
+	}
+
+	switch *data.Level {
+	case "authPriv":
+		if isEmptyString(data.PrivProtocol) {
+			return errors.New("no SNMP v3 priv protocol provided")
+		} else if ValidateSNMPv3PrivProtocol(*data.PrivProtocol) != nil {
+			return errors.New("invalid SNMP v3 priv protocol provided")
+		}
+		if isEmptyString(data.PrivKey) {
+			return errors.New("no SNMP v3 priv key provided")
+		}
+		fallthrough
+	case "authNoPriv":
+		if isEmptyString(data.AuthProtocol) {
+			return errors.New("no SNMP v3 auth protocol provided")
+		} else if ValidateSNMPv3AuthProtocol(*data.AuthProtocol) != nil {
+			return errors.New("invalid SNMP v3 auth protocol provided")
+		}
+		if isEmptyString(data.AuthKey) {
+			return errors.New("no SNMP v3 auth key provided")
+		}
+		if *data.Level == "authNoPriv" && !isEmptyString(data.PrivKey) {
+			return errors.New("SNMP v3 priv key provided for level 'authNoPriv'")
+		}
+	case "noAuthNoPriv":
+		if !isEmptyString(data.AuthKey) {
+			return errors.New("SNMP v3 auth key provided for level 'noAuthNoPriv'")
+		}
+	default:
+		return errors.New("invalid SNMP v3 level, only 'noAuthNoPriv', 'authNoPriv' and 'authPriv' are possible")
+	}
+	return nil
+}
+
+func isEmptyString(s *string) bool {
+	return s == nil || *s == ""
+}
+
 func ValidateSNMPv3AuthProtocol(protocol string) error {
 	_, err := getGoSNMPV3AuthProtocol(protocol)
 	return err
@@ -338,7 +486,7 @@ func ValidateSNMPv3AuthProtocol(protocol string) error {
 
 func getGoSNMPV3AuthProtocol(protocol string) (gosnmp.SnmpV3AuthProtocol, error) {
 	switch protocol {
-	case "noAuth":
+	case "noAuth", "NoAuth":
 		return gosnmp.NoAuth, nil
 	case "md5", "MD5":
 		return gosnmp.MD5, nil
@@ -364,19 +512,19 @@ func ValidateSNMPv3PrivProtocol(protocol string) error {
 
 func getGoSNMPV3PrivProtocol(protocol string) (gosnmp.SnmpV3PrivProtocol, error) {
 	switch protocol {
-	case "noAuth":
+	case "noAuth", "noPriv", "NoPriv":
 		return gosnmp.NoPriv, nil
 	case "des", "DES":
 		return gosnmp.DES, nil
-	case "aes", "AES":
+	case "aes", "AES", "aes128", "AES128", "aes-128", "AES-128":
 		return gosnmp.AES, nil
-	case "aes192", "AES192":
+	case "aes192", "AES192", "aes-192", "AES-192":
 		return gosnmp.AES192, nil
-	case "aes256", "AES256":
+	case "aes256", "AES256", "aes-256", "AES-256":
 		return gosnmp.AES256, nil
-	case "aes192c", "AES192C":
+	case "aes192c", "AES192C", "aes-192c", "AES-192C":
 		return gosnmp.AES192C, nil
-	case "aes256c", "AES256C":
+	case "aes256c", "AES256C", "aes-256c", "AES-256C":
 		return gosnmp.AES256C, nil
 	default:
 		return 0, fmt.Errorf("invalid privacy protocol '%s'", protocol)
@@ -411,7 +559,8 @@ func (s *snmpClient) SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, e
 	}
 
 	var batch []OID
-	s.client.Context = ctx
+	defer s.startOperation(ctx)()
+	trace, traced := snmpTraceRecorderFromContext(ctx)
 
 	for len(reqOIDs) > 0 {
 		var batchSize int
@@ -426,10 +575,21 @@ func (s *snmpClient) SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, e
 		for _, elem := range batch {
 			batchString = append(batchString, elem.String())
 		}
+		var start time.Time
+		if traced {
+			start = time.Now()
+		}
 		response, err := s.client.Get(batchString)
+		if traced {
+			var pdus int
+			if response != nil {
+				pdus = len(response.Variables)
+			}
+			trace.add("get", batchString, pdus, start, err)
+		}
 		if err != nil {
 			log.Ctx(ctx).Trace().Str("network_request", "snmpget").Strs("oid", batchString).Err(err).Msg("SNMP Get failed")
-			return nil, errors.Wrap(err, "error during snmpget")
+			return nil, errors.Wrap(convertSNMPError(err), "error during snmpget")
 		}
 
 		for _, currentResponse := range response.Variables {
@@ -459,11 +619,161 @@ func (s *snmpClient) SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, e
 	return snmpResponses, nil
 }
 
-// SNMPWalk sends a snmpwalk request to the specified oid.
+// SNMPVariable is a variable that is written to a device with a snmpset request.
+type SNMPVariable struct {
+	OID   OID
+	Type  gosnmp.Asn1BER
+	Value interface{}
+}
+
+// SNMPSet writes the given variables to the target host in a single snmpset request. As the values on the device
+// may change due to the request, the caches of the client are reset.
+func (s *snmpClient) SNMPSet(ctx context.Context, variables ...SNMPVariable) error {
+	if len(variables) == 0 {
+		return errors.New("no variables to set")
+	}
+
+	pdus := make([]gosnmp.SnmpPDU, 0, len(variables))
+	oids := make([]string, 0, len(variables))
+	for _, v := range variables {
+		pdus = append(pdus, gosnmp.SnmpPDU{
+			Name:  v.OID.String(),
+			Type:  v.Type,
+			Value: v.Value,
+		})
+		oids = append(oids, v.OID.String())
+	}
+
+	defer s.startOperation(ctx)()
+	trace, traced := snmpTraceRecorderFromContext(ctx)
+
+	var start time.Time
+	if traced {
+		start = time.Now()
+	}
+	response, err := s.client.Set(pdus)
+	if traced {
+		var resPDUs int
+		if response != nil {
+			resPDUs = len(response.Variables)
+		}
+		trace.add("set", oids, resPDUs, start, err)
+	}
+
+	s.getCache.reset()
+	s.walkCache.reset()
+
+	if err != nil {
+		log.Ctx(ctx).Trace().Str("network_request", "snmpset").Strs("oid", oids).Err(err).Msg("SNMP Set failed")
+		return errors.Wrap(convertSNMPError(err), "error during snmpset")
+	}
+	if response.Error != gosnmp.NoError {
+		log.Ctx(ctx).Trace().Str("network_request", "snmpset").Strs("oid", oids).Str("error", response.Error.String()).Msg("SNMP Set was rejected")
+		if response.ErrorIndex > 0 && int(response.ErrorIndex) <= len(oids) {
+			return fmt.Errorf("snmpset of oid '%s' was rejected: %s", oids[response.ErrorIndex-1], response.Error)
+		}
+		return fmt.Errorf("snmpset was rejected: %s", response.Error)
+	}
+
+	log.Ctx(ctx).Trace().Str("network_request", "snmpset").Strs("oid", oids).Msg("SNMP Set was successful")
+	return nil
+}
+
+// startOperation locks the session for an operation of the given context and applies the context and its operation
+// settings to the session. The returned function restores the settings and unlocks the session.
+func (s *snmpClient) startOperation(ctx context.Context) func() {
+	s.mu.Lock()
+	s.client.Context = ctx
+	restore := s.applyOperationSettings(ctx)
+	return func() {
+		restore()
+		s.mu.Unlock()
+	}
+}
+
+// applyOperationSettings applies the timeout and retries overrides of the context to the snmp session and returns a
+// function that restores the previous settings of the session.
+func (s *snmpClient) applyOperationSettings(ctx context.Context) func() {
+	settings, ok := SNMPOperationSettingsFromContext(ctx)
+	if !ok {
+		return func() {}
+	}
+
+	timeout, retries := s.client.Timeout, s.client.Retries
+	if settings.Timeout != nil {
+		s.client.Timeout = time.Duration(*settings.Timeout) * time.Second
+	}
+	if settings.Retries != nil {
+		s.client.Retries = *settings.Retries
+	}
+	return func() {
+		s.client.Timeout = timeout
+		s.client.Retries = retries
+	}
+}
+
+// usmStatsErrors maps the counters of the SNMP-USER-BASED-SM-MIB, which are returned in report PDUs if a
+// request is rejected due to invalid credentials, to a description of the error.
+var usmStatsErrors = map[string]string{
+	".1.3.6.1.6.3.15.1.1.1.0": "unsupported security level",
+	".1.3.6.1.6.3.15.1.1.3.0": "unknown user name",
+	".1.3.6.1.6.3.15.1.1.5.0": "wrong digest",
+	".1.3.6.1.6.3.15.1.1.6.0": "decryption error",
+}
+
+// checkSNMPReport returns an AuthError if the given packet is a report of a rejected SNMPv3 request.
+func checkSNMPReport(packet *gosnmp.SnmpPacket) error {
+	if packet == nil || packet.PDUType != gosnmp.Report {
+		return nil
+	}
+	for _, variable := range packet.Variables {
+		if description, ok := usmStatsErrors[variable.Name]; ok {
+			return tholaerr.NewAuthError("snmpv3 authentication failed: " + description)
+		}
+	}
+	return nil
+}
+
+// convertSNMPError converts errors of the snmp library to the corresponding thola errors.
+func convertSNMPError(err error) error {
+	if isSNMPTimeout(err) {
+		return tholaerr.NewSNMPTimeoutError(err.Error())
+	}
+	if strings.Contains(err.Error(), "not authentic") {
+		return tholaerr.NewAuthError(err.Error())
+	}
+	return err
+}
+
+func isSNMPTimeout(err error) bool {
+	return strings.Contains(err.Error(), "request timeout")
+}
+
+// isCacheableSNMPWalkError returns whether a failed walk can be cached. Walks that failed because their context was
+// done or because they timed out are not cached, as later reads of the request with more time left may succeed.
+func isCacheableSNMPWalkError(ctx context.Context, err error) bool {
+	return ctx.Err() == nil &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded) &&
+		!tholaerr.IsSNMPTimeoutError(err) &&
+		!tholaerr.IsTimeoutError(err)
+}
+
+// SNMPWalk sends a snmpwalk request to the specified oid. Bulk requests are used if the snmp version supports them.
 func (s *snmpClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, error) {
+	return s.walk(ctx, oid, s.client.Version != gosnmp.Version1)
+}
+
+// SNMPWalkGetNext sends a snmpwalk request to the specified oid which only uses getnext requests.
+// It is needed for agents which don't handle bulk requests correctly.
+func (s *snmpClient) SNMPWalkGetNext(ctx context.Context, oid OID) ([]SNMPResponse, error) {
+	return s.walk(ctx, oid, false)
+}
+
+func (s *snmpClient) walk(ctx context.Context, oid OID, bulk bool) ([]SNMPResponse, error) {
 	if s.useCache {
 		cacheEntry, err := s.walkCache.get(oid.String())
-		if err == nil {
+		if err == nil && !(cacheEntry.err != nil && snmpWalkErrorCacheBypassFromContext(ctx)) {
 			log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Msg("used cached snmp walk result")
 			if cacheEntry.err != nil {
 				return nil, cacheEntry.err
@@ -476,23 +786,40 @@ func (s *snmpClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, err
 		}
 	}
 
-	s.client.Context = ctx
+	defer s.startOperation(ctx)()
+	trace, traced := snmpTraceRecorderFromContext(ctx)
+	var start time.Time
+	if traced {
+		start = time.Now()
+	}
+
+	var maxRows int
+	if con, ok := DeviceConnectionFromContext(ctx); ok {
+		maxRows = con.GetSNMPMaxWalkRows()
+	}
 
 	var response []gosnmp.SnmpPDU
+	var limitReached bool
 	var err error
-	if s.client.Version != gosnmp.Version1 {
-		response, err = s.client.BulkWalkAll(oid.String())
+	if bulk {
+		response, limitReached, err = s.walkPDUs(oid.String(), true, maxRows)
 		if err != nil {
 			log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Err(err).Msg("snmp bulk walk failed")
 		}
 	}
-	if s.client.Version == gosnmp.Version1 || err != nil {
-		response, err = s.client.WalkAll(oid.String())
+	if !bulk || err != nil {
+		response, limitReached, err = s.walkPDUs(oid.String(), false, maxRows)
+	}
+	if limitReached {
+		log.Ctx(ctx).Warn().Str("oid", oid.String()).Int("max_walk_rows", maxRows).Msg("snmp walk was stopped, because it reached the max walk rows")
+	}
+	if traced {
+		trace.add("walk", []string{oid.String()}, len(response), start, err)
 	}
 	if err != nil {
 		log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Err(err).Msg("snmp walk failed")
-		err = errors.Wrap(err, "snmpwalk failed")
-		if s.useCache {
+		err = errors.Wrap(convertSNMPError(err), "snmpwalk failed")
+		if s.useCache && isCacheableSNMPWalkError(ctx, err) {
 			s.walkCache.add(oid.String(), nil, err)
 		}
 		return nil, err
@@ -530,6 +857,44 @@ func (s *snmpClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, err
 	return res, nil
 }
 
+// errWalkRowLimitReached is used to stop a snmp walk when the max walk rows are reached.
+var errWalkRowLimitReached = errors.New("max walk rows reached")
+
+// walkPDUs walks the oid and stops after maxRows rows if maxRows is greater than 0. It returns whether the walk was
+// stopped because of the limit.
+func (s *snmpClient) walkPDUs(oid string, bulk bool, maxRows int) ([]gosnmp.SnmpPDU, bool, error) {
+	if maxRows <= 0 {
+		var response []gosnmp.SnmpPDU
+		var err error
+		if bulk {
+			response, err = s.client.BulkWalkAll(oid)
+		} else {
+			response, err = s.client.WalkAll(oid)
+		}
+		return response, false, err
+	}
+
+	var response []gosnmp.SnmpPDU
+	walkFn := func(pdu gosnmp.SnmpPDU) error {
+		response = append(response, pdu)
+		if len(response) >= maxRows {
+			return errWalkRowLimitReached
+		}
+		return nil
+	}
+
+	var err error
+	if bulk {
+		err = s.client.BulkWalk(oid, walkFn)
+	} else {
+		err = s.client.Walk(oid, walkFn)
+	}
+	if errors.Is(err, errWalkRowLimitReached) {
+		return response, true, nil
+	}
+	return response, false, err
+}
+
 // UseCache configures whether the snmp cache should be used or not
 func (s *snmpClient) UseCache(b bool) {
 	s.useCache = b
@@ -540,6 +905,91 @@ func (s *snmpClient) HasSuccessfulCachedRequest() bool {
 	return len(s.getCache.getSuccessfulRequests()) > 0
 }
 
+// snmpClientState contains the settings of a session that can be changed while processing a request.
+type snmpClientState struct {
+	community      string
+	maxRepetitions uint32
+	maxOids        int
+}
+
+func (s *snmpClient) state() snmpClientState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return snmpClientState{
+		community:      s.client.Community,
+		maxRepetitions: s.client.MaxRepetitions,
+		maxOids:        s.client.MaxOids,
+	}
+}
+
+// reset restores the given settings and clears the caches, so that the session can be used by another request.
+func (s *snmpClient) reset(state snmpClientState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client.Community = state.community
+	s.client.MaxRepetitions = state.maxRepetitions
+	s.client.MaxOids = state.maxOids
+	s.useCache = true
+	s.getCache.reset()
+	s.walkCache.reset()
+}
+
+// NewSNMPSession opens a new session to the device of the client with the same connection settings. A session can
+// only send one request at a time, so parallel requests to a device need a session each. Clients that are safe for
+// concurrent use, like the client for recorded snmp data, are returned as they are.
+// Sessions of pooled clients are taken from their pool. Other snmp v3 clients can't open new sessions, as every
+// session would need its own engine discovery.
+func NewSNMPSession(client SNMPClient) (SNMPClient, error) {
+	switch c := client.(type) {
+	case *snmpClient:
+		if c.client.Version == gosnmp.Version3 {
+			return nil, tholaerr.NewNotImplementedError("new snmp v3 sessions are only opened by the session pool")
+		}
+		return c.newSession()
+	case *pooledSNMPClient:
+		return c.pool.newSession(c)
+	case *snmpRecClient:
+		return c, nil
+	default:
+		return nil, tholaerr.NewNotImplementedError("snmp client doesn't support new sessions")
+	}
+}
+
+func (s *snmpClient) newSession() (SNMPClient, error) {
+	s.mu.Lock()
+	client := &gosnmp.GoSNMP{
+		Target:          s.client.Target,
+		Port:            s.client.Port,
+		Transport:       s.client.Transport,
+		Community:       s.client.Community,
+		Version:         s.client.Version,
+		Timeout:         s.client.Timeout,
+		Retries:         s.client.Retries,
+		MaxOids:         s.client.MaxOids,
+		MaxRepetitions:  s.client.MaxRepetitions,
+		MsgFlags:        s.client.MsgFlags,
+		SecurityModel:   s.client.SecurityModel,
+		ContextEngineID: s.client.ContextEngineID,
+		ContextName:     s.client.ContextName,
+	}
+	if s.client.SecurityParameters != nil {
+		client.SecurityParameters = s.client.SecurityParameters.Copy()
+	}
+	useCache := s.useCache
+	s.mu.Unlock()
+
+	if err := client.ConnectIPv4(); err != nil {
+		return nil, errors.Wrap(err, "connect ip v4 failed")
+	}
+
+	return &snmpClient{
+		client:    client,
+		useCache:  useCache,
+		getCache:  newRequestCache(),
+		walkCache: newRequestCache(),
+	}, nil
+}
+
 // Disconnect closes an snmp connection.
 func (s *snmpClient) Disconnect() error {
 	return s.client.Conn.Close()
@@ -547,11 +997,15 @@ func (s *snmpClient) Disconnect() error {
 
 // GetCommunity returns the community string
 func (s *snmpClient) GetCommunity() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.client.Community
 }
 
-// SetCommunity updates the community string. This function is not thread safe!
+// SetCommunity updates the community string. It applies to all following requests of the session.
 func (s *snmpClient) SetCommunity(community string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.client.Community = community
 }
 
@@ -575,11 +1029,15 @@ func (s *snmpClient) GetVersion() string {
 
 // GetMaxRepetitions returns the max repetitions.
 func (s *snmpClient) GetMaxRepetitions() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.client.MaxRepetitions
 }
 
 // SetMaxRepetitions sets the maximum repetitions.
 func (s *snmpClient) SetMaxRepetitions(maxRepetitions uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.client.MaxRepetitions = maxRepetitions
 }
 
@@ -591,6 +1049,8 @@ func (s *snmpClient) SetMaxOIDs(maxOIDs int) error {
 	if s.client.Version == gosnmp.Version1 {
 		return errors.New("max oids cannot be changed for snmp v1")
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.client.MaxOids = maxOIDs
 	return nil
 }
@@ -768,6 +1228,23 @@ func (s *SNMPResponse) GetValueRaw() (value.Value, error) {
 	return value.New(s.value), nil
 }
 
+// GetValueHexString returns the value of the snmp response as hex string, if it is an octet string.
+// Six bytes are rendered as a colon separated MAC address, all other lengths as plain hex.
+func (s *SNMPResponse) GetValueHexString() (value.Value, error) {
+	if !s.WasSuccessful() {
+		return nil, tholaerr.NewNotFoundError("no such object")
+	}
+	if s.snmpType == gosnmp.OctetString {
+		switch x := s.value.(type) {
+		case string:
+			return value.NewHexString([]byte(x)), nil
+		case []byte:
+			return value.NewHexString(x), nil
+		}
+	}
+	return value.New(s.value), nil
+}
+
 func (s *SNMPResponse) getValueDecoded() (interface{}, error) {
 	var err error
 	i := s.value
@@ -803,6 +1280,31 @@ func (s *SNMPResponse) GetSNMPType() gosnmp.Asn1BER {
 type SNMPGetConfiguration struct {
 	OID          OID  `yaml:"oid" mapstructure:"oid"`
 	UseRawResult bool `yaml:"use_raw_result" mapstructure:"use_raw_result"`
+	// UseHexString renders octet strings as hex strings, e.g. for MAC addresses or hex encoded serial numbers.
+	UseHexString bool `yaml:"use_hex_string" mapstructure:"use_hex_string"`
+	// Timeout in seconds that overrides the timeout of the snmp session for this oid, e.g. for slow vendor oids.
+	Timeout *int `yaml:"timeout" mapstructure:"timeout"`
+	// Retries that override the retries of the snmp session for this oid.
+	Retries *int `yaml:"retries" mapstructure:"retries"`
+}
+
+// NewContext returns a new context with the timeout and retries overrides of the configuration, if there are any.
+func (s SNMPGetConfiguration) NewContext(ctx context.Context) context.Context {
+	if s.Timeout == nil && s.Retries == nil {
+		return ctx
+	}
+	return NewContextWithSNMPOperationSettings(ctx, SNMPOperationSettings{Timeout: s.Timeout, Retries: s.Retries})
+}
+
+// ValidateOperationSettings checks the timeout and retries overrides of the configuration.
+func (s SNMPGetConfiguration) ValidateOperationSettings() error {
+	if s.Timeout != nil && *s.Timeout <= 0 {
+		return errors.New("timeout must be greater than 0")
+	}
+	if s.Retries != nil && *s.Retries < 0 {
+		return errors.New("retries must not be negative")
+	}
+	return nil
 }
 
 // GetValueBySNMPGetConfiguration returns the value of the snmp response according to the snmpgetConfig
@@ -814,6 +1316,11 @@ func (s *SNMPResponse) GetValueBySNMPGetConfiguration(snmpGetConfig SNMPGetConfi
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get snmp result raw string")
 		}
+	} else if snmpGetConfig.UseHexString {
+		val, err = s.GetValueHexString()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get snmp result hex string")
+		}
 	} else {
 		val, err = s.GetValue()
 		if err != nil {
@@ -830,13 +1337,12 @@ func (o OID) String() string {
 	return string(o)
 }
 
+// oidRegex matches syntactically correct OIDs, which consist of numbers separated by dots and may start with a dot.
+var oidRegex = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)*$`)
+
 // Validate checks if the OID is syntactically correct
 func (o OID) Validate() error {
-	m, err := regexp.MatchString("^[0-9.]+$", string(o))
-	if err != nil {
-		return errors.Wrap(err, "regex match string failed")
-	}
-	if !m {
+	if !oidRegex.MatchString(string(o)) {
 		return errors.New("invalid oid")
 	}
 	return nil
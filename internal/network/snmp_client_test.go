@@ -1,8 +1,16 @@
 package network
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestOID_Cmp_smaller(t *testing.T) {
@@ -70,3 +78,214 @@ func TestOID_AddIndex_noDot(t *testing.T) {
 func TestOID_AddIndex_doubleDot(t *testing.T) {
 	assert.Equal(t, OID("1.1"), OID("1.").AddIndex(".1"))
 }
+
+func TestValidateSNMPv3ConnectionData_authPriv(t *testing.T) {
+	level, user, authKey, authProto, privKey, privProto := "authPriv", "user", "authpass", "SHA256", "privpass", "AES-256"
+	err := ValidateSNMPv3ConnectionData(SNMPv3ConnectionData{
+		Level:        &level,
+		User:         &user,
+		AuthKey:      &authKey,
+		AuthProtocol: &authProto,
+		PrivKey:      &privKey,
+		PrivProtocol: &privProto,
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateSNMPv3ConnectionData_privWithoutAuth(t *testing.T) {
+	level, user, privKey, privProto := "authPriv", "user", "privpass", "AES192"
+	err := ValidateSNMPv3ConnectionData(SNMPv3ConnectionData{
+		Level:        &level,
+		User:         &user,
+		PrivKey:      &privKey,
+		PrivProtocol: &privProto,
+	})
+	assert.EqualError(t, err, "SNMP v3 priv key provided without auth key")
+}
+
+func TestValidateSNMPv3ConnectionData_privKeyAuthNoPriv(t *testing.T) {
+	level, user, authKey, authProto, privKey := "authNoPriv", "user", "authpass", "MD5", "privpass"
+	err := ValidateSNMPv3ConnectionData(SNMPv3ConnectionData{
+		Level:        &level,
+		User:         &user,
+		AuthKey:      &authKey,
+		AuthProtocol: &authProto,
+		PrivKey:      &privKey,
+	})
+	assert.EqualError(t, err, "SNMP v3 priv key provided for level 'authNoPriv'")
+}
+
+func TestValidateSNMPv3ConnectionData_emptyLevel(t *testing.T) {
+	level, user := "", "user"
+	err := ValidateSNMPv3ConnectionData(SNMPv3ConnectionData{
+		Level: &level,
+		User:  &user,
+	})
+	assert.EqualError(t, err, "no SNMP v3 level provided")
+}
+
+func TestConvertSNMPError(t *testing.T) {
+	err := convertSNMPError(errors.New("request timeout (after 1 retries)"))
+	assert.True(t, tholaerr.IsSNMPTimeoutError(err))
+	assert.Equal(t, tholaerr.ErrorCodeSNMPTimeout, tholaerr.Code(errors.Wrap(err, "error during snmpget")))
+
+	err = convertSNMPError(errors.New("incoming packet is not authentic, discarding"))
+	assert.Equal(t, tholaerr.ErrorCodeAuthFailed, tholaerr.Code(errors.Wrap(err, "snmpwalk failed")))
+
+	err = convertSNMPError(errors.New("connection refused"))
+	assert.Equal(t, tholaerr.ErrorCodeRequestFailed, tholaerr.Code(err))
+}
+
+func TestCheckSNMPReport(t *testing.T) {
+	err := checkSNMPReport(&gosnmp.SnmpPacket{
+		PDUType: gosnmp.Report,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.15.1.1.3.0", Type: gosnmp.Counter32, Value: uint(1)},
+		},
+	})
+	if assert.Error(t, err) {
+		assert.Equal(t, tholaerr.ErrorCodeAuthFailed, tholaerr.Code(err))
+		assert.Equal(t, "snmpv3 authentication failed: unknown user name", err.Error())
+	}
+
+	assert.NoError(t, checkSNMPReport(&gosnmp.SnmpPacket{PDUType: gosnmp.GetResponse}))
+}
+
+func TestSNMPClientCandidates(t *testing.T) {
+	timeout, retries := 2, 0
+	data := SNMPConnectionData{
+		Communities:     []string{"public", "private"},
+		Ports:           []int{1161, 161},
+		DiscoverTimeout: &timeout,
+		DiscoverRetries: &retries,
+	}
+
+	var order []string
+	for i, c := range snmpClientCandidates("192.0.2.1", &data, []string{"1", "2c", "3"}) {
+		assert.Equal(t, i, c.rank)
+		order = append(order, fmt.Sprintf("%d/%s/%s", c.port, c.snmpVersion, c.community))
+	}
+	assert.Equal(t, []string{
+		"1161/3/", "1161/2c/public", "1161/2c/private", "1161/1/public", "1161/1/private",
+		"161/3/", "161/2c/public", "161/2c/private", "161/1/public", "161/1/private",
+	}, order)
+}
+
+func TestSNMPClientSelector(t *testing.T) {
+	first, second := &MockSNMPClient{}, &MockSNMPClient{}
+	second.On("Disconnect").Return(nil)
+	selector := newSNMPClientSelector(3)
+
+	// a successful attempt is not selected while a better one is still running
+	assert.Nil(t, selector.add(snmpClientCreation{client: second, rank: 2}))
+	assert.Nil(t, selector.add(snmpClientCreation{err: tholaerr.NewSNMPTimeoutError("request timeout"), rank: 0}))
+	assert.Equal(t, first, selector.add(snmpClientCreation{client: first, rank: 1}))
+
+	selector.disconnectOthers(first)
+	second.AssertCalled(t, "Disconnect")
+	first.AssertNotCalled(t, "Disconnect")
+}
+
+func TestSNMPClientSelector_allFailed(t *testing.T) {
+	selector := newSNMPClientSelector(2)
+	assert.Nil(t, selector.add(snmpClientCreation{err: tholaerr.NewSNMPError("failed"), rank: 1}))
+	assert.Nil(t, selector.add(snmpClientCreation{err: tholaerr.NewSNMPError("failed"), rank: 0}))
+}
+
+func TestSNMPResponse_GetValueBySNMPGetConfiguration_hexString(t *testing.T) {
+	tests := []struct {
+		response SNMPResponse
+		expected value.Value
+	}{
+		{NewSNMPResponse("1.1", gosnmp.OctetString, []byte{0x00, 0x0c, 0x29, 0xab, 0xcd, 0xef}), value.New("00:0C:29:AB:CD:EF")},
+		{NewSNMPResponse("1.2", gosnmp.OctetString, []byte{0x46, 0x44, 0x4f, 0x00, 0x0a, 0xff, 0x10, 0x20}), value.New("46444F000AFF1020")},
+		{NewSNMPResponse("1.3", gosnmp.OctetString, []byte{}), value.New("")},
+		{NewSNMPResponse("1.4", gosnmp.OctetString, string([]byte{0x00, 0x0c, 0x29, 0xab, 0xcd, 0xef})), value.New("00:0C:29:AB:CD:EF")},
+		{NewSNMPResponse("1.5", gosnmp.Integer, 6), value.New(6)},
+	}
+	for _, test := range tests {
+		res, err := test.response.GetValueBySNMPGetConfiguration(SNMPGetConfiguration{OID: test.response.GetOID(), UseHexString: true})
+		if assert.NoError(t, err, test.response.GetOID()) {
+			assert.Equal(t, test.expected, res, test.response.GetOID())
+		}
+	}
+}
+
+func TestSNMPClient_applyOperationSettings(t *testing.T) {
+	s := &snmpClient{client: &gosnmp.GoSNMP{Timeout: 2 * time.Second, Retries: 1, MaxOids: gosnmp.MaxOids}}
+	timeout, retries := 30, 3
+	ctx := NewContextWithSNMPOperationSettings(context.Background(), SNMPOperationSettings{Timeout: &timeout, Retries: &retries})
+
+	restore := s.applyOperationSettings(ctx)
+	assert.Equal(t, 30*time.Second, s.client.Timeout)
+	assert.Equal(t, 3, s.client.Retries)
+	restore()
+	assert.Equal(t, 2*time.Second, s.client.Timeout)
+	assert.Equal(t, 1, s.client.Retries)
+
+	// the session settings are restored even if the operation fails
+	_, err := s.SNMPGet(ctx, "1.3.6.1.2.1.1.1.0")
+	assert.Error(t, err)
+	_, err = s.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	assert.Error(t, err)
+	assert.Equal(t, 2*time.Second, s.client.Timeout)
+	assert.Equal(t, 1, s.client.Retries)
+}
+
+func TestSNMPClient_SNMPWalk_errorCache(t *testing.T) {
+	s := &snmpClient{client: &gosnmp.GoSNMP{Timeout: 2 * time.Second, MaxOids: gosnmp.MaxOids}, useCache: true, getCache: newRequestCache(), walkCache: newRequestCache()}
+	ctx := context.Background()
+
+	_, err := s.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	if !assert.Error(t, err) {
+		return
+	}
+
+	// failed walks are cached
+	_, cachedErr := s.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	assert.True(t, err == cachedErr)
+
+	// unless the cached error is bypassed for a retry
+	_, retryErr := s.SNMPWalk(NewContextWithSNMPWalkErrorCacheBypass(ctx), "1.3.6.1.2.1.2.2.1.2")
+	assert.Error(t, retryErr)
+	assert.False(t, err == retryErr)
+}
+
+func TestSNMPClient_SNMPWalk_cancelledNotCached(t *testing.T) {
+	s := &snmpClient{client: &gosnmp.GoSNMP{Timeout: 2 * time.Second, MaxOids: gosnmp.MaxOids}, useCache: true, getCache: newRequestCache(), walkCache: newRequestCache()}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	if !assert.Error(t, err) {
+		return
+	}
+	_, cacheErr := s.walkCache.get("1.3.6.1.2.1.2.2.1.2")
+	assert.Error(t, cacheErr, "walks of a cancelled context must not be cached")
+
+	// a later walk of the request reaches the device again
+	_, laterErr := s.SNMPWalk(context.Background(), "1.3.6.1.2.1.2.2.1.2")
+	assert.Error(t, laterErr)
+	assert.False(t, err == laterErr)
+
+	// timeouts are not cached either
+	assert.False(t, isCacheableSNMPWalkError(context.Background(), errors.Wrap(tholaerr.NewSNMPTimeoutError("request timeout (after 0 retries)"), "snmpwalk failed")))
+	assert.False(t, isCacheableSNMPWalkError(context.Background(), errors.Wrap(context.DeadlineExceeded, "snmpwalk failed")))
+	assert.True(t, isCacheableSNMPWalkError(context.Background(), errors.New("no such host")))
+}
+
+func TestSNMPConnectionData_serverSettings(t *testing.T) {
+	// the limits of the server can't be changed by requests
+	var data SNMPConnectionData
+	err := json.Unmarshal([]byte(`{"maxWalkRows": 0, "MaxWalkRows": 0, "writesEnabled": true, "WritesEnabled": true,
+		"walkRetries": 1000, "WalkRetries": 1000, "walkRetryDelay": 0, "WalkRetryDelay": 0,
+		"emptyValueRetries": 1000, "EmptyValueRetries": 1000, "emptyValueRetryDelay": 0, "EmptyValueRetryDelay": 0}`), &data)
+	if assert.NoError(t, err) {
+		assert.Nil(t, data.MaxWalkRows)
+		assert.Nil(t, data.WritesEnabled)
+		assert.Nil(t, data.WalkRetries)
+		assert.Nil(t, data.WalkRetryDelay)
+		assert.Nil(t, data.EmptyValueRetries)
+		assert.Nil(t, data.EmptyValueRetryDelay)
+	}
+}
@@ -0,0 +1,297 @@
+package network
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SNMPSessionPoolStatistics
+//
+// SNMPSessionPoolStatistics contains the statistics of the snmp session pool.
+//
+// swagger:model
+type SNMPSessionPoolStatistics struct {
+	Enabled bool `json:"enabled" xml:"enabled"`
+	// Number of idle sessions in the pool
+	Size int `json:"size" xml:"size"`
+	// Number of pooled sessions that are currently used by requests
+	InUse     int     `json:"in_use" xml:"in_use"`
+	MaxSize   int     `json:"max_size" xml:"max_size"`
+	Created   uint64  `json:"created" xml:"created"`
+	Reused    uint64  `json:"reused" xml:"reused"`
+	Evictions uint64  `json:"evictions" xml:"evictions"`
+	ReuseRate float64 `json:"reuse_rate" xml:"reuse_rate"`
+}
+
+// SNMPSessionPool keeps snmp sessions open after a request, so that later requests to the same device with the same
+// connection data don't have to connect again (including the engine discovery of snmp v3).
+// A session is only used by one request at a time. Concurrent requests to the same device get separate sessions, at
+// most maxPerHost of them are pooled, further sessions are closed after their request.
+// Idle sessions are closed after the idle TTL, and the least recently used idle session is closed if the pool is full.
+type SNMPSessionPool struct {
+	sync.Mutex
+	idleTTL    time.Duration
+	maxSize    int
+	maxPerHost int
+
+	// idle contains the idle sessions, the most recently released one at the front
+	idle *list.List
+	// sessions contains the number of pooled sessions (idle and in use) per key
+	sessions map[string]int
+
+	created   uint64
+	reused    uint64
+	evictions uint64
+}
+
+type snmpSessionPoolEntry struct {
+	key     string
+	client  *snmpClient
+	state   snmpClientState
+	expires time.Time
+}
+
+// NewSNMPSessionPool creates a new snmp session pool. maxSize limits the idle sessions of the pool and maxPerHost the
+// pooled sessions per device and connection data, a value <= 0 means no limit.
+func NewSNMPSessionPool(idleTTL time.Duration, maxSize, maxPerHost int) *SNMPSessionPool {
+	return &SNMPSessionPool{
+		idleTTL:    idleTTL,
+		maxSize:    maxSize,
+		maxPerHost: maxPerHost,
+		idle:       list.New(),
+		sessions:   make(map[string]int),
+	}
+}
+
+// Get returns an snmp client for the device. An idle session of the pool is used if there is one, otherwise a new
+// session is created with NewSNMPClientByConnectionData.
+// Disconnect of the returned client returns the session to the pool.
+func (p *SNMPSessionPool) Get(ctx context.Context, ipAddress string, data *SNMPConnectionData) (SNMPClient, error) {
+	if IsSNMPRecAddress(ipAddress) {
+		return NewSNMPClientByConnectionData(ctx, ipAddress, data)
+	}
+
+	key, err := snmpSessionPoolKey(ipAddress, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry := p.take(key); entry != nil {
+		log.Ctx(ctx).Debug().Msg("reusing pooled snmp session")
+		return &pooledSNMPClient{snmpClient: entry.client, pool: p, key: key, state: entry.state, pooled: true}, nil
+	}
+
+	client, err := NewSNMPClientByConnectionData(ctx, ipAddress, data)
+	if err != nil {
+		return nil, err
+	}
+	sc, ok := client.(*snmpClient)
+	if !ok {
+		return client, nil
+	}
+
+	return &pooledSNMPClient{snmpClient: sc, pool: p, key: key, state: sc.state(), pooled: p.register(key)}, nil
+}
+
+// newSession returns another session for the request of the given client. An idle session of the pool is used if there
+// is one, otherwise a new session is opened which counts towards the per host limit of the pool.
+func (p *SNMPSessionPool) newSession(c *pooledSNMPClient) (SNMPClient, error) {
+	if entry := p.take(c.key); entry != nil {
+		// the session continues with the current settings of the request
+		entry.client.reset(c.snmpClient.state())
+		entry.client.useCache = c.snmpClient.useCache
+		return &pooledSNMPClient{snmpClient: entry.client, pool: p, key: c.key, state: entry.state, pooled: true}, nil
+	}
+
+	session, err := c.snmpClient.newSession()
+	if err != nil {
+		return nil, err
+	}
+	return &pooledSNMPClient{snmpClient: session.(*snmpClient), pool: p, key: c.key, state: c.state, pooled: p.register(c.key)}, nil
+}
+
+// take removes an idle session with the given key from the pool and returns it.
+func (p *SNMPSessionPool) take(key string) *snmpSessionPoolEntry {
+	p.Lock()
+	defer p.Unlock()
+
+	p.evictExpired()
+	for elem := p.idle.Front(); elem != nil; elem = elem.Next() {
+		if entry := elem.Value.(*snmpSessionPoolEntry); entry.key == key {
+			p.idle.Remove(elem)
+			p.reused++
+			return entry
+		}
+	}
+	return nil
+}
+
+// register counts a newly created session and returns whether it can be pooled.
+func (p *SNMPSessionPool) register(key string) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	p.created++
+	if p.maxPerHost > 0 && p.sessions[key] >= p.maxPerHost {
+		return false
+	}
+	p.sessions[key]++
+	return true
+}
+
+// release returns the session of the client to the pool, or closes it if it can't be reused.
+func (p *SNMPSessionPool) release(c *pooledSNMPClient) error {
+	if !c.pooled {
+		return c.snmpClient.Disconnect()
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if c.broken {
+		p.evictions++
+		p.unregister(c.key)
+		return c.snmpClient.Disconnect()
+	}
+
+	c.snmpClient.reset(c.state)
+	p.idle.PushFront(&snmpSessionPoolEntry{
+		key:     c.key,
+		client:  c.snmpClient,
+		state:   c.state,
+		expires: time.Now().Add(p.idleTTL),
+	})
+
+	p.evictExpired()
+	for p.maxSize > 0 && p.idle.Len() > p.maxSize {
+		p.evict(p.idle.Back())
+	}
+	return nil
+}
+
+// evictExpired closes all idle sessions whose TTL expired. The caller has to hold the lock.
+func (p *SNMPSessionPool) evictExpired() {
+	now := time.Now()
+	for elem := p.idle.Back(); elem != nil && now.After(elem.Value.(*snmpSessionPoolEntry).expires); elem = p.idle.Back() {
+		p.evict(elem)
+	}
+}
+
+// evict closes the given idle session. The caller has to hold the lock.
+func (p *SNMPSessionPool) evict(elem *list.Element) {
+	entry := p.idle.Remove(elem).(*snmpSessionPoolEntry)
+	p.evictions++
+	p.unregister(entry.key)
+	_ = entry.client.Disconnect()
+}
+
+func (p *SNMPSessionPool) unregister(key string) {
+	p.sessions[key]--
+	if p.sessions[key] <= 0 {
+		delete(p.sessions, key)
+	}
+}
+
+// Statistics returns the statistics of the pool.
+func (p *SNMPSessionPool) Statistics() SNMPSessionPoolStatistics {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := SNMPSessionPoolStatistics{
+		Enabled:   true,
+		Size:      p.idle.Len(),
+		MaxSize:   p.maxSize,
+		Created:   p.created,
+		Reused:    p.reused,
+		Evictions: p.evictions,
+	}
+	for _, sessions := range p.sessions {
+		stats.InUse += sessions
+	}
+	stats.InUse -= stats.Size
+	if total := p.created + p.reused; total > 0 {
+		stats.ReuseRate = float64(p.reused) / float64(total)
+	}
+	return stats
+}
+
+// snmpSessionPoolKey returns the pool key for the device. The connection data is part of the key, so that requests
+// with different credentials don't share a session.
+func snmpSessionPoolKey(ipAddress string, data *SNMPConnectionData) (string, error) {
+	connectionData, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(connectionData)
+	return ipAddress + "/" + hex.EncodeToString(hash[:]), nil
+}
+
+// pooledSNMPClient is an snmp client whose session belongs to an SNMPSessionPool.
+type pooledSNMPClient struct {
+	*snmpClient
+	pool  *SNMPSessionPool
+	key   string
+	state snmpClientState
+	// pooled is false if the per host limit was reached when the session was created
+	pooled bool
+	// broken is set if a request failed with an error that indicates that the session cannot be used anymore
+	broken   bool
+	released bool
+}
+
+// SNMPGet sends one or more simple snmpget requests to the target host and returns the result.
+func (c *pooledSNMPClient) SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, error) {
+	res, err := c.snmpClient.SNMPGet(ctx, oid...)
+	c.observe(err)
+	return res, err
+}
+
+// SNMPWalk sends a snmpwalk request to the specified oid.
+func (c *pooledSNMPClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, error) {
+	res, err := c.snmpClient.SNMPWalk(ctx, oid)
+	c.observe(err)
+	return res, err
+}
+
+// SNMPWalkGetNext sends a snmpwalk request to the specified oid which only uses getnext requests.
+func (c *pooledSNMPClient) SNMPWalkGetNext(ctx context.Context, oid OID) ([]SNMPResponse, error) {
+	res, err := c.snmpClient.SNMPWalkGetNext(ctx, oid)
+	c.observe(err)
+	return res, err
+}
+
+// SNMPSet sends a snmpset request with the given variables.
+func (c *pooledSNMPClient) SNMPSet(ctx context.Context, variables ...SNMPVariable) error {
+	err := c.snmpClient.SNMPSet(ctx, variables...)
+	c.observe(err)
+	return err
+}
+
+// Disconnect returns the session to the pool.
+func (c *pooledSNMPClient) Disconnect() error {
+	if c.released {
+		return nil
+	}
+	c.released = true
+	return c.pool.release(c)
+}
+
+func (c *pooledSNMPClient) observe(err error) {
+	if err != nil && isSNMPSessionError(err) {
+		c.broken = true
+	}
+}
+
+// isSNMPSessionError returns whether the error indicates that the session cannot be used anymore, e.g. because the
+// credentials of the device or its engine changed.
+func isSNMPSessionError(err error) bool {
+	return tholaerr.IsAuthError(err) || strings.Contains(strings.ToLower(err.Error()), "engine")
+}
@@ -0,0 +1,162 @@
+package network
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestSNMPSession returns a session of the pool that was created by a request, without connecting to a device.
+func newTestSNMPSession(t *testing.T, p *SNMPSessionPool, key string) *pooledSNMPClient {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &snmpClient{
+		client: &gosnmp.GoSNMP{
+			Conn:           conn.(net.Conn),
+			Community:      "public",
+			MaxRepetitions: 20,
+			MaxOids:        gosnmp.MaxOids,
+		},
+		useCache:  true,
+		getCache:  newRequestCache(),
+		walkCache: newRequestCache(),
+	}
+	return &pooledSNMPClient{snmpClient: client, pool: p, key: key, state: client.state(), pooled: p.register(key)}
+}
+
+func TestSNMPSessionPool_reuse(t *testing.T) {
+	p := NewSNMPSessionPool(time.Minute, 10, 2)
+
+	session := newTestSNMPSession(t, p, "a")
+	session.SetCommunity("public@2")
+	session.SetMaxRepetitions(5)
+	session.UseCache(false)
+	session.getCache.add(".1.3.6.1.2.1.1.1.0", nil, nil)
+	assert.NoError(t, session.Disconnect())
+	assert.NoError(t, session.Disconnect(), "releasing a session twice must not pool it twice")
+
+	assert.Nil(t, p.take("b"))
+	entry := p.take("a")
+	if assert.NotNil(t, entry) {
+		assert.Same(t, session.snmpClient, entry.client)
+		assert.Equal(t, "public", entry.client.GetCommunity())
+		assert.Equal(t, uint32(20), entry.client.GetMaxRepetitions())
+		assert.True(t, entry.client.useCache)
+		assert.Empty(t, entry.client.getCache.cache)
+	}
+	assert.Nil(t, p.take("a"))
+
+	stats := p.Statistics()
+	assert.Equal(t, uint64(1), stats.Created)
+	assert.Equal(t, uint64(1), stats.Reused)
+	assert.Equal(t, 0.5, stats.ReuseRate)
+	assert.Equal(t, 0, stats.Size)
+	assert.Equal(t, 1, stats.InUse)
+}
+
+func TestSNMPSessionPool_evictBroken(t *testing.T) {
+	p := NewSNMPSessionPool(time.Minute, 10, 2)
+
+	session := newTestSNMPSession(t, p, "a")
+	session.observe(errors.Wrap(tholaerr.NewAuthError("incoming packet is not authentic"), "error during snmpget"))
+	assert.NoError(t, session.Disconnect())
+
+	assert.Nil(t, p.take("a"))
+	stats := p.Statistics()
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, 0, stats.InUse)
+}
+
+func TestSNMPSessionPool_limits(t *testing.T) {
+	p := NewSNMPSessionPool(time.Minute, 2, 2)
+
+	a1, a2, a3 := newTestSNMPSession(t, p, "a"), newTestSNMPSession(t, p, "a"), newTestSNMPSession(t, p, "a")
+	assert.True(t, a1.pooled)
+	assert.True(t, a2.pooled)
+	assert.False(t, a3.pooled, "sessions above the per host limit must not be pooled")
+
+	b := newTestSNMPSession(t, p, "b")
+	for _, session := range []*pooledSNMPClient{a1, a2, a3, b} {
+		assert.NoError(t, session.Disconnect())
+	}
+
+	// a1 is the least recently released session and is evicted, as the pool is full
+	stats := p.Statistics()
+	assert.Equal(t, 2, stats.Size)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Same(t, a2.snmpClient, p.take("a").client)
+	assert.Nil(t, p.take("a"))
+	assert.NotNil(t, p.take("b"))
+}
+
+func TestSNMPSessionPool_idleTTL(t *testing.T) {
+	p := NewSNMPSessionPool(10*time.Millisecond, 10, 2)
+
+	assert.NoError(t, newTestSNMPSession(t, p, "a").Disconnect())
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Nil(t, p.take("a"))
+	assert.Equal(t, uint64(1), p.Statistics().Evictions)
+}
+
+func TestSNMPSessionPool_newSession(t *testing.T) {
+	p := NewSNMPSessionPool(time.Minute, 10, 2)
+
+	idle := newTestSNMPSession(t, p, "a")
+	assert.NoError(t, idle.Disconnect())
+
+	session := newTestSNMPSession(t, p, "a")
+	session.SetCommunity("public@2")
+
+	// further sessions of a request are taken from the pool and continue with the settings of the request
+	client, err := NewSNMPSession(session)
+	if assert.NoError(t, err) && assert.IsType(t, &pooledSNMPClient{}, client) {
+		parallel := client.(*pooledSNMPClient)
+		assert.Same(t, idle.snmpClient, parallel.snmpClient)
+		assert.Equal(t, "public@2", parallel.GetCommunity())
+
+		assert.NoError(t, parallel.Disconnect())
+		entry := p.take("a")
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, "public", entry.client.GetCommunity())
+		}
+	}
+
+	// snmp v3 clients without a pool don't open further sessions
+	_, err = NewSNMPSession(&snmpClient{client: &gosnmp.GoSNMP{Version: gosnmp.Version3}})
+	assert.True(t, tholaerr.IsNotImplementedError(err))
+}
+
+func TestSNMPSessionPool_concurrentWalks(t *testing.T) {
+	p := NewSNMPSessionPool(time.Minute, 10, 2)
+
+	session := newTestSNMPSession(t, p, "a")
+	session.UseCache(false)
+	session.client.Timeout = 2 * time.Second
+	session.client.Retries = 1
+
+	// the operation settings of concurrent walks are applied to the same session, so the walks must not overlap
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			timeout, retries := i%3+1, i%2
+			ctx := NewContextWithSNMPOperationSettings(context.Background(), SNMPOperationSettings{Timeout: &timeout, Retries: &retries})
+			_, _ = session.SNMPWalk(ctx, "1.3.6.1.2.1.1")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2*time.Second, session.client.Timeout)
+	assert.Equal(t, 1, session.client.Retries)
+	assert.NoError(t, session.Disconnect())
+}
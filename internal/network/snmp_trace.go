@@ -0,0 +1,98 @@
+package network
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SNMPTrace
+//
+// SNMPTrace contains the snmp requests that were sent to a device during a request.
+//
+// swagger:model
+type SNMPTrace struct {
+	// The traced snmp requests in the order they were finished
+	Entries []SNMPTraceEntry `yaml:"entries" json:"entries" xml:"entries"`
+	// Number of snmp requests that weren't traced because the maximum number of entries was reached
+	//
+	// example: 0
+	Dropped int `yaml:"dropped,omitempty" json:"dropped,omitempty" xml:"dropped,omitempty"`
+}
+
+// SNMPTraceEntry
+//
+// SNMPTraceEntry describes one snmp request that was sent to a device.
+//
+// swagger:model
+type SNMPTraceEntry struct {
+	// The snmp operation
+	//
+	// example: walk
+	Operation string `yaml:"operation" json:"operation" xml:"operation"`
+	// The requested OIDs
+	OIDs []string `yaml:"oids" json:"oids" xml:"oids"`
+	// Number of PDUs in the response
+	//
+	// example: 24
+	PDUs int `yaml:"pdus" json:"pdus" xml:"pdus"`
+	// Duration of the request in milliseconds
+	//
+	// example: 12.5
+	Duration float64 `yaml:"duration_ms" json:"duration_ms" xml:"duration_ms"`
+	// The error of the request if it failed
+	Error string `yaml:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// snmpTraceRecorder collects the trace entries of a request up to a maximum number of entries.
+type snmpTraceRecorder struct {
+	sync.Mutex
+
+	maxEntries int
+	trace      SNMPTrace
+}
+
+// NewContextWithSNMPTrace returns a new context which records all snmp requests sent with it (or a derived context).
+// At most maxEntries requests are recorded, a value <= 0 means no limit.
+func NewContextWithSNMPTrace(ctx context.Context, maxEntries int) context.Context {
+	return context.WithValue(ctx, snmpTraceKey, &snmpTraceRecorder{maxEntries: maxEntries})
+}
+
+// SNMPTraceFromContext returns a copy of the snmp trace recorded so far for the context.
+func SNMPTraceFromContext(ctx context.Context) (SNMPTrace, bool) {
+	recorder, ok := ctx.Value(snmpTraceKey).(*snmpTraceRecorder)
+	if !ok {
+		return SNMPTrace{}, false
+	}
+	recorder.Lock()
+	defer recorder.Unlock()
+	return SNMPTrace{
+		Entries: append([]SNMPTraceEntry{}, recorder.trace.Entries...),
+		Dropped: recorder.trace.Dropped,
+	}, true
+}
+
+func snmpTraceRecorderFromContext(ctx context.Context) (*snmpTraceRecorder, bool) {
+	recorder, ok := ctx.Value(snmpTraceKey).(*snmpTraceRecorder)
+	return recorder, ok
+}
+
+func (r *snmpTraceRecorder) add(operation string, oids []string, pdus int, start time.Time, err error) {
+	entry := SNMPTraceEntry{
+		Operation: operation,
+		OIDs:      oids,
+		PDUs:      pdus,
+		Duration:  float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if r.maxEntries > 0 && len(r.trace.Entries) >= r.maxEntries {
+		r.trace.Dropped++
+		return
+	}
+	r.trace.Entries = append(r.trace.Entries, entry)
+}
@@ -0,0 +1,36 @@
+package network
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSNMPTrace(t *testing.T) {
+	ctx := NewContextWithSNMPTrace(context.Background(), 2)
+	recorder, ok := snmpTraceRecorderFromContext(ctx)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	recorder.add("get", []string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.2.0"}, 2, time.Now(), nil)
+	recorder.add("walk", []string{".1.3.6.1.2.1.2.2.1.2"}, 0, time.Now(), errors.New("request timeout (after 1 retries)"))
+	recorder.add("walk", []string{".1.3.6.1.2.1.2.2.1.3"}, 24, time.Now(), nil)
+
+	trace, ok := SNMPTraceFromContext(ctx)
+	if assert.True(t, ok) && assert.Len(t, trace.Entries, 2) {
+		assert.Equal(t, "get", trace.Entries[0].Operation)
+		assert.Equal(t, 2, trace.Entries[0].PDUs)
+		assert.Empty(t, trace.Entries[0].Error)
+		assert.Equal(t, "walk", trace.Entries[1].Operation)
+		assert.Equal(t, "request timeout (after 1 retries)", trace.Entries[1].Error)
+		assert.Equal(t, 1, trace.Dropped)
+	}
+}
+
+func TestSNMPTrace_notEnabled(t *testing.T) {
+	_, ok := SNMPTraceFromContext(context.Background())
+	assert.False(t, ok)
+}
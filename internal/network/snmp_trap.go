@@ -0,0 +1,183 @@
+package network
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	snmpTrapOID     = "1.3.6.1.6.3.1.1.4.1.0"
+	snmpTrapAddress = "1.3.6.1.6.3.18.1.3.0"
+	// snmpTrapsPrefix is the prefix of the trap oids of the generic SNMPv1 traps, see RFC 3584 section 3.2.
+	snmpTrapsPrefix = "1.3.6.1.6.3.1.1.5"
+)
+
+// SNMPTrap is a trap that was received from a device.
+type SNMPTrap struct {
+	// Host is the address of the device that sent the trap. Traps that were forwarded by a trusted relay are mapped to
+	// the address of the original device, if the relay includes it.
+	Host string
+	// OID is the snmpTrapOID of the trap. Generic SNMPv1 traps are translated to their SNMPv2 trap oid.
+	OID OID
+}
+
+// SNMPTrapListenerData includes the credentials that are accepted by an SNMPTrapListener.
+type SNMPTrapListenerData struct {
+	// Community that is accepted for SNMP v1 and v2c traps. If it is empty, v1 and v2c traps are dropped.
+	Community string
+	// V3Data contains the USM user that is accepted for SNMP v3 traps. If no user is set, v3 traps are dropped.
+	V3Data SNMPv3ConnectionData
+	// Relays contains the IPs or CIDR ranges of the trap relays. The device address in a trap (snmpTrapAddress or the
+	// agent address of v1 traps) is only used if the trap was sent by one of them, otherwise the sender is the device.
+	Relays []string
+}
+
+// SNMPTrapListener listens for SNMP traps and passes them to a handler.
+type SNMPTrapListener struct {
+	listener  *gosnmp.TrapListener
+	community string
+	v3        bool
+	relays    []*net.IPNet
+}
+
+// NewSNMPTrapListener creates a new trap listener which calls the handler for every accepted trap.
+func NewSNMPTrapListener(data SNMPTrapListenerData, handler func(SNMPTrap)) (*SNMPTrapListener, error) {
+	params := &gosnmp.GoSNMP{
+		Version: gosnmp.Version2c,
+		Timeout: time.Second,
+	}
+
+	l := SNMPTrapListener{
+		listener:  gosnmp.NewTrapListener(),
+		community: data.Community,
+	}
+
+	for _, relay := range data.Relays {
+		relayNet, err := ParseIPNet(relay)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trap relay '%s'", relay)
+		}
+		l.relays = append(l.relays, relayNet)
+	}
+
+	if !isEmptyString(data.V3Data.User) {
+		if err := ValidateSNMPv3ConnectionData(data.V3Data); err != nil {
+			return nil, errors.Wrap(err, "invalid snmp v3 data")
+		}
+		securityParameters, msgFlags, err := getUsmSecurityParameters(data.V3Data)
+		if err != nil {
+			return nil, err
+		}
+		params.SecurityModel = gosnmp.UserSecurityModel
+		params.SecurityParameters = securityParameters
+		params.MsgFlags = msgFlags
+		l.v3 = true
+	}
+
+	l.listener.Params = params
+	l.listener.OnNewTrap = func(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+		trap, err := l.parse(packet, addr)
+		if err != nil {
+			log.Debug().Err(err).Str("source", addr.String()).Msg("dropped snmp trap")
+			return
+		}
+		handler(trap)
+	}
+
+	return &l, nil
+}
+
+// Listen listens for traps on the given udp address. It blocks until the listener is closed.
+func (l *SNMPTrapListener) Listen(addr string) error {
+	return l.listener.Listen(addr)
+}
+
+// Listening returns a channel that receives a value as soon as the listener is ready to receive traps.
+func (l *SNMPTrapListener) Listening() <-chan bool {
+	return l.listener.Listening()
+}
+
+// Close stops the listener.
+func (l *SNMPTrapListener) Close() {
+	l.listener.Close()
+}
+
+func (l *SNMPTrapListener) parse(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) (SNMPTrap, error) {
+	switch packet.Version {
+	case gosnmp.Version1, gosnmp.Version2c:
+		if l.community == "" || packet.Community != l.community {
+			return SNMPTrap{}, fmt.Errorf("community of snmp %s trap is not accepted", packet.Version)
+		}
+	case gosnmp.Version3:
+		if !l.v3 {
+			return SNMPTrap{}, errors.New("snmp v3 traps are not accepted")
+		}
+	}
+
+	trap := SNMPTrap{
+		Host: addr.IP.String(),
+	}
+	relayed := l.isRelay(addr.IP)
+
+	if packet.Version == gosnmp.Version1 {
+		if relayed && packet.AgentAddress != "" && packet.AgentAddress != "0.0.0.0" {
+			trap.Host = packet.AgentAddress
+		}
+		if packet.GenericTrap < 6 {
+			trap.OID = OID(fmt.Sprintf("%s.%d", snmpTrapsPrefix, packet.GenericTrap+1))
+		} else {
+			trap.OID = OID(fmt.Sprintf("%s.0.%d", strings.TrimPrefix(packet.Enterprise, "."), packet.SpecificTrap))
+		}
+		return trap, nil
+	}
+
+	for _, variable := range packet.Variables {
+		switch strings.TrimPrefix(variable.Name, ".") {
+		case snmpTrapOID:
+			oid, ok := variable.Value.(string)
+			if !ok {
+				return SNMPTrap{}, errors.New("snmpTrapOID is not an oid")
+			}
+			trap.OID = OID(strings.TrimPrefix(oid, "."))
+		case snmpTrapAddress:
+			if address, ok := variable.Value.(string); ok && address != "" && relayed {
+				trap.Host = address
+			}
+		}
+	}
+	if trap.OID == "" {
+		return SNMPTrap{}, errors.New("snmp trap has no snmpTrapOID")
+	}
+	return trap, nil
+}
+
+// isRelay returns whether the ip belongs to a trusted trap relay.
+func (l *SNMPTrapListener) isRelay(ip net.IP) bool {
+	for _, relay := range l.relays {
+		if relay.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseIPNet parses an IP or a CIDR range. A single IP is returned as a range which only contains the IP.
+func ParseIPNet(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		return ipNet, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("'%s' is not an ip address", s)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
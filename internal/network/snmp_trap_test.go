@@ -0,0 +1,140 @@
+package network
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+// startTestSNMPTrapListener starts a trap listener on a free loopback port and returns the port and the channel which
+// receives the accepted traps.
+func startTestSNMPTrapListener(t *testing.T, data SNMPTrapListenerData) (uint16, <-chan SNMPTrap) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	traps := make(chan SNMPTrap, 10)
+	l, err := NewSNMPTrapListener(data, func(trap SNMPTrap) {
+		traps <- trap
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- l.Listen(fmt.Sprintf("127.0.0.1:%d", port))
+	}()
+	select {
+	case <-l.Listening():
+	case err := <-errs:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("trap listener didn't start")
+	}
+	t.Cleanup(l.Close)
+
+	return uint16(port), traps
+}
+
+// sendTestSNMPTrap sends a trap to the loopback port.
+func sendTestSNMPTrap(t *testing.T, port uint16, version gosnmp.SnmpVersion, community string, trap gosnmp.SnmpTrap) {
+	client := &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      port,
+		Transport: "udp",
+		Community: community,
+		Version:   version,
+		Timeout:   time.Second,
+	}
+	if err := client.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Conn.Close()
+
+	if _, err := client.SendTrap(trap); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func receiveTestSNMPTrap(t *testing.T, traps <-chan SNMPTrap) SNMPTrap {
+	select {
+	case trap := <-traps:
+		return trap
+	case <-time.After(5 * time.Second):
+		t.Fatal("no trap received")
+	}
+	return SNMPTrap{}
+}
+
+func TestSNMPTrapListener(t *testing.T) {
+	port, traps := startTestSNMPTrapListener(t, SNMPTrapListenerData{Community: "traps", Relays: []string{"127.0.0.0/8"}})
+
+	linkDown := gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+			{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+		},
+	}
+
+	// traps with a wrong community are dropped
+	sendTestSNMPTrap(t, port, gosnmp.Version2c, "public", linkDown)
+	sendTestSNMPTrap(t, port, gosnmp.Version2c, "traps", linkDown)
+	assert.Equal(t, SNMPTrap{Host: "127.0.0.1", OID: "1.3.6.1.6.3.1.1.5.3"}, receiveTestSNMPTrap(t, traps))
+
+	// traps forwarded by a trusted relay are mapped to the original device
+	relayed := gosnmp.SnmpTrap{
+		Variables: append([]gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.18.1.3.0", Type: gosnmp.IPAddress, Value: "192.0.2.1"},
+		}, linkDown.Variables...),
+	}
+	sendTestSNMPTrap(t, port, gosnmp.Version2c, "traps", relayed)
+	assert.Equal(t, SNMPTrap{Host: "192.0.2.1", OID: "1.3.6.1.6.3.1.1.5.3"}, receiveTestSNMPTrap(t, traps))
+
+	// generic v1 traps are translated to their v2 trap oid
+	coldStart := gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.14988",
+		AgentAddress: "192.0.2.2",
+		GenericTrap:  0,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: "RouterOS CHR"},
+		},
+	}
+	sendTestSNMPTrap(t, port, gosnmp.Version1, "traps", coldStart)
+	assert.Equal(t, SNMPTrap{Host: "192.0.2.2", OID: "1.3.6.1.6.3.1.1.5.1"}, receiveTestSNMPTrap(t, traps))
+}
+
+func TestSNMPTrapListener_untrustedRelay(t *testing.T) {
+	port, traps := startTestSNMPTrapListener(t, SNMPTrapListenerData{Community: "traps", Relays: []string{"192.0.2.0/24"}})
+
+	// the device address in the trap is ignored, because the sender is not a trusted relay
+	relayed := gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.18.1.3.0", Type: gosnmp.IPAddress, Value: "192.0.2.1"},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+		},
+	}
+	sendTestSNMPTrap(t, port, gosnmp.Version2c, "traps", relayed)
+	assert.Equal(t, SNMPTrap{Host: "127.0.0.1", OID: "1.3.6.1.6.3.1.1.5.3"}, receiveTestSNMPTrap(t, traps))
+
+	coldStart := gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.14988",
+		AgentAddress: "192.0.2.2",
+		GenericTrap:  0,
+	}
+	sendTestSNMPTrap(t, port, gosnmp.Version1, "traps", coldStart)
+	assert.Equal(t, SNMPTrap{Host: "127.0.0.1", OID: "1.3.6.1.6.3.1.1.5.1"}, receiveTestSNMPTrap(t, traps))
+}
+
+func TestNewSNMPTrapListener_invalidRelay(t *testing.T) {
+	_, err := NewSNMPTrapListener(SNMPTrapListenerData{Relays: []string{"not an ip"}}, func(SNMPTrap) {})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"sync"
+)
+
+/*
+snmpWalkCache stores the results of snmp walks for the lifetime of a context.
+Concurrent walks of the same oid wait for the first one instead of sending the same walk again.
+*/
+type snmpWalkCache struct {
+	sync.Mutex
+
+	entries map[string]*snmpWalkCacheEntry
+}
+
+type snmpWalkCacheEntry struct {
+	done chan struct{}
+	res  []SNMPResponse
+	err  error
+}
+
+// NewContextWithSNMPWalkCache returns a new context with an empty snmp walk cache.
+// All snmp walks done with SNMPWalkCached and this context (or a derived one) share the cache.
+func NewContextWithSNMPWalkCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, snmpWalkCacheKey, &snmpWalkCache{
+		entries: make(map[string]*snmpWalkCacheEntry),
+	})
+}
+
+// NewContextWithSNMPWalkCacheBypass returns a new context that bypasses the snmp walk cache if b is true.
+func NewContextWithSNMPWalkCacheBypass(ctx context.Context, b bool) context.Context {
+	return context.WithValue(ctx, snmpWalkCacheBypassKey, b)
+}
+
+func snmpWalkCacheFromContext(ctx context.Context) (*snmpWalkCache, bool) {
+	if bypass, ok := ctx.Value(snmpWalkCacheBypassKey).(bool); ok && bypass {
+		return nil, false
+	}
+	cache, ok := ctx.Value(snmpWalkCacheKey).(*snmpWalkCache)
+	return cache, ok
+}
+
+// SNMPWalkCached sends a snmpwalk request to the specified oid using the snmp walk cache of the context.
+// If the context has no cache or bypasses it, the walk is sent directly.
+// Transport errors are not cached, so that failed walks can be retried.
+func SNMPWalkCached(ctx context.Context, client SNMPClient, oid OID) ([]SNMPResponse, error) {
+	return SNMPWalkCachedWithMode(ctx, client, oid, SNMPWalkModeBulk)
+}
+
+// SNMPWalkCachedWithMode works like SNMPWalkCached, but walks the oid with the given walk mode.
+// The results are cached independent of the walk mode, as they don't differ.
+func SNMPWalkCachedWithMode(ctx context.Context, client SNMPClient, oid OID, mode SNMPWalkMode) ([]SNMPResponse, error) {
+	walk := client.SNMPWalk
+	if mode == SNMPWalkModeGetNext {
+		walk = client.SNMPWalkGetNext
+	}
+
+	cache, ok := snmpWalkCacheFromContext(ctx)
+	if !ok {
+		return walk(ctx, oid)
+	}
+
+	key := oid.String()
+	cache.Lock()
+	if entry, ok := cache.entries[key]; ok {
+		cache.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "context done while waiting for cached snmp walk")
+		}
+		log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", key).Msg("used snmp walk result of request cache")
+		return entry.res, entry.err
+	}
+	entry := &snmpWalkCacheEntry{
+		done: make(chan struct{}),
+	}
+	cache.entries[key] = entry
+	cache.Unlock()
+
+	entry.res, entry.err = walk(ctx, oid)
+	if entry.err != nil && !tholaerr.IsNotFoundError(entry.err) {
+		cache.Lock()
+		delete(cache.entries, key)
+		cache.Unlock()
+	}
+	close(entry.done)
+
+	return entry.res, entry.err
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestSNMPWalkCached_concurrent(t *testing.T) {
+	var client MockSNMPClient
+	ctx := NewContextWithSNMPWalkCache(context.Background())
+
+	client.
+		On("SNMPWalk", ctx, OID("1.3.6.1.2.1.2.2.1.2")).
+		Return([]SNMPResponse{NewSNMPResponse("1.3.6.1.2.1.2.2.1.2.1", gosnmp.OctetString, "eth0")}, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := SNMPWalkCached(ctx, &client, "1.3.6.1.2.1.2.2.1.2")
+			if assert.NoError(t, err) {
+				assert.Len(t, res, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	client.AssertNumberOfCalls(t, "SNMPWalk", 1)
+}
+
+func TestSNMPWalkCached_transportErrorNotCached(t *testing.T) {
+	var client MockSNMPClient
+	ctx := NewContextWithSNMPWalkCache(context.Background())
+
+	client.
+		On("SNMPWalk", ctx, OID("1.3.6.1.2.1.2.2.1.2")).
+		Return(nil, errors.New("request timeout"))
+
+	for i := 0; i < 2; i++ {
+		_, err := SNMPWalkCached(ctx, &client, "1.3.6.1.2.1.2.2.1.2")
+		assert.Error(t, err)
+	}
+
+	client.AssertNumberOfCalls(t, "SNMPWalk", 2)
+}
@@ -0,0 +1,454 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SNMPRecScheme is the scheme of device addresses which are served from recorded snmp data instead of a device.
+// The address is either the path of a .snmprec or .snmpwalk file, or the path of a directory which contains a file
+// for every community, named like the community (e.g. "public.snmprec"), as it is done by snmpsim.
+const SNMPRecScheme = "file://"
+
+// snmpRecFileExtensions are the supported file extensions of recorded snmp data in the order they are looked up.
+var snmpRecFileExtensions = []string{".snmprec", ".snmpwalk"}
+
+// IsSNMPRecAddress checks if the address of a device refers to recorded snmp data.
+func IsSNMPRecAddress(address string) bool {
+	return strings.HasPrefix(address, SNMPRecScheme)
+}
+
+// snmpRecClient is a snmp client which serves snmp requests from recorded snmp data.
+type snmpRecClient struct {
+	community      string
+	entries        []snmpRecEntry
+	index          map[string]int
+	maxRepetitions uint32
+	maxOIDs        int
+}
+
+type snmpRecEntry struct {
+	oid      OID
+	parts    []int
+	response SNMPResponse
+}
+
+// NewSNMPRecClient creates a snmp client which serves the snmp requests from the recorded snmp data of the given
+// address. If the address is a directory, the file of the first community which has a file is used.
+func NewSNMPRecClient(ctx context.Context, address string, communities []string) (SNMPClient, error) {
+	path := strings.TrimPrefix(address, SNMPRecScheme)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, tholaerr.NewSNMPError(fmt.Sprintf("failed to open recorded snmp data: %s", err.Error()))
+	}
+
+	if !info.IsDir() {
+		community := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		return newSNMPRecClientFromFile(ctx, path, community)
+	}
+
+	for _, community := range communities {
+		for _, ext := range snmpRecFileExtensions {
+			file := filepath.Join(path, community+ext)
+			if _, err := os.Stat(file); err != nil {
+				continue
+			}
+			return newSNMPRecClientFromFile(ctx, file, community)
+		}
+	}
+	return nil, tholaerr.NewSNMPError(fmt.Sprintf("no recorded snmp data found in '%s' for any community", path))
+}
+
+func newSNMPRecClientFromFile(ctx context.Context, path, community string) (SNMPClient, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open recorded snmp data")
+	}
+	defer file.Close()
+
+	parseLine := parseSNMPRecLine
+	if filepath.Ext(path) == ".snmpwalk" {
+		parseLine = parseSNMPWalkLine
+	}
+
+	client := snmpRecClient{
+		community: community,
+		index:     make(map[string]int),
+		maxOIDs:   gosnmp.MaxOids,
+	}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		response, err := parseLine(text)
+		if err != nil {
+			return nil, tholaerr.NewParseError(fmt.Sprintf("%s:%d: %s", path, line, err.Error()))
+		}
+		parts, err := response.oid.parts()
+		if err != nil {
+			return nil, tholaerr.NewParseError(fmt.Sprintf("%s:%d: %s", path, line, err.Error()))
+		}
+		client.entries = append(client.entries, snmpRecEntry{
+			oid:      response.oid,
+			parts:    parts,
+			response: response,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read recorded snmp data")
+	}
+
+	sort.SliceStable(client.entries, func(i, j int) bool {
+		return compareOIDParts(client.entries[i].parts, client.entries[j].parts) < 0
+	})
+	for i, entry := range client.entries {
+		client.index[entry.oid.String()] = i
+	}
+
+	log.Ctx(ctx).Debug().Str("file", path).Int("oids", len(client.entries)).Msg("loaded recorded snmp data")
+	return &client, nil
+}
+
+// parseSNMPRecLine parses a line of a .snmprec file, which has the format "<oid>|<tag>|<value>".
+// Tags with the suffix "x" contain hex encoded values.
+func parseSNMPRecLine(line string) (SNMPResponse, error) {
+	fields := strings.SplitN(line, "|", 3)
+	if len(fields) != 3 {
+		return SNMPResponse{}, errors.New("line has not the format '<oid>|<tag>|<value>'")
+	}
+	oid, tag, val := OID(strings.TrimPrefix(fields[0], ".")), fields[1], fields[2]
+
+	if strings.HasSuffix(tag, "x") {
+		tag = strings.TrimSuffix(tag, "x")
+		b, err := hex.DecodeString(val)
+		if err != nil {
+			return SNMPResponse{}, errors.Wrap(err, "invalid hex value")
+		}
+		val = string(b)
+	}
+	t, err := strconv.Atoi(tag)
+	if err != nil {
+		return SNMPResponse{}, fmt.Errorf("unsupported tag '%s'", fields[1])
+	}
+
+	snmpType := gosnmp.Asn1BER(t)
+	switch snmpType {
+	case gosnmp.OctetString, gosnmp.Opaque, gosnmp.BitString:
+		return NewSNMPResponse(oid, snmpType, []byte(val)), nil
+	case gosnmp.IPAddress:
+		if len(val) == net.IPv4len && net.ParseIP(val) == nil {
+			val = net.IP(val).String()
+		}
+	}
+	return newSNMPRecResponse(oid, snmpType, val)
+}
+
+// snmpWalkTypes maps the types of the output of snmpwalk to their asn1 types.
+var snmpWalkTypes = map[string]gosnmp.Asn1BER{
+	"INTEGER":    gosnmp.Integer,
+	"STRING":     gosnmp.OctetString,
+	"Hex-STRING": gosnmp.OctetString,
+	"BITS":       gosnmp.OctetString,
+	"OID":        gosnmp.ObjectIdentifier,
+	"IpAddress":  gosnmp.IPAddress,
+	"Counter32":  gosnmp.Counter32,
+	"Gauge32":    gosnmp.Gauge32,
+	"Timeticks":  gosnmp.TimeTicks,
+	"Opaque":     gosnmp.Opaque,
+	"Counter64":  gosnmp.Counter64,
+	"Null":       gosnmp.Null,
+}
+
+// parseSNMPWalkLine parses a line of the output of snmpwalk with numeric oids, e.g. "snmpwalk -On -Oe", which has the
+// format "<oid> = <type>: <value>".
+func parseSNMPWalkLine(line string) (SNMPResponse, error) {
+	fields := strings.SplitN(line, " = ", 2)
+	if len(fields) != 2 {
+		return SNMPResponse{}, errors.New("line has not the format '<oid> = <type>: <value>'")
+	}
+	oid := OID(strings.TrimPrefix(strings.TrimSpace(fields[0]), "."))
+
+	typeAndValue := strings.SplitN(fields[1], ":", 2)
+	if len(typeAndValue) == 1 {
+		// empty strings are returned without type by snmpwalk
+		if typeAndValue[0] == `""` {
+			return NewSNMPResponse(oid, gosnmp.OctetString, []byte{}), nil
+		}
+		return SNMPResponse{}, errors.New("missing type")
+	}
+	typeString, val := typeAndValue[0], strings.TrimSpace(typeAndValue[1])
+	snmpType, ok := snmpWalkTypes[typeString]
+	if !ok {
+		return SNMPResponse{}, fmt.Errorf("unsupported type '%s'", typeString)
+	}
+
+	switch typeString {
+	case "STRING":
+		if unquoted, err := strconv.Unquote(val); err == nil {
+			val = unquoted
+		}
+		return NewSNMPResponse(oid, snmpType, []byte(val)), nil
+	case "Hex-STRING", "BITS", "Opaque":
+		b, err := decodeSNMPWalkHex(val)
+		if err != nil {
+			return SNMPResponse{}, errors.Wrap(err, "invalid hex value")
+		}
+		return NewSNMPResponse(oid, snmpType, b), nil
+	case "Timeticks":
+		// "(<ticks>) <duration>"
+		if i := strings.Index(val, ")"); strings.HasPrefix(val, "(") && i != -1 {
+			val = val[1:i]
+		}
+	case "INTEGER":
+		// enums are printed as "<name>(<value>)" without -Oe
+		if i := strings.Index(val, "("); i != -1 && strings.HasSuffix(val, ")") {
+			val = val[i+1 : len(val)-1]
+		}
+	}
+	return newSNMPRecResponse(oid, snmpType, val)
+}
+
+// decodeSNMPWalkHex decodes a hex value of snmpwalk, e.g. "00 1A 2B". BITS are followed by the names of the set bits,
+// which are ignored.
+func decodeSNMPWalkHex(val string) ([]byte, error) {
+	var res []byte
+	for _, field := range strings.Fields(val) {
+		if len(field) != 2 {
+			break
+		}
+		b, err := hex.DecodeString(field)
+		if err != nil {
+			if len(res) > 0 {
+				break
+			}
+			return nil, err
+		}
+		res = append(res, b...)
+	}
+	return res, nil
+}
+
+// newSNMPRecResponse creates a snmp response with a value of the same go type as gosnmp would return it.
+func newSNMPRecResponse(oid OID, snmpType gosnmp.Asn1BER, val string) (SNMPResponse, error) {
+	var v interface{}
+	var err error
+	switch snmpType {
+	case gosnmp.Integer:
+		v, err = strconv.Atoi(val)
+	case gosnmp.Counter32, gosnmp.Gauge32:
+		var u uint64
+		u, err = strconv.ParseUint(val, 10, 32)
+		v = uint(u)
+	case gosnmp.TimeTicks:
+		var u uint64
+		u, err = strconv.ParseUint(val, 10, 32)
+		v = uint32(u)
+	case gosnmp.Counter64:
+		v, err = strconv.ParseUint(val, 10, 64)
+	case gosnmp.ObjectIdentifier:
+		v = "." + strings.TrimPrefix(val, ".")
+	case gosnmp.IPAddress:
+		if net.ParseIP(val) == nil {
+			err = fmt.Errorf("invalid ip address '%s'", val)
+		}
+		v = val
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		v = nil
+	default:
+		return SNMPResponse{}, fmt.Errorf("unsupported type %d", snmpType)
+	}
+	if err != nil {
+		return SNMPResponse{}, errors.Wrapf(err, "invalid value for type %s", snmpType)
+	}
+	return NewSNMPResponse(oid, snmpType, v), nil
+}
+
+// SNMPGet returns the recorded values of the given oids.
+func (s *snmpRecClient) SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, error) {
+	var res []SNMPResponse
+	var successful bool
+	for _, o := range oid {
+		o = OID(strings.TrimPrefix(o.String(), "."))
+		i, ok := s.index[o.String()]
+		if !ok {
+			log.Ctx(ctx).Trace().Str("network_request", "snmpget").Str("oid", o.String()).Msg("No Such Object available in recorded snmp data at this OID")
+			res = append(res, NewSNMPResponse(o, gosnmp.NoSuchObject, nil))
+			continue
+		}
+		res = append(res, s.entries[i].response)
+		if s.entries[i].response.WasSuccessful() {
+			successful = true
+		}
+	}
+	if !successful {
+		return nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")
+	}
+	return res, nil
+}
+
+// SNMPWalk returns the recorded values of the subtree of the given oid. Like gosnmp, the value of the oid itself is
+// returned if the subtree is empty.
+func (s *snmpRecClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, error) {
+	root, err := OID(strings.TrimPrefix(oid.String(), ".")).parts()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid oid")
+	}
+
+	start := sort.Search(len(s.entries), func(i int) bool {
+		return compareOIDParts(s.entries[i].parts, root) > 0
+	})
+
+	var res []SNMPResponse
+	for _, entry := range s.entries[start:] {
+		if len(entry.parts) <= len(root) || compareOIDParts(entry.parts[:len(root)], root) != 0 {
+			break
+		}
+		res = append(res, entry.response)
+	}
+
+	if res == nil {
+		if i, ok := s.index[strings.TrimPrefix(oid.String(), ".")]; ok && s.entries[i].response.WasSuccessful() {
+			return []SNMPResponse{s.entries[i].response}, nil
+		}
+		log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Msg("No Such Object available in recorded snmp data at this OID")
+		return nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")
+	}
+	return res, nil
+}
+
+// SNMPWalkGetNext returns the same recorded responses as SNMPWalk, the kind of requests doesn't matter for recorded data.
+func (s *snmpRecClient) SNMPWalkGetNext(ctx context.Context, oid OID) ([]SNMPResponse, error) {
+	return s.SNMPWalk(ctx, oid)
+}
+
+// SNMPSet is not supported, recorded snmp data is read-only.
+func (s *snmpRecClient) SNMPSet(context.Context, ...SNMPVariable) error {
+	return tholaerr.NewNotImplementedError("snmpset is not supported for recorded snmp data")
+}
+
+// Disconnect does nothing, as there is no connection.
+func (s *snmpRecClient) Disconnect() error {
+	return nil
+}
+
+// UseCache does nothing, the recorded snmp data is always in memory.
+func (s *snmpRecClient) UseCache(bool) {}
+
+// HasSuccessfulCachedRequest returns if there is recorded snmp data.
+func (s *snmpRecClient) HasSuccessfulCachedRequest() bool {
+	return len(s.entries) > 0
+}
+
+// GetCommunity returns the community of the recorded snmp data.
+func (s *snmpRecClient) GetCommunity() string {
+	return s.community
+}
+
+// SetCommunity updates the community string.
+func (s *snmpRecClient) SetCommunity(community string) {
+	s.community = community
+}
+
+// GetPort returns the default snmp port.
+func (s *snmpRecClient) GetPort() int {
+	return 161
+}
+
+// GetVersion returns the snmp version, recorded snmp data is always served like snmp v2c.
+func (s *snmpRecClient) GetVersion() string {
+	return "2c"
+}
+
+// GetMaxRepetitions returns the max repetitions.
+func (s *snmpRecClient) GetMaxRepetitions() uint32 {
+	return s.maxRepetitions
+}
+
+// SetMaxRepetitions sets the max repetitions, they don't affect the recorded snmp data.
+func (s *snmpRecClient) SetMaxRepetitions(maxRepetitions uint32) {
+	s.maxRepetitions = maxRepetitions
+}
+
+// SetMaxOIDs sets the max oids, they don't affect the recorded snmp data.
+func (s *snmpRecClient) SetMaxOIDs(maxOIDs int) error {
+	if maxOIDs < 0 {
+		return errors.New("cannot set max oids to a negative number")
+	}
+	s.maxOIDs = maxOIDs
+	return nil
+}
+
+// GetV3Level returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3Level() *string {
+	return nil
+}
+
+// GetV3ContextName returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3ContextName() *string {
+	return nil
+}
+
+// GetV3User returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3User() *string {
+	return nil
+}
+
+// GetV3AuthKey returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3AuthKey() *string {
+	return nil
+}
+
+// GetV3AuthProto returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3AuthProto() *string {
+	return nil
+}
+
+// GetV3PrivKey returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3PrivKey() *string {
+	return nil
+}
+
+// GetV3PrivProto returns nil, as recorded snmp data is served like snmp v2c.
+func (s *snmpRecClient) GetV3PrivProto() *string {
+	return nil
+}
+
+// parts returns the numeric parts of the oid.
+func (o OID) parts() ([]int, error) {
+	var res []int
+	for _, p := range strings.Split(strings.Trim(o.String(), "."), ".") {
+		i, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid oid '%s'", o)
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+func compareOIDParts(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,149 @@
+package network
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testSNMPRec = `1.3.6.1.2.1.1.1.0|4x|526f757465724f5320434852
+1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.14988.1
+1.3.6.1.2.1.1.3.0|67|1097900
+1.3.6.1.2.1.2.2.1.2.10|4|ether10
+1.3.6.1.2.1.2.2.1.2.2|4|ether2
+1.3.6.1.2.1.2.2.1.2.1|4|ether1
+1.3.6.1.2.1.2.2.1.5.1|66|1000000000
+1.3.6.1.2.1.4.20.1.1.192.0.2.1|64x|c0000201
+1.3.6.1.2.1.31.1.1.1.6.1|70|18446744073709551615
+`
+
+func writeTestSNMPRecFile(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "thola-snmprec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestNewSNMPRecClient(t *testing.T) {
+	dir := writeTestSNMPRecFile(t, "device.snmprec", testSNMPRec)
+
+	client, err := NewSNMPRecClient(context.Background(), SNMPRecScheme+dir, []string{"public", "device"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "device", client.GetCommunity())
+		assert.Equal(t, "2c", client.GetVersion())
+	}
+
+	client, err = NewSNMPRecClient(context.Background(), SNMPRecScheme+filepath.Join(dir, "device.snmprec"), nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "device", client.GetCommunity())
+	}
+
+	_, err = NewSNMPRecClient(context.Background(), SNMPRecScheme+dir, []string{"public"})
+	assert.True(t, tholaerr.IsNetworkError(err))
+}
+
+func TestNewSNMPRecClient_invalidLine(t *testing.T) {
+	dir := writeTestSNMPRecFile(t, "public.snmprec", "1.3.6.1.2.1.1.1.0|4|ok\n1.3.6.1.2.1.1.3.0|67|abc\n")
+
+	_, err := NewSNMPRecClient(context.Background(), SNMPRecScheme+dir, []string{"public"})
+	if assert.Error(t, err) {
+		assert.True(t, tholaerr.IsParseError(err))
+		assert.Contains(t, err.Error(), "public.snmprec:2")
+	}
+}
+
+func TestSNMPRecClient_SNMPGet(t *testing.T) {
+	dir := writeTestSNMPRecFile(t, "public.snmprec", testSNMPRec)
+	client, err := NewSNMPRecClient(context.Background(), SNMPRecScheme+dir, []string{"public"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := client.SNMPGet(context.Background(), ".1.3.6.1.2.1.1.1.0", "1.3.6.1.2.1.1.2.0", "1.3.6.1.2.1.1.3.0", "1.3.6.1.2.1.1.4.0")
+	if assert.NoError(t, err) && assert.Len(t, res, 4) {
+		assert.Equal(t, NewSNMPResponse("1.3.6.1.2.1.1.1.0", gosnmp.OctetString, []byte("RouterOS CHR")), res[0])
+		assert.Equal(t, NewSNMPResponse("1.3.6.1.2.1.1.2.0", gosnmp.ObjectIdentifier, ".1.3.6.1.4.1.14988.1"), res[1])
+		assert.Equal(t, NewSNMPResponse("1.3.6.1.2.1.1.3.0", gosnmp.TimeTicks, uint32(1097900)), res[2])
+		assert.False(t, res[3].WasSuccessful())
+	}
+
+	_, err = client.SNMPGet(context.Background(), "1.3.6.1.2.1.1.4.0")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestSNMPRecClient_SNMPWalk(t *testing.T) {
+	dir := writeTestSNMPRecFile(t, "public.snmprec", testSNMPRec)
+	client, err := NewSNMPRecClient(context.Background(), SNMPRecScheme+dir, []string{"public"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the responses are sorted numerically, not like the file
+	res, err := client.SNMPWalk(context.Background(), "1.3.6.1.2.1.2.2.1.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []SNMPResponse{
+			NewSNMPResponse("1.3.6.1.2.1.2.2.1.2.1", gosnmp.OctetString, []byte("ether1")),
+			NewSNMPResponse("1.3.6.1.2.1.2.2.1.2.2", gosnmp.OctetString, []byte("ether2")),
+			NewSNMPResponse("1.3.6.1.2.1.2.2.1.2.10", gosnmp.OctetString, []byte("ether10")),
+		}, res)
+	}
+
+	res, err = client.SNMPWalk(context.Background(), ".1.3.6.1.2.1.4.20.1.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []SNMPResponse{
+			NewSNMPResponse("1.3.6.1.2.1.4.20.1.1.192.0.2.1", gosnmp.IPAddress, "192.0.2.1"),
+		}, res)
+	}
+
+	// a walk of a scalar returns the scalar itself
+	res, err = client.SNMPWalk(context.Background(), "1.3.6.1.2.1.31.1.1.1.6.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []SNMPResponse{
+			NewSNMPResponse("1.3.6.1.2.1.31.1.1.1.6.1", gosnmp.Counter64, uint64(18446744073709551615)),
+		}, res)
+	}
+
+	// 1.3.6.1.2.1.2.2.1.2.1 must not match 1.3.6.1.2.1.2.2.1.2.10
+	res, err = client.SNMPWalk(context.Background(), "1.3.6.1.2.1.2.2.1.2.1")
+	if assert.NoError(t, err) {
+		assert.Len(t, res, 1)
+	}
+
+	_, err = client.SNMPWalk(context.Background(), "1.3.6.1.2.1.2.2.1.3")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestParseSNMPWalkLine(t *testing.T) {
+	tests := map[string]SNMPResponse{
+		`.1.3.6.1.2.1.1.1.0 = STRING: "RouterOS CHR"`:                NewSNMPResponse("1.3.6.1.2.1.1.1.0", gosnmp.OctetString, []byte("RouterOS CHR")),
+		`.1.3.6.1.2.1.1.4.0 = ""`:                                    NewSNMPResponse("1.3.6.1.2.1.1.4.0", gosnmp.OctetString, []byte{}),
+		`.1.3.6.1.2.1.1.2.0 = OID: .1.3.6.1.4.1.14988.1`:             NewSNMPResponse("1.3.6.1.2.1.1.2.0", gosnmp.ObjectIdentifier, ".1.3.6.1.4.1.14988.1"),
+		`.1.3.6.1.2.1.1.3.0 = Timeticks: (1097900) 3:02:59.00`:       NewSNMPResponse("1.3.6.1.2.1.1.3.0", gosnmp.TimeTicks, uint32(1097900)),
+		`.1.3.6.1.2.1.2.2.1.3.1 = INTEGER: ethernetCsmacd(6)`:        NewSNMPResponse("1.3.6.1.2.1.2.2.1.3.1", gosnmp.Integer, 6),
+		`.1.3.6.1.2.1.2.2.1.6.1 = Hex-STRING: 00 0C 29 AB CD EF`:     NewSNMPResponse("1.3.6.1.2.1.2.2.1.6.1", gosnmp.OctetString, []byte{0x00, 0x0c, 0x29, 0xab, 0xcd, 0xef}),
+		`.1.3.6.1.2.1.2.2.1.10.1 = Counter32: 4294967295`:            NewSNMPResponse("1.3.6.1.2.1.2.2.1.10.1", gosnmp.Counter32, uint(4294967295)),
+		`.1.3.6.1.2.1.4.20.1.1.192.0.2.1 = IpAddress: 192.0.2.1`:     NewSNMPResponse("1.3.6.1.2.1.4.20.1.1.192.0.2.1", gosnmp.IPAddress, "192.0.2.1"),
+		`.1.3.6.1.2.1.17.7.1.4.3.1.2.1 = BITS: FF 00 1(0) 2(1) 3(2)`: NewSNMPResponse("1.3.6.1.2.1.17.7.1.4.3.1.2.1", gosnmp.OctetString, []byte{0xff, 0x00}),
+	}
+	for line, expected := range tests {
+		res, err := parseSNMPWalkLine(line)
+		if assert.NoError(t, err, line) {
+			assert.Equal(t, expected, res, line)
+		}
+	}
+
+	_, err := parseSNMPWalkLine(`.1.3.6.1.2.1.1.1.0 = Unknown: 1`)
+	assert.Error(t, err)
+}
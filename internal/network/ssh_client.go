@@ -0,0 +1,250 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=SSHClient --inpackage
+
+// SSHClient is used to run cli commands on a device via ssh.
+type SSHClient interface {
+	Disconnect() error
+
+	RunCommand(ctx context.Context, command string) (string, error)
+
+	UseCache(b bool)
+	HasSuccessfulCachedRequest() bool
+
+	GetPort() int
+	GetUsername() string
+}
+
+type sshClient struct {
+	client   *ssh.Client
+	port     int
+	username string
+	useCache bool
+	cache    requestCache
+}
+
+// sshDialTimeout is the timeout for establishing an ssh connection including the login.
+const sshDialTimeout = 10 * time.Second
+
+// NewSSHClientByConnectionData tries to create a new ssh client by SSHConnectionData and returns it. The ports are
+// tried in the given order, the first one that accepts the login is used.
+func NewSSHClientByConnectionData(ctx context.Context, ipAddress string, data *SSHConnectionData) (SSHClient, error) {
+	if data == nil {
+		return nil, errors.New("ssh connection data is nil")
+	}
+	if len(data.Ports) == 0 {
+		return nil, tholaerr.NewPreConditionError("no ssh ports given")
+	}
+
+	config, err := newSSHClientConfig(data)
+	if err != nil {
+		return nil, tholaerr.NewPreConditionError(err.Error())
+	}
+
+	for _, port := range data.Ports {
+		var client *ssh.Client
+		client, err = dialSSH(ctx, net.JoinHostPort(ipAddress, strconv.Itoa(port)), config)
+		if err == nil {
+			return &sshClient{
+				client:   client,
+				port:     port,
+				username: config.User,
+				useCache: true,
+				cache:    newRequestCache(),
+			}, nil
+		}
+		// the other ports won't accept the credentials either
+		if tholaerr.IsAuthError(err) {
+			break
+		}
+	}
+	return nil, errors.Wrap(err, "failed to create ssh client")
+}
+
+func newSSHClientConfig(data *SSHConnectionData) (*ssh.ClientConfig, error) {
+	if isEmptyString(data.Username) {
+		return nil, errors.New("no ssh username given")
+	}
+
+	var auth []ssh.AuthMethod
+	if !isEmptyString(data.PrivateKey) {
+		signer, err := ssh.ParsePrivateKey([]byte(*data.PrivateKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ssh private key")
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if !isEmptyString(data.Password) {
+		password := *data.Password
+		// many network devices only offer keyboard-interactive instead of password authentication
+		auth = append(auth, ssh.Password(password), ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+			answers := make([]string, len(questions))
+			for i := range answers {
+				answers[i] = password
+			}
+			return answers, nil
+		}))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("no ssh password or private key given")
+	}
+
+	hostKeyCallback, err := newSSHHostKeyCallback(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User:            *data.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	}, nil
+}
+
+// newSSHHostKeyCallback returns the callback which verifies the host key of the device, either by its fingerprint or
+// by a known_hosts file. The host key is only ignored if this is explicitly allowed, because otherwise the login
+// credentials could be intercepted.
+func newSSHHostKeyCallback(data *SSHConnectionData) (ssh.HostKeyCallback, error) {
+	if data.InsecureHostKey != nil && *data.InsecureHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	if !isEmptyString(data.HostKeyFingerprint) {
+		fingerprint := *data.HostKeyFingerprint
+		return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			if ssh.FingerprintSHA256(key) != fingerprint {
+				return fmt.Errorf("ssh host key fingerprint %s doesn't match", ssh.FingerprintSHA256(key))
+			}
+			return nil
+		}, nil
+	}
+	if !isEmptyString(data.KnownHostsFile) {
+		callback, err := knownhosts.New(*data.KnownHostsFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read ssh known hosts file")
+		}
+		return callback, nil
+	}
+	return nil, errors.New("no ssh host key fingerprint or known hosts file given")
+}
+
+func dialSSH(ctx context.Context, address string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, sshDialTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, tholaerr.NewSSHError(err.Error())
+	}
+
+	// the handshake doesn't respect the context, so the deadline is applied to the connection
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, channels, requests, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+		if strings.Contains(err.Error(), "unable to authenticate") {
+			return nil, tholaerr.NewAuthError(err.Error())
+		}
+		return nil, tholaerr.NewSSHError(err.Error())
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return ssh.NewClient(c, channels, requests), nil
+}
+
+// RunCommand runs a cli command on the device and returns its output.
+func (s *sshClient) RunCommand(ctx context.Context, command string) (string, error) {
+	if s.useCache {
+		if x, err := s.cache.get(command); err == nil {
+			if x.err != nil {
+				return "", x.err
+			}
+			res, ok := x.res.(string)
+			if !ok {
+				return "", errors.New("cached ssh result is not a string")
+			}
+			return res, nil
+		}
+	}
+
+	res, err := s.runCommand(ctx, command)
+	// a canceled request says nothing about the command, so it is not cached
+	if s.useCache && ctx.Err() == nil {
+		s.cache.add(command, res, err)
+	}
+	return res, err
+}
+
+func (s *sshClient) runCommand(ctx context.Context, command string) (string, error) {
+	session, err := s.client.NewSession()
+	if err != nil {
+		return "", tholaerr.NewSSHError(err.Error())
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(command)
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = session.Close()
+		return "", tholaerr.NewSSHError(ctx.Err().Error())
+	case err = <-done:
+	}
+	if err != nil {
+		msg := err.Error()
+		if e := strings.TrimSpace(stderr.String()); e != "" {
+			msg += ": " + e
+		}
+		return "", tholaerr.NewSSHError("command '" + command + "' failed: " + msg)
+	}
+	return stdout.String(), nil
+}
+
+// UseCache configures whether the ssh cache should be used or not.
+func (s *sshClient) UseCache(b bool) {
+	s.useCache = b
+}
+
+// HasSuccessfulCachedRequest returns if there was at least one successful cached request.
+func (s *sshClient) HasSuccessfulCachedRequest() bool {
+	return len(s.cache.getSuccessfulRequests()) > 0
+}
+
+// Disconnect closes the ssh connection.
+func (s *sshClient) Disconnect() error {
+	return s.client.Close()
+}
+
+// GetPort returns the port of the ssh connection.
+func (s *sshClient) GetPort() int {
+	return s.port
+}
+
+// GetUsername returns the username of the ssh connection.
+func (s *sshClient) GetUsername() string {
+	return s.username
+}
@@ -0,0 +1,217 @@
+package network
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// startTestSSHServer starts an ssh server that accepts the password "secret" and answers every command with its
+// output in the outputs map. Unknown commands exit with status 127. It returns the port and the host key of the server.
+func startTestSSHServer(t *testing.T, outputs map[string]string) (int, ssh.PublicKey) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if string(password) != "secret" {
+				return nil, tholaerr.NewAuthError("wrong password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config, outputs)
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, signer.PublicKey()
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig, outputs map[string]string) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer channel.Close()
+			for req := range channelRequests {
+				if req.Type != "exec" || len(req.Payload) < 4 {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				_ = req.Reply(true, nil)
+				command := string(req.Payload[4:])
+				status := make([]byte, 4)
+				if output, ok := outputs[command]; ok {
+					_, _ = channel.Write([]byte(output))
+				} else {
+					_, _ = channel.Stderr().Write([]byte("command not found"))
+					binary.BigEndian.PutUint32(status, 127)
+				}
+				_, _ = channel.SendRequest("exit-status", false, status)
+				return
+			}
+		}()
+	}
+}
+
+func TestNewSSHClientByConnectionData(t *testing.T) {
+	port, hostKey := startTestSSHServer(t, map[string]string{"uname -s": "Linux\n"})
+	username, password := "admin", "secret"
+	fingerprint := ssh.FingerprintSHA256(hostKey)
+
+	// the closed port is skipped
+	client, err := NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:              []int{1, port},
+		Username:           &username,
+		Password:           &password,
+		HostKeyFingerprint: &fingerprint,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Disconnect()
+	assert.Equal(t, port, client.GetPort())
+	assert.Equal(t, "admin", client.GetUsername())
+	assert.False(t, client.HasSuccessfulCachedRequest())
+
+	output, err := client.RunCommand(context.Background(), "uname -s")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Linux\n", output)
+	}
+	assert.True(t, client.HasSuccessfulCachedRequest())
+
+	_, err = client.RunCommand(context.Background(), "show version")
+	if assert.Error(t, err) {
+		assert.True(t, tholaerr.IsNetworkError(err))
+		assert.Contains(t, err.Error(), "command not found")
+	}
+
+	wrongPassword := "wrong"
+	_, err = NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:              []int{port},
+		Username:           &username,
+		Password:           &wrongPassword,
+		HostKeyFingerprint: &fingerprint,
+	})
+	assert.True(t, tholaerr.IsAuthError(err))
+}
+
+func TestNewSSHClientByConnectionData_hostKey(t *testing.T) {
+	port, hostKey := startTestSSHServer(t, nil)
+	username, password := "admin", "secret"
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	knownHostsLine := knownhosts.Line([]string{knownhosts.Normalize(net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))}, hostKey)
+	if !assert.NoError(t, os.WriteFile(knownHostsFile, []byte(knownHostsLine+"\n"), 0600)) {
+		return
+	}
+	client, err := NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:          []int{port},
+		Username:       &username,
+		Password:       &password,
+		KnownHostsFile: &knownHostsFile,
+	})
+	if assert.NoError(t, err) {
+		_ = client.Disconnect()
+	}
+
+	insecure := true
+	client, err = NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:           []int{port},
+		Username:        &username,
+		Password:        &password,
+		InsecureHostKey: &insecure,
+	})
+	if assert.NoError(t, err) {
+		_ = client.Disconnect()
+	}
+
+	wrongFingerprint := "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s"
+	_, err = NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:              []int{port},
+		Username:           &username,
+		Password:           &password,
+		HostKeyFingerprint: &wrongFingerprint,
+	})
+	if assert.Error(t, err) {
+		assert.False(t, tholaerr.IsAuthError(err))
+	}
+
+	// without a way to verify the host key, no connection is established
+	_, err = NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:    []int{port},
+		Username: &username,
+		Password: &password,
+	})
+	assert.True(t, tholaerr.IsPreConditionError(err))
+}
+
+func TestNewSSHClientByConnectionData_invalidData(t *testing.T) {
+	username, key := "admin", "no key"
+
+	_, err := NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:    []int{22},
+		Username: &username,
+	})
+	assert.True(t, tholaerr.IsPreConditionError(err))
+
+	_, err = NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Ports:      []int{22},
+		Username:   &username,
+		PrivateKey: &key,
+	})
+	assert.True(t, tholaerr.IsPreConditionError(err))
+
+	_, err = NewSSHClientByConnectionData(context.Background(), "127.0.0.1", &SSHConnectionData{
+		Username: &username,
+	})
+	assert.True(t, tholaerr.IsPreConditionError(err))
+}
+
+func TestSSHConnectionData_serverSettings(t *testing.T) {
+	// the host key verification can't be disabled and the known hosts file can't be set by requests
+	var data SSHConnectionData
+	err := json.Unmarshal([]byte(`{"insecure_host_key": true, "InsecureHostKey": true, "KnownHostsFile": "/etc/passwd"}`), &data)
+	if assert.NoError(t, err) {
+		assert.Nil(t, data.InsecureHostKey)
+		assert.Nil(t, data.KnownHostsFile)
+	}
+}
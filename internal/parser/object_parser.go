@@ -33,6 +33,10 @@ type toCheckPluginOutputParser interface {
 	ToCheckPluginOutput() ([]byte, error)
 }
 
+type prometheusParser interface {
+	ToPrometheus() ([]byte, error)
+}
+
 // Parse parses the object into the desired format
 func Parse(i interface{}, format string) ([]byte, error) {
 	switch format {
@@ -44,6 +48,8 @@ func Parse(i interface{}, format string) ([]byte, error) {
 		return ToCSV(i)
 	case "check-plugin":
 		return ToCheckPluginOutput(i)
+	case "prometheus":
+		return ToPrometheus(i)
 	default:
 		return ToHumanReadable(i)
 	}
@@ -106,6 +112,14 @@ func ToCheckPluginOutput(i interface{}) ([]byte, error) {
 	return nil, errors.New("object cannot be passed to check plugin output")
 }
 
+// ToPrometheus parses the object to the prometheus text exposition format.
+func ToPrometheus(i interface{}) ([]byte, error) {
+	if p, ok := i.(prometheusParser); ok {
+		return p.ToPrometheus()
+	}
+	return nil, errors.New("object cannot be parsed to prometheus format")
+}
+
 // ToStruct parses the formatted content into the struct with the correct unmarshal method.
 func ToStruct(contents []byte, format string, i interface{}) error {
 	switch format {
@@ -122,7 +136,7 @@ func ToStruct(contents []byte, format string, i interface{}) error {
 
 func checkIfError(i interface{}) interface{} {
 	if err, ok := i.(error); ok {
-		i = tholaerr.OutputError{Error: err.Error()}
+		i = tholaerr.NewOutputError("", err)
 	}
 	return i
 }
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PrometheusContentType is the content type of the prometheus text exposition format.
+const PrometheusContentType = "text/plain; version=0.0.4"
+
+// Prometheus metric types.
+const (
+	PrometheusGauge   = "gauge"
+	PrometheusCounter = "counter"
+)
+
+var prometheusInvalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// PrometheusLabel is a label of a prometheus sample.
+type PrometheusLabel struct {
+	Name  string
+	Value string
+}
+
+type prometheusSample struct {
+	labels []PrometheusLabel
+	value  float64
+}
+
+type prometheusMetric struct {
+	name       string
+	help       string
+	metricType string
+	samples    []prometheusSample
+}
+
+// PrometheusMetrics collects metrics which are rendered in the prometheus text exposition format.
+// Metrics are rendered in the order in which they were added first.
+type PrometheusMetrics struct {
+	metrics []*prometheusMetric
+	index   map[string]*prometheusMetric
+}
+
+// Add adds a sample to the metric with the given name. The help text and type of a metric are taken from its first sample.
+func (p *PrometheusMetrics) Add(name, help, metricType string, value float64, labels ...PrometheusLabel) {
+	name = PrometheusMetricName(name)
+	if p.index == nil {
+		p.index = make(map[string]*prometheusMetric)
+	}
+	metric, ok := p.index[name]
+	if !ok {
+		metric = &prometheusMetric{
+			name:       name,
+			help:       help,
+			metricType: metricType,
+		}
+		p.index[name] = metric
+		p.metrics = append(p.metrics, metric)
+	}
+	metric.samples = append(metric.samples, prometheusSample{
+		labels: labels,
+		value:  value,
+	})
+}
+
+// ToPrometheus returns the collected metrics in the prometheus text exposition format.
+func (p *PrometheusMetrics) ToPrometheus() ([]byte, error) {
+	var b bytes.Buffer
+	for _, metric := range p.metrics {
+		if metric.help != "" {
+			b.WriteString("# HELP " + metric.name + " " + escapePrometheusHelp(metric.help) + "\n")
+		}
+		if metric.metricType != "" {
+			b.WriteString("# TYPE " + metric.name + " " + metric.metricType + "\n")
+		}
+		for _, sample := range metric.samples {
+			b.WriteString(metric.name)
+			if len(sample.labels) > 0 {
+				var labels []string
+				for _, label := range sample.labels {
+					labels = append(labels, PrometheusMetricName(label.Name)+`="`+escapePrometheusLabelValue(label.Value)+`"`)
+				}
+				b.WriteString("{" + strings.Join(labels, ",") + "}")
+			}
+			b.WriteString(" " + strconv.FormatFloat(sample.value, 'g', -1, 64) + "\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// PrometheusMetricName replaces all characters that are not allowed in prometheus metric and label names.
+func PrometheusMetricName(name string) string {
+	name = prometheusInvalidNameChars.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+func escapePrometheusHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapePrometheusLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
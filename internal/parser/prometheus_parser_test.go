@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestToPrometheus(t *testing.T) {
+	var metrics PrometheusMetrics
+	metrics.Add("thola_interface_in_octets_total", "ifInOctets of the interface.", PrometheusCounter, 1024,
+		PrometheusLabel{Name: "ifIndex", Value: "1"},
+		PrometheusLabel{Name: "ifName", Value: "Gi0/1"})
+	metrics.Add("thola_cpu_load", "CPU load in percent.", PrometheusGauge, 12.5,
+		PrometheusLabel{Name: "cpu", Value: "0"})
+	metrics.Add("thola_interface_in_octets_total", "", PrometheusCounter, 2048,
+		PrometheusLabel{Name: "ifIndex", Value: "2"},
+		PrometheusLabel{Name: "ifName", Value: "Gi0/2"})
+
+	output, err := ToPrometheus(&metrics)
+	assert.Nil(t, err)
+	assert.Equal(t, `# HELP thola_interface_in_octets_total ifInOctets of the interface.
+# TYPE thola_interface_in_octets_total counter
+thola_interface_in_octets_total{ifIndex="1",ifName="Gi0/1"} 1024
+thola_interface_in_octets_total{ifIndex="2",ifName="Gi0/2"} 2048
+# HELP thola_cpu_load CPU load in percent.
+# TYPE thola_cpu_load gauge
+thola_cpu_load{cpu="0"} 12.5
+`, string(output))
+}
+
+func TestToPrometheusEscaping(t *testing.T) {
+	var metrics PrometheusMetrics
+	metrics.Add("thola_check_used-space", "", PrometheusGauge, 1, PrometheusLabel{Name: "label", Value: "C:\\ \"Label\"\n"})
+
+	output, err := ToPrometheus(&metrics)
+	assert.Nil(t, err)
+	assert.Equal(t, "# TYPE thola_check_used_space gauge\nthola_check_used_space{label=\"C:\\\\ \\\"Label\\\"\\n\"} 1\n", string(output))
+}
+
+func TestToPrometheusUnsupported(t *testing.T) {
+	_, err := ToPrometheus("foobla")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,15 @@
+package request
+
+import "context"
+
+// NewContextWithAPIRequest returns a new context which marks the request as received by the API. Settings that affect
+// the server itself, like directories on the server, can't be set by API requests.
+func NewContextWithAPIRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, apiRequestKey, true)
+}
+
+// IsAPIRequest returns whether the request of the context was received by the API.
+func IsAPIRequest(ctx context.Context) bool {
+	b, ok := ctx.Value(apiRequestKey).(bool)
+	return ok && b
+}
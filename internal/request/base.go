@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
@@ -9,8 +10,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
+	"io/ioutil"
 	"net"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +25,15 @@ type BaseRequest struct {
 
 	// Timeout for the request (0 => no timeout)
 	Timeout *int `json:"timeout" xml:"timeout"`
+
+	// Weights for splitting the remaining request time between components, e.g. "ups"
+	ComponentTimeoutWeights map[string]float64 `json:"component_timeout_weights,omitempty" xml:"-"`
+
+	// Don't use the identify cache and the match cache of the API for this request
+	NoIdentifyCache bool `json:"no_identify_cache,omitempty" xml:"no_identify_cache,omitempty"`
+
+	// Add a trace of all snmp requests sent to the device to the response
+	SNMPTrace bool `json:"snmp_trace,omitempty" xml:"snmp_trace,omitempty"`
 }
 
 // DeviceData
@@ -43,7 +56,22 @@ func (r *BaseRequest) GetDeviceData() *DeviceData {
 }
 
 func (r *BaseRequest) validate(ctx context.Context) error {
-	if net.ParseIP(r.DeviceData.IPAddress) == nil {
+	for comp, weight := range r.ComponentTimeoutWeights {
+		if weight <= 0 {
+			return fmt.Errorf("invalid component timeout weight for '%s'", comp)
+		}
+	}
+
+	if network.IsSNMPRecAddress(r.DeviceData.IPAddress) && IsAPIRequest(ctx) {
+		address, err := snmpRecAPIAddress(r.DeviceData.IPAddress)
+		if err != nil {
+			return err
+		}
+		r.DeviceData.IPAddress = address
+	}
+
+	// recorded snmp data is served without a network connection
+	if !network.IsSNMPRecAddress(r.DeviceData.IPAddress) && net.ParseIP(r.DeviceData.IPAddress) == nil {
 		ips, err := net.LookupIP(r.DeviceData.IPAddress)
 		if err != nil {
 			return errors.Wrap(err, "Domain lookup failed")
@@ -69,6 +97,9 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 	if configData.HTTP == nil {
 		configData.HTTP = &network.HTTPConnectionData{}
 	}
+	if configData.SSH == nil {
+		configData.SSH = &network.SSHConnectionData{}
+	}
 
 	db, err := database.GetDB(ctx)
 	if err != nil {
@@ -88,6 +119,9 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 	if cacheData.HTTP == nil {
 		cacheData.HTTP = &network.HTTPConnectionData{}
 	}
+	if cacheData.SSH == nil {
+		cacheData.SSH = &network.SSHConnectionData{}
+	}
 
 	mergedData := network.ConnectionData{
 		SNMP: &network.SNMPConnectionData{
@@ -112,6 +146,14 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 			HTTPSPorts:   utility.SliceUniqueInt(append(cacheData.HTTP.HTTPSPorts, configData.HTTP.HTTPSPorts...)),
 			AuthUsername: utility.IfThenElse(cacheData.HTTP.AuthUsername != nil, cacheData.HTTP.AuthUsername, configData.HTTP.AuthUsername).(*string),
 			AuthPassword: utility.IfThenElse(cacheData.HTTP.AuthPassword != nil, cacheData.HTTP.AuthPassword, configData.HTTP.AuthPassword).(*string),
+			AuthToken:    utility.IfThenElse(cacheData.HTTP.AuthToken != nil, cacheData.HTTP.AuthToken, configData.HTTP.AuthToken).(*string),
+		},
+		SSH: &network.SSHConnectionData{
+			Ports:              utility.SliceUniqueInt(append(cacheData.SSH.Ports, configData.SSH.Ports...)),
+			Username:           utility.IfThenElse(cacheData.SSH.Username != nil, cacheData.SSH.Username, configData.SSH.Username).(*string),
+			Password:           utility.IfThenElse(cacheData.SSH.Password != nil, cacheData.SSH.Password, configData.SSH.Password).(*string),
+			PrivateKey:         utility.IfThenElse(cacheData.SSH.PrivateKey != nil, cacheData.SSH.PrivateKey, configData.SSH.PrivateKey).(*string),
+			HostKeyFingerprint: utility.IfThenElse(cacheData.SSH.HostKeyFingerprint != nil, cacheData.SSH.HostKeyFingerprint, configData.SSH.HostKeyFingerprint).(*string),
 		},
 	}
 
@@ -134,6 +176,9 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 
 	if len(r.DeviceData.ConnectionData.SNMP.Ports) == 0 {
 		r.DeviceData.ConnectionData.SNMP.Ports = mergedData.SNMP.Ports
+	} else {
+		// the port of the cached connection is tried first, if it is one of the requested ports
+		r.DeviceData.ConnectionData.SNMP.Ports = preferPorts(r.DeviceData.ConnectionData.SNMP.Ports, cacheData.SNMP.Ports)
 	}
 	for _, port := range r.DeviceData.ConnectionData.SNMP.Ports {
 		if port <= 0 {
@@ -153,6 +198,40 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 		r.DeviceData.ConnectionData.SNMP.DiscoverRetries = mergedData.SNMP.DiscoverRetries
 	}
 
+	// the walk retry policy determines the load on the device, so it is always taken from the configuration
+	r.DeviceData.ConnectionData.SNMP.WalkRetries = configData.SNMP.WalkRetries
+	r.DeviceData.ConnectionData.SNMP.WalkRetryDelay = configData.SNMP.WalkRetryDelay
+
+	// the empty value retry policy determines the load on the device, so it is always taken from the configuration
+	r.DeviceData.ConnectionData.SNMP.EmptyValueRetries = configData.SNMP.EmptyValueRetries
+	r.DeviceData.ConnectionData.SNMP.EmptyValueRetryDelay = configData.SNMP.EmptyValueRetryDelay
+
+	// the walk row limit protects the server from endless walks, so it is always taken from the configuration
+	r.DeviceData.ConnectionData.SNMP.MaxWalkRows = configData.SNMP.MaxWalkRows
+
+	// writes change the state of the device, so they can only be enabled in the configuration
+	r.DeviceData.ConnectionData.SNMP.WritesEnabled = configData.SNMP.WritesEnabled
+
+	if r.DeviceData.ConnectionData.SNMP.WalkMode != nil {
+		if err := network.ValidateSNMPWalkMode(*r.DeviceData.ConnectionData.SNMP.WalkMode); err != nil {
+			return err
+		}
+	}
+
+	if (r.DeviceData.ConnectionData.SNMP.WalkRetries != nil && *r.DeviceData.ConnectionData.SNMP.WalkRetries < 0) ||
+		(r.DeviceData.ConnectionData.SNMP.WalkRetryDelay != nil && *r.DeviceData.ConnectionData.SNMP.WalkRetryDelay < 0) {
+		return errors.New("invalid snmp walk retry preferences")
+	}
+
+	if (r.DeviceData.ConnectionData.SNMP.EmptyValueRetries != nil && *r.DeviceData.ConnectionData.SNMP.EmptyValueRetries < 0) ||
+		(r.DeviceData.ConnectionData.SNMP.EmptyValueRetryDelay != nil && *r.DeviceData.ConnectionData.SNMP.EmptyValueRetryDelay < 0) {
+		return errors.New("invalid snmp empty value retry preferences")
+	}
+
+	if r.DeviceData.ConnectionData.SNMP.MaxWalkRows != nil && *r.DeviceData.ConnectionData.SNMP.MaxWalkRows < 0 {
+		return errors.New("invalid snmp max walk rows")
+	}
+
 	if (r.DeviceData.ConnectionData.SNMP.DiscoverParallelRequests != nil && *r.DeviceData.ConnectionData.SNMP.DiscoverParallelRequests <= 0) ||
 		(r.DeviceData.ConnectionData.SNMP.DiscoverTimeout != nil && *r.DeviceData.ConnectionData.SNMP.DiscoverTimeout <= 0) {
 		return errors.New("invalid snmp connection discover preferences")
@@ -186,38 +265,11 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 		r.DeviceData.ConnectionData.SNMP.V3Data.PrivProtocol = mergedData.SNMP.V3Data.PrivProtocol
 	}
 
-	if utility.StringSliceContains(r.DeviceData.ConnectionData.SNMP.Versions, "3") {
-		if r.DeviceData.ConnectionData.SNMP.V3Data.Level == nil {
-			return errors.New("no SNMP v3 level provided")
-		}
-		if r.DeviceData.ConnectionData.SNMP.V3Data.User == nil {
-			return errors.New("no SNMP v3 username provided")
-		}
-
-		switch *r.DeviceData.ConnectionData.SNMP.V3Data.Level {
-		case "authPriv":
-			if r.DeviceData.ConnectionData.SNMP.V3Data.PrivProtocol == nil {
-				return errors.New("no SNMP v3 priv protocol provided")
-			} else if network.ValidateSNMPv3PrivProtocol(*r.DeviceData.ConnectionData.SNMP.V3Data.PrivProtocol) != nil {
-				return errors.New("invalid SNMP v3 priv protocol provided")
-			}
-			if r.DeviceData.ConnectionData.SNMP.V3Data.PrivKey == nil {
-				return errors.New("no SNMP v3 priv key provided")
-			}
-			fallthrough
-		case "authNoPriv":
-			if r.DeviceData.ConnectionData.SNMP.V3Data.AuthProtocol == nil {
-				return errors.New("no SNMP v3 auth protocol provided")
-			} else if network.ValidateSNMPv3AuthProtocol(*r.DeviceData.ConnectionData.SNMP.V3Data.AuthProtocol) != nil {
-				return errors.New("invalid SNMP v3 auth protocol provided")
-			}
-			if r.DeviceData.ConnectionData.SNMP.V3Data.AuthKey == nil {
-				return errors.New("no SNMP v3 auth key provided")
-			}
-		case "noAuthNoPriv":
-		//Nothing else needed
-		default:
-			return errors.New("invalid SNMP v3 level, only 'noAuthNoPriv', 'authNoPriv' and 'authPriv' are possible")
+	v3Data := r.DeviceData.ConnectionData.SNMP.V3Data
+	v3Set := v3Data.Level != nil && *v3Data.Level != "" && v3Data.User != nil && *v3Data.User != ""
+	if utility.StringSliceContains(r.DeviceData.ConnectionData.SNMP.Versions, "3") || v3Set {
+		if err := network.ValidateSNMPv3ConnectionData(v3Data); err != nil {
+			return err
 		}
 	}
 
@@ -251,6 +303,44 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 		r.DeviceData.ConnectionData.HTTP.AuthPassword = mergedData.HTTP.AuthPassword
 	}
 
+	if r.DeviceData.ConnectionData.HTTP.AuthToken == nil {
+		r.DeviceData.ConnectionData.HTTP.AuthToken = mergedData.HTTP.AuthToken
+	}
+
+	if r.DeviceData.ConnectionData.SSH == nil {
+		r.DeviceData.ConnectionData.SSH = mergedData.SSH
+	}
+
+	if len(r.DeviceData.ConnectionData.SSH.Ports) == 0 {
+		r.DeviceData.ConnectionData.SSH.Ports = mergedData.SSH.Ports
+	}
+	for _, port := range r.DeviceData.ConnectionData.SSH.Ports {
+		if port <= 0 {
+			return errors.New("invalid SSH port")
+		}
+	}
+
+	if r.DeviceData.ConnectionData.SSH.Username == nil {
+		r.DeviceData.ConnectionData.SSH.Username = mergedData.SSH.Username
+	}
+
+	if r.DeviceData.ConnectionData.SSH.Password == nil {
+		r.DeviceData.ConnectionData.SSH.Password = mergedData.SSH.Password
+	}
+
+	if r.DeviceData.ConnectionData.SSH.PrivateKey == nil {
+		r.DeviceData.ConnectionData.SSH.PrivateKey = mergedData.SSH.PrivateKey
+	}
+
+	if r.DeviceData.ConnectionData.SSH.HostKeyFingerprint == nil {
+		r.DeviceData.ConnectionData.SSH.HostKeyFingerprint = mergedData.SSH.HostKeyFingerprint
+	}
+
+	// the host key verification and the known hosts file protect the credentials of the server, so they are always
+	// taken from the configuration
+	r.DeviceData.ConnectionData.SSH.InsecureHostKey = configData.SSH.InsecureHostKey
+	r.DeviceData.ConnectionData.SSH.KnownHostsFile = configData.SSH.KnownHostsFile
+
 	if r.Timeout == nil {
 		timeout := viper.GetInt("request.timeout")
 		r.Timeout = &timeout
@@ -259,18 +349,84 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 	return nil
 }
 
+// snmpRecAPIAddress checks a file:// address of an api request. The api only serves recorded snmp data from the
+// directory that is configured on the server, so that clients can't access other files of the server.
+// Relative paths are relative to this directory.
+func snmpRecAPIAddress(address string) (string, error) {
+	dir := viper.GetString("api.snmprec-dir")
+	if dir == "" {
+		return "", errors.New("recorded snmp data is not available in api requests, because no snmprec dir is configured")
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get absolute path of snmprec dir")
+	}
+
+	path := strings.TrimPrefix(address, network.SNMPRecScheme)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if !isInsideDir(dir, path) {
+		return "", errors.New("recorded snmp data has to be inside the snmprec dir")
+	}
+
+	// symlinks inside the directory must not point to files outside of it
+	if resolvedPath, err := filepath.EvalSymlinks(path); err == nil {
+		resolvedDir, err := filepath.EvalSymlinks(dir)
+		if err != nil || !isInsideDir(resolvedDir, resolvedPath) {
+			return "", errors.New("recorded snmp data has to be inside the snmprec dir")
+		}
+	}
+	return network.SNMPRecScheme + path, nil
+}
+
+func isInsideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func (r *BaseRequest) getTimeout() *int {
 	return r.Timeout
 }
 
+func (r *BaseRequest) getComponentTimeoutWeights() map[string]float64 {
+	return r.ComponentTimeoutWeights
+}
+
+func (r *BaseRequest) getSNMPTrace() bool {
+	return r.SNMPTrace
+}
+
 func (r *BaseRequest) HandlePreProcessError(err error) (Response, error) {
 	return nil, err
 }
 
+// preferPorts returns the given ports with the preferred ones moved to the front. Preferred ports which are not part
+// of the given ports are ignored.
+func preferPorts(ports, preferred []int) []int {
+	res := make([]int, 0, len(ports))
+	for _, p := range preferred {
+		for _, port := range ports {
+			if port == p {
+				res = append(res, port)
+				break
+			}
+		}
+	}
+	return utility.SliceUniqueInt(append(res, ports...))
+}
+
 func getConfigConnectionData() network.ConnectionData {
 	parallelRequests := viper.GetInt("device.snmp-discover-par-requests")
 	timeout := viper.GetInt("device.snmp-discover-timeout")
 	retries := viper.GetInt("device.snmp-discover-retries")
+	walkRetries := viper.GetInt("device.snmp-walk-retries")
+	walkRetryDelay := viper.GetInt("device.snmp-walk-retry-delay")
+	emptyValueRetries := viper.GetInt("device.snmp-empty-value-retries")
+	emptyValueRetryDelay := viper.GetInt("device.snmp-empty-value-retry-delay")
+	maxWalkRows := viper.GetInt("device.snmp-max-walk-rows")
+	writesEnabled := viper.GetBool("device.snmp-writes-enabled")
 	v3Level := viper.GetString("device.snmp-v3-level")
 	v3ContextName := viper.GetString("device.snmp-v3-context")
 	v3User := viper.GetString("device.snmp-v3-user")
@@ -280,6 +436,12 @@ func getConfigConnectionData() network.ConnectionData {
 	v3PrivProto := viper.GetString("device.snmp-v3-priv-proto")
 	authUsername := viper.GetString("device.http-username")
 	authPassword := viper.GetString("device.http-password")
+	authToken := viper.GetString("device.http-token")
+	sshUsername := viper.GetString("device.ssh-username")
+	sshPassword := viper.GetString("device.ssh-password")
+	sshHostKeyFingerprint := viper.GetString("device.ssh-host-key-fingerprint")
+	sshInsecureHostKey := viper.GetBool("device.ssh-insecure-host-key")
+	sshKnownHostsFile := viper.GetString("device.ssh-known-hosts-file")
 	return network.ConnectionData{
 		SNMP: &network.SNMPConnectionData{
 			Communities:              viper.GetStringSlice("device.snmp-communities"),
@@ -288,6 +450,12 @@ func getConfigConnectionData() network.ConnectionData {
 			DiscoverParallelRequests: &parallelRequests,
 			DiscoverTimeout:          &timeout,
 			DiscoverRetries:          &retries,
+			WalkRetries:              &walkRetries,
+			WalkRetryDelay:           &walkRetryDelay,
+			EmptyValueRetries:        &emptyValueRetries,
+			EmptyValueRetryDelay:     &emptyValueRetryDelay,
+			MaxWalkRows:              &maxWalkRows,
+			WritesEnabled:            &writesEnabled,
 			V3Data: network.SNMPv3ConnectionData{
 				Level:        &v3Level,
 				ContextName:  &v3ContextName,
@@ -303,10 +471,35 @@ func getConfigConnectionData() network.ConnectionData {
 			HTTPSPorts:   viper.GetIntSlice("device.https-ports"),
 			AuthUsername: &authUsername,
 			AuthPassword: &authPassword,
+			AuthToken:    &authToken,
+		},
+		SSH: &network.SSHConnectionData{
+			Ports:              viper.GetIntSlice("device.ssh-ports"),
+			Username:           &sshUsername,
+			Password:           &sshPassword,
+			PrivateKey:         getConfigSSHPrivateKey(),
+			HostKeyFingerprint: &sshHostKeyFingerprint,
+			InsecureHostKey:    &sshInsecureHostKey,
+			KnownHostsFile:     &sshKnownHostsFile,
 		},
 	}
 }
 
+// getConfigSSHPrivateKey returns the contents of the configured ssh key file, nil if there is none.
+func getConfigSSHPrivateKey() *string {
+	file := viper.GetString("device.ssh-key-file")
+	if file == "" {
+		return nil
+	}
+	key, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to read ssh key file '%s'", file)
+		return nil
+	}
+	keyString := string(key)
+	return &keyString
+}
+
 func (r *BaseRequest) setupConnection(ctx context.Context) (*network.RequestDeviceConnection, error) {
 	var con network.RequestDeviceConnection
 	con.RawConnectionData = r.DeviceData.ConnectionData
@@ -322,7 +515,7 @@ func (r *BaseRequest) setupConnection(ctx context.Context) (*network.RequestDevi
 		}
 	}
 
-	if r.DeviceData.ConnectionData.HTTP != nil && (len(r.DeviceData.ConnectionData.HTTP.HTTPSPorts) != 0 || len(r.DeviceData.ConnectionData.HTTP.HTTPPorts) != 0) {
+	if r.DeviceData.ConnectionData.HTTP != nil && !network.IsSNMPRecAddress(r.DeviceData.IPAddress) && (len(r.DeviceData.ConnectionData.HTTP.HTTPSPorts) != 0 || len(r.DeviceData.ConnectionData.HTTP.HTTPPorts) != 0) {
 		httpCon, err := r.setupHTTPConnection()
 		if err != nil {
 			log.Ctx(ctx).Debug().Err(err).Msg("failed to setup http connection data")
@@ -332,6 +525,18 @@ func (r *BaseRequest) setupConnection(ctx context.Context) (*network.RequestDevi
 			createdData = true
 		}
 	}
+
+	if r.DeviceData.ConnectionData.SSH != nil && !network.IsSNMPRecAddress(r.DeviceData.IPAddress) && len(r.DeviceData.ConnectionData.SSH.Ports) != 0 && r.DeviceData.ConnectionData.SSH.Username != nil && *r.DeviceData.ConnectionData.SSH.Username != "" {
+		sshCon, err := r.setupSSHConnection(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to setup ssh connection data")
+		} else {
+			log.Ctx(ctx).Debug().Msg("successfully setup ssh connection data")
+			con.SSH = sshCon
+			createdData = true
+		}
+	}
+
 	if !createdData {
 		return nil, errors.New("cannot create any connection to the device")
 	}
@@ -343,7 +548,13 @@ func (r *BaseRequest) setupSNMPConnection(ctx context.Context) (*network.Request
 		return nil, errors.New("no SNMP connection data available")
 	}
 
-	snmpClient, err := network.NewSNMPClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.SNMP)
+	var snmpClient network.SNMPClient
+	var err error
+	if pool := getSNMPSessionPool(); pool != nil {
+		snmpClient, err = pool.Get(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.SNMP)
+	} else {
+		snmpClient, err = network.NewSNMPClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.SNMP)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "error during NewSNMPClientByConnectionData")
 	}
@@ -385,6 +596,12 @@ func (r *BaseRequest) setupHTTPConnection() (*network.RequestDeviceConnectionHTT
 			return nil, errors.Wrap(err, "error during set username and password")
 		}
 	}
+	if r.DeviceData.ConnectionData.HTTP.AuthToken != nil && *r.DeviceData.ConnectionData.HTTP.AuthToken != "" {
+		err = httpClient.SetAuthToken(*r.DeviceData.ConnectionData.HTTP.AuthToken)
+		if err != nil {
+			return nil, errors.Wrap(err, "error during set auth token")
+		}
+	}
 	httpClient.SetTimeout(15 * time.Second)
 	con := &network.RequestDeviceConnectionHTTP{}
 	con.HTTPClient = httpClient
@@ -392,12 +609,34 @@ func (r *BaseRequest) setupHTTPConnection() (*network.RequestDeviceConnectionHTT
 	return con, nil
 }
 
+func (r *BaseRequest) setupSSHConnection(ctx context.Context) (*network.RequestDeviceConnectionSSH, error) {
+	if r.DeviceData.ConnectionData.SSH == nil {
+		return nil, errors.New("no SSH connection data available")
+	}
+
+	sshClient, err := network.NewSSHClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.SSH)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during NewSSHClientByConnectionData")
+	}
+
+	con := &network.RequestDeviceConnectionSSH{}
+	con.SSHClient = sshClient
+	con.ConnectionData = r.DeviceData.ConnectionData.SSH
+	return con, nil
+}
+
 // BaseResponse
 //
 // BaseResponse defines attributes every response has.
 //
 // swagger:model
 type BaseResponse struct {
+	// Trace of all snmp requests sent to the device, only set if it was requested
+	SNMPTrace *network.SNMPTrace `yaml:"snmp_trace,omitempty" json:"snmp_trace,omitempty" xml:"snmp_trace,omitempty"`
+}
+
+func (b *BaseResponse) setSNMPTrace(trace network.SNMPTrace) {
+	b.SNMPTrace = &trace
 }
 
 // GetExitCode returns the exit code of the response.
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreferPorts(t *testing.T) {
+	assert.Equal(t, []int{1161, 161, 10161}, preferPorts([]int{161, 1161, 10161}, []int{1161}))
+	assert.Equal(t, []int{161, 1161}, preferPorts([]int{161, 1161}, []int{2161}))
+	assert.Equal(t, []int{161, 1161}, preferPorts([]int{161, 1161}, nil))
+}
+
+func TestSNMPRecAPIAddress(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	if !assert.NoError(t, os.WriteFile(filepath.Join(outside, "public.snmprec"), nil, 0600)) ||
+		!assert.NoError(t, os.Symlink(outside, filepath.Join(dir, "link"))) {
+		return
+	}
+
+	viper.Set("api.snmprec-dir", "")
+	_, err := snmpRecAPIAddress("file://" + dir + "/public.snmprec")
+	assert.Error(t, err, "no snmprec dir configured")
+
+	viper.Set("api.snmprec-dir", dir)
+	defer viper.Set("api.snmprec-dir", "")
+
+	address, err := snmpRecAPIAddress("file://device/public.snmprec")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "file://"+filepath.Join(dir, "device", "public.snmprec"), address)
+	}
+
+	address, err = snmpRecAPIAddress("file://" + dir + "/public.snmprec")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "file://"+filepath.Join(dir, "public.snmprec"), address)
+	}
+
+	_, err = snmpRecAPIAddress("file://../" + filepath.Base(outside) + "/public.snmprec")
+	assert.Error(t, err, "relative path outside of the dir")
+
+	_, err = snmpRecAPIAddress("file:///etc/passwd")
+	assert.Error(t, err, "absolute path outside of the dir")
+
+	_, err = snmpRecAPIAddress("file://link/public.snmprec")
+	assert.Error(t, err, "symlink pointing outside of the dir")
+}
@@ -0,0 +1,226 @@
+package request
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultBatchWorkers is the amount of requests of a batch which are processed concurrently, if nothing else is configured.
+const defaultBatchWorkers = 10
+
+// Statuses of a single entry of a batch response.
+const (
+	BatchStatusOK    = "ok"
+	BatchStatusError = "error"
+)
+
+// BatchOptions
+//
+// BatchOptions contains the options for processing multiple requests in one batch.
+//
+// swagger:model
+type BatchOptions struct {
+	// Amount of requests which are processed concurrently. It can only lower the configured amount of batch workers.
+	Workers *int `json:"workers" xml:"workers"`
+
+	// Overall deadline for the whole batch in seconds (0 => no deadline)
+	Timeout *int `json:"timeout" xml:"timeout"`
+}
+
+// BatchResult
+//
+// BatchResult is the result of a single request of a batch.
+//
+// swagger:model
+type BatchResult struct {
+	Host     string   `json:"host" xml:"host"`
+	Status   string   `json:"status" xml:"status"`
+	Response Response `json:"response,omitempty" xml:"response,omitempty"`
+	Error    string   `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// BatchResponse
+//
+// BatchResponse contains the results of a batch mapped by the host of the requests.
+// A failed request does not fail the batch, every result carries its own status.
+//
+// swagger:model
+type BatchResponse struct {
+	Results map[string]BatchResult `json:"results" xml:"results"`
+	// Requests which were not processed, because their host is already part of the batch
+	Duplicates []BatchResult `json:"duplicates,omitempty" xml:"duplicates,omitempty"`
+}
+
+// GetExitCode returns the highest exit code of all successful requests of the batch.
+// The exit code is 3 only if every request of the batch failed.
+func (r *BatchResponse) GetExitCode() int {
+	exitCode, failed := 0, 0
+	for _, result := range r.Results {
+		if result.Status != BatchStatusOK {
+			failed++
+			continue
+		}
+		if c := result.Response.GetExitCode(); c > exitCode {
+			exitCode = c
+		}
+	}
+	if len(r.Results) > 0 && failed == len(r.Results) {
+		return 3
+	}
+	return exitCode
+}
+
+// MarshalXML encodes the batch response as XML. The results are sorted by host, as maps are not supported by XML.
+func (r BatchResponse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	hosts := make([]string, 0, len(r.Results))
+	for host := range r.Results {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	res := struct {
+		Results    []BatchResult `xml:"results>result"`
+		Duplicates []BatchResult `xml:"duplicates>result,omitempty"`
+	}{
+		Duplicates: r.Duplicates,
+	}
+	for _, host := range hosts {
+		res.Results = append(res.Results, r.Results[host])
+	}
+	return e.EncodeElement(res, start)
+}
+
+// BatchProcessFunc processes a single request of a batch.
+type BatchProcessFunc func(ctx context.Context, r Request) (Response, error)
+
+type deviceDataRequest interface {
+	GetDeviceData() *DeviceData
+}
+
+// BatchHost returns the host of a request of a batch.
+func BatchHost(r Request) (string, error) {
+	d, ok := r.(deviceDataRequest)
+	if !ok {
+		return "", errors.New("request has no device data")
+	}
+	return d.GetDeviceData().IPAddress, nil
+}
+
+// ProcessBatch processes the given requests concurrently with a pool of workers and returns the results mapped by host.
+// Requests which couldn't be started before the deadline of the batch is exceeded or the context is cancelled fail
+// with a corresponding error. Only the first request of a host is processed, further requests of the host are reported
+// as duplicates.
+func ProcessBatch(ctx context.Context, requests []Request, options BatchOptions, process BatchProcessFunc) (*BatchResponse, error) {
+	// the configured amount of workers is the maximum, so that a batch can't open an unlimited amount of connections
+	workers := defaultBatchWorkers
+	if w := viper.GetInt("request.batch-workers"); w > 0 {
+		workers = w
+	}
+	if options.Workers != nil {
+		if *options.Workers <= 0 {
+			return nil, errors.New("invalid amount of batch workers")
+		}
+		if *options.Workers < workers {
+			workers = *options.Workers
+		}
+	}
+	if options.Timeout != nil && *options.Timeout < 0 {
+		return nil, errors.New("invalid batch timeout")
+	}
+
+	res := BatchResponse{
+		Results: make(map[string]BatchResult, len(requests)),
+	}
+
+	var unique []Request
+	var hosts []string
+	seen := make(map[string]struct{})
+	for _, r := range requests {
+		host, err := BatchHost(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[host]; ok {
+			res.Duplicates = append(res.Duplicates, BatchResult{
+				Host:   host,
+				Status: BatchStatusError,
+				Error:  fmt.Sprintf("host '%s' is part of the batch more than once", host),
+			})
+			continue
+		}
+		seen[host] = struct{}{}
+		unique = append(unique, r)
+		hosts = append(hosts, host)
+	}
+	requests = unique
+
+	var cancel context.CancelFunc
+	if options.Timeout != nil && *options.Timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*options.Timeout)*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan int)
+	for i := 0; i < workers && i < len(requests); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result := processBatchEntry(ctx, requests[j], hosts[j], process)
+				mutex.Lock()
+				res.Results[hosts[j]] = result
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for i := range requests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return &res, nil
+}
+
+func processBatchEntry(ctx context.Context, r Request, host string, process BatchProcessFunc) BatchResult {
+	logger := log.Ctx(ctx).With().Str("host", host).Logger()
+	ctx = logger.WithContext(ctx)
+
+	var resp Response
+	var err error
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		resp, err = r.HandlePreProcessError(errors.New("batch timed out before the request was started"))
+	case context.Canceled:
+		resp, err = r.HandlePreProcessError(errors.New("batch was cancelled before the request was started"))
+	default:
+		resp, err = process(ctx, r)
+	}
+
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("request of batch failed")
+		return BatchResult{
+			Host:   host,
+			Status: BatchStatusError,
+			Error:  err.Error(),
+		}
+	}
+	return BatchResult{
+		Host:     host,
+		Status:   BatchStatusOK,
+		Response: resp,
+	}
+}
@@ -0,0 +1,115 @@
+package request
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newBatchTestRequest(host string) Request {
+	return &ReadCountInterfacesRequest{
+		ReadRequest: ReadRequest{
+			BaseRequest: BaseRequest{
+				DeviceData: DeviceData{IPAddress: host},
+			},
+		},
+	}
+}
+
+func TestProcessBatch(t *testing.T) {
+	requests := []Request{newBatchTestRequest("192.0.2.1"), newBatchTestRequest("192.0.2.2"), newBatchTestRequest("192.0.2.3")}
+	workers := 2
+
+	res, err := ProcessBatch(context.Background(), requests, BatchOptions{Workers: &workers}, func(ctx context.Context, r Request) (Response, error) {
+		host, _ := BatchHost(r)
+		if host == "192.0.2.2" {
+			return nil, errors.New("device not reachable")
+		}
+		return &ReadCountInterfacesResponse{Count: 3}, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, res.Results, 3)
+		assert.Equal(t, BatchStatusOK, res.Results["192.0.2.1"].Status)
+		assert.Equal(t, &ReadCountInterfacesResponse{Count: 3}, res.Results["192.0.2.1"].Response)
+		assert.Equal(t, BatchStatusError, res.Results["192.0.2.2"].Status)
+		assert.Equal(t, "device not reachable", res.Results["192.0.2.2"].Error)
+		assert.Nil(t, res.Results["192.0.2.2"].Response)
+		assert.Equal(t, BatchStatusOK, res.Results["192.0.2.3"].Status)
+		assert.Equal(t, 0, res.GetExitCode())
+
+		b, err := xml.Marshal(res)
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(b), "<host>192.0.2.2</host><status>error</status><error>device not reachable</error>")
+		}
+	}
+}
+
+func TestProcessBatch_timeout(t *testing.T) {
+	requests := []Request{newBatchTestRequest("192.0.2.1"), newBatchTestRequest("192.0.2.2")}
+	workers, timeout := 1, 1
+
+	res, err := ProcessBatch(context.Background(), requests, BatchOptions{Workers: &workers, Timeout: &timeout}, func(ctx context.Context, r Request) (Response, error) {
+		<-ctx.Done()
+		return r.HandlePreProcessError(errors.New("request timed out"))
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "request timed out", res.Results["192.0.2.1"].Error)
+		assert.Equal(t, "batch timed out before the request was started", res.Results["192.0.2.2"].Error)
+		assert.Equal(t, 3, res.GetExitCode())
+	}
+}
+
+func TestProcessBatch_duplicateHost(t *testing.T) {
+	requests := []Request{newBatchTestRequest("192.0.2.1"), newBatchTestRequest("192.0.2.1"), newBatchTestRequest("192.0.2.2")}
+
+	processed := make(chan string, len(requests))
+	res, err := ProcessBatch(context.Background(), requests, BatchOptions{}, func(ctx context.Context, r Request) (Response, error) {
+		host, _ := BatchHost(r)
+		processed <- host
+		return &ReadCountInterfacesResponse{Count: 3}, nil
+	})
+	if assert.NoError(t, err) {
+		assert.Len(t, processed, 2)
+		assert.Equal(t, BatchStatusOK, res.Results["192.0.2.1"].Status)
+		assert.Equal(t, BatchStatusOK, res.Results["192.0.2.2"].Status)
+		if assert.Len(t, res.Duplicates, 1) {
+			assert.Equal(t, "192.0.2.1", res.Duplicates[0].Host)
+			assert.Equal(t, BatchStatusError, res.Duplicates[0].Status)
+		}
+		assert.Equal(t, 0, res.GetExitCode())
+	}
+}
+
+func TestProcessBatch_workers(t *testing.T) {
+	viper.Set("request.batch-workers", 2)
+	defer viper.Set("request.batch-workers", nil)
+
+	requests := make([]Request, 10)
+	for i := range requests {
+		requests[i] = newBatchTestRequest(fmt.Sprintf("192.0.2.%d", i+1))
+	}
+
+	// the workers of the options can't exceed the configured workers
+	workers := 100
+	var running, maxRunning int32
+	_, err := ProcessBatch(context.Background(), requests, BatchOptions{Workers: &workers}, func(ctx context.Context, r Request) (Response, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return &ReadCountInterfacesResponse{}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
@@ -1,8 +1,12 @@
 package request
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/network"
 	"strconv"
+	"strings"
 )
 
 // CheckRequest
@@ -11,13 +15,13 @@ import (
 //
 // swagger:model
 type CheckRequest struct {
-	mon                  *monitoringplugin.Response
+	mon                  *checkResponse
 	PrintPerformanceData bool `yaml:"print_performance_data" json:"print_performance_data" xml:"print_performance_data"`
 	JSONMetrics          bool `yaml:"json_metrics" json:"json_metrics" xml:"json_metrics"`
 }
 
 func (r *CheckRequest) init() {
-	r.mon = monitoringplugin.NewResponse("checked")
+	r.mon = newCheckResponse("checked")
 	_ = r.mon.SetInvalidCharacterBehavior(monitoringplugin.InvalidCharacterReplaceWithErrorAndSetUNKNOWN, "")
 	r.mon.PrintPerformanceData(r.PrintPerformanceData)
 	r.mon.SetPerformanceDataJSONLabel(r.JSONMetrics)
@@ -26,7 +30,115 @@ func (r *CheckRequest) init() {
 func (r *CheckRequest) HandlePreProcessError(err error) (Response, error) {
 	r.init()
 	r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, err.Error(), false)
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
+
+// checkResponse is the monitoring plugin response of a check. Inverted threshold ranges ('@') can't be expressed with
+// monitoringplugin.Thresholds, so it keeps the thresholds of the performance data points with inverted ranges and
+// writes their ranges into the performance data itself.
+type checkResponse struct {
+	*monitoringplugin.Response
+	printPerformanceData bool
+	jsonLabel            bool
+	thresholds           map[performanceDataPointKey]Threshold
+}
+
+type performanceDataPointKey struct {
+	Metric string `json:"metric"`
+	Label  string `json:"label,omitempty"`
+}
+
+func newCheckResponse(defaultOkMessage string) *checkResponse {
+	return &checkResponse{
+		Response:             monitoringplugin.NewResponse(defaultOkMessage),
+		printPerformanceData: true,
+		thresholds:           make(map[performanceDataPointKey]Threshold),
+	}
+}
+
+// PrintPerformanceData activates or deactivates printing performance data.
+func (r *checkResponse) PrintPerformanceData(b bool) {
+	r.printPerformanceData = b
+	r.Response.PrintPerformanceData(b)
+}
+
+// SetPerformanceDataJSONLabel updates the JSON metric.
+func (r *checkResponse) SetPerformanceDataJSONLabel(jsonLabel bool) {
+	r.jsonLabel = jsonLabel
+	r.Response.SetPerformanceDataJSONLabel(jsonLabel)
+}
+
+// setThreshold sets the threshold that is written into the performance data of the point with the metric and label.
+func (r *checkResponse) setThreshold(metric, label string, threshold Threshold) {
+	r.thresholds[performanceDataPointKey{Metric: metric, Label: label}] = threshold
+}
+
+// GetInfo returns all information of the response. If thresholds with inverted ranges were set, the performance data
+// is written by the response instead of the monitoring plugin.
+func (r *checkResponse) GetInfo() monitoringplugin.ResponseInfo {
+	if !r.printPerformanceData || len(r.thresholds) == 0 {
+		return r.Response.GetInfo()
+	}
+
+	r.Response.PrintPerformanceData(false)
+	info := r.Response.GetInfo()
+	r.Response.PrintPerformanceData(true)
+
+	points := make([]string, 0, len(info.PerformanceData))
+	for _, point := range info.PerformanceData {
+		points = append(points, r.performanceDataOutput(point))
+	}
+	if len(points) > 0 {
+		info.RawOutput += " | " + strings.Join(points, " ")
+	}
+	return info
+}
+
+// performanceDataOutput returns the point in the performance data format of the monitoring plugin.
+func (r *checkResponse) performanceDataOutput(point monitoringplugin.PerformanceDataPoint) string {
+	key := performanceDataPointKey{Metric: point.Metric, Label: point.Label}
+	name := point.Metric
+	if r.jsonLabel {
+		b, _ := json.Marshal(key)
+		name = string(b)
+	} else if point.Label != "" {
+		name += "_" + point.Label
+	}
+	output := "'" + name + "'=" + performanceDataValue(point.Value) + point.Unit
+
+	threshold, ok := r.thresholds[key]
+	if !ok {
+		// the thresholds were already validated when the point was added
+		threshold, _ = NewThresholdFromMinMax(point.Thresholds)
+	}
+	if threshold.IsEmpty() && point.Min == nil && point.Max == nil {
+		return output
+	}
+
+	output += ";"
+	if threshold.Warning != nil {
+		output += threshold.Warning.String()
+	}
+	output += ";"
+	if threshold.Critical != nil {
+		output += threshold.Critical.String()
+	}
+	output += ";"
+	if point.Min != nil {
+		output += performanceDataValue(point.Min)
+	}
+	output += ";"
+	if point.Max != nil {
+		output += performanceDataValue(point.Max)
+	}
+	return output
+}
+
+func performanceDataValue(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
 }
 
 type labelCounter struct {
@@ -75,6 +187,12 @@ func (d *duplicateLabelChecker) getModifiedLabel(label *string) string {
 // swagger:model
 type CheckResponse struct {
 	monitoringplugin.ResponseInfo
+	// Trace of all snmp requests sent to the device, only set if it was requested
+	SNMPTrace *network.SNMPTrace `yaml:"snmp_trace,omitempty" json:"snmp_trace,omitempty" xml:"snmp_trace,omitempty"`
+}
+
+func (c *CheckResponse) setSNMPTrace(trace network.SNMPTrace) {
+	c.SNMPTrace = &trace
 }
 
 // ToCheckPluginOutput returns the response in checkplugin format.
@@ -2,7 +2,6 @@ package request
 
 import (
 	"context"
-	"github.com/inexio/go-monitoringplugin"
 )
 
 // CheckCPULoadRequest
@@ -12,7 +11,7 @@ import (
 // swagger:model
 type CheckCPULoadRequest struct {
 	CheckDeviceRequest
-	CPULoadThresholds monitoringplugin.Thresholds `json:"cpuLoadThresholds" xml:"cpuLoadThresholds"`
+	CPULoadThresholds Threshold `json:"cpuLoadThresholds" xml:"cpuLoadThresholds"`
 }
 
 func (r *CheckCPULoadRequest) validate(ctx context.Context) error {
@@ -15,12 +15,12 @@ func (r *CheckCPULoadRequest) process(ctx context.Context) (Response, error) {
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	result, err := com.GetCPUComponentCPULoad(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading cpu load", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 	cpuSum := 0.0
 	cpuAmount := len(result)
@@ -33,33 +33,34 @@ func (r *CheckCPULoadRequest) process(ctx context.Context) (Response, error) {
 		cpuSum += *cpu.Load
 
 		point := monitoringplugin.NewPerformanceDataPoint("cpu_load", *cpu.Load).SetUnit("%")
+		var threshold Threshold
 		if cpuAmount == 1 {
-			point.SetThresholds(r.CPULoadThresholds)
+			threshold = r.CPULoadThresholds
 		}
 		if cpu.Label != nil {
 			point.SetLabel(*cpu.Label)
 		} else if cpuAmount > 1 {
 			point.SetLabel(strconv.Itoa(k))
 		}
-		err = r.mon.AddPerformanceDataPoint(point)
+		err = addPerformanceDataPointWithThreshold(r.mon, point, threshold)
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
 	if cpuAmount > 1 {
 		val := cpuSum / float64(cpuAmount)
-		err = r.mon.AddPerformanceDataPoint(
+		err = addPerformanceDataPointWithThreshold(r.mon,
 			monitoringplugin.NewPerformanceDataPoint("cpu_load", fmt.Sprintf("%.3f", val)).
 				SetUnit("%").
-				SetLabel("average").
-				SetThresholds(r.CPULoadThresholds))
+				SetLabel("average"),
+			r.CPULoadThresholds)
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	} else if cpuAmount == 0 {
 		r.mon.UpdateStatus(monitoringplugin.UNKNOWN, "no CPUs found")
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
@@ -2,7 +2,6 @@ package request
 
 import (
 	"context"
-	"github.com/inexio/go-monitoringplugin"
 )
 
 // CheckDiskRequest
@@ -11,13 +10,17 @@ import (
 //
 // swagger:model
 type CheckDiskRequest struct {
+	DiskOptions
 	CheckDeviceRequest
-	DiskThresholds monitoringplugin.Thresholds `json:"diskThresholds" xml:"diskThresholds"`
+	DiskThresholds Threshold `json:"diskThresholds" xml:"diskThresholds"`
 }
 
 func (r *CheckDiskRequest) validate(ctx context.Context) error {
 	if err := r.DiskThresholds.Validate(); err != nil {
 		return err
 	}
+	if err := r.DiskOptions.validate(); err != nil {
+		return err
+	}
 	return r.CheckDeviceRequest.validate(ctx)
 }
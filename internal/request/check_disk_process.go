@@ -13,39 +13,33 @@ func (r *CheckDiskRequest) process(ctx context.Context) (Response, error) {
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
-	disk, err := com.GetDiskComponent(ctx)
+	disk, err := com.GetDiskComponent(r.DiskOptions.newContext(ctx))
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading disk", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	duplicateLabelCheckerDisk := make(duplicateLabelChecker)
 	for _, disk := range disk.Storages {
+		if disk.Virtual != nil && *disk.Virtual {
+			continue
+		}
 		duplicateLabelCheckerDisk.addLabel(disk.Description)
 	}
 
 	for _, storage := range disk.Storages {
+		if storage.Virtual != nil && *storage.Virtual {
+			continue
+		}
 		if storage.Used != nil {
-			var p *monitoringplugin.PerformanceDataPoint
-
-			if (r.DiskThresholds.HasWarning() || r.DiskThresholds.HasCritical()) && storage.Available != nil {
-				thresholds := monitoringplugin.Thresholds{
-					WarningMin:  0,
-					CriticalMin: 0,
-				}
+			p := monitoringplugin.NewPerformanceDataPoint("disk_used", *storage.Used).SetUnit("B")
 
-				if r.DiskThresholds.HasWarning() {
-					thresholds.WarningMax = float64(*storage.Available) * r.DiskThresholds.WarningMax.(float64) / 100
-				}
-				if r.DiskThresholds.HasCritical() {
-					thresholds.CriticalMax = float64(*storage.Available) * r.DiskThresholds.CriticalMax.(float64) / 100
-				}
-
-				p = monitoringplugin.NewPerformanceDataPoint("disk_used", *storage.Used).SetUnit("B").SetThresholds(thresholds)
-			} else {
-				p = monitoringplugin.NewPerformanceDataPoint("disk_used", *storage.Used).SetUnit("B")
+			// the thresholds are given in percent of the available space
+			var threshold Threshold
+			if storage.Available != nil {
+				threshold = r.DiskThresholds.scale(float64(*storage.Available) / 100)
 			}
 
 			if storage.Description != nil {
@@ -56,13 +50,13 @@ func (r *CheckDiskRequest) process(ctx context.Context) (Response, error) {
 				p.SetMax(*storage.Available)
 			}
 
-			err = r.mon.AddPerformanceDataPoint(p)
+			err = addPerformanceDataPointWithThreshold(r.mon, p, threshold)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
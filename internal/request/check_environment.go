@@ -0,0 +1,45 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/pkg/errors"
+)
+
+// CheckEnvironmentRequest
+//
+// CheckEnvironmentRequest is the request struct for the check environment request.
+//
+// swagger:model
+type CheckEnvironmentRequest struct {
+	CheckDeviceRequest
+	// Thresholds for the temperature sensors in degrees celsius.
+	TemperatureThresholds monitoringplugin.Thresholds `json:"temperatureThresholds" xml:"temperatureThresholds"`
+	// Thresholds for the humidity sensors in percent.
+	HumidityThresholds monitoringplugin.Thresholds `json:"humidityThresholds" xml:"humidityThresholds"`
+	// Thresholds of single sensors mapped by their description. They are used instead of the thresholds of the sensor type.
+	SensorThresholds map[string]monitoringplugin.Thresholds `json:"sensorThresholds" xml:"sensorThresholds"`
+	// State of the dry contacts which results in a critical status, e.g. 'open' for door contacts.
+	DryContactAlarmState *device.EnvironmentComponentDryContactState `yaml:"dry_contact_alarm_state" json:"dry_contact_alarm_state" xml:"dry_contact_alarm_state"`
+}
+
+func (r *CheckEnvironmentRequest) validate(ctx context.Context) error {
+	if err := r.TemperatureThresholds.Validate(); err != nil {
+		return errors.Wrap(err, "invalid temperature thresholds")
+	}
+	if err := r.HumidityThresholds.Validate(); err != nil {
+		return errors.Wrap(err, "invalid humidity thresholds")
+	}
+	for sensor, thresholds := range r.SensorThresholds {
+		if err := thresholds.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid thresholds for sensor '%s'", sensor)
+		}
+	}
+	if r.DryContactAlarmState != nil {
+		if err := r.DryContactAlarmState.Validate(); err != nil {
+			return errors.Wrap(err, "invalid dry contact alarm state")
+		}
+	}
+	return r.CheckDeviceRequest.validate(ctx)
+}
@@ -0,0 +1,107 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+)
+
+func (r *CheckEnvironmentRequest) process(ctx context.Context) (Response, error) {
+	r.init()
+
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	environment, err := com.GetEnvironmentComponent(ctx)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading environment", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	duplicateLabelCheckerTemperature := make(duplicateLabelChecker)
+	for _, sensor := range environment.Temperature {
+		duplicateLabelCheckerTemperature.addLabel(sensor.Description)
+	}
+	for _, sensor := range environment.Temperature {
+		if sensor.Temperature == nil {
+			continue
+		}
+		label := duplicateLabelCheckerTemperature.getModifiedLabel(sensor.Description)
+		p := monitoringplugin.NewPerformanceDataPoint("environment_temperature", *sensor.Temperature).SetLabel(label).
+			SetThresholds(r.getSensorThresholds(sensor.Description, r.TemperatureThresholds))
+		err = r.mon.AddPerformanceDataPoint(p)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	duplicateLabelCheckerHumidity := make(duplicateLabelChecker)
+	for _, sensor := range environment.Humidity {
+		duplicateLabelCheckerHumidity.addLabel(sensor.Description)
+	}
+	for _, sensor := range environment.Humidity {
+		if sensor.Humidity == nil {
+			continue
+		}
+		label := duplicateLabelCheckerHumidity.getModifiedLabel(sensor.Description)
+		p := monitoringplugin.NewPerformanceDataPoint("environment_humidity", *sensor.Humidity).SetUnit("%").SetLabel(label).SetMin(0).SetMax(100).
+			SetThresholds(r.getSensorThresholds(sensor.Description, r.HumidityThresholds))
+		err = r.mon.AddPerformanceDataPoint(p)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	duplicateLabelCheckerDryContacts := make(duplicateLabelChecker)
+	for _, contact := range environment.DryContacts {
+		duplicateLabelCheckerDryContacts.addLabel(contact.Description)
+	}
+	for _, contact := range environment.DryContacts {
+		if contact.State == nil {
+			continue
+		}
+		label := duplicateLabelCheckerDryContacts.getModifiedLabel(contact.Description)
+		outputDescription := "dry contact"
+		if label != "" {
+			outputDescription += " (" + label + ")"
+		}
+
+		if err := contact.State.Validate(); err != nil {
+			r.mon.UpdateStatus(monitoringplugin.UNKNOWN, outputDescription+" has an invalid state")
+			continue
+		}
+
+		closed := 0
+		if *contact.State == device.EnvironmentComponentDryContactStateClosed {
+			closed = 1
+		}
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("environment_dry_contact_closed", closed).SetLabel(label))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+
+		if r.DryContactAlarmState != nil {
+			r.mon.UpdateStatusIf(*contact.State == *r.DryContactAlarmState, monitoringplugin.CRITICAL, outputDescription+" is "+string(*contact.State))
+		}
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
+
+// getSensorThresholds returns the thresholds of the sensor with the given description, or the given thresholds of
+// its sensor type if there are none.
+func (r *CheckEnvironmentRequest) getSensorThresholds(description *string, typeThresholds monitoringplugin.Thresholds) monitoringplugin.Thresholds {
+	if description != nil {
+		if thresholds, ok := r.SensorThresholds[*description]; ok {
+			return thresholds
+		}
+	}
+	return typeThresholds
+}
@@ -0,0 +1,24 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckEnvironmentRequest_getSensorThresholds(t *testing.T) {
+	r := CheckEnvironmentRequest{
+		SensorThresholds: map[string]monitoringplugin.Thresholds{
+			"Rack 1": {WarningMax: 30.0},
+		},
+	}
+	typeThresholds := monitoringplugin.Thresholds{WarningMax: 25.0}
+
+	rack1, rack2 := "Rack 1", "Rack 2"
+	assert.Equal(t, monitoringplugin.Thresholds{WarningMax: 30.0}, r.getSensorThresholds(&rack1, typeThresholds))
+	assert.Equal(t, typeThresholds, r.getSensorThresholds(&rack2, typeThresholds))
+	assert.Equal(t, typeThresholds, r.getSensorThresholds(nil, typeThresholds))
+}
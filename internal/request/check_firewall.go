@@ -0,0 +1,23 @@
+package request
+
+import (
+	"context"
+	"github.com/pkg/errors"
+)
+
+// CheckFirewallRequest
+//
+// CheckFirewallRequest is the request struct for the check firewall request.
+//
+// swagger:model
+type CheckFirewallRequest struct {
+	CheckDeviceRequest
+	ActiveSessionsThresholds Threshold `json:"activeSessionsThresholds" xml:"activeSessionsThresholds"`
+}
+
+func (r *CheckFirewallRequest) validate(ctx context.Context) error {
+	if err := r.ActiveSessionsThresholds.Validate(); err != nil {
+		return errors.Wrap(err, "active sessions thresholds are invalid")
+	}
+	return r.CheckDeviceRequest.validate(ctx)
+}
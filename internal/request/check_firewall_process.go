@@ -0,0 +1,75 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+)
+
+func (r *CheckFirewallRequest) process(ctx context.Context) (Response, error) {
+	r.init()
+
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	firewall, err := com.GetFirewallComponent(ctx)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading firewall information", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	err = r.checkFirewall(firewall)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+		r.mon.PrintPerformanceData(false)
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
+
+// checkFirewall checks the active sessions against the thresholds and adds them as performance data. Every vpn tunnel
+// which is down results in a CRITICAL status.
+func (r *CheckFirewallRequest) checkFirewall(firewall device.FirewallComponent) error {
+	if firewall.ActiveSessions != nil {
+		err := addPerformanceDataPointWithThreshold(r.mon, monitoringplugin.NewPerformanceDataPoint("active_sessions", *firewall.ActiveSessions), r.ActiveSessionsThresholds)
+		if err != nil {
+			return err
+		}
+	}
+
+	if firewall.VPNTunnels != nil {
+		var up, down int
+		for _, tunnel := range firewall.VPNTunnels {
+			if tunnel.Status == nil {
+				continue
+			}
+			switch *tunnel.Status {
+			case device.FirewallVPNTunnelStatusUp:
+				up++
+			case device.FirewallVPNTunnelStatusDown:
+				down++
+				name := "unknown"
+				if tunnel.Name != nil {
+					name = *tunnel.Name
+				}
+				r.mon.UpdateStatus(monitoringplugin.CRITICAL, fmt.Sprintf("vpn tunnel '%s' is down", name))
+			}
+		}
+
+		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("vpn_tunnels_up", up))
+		if err != nil {
+			return err
+		}
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("vpn_tunnels_down", down))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
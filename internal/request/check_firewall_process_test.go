@@ -0,0 +1,48 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckFirewallRequest_checkFirewall(t *testing.T) {
+	threshold, err := ParseThreshold("1000", "2000")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	up, down := device.FirewallVPNTunnelStatusUp, device.FirewallVPNTunnelStatusDown
+	name := "branch-office"
+
+	for _, c := range []struct {
+		name     string
+		sessions int
+		tunnels  []device.FirewallVPNTunnel
+		status   int
+	}{
+		{"sessions below thresholds", 500, nil, monitoringplugin.OK},
+		{"sessions above warning", 1500, nil, monitoringplugin.WARNING},
+		{"sessions above critical", 2500, nil, monitoringplugin.CRITICAL},
+		{"tunnels up", 500, []device.FirewallVPNTunnel{{Name: &name, Status: &up}, {Status: &up}}, monitoringplugin.OK},
+		{"tunnel down", 500, []device.FirewallVPNTunnel{{Name: &name, Status: &down}, {Status: &up}}, monitoringplugin.CRITICAL},
+		{"tunnel without status", 500, []device.FirewallVPNTunnel{{Name: &name}}, monitoringplugin.OK},
+	} {
+		sessions := c.sessions
+		r := CheckFirewallRequest{
+			ActiveSessionsThresholds: threshold,
+		}
+		r.init()
+		err := r.checkFirewall(device.FirewallComponent{
+			ActiveSessions: &sessions,
+			VPNTunnels:     c.tunnels,
+		})
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, c.status, r.mon.GetStatusCode(), c.name)
+		}
+	}
+}
@@ -5,6 +5,7 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/device"
 )
@@ -14,27 +15,29 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	res, err := com.GetHardwareHealthComponent(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading hardware-health", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	if res.EnvironmentMonitorState != nil {
 		stateInt, err := (*res.EnvironmentMonitorState).GetInt()
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "read out invalid environment monitor state", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("environment_monitor_state", stateInt))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
-		r.mon.UpdateStatusIf((*res.EnvironmentMonitorState) != device.HardwareHealthComponentStateNormal, monitoringplugin.CRITICAL, "environment monitor state is critical")
+		if state := res.EnvironmentMonitorState.GetMonitoringState(); state != monitoringplugin.OK {
+			r.mon.UpdateStatus(state, environmentMonitorStateMessage(res))
+		}
 	}
 
 	// check duplicate labels
@@ -43,6 +46,21 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 		duplicateLabelCheckerFans.addLabel(fan.Description)
 	}
 	for _, fan := range res.Fans {
+		label := duplicateLabelCheckerFans.getModifiedLabel(fan.Description)
+
+		// fans without a speed sensor only report their state
+		if fan.SpeedRPM != nil {
+			p := monitoringplugin.NewPerformanceDataPoint("fan_speed", *fan.SpeedRPM)
+			if label != "" {
+				p.SetLabel(label)
+			}
+			err = r.mon.AddPerformanceDataPoint(p)
+			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+				r.mon.PrintPerformanceData(false)
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+			}
+		}
+
 		if fan.State == nil {
 			continue
 		}
@@ -50,13 +68,13 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 		stateInt, err := (*fan.State).GetInt()
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "read out invalid hardware health component state for fan", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		p := monitoringplugin.NewPerformanceDataPoint("fan_state", stateInt)
 
 		outputDescription := "fan state"
-		if label := duplicateLabelCheckerFans.getModifiedLabel(fan.Description); label != "" {
+		if label != "" {
 			p.SetLabel(label)
 			outputDescription += " (" + label + ")"
 		}
@@ -64,7 +82,7 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 		err = r.mon.AddPerformanceDataPoint(p)
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		r.mon.UpdateStatusIf(*fan.State == device.HardwareHealthComponentStateWarning, monitoringplugin.WARNING, outputDescription+" is warning")
@@ -84,7 +102,7 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 		stateInt, err := (*powerSupply.State).GetInt()
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "read out invalid hardware health component state for power supply", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		p := monitoringplugin.NewPerformanceDataPoint("power_supply_state", stateInt)
@@ -98,7 +116,7 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 		err = r.mon.AddPerformanceDataPoint(p)
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		r.mon.UpdateStatusIf(*powerSupply.State == device.HardwareHealthComponentStateWarning, monitoringplugin.WARNING, outputDescription+" is warning")
@@ -125,14 +143,14 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 		if temp.State != nil {
 			stateInt, err := (*temp.State).GetInt()
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "read out invalid hardware health component state for temperature", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 
 			p := monitoringplugin.NewPerformanceDataPoint("temperature_state", stateInt)
@@ -146,7 +164,7 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 
 			r.mon.UpdateStatusIf(*temp.State == device.HardwareHealthComponentStateWarning, monitoringplugin.WARNING, outputDescription+" is warning")
@@ -174,14 +192,14 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 		if volt.State != nil {
 			stateInt, err := (*volt.State).GetInt()
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "read out invalid hardware health component state for voltage", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 
 			p := monitoringplugin.NewPerformanceDataPoint("voltage_state", stateInt)
@@ -195,7 +213,7 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 
 			r.mon.UpdateStatusIf(*volt.State == device.HardwareHealthComponentStateWarning, monitoringplugin.WARNING, outputDescription+" is warning")
@@ -203,5 +221,36 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	// check duplicate labels
+	duplicateLabelCheckerHumidity := make(duplicateLabelChecker)
+	for _, h := range res.Humidity {
+		duplicateLabelCheckerHumidity.addLabel(h.Description)
+	}
+	for _, humidity := range res.Humidity {
+		if humidity.Value == nil {
+			continue
+		}
+
+		p := monitoringplugin.NewPerformanceDataPoint("humidity", *humidity.Value).SetUnit("%")
+
+		if label := duplicateLabelCheckerHumidity.getModifiedLabel(humidity.Description); label != "" {
+			p.SetLabel(label)
+		}
+
+		err = r.mon.AddPerformanceDataPoint(p)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
+
+// environmentMonitorStateMessage returns the output message for the environment monitor state of the hardware health.
+func environmentMonitorStateMessage(res device.HardwareHealthComponent) string {
+	if res.EnvironmentMonitorStateRaw != nil {
+		return fmt.Sprintf("environment monitor state is %s (raw value: %s)", *res.EnvironmentMonitorState, *res.EnvironmentMonitorStateRaw)
+	}
+	return fmt.Sprintf("environment monitor state is %s", *res.EnvironmentMonitorState)
 }
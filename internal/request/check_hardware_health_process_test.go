@@ -0,0 +1,23 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEnvironmentMonitorStateMessage(t *testing.T) {
+	critical := device.HardwareHealthComponentStateCritical
+	assert.Equal(t, "environment monitor state is critical", environmentMonitorStateMessage(device.HardwareHealthComponent{
+		EnvironmentMonitorState: &critical,
+	}))
+
+	unknown, raw := device.HardwareHealthComponentStateUnknown, "42"
+	assert.Equal(t, "environment monitor state is unknown (raw value: 42)", environmentMonitorStateMessage(device.HardwareHealthComponent{
+		EnvironmentMonitorState:    &unknown,
+		EnvironmentMonitorStateRaw: &raw,
+	}))
+}
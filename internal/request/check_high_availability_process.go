@@ -15,17 +15,17 @@ func (r *CheckHighAvailabilityRequest) process(ctx context.Context) (Response, e
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	res, err := com.GetHighAvailabilityComponent(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading high-availability information", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	if res.State != nil {
 		if r.mon.UpdateStatusIf(*res.State == device.HighAvailabilityComponentStateStandalone, monitoringplugin.UNKNOWN, "device is in standalone mode, no high availability setup configured") {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		statusCode := monitoringplugin.OK
@@ -36,13 +36,13 @@ func (r *CheckHighAvailabilityRequest) process(ctx context.Context) (Response, e
 
 		state, err := (*res.State).GetInt()
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "unknown high availability state", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("state", state))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
@@ -58,9 +58,9 @@ func (r *CheckHighAvailabilityRequest) process(ctx context.Context) (Response, e
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("nodes", *res.Nodes).SetThresholds(r.NodesThresholds))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
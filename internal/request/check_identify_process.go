@@ -20,7 +20,7 @@ func (r *CheckIdentifyRequest) process(ctx context.Context) (Response, error) {
 	response, err := identifyRequest.process(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while processing identify request", true) {
 		return &CheckIdentifyResponse{
-			CheckResponse:  CheckResponse{r.mon.GetInfo()},
+			CheckResponse:  CheckResponse{ResponseInfo: r.mon.GetInfo()},
 			IdentifyResult: nil,
 		}, nil
 	}
@@ -127,7 +127,7 @@ func (r *CheckIdentifyRequest) process(ctx context.Context) (Response, error) {
 	}
 
 	return &CheckIdentifyResponse{
-		CheckResponse:      CheckResponse{r.mon.GetInfo()},
+		CheckResponse:      CheckResponse{ResponseInfo: r.mon.GetInfo()},
 		IdentifyResult:     &identifyResponse.Device,
 		FailedExpectations: failedExpectations,
 	}, nil
@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"github.com/pkg/errors"
 )
 
 // CheckInterfaceMetricsRequest
@@ -11,13 +12,60 @@ import (
 // swagger:model
 type CheckInterfaceMetricsRequest struct {
 	PrintInterfaces bool `yaml:"print_interfaces" json:"print_interfaces" xml:"print_interfaces"`
+	// If set, traffic and error rates are calculated from the counters of the previous check and added as performance data.
+	RateMode bool `yaml:"rate_mode" json:"rate_mode" xml:"rate_mode"`
+	// Directory in which the counters are persisted between checks in rate mode. It is required for checks that are not
+	// sent to the API and must not be set in API requests, the API uses the state directory of its configuration.
+	StateDir string `yaml:"state_dir" json:"state_dir" xml:"state_dir"`
+	// Maximum speeds in bits per second of interfaces mapped by their ifName, e.g. for shaped ports. In rate mode, they
+	// are used instead of the speed of the interface to calculate the utilization.
+	MaxSpeeds map[string]uint64 `yaml:"max_speeds" json:"max_speeds" xml:"max_speeds"`
+	// Maximum speed in bits per second of all interfaces without an entry in the max speeds.
+	DefaultMaxSpeed *uint64 `yaml:"default_max_speed" json:"default_max_speed" xml:"default_max_speed"`
+	// Thresholds for the utilization of the interfaces in percent, which is calculated in rate mode.
+	UtilizationThresholds Threshold `json:"utilizationThresholds" xml:"utilizationThresholds"`
+	// If set, the check warns about admin up interfaces whose last state change is younger than the flap threshold.
+	FlapDetection bool `yaml:"flap_detection" json:"flap_detection" xml:"flap_detection"`
+	// Minimum time in seconds since the last state change of an interface in flap detection. Defaults to 300 seconds.
+	FlapThreshold *uint64 `yaml:"flap_threshold" json:"flap_threshold" xml:"flap_threshold"`
 	InterfaceOptions
 	CheckDeviceRequest
 }
 
+const defaultFlapThreshold uint64 = 300
+
 func (r *CheckInterfaceMetricsRequest) validate(ctx context.Context) error {
 	if err := r.InterfaceOptions.validate(); err != nil {
 		return err
 	}
+	if err := r.UtilizationThresholds.Validate(); err != nil {
+		return errors.Wrap(err, "invalid utilization thresholds")
+	}
+	if !r.RateMode && (len(r.MaxSpeeds) > 0 || r.DefaultMaxSpeed != nil || !r.UtilizationThresholds.IsEmpty()) {
+		return errors.New("max speeds and utilization thresholds can only be used in rate mode")
+	}
+	if IsAPIRequest(ctx) {
+		if r.StateDir != "" {
+			return errors.New("state dir can't be set in api requests, it is configured on the server")
+		}
+	} else if r.RateMode && r.StateDir == "" {
+		return errors.New("state dir is required in rate mode")
+	}
+	for ifName, maxSpeed := range r.MaxSpeeds {
+		if maxSpeed == 0 {
+			return errors.Errorf("max speed of interface '%s' must be greater than 0", ifName)
+		}
+	}
+	if r.DefaultMaxSpeed != nil && *r.DefaultMaxSpeed == 0 {
+		return errors.New("default max speed must be greater than 0")
+	}
+	if r.FlapThreshold != nil {
+		if !r.FlapDetection {
+			return errors.New("flap threshold can only be used with flap detection")
+		}
+		if *r.FlapThreshold == 0 {
+			return errors.New("flap threshold must be greater than 0")
+		}
+	}
 	return r.CheckDeviceRequest.validate(ctx)
 }
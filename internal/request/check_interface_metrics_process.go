@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/network"
@@ -32,29 +33,48 @@ func (r *CheckInterfaceMetricsRequest) process(ctx context.Context) (Response, e
 	r.init()
 
 	ctx = network.NewContextWithSNMPGetsInsteadOfWalk(ctx, r.SNMPGetsInsteadOfWalk)
+	ctx = communicator.NewContextWithSkipInterfaceEnrichment(ctx, r.SkipVendorEnrichment)
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "failed to get communicator", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	interfaces, err := com.GetInterfaces(ctx, r.getFilter()...)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "failed to read out interfaces", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	err = r.normalizeInterfaces(interfaces)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while normalizing interfaces", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
-	err = addCheckInterfacePerformanceData(interfaces, r.mon)
+	if r.FlapDetection {
+		r.checkFlaps(interfaces)
+	}
+
+	err = addCheckInterfacePerformanceData(interfaces, r.mon.Response)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	if r.RateMode {
+		err = r.checkMaxSpeeds(interfaces)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "invalid max speeds", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+
+		err = r.addInterfaceRatePerformanceData(ctx, interfaces)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding rate performance data", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
 	}
 
 	if r.PrintInterfaces {
@@ -70,7 +90,7 @@ func (r *CheckInterfaceMetricsRequest) process(ctx context.Context) (Response, e
 				IfIndex:       index,
 				IfDescr:       interf.IfDescr,
 				IfName:        interf.IfName,
-				IfType:        interf.IfType,
+				IfType:        interf.IfTypeName,
 				IfAlias:       interf.IfAlias,
 				IfPhysAddress: interf.IfPhysAddress,
 				IfAdminStatus: (*string)(interf.IfAdminStatus),
@@ -94,24 +114,30 @@ func (r *CheckInterfaceMetricsRequest) process(ctx context.Context) (Response, e
 			output, err := parser.Parse(interfaceOutput, "csv")
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while marshalling output", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 			r.mon.UpdateStatus(monitoringplugin.OK, string(output))
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
 
 func (r *CheckInterfaceMetricsRequest) getFilter() []groupproperty.Filter {
 	valueFilter := []groupproperty.Filter{
 		// ifTable
 		groupproperty.GetValueFilter([]string{"ifMtu"}),
-		groupproperty.GetValueFilter([]string{"ifLastChange"}),
 		groupproperty.GetValueFilter([]string{"ifOutQLen"}),
 		groupproperty.GetValueFilter([]string{"ifSpecific"}),
+		groupproperty.GetValueFilter([]string{"mac_address"}),
+		groupproperty.GetValueFilter([]string{"aggregation_id"}),
+		groupproperty.GetValueFilter([]string{"parent_if_index"}),
 		// VLANs
 		groupproperty.GetValueFilter([]string{"vlan"}),
+		// IP addresses
+		groupproperty.GetValueFilter([]string{"ip_addresses"}),
+		groupproperty.GetValueFilter([]string{"ipv4_stats"}),
+		groupproperty.GetValueFilter([]string{"ipv6_stats"}),
 		// Radio
 		groupproperty.GetValueFilter([]string{"radio", "rx_frequency"}),
 		groupproperty.GetValueFilter([]string{"radio", "tx_frequency"}),
@@ -119,12 +145,19 @@ func (r *CheckInterfaceMetricsRequest) getFilter() []groupproperty.Filter {
 		groupproperty.GetValueFilter([]string{"radio", "channels", "tx_frequency"}),
 	}
 
+	if !r.FlapDetection {
+		valueFilter = append(valueFilter, groupproperty.GetValueFilter([]string{"ifLastChange"}))
+	}
+
 	if !r.PrintInterfaces {
 		valueFilter = append(valueFilter,
 			groupproperty.GetValueFilter([]string{"ifType"}),
-			groupproperty.GetValueFilter([]string{"ifName"}),
 			groupproperty.GetValueFilter([]string{"ifAlias"}),
 		)
+		// the max speeds are given by ifName
+		if len(r.MaxSpeeds) == 0 {
+			valueFilter = append(valueFilter, groupproperty.GetValueFilter([]string{"ifName"}))
+		}
 	}
 
 	return append(r.InterfaceOptions.getFilter(), valueFilter...)
@@ -175,6 +208,24 @@ func (r *CheckInterfaceMetricsRequest) normalizeInterfaces(interfaces []device.I
 	return nil
 }
 
+// checkFlaps warns about all admin up interfaces that changed their state more recently than the flap threshold.
+// Interfaces without a known last change, e.g. because of a sysUpTime wrap, are skipped.
+func (r *CheckInterfaceMetricsRequest) checkFlaps(interfaces []device.Interface) {
+	threshold := defaultFlapThreshold
+	if r.FlapThreshold != nil {
+		threshold = *r.FlapThreshold
+	}
+
+	for _, interf := range interfaces {
+		if interf.IfAdminStatus == nil || *interf.IfAdminStatus != device.StatusUp || interf.IfLastChange == nil {
+			continue
+		}
+		if *interf.IfLastChange < threshold {
+			r.mon.UpdateStatus(monitoringplugin.WARNING, fmt.Sprintf("interface %s changed its state %d seconds ago", *interf.IfDescr, *interf.IfLastChange))
+		}
+	}
+}
+
 func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitoringplugin.Response) error {
 	for _, i := range interfaces {
 		//error_counter_in
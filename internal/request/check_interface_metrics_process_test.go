@@ -0,0 +1,36 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckInterfaceMetricsRequest_checkFlaps(t *testing.T) {
+	up, down := device.StatusUp, device.StatusDown
+	flapping, stable, disabled, unknown := "ether1", "ether2", "ether3", "ether4"
+	recent, old := uint64(60), uint64(3600)
+	interfaces := []device.Interface{
+		{IfDescr: &flapping, IfAdminStatus: &up, IfLastChange: &recent},
+		{IfDescr: &stable, IfAdminStatus: &up, IfLastChange: &old},
+		{IfDescr: &disabled, IfAdminStatus: &down, IfLastChange: &recent},
+		{IfDescr: &unknown, IfAdminStatus: &up},
+	}
+
+	r := CheckInterfaceMetricsRequest{FlapDetection: true}
+	r.init()
+	r.checkFlaps(interfaces)
+	assert.Equal(t, monitoringplugin.WARNING, r.mon.GetStatusCode())
+	assert.Contains(t, r.mon.GetInfo().RawOutput, "interface ether1 changed its state 60 seconds ago")
+	assert.NotContains(t, r.mon.GetInfo().RawOutput, "ether2")
+
+	threshold := uint64(30)
+	r = CheckInterfaceMetricsRequest{FlapDetection: true, FlapThreshold: &threshold}
+	r.init()
+	r.checkFlaps(interfaces)
+	assert.Equal(t, monitoringplugin.OK, r.mon.GetStatusCode())
+}
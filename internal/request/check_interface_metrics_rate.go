@@ -0,0 +1,332 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// interfaceCounterState holds the counters of a device which are needed to calculate rates on the next check.
+type interfaceCounterState struct {
+	Timestamp  time.Time                    `json:"timestamp"`
+	SysUpTime  *uint64                      `json:"sys_up_time"`
+	Interfaces map[string]interfaceCounters `json:"interfaces"`
+}
+
+type interfaceCounters struct {
+	InOctets  *uint64 `json:"in_octets"`
+	OutOctets *uint64 `json:"out_octets"`
+	InErrors  *uint64 `json:"in_errors"`
+	OutErrors *uint64 `json:"out_errors"`
+}
+
+// interfaceMaxSpeeds are the maximum speeds of an interface in bits per second, which its utilization is calculated against.
+type interfaceMaxSpeeds struct {
+	In  *uint64
+	Out *uint64
+}
+
+type interfaceCounterStateStore interface {
+	load(key string) (interfaceCounterState, error)
+	save(key string, state interfaceCounterState) error
+}
+
+func (r *CheckInterfaceMetricsRequest) addInterfaceRatePerformanceData(ctx context.Context, interfaces []device.Interface) error {
+	current := interfaceCounterState{
+		Timestamp:  time.Now(),
+		Interfaces: make(map[string]interfaceCounters),
+	}
+	maxSpeeds := make(map[string]interfaceMaxSpeeds)
+
+	sysUpTime, err := getSysUpTime(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get sysUpTime, reboots cannot be detected")
+	} else {
+		current.SysUpTime = &sysUpTime
+	}
+
+	for _, interf := range interfaces {
+		current.Interfaces[*interf.IfDescr] = interfaceCounters{
+			InOctets:  checkHCCounter(interf.IfHCInOctets, interf.IfInOctets),
+			OutOctets: checkHCCounter(interf.IfHCOutOctets, interf.IfOutOctets),
+			InErrors:  interf.IfInErrors,
+			OutErrors: interf.IfOutErrors,
+		}
+		maxSpeeds[*interf.IfDescr] = r.getMaxSpeeds(interf)
+	}
+
+	store := r.getCounterStateStore(ctx)
+	key := r.DeviceData.IPAddress
+
+	previous, loadErr := store.load(key)
+	if loadErr != nil && !tholaerr.IsNotFoundError(loadErr) {
+		return errors.Wrap(loadErr, "failed to load previous interface counters")
+	}
+
+	if err = store.save(key, current); err != nil {
+		return errors.Wrap(err, "failed to save interface counters")
+	}
+
+	if loadErr != nil {
+		log.Ctx(ctx).Debug().Msg("no previous interface counters available, rates will be calculated on the next check")
+		return nil
+	}
+
+	return addInterfaceRatePerformanceData(ctx, previous, current, maxSpeeds, r.UtilizationThresholds, r.mon)
+}
+
+// checkMaxSpeeds returns an error if the request contains max speeds for interfaces that don't exist.
+func (r *CheckInterfaceMetricsRequest) checkMaxSpeeds(interfaces []device.Interface) error {
+	ifNames := make(map[string]struct{})
+	for _, interf := range interfaces {
+		if interf.IfName != nil {
+			ifNames[*interf.IfName] = struct{}{}
+		}
+	}
+
+	var unknown []string
+	for ifName := range r.MaxSpeeds {
+		if _, ok := ifNames[ifName]; !ok {
+			unknown = append(unknown, ifName)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf("max speeds given for unknown interfaces '%s'", strings.Join(unknown, "', '"))
+	}
+	return nil
+}
+
+// getMaxSpeeds returns the max speeds of the interface. The max speeds of the request take precedence over the default
+// max speed, which takes precedence over the speed of the interface.
+func (r *CheckInterfaceMetricsRequest) getMaxSpeeds(interf device.Interface) interfaceMaxSpeeds {
+	if interf.IfName != nil {
+		if maxSpeed, ok := r.MaxSpeeds[*interf.IfName]; ok {
+			return interfaceMaxSpeeds{In: &maxSpeed, Out: &maxSpeed}
+		}
+	}
+	if r.DefaultMaxSpeed != nil {
+		return interfaceMaxSpeeds{In: r.DefaultMaxSpeed, Out: r.DefaultMaxSpeed}
+	}
+	return interfaceMaxSpeeds{In: getMaxSpeedIn(interf), Out: getMaxSpeedOut(interf)}
+}
+
+func addInterfaceRatePerformanceData(ctx context.Context, previous, current interfaceCounterState, maxSpeeds map[string]interfaceMaxSpeeds, utilizationThresholds Threshold, r *checkResponse) error {
+	if previous.SysUpTime != nil && current.SysUpTime != nil && *current.SysUpTime < *previous.SysUpTime {
+		log.Ctx(ctx).Debug().Msg("sysUpTime decreased, device was rebooted. discarding interval")
+		return nil
+	}
+
+	interval := current.Timestamp.Sub(previous.Timestamp).Seconds()
+	if interval <= 0 {
+		log.Ctx(ctx).Debug().Msg("interval between checks is not positive, discarding interval")
+		return nil
+	}
+
+	for label, counters := range current.Interfaces {
+		prevCounters, ok := previous.Interfaces[label]
+		if !ok {
+			continue
+		}
+
+		//traffic_rate_in
+		if rate, ok := calculateCounterRate(prevCounters.InOctets, counters.InOctets, interval); ok {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("traffic_rate_in", rate*8).SetLabel(label))
+			if err != nil {
+				return err
+			}
+
+			//traffic_utilization_in
+			if utilization, ok := calculateUtilization(rate*8, maxSpeeds[label].In); ok {
+				err = addPerformanceDataPointWithThreshold(r, monitoringplugin.NewPerformanceDataPoint("traffic_utilization_in", utilization).SetUnit("%").SetLabel(label), utilizationThresholds)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		//traffic_rate_out
+		if rate, ok := calculateCounterRate(prevCounters.OutOctets, counters.OutOctets, interval); ok {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("traffic_rate_out", rate*8).SetLabel(label))
+			if err != nil {
+				return err
+			}
+
+			//traffic_utilization_out
+			if utilization, ok := calculateUtilization(rate*8, maxSpeeds[label].Out); ok {
+				err = addPerformanceDataPointWithThreshold(r, monitoringplugin.NewPerformanceDataPoint("traffic_utilization_out", utilization).SetUnit("%").SetLabel(label), utilizationThresholds)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		//error_rate_in
+		if rate, ok := calculateCounterRate(prevCounters.InErrors, counters.InErrors, interval); ok {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_rate_in", rate).SetLabel(label))
+			if err != nil {
+				return err
+			}
+		}
+
+		//error_rate_out
+		if rate, ok := calculateCounterRate(prevCounters.OutErrors, counters.OutErrors, interval); ok {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_rate_out", rate).SetLabel(label))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// calculateCounterRate returns the per second rate of the counter.
+// If the counter wrapped or was reset, the interval is discarded.
+func calculateCounterRate(previous, current *uint64, seconds float64) (float64, bool) {
+	if previous == nil || current == nil || *current < *previous {
+		return 0, false
+	}
+	return float64(*current-*previous) / seconds, true
+}
+
+// calculateUtilization returns the utilization in percent of the bit rate against the max speed.
+func calculateUtilization(bitRate float64, maxSpeed *uint64) (float64, bool) {
+	if maxSpeed == nil || *maxSpeed == 0 {
+		return 0, false
+	}
+	return bitRate / float64(*maxSpeed) * 100, true
+}
+
+func getSysUpTime(ctx context.Context) (uint64, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return 0, errors.New("no device connection available")
+	}
+
+	res, err := con.SNMP.SnmpClient.SNMPGet(ctx, "1.3.6.1.2.1.1.3.0")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get sysUpTime")
+	}
+
+	val, err := res[0].GetValue()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get sysUpTime value")
+	}
+
+	return val.UInt64()
+}
+
+// getCounterStateStore returns the store of the counter states. API requests use the state directory of the server
+// configuration, or an in-memory store if none is configured.
+func (r *CheckInterfaceMetricsRequest) getCounterStateStore(ctx context.Context) interfaceCounterStateStore {
+	dir := r.StateDir
+	if IsAPIRequest(ctx) {
+		dir = viper.GetString("api.interface-metrics-state-dir")
+	}
+	if dir != "" {
+		return &fileCounterStateStore{dir: dir}
+	}
+	return &memoryCounterStates
+}
+
+// fileCounterStateStore persists the counter states as json files in a directory.
+type fileCounterStateStore struct {
+	dir string
+}
+
+var invalidStateFileCharacters = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+func (f *fileCounterStateStore) getPath(key string) string {
+	return filepath.Join(f.dir, "interface_metrics_"+invalidStateFileCharacters.ReplaceAllString(key, "_")+".json")
+}
+
+func (f *fileCounterStateStore) load(key string) (interfaceCounterState, error) {
+	b, err := os.ReadFile(f.getPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return interfaceCounterState{}, tholaerr.NewNotFoundError("no state file found")
+		}
+		return interfaceCounterState{}, errors.Wrap(err, "failed to read state file")
+	}
+
+	var state interfaceCounterState
+	if err = json.Unmarshal(b, &state); err != nil {
+		return interfaceCounterState{}, errors.Wrap(err, "failed to unmarshal state file")
+	}
+	return state, nil
+}
+
+func (f *fileCounterStateStore) save(key string, state interfaceCounterState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal state")
+	}
+
+	if err = os.MkdirAll(f.dir, 0755); err != nil {
+		return errors.Wrap(err, "failed to create state directory")
+	}
+
+	// write to a temporary file first, so concurrent checks never read a partially written state
+	tmp, err := os.CreateTemp(f.dir, ".interface_metrics_*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary state file")
+	}
+	if _, err = tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write temporary state file")
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to close temporary state file")
+	}
+	if err = os.Rename(tmp.Name(), f.getPath(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to rename temporary state file")
+	}
+	return nil
+}
+
+// memoryCounterStateStore keeps the counter states in memory, which is only useful when running as API.
+type memoryCounterStateStore struct {
+	sync.Mutex
+	states map[string]interfaceCounterState
+}
+
+var memoryCounterStates = memoryCounterStateStore{
+	states: make(map[string]interfaceCounterState),
+}
+
+func (m *memoryCounterStateStore) load(key string) (interfaceCounterState, error) {
+	m.Lock()
+	defer m.Unlock()
+	state, ok := m.states[key]
+	if !ok {
+		return interfaceCounterState{}, tholaerr.NewNotFoundError("no state found")
+	}
+	return state, nil
+}
+
+func (m *memoryCounterStateStore) save(key string, state interfaceCounterState) error {
+	m.Lock()
+	defer m.Unlock()
+	m.states[key] = state
+	return nil
+}
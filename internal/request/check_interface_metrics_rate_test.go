@@ -0,0 +1,114 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAddInterfaceRatePerformanceData_utilization(t *testing.T) {
+	r := CheckInterfaceMetricsRequest{
+		MaxSpeeds: map[string]uint64{"ether1": 2000000000},
+	}
+	shaped, speed := "ether1", uint64(10000000000)
+	unshaped := "ether2"
+
+	maxSpeeds := map[string]interfaceMaxSpeeds{
+		"ether1": r.getMaxSpeeds(device.Interface{IfName: &shaped, IfSpeed: &speed}),
+		"ether2": r.getMaxSpeeds(device.Interface{IfName: &unshaped, IfSpeed: &speed}),
+	}
+
+	// 250 MB/s in and 25 MB/s out
+	previousIn, previousOut := uint64(0), uint64(0)
+	currentIn, currentOut := uint64(2500000000), uint64(250000000)
+	now := time.Now()
+	previous := interfaceCounterState{
+		Timestamp: now.Add(-10 * time.Second),
+		Interfaces: map[string]interfaceCounters{
+			"ether1": {InOctets: &previousIn, OutOctets: &previousOut},
+			"ether2": {InOctets: &previousIn, OutOctets: &previousOut},
+		},
+	}
+	current := interfaceCounterState{
+		Timestamp: now,
+		Interfaces: map[string]interfaceCounters{
+			"ether1": {InOctets: &currentIn, OutOctets: &currentOut},
+			"ether2": {InOctets: &currentIn, OutOctets: &currentOut},
+		},
+	}
+
+	warning, critical := 80.0, 90.0
+	mon := newCheckResponse("checked")
+	err := addInterfaceRatePerformanceData(context.Background(), previous, current, maxSpeeds, Threshold{Warning: &ThresholdRange{End: &warning}, Critical: &ThresholdRange{End: &critical}}, mon)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	utilization := make(map[string]interface{})
+	for _, point := range mon.GetInfo().PerformanceData {
+		if point.Metric == "traffic_utilization_in" || point.Metric == "traffic_utilization_out" {
+			utilization[point.Metric+" "+point.Label] = point.Value
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"traffic_utilization_in ether1":  float64(100),
+		"traffic_utilization_out ether1": float64(10),
+		"traffic_utilization_in ether2":  float64(20),
+		"traffic_utilization_out ether2": float64(2),
+	}, utilization)
+	assert.Equal(t, monitoringplugin.CRITICAL, mon.GetStatusCode())
+}
+
+func TestCheckInterfaceMetricsRequest_checkMaxSpeeds(t *testing.T) {
+	ifName := "ether1"
+	interfaces := []device.Interface{{IfName: &ifName}}
+
+	r := CheckInterfaceMetricsRequest{
+		MaxSpeeds: map[string]uint64{"ether1": 2000000000},
+	}
+	assert.NoError(t, r.checkMaxSpeeds(interfaces))
+
+	r.MaxSpeeds["ether3"] = 1000000000
+	r.MaxSpeeds["ether2"] = 1000000000
+	assert.EqualError(t, r.checkMaxSpeeds(interfaces), "max speeds given for unknown interfaces 'ether2', 'ether3'")
+}
+
+func TestCheckInterfaceMetricsRequest_getMaxSpeeds(t *testing.T) {
+	ifName, speed, highSpeed := "ether2", uint64(100000000), uint64(1000000000)
+	interf := device.Interface{IfName: &ifName, IfSpeed: &speed, MaxSpeedOut: &highSpeed}
+
+	r := CheckInterfaceMetricsRequest{}
+	assert.Equal(t, interfaceMaxSpeeds{In: &speed, Out: &highSpeed}, r.getMaxSpeeds(interf))
+
+	defaultMaxSpeed := uint64(500000000)
+	r.DefaultMaxSpeed = &defaultMaxSpeed
+	assert.Equal(t, interfaceMaxSpeeds{In: &defaultMaxSpeed, Out: &defaultMaxSpeed}, r.getMaxSpeeds(interf))
+}
+
+func TestCheckInterfaceMetricsRequest_validate_stateDir(t *testing.T) {
+	r := CheckInterfaceMetricsRequest{RateMode: true}
+	assert.Error(t, r.validate(context.Background()), "state dir is required outside of the api")
+
+	r.StateDir = "/tmp"
+	assert.Error(t, r.validate(NewContextWithAPIRequest(context.Background())), "state dir can't be set in api requests")
+}
+
+func TestCheckInterfaceMetricsRequest_getCounterStateStore(t *testing.T) {
+	r := CheckInterfaceMetricsRequest{StateDir: "/var/lib/thola"}
+	assert.Equal(t, &fileCounterStateStore{dir: "/var/lib/thola"}, r.getCounterStateStore(context.Background()))
+
+	viper.Set("api.interface-metrics-state-dir", "/srv/thola")
+	defer viper.Set("api.interface-metrics-state-dir", "")
+	apiCtx := NewContextWithAPIRequest(context.Background())
+	assert.Equal(t, &fileCounterStateStore{dir: "/srv/thola"}, (&CheckInterfaceMetricsRequest{}).getCounterStateStore(apiCtx))
+
+	viper.Set("api.interface-metrics-state-dir", "")
+	assert.Equal(t, &memoryCounterStates, (&CheckInterfaceMetricsRequest{}).getCounterStateStore(apiCtx))
+}
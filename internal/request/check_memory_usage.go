@@ -2,7 +2,6 @@ package request
 
 import (
 	"context"
-	"github.com/inexio/go-monitoringplugin"
 )
 
 // CheckMemoryUsageRequest
@@ -12,7 +11,7 @@ import (
 // swagger:model
 type CheckMemoryUsageRequest struct {
 	CheckDeviceRequest
-	MemoryUsageThresholds monitoringplugin.Thresholds `json:"memoryUsageThresholds" xml:"memoryUsageThresholds"`
+	MemoryUsageThresholds Threshold `json:"memoryUsageThresholds" xml:"memoryUsageThresholds"`
 }
 
 func (r *CheckMemoryUsageRequest) validate(ctx context.Context) error {
@@ -14,12 +14,12 @@ func (r *CheckMemoryUsageRequest) process(ctx context.Context) (Response, error)
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	memoryPools, err := com.GetMemoryComponentMemoryUsage(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading memory usage", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	for k, memPool := range memoryPools {
@@ -27,7 +27,7 @@ func (r *CheckMemoryUsageRequest) process(ctx context.Context) (Response, error)
 			continue
 		}
 
-		point := monitoringplugin.NewPerformanceDataPoint("memory_usage", *memPool.Usage).SetUnit("%").SetThresholds(r.MemoryUsageThresholds)
+		point := monitoringplugin.NewPerformanceDataPoint("memory_usage", *memPool.Usage).SetUnit("%")
 
 		if memPool.Label != nil {
 			point.SetLabel(*memPool.Label)
@@ -39,11 +39,11 @@ func (r *CheckMemoryUsageRequest) process(ctx context.Context) (Response, error)
 			memPool.PerformanceDataPointModifier(point)
 		}
 
-		err = r.mon.AddPerformanceDataPoint(point)
+		err = addPerformanceDataPointWithThreshold(r.mon, point, r.MemoryUsageThresholds)
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
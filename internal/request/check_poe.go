@@ -0,0 +1,23 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+)
+
+// CheckPOERequest
+//
+// CheckPOERequest is the request struct for the check poe request.
+//
+// swagger:model
+type CheckPOERequest struct {
+	CheckDeviceRequest
+	PowerUsageThresholds monitoringplugin.Thresholds `json:"powerUsageThresholds" xml:"powerUsageThresholds"`
+}
+
+func (r *CheckPOERequest) validate(ctx context.Context) error {
+	if err := r.PowerUsageThresholds.Validate(); err != nil {
+		return err
+	}
+	return r.CheckDeviceRequest.validate(ctx)
+}
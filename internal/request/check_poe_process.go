@@ -0,0 +1,104 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+)
+
+func (r *CheckPOERequest) process(ctx context.Context) (Response, error) {
+	r.init()
+
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	poe, err := com.GetPOEComponent(ctx)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading poe", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	duplicateLabelCheckerPSE := make(duplicateLabelChecker)
+	for _, pse := range poe.PSEs {
+		duplicateLabelCheckerPSE.addLabel(pse.Description)
+	}
+	for _, pse := range poe.PSEs {
+		label := duplicateLabelCheckerPSE.getModifiedLabel(pse.Description)
+		outputDescription := "poe pse"
+		if label != "" {
+			outputDescription += " (" + label + ")"
+		}
+
+		if pse.OperStatus != nil {
+			r.mon.UpdateStatusIf(*pse.OperStatus == device.POEComponentPSEStatusFaulty, monitoringplugin.CRITICAL, outputDescription+" is faulty")
+		}
+
+		if pse.ConsumptionPower == nil {
+			continue
+		}
+
+		p := monitoringplugin.NewPerformanceDataPoint("poe_power_consumption", *pse.ConsumptionPower).SetUnit("W").SetLabel(label)
+		if pse.Power != nil {
+			p.SetMax(*pse.Power)
+		}
+		err = r.mon.AddPerformanceDataPoint(p)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+
+		if pse.Power == nil || *pse.Power == 0 {
+			continue
+		}
+		usage := *pse.ConsumptionPower / *pse.Power * 100
+
+		thresholds := r.PowerUsageThresholds
+		if !thresholds.HasWarning() && !thresholds.HasCritical() && pse.UsageThreshold != nil && *pse.UsageThreshold > 0 {
+			thresholds = monitoringplugin.Thresholds{
+				WarningMin: 0,
+				WarningMax: *pse.UsageThreshold,
+			}
+		}
+
+		p = monitoringplugin.NewPerformanceDataPoint("poe_power_usage", usage).SetUnit("%").SetLabel(label).SetMin(0).SetMax(100).SetThresholds(thresholds)
+		err = r.mon.AddPerformanceDataPoint(p)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	duplicateLabelCheckerPorts := make(duplicateLabelChecker)
+	for _, port := range poe.Ports {
+		duplicateLabelCheckerPorts.addLabel(port.Description)
+	}
+	for _, port := range poe.Ports {
+		label := duplicateLabelCheckerPorts.getModifiedLabel(port.Description)
+
+		if port.DetectionStatus != nil {
+			outputDescription := "poe port"
+			if label != "" {
+				outputDescription += " (" + label + ")"
+			}
+			r.mon.UpdateStatusIf(port.DetectionStatus.IsFaulty(), monitoringplugin.CRITICAL, outputDescription+" is in faulty state")
+		}
+
+		if port.Power != nil {
+			p := monitoringplugin.NewPerformanceDataPoint("poe_port_power", *port.Power).SetUnit("W").SetLabel(label)
+			if port.PowerAllocated != nil {
+				p.SetMax(*port.PowerAllocated)
+			}
+			err = r.mon.AddPerformanceDataPoint(p)
+			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+				r.mon.PrintPerformanceData(false)
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+			}
+		}
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
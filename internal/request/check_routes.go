@@ -0,0 +1,23 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+)
+
+// CheckRoutesRequest
+//
+// CheckRoutesRequest is the request struct for the check routes request.
+//
+// swagger:model
+type CheckRoutesRequest struct {
+	CheckDeviceRequest
+	RouteCountThresholds monitoringplugin.Thresholds `json:"routeCountThresholds" xml:"routeCountThresholds"`
+}
+
+func (r *CheckRoutesRequest) validate(ctx context.Context) error {
+	if err := r.RouteCountThresholds.Validate(); err != nil {
+		return err
+	}
+	return r.CheckDeviceRequest.validate(ctx)
+}
@@ -0,0 +1,30 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+)
+
+func (r *CheckRoutesRequest) process(ctx context.Context) (Response, error) {
+	r.init()
+
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	count, err := com.GetCountRoutes(ctx)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading count of routes", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("route_count", count).SetThresholds(r.RouteCountThresholds))
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
@@ -2,9 +2,24 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/pkg/errors"
+	"regexp"
 )
 
+// defaultSBCAgentStatusStates maps the status of an sbc agent (apSipSAStatus of Oracle ACME) to a monitoring state.
+// Statuses that are not part of the mapping result in an unknown state.
+var defaultSBCAgentStatusStates = map[int]int{
+	0: monitoringplugin.OK,       // disabled
+	1: monitoringplugin.CRITICAL, // outOfService
+	2: monitoringplugin.OK,       // standby
+	3: monitoringplugin.OK,       // inService
+	4: monitoringplugin.WARNING,  // constraintsViolation
+	5: monitoringplugin.WARNING,  // inServiceTimedOut
+	6: monitoringplugin.CRITICAL, // oosProvisionedResponse
+}
+
 // CheckSBCRequest
 //
 // CheckSBCRequest is the request struct for the check sbc request.
@@ -13,11 +28,46 @@ import (
 type CheckSBCRequest struct {
 	CheckDeviceRequest
 	SystemHealthScoreThresholds monitoringplugin.Thresholds
+	// Monitoring states (0 = OK, 1 = WARNING, 2 = CRITICAL, 3 = UNKNOWN) mapped by agent status. They override the
+	// default mapping of the status.
+	AgentStatusStates map[int]int `yaml:"agent_status_states" json:"agent_status_states" xml:"agent_status_states"`
+	// Agents whose hostname matches this regex are not checked for their status.
+	IgnoreAgents *string `yaml:"ignore_agents" json:"ignore_agents" xml:"ignore_agents"`
+
+	ignoreAgentsRegex *regexp.Regexp
 }
 
 func (r *CheckSBCRequest) validate(ctx context.Context) error {
 	if err := r.SystemHealthScoreThresholds.Validate(); err != nil {
 		return err
 	}
+	for status, state := range r.AgentStatusStates {
+		if state < monitoringplugin.OK || state > monitoringplugin.UNKNOWN {
+			return fmt.Errorf("invalid monitoring state '%d' for agent status '%d'", state, status)
+		}
+	}
+	if r.IgnoreAgents != nil {
+		regex, err := regexp.Compile(*r.IgnoreAgents)
+		if err != nil {
+			return errors.Wrap(err, "compiling ignore agents regex failed")
+		}
+		r.ignoreAgentsRegex = regex
+	}
 	return r.CheckDeviceRequest.validate(ctx)
 }
+
+// getAgentState returns the monitoring state of an agent status.
+func (r *CheckSBCRequest) getAgentState(status int) int {
+	if state, ok := r.AgentStatusStates[status]; ok {
+		return state
+	}
+	if state, ok := defaultSBCAgentStatusStates[status]; ok {
+		return state
+	}
+	return monitoringplugin.UNKNOWN
+}
+
+// isAgentIgnored returns whether the status of the agent with the given hostname is not checked.
+func (r *CheckSBCRequest) isAgentIgnored(hostname string) bool {
+	return r.ignoreAgentsRegex != nil && r.ignoreAgentsRegex.MatchString(hostname)
+}
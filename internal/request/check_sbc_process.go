@@ -5,7 +5,9 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
 )
 
 func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
@@ -13,19 +15,19 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	sbc, err := com.GetSBCComponent(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading sbc", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	if sbc.GlobalCallPerSecond != nil {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("global_call_per_second", *sbc.GlobalCallPerSecond))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
@@ -33,7 +35,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("global_concurrent_sessions", *sbc.GlobalConcurrentSessions))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
@@ -41,7 +43,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("active_local_contacts", *sbc.ActiveLocalContacts))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
@@ -49,21 +51,28 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("transcoding_capacity", *sbc.TranscodingCapacity))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
 	if sbc.LicenseCapacity != nil {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("license_capacity", *sbc.LicenseCapacity))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	if sbc.SessionUtilization != nil {
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("session_utilization", *sbc.SessionUtilization).SetUnit("%"))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
 	if sbc.SystemRedundancy != nil {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("system_redundancy", *sbc.SystemRedundancy))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 
 		r.mon.UpdateStatusIf(*sbc.SystemRedundancy != 2 && *sbc.SystemRedundancy != 3, monitoringplugin.CRITICAL, "system redundancy is critical")
@@ -76,7 +85,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 				SetMin(0).
 				SetMax(100))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
@@ -90,7 +99,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -99,7 +108,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -108,7 +117,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -117,7 +126,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -126,7 +135,16 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+			}
+		}
+
+		if agent.RegisteredEndpoints != nil {
+			p := monitoringplugin.NewPerformanceDataPoint("registered_endpoints", *agent.RegisteredEndpoints).SetLabel(*agent.Hostname)
+			err = r.mon.AddPerformanceDataPoint(p)
+			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+				r.mon.PrintPerformanceData(false)
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -135,7 +153,11 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+			}
+
+			if state := r.getAgentState(*agent.Status); state != monitoringplugin.OK && !r.isAgentIgnored(*agent.Hostname) {
+				r.mon.UpdateStatus(state, sbcAgentStatusMessage(agent))
 			}
 		}
 	}
@@ -150,7 +172,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -159,7 +181,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -168,7 +190,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -177,7 +199,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -186,7 +208,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -195,7 +217,7 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 
@@ -204,10 +226,17 @@ func (r *CheckSBCRequest) process(ctx context.Context) (Response, error) {
 			err = r.mon.AddPerformanceDataPoint(p)
 			if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 				r.mon.PrintPerformanceData(false)
-				return &CheckResponse{r.mon.GetInfo()}, nil
+				return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 			}
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
+
+func sbcAgentStatusMessage(agent device.SBCComponentAgent) string {
+	if agent.StatusName != nil {
+		return fmt.Sprintf("agent '%s' is %s (status: %d)", *agent.Hostname, *agent.StatusName, *agent.Status)
+	}
+	return fmt.Sprintf("agent '%s' has status %d", *agent.Hostname, *agent.Status)
 }
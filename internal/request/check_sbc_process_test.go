@@ -0,0 +1,54 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestCheckSBCRequest_getAgentState(t *testing.T) {
+	r := CheckSBCRequest{
+		AgentStatusStates: map[int]int{
+			2: monitoringplugin.CRITICAL,
+		},
+	}
+
+	assert.Equal(t, monitoringplugin.OK, r.getAgentState(3))
+	assert.Equal(t, monitoringplugin.CRITICAL, r.getAgentState(1))
+	assert.Equal(t, monitoringplugin.CRITICAL, r.getAgentState(2), "mapping overrides the default")
+	assert.Equal(t, monitoringplugin.UNKNOWN, r.getAgentState(42))
+}
+
+func TestCheckSBCRequest_validate(t *testing.T) {
+	r := CheckSBCRequest{
+		AgentStatusStates: map[int]int{1: 4},
+	}
+	assert.Error(t, r.validate(context.Background()), "invalid monitoring state")
+
+	ignoreAgents := "("
+	r = CheckSBCRequest{IgnoreAgents: &ignoreAgents}
+	assert.Error(t, r.validate(context.Background()), "invalid regex")
+}
+
+func TestCheckSBCRequest_isAgentIgnored(t *testing.T) {
+	r := CheckSBCRequest{ignoreAgentsRegex: regexp.MustCompile("^lab-")}
+
+	assert.True(t, r.isAgentIgnored("lab-sip-1"))
+	assert.False(t, r.isAgentIgnored("prod-sip-1"))
+	assert.False(t, (&CheckSBCRequest{}).isAgentIgnored("lab-sip-1"))
+}
+
+func TestSBCAgentStatusMessage(t *testing.T) {
+	hostname, status, statusName := "sip-1", 1, "outOfService"
+	agent := device.SBCComponentAgent{Hostname: &hostname, Status: &status}
+	assert.Equal(t, "agent 'sip-1' has status 1", sbcAgentStatusMessage(agent))
+
+	agent.StatusName = &statusName
+	assert.Equal(t, "agent 'sip-1' is outOfService (status: 1)", sbcAgentStatusMessage(agent))
+}
@@ -14,6 +14,9 @@ type CheckServerRequest struct {
 	CheckDeviceRequest
 	UsersThreshold monitoringplugin.Thresholds `json:"usersThreshold" xml:"usersThreshold"`
 	ProcsThreshold monitoringplugin.Thresholds `json:"procsThreshold" xml:"procsThreshold"`
+	LoadThreshold  monitoringplugin.Thresholds `json:"loadThreshold" xml:"loadThreshold"`
+	// SwapUsageThreshold is the threshold for the used swap space in percent.
+	SwapUsageThreshold monitoringplugin.Thresholds `json:"swapUsageThreshold" xml:"swapUsageThreshold"`
 }
 
 func (r *CheckServerRequest) validate(ctx context.Context) error {
@@ -25,5 +28,13 @@ func (r *CheckServerRequest) validate(ctx context.Context) error {
 		return err
 	}
 
+	if err := r.LoadThreshold.Validate(); err != nil {
+		return err
+	}
+
+	if err := r.SwapUsageThreshold.Validate(); err != nil {
+		return err
+	}
+
 	return r.CheckDeviceRequest.validate(ctx)
 }
@@ -13,28 +13,75 @@ func (r *CheckServerRequest) process(ctx context.Context) (Response, error) {
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	server, err := com.GetServerComponent(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading server", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	if server.Procs != nil {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("procs", *server.Procs).SetThresholds(r.ProcsThreshold))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 	if server.Users != nil {
 		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("users", *server.Users).SetThresholds(r.UsersThreshold))
 		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	for _, load := range []struct {
+		label string
+		value *float64
+	}{
+		{"1", server.LoadAverage1},
+		{"5", server.LoadAverage5},
+		{"15", server.LoadAverage15},
+	} {
+		if load.value == nil {
+			continue
+		}
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("load_average", *load.value).SetLabel(load.label).SetThresholds(r.LoadThreshold))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	if server.SwapUsed != nil {
+		p := monitoringplugin.NewPerformanceDataPoint("swap_used", *server.SwapUsed).SetUnit("B")
+
+		if server.SwapTotal != nil {
+			p.SetMax(*server.SwapTotal)
+
+			// the threshold is given in percent of the total swap space
+			if r.SwapUsageThreshold.HasWarning() || r.SwapUsageThreshold.HasCritical() {
+				thresholds := monitoringplugin.Thresholds{
+					WarningMin:  0,
+					CriticalMin: 0,
+				}
+				if r.SwapUsageThreshold.HasWarning() {
+					thresholds.WarningMax = float64(*server.SwapTotal) * r.SwapUsageThreshold.WarningMax.(float64) / 100
+				}
+				if r.SwapUsageThreshold.HasCritical() {
+					thresholds.CriticalMax = float64(*server.SwapTotal) * r.SwapUsageThreshold.CriticalMax.(float64) / 100
+				}
+				p.SetThresholds(thresholds)
+			}
+		}
+
+		err = r.mon.AddPerformanceDataPoint(p)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
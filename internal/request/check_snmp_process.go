@@ -20,12 +20,14 @@ func (r *CheckSNMPRequest) process(ctx context.Context) (Response, error) {
 		version := con.SnmpClient.GetVersion()
 		if version == "3" {
 			res.SuccessfulSnmpCredentials = &network.SNMPCredentials{
-				Version:       version,
-				Port:          con.SnmpClient.GetPort(),
-				V3Level:       utility.IfThenElse(con.SnmpClient.GetV3Level() == nil, "", *con.SnmpClient.GetV3Level()).(string),
-				V3ContextName: utility.IfThenElse(con.SnmpClient.GetV3ContextName() == nil, "", *con.SnmpClient.GetV3ContextName()).(string),
+				Version:        version,
+				Port:           con.SnmpClient.GetPort(),
+				V3Level:        utility.IfThenElse(con.SnmpClient.GetV3Level() == nil, "", *con.SnmpClient.GetV3Level()).(string),
+				V3ContextName:  utility.IfThenElse(con.SnmpClient.GetV3ContextName() == nil, "", *con.SnmpClient.GetV3ContextName()).(string),
+				V3AuthProtocol: utility.IfThenElse(con.SnmpClient.GetV3AuthProto() == nil, "", *con.SnmpClient.GetV3AuthProto()).(string),
+				V3PrivProtocol: utility.IfThenElse(con.SnmpClient.GetV3PrivProto() == nil, "", *con.SnmpClient.GetV3PrivProto()).(string),
 			}
-			r.mon.UpdateStatus(monitoringplugin.OK, fmt.Sprintf("version: '%s'; port: '%d'; level: '%s'; context_name: '%s'", res.SuccessfulSnmpCredentials.Version, res.SuccessfulSnmpCredentials.Port, res.SuccessfulSnmpCredentials.V3Level, res.SuccessfulSnmpCredentials.V3ContextName))
+			r.mon.UpdateStatus(monitoringplugin.OK, fmt.Sprintf("version: '%s'; port: '%d'; level: '%s'; context_name: '%s'; auth_protocol: '%s'; priv_protocol: '%s'", res.SuccessfulSnmpCredentials.Version, res.SuccessfulSnmpCredentials.Port, res.SuccessfulSnmpCredentials.V3Level, res.SuccessfulSnmpCredentials.V3ContextName, res.SuccessfulSnmpCredentials.V3AuthProtocol, res.SuccessfulSnmpCredentials.V3PrivProtocol))
 		} else {
 			res.SuccessfulSnmpCredentials = &network.SNMPCredentials{
 				Version:   con.SnmpClient.GetVersion(),
@@ -35,6 +37,6 @@ func (r *CheckSNMPRequest) process(ctx context.Context) (Response, error) {
 			r.mon.UpdateStatus(monitoringplugin.OK, fmt.Sprintf("version: '%s'; community: '%s'; port: '%d'", res.SuccessfulSnmpCredentials.Version, res.SuccessfulSnmpCredentials.Community, res.SuccessfulSnmpCredentials.Port))
 		}
 	}
-	res.CheckResponse = CheckResponse{r.mon.GetInfo()}
+	res.CheckResponse = CheckResponse{ResponseInfo: r.mon.GetInfo()}
 	return &res, nil
 }
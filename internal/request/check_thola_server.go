@@ -23,6 +23,14 @@ func (r *CheckTholaServerRequest) getTimeout() *int {
 	return r.Timeout
 }
 
+func (r *CheckTholaServerRequest) getComponentTimeoutWeights() map[string]float64 {
+	return nil
+}
+
+func (r *CheckTholaServerRequest) getSNMPTrace() bool {
+	return false
+}
+
 func (r *CheckTholaServerRequest) validate(_ context.Context) error {
 	return nil
 }
@@ -17,7 +17,7 @@ func (r *CheckTholaServerRequest) process(ctx context.Context) (Response, error)
 	stats, err := statistics.GetStatistics()
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "failed to get statistics", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	r.mon.UpdateStatus(monitoringplugin.OK, "thola server is running since "+stats.UpSince.Format(time.UnixDate))
@@ -25,38 +25,88 @@ func (r *CheckTholaServerRequest) process(ctx context.Context) (Response, error)
 	db, err := database.GetDB(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "failed to get database", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	err = db.CheckConnection(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.CRITICAL, "database is not alive", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("total_request_counter", stats.TotalCount).SetUnit("c"))
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("successful_request_counter", stats.SuccessfulCounter).SetUnit("c"))
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("failed_request_counter", stats.FailedCounter).SetUnit("c"))
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("average_response_time", stats.AverageResponseTime).SetUnit("s"))
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	if cacheStats := GetIdentifyCacheStatistics(); cacheStats.Enabled {
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("identify_cache_hit_rate", cacheStats.HitRate*100).SetUnit("%").SetMin(0).SetMax(100))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+
+		point := monitoringplugin.NewPerformanceDataPoint("identify_cache_size", cacheStats.Size).SetMin(0)
+		if cacheStats.MaxSize > 0 {
+			point.SetMax(cacheStats.MaxSize)
+		}
+		err = r.mon.AddPerformanceDataPoint(point)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	if cacheStats := GetMatchCacheStatistics(); cacheStats.Enabled {
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("match_cache_hit_rate", cacheStats.HitRate*100).SetUnit("%").SetMin(0).SetMax(100))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	if poolStats := GetSNMPSessionPoolStatistics(); poolStats.Enabled {
+		point := monitoringplugin.NewPerformanceDataPoint("snmp_session_pool_size", poolStats.Size).SetMin(0)
+		if poolStats.MaxSize > 0 {
+			point.SetMax(poolStats.MaxSize)
+		}
+		err = r.mon.AddPerformanceDataPoint(point)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("snmp_session_pool_reuse_rate", poolStats.ReuseRate*100).SetUnit("%").SetMin(0).SetMax(100))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+
+		err = r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("snmp_session_pool_evictions", poolStats.Evictions).SetUnit("c"))
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+		}
+	}
+
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 }
@@ -2,7 +2,6 @@ package request
 
 import (
 	"context"
-	"github.com/inexio/go-monitoringplugin"
 )
 
 // CheckUPSRequest
@@ -12,14 +11,26 @@ import (
 // swagger:model
 type CheckUPSRequest struct {
 	CheckDeviceRequest
-	BatteryCurrentThresholds     monitoringplugin.Thresholds `json:"batteryCurrentThresholds" xml:"batteryCurrentThresholds"`
-	BatteryTemperatureThresholds monitoringplugin.Thresholds `json:"batteryTemperatureThresholds" xml:"batteryTemperatureThresholds"`
-	CurrentLoadThresholds        monitoringplugin.Thresholds `json:"currentLoadThresholds" xml:"currentLoadThresholds"`
-	RectifierCurrentThresholds   monitoringplugin.Thresholds `json:"rectifierCurrentThresholds" xml:"rectifierCurrentThresholds"`
-	SystemVoltageThresholds      monitoringplugin.Thresholds `json:"systemVoltageThresholds" xml:"systemVoltageThresholds"`
+	// BatteryCapacityThresholds are only checked if the mains voltage is applied or unknown.
+	BatteryCapacityThresholds Threshold `json:"batteryCapacityThresholds" xml:"batteryCapacityThresholds"`
+	// BatteryRemainingTimeThresholds are only checked if the mains voltage is not applied or unknown.
+	BatteryRemainingTimeThresholds Threshold `json:"batteryRemainingTimeThresholds" xml:"batteryRemainingTimeThresholds"`
+	BatteryCurrentThresholds       Threshold `json:"batteryCurrentThresholds" xml:"batteryCurrentThresholds"`
+	BatteryTemperatureThresholds   Threshold `json:"batteryTemperatureThresholds" xml:"batteryTemperatureThresholds"`
+	CurrentLoadThresholds          Threshold `json:"currentLoadThresholds" xml:"currentLoadThresholds"`
+	RectifierCurrentThresholds     Threshold `json:"rectifierCurrentThresholds" xml:"rectifierCurrentThresholds"`
+	SystemVoltageThresholds        Threshold `json:"systemVoltageThresholds" xml:"systemVoltageThresholds"`
 }
 
 func (r *CheckUPSRequest) validate(ctx context.Context) error {
+	if err := r.BatteryCapacityThresholds.Validate(); err != nil {
+		return err
+	}
+
+	if err := r.BatteryRemainingTimeThresholds.Validate(); err != nil {
+		return err
+	}
+
 	if err := r.BatteryCurrentThresholds.Validate(); err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ package request
 import (
 	"context"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/utility"
 )
 
@@ -14,117 +15,129 @@ func (r *CheckUPSRequest) process(ctx context.Context) (Response, error) {
 
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
 	readUPSResponse, err := com.GetUPSComponent(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading ups", true) {
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
-	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while processing read ups request", true) {
+	err = r.checkUPS(readUPSResponse)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
 		r.mon.PrintPerformanceData(false)
-		return &CheckResponse{r.mon.GetInfo()}, nil
+		return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
 	}
 
-	if readUPSResponse.AlarmLowVoltageDisconnect != nil {
-		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("alarm_low_voltage_disconnect", *readUPSResponse.AlarmLowVoltageDisconnect))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	return &CheckResponse{ResponseInfo: r.mon.GetInfo()}, nil
+}
+
+// checkUPS adds all values of the ups as performance data and checks them against the thresholds.
+// If the mains voltage is not applied, the status is at least WARNING and only the battery remaining time is checked.
+// If it is applied, only the battery capacity is checked. If it is unknown, both are checked.
+func (r *CheckUPSRequest) checkUPS(ups device.UPSComponent) error {
+	checkRemainingTime := ups.MainsVoltageApplied == nil || !*ups.MainsVoltageApplied
+	checkCapacity := ups.MainsVoltageApplied == nil || *ups.MainsVoltageApplied
+
+	if ups.MainsVoltageApplied != nil {
+		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("mains_voltage_applied", utility.IfThenElse(*ups.MainsVoltageApplied, 1, 0)))
+		if err != nil {
+			return err
 		}
+		r.mon.UpdateStatusIfNot(*ups.MainsVoltageApplied, monitoringplugin.WARNING, "Mains voltage is not applied")
 	}
 
-	if readUPSResponse.BatteryAmperage != nil {
-		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("batt_amperage", *readUPSResponse.BatteryAmperage))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.AlarmLowVoltageDisconnect != nil {
+		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("alarm_low_voltage_disconnect", *ups.AlarmLowVoltageDisconnect))
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.BatteryRemainingTime != nil {
-		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("batt_remaining_time", *readUPSResponse.BatteryRemainingTime))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.BatteryAmperage != nil {
+		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("batt_amperage", *ups.BatteryAmperage))
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.BatteryCapacity != nil {
-		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("batt_capacity", *readUPSResponse.BatteryCapacity))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.BatteryRemainingTime != nil {
+		var threshold Threshold
+		if checkRemainingTime {
+			threshold = r.BatteryRemainingTimeThresholds
+		}
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("batt_remaining_time", *ups.BatteryRemainingTime),
+			threshold)
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.BatteryCurrent != nil {
-		err := r.mon.AddPerformanceDataPoint(
-			monitoringplugin.NewPerformanceDataPoint("batt_current", *readUPSResponse.BatteryCurrent).
-				SetThresholds(r.BatteryCurrentThresholds))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.BatteryCapacity != nil {
+		var threshold Threshold
+		if checkCapacity {
+			threshold = r.BatteryCapacityThresholds
+		}
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("batt_capacity", *ups.BatteryCapacity),
+			threshold)
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.BatteryTemperature != nil {
-		err := r.mon.AddPerformanceDataPoint(
-			monitoringplugin.NewPerformanceDataPoint("batt_temperature", *readUPSResponse.BatteryTemperature).
-				SetThresholds(r.BatteryTemperatureThresholds))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.BatteryCurrent != nil {
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("batt_current", *ups.BatteryCurrent),
+			r.BatteryCurrentThresholds)
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.BatteryVoltage != nil {
-		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("batt_voltage", *readUPSResponse.BatteryVoltage))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.BatteryTemperature != nil {
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("batt_temperature", *ups.BatteryTemperature),
+			r.BatteryTemperatureThresholds)
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.CurrentLoad != nil {
-		err := r.mon.AddPerformanceDataPoint(
-			monitoringplugin.NewPerformanceDataPoint("current_load", *readUPSResponse.CurrentLoad).
-				SetThresholds(r.CurrentLoadThresholds))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.BatteryVoltage != nil {
+		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("batt_voltage", *ups.BatteryVoltage))
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.MainsVoltageApplied != nil {
-		err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("mains_voltage_applied", utility.IfThenElse(*readUPSResponse.MainsVoltageApplied, 1, 0)))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.CurrentLoad != nil {
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("current_load", *ups.CurrentLoad),
+			r.CurrentLoadThresholds)
+		if err != nil {
+			return err
 		}
-		r.mon.UpdateStatusIfNot(*readUPSResponse.MainsVoltageApplied, monitoringplugin.CRITICAL, "Mains voltage is not applied")
 	}
 
-	if readUPSResponse.RectifierCurrent != nil {
-		err := r.mon.AddPerformanceDataPoint(
-			monitoringplugin.NewPerformanceDataPoint("rectifier_current", *readUPSResponse.RectifierCurrent).
-				SetThresholds(r.RectifierCurrentThresholds))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.RectifierCurrent != nil {
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("rectifier_current", *ups.RectifierCurrent),
+			r.RectifierCurrentThresholds)
+		if err != nil {
+			return err
 		}
 	}
 
-	if readUPSResponse.SystemVoltage != nil {
-		err := r.mon.AddPerformanceDataPoint(
-			monitoringplugin.NewPerformanceDataPoint("sys_voltage", *readUPSResponse.SystemVoltage).
-				SetThresholds(r.SystemVoltageThresholds))
-		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
-			r.mon.PrintPerformanceData(false)
-			return &CheckResponse{r.mon.GetInfo()}, nil
+	if ups.SystemVoltage != nil {
+		err := addPerformanceDataPointWithThreshold(r.mon,
+			monitoringplugin.NewPerformanceDataPoint("sys_voltage", *ups.SystemVoltage),
+			r.SystemVoltageThresholds)
+		if err != nil {
+			return err
 		}
 	}
 
-	return &CheckResponse{r.mon.GetInfo()}, nil
+	return nil
 }
@@ -0,0 +1,58 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckUPSRequest_checkUPS(t *testing.T) {
+	capacityThreshold, err := ParseThreshold("50:", "20:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	remainingTimeThreshold, err := ParseThreshold("", "10:")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	applied, notApplied := true, false
+	lowCapacity, highCapacity := 10.0, 100.0
+	shortTime, longTime := 5.0, 60.0
+
+	for _, c := range []struct {
+		name          string
+		mains         *bool
+		capacity      float64
+		remainingTime float64
+		status        int
+	}{
+		{"mains applied and low capacity", &applied, lowCapacity, shortTime, monitoringplugin.CRITICAL},
+		{"mains applied ignores remaining time", &applied, highCapacity, shortTime, monitoringplugin.OK},
+		{"mains not applied", &notApplied, highCapacity, longTime, monitoringplugin.WARNING},
+		{"mains not applied ignores capacity", &notApplied, lowCapacity, longTime, monitoringplugin.WARNING},
+		{"mains not applied and short remaining time", &notApplied, highCapacity, shortTime, monitoringplugin.CRITICAL},
+		{"unknown mains and low capacity", nil, lowCapacity, longTime, monitoringplugin.CRITICAL},
+		{"unknown mains and short remaining time", nil, highCapacity, shortTime, monitoringplugin.CRITICAL},
+		{"unknown mains", nil, highCapacity, longTime, monitoringplugin.OK},
+	} {
+		capacity, remainingTime := c.capacity, c.remainingTime
+		r := CheckUPSRequest{
+			BatteryCapacityThresholds:      capacityThreshold,
+			BatteryRemainingTimeThresholds: remainingTimeThreshold,
+		}
+		r.init()
+		err := r.checkUPS(device.UPSComponent{
+			MainsVoltageApplied:  c.mains,
+			BatteryCapacity:      &capacity,
+			BatteryRemainingTime: &remainingTime,
+		})
+		if assert.NoError(t, err, c.name) {
+			assert.Equal(t, c.status, r.mon.GetStatusCode(), c.name)
+		}
+	}
+}
@@ -124,10 +124,26 @@ func (r *CheckHardwareHealthRequest) process(ctx context.Context) (Response, err
 	return checkProcess(ctx, r, "check/hardware-health"), nil
 }
 
+func (r *CheckRoutesRequest) process(ctx context.Context) (Response, error) {
+	return checkProcess(ctx, r, "check/routes"), nil
+}
+
+func (r *CheckPOERequest) process(ctx context.Context) (Response, error) {
+	return checkProcess(ctx, r, "check/poe"), nil
+}
+
+func (r *CheckEnvironmentRequest) process(ctx context.Context) (Response, error) {
+	return checkProcess(ctx, r, "check/environment"), nil
+}
+
 func (r *CheckHighAvailabilityRequest) process(ctx context.Context) (Response, error) {
 	return checkProcess(ctx, r, "check/high-availability"), nil
 }
 
+func (r *CheckFirewallRequest) process(ctx context.Context) (Response, error) {
+	return checkProcess(ctx, r, "check/firewall"), nil
+}
+
 func (r *ReadInterfacesRequest) process(ctx context.Context) (Response, error) {
 	apiFormat := viper.GetString("target-api-format")
 	responseBody, err := sendToAPI(ctx, r, "read/interfaces", apiFormat)
@@ -282,6 +298,132 @@ func (r *ReadAvailableComponentsRequest) process(ctx context.Context) (Response,
 	return &res, nil
 }
 
+func (r *ReadNeighborsRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/neighbors", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadNeighborsResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadComponentsRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/components", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadComponentsResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadVLANsRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/vlans", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadVLANsResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadRoutesRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/routes", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadRoutesResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadEntityRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/entity", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadEntityResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadConfigRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/config", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadConfigResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadWLANRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/wlan", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadWLANResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadFirewallRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/firewall", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadFirewallResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadOIDRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/oid", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadOIDResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
 func checkProcess(ctx context.Context, r Request, apiPath string) Response {
 	var res CheckResponse
 	apiFormat := viper.GetString("target-api-format")
@@ -346,10 +488,14 @@ func sendToAPI(ctx context.Context, request Request, path, format string) ([]byt
 			resStr := strings.Trim(fmt.Sprintf("%s", restyResponse.Body()), " \t\n")
 			return nil, fmt.Errorf("an error occurred during api call. response body: '%s'", resStr)
 		}
-		if errMsg, ok := errorMessageFetcher["message"]; ok {
-			return nil, fmt.Errorf("%s", errMsg)
-		}
 		if errMsg, ok := errorMessageFetcher["error"]; ok {
+			err = fmt.Errorf("%s", errMsg)
+			if code, ok := errorMessageFetcher["code"].(string); ok {
+				err = tholaerr.WithCode(err, tholaerr.ErrorCode(code))
+			}
+			return nil, err
+		}
+		if errMsg, ok := errorMessageFetcher["message"]; ok {
 			return nil, fmt.Errorf("%s", errMsg)
 		}
 		return nil, fmt.Errorf("an error occurred during api call. response body: '%s'", restyResponse.Body())
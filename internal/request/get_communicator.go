@@ -16,16 +16,68 @@ import (
 
 // GetCommunicator returns a NetworkDeviceCommunicator for the given device.
 func GetCommunicator(ctx context.Context, baseRequest BaseRequest) (communicator.Communicator, error) {
+	deviceProperties, err := getDevicePropertiesWithIdentifyCache(ctx, baseRequest)
+	if err != nil {
+		return nil, err
+	}
+	ctx = device.NewContextWithDeviceProperties(ctx, deviceProperties)
+
+	com, err := create.GetNetworkDeviceCommunicator(ctx, deviceProperties.Class)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get communicator for os '%s'", deviceProperties.Class)
+	}
+
+	err = com.UpdateConnection(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update connection")
+	}
+
+	return com, nil
+}
+
+// getDevicePropertiesWithIdentifyCache returns the device properties from the in-process identify cache if possible.
+// Otherwise, they are read from the database or identified, and stored in the identify cache.
+func getDevicePropertiesWithIdentifyCache(ctx context.Context, baseRequest BaseRequest) (device.Device, error) {
+	cache := getIdentifyCache()
+	if cache == nil {
+		return getDeviceProperties(ctx, baseRequest)
+	}
+
+	key, err := identifyCacheKey(baseRequest.DeviceData)
+	if err != nil {
+		return device.Device{}, errors.Wrap(err, "failed to get identify cache key")
+	}
+
+	if !baseRequest.NoIdentifyCache {
+		if deviceProperties, ok := cache.get(key); ok {
+			log.Ctx(ctx).Debug().Str("device_class", deviceProperties.Class).Msg("found device properties in identify cache")
+			if usage, ok := identifyCacheUsageFromContext(ctx); ok {
+				usage.key = key
+			}
+			return deviceProperties, nil
+		}
+	}
+
+	deviceProperties, err := getDeviceProperties(ctx, baseRequest)
+	if err != nil {
+		return device.Device{}, err
+	}
+	cache.set(key, deviceProperties)
+	return deviceProperties, nil
+}
+
+// getDeviceProperties returns the device properties from the database if they are still valid, otherwise the device is identified.
+func getDeviceProperties(ctx context.Context, baseRequest BaseRequest) (device.Device, error) {
 	db, err := database.GetDB(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get DB")
+		return device.Device{}, errors.Wrap(err, "failed to get DB")
 	}
 
 	var invalidCache bool
 	deviceProperties, err := db.GetDeviceProperties(ctx, baseRequest.DeviceData.IPAddress)
 	if err != nil {
 		if !tholaerr.IsNotFoundError(err) {
-			return nil, errors.Wrap(err, "failed to get device properties from cache")
+			return device.Device{}, errors.Wrap(err, "failed to get device properties from cache")
 		}
 		log.Ctx(ctx).Debug().Msg("no device properties found in cache")
 		invalidCache = true
@@ -34,9 +86,9 @@ func GetCommunicator(ctx context.Context, baseRequest BaseRequest) (communicator
 		ctx := logger.WithContext(ctx)
 
 		log.Ctx(ctx).Debug().Msg("found device properties in cache, starting to validate")
-		res, err := create.MatchDeviceClass(ctx, deviceProperties.Class)
+		res, err := matchDeviceClass(ctx, baseRequest, deviceProperties.Class)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to match device class")
+			return device.Device{}, errors.Wrap(err, "failed to match device class")
 		}
 		if invalidCache = !res; invalidCache {
 			log.Ctx(ctx).Debug().Msg("cached device class is invalid")
@@ -48,21 +100,34 @@ func GetCommunicator(ctx context.Context, baseRequest BaseRequest) (communicator
 		identifyRequest := IdentifyRequest{BaseRequest: baseRequest}
 		res, err := identifyRequest.process(ctx)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to run identify")
+			return device.Device{}, errors.Wrap(err, "failed to run identify")
 		}
 		deviceProperties = res.(*IdentifyResponse).Device
 	}
-	ctx = device.NewContextWithDeviceProperties(ctx, deviceProperties)
+	return deviceProperties, nil
+}
 
-	com, err := create.GetNetworkDeviceCommunicator(ctx, deviceProperties.Class)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get communicator for os '%s'", deviceProperties.Class)
+// matchDeviceClass checks if the device matches the given device class. If the match cache is enabled and contains the
+// device class for the device, the match conditions aren't checked again.
+func matchDeviceClass(ctx context.Context, baseRequest BaseRequest, class string) (bool, error) {
+	cache := getMatchCache()
+	if cache == nil {
+		return create.MatchDeviceClass(ctx, class)
 	}
 
-	err = com.UpdateConnection(ctx)
+	key, err := identifyCacheKey(baseRequest.DeviceData)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to update connection")
+		return false, errors.Wrap(err, "failed to get match cache key")
+	}
+	if !baseRequest.NoIdentifyCache {
+		if cachedClass, _, ok := cache.get(key); ok && cachedClass == class {
+			log.Ctx(ctx).Debug().Msg("found device class in match cache")
+			if usage, ok := identifyCacheUsageFromContext(ctx); ok {
+				usage.key = key
+			}
+			return true, nil
+		}
 	}
 
-	return com, nil
+	return create.MatchDeviceClass(ctx, class)
 }
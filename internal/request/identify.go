@@ -2,6 +2,7 @@ package request
 
 import (
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
 )
 
 // IdentifyRequest
@@ -20,5 +21,11 @@ type IdentifyRequest struct {
 // swagger:model
 type IdentifyResponse struct {
 	device.Device `yaml:",inline"`
-	BaseResponse  `yaml:",inline"`
+	// The ratio of passed to total match conditions of the identified device class.
+	//
+	// example: 1
+	Confidence float64 `yaml:"confidence" json:"confidence" xml:"confidence"`
+	// The SNMP connection which was used to identify the device.
+	SNMPConnection *network.SNMPConnectionInfo `yaml:"snmp_connection,omitempty" json:"snmp_connection,omitempty" xml:"snmp_connection,omitempty"`
+	BaseResponse   `yaml:",inline"`
 }
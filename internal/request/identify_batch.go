@@ -0,0 +1,26 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+)
+
+// processIdentifyBatchEntry processes a single identify request of an identify batch.
+var processIdentifyBatchEntry BatchProcessFunc = ProcessRequest
+
+// IdentifyBatch identifies the given devices concurrently with the given amount of workers and returns the identify
+// responses mapped by the IP address of the devices. A device which can't be identified doesn't fail the batch, its
+// error is part of its result instead. All identifies are cancelled as soon as the given context is done.
+func IdentifyBatch(ctx context.Context, targets []DeviceData, workers int) (*BatchResponse, error) {
+	requests := make([]Request, len(targets))
+	for i, target := range targets {
+		requests[i] = &IdentifyRequest{
+			BaseRequest: BaseRequest{
+				DeviceData: target,
+			},
+		}
+	}
+	return ProcessBatch(ctx, requests, BatchOptions{Workers: &workers}, processIdentifyBatchEntry)
+}
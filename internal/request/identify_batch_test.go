@@ -0,0 +1,99 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// mockIdentifyBatchEntry identifies the mock targets. Unreachable targets don't respond until the context is done.
+func mockIdentifyBatchEntry(unreachable map[string]bool, delay time.Duration) BatchProcessFunc {
+	return func(ctx context.Context, r Request) (Response, error) {
+		host, err := BatchHost(r)
+		if err != nil {
+			return nil, err
+		}
+		if unreachable[host] {
+			<-ctx.Done()
+			return r.HandlePreProcessError(errors.New("request timed out"))
+		}
+		time.Sleep(delay)
+		return &IdentifyResponse{Device: device.Device{Class: "generic"}}, nil
+	}
+}
+
+func setMockIdentifyBatchEntry(t testing.TB, process BatchProcessFunc) {
+	processIdentifyBatchEntry = process
+	t.Cleanup(func() {
+		processIdentifyBatchEntry = ProcessRequest
+	})
+}
+
+func TestIdentifyBatch(t *testing.T) {
+	setMockIdentifyBatchEntry(t, mockIdentifyBatchEntry(map[string]bool{"192.0.2.2": true}, 0))
+
+	targets := []DeviceData{{IPAddress: "192.0.2.1"}, {IPAddress: "192.0.2.2"}, {IPAddress: "192.0.2.3"}, {IPAddress: "192.0.2.4"}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	res, err := IdentifyBatch(ctx, targets, 2)
+	if assert.NoError(t, err) {
+		assert.Len(t, res.Results, 4)
+		for _, host := range []string{"192.0.2.1", "192.0.2.3", "192.0.2.4"} {
+			if assert.Equal(t, BatchStatusOK, res.Results[host].Status, host) {
+				assert.Equal(t, "generic", res.Results[host].Response.(*IdentifyResponse).Class)
+			}
+		}
+		assert.Equal(t, BatchStatusError, res.Results["192.0.2.2"].Status)
+		assert.Equal(t, "request timed out", res.Results["192.0.2.2"].Error)
+		assert.Equal(t, 0, res.GetExitCode())
+	}
+}
+
+func TestIdentifyBatch_cancelled(t *testing.T) {
+	setMockIdentifyBatchEntry(t, mockIdentifyBatchEntry(nil, 0))
+
+	targets := []DeviceData{{IPAddress: "192.0.2.1"}, {IPAddress: "192.0.2.2"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err := IdentifyBatch(ctx, targets, 1)
+	if assert.NoError(t, err) {
+		for _, result := range res.Results {
+			assert.Equal(t, BatchStatusError, result.Status)
+			assert.Equal(t, "batch was cancelled before the request was started", result.Error)
+		}
+		assert.Equal(t, 3, res.GetExitCode())
+	}
+}
+
+func TestIdentifyBatch_invalidWorkers(t *testing.T) {
+	_, err := IdentifyBatch(context.Background(), []DeviceData{{IPAddress: "192.0.2.1"}}, 0)
+	assert.Error(t, err)
+}
+
+func BenchmarkIdentifyBatch(b *testing.B) {
+	setMockIdentifyBatchEntry(b, mockIdentifyBatchEntry(nil, time.Millisecond))
+
+	targets := make([]DeviceData, 256)
+	for i := range targets {
+		targets[i] = DeviceData{IPAddress: fmt.Sprintf("10.0.%d.%d", i/256, i%256)}
+	}
+
+	for _, workers := range []int{1, 16, 64} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := IdentifyBatch(context.Background(), targets, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
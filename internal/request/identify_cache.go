@@ -0,0 +1,128 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"sync"
+	"time"
+)
+
+var identifyCacheInstance struct {
+	sync.Once
+	cache *identifyCache
+}
+
+// identifyCache is an in-process cache which maps a device (ip and connection data) to its identified device properties.
+// It is only enabled in API mode, if a TTL is configured.
+type identifyCache struct {
+	*ttlCache
+}
+
+// getIdentifyCache returns the identify cache, or nil if it is disabled.
+func getIdentifyCache() *identifyCache {
+	identifyCacheInstance.Do(func() {
+		ttl := viper.GetDuration("api.identify-cache-ttl")
+		if ttl <= 0 {
+			return
+		}
+		identifyCacheInstance.cache = newIdentifyCache(ttl, viper.GetInt("api.identify-cache-size"))
+	})
+	return identifyCacheInstance.cache
+}
+
+func newIdentifyCache(ttl time.Duration, maxSize int) *identifyCache {
+	return &identifyCache{newTTLCache(ttl, maxSize)}
+}
+
+func (c *identifyCache) get(key string) (device.Device, bool) {
+	dev, ok := c.ttlCache.get(key)
+	if !ok {
+		return device.Device{}, false
+	}
+	return dev.(device.Device), true
+}
+
+func (c *identifyCache) set(key string, dev device.Device) {
+	c.ttlCache.set(key, dev)
+}
+
+// GetIdentifyCacheStatistics returns the statistics of the identify cache.
+func GetIdentifyCacheStatistics() CacheStatistics {
+	cache := getIdentifyCache()
+	if cache == nil {
+		return CacheStatistics{}
+	}
+	return cache.statistics()
+}
+
+// FlushIdentifyCache removes all entries from the identify cache.
+func FlushIdentifyCache() {
+	if cache := getIdentifyCache(); cache != nil {
+		cache.flush()
+	}
+}
+
+// identifyCacheKey returns the cache key for the device of the request.
+// The connection data is part of the key, so that requests with different credentials don't share an entry.
+func identifyCacheKey(deviceData DeviceData) (string, error) {
+	connectionData, err := json.Marshal(deviceData.ConnectionData)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(connectionData)
+	return deviceData.IPAddress + "/" + hex.EncodeToString(hash[:]), nil
+}
+
+type identifyCacheUsageKey byte
+
+const identifyCacheUsageCtxKey identifyCacheUsageKey = iota + 1
+
+// identifyCacheUsage stores which identify or match cache entry was used while processing a request.
+type identifyCacheUsage struct {
+	key string
+}
+
+func newContextWithIdentifyCacheUsage(ctx context.Context) (context.Context, *identifyCacheUsage) {
+	usage := &identifyCacheUsage{}
+	return context.WithValue(ctx, identifyCacheUsageCtxKey, usage), usage
+}
+
+func identifyCacheUsageFromContext(ctx context.Context) (*identifyCacheUsage, bool) {
+	usage, ok := ctx.Value(identifyCacheUsageCtxKey).(*identifyCacheUsage)
+	return usage, ok
+}
+
+// invalidateIdentifyCacheOnFailure removes the used identify and match cache entry if the request failed with an error
+// that indicates that the cached device class doesn't match the device anymore.
+func invalidateIdentifyCacheOnFailure(ctx context.Context, usage *identifyCacheUsage, res Response, err error) {
+	if usage.key == "" {
+		return
+	}
+
+	var failed bool
+	if err != nil {
+		failed = tholaerr.IsNotFoundError(err) || tholaerr.IsComponentNotFoundError(err) || tholaerr.IsNotImplementedError(err)
+	} else if checkResponse, ok := res.(*CheckResponse); ok {
+		failed = checkResponse.StatusCode == monitoringplugin.UNKNOWN
+	}
+
+	if failed {
+		log.Ctx(ctx).Debug().Msg("request failed, invalidating identify cache entry")
+		if cache := getIdentifyCache(); cache != nil {
+			cache.invalidate(usage.key)
+		}
+		if cache := getMatchCache(); cache != nil {
+			cache.invalidate(usage.key)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIdentifyCache(t *testing.T) {
+	cache := newIdentifyCache(time.Hour, 0)
+
+	_, ok := cache.get("a")
+	assert.False(t, ok)
+
+	cache.set("a", device.Device{Class: "ios"})
+	dev, ok := cache.get("a")
+	if assert.True(t, ok) {
+		assert.Equal(t, "ios", dev.Class)
+	}
+}
+
+func TestIdentifyCacheKey(t *testing.T) {
+	community := DeviceData{
+		IPAddress: "192.0.2.1",
+		ConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{Communities: []string{"public"}},
+		},
+	}
+	otherCommunity := DeviceData{
+		IPAddress: "192.0.2.1",
+		ConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{Communities: []string{"private"}},
+		},
+	}
+
+	key1, err := identifyCacheKey(community)
+	assert.NoError(t, err)
+	key2, err := identifyCacheKey(otherCommunity)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key1, key2)
+
+	key3, err := identifyCacheKey(community)
+	assert.NoError(t, err)
+	assert.Equal(t, key1, key3)
+}
@@ -5,6 +5,7 @@ package request
 
 import (
 	"context"
+	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/communicator/create"
 	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/network"
@@ -29,6 +30,7 @@ func (r *IdentifyRequest) process(ctx context.Context) (Response, error) {
 	if !ok {
 		return nil, errors.New("no connection data found in context")
 	}
+	response.SNMPConnection = con.GetSNMPConnectionInfo()
 
 	err = db.SetDeviceProperties(ctx, r.DeviceData.IPAddress, response.Device)
 	if err != nil {
@@ -40,17 +42,26 @@ func (r *IdentifyRequest) process(ctx context.Context) (Response, error) {
 		return nil, errors.Wrap(err, "failed to save connection data to cache")
 	}
 
+	if cache := getIdentifyCache(); cache != nil {
+		key, err := identifyCacheKey(r.DeviceData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get identify cache key")
+		}
+		cache.set(key, response.Device)
+	}
+
 	return response, nil
 }
 
 func (r *IdentifyRequest) identify(ctx context.Context) (*IdentifyResponse, error) {
-	com, err := create.IdentifyNetworkDeviceCommunicator(ctx)
+	com, confidence, err := r.identifyDeviceClass(ctx, getMatchCache())
 	if err != nil {
 		return nil, err
 	}
 
 	var response IdentifyResponse
 	response.Class = com.GetIdentifier()
+	response.Confidence = confidence
 
 	response.Properties, err = com.GetIdentifyProperties(ctx)
 	if err != nil {
@@ -58,3 +69,52 @@ func (r *IdentifyRequest) identify(ctx context.Context) (*IdentifyResponse, erro
 	}
 	return &response, nil
 }
+
+// identifyDeviceClass returns the communicator of the device class which matches the device and the confidence of the
+// match. If the match cache is enabled, the device class of a previous identify is used without matching again.
+func (r *IdentifyRequest) identifyDeviceClass(ctx context.Context, cache *matchCache) (communicator.Communicator, float64, error) {
+	var key string
+	if cache != nil {
+		var err error
+		key, err = identifyCacheKey(r.DeviceData)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to get match cache key")
+		}
+
+		if !r.NoIdentifyCache {
+			if class, confidence, ok := cache.get(key); ok {
+				com, err := create.GetNetworkDeviceCommunicator(ctx, class)
+				if err == nil {
+					log.Ctx(ctx).Debug().Str("device_class", class).Msg("found device class in match cache")
+					if usage, ok := identifyCacheUsageFromContext(ctx); ok {
+						usage.key = key
+					}
+					return com, confidence, nil
+				}
+				log.Ctx(ctx).Debug().Err(err).Str("device_class", class).Msg("device class of match cache doesn't exist anymore")
+				cache.invalidate(key)
+			}
+		}
+	}
+
+	com, confidence, err := create.IdentifyNetworkDeviceCommunicatorWithConfidence(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the explanation needs additional requests, so it is only created if it is logged
+	if e := log.Ctx(ctx).Debug(); e.Enabled() {
+		explanations, err := create.ExplainIdentify(ctx)
+		if err != nil {
+			e.Discard()
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to explain device class matching")
+		} else {
+			e.Interface("device_classes", explanations).Msg("device class matching explanation")
+		}
+	}
+
+	if cache != nil {
+		cache.set(key, com.GetIdentifier(), confidence)
+	}
+	return com, confidence, nil
+}
@@ -0,0 +1,76 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/spf13/viper"
+	"sync"
+	"time"
+)
+
+var matchCacheInstance struct {
+	sync.Once
+	cache *matchCache
+}
+
+// matchCache is an in-process cache which maps a device (ip and connection data) to the device class it matched.
+// In contrast to the identify cache, the identify properties are read from the device on every identify, only the
+// match conditions of the device classes are skipped.
+// It is only enabled in API mode, if a TTL is configured.
+type matchCache struct {
+	*ttlCache
+}
+
+type matchCacheEntry struct {
+	class      string
+	confidence float64
+}
+
+// getMatchCache returns the match cache, or nil if it is disabled.
+func getMatchCache() *matchCache {
+	matchCacheInstance.Do(func() {
+		ttl := viper.GetDuration("api.match-cache-ttl")
+		if ttl <= 0 {
+			return
+		}
+		matchCacheInstance.cache = newMatchCache(ttl, viper.GetInt("api.match-cache-size"))
+	})
+	return matchCacheInstance.cache
+}
+
+func newMatchCache(ttl time.Duration, maxSize int) *matchCache {
+	return &matchCache{newTTLCache(ttl, maxSize)}
+}
+
+// get returns the matched device class and its confidence.
+func (c *matchCache) get(key string) (string, float64, bool) {
+	entry, ok := c.ttlCache.get(key)
+	if !ok {
+		return "", 0, false
+	}
+	return entry.(matchCacheEntry).class, entry.(matchCacheEntry).confidence, true
+}
+
+func (c *matchCache) set(key, class string, confidence float64) {
+	c.ttlCache.set(key, matchCacheEntry{
+		class:      class,
+		confidence: confidence,
+	})
+}
+
+// GetMatchCacheStatistics returns the statistics of the match cache.
+func GetMatchCacheStatistics() CacheStatistics {
+	cache := getMatchCache()
+	if cache == nil {
+		return CacheStatistics{}
+	}
+	return cache.statistics()
+}
+
+// FlushMatchCache removes all entries from the match cache.
+func FlushMatchCache() {
+	if cache := getMatchCache(); cache != nil {
+		cache.flush()
+	}
+}
@@ -0,0 +1,74 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestMatchCache(t *testing.T) {
+	cache := newMatchCache(time.Hour, 0)
+
+	_, _, ok := cache.get("a")
+	assert.False(t, ok)
+
+	cache.set("a", "junos", 0.5)
+	class, confidence, ok := cache.get("a")
+	if assert.True(t, ok) {
+		assert.Equal(t, "junos", class)
+		assert.Equal(t, 0.5, confidence)
+	}
+}
+
+func TestIdentifyRequest_identifyDeviceClass_matchCache(t *testing.T) {
+	sysObjectID, sysDescription := ".1.3.6.1.4.1.8072.3.2.10", "Linux"
+	newContext := func(snmpClient *network.MockSNMPClient) context.Context {
+		snmpClient.On("SNMPGet", mock.Anything, mock.Anything).Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+		snmpClient.On("SNMPWalk", mock.Anything, mock.Anything).Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+		snmpClient.On("SNMPWalkGetNext", mock.Anything, mock.Anything).Return(nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID"))
+		snmpClient.On("SetMaxRepetitions", mock.Anything).Return()
+		snmpClient.On("HasSuccessfulCachedRequest").Return(true)
+		return network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+			RawConnectionData: network.ConnectionData{SNMP: &network.SNMPConnectionData{}},
+			SNMP: &network.RequestDeviceConnectionSNMP{
+				SnmpClient: snmpClient,
+				CommonOIDs: network.CommonOIDs{SysObjectID: &sysObjectID, SysDescription: &sysDescription},
+			},
+		})
+	}
+
+	cache := newMatchCache(time.Hour, 0)
+	r := IdentifyRequest{BaseRequest: BaseRequest{DeviceData: DeviceData{IPAddress: "192.0.2.1"}}}
+
+	var snmpClient network.MockSNMPClient
+	com, confidence, err := r.identifyDeviceClass(newContext(&snmpClient), cache)
+	if !assert.NoError(t, err) {
+		return
+	}
+	class := com.GetIdentifier()
+	assert.NotEmpty(t, snmpClient.Calls)
+
+	// the second identify uses the device class of the match cache without sending any snmp requests
+	var secondSNMPClient network.MockSNMPClient
+	com, cachedConfidence, err := r.identifyDeviceClass(newContext(&secondSNMPClient), cache)
+	if assert.NoError(t, err) {
+		assert.Equal(t, class, com.GetIdentifier())
+		assert.Equal(t, confidence, cachedConfidence)
+	}
+	secondSNMPClient.AssertNotCalled(t, "SNMPGet", mock.Anything, mock.Anything)
+	secondSNMPClient.AssertNotCalled(t, "SNMPWalk", mock.Anything, mock.Anything)
+
+	// the match cache can be bypassed
+	r.NoIdentifyCache = true
+	var thirdSNMPClient network.MockSNMPClient
+	_, _, err = r.identifyDeviceClass(newContext(&thirdSNMPClient), cache)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), cache.statistics().Hits)
+}
@@ -6,8 +6,11 @@ package request
 import (
 	"context"
 	"fmt"
+	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"strconv"
 	"time"
 )
@@ -24,7 +27,7 @@ func ProcessRequest(ctx context.Context, request Request) (Response, error) {
 
 	err := request.validate(ctx)
 	if err != nil {
-		return request.HandlePreProcessError(errors.Wrap(err, "invalid request"))
+		return request.HandlePreProcessError(tholaerr.WithCode(errors.Wrap(err, "invalid request"), tholaerr.ErrorCodeInvalidRequest))
 	}
 
 	responseChannel := make(chan response)
@@ -33,7 +36,7 @@ func ProcessRequest(ctx context.Context, request Request) (Response, error) {
 	case res := <-responseChannel:
 		return res.res, res.err
 	case <-ctx.Done():
-		return request.HandlePreProcessError(errors.New("request timed out"))
+		return request.HandlePreProcessError(tholaerr.NewTimeoutError("request timed out"))
 	}
 }
 
@@ -57,9 +60,13 @@ func processRequest(ctx context.Context, request Request, responseChan chan resp
 			}
 		}
 	}()
+	if request.getSNMPTrace() {
+		ctx = network.NewContextWithSNMPTrace(ctx, viper.GetInt("request.snmp-trace-max-entries"))
+	}
 	con, err := request.setupConnection(ctx)
 	if err != nil {
 		res, err := request.HandlePreProcessError(err)
+		addSNMPTrace(ctx, res)
 		responseChan <- response{
 			res: res,
 			err: err,
@@ -68,9 +75,25 @@ func processRequest(ctx context.Context, request Request, responseChan chan resp
 	}
 	defer con.CloseConnections()
 	ctx = network.NewContextWithDeviceConnection(ctx, con)
+	ctx = network.NewContextWithSNMPWalkCache(ctx)
+	ctx = communicator.NewContextWithComponentTimeoutWeights(ctx, request.getComponentTimeoutWeights())
+	ctx, identifyCacheUsage := newContextWithIdentifyCacheUsage(ctx)
 	res, err := request.process(ctx)
+	invalidateIdentifyCacheOnFailure(ctx, identifyCacheUsage, res, err)
+	addSNMPTrace(ctx, res)
 	responseChan <- response{
 		res: res,
 		err: err,
 	}
 }
+
+// addSNMPTrace adds the snmp trace of the context to the response, if the request was traced.
+func addSNMPTrace(ctx context.Context, res Response) {
+	traceResponse, ok := res.(snmpTraceResponse)
+	if !ok {
+		return
+	}
+	if trace, ok := network.SNMPTraceFromContext(ctx); ok {
+		traceResponse.setSNMPTrace(trace)
+	}
+}
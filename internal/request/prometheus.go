@@ -0,0 +1,199 @@
+package request
+
+import (
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/parser"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	prometheusCamelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	prometheusAcronymBoundary   = regexp.MustCompile(`([A-Z])([A-Z][a-z])`)
+	prometheusCounterFields     = regexp.MustCompile(`(Octets|Pkts|Discards|Errors|UnknownProtos)$`)
+)
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadInterfacesResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	for _, interf := range r.Interfaces {
+		labels := interfacePrometheusLabels(interf)
+
+		if interf.IfAdminStatus != nil {
+			metrics.Add("thola_interface_admin_status", "Administrative status of the interface.", parser.PrometheusGauge, 1,
+				withPrometheusLabel(labels, "status", string(*interf.IfAdminStatus))...)
+		}
+		if interf.IfOperStatus != nil {
+			metrics.Add("thola_interface_oper_status", "Operational status of the interface.", parser.PrometheusGauge, 1,
+				withPrometheusLabel(labels, "status", string(*interf.IfOperStatus))...)
+		}
+
+		v := reflect.ValueOf(interf)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			counter, ok := v.Field(i).Interface().(*uint64)
+			if !ok || counter == nil || field.Name == "IfIndex" {
+				continue
+			}
+			tag := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			name := "thola_interface_" + toPrometheusSnakeCase(strings.TrimPrefix(tag, "if"))
+			if prometheusCounterFields.MatchString(field.Name) {
+				metrics.Add(name+"_total", tag+" of the interface.", parser.PrometheusCounter, float64(*counter), labels...)
+			} else {
+				metrics.Add(name, tag+" of the interface.", parser.PrometheusGauge, float64(*counter), labels...)
+			}
+		}
+	}
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadCountInterfacesResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	metrics.Add("thola_interface_count", "Number of interfaces of the device.", parser.PrometheusGauge, float64(r.Count))
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadRoutesResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	metrics.Add("thola_route_count", "Number of routes of the device.", parser.PrometheusGauge, float64(r.Count))
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadCPULoadResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	for i, cpu := range r.CPUs {
+		if cpu.Load == nil {
+			continue
+		}
+		metrics.Add("thola_cpu_load", "CPU load in percent.", parser.PrometheusGauge, *cpu.Load,
+			parser.PrometheusLabel{Name: "cpu", Value: prometheusLabelOrIndex(cpu.Label, i)})
+	}
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadMemoryUsageResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	for i, pool := range r.MemoryPools {
+		if pool.Usage == nil {
+			continue
+		}
+		metrics.Add("thola_memory_usage", "Memory usage in percent.", parser.PrometheusGauge, *pool.Usage,
+			parser.PrometheusLabel{Name: "pool", Value: prometheusLabelOrIndex(pool.Label, i)})
+	}
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadDiskResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	for i, storage := range r.Disk.Storages {
+		labels := []parser.PrometheusLabel{{Name: "storage", Value: prometheusLabelOrIndex(storage.Description, i)}}
+		if storage.Type != nil {
+			labels = append(labels, parser.PrometheusLabel{Name: "type", Value: *storage.Type})
+		}
+		if storage.TotalBytes != nil {
+			metrics.Add("thola_disk_total_bytes", "Size of the storage in bytes.", parser.PrometheusGauge, float64(*storage.TotalBytes), labels...)
+		}
+		if storage.UsedBytes != nil {
+			metrics.Add("thola_disk_used_bytes", "Used space of the storage in bytes.", parser.PrometheusGauge, float64(*storage.UsedBytes), labels...)
+		}
+	}
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadServerResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	if r.Server.Procs != nil {
+		metrics.Add("thola_server_procs", "Number of processes running on the server.", parser.PrometheusGauge, float64(*r.Server.Procs))
+	}
+	if r.Server.Users != nil {
+		metrics.Add("thola_server_users", "Number of users logged in on the server.", parser.PrometheusGauge, float64(*r.Server.Users))
+	}
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the response in prometheus text exposition format.
+func (r *ReadUPSResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	v := reflect.ValueOf(r.UPS)
+	for i := 0; i < v.NumField(); i++ {
+		name := "thola_ups_" + strings.TrimSpace(strings.Split(v.Type().Field(i).Tag.Get("mapstructure"), ",")[0])
+		switch val := v.Field(i).Interface().(type) {
+		case *float64:
+			if val != nil {
+				metrics.Add(name, "", parser.PrometheusGauge, *val)
+			}
+		case *int:
+			if val != nil {
+				metrics.Add(name, "", parser.PrometheusGauge, float64(*val))
+			}
+		case *bool:
+			if val != nil {
+				metrics.Add(name, "", parser.PrometheusGauge, prometheusBool(*val))
+			}
+		}
+	}
+	return metrics.ToPrometheus()
+}
+
+// ToPrometheus returns the performance data of the check in prometheus text exposition format.
+func (c *CheckResponse) ToPrometheus() ([]byte, error) {
+	var metrics parser.PrometheusMetrics
+	metrics.Add("thola_check_status", "Status code of the check (0 = OK, 1 = WARNING, 2 = CRITICAL, 3 = UNKNOWN).", parser.PrometheusGauge, float64(c.StatusCode))
+	for _, point := range c.PerformanceData {
+		f, err := strconv.ParseFloat(fmt.Sprint(point.Value), 64)
+		if err != nil {
+			continue
+		}
+		var labels []parser.PrometheusLabel
+		if point.Label != "" {
+			labels = append(labels, parser.PrometheusLabel{Name: "label", Value: point.Label})
+		}
+		metrics.Add("thola_check_"+point.Metric, "", parser.PrometheusGauge, f, labels...)
+	}
+	return metrics.ToPrometheus()
+}
+
+func interfacePrometheusLabels(interf device.Interface) []parser.PrometheusLabel {
+	var labels []parser.PrometheusLabel
+	if interf.IfIndex != nil {
+		labels = append(labels, parser.PrometheusLabel{Name: "ifIndex", Value: strconv.FormatUint(*interf.IfIndex, 10)})
+	}
+	if interf.IfName != nil {
+		labels = append(labels, parser.PrometheusLabel{Name: "ifName", Value: *interf.IfName})
+	}
+	return labels
+}
+
+func withPrometheusLabel(labels []parser.PrometheusLabel, name, value string) []parser.PrometheusLabel {
+	res := make([]parser.PrometheusLabel, len(labels), len(labels)+1)
+	copy(res, labels)
+	return append(res, parser.PrometheusLabel{Name: name, Value: value})
+}
+
+func prometheusLabelOrIndex(label *string, index int) string {
+	if label != nil && *label != "" {
+		return *label
+	}
+	return strconv.Itoa(index)
+}
+
+func prometheusBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toPrometheusSnakeCase(s string) string {
+	s = prometheusAcronymBoundary.ReplaceAllString(s, "${1}_${2}")
+	return strings.ToLower(prometheusCamelCaseBoundary.ReplaceAllString(s, "${1}_${2}"))
+}
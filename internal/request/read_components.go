@@ -0,0 +1,37 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadComponentsRequest
+//
+// ReadComponentsRequest is the request struct for the read components request.
+//
+// swagger:model
+type ReadComponentsRequest struct {
+	// Split the time that is left until the timeout of the request between the components, so that a slow component
+	// can't use up the time of the following components. The split can be weighted with component_timeout_weights.
+	SplitTimeout bool `yaml:"split_timeout" json:"split_timeout" xml:"split_timeout"`
+	ReadRequest
+}
+
+// ReadComponentsResponse
+//
+// ReadComponentsResponse is the response struct for the read components response.
+//
+// swagger:model
+type ReadComponentsResponse struct {
+	Components device.Components `yaml:"components" json:"components" xml:"components"`
+	// The errors of the components that couldn't be read.
+	Errors []ReadComponentsError `yaml:"errors,omitempty" json:"errors,omitempty" xml:"errors,omitempty"`
+	ReadResponse
+}
+
+// ReadComponentsError
+//
+// ReadComponentsError is the error of a single component in the read components response.
+//
+// swagger:model
+type ReadComponentsError struct {
+	Component string `yaml:"component" json:"component" xml:"component"`
+	Error     string `yaml:"error" json:"error" xml:"error"`
+}
@@ -0,0 +1,41 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/communicator"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+)
+
+func (r *ReadComponentsRequest) process(ctx context.Context) (Response, error) {
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get communicator")
+	}
+
+	if r.SplitTimeout {
+		ctx = communicator.NewContextWithSplitComponentsTimeout(ctx)
+	}
+
+	components, err := communicator.GetComponents(ctx, com)
+	var multiErr *tholaerr.MultiError
+	if err != nil && !errors.As(err, &multiErr) {
+		return nil, errors.Wrap(err, "can't get components")
+	}
+
+	res := ReadComponentsResponse{
+		Components: components,
+	}
+	if multiErr != nil {
+		for _, componentErr := range multiErr.Errors() {
+			res.Errors = append(res.Errors, ReadComponentsError{
+				Component: componentErr.Component,
+				Error:     componentErr.Err.Error(),
+			})
+		}
+	}
+	return &res, nil
+}
@@ -0,0 +1,85 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/utility"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"net"
+)
+
+// configBackupProtocols contains the protocols which can be used to copy the configuration of a device.
+var configBackupProtocols = []string{"tftp", "ftp", "rcp", "scp", "sftp"}
+
+// ReadConfigRequest
+//
+// ReadConfigRequest is the request struct for the read config request.
+//
+// swagger:model
+type ReadConfigRequest struct {
+	// The server to which the configuration is copied. API requests can only use the servers of the configuration.
+	Destination device.ConfigBackupDestination `yaml:"destination" json:"destination" xml:"destination"`
+
+	// The request performs snmp set requests on the device, which have to be allowed explicitly. Additionally, snmp
+	// writes need to be enabled in the configuration.
+	AllowWrite bool `yaml:"allow_write" json:"allow_write" xml:"allow_write"`
+	ReadRequest
+}
+
+func (r *ReadConfigRequest) validate(ctx context.Context) error {
+	if !r.AllowWrite {
+		return errors.New("reading the config performs write operations on the device and needs to be allowed explicitly")
+	}
+	if !utility.StringSliceContains(configBackupProtocols, r.Destination.Protocol) {
+		return fmt.Errorf("invalid protocol '%s', supported protocols are %v", r.Destination.Protocol, configBackupProtocols)
+	}
+	server := net.ParseIP(r.Destination.Server)
+	if server == nil {
+		return fmt.Errorf("server '%s' is not an ip address", r.Destination.Server)
+	}
+	if IsAPIRequest(ctx) {
+		if err := checkConfigBackupServer(server); err != nil {
+			return err
+		}
+	}
+	if r.Destination.Path == "" {
+		return errors.New("no path on the server given")
+	}
+	if err := r.ReadRequest.validate(ctx); err != nil {
+		return err
+	}
+	// the request can only allow writes that are enabled in the configuration of the server
+	writesEnabled := r.DeviceData.ConnectionData.SNMP.WritesEnabled
+	if writesEnabled == nil || !*writesEnabled {
+		return errors.New("reading the config performs write operations on the device, but snmp writes are not enabled in the configuration")
+	}
+	return nil
+}
+
+// checkConfigBackupServer checks whether the configuration of devices may be copied to the server. The configuration
+// contains secrets of the device, so API requests can only copy it to the servers of the configuration.
+func checkConfigBackupServer(server net.IP) error {
+	for _, allowed := range viper.GetStringSlice("api.config-backup-servers") {
+		allowedNet, err := network.ParseIPNet(allowed)
+		if err != nil {
+			return errors.Wrap(err, "invalid config backup server in the configuration")
+		}
+		if allowedNet.Contains(server) {
+			return nil
+		}
+	}
+	return fmt.Errorf("server '%s' is not an allowed config backup server", server)
+}
+
+// ReadConfigResponse
+//
+// ReadConfigResponse is the response struct for the read config response.
+//
+// swagger:model
+type ReadConfigResponse struct {
+	ConfigBackup device.ConfigBackup `yaml:"config_backup" json:"config_backup" xml:"config_backup"`
+	ReadResponse
+}
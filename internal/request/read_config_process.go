@@ -0,0 +1,25 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/pkg/errors"
+)
+
+func (r *ReadConfigRequest) process(ctx context.Context) (Response, error) {
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get communicator")
+	}
+
+	result, err := com.GetConfigBackup(ctx, r.Destination)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to back up config")
+	}
+
+	return &ReadConfigResponse{
+		ConfigBackup: result,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package request
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+func TestCheckConfigBackupServer(t *testing.T) {
+	assert.Error(t, checkConfigBackupServer(net.ParseIP("192.0.2.10")), "without servers in the configuration every server is rejected")
+
+	viper.Set("api.config-backup-servers", []string{"192.0.2.10", "2001:db8::/64"})
+	defer viper.Set("api.config-backup-servers", nil)
+
+	assert.NoError(t, checkConfigBackupServer(net.ParseIP("192.0.2.10")))
+	assert.NoError(t, checkConfigBackupServer(net.ParseIP("2001:db8::10")))
+	assert.Error(t, checkConfigBackupServer(net.ParseIP("192.0.2.11")))
+	assert.Error(t, checkConfigBackupServer(net.ParseIP("2001:db8:1::10")))
+}
@@ -1,6 +1,12 @@
 package request
 
-import "github.com/inexio/thola/internal/device"
+import (
+	"context"
+	"github.com/inexio/thola/internal/communicator"
+	"github.com/inexio/thola/internal/device"
+	"github.com/pkg/errors"
+	"regexp"
+)
 
 // ReadDiskRequest
 //
@@ -8,9 +14,17 @@ import "github.com/inexio/thola/internal/device"
 //
 // swagger:model
 type ReadDiskRequest struct {
+	DiskOptions
 	ReadRequest
 }
 
+func (r *ReadDiskRequest) validate(ctx context.Context) error {
+	if err := r.DiskOptions.validate(); err != nil {
+		return err
+	}
+	return r.ReadRequest.validate(ctx)
+}
+
 // ReadDiskResponse
 //
 // ReadDiskResponse is the response struct for the read disk response.
@@ -20,3 +34,40 @@ type ReadDiskResponse struct {
 	Disk device.DiskComponent `yaml:"disk" json:"disk" xml:"disk"`
 	ReadResponse
 }
+
+// DiskOptions
+//
+// DiskOptions is the request struct for the options of a disk request.
+//
+// swagger:model
+type DiskOptions struct {
+	// Only keep storages whose description matches one of the given regular expressions.
+	StorageIncludeFilter []string `yaml:"storage_include_filter" json:"storage_include_filter" xml:"storage_include_filter"`
+	// Drop storages whose description matches one of the given regular expressions.
+	StorageExcludeFilter []string `yaml:"storage_exclude_filter" json:"storage_exclude_filter" xml:"storage_exclude_filter"`
+	storageFilter        communicator.DiskStorageFilter
+}
+
+func (r *DiskOptions) validate() error {
+	r.storageFilter = communicator.DiskStorageFilter{}
+	for _, f := range r.StorageIncludeFilter {
+		regex, err := regexp.Compile(f)
+		if err != nil {
+			return errors.Wrap(err, "compiling storage include filter failed")
+		}
+		r.storageFilter.Include = append(r.storageFilter.Include, regex)
+	}
+	for _, f := range r.StorageExcludeFilter {
+		regex, err := regexp.Compile(f)
+		if err != nil {
+			return errors.Wrap(err, "compiling storage exclude filter failed")
+		}
+		r.storageFilter.Exclude = append(r.storageFilter.Exclude, regex)
+	}
+	return nil
+}
+
+// newContext returns a new context with the storage filter of the options.
+func (r *DiskOptions) newContext(ctx context.Context) context.Context {
+	return communicator.NewContextWithDiskStorageFilter(ctx, r.storageFilter)
+}
@@ -14,7 +14,7 @@ func (r *ReadDiskRequest) process(ctx context.Context) (Response, error) {
 		return nil, errors.Wrap(err, "failed to get communicator")
 	}
 
-	result, err := com.GetDiskComponent(ctx)
+	result, err := com.GetDiskComponent(r.DiskOptions.newContext(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "can't get disk components")
 	}
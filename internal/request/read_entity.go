@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadEntityRequest
+//
+// ReadEntityRequest is the request struct for the read entity request.
+//
+// swagger:model
+type ReadEntityRequest struct {
+	ReadRequest
+}
+
+// ReadEntityResponse
+//
+// ReadEntityResponse is the response struct for the read entity request.
+//
+// swagger:model
+type ReadEntityResponse struct {
+	Entity device.EntityComponent `yaml:"entity" json:"entity" xml:"entity"`
+	ReadResponse
+}
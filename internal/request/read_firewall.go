@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadFirewallRequest
+//
+// ReadFirewallRequest is the request struct for the read firewall request.
+//
+// swagger:model
+type ReadFirewallRequest struct {
+	ReadRequest
+}
+
+// ReadFirewallResponse
+//
+// ReadFirewallResponse is the response struct for the read firewall request.
+//
+// swagger:model
+type ReadFirewallResponse struct {
+	Firewall device.FirewallComponent `yaml:"firewall" json:"firewall" xml:"firewall"`
+	ReadResponse
+}
@@ -0,0 +1,25 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/pkg/errors"
+)
+
+func (r *ReadFirewallRequest) process(ctx context.Context) (Response, error) {
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get communicator")
+	}
+
+	result, err := com.GetFirewallComponent(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get firewall component")
+	}
+
+	return &ReadFirewallResponse{
+		Firewall: result,
+	}, nil
+}
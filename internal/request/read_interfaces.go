@@ -4,8 +4,11 @@ import (
 	"context"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/mapping"
 	"github.com/pkg/errors"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -16,6 +19,10 @@ import (
 // swagger:model
 type ReadInterfacesRequest struct {
 	InterfaceOptions
+	// Amount of interfaces to skip after filtering, for paging through the interfaces.
+	Offset int `yaml:"offset" json:"offset" xml:"offset"`
+	// Maximum amount of interfaces in the response, 0 means no limit.
+	Limit int `yaml:"limit" json:"limit" xml:"limit"`
 	ReadRequest
 }
 
@@ -23,9 +30,27 @@ func (r *ReadInterfacesRequest) validate(ctx context.Context) error {
 	if err := r.InterfaceOptions.validate(); err != nil {
 		return err
 	}
+	if r.Offset < 0 {
+		return errors.New("offset must not be negative")
+	}
+	if r.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
 	return r.ReadRequest.validate(ctx)
 }
 
+// paginate returns the interfaces of the page selected by the offset and limit of the request.
+func (r *ReadInterfacesRequest) paginate(interfaces []device.Interface) []device.Interface {
+	if r.Offset >= len(interfaces) {
+		return []device.Interface{}
+	}
+	interfaces = interfaces[r.Offset:]
+	if r.Limit > 0 && r.Limit < len(interfaces) {
+		interfaces = interfaces[:r.Limit]
+	}
+	return interfaces
+}
+
 // ReadInterfacesResponse
 //
 // ReadInterfacesResponse is the request struct for the read interfaces response.
@@ -33,6 +58,8 @@ func (r *ReadInterfacesRequest) validate(ctx context.Context) error {
 // swagger:model
 type ReadInterfacesResponse struct {
 	Interfaces []device.Interface `yaml:"interfaces" json:"interfaces" xml:"interfaces"`
+	// Amount of interfaces after filtering, independent of the offset and limit.
+	TotalCount int `yaml:"total_count" json:"total_count" xml:"total_count"`
 	ReadResponse
 }
 
@@ -42,7 +69,8 @@ type ReadInterfacesResponse struct {
 //
 // swagger:model
 type InterfaceOptions struct {
-	// If you only want specific values of the interfaces you can specify them here.
+	// If you only want specific values of the interfaces you can specify them here, e.g. "ifOperStatus" or
+	// "ethernet_like/dot3StatsFCSErrors". Values that aren't selected aren't read from the device.
 	Values                []string `yaml:"values" json:"values" xml:"values"`
 	IfDescrRegex          string   `yaml:"ifDescr_regex" json:"ifDescr_regex" xml:"ifDescr_regex"`
 	ifDescrRegex          *regexp.Regexp
@@ -51,6 +79,10 @@ type InterfaceOptions struct {
 	IfNameFilter          []string `yaml:"ifName_filter" json:"ifName_filter" xml:"ifName_filter"`
 	IfDescrFilter         []string `yaml:"ifDescr_filter" json:"ifDescr_filter" xml:"ifDescr_filter"`
 	SNMPGetsInsteadOfWalk bool     `yaml:"snmp_gets_instead_of_walk" json:"snmp_gets_instead_of_walk" xml:"snmp_gets_instead_of_walk"`
+	// If set, only the interfaces of the device class are read, without the vendor-specific values that code
+	// communicators add with extra requests, like the SAPs of Nokia devices. The interfaces are still normalized and
+	// filtered. This is faster if only the counters of the interfaces are needed.
+	SkipVendorEnrichment bool `yaml:"skip_vendor_enrichment" json:"skip_vendor_enrichment" xml:"skip_vendor_enrichment"`
 }
 
 func (r *InterfaceOptions) validate() error {
@@ -72,7 +104,7 @@ func (r *InterfaceOptions) getFilter() []groupproperty.Filter {
 	var res []groupproperty.Filter
 
 	for _, f := range r.IfTypeFilter {
-		res = append(res, groupproperty.GetGroupFilter([]string{"ifType"}, f))
+		res = append(res, groupproperty.GetGroupFilter([]string{"ifType"}, ifTypeFilterRegex(f)))
 	}
 	for _, f := range r.IfNameFilter {
 		res = append(res, groupproperty.GetGroupFilter([]string{"ifName"}, f))
@@ -91,3 +123,37 @@ func (r *InterfaceOptions) getFilter() []groupproperty.Filter {
 
 	return res
 }
+
+// ifTypeFilterRegex converts a filter regex for names of ifTypes into a regex for their numbers, as the ifType of an
+// interface is read as number. The regex is returned unchanged if it doesn't match the name of any ifType in the
+// IANAifType registry.
+func ifTypeFilterRegex(filter string) string {
+	regex, err := regexp.Compile(filter)
+	if err != nil {
+		return filter
+	}
+	ifTypes, err := mapping.GetMapping("ifType.yaml")
+	if err != nil {
+		return filter
+	}
+
+	var numbers []int
+	for number, name := range ifTypes {
+		if !regex.MatchString(name) {
+			continue
+		}
+		if n, err := strconv.Atoi(number); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	if len(numbers) == 0 {
+		return filter
+	}
+	sort.Ints(numbers)
+
+	var alternatives []string
+	for _, n := range numbers {
+		alternatives = append(alternatives, strconv.Itoa(n))
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
@@ -5,10 +5,13 @@ package request
 
 import (
 	"context"
+	"github.com/inexio/thola/internal/communicator"
 	"github.com/pkg/errors"
 )
 
 func (r *ReadInterfacesRequest) process(ctx context.Context) (Response, error) {
+	ctx = communicator.NewContextWithSkipInterfaceEnrichment(ctx, r.SkipVendorEnrichment)
+
 	com, err := GetCommunicator(ctx, r.BaseRequest)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get communicator")
@@ -20,6 +23,7 @@ func (r *ReadInterfacesRequest) process(ctx context.Context) (Response, error) {
 	}
 
 	return &ReadInterfacesResponse{
-		Interfaces: result,
+		Interfaces: r.paginate(result),
+		TotalCount: len(result),
 	}, nil
 }
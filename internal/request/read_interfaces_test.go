@@ -0,0 +1,42 @@
+package request
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadInterfacesRequest_paginate(t *testing.T) {
+	var interfaces []device.Interface
+	for i := uint64(1); i <= 5; i++ {
+		ifIndex := i
+		interfaces = append(interfaces, device.Interface{IfIndex: &ifIndex})
+	}
+	ifIndices := func(interfaces []device.Interface) []uint64 {
+		res := []uint64{}
+		for _, interf := range interfaces {
+			res = append(res, *interf.IfIndex)
+		}
+		return res
+	}
+
+	r := ReadInterfacesRequest{}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, ifIndices(r.paginate(interfaces)))
+
+	r = ReadInterfacesRequest{Offset: 1, Limit: 2}
+	assert.Equal(t, []uint64{2, 3}, ifIndices(r.paginate(interfaces)))
+
+	r = ReadInterfacesRequest{Offset: 4, Limit: 2}
+	assert.Equal(t, []uint64{5}, ifIndices(r.paginate(interfaces)))
+
+	r = ReadInterfacesRequest{Offset: 5}
+	assert.Empty(t, r.paginate(interfaces))
+}
+
+func TestIfTypeFilterRegex(t *testing.T) {
+	assert.Equal(t, "^(6)$", ifTypeFilterRegex("^ethernetCsmacd$"))
+	assert.Equal(t, "^(24|161)$", ifTypeFilterRegex("^(softwareLoopback|ieee8023adLag)$"))
+	// filters which don't match any name are kept, e.g. filters for the number
+	assert.Equal(t, "^6$", ifTypeFilterRegex("^6$"))
+	assert.Equal(t, "(", ifTypeFilterRegex("("))
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadNeighborsRequest
+//
+// ReadNeighborsRequest is the request struct for the read neighbors request.
+//
+// swagger:model
+type ReadNeighborsRequest struct {
+	ReadRequest
+}
+
+// ReadNeighborsResponse
+//
+// ReadNeighborsResponse is the response struct for the read neighbors response.
+//
+// swagger:model
+type ReadNeighborsResponse struct {
+	Neighbors []device.Neighbor `yaml:"neighbors" json:"neighbors" xml:"neighbors"`
+	ReadResponse
+}
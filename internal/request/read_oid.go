@@ -0,0 +1,38 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+)
+
+// ReadOIDRequest
+//
+// ReadOIDRequest is the request struct for the read oid request.
+//
+// swagger:model
+type ReadOIDRequest struct {
+	// The OID which is walked.
+	//
+	// example: 1.3.6.1.2.1.2.2.1.2
+	OID network.OID `yaml:"oid" json:"oid" xml:"oid"`
+	ReadRequest
+}
+
+func (r *ReadOIDRequest) validate(ctx context.Context) error {
+	if err := r.OID.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid oid '%s'", r.OID)
+	}
+	return r.ReadRequest.validate(ctx)
+}
+
+// ReadOIDResponse
+//
+// ReadOIDResponse is the response struct for the read oid response.
+//
+// swagger:model
+type ReadOIDResponse struct {
+	// The raw values of the walked OID mapped by their index.
+	Values map[string]string `yaml:"values" json:"values" xml:"values"`
+	ReadResponse
+}
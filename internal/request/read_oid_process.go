@@ -0,0 +1,25 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/pkg/errors"
+)
+
+func (r *ReadOIDRequest) process(ctx context.Context) (Response, error) {
+	values, err := groupproperty.ReadOID(ctx, r.OID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read oid '%s'", r.OID)
+	}
+
+	res := ReadOIDResponse{
+		Values: make(map[string]string, len(values)),
+	}
+	for idx, val := range values {
+		res.Values[idx] = val.String()
+	}
+	return &res, nil
+}
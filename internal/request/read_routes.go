@@ -0,0 +1,25 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadRoutesRequest
+//
+// ReadRoutesRequest is the request struct for the read routes request.
+//
+// swagger:model
+type ReadRoutesRequest struct {
+	// Only read out the count of routes instead of the whole routing table.
+	CountOnly bool `yaml:"count_only" json:"count_only" xml:"count_only"`
+	ReadRequest
+}
+
+// ReadRoutesResponse
+//
+// ReadRoutesResponse is the response struct for the read routes response.
+//
+// swagger:model
+type ReadRoutesResponse struct {
+	Count  int            `yaml:"count" json:"count" xml:"count"`
+	Routes []device.Route `yaml:"routes,omitempty" json:"routes,omitempty" xml:"routes,omitempty"`
+	ReadResponse
+}
@@ -0,0 +1,36 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/pkg/errors"
+)
+
+func (r *ReadRoutesRequest) process(ctx context.Context) (Response, error) {
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get communicator")
+	}
+
+	if r.CountOnly {
+		count, err := com.GetCountRoutes(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't get count of routes")
+		}
+		return &ReadRoutesResponse{
+			Count: count,
+		}, nil
+	}
+
+	routes, err := com.GetRoutingTable(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get routing table")
+	}
+
+	return &ReadRoutesResponse{
+		Count:  len(routes),
+		Routes: routes,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadVLANsRequest
+//
+// ReadVLANsRequest is the request struct for the read vlans request.
+//
+// swagger:model
+type ReadVLANsRequest struct {
+	ReadRequest
+}
+
+// ReadVLANsResponse
+//
+// ReadVLANsResponse is the response struct for the read vlans response.
+//
+// swagger:model
+type ReadVLANsResponse struct {
+	VLAN device.VLANComponent `yaml:"vlan" json:"vlan" xml:"vlan"`
+	ReadResponse
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadWLANRequest
+//
+// ReadWLANRequest is the request struct for the read wlan request.
+//
+// swagger:model
+type ReadWLANRequest struct {
+	ReadRequest
+}
+
+// ReadWLANResponse
+//
+// ReadWLANResponse is the response struct for the read wlan request.
+//
+// swagger:model
+type ReadWLANResponse struct {
+	WLAN device.WLANComponent `yaml:"wlan" json:"wlan" xml:"wlan"`
+	ReadResponse
+}
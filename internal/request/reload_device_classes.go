@@ -0,0 +1,74 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/communicator/create"
+	"github.com/inexio/thola/internal/deviceclass"
+	"github.com/pkg/errors"
+)
+
+// ReloadDeviceClassesResponse
+//
+// ReloadDeviceClassesResponse is the response of a reload of the device classes.
+//
+// swagger:model
+type ReloadDeviceClassesResponse struct {
+	Reloaded bool                  `json:"reloaded" xml:"reloaded"`
+	Error    *DeviceClassFileError `json:"error,omitempty" xml:"error,omitempty"`
+	// Problems contains all problems if the device classes are invalid, Error is the first of them.
+	Problems []DeviceClassFileError `json:"problems,omitempty" xml:"problems>problem,omitempty"`
+}
+
+// DeviceClassFileError
+//
+// DeviceClassFileError describes why a device class file couldn't be read in.
+// The line and the yaml path are omitted if the position of the error in the file is unknown.
+//
+// swagger:model
+type DeviceClassFileError struct {
+	File    string `json:"file" xml:"file"`
+	Line    int    `json:"line,omitempty" xml:"line,omitempty"`
+	Path    string `json:"path,omitempty" xml:"path,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+func newDeviceClassFileError(fileErr *deviceclass.FileError) DeviceClassFileError {
+	return DeviceClassFileError{
+		File:    fileErr.File,
+		Line:    fileErr.Line,
+		Path:    fileErr.Path,
+		Message: fileErr.Message,
+	}
+}
+
+// ReloadDeviceClasses reads in the device classes again and replaces the active ones. Requests that are already
+// running keep using the old device classes. If a device class file can't be read in, the old device classes stay
+// active and the error is part of the response.
+func ReloadDeviceClasses(ctx context.Context) (ReloadDeviceClassesResponse, error) {
+	err := create.ReloadHierarchy(ctx)
+	if err != nil {
+		var res ReloadDeviceClassesResponse
+		switch cause := errors.Cause(err).(type) {
+		case *deviceclass.FileError:
+			fileErr := newDeviceClassFileError(cause)
+			res.Error = &fileErr
+		case *deviceclass.ValidationError:
+			for _, problem := range cause.Problems {
+				res.Problems = append(res.Problems, newDeviceClassFileError(problem))
+			}
+			if len(res.Problems) > 0 {
+				res.Error = &res.Problems[0]
+			}
+		}
+		return res, err
+	}
+
+	// identified devices may match a different device class now
+	FlushIdentifyCache()
+	FlushMatchCache()
+
+	return ReloadDeviceClassesResponse{Reloaded: true}, nil
+}
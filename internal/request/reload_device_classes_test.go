@@ -0,0 +1,67 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/communicator/create"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testDeviceClass = `name: %s
+
+match:
+  logical_operator: OR
+  conditions:
+    - type: SysDescription
+      match_mode: startsWith
+      values:
+        - Test
+`
+
+func TestReloadDeviceClasses(t *testing.T) {
+	dir := t.TempDir()
+	if !assert.NoError(t, os.Mkdir(filepath.Join(dir, "generic"), 0755)) {
+		return
+	}
+	writeFile := func(name, content string) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	writeFile("generic.yaml", "name: generic\n")
+	writeFile("generic/test.yaml", fmt.Sprintf(testDeviceClass, "test"))
+
+	viper.Set("device-class-dir", dir)
+	defer func() {
+		viper.Set("device-class-dir", "")
+		_, _ = ReloadDeviceClasses(context.Background())
+	}()
+
+	ctx := context.Background()
+	res, err := ReloadDeviceClasses(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ReloadDeviceClassesResponse{Reloaded: true}, res)
+	}
+	_, err = create.GetNetworkDeviceCommunicator(ctx, "test")
+	assert.NoError(t, err)
+
+	// a broken device class leaves the old device classes active
+	writeFile("generic/test.yaml", "name: test\n\nconfig:\n  components: abc\n")
+	writeFile("generic/other.yaml", fmt.Sprintf(testDeviceClass, "other"))
+	res, err = ReloadDeviceClasses(ctx)
+	if assert.Error(t, err) && assert.NotNil(t, res.Error) {
+		assert.False(t, res.Reloaded)
+		assert.Equal(t, filepath.Join("generic", "test.yaml"), res.Error.File)
+		assert.Equal(t, 4, res.Error.Line)
+	}
+	_, err = create.GetNetworkDeviceCommunicator(ctx, "test")
+	assert.NoError(t, err)
+	_, err = create.GetNetworkDeviceCommunicator(ctx, "other")
+	assert.Error(t, err)
+}
@@ -15,6 +15,8 @@ type Request interface {
 
 	validate(ctx context.Context) error
 	getTimeout() *int
+	getComponentTimeoutWeights() map[string]float64
+	getSNMPTrace() bool
 	setupConnection(ctx context.Context) (*network.RequestDeviceConnection, error)
 	process(ctx context.Context) (Response, error)
 }
@@ -23,3 +25,8 @@ type Request interface {
 type Response interface {
 	GetExitCode() int
 }
+
+// snmpTraceResponse is implemented by all responses which can contain the snmp trace of their request.
+type snmpTraceResponse interface {
+	setSNMPTrace(network.SNMPTrace)
+}
@@ -0,0 +1,55 @@
+package request
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type ctxKey byte
+
+const (
+	requestIDKey ctxKey = iota + 1
+	apiRequestKey
+)
+
+// maxRequestIDLength is the maximum length of a request ID that is supplied by a client.
+const maxRequestIDLength = 128
+
+// NewRequestID returns a new unique request ID.
+func NewRequestID() string {
+	return xid.New().String()
+}
+
+// ValidRequestID returns whether a request ID that is supplied by a client can be used. It must not be empty, too long
+// or contain anything but printable ASCII characters without spaces, so that it can't break log lines or headers.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContextWithRequestID returns a new context with the request ID and a logger which adds the request ID to every
+// log line. The logger is derived from the logger of the context, or the global logger if the context has none.
+func NewContextWithRequestID(ctx context.Context, id string) context.Context {
+	logger := zerolog.Ctx(ctx)
+	// contexts without logger return the same disabled logger
+	if logger == zerolog.Ctx(context.Background()) {
+		logger = &log.Logger
+	}
+	l := logger.With().Str("request_id", id).Logger()
+	return l.WithContext(context.WithValue(ctx, requestIDKey, id))
+}
+
+// RequestIDFromContext returns the request ID from the context
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok
+}
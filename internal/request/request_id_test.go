@@ -0,0 +1,39 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNewContextWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).With().Str("component", "test").Logger()
+	ctx := NewContextWithRequestID(logger.WithContext(context.Background()), "abc")
+
+	id, ok := RequestIDFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "abc", id)
+
+	// the request id is added to the logger of the context
+	log.Ctx(ctx).Info().Msg("test")
+	assert.JSONEq(t, `{"level":"info","component":"test","request_id":"abc","message":"test"}`, buf.String())
+
+	_, ok = RequestIDFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, ValidRequestID(NewRequestID()))
+	assert.True(t, ValidRequestID("check-42_a.b"))
+
+	assert.False(t, ValidRequestID(""))
+	assert.False(t, ValidRequestID("with space"))
+	assert.False(t, ValidRequestID("line\nbreak"))
+	assert.False(t, ValidRequestID("ümlaut"))
+	assert.False(t, ValidRequestID(strings.Repeat("a", maxRequestIDLength+1)))
+}
@@ -0,0 +1,34 @@
+package request
+
+import (
+	"github.com/inexio/thola/internal/network"
+	"github.com/spf13/viper"
+	"sync"
+)
+
+var snmpSessionPoolInstance struct {
+	sync.Once
+	pool *network.SNMPSessionPool
+}
+
+// getSNMPSessionPool returns the snmp session pool, or nil if it is disabled.
+// The pool is only enabled in API mode, if an idle TTL is configured.
+func getSNMPSessionPool() *network.SNMPSessionPool {
+	snmpSessionPoolInstance.Do(func() {
+		ttl := viper.GetDuration("api.snmp-session-pool-ttl")
+		if ttl <= 0 {
+			return
+		}
+		snmpSessionPoolInstance.pool = network.NewSNMPSessionPool(ttl, viper.GetInt("api.snmp-session-pool-size"), viper.GetInt("api.snmp-session-pool-host-limit"))
+	})
+	return snmpSessionPoolInstance.pool
+}
+
+// GetSNMPSessionPoolStatistics returns the statistics of the snmp session pool.
+func GetSNMPSessionPoolStatistics() network.SNMPSessionPoolStatistics {
+	pool := getSNMPSessionPool()
+	if pool == nil {
+		return network.SNMPSessionPoolStatistics{}
+	}
+	return pool.Statistics()
+}
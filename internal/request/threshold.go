@@ -0,0 +1,321 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+// ThresholdRange is a range in the nagios range syntax, see https://www.monitoring-plugins.org/doc/guidelines.html#THRESHOLDFORMAT.
+// A value outside of the range results in an alert, if the range is prefixed with '@' a value inside of the range.
+//
+// Examples: "10" (alert if < 0 or > 10), "10:" (alert if < 10), "~:10" (alert if > 10), "10:20" (alert if < 10 or > 20),
+// "@10:20" (alert if >= 10 and <= 20).
+type ThresholdRange struct {
+	// Start of the range, nil means negative infinity.
+	Start *float64
+	// End of the range, nil means positive infinity.
+	End *float64
+	// Inside inverts the range, so that values inside of it result in an alert.
+	Inside bool
+}
+
+// ParseThresholdRange parses a range in the nagios range syntax.
+func ParseThresholdRange(s string) (ThresholdRange, error) {
+	var r ThresholdRange
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "@") {
+		r.Inside = true
+		s = s[1:]
+	}
+	if s == "" {
+		return ThresholdRange{}, errors.New("threshold range is empty")
+	}
+
+	start, end := "", s
+	if i := strings.Index(s, ":"); i >= 0 {
+		start, end = s[:i], s[i+1:]
+	}
+
+	zero := 0.0
+	switch start {
+	case "":
+		r.Start = &zero
+	case "~":
+	default:
+		v, err := strconv.ParseFloat(start, 64)
+		if err != nil {
+			return ThresholdRange{}, errors.Wrapf(err, "invalid start of threshold range '%s'", s)
+		}
+		r.Start = &v
+	}
+	if end != "" {
+		v, err := strconv.ParseFloat(end, 64)
+		if err != nil {
+			return ThresholdRange{}, errors.Wrapf(err, "invalid end of threshold range '%s'", s)
+		}
+		r.End = &v
+	}
+
+	if r.Start != nil && r.End != nil && *r.Start > *r.End {
+		return ThresholdRange{}, fmt.Errorf("start of threshold range '%s' is greater than its end", s)
+	}
+	return r, nil
+}
+
+// Alerts returns whether the value results in an alert.
+func (r ThresholdRange) Alerts(value float64) bool {
+	outside := (r.Start != nil && value < *r.Start) || (r.End != nil && value > *r.End)
+	return outside != r.Inside
+}
+
+// String returns the range in the nagios range syntax.
+func (r ThresholdRange) String() string {
+	var s string
+	if r.Inside {
+		s = "@"
+	}
+	switch {
+	case r.Start == nil:
+		s += "~:"
+	case *r.Start != 0 || r.End == nil:
+		s += strconv.FormatFloat(*r.Start, 'f', -1, 64) + ":"
+	}
+	if r.End != nil {
+		s += strconv.FormatFloat(*r.End, 'f', -1, 64)
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r ThresholdRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *ThresholdRange) UnmarshalText(text []byte) error {
+	parsed, err := ParseThresholdRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// scale returns the range with its start and end multiplied by the factor.
+func (r ThresholdRange) scale(factor float64) ThresholdRange {
+	if r.Start != nil {
+		start := *r.Start * factor
+		r.Start = &start
+	}
+	if r.End != nil {
+		end := *r.End * factor
+		r.End = &end
+	}
+	return r
+}
+
+// Threshold
+//
+// Threshold contains the warning and critical range of a checked value in the nagios range syntax.
+// For compatibility, the old format with warningMin, warningMax, criticalMin and criticalMax is also accepted in JSON.
+//
+// swagger:model
+type Threshold struct {
+	// Warning range
+	//
+	// example: 80
+	Warning *ThresholdRange `yaml:"warning,omitempty" json:"warning,omitempty" xml:"warning,omitempty"`
+	// Critical range
+	//
+	// example: 90
+	Critical *ThresholdRange `yaml:"critical,omitempty" json:"critical,omitempty" xml:"critical,omitempty"`
+}
+
+// ParseThreshold parses the warning and critical range of a threshold. An empty string means that there is no range.
+func ParseThreshold(warning, critical string) (Threshold, error) {
+	var t Threshold
+	if warning != "" {
+		r, err := ParseThresholdRange(warning)
+		if err != nil {
+			return Threshold{}, errors.Wrap(err, "invalid warning threshold")
+		}
+		t.Warning = &r
+	}
+	if critical != "" {
+		r, err := ParseThresholdRange(critical)
+		if err != nil {
+			return Threshold{}, errors.Wrap(err, "invalid critical threshold")
+		}
+		t.Critical = &r
+	}
+	return t, nil
+}
+
+// NewThresholdFromMinMax converts thresholds with min and max values into ranges.
+// A missing min or max means that the range is unbounded on that side.
+func NewThresholdFromMinMax(thresholds monitoringplugin.Thresholds) (Threshold, error) {
+	var t Threshold
+	var err error
+	if thresholds.HasWarning() {
+		t.Warning, err = minMax2ThresholdRange(thresholds.WarningMin, thresholds.WarningMax)
+		if err != nil {
+			return Threshold{}, errors.Wrap(err, "invalid warning threshold")
+		}
+	}
+	if thresholds.HasCritical() {
+		t.Critical, err = minMax2ThresholdRange(thresholds.CriticalMin, thresholds.CriticalMax)
+		if err != nil {
+			return Threshold{}, errors.Wrap(err, "invalid critical threshold")
+		}
+	}
+	return t, nil
+}
+
+func minMax2ThresholdRange(min, max interface{}) (*ThresholdRange, error) {
+	var r ThresholdRange
+	if min != nil {
+		v, err := strconv.ParseFloat(fmt.Sprint(min), 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "min can't be parsed")
+		}
+		r.Start = &v
+	}
+	if max != nil {
+		v, err := strconv.ParseFloat(fmt.Sprint(max), 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "max can't be parsed")
+		}
+		r.End = &v
+	}
+	if r.Start != nil && r.End != nil && *r.Start > *r.End {
+		return nil, errors.New("min is greater than max")
+	}
+	return &r, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts ranges as well as the old format with min and max values.
+func (t *Threshold) UnmarshalJSON(b []byte) error {
+	var data struct {
+		Warning     *ThresholdRange `json:"warning"`
+		Critical    *ThresholdRange `json:"critical"`
+		WarningMin  interface{}     `json:"warningMin"`
+		WarningMax  interface{}     `json:"warningMax"`
+		CriticalMin interface{}     `json:"criticalMin"`
+		CriticalMax interface{}     `json:"criticalMax"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	threshold, err := NewThresholdFromMinMax(monitoringplugin.NewThresholds(data.WarningMin, data.WarningMax, data.CriticalMin, data.CriticalMax))
+	if err != nil {
+		return err
+	}
+	if data.Warning != nil {
+		threshold.Warning = data.Warning
+	}
+	if data.Critical != nil {
+		threshold.Critical = data.Critical
+	}
+	*t = threshold
+	return nil
+}
+
+// Validate checks that the start of the ranges is not greater than their end.
+func (t Threshold) Validate() error {
+	if t.Warning != nil && t.Warning.Start != nil && t.Warning.End != nil && *t.Warning.Start > *t.Warning.End {
+		return errors.New("start of warning threshold is greater than its end")
+	}
+	if t.Critical != nil && t.Critical.Start != nil && t.Critical.End != nil && *t.Critical.Start > *t.Critical.End {
+		return errors.New("start of critical threshold is greater than its end")
+	}
+	return nil
+}
+
+// IsEmpty returns whether neither a warning nor a critical range is set.
+func (t Threshold) IsEmpty() bool {
+	return t.Warning == nil && t.Critical == nil
+}
+
+// Check returns the status of the value, which is monitoringplugin.CRITICAL, monitoringplugin.WARNING or
+// monitoringplugin.OK.
+func (t Threshold) Check(value float64) int {
+	if t.Critical != nil && t.Critical.Alerts(value) {
+		return monitoringplugin.CRITICAL
+	}
+	if t.Warning != nil && t.Warning.Alerts(value) {
+		return monitoringplugin.WARNING
+	}
+	return monitoringplugin.OK
+}
+
+// scale returns the threshold with all ranges multiplied by the factor.
+func (t Threshold) scale(factor float64) Threshold {
+	if t.Warning != nil {
+		r := t.Warning.scale(factor)
+		t.Warning = &r
+	}
+	if t.Critical != nil {
+		r := t.Critical.scale(factor)
+		t.Critical = &r
+	}
+	return t
+}
+
+// monitoringPluginThresholds returns the ranges of the threshold that can be expressed as monitoringplugin.Thresholds,
+// which are all ranges except the inverted ones.
+func (t Threshold) monitoringPluginThresholds() monitoringplugin.Thresholds {
+	var thresholds monitoringplugin.Thresholds
+	if t.Warning != nil && !t.Warning.Inside {
+		thresholds.WarningMin, thresholds.WarningMax = float64PointerToInterface(t.Warning.Start), float64PointerToInterface(t.Warning.End)
+	}
+	if t.Critical != nil && !t.Critical.Inside {
+		thresholds.CriticalMin, thresholds.CriticalMax = float64PointerToInterface(t.Critical.Start), float64PointerToInterface(t.Critical.End)
+	}
+	return thresholds
+}
+
+func float64PointerToInterface(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+// addPerformanceDataPointWithThreshold adds the performance data point to the response and checks its value against
+// the threshold. The ranges are added to the performance data, so that they can be shown in graphs.
+// Inverted ranges ('@') cannot be expressed with monitoringplugin.Thresholds, so they are checked here and written into
+// the performance data by the response.
+func addPerformanceDataPointWithThreshold(mon *checkResponse, point *monitoringplugin.PerformanceDataPoint, threshold Threshold) error {
+	point.SetThresholds(threshold.monitoringPluginThresholds())
+	if err := mon.AddPerformanceDataPoint(point); err != nil {
+		return err
+	}
+
+	if (threshold.Warning == nil || !threshold.Warning.Inside) && (threshold.Critical == nil || !threshold.Critical.Inside) {
+		return nil
+	}
+	mon.setThreshold(point.Metric, point.Label, threshold)
+	value, err := strconv.ParseFloat(fmt.Sprint(point.Value), 64)
+	if err != nil {
+		return errors.Wrap(err, "value can't be parsed")
+	}
+	name := point.Metric
+	if point.Label != "" {
+		name += " (" + point.Label + ")"
+	}
+	for _, r := range []struct {
+		status int
+		r      *ThresholdRange
+	}{{monitoringplugin.CRITICAL, threshold.Critical}, {monitoringplugin.WARNING, threshold.Warning}} {
+		if r.r != nil && r.r.Inside && r.r.Alerts(value) {
+			mon.UpdateStatus(r.status, fmt.Sprintf("%s is inside of %s threshold", name, monitoringplugin.StatusCode2Text(r.status)))
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package request
+
+import (
+	"fmt"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/pkg/errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Thresholds
+//
+// Thresholds maps metric paths to the thresholds of the metrics.
+// A metric path consists of the field names of the collected values separated by dots, e.g. "cpu.cpus.load" or
+// "ups.battery_capacity". Field names can be given in snake case or camel case ("ups.batteryCapacity").
+// If a path leads through a list, the threshold is checked for every element of the list.
+//
+// swagger:model
+type Thresholds map[string]Threshold
+
+// MetricState
+//
+// MetricState contains the state of a single metric value that was checked against its threshold.
+//
+// swagger:model
+type MetricState struct {
+	// Metric is the path of the value, lists contain the index of the element, e.g. "cpu.cpus[0].load".
+	Metric string `yaml:"metric" json:"metric" xml:"metric"`
+	// Value is the checked value.
+	Value float64 `yaml:"value" json:"value" xml:"value"`
+	// State is the resulting state as monitoring plugin status code.
+	State int `yaml:"state" json:"state" xml:"state"`
+}
+
+// Evaluate checks all values of the collected struct v against the thresholds of their metric path.
+// It returns the states of all checked values, sorted by metric, and the worst of all states.
+// Values that are not available (nil) are skipped, paths that don't exist in v result in an error.
+func (t Thresholds) Evaluate(v interface{}) ([]MetricState, int, error) {
+	var states []MetricState
+	for path, threshold := range t {
+		if err := threshold.Validate(); err != nil {
+			return nil, monitoringplugin.UNKNOWN, errors.Wrapf(err, "invalid threshold for '%s'", path)
+		}
+		segments := strings.Split(path, ".")
+		res, err := evaluateThresholdPath(reflect.ValueOf(v), segments, "", threshold)
+		if err != nil {
+			return nil, monitoringplugin.UNKNOWN, errors.Wrapf(err, "failed to evaluate threshold for '%s'", path)
+		}
+		states = append(states, res...)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Metric < states[j].Metric
+	})
+
+	worst := monitoringplugin.OK
+	for _, state := range states {
+		if state.State > worst {
+			worst = state.State
+		}
+	}
+	return states, worst, nil
+}
+
+func evaluateThresholdPath(v reflect.Value, segments []string, metric string, threshold Threshold) ([]MetricState, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		var states []MetricState
+		for i := 0; i < v.Len(); i++ {
+			res, err := evaluateThresholdPath(v.Index(i), segments, fmt.Sprintf("%s[%d]", metric, i), threshold)
+			if err != nil {
+				return nil, err
+			}
+			states = append(states, res...)
+		}
+		return states, nil
+	}
+
+	if len(segments) == 0 {
+		value, ok := reflectValue2Float64(v)
+		if !ok {
+			return nil, fmt.Errorf("value of '%s' is not a number", metric)
+		}
+		return []MetricState{{
+			Metric: metric,
+			Value:  value,
+			State:  threshold.Check(value),
+		}}, nil
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("'%s' has no field '%s'", metric, segments[0])
+	}
+	field, ok := thresholdPathField(v, segments[0])
+	if !ok {
+		return nil, fmt.Errorf("unknown field '%s'", strings.TrimPrefix(metric+"."+segments[0], "."))
+	}
+	return evaluateThresholdPath(field, segments[1:], strings.TrimPrefix(metric+"."+segments[0], "."), threshold)
+}
+
+// thresholdPathField returns the field of the struct whose json name or field name matches the segment, ignoring case
+// and underscores.
+func thresholdPathField(v reflect.Value, segment string) (reflect.Value, bool) {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+	segment = normalize(segment)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := strings.TrimSpace(strings.Split(field.Tag.Get("json"), ",")[0])
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		if normalize(tag) == segment || normalize(field.Name) == segment {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func reflectValue2Float64(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
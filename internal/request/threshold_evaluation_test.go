@@ -0,0 +1,64 @@
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThresholds_Evaluate(t *testing.T) {
+	load1, load2, capacity := 50.0, 85.0, 15.0
+	components := device.Components{
+		CPU: &device.CPUComponent{CPUs: []device.CPU{{Load: &load1}, {Load: &load2}, {}}},
+		UPS: &device.UPSComponent{BatteryCapacity: &capacity},
+	}
+
+	cpu, err := ParseThreshold("80", "90")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ups, err := ParseThreshold("30:", "20:")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	states, worst, err := Thresholds{"cpu.cpus.load": cpu, "ups.batteryCapacity": ups}.Evaluate(components)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []MetricState{
+			{Metric: "cpu.cpus[0].load", Value: 50, State: monitoringplugin.OK},
+			{Metric: "cpu.cpus[1].load", Value: 85, State: monitoringplugin.WARNING},
+			{Metric: "ups.batteryCapacity", Value: 15, State: monitoringplugin.CRITICAL},
+		}, states)
+		assert.Equal(t, monitoringplugin.CRITICAL, worst)
+	}
+
+	// inverted ranges alert inside of the range
+	inverted, err := ParseThreshold("@40:60", "@80:90")
+	if !assert.NoError(t, err) {
+		return
+	}
+	states, worst, err = Thresholds{"cpu.cpus.load": inverted}.Evaluate(components)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []MetricState{
+			{Metric: "cpu.cpus[0].load", Value: 50, State: monitoringplugin.WARNING},
+			{Metric: "cpu.cpus[1].load", Value: 85, State: monitoringplugin.CRITICAL},
+		}, states)
+		assert.Equal(t, monitoringplugin.CRITICAL, worst)
+	}
+
+	// unset components are skipped
+	_, worst, err = Thresholds{"memory.pools.usage": cpu}.Evaluate(components)
+	if assert.NoError(t, err) {
+		assert.Equal(t, monitoringplugin.OK, worst)
+	}
+
+	_, _, err = Thresholds{"cpu.cpus.temperature": cpu}.Evaluate(components)
+	assert.Error(t, err)
+	_, _, err = Thresholds{"cpu.cpus.label": cpu}.Evaluate(components)
+	assert.NoError(t, err, "nil labels are skipped")
+	label := "cpu"
+	components.CPU.CPUs[0].Label = &label
+	_, _, err = Thresholds{"cpu.cpus.label": cpu}.Evaluate(components)
+	assert.Error(t, err, "labels are no numbers")
+}
@@ -0,0 +1,118 @@
+package request
+
+import (
+	"encoding/json"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseThresholdRange(t *testing.T) {
+	for _, c := range []struct {
+		s      string
+		string string
+		alerts []float64
+		ok     []float64
+	}{
+		{"10", "10", []float64{-1, 11}, []float64{0, 5, 10}},
+		{"10:", "10:", []float64{9.9}, []float64{10, 1000}},
+		{"~:10", "~:10", []float64{10.5}, []float64{-1000, 10}},
+		{"10:20", "10:20", []float64{9, 21}, []float64{10, 15, 20}},
+		{"@10:20", "@10:20", []float64{10, 15, 20}, []float64{9, 21}},
+		{"0:80.5", "80.5", []float64{81}, []float64{80.5}},
+	} {
+		r, err := ParseThresholdRange(c.s)
+		if !assert.NoError(t, err, c.s) {
+			continue
+		}
+		assert.Equal(t, c.string, r.String(), c.s)
+		for _, v := range c.alerts {
+			assert.True(t, r.Alerts(v), "%s should alert for %v", c.s, v)
+		}
+		for _, v := range c.ok {
+			assert.False(t, r.Alerts(v), "%s should not alert for %v", c.s, v)
+		}
+	}
+
+	for _, s := range []string{"", "@", "abc", "10:abc", "20:10"} {
+		_, err := ParseThresholdRange(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestThreshold_Check(t *testing.T) {
+	threshold, err := ParseThreshold("80", "@90:95")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, monitoringplugin.OK, threshold.Check(50))
+	assert.Equal(t, monitoringplugin.WARNING, threshold.Check(85))
+	assert.Equal(t, monitoringplugin.CRITICAL, threshold.Check(92))
+	assert.Equal(t, monitoringplugin.WARNING, threshold.Check(96))
+	assert.Equal(t, monitoringplugin.OK, Threshold{}.Check(100))
+}
+
+func TestThreshold_UnmarshalJSON(t *testing.T) {
+	var threshold Threshold
+	if assert.NoError(t, json.Unmarshal([]byte(`{"warning": "~:10", "critical": "@20:30"}`), &threshold)) {
+		assert.Equal(t, "~:10", threshold.Warning.String())
+		assert.Equal(t, "@20:30", threshold.Critical.String())
+	}
+
+	threshold = Threshold{}
+	if assert.NoError(t, json.Unmarshal([]byte(`{"warningMin": 0, "warningMax": 80, "criticalMax": 90}`), &threshold)) {
+		assert.Equal(t, "80", threshold.Warning.String())
+		assert.Equal(t, "~:90", threshold.Critical.String())
+	}
+
+	b, err := json.Marshal(threshold)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"warning": "80", "critical": "~:90"}`, string(b))
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"warning": "20:10"}`), &threshold))
+}
+
+func TestAddPerformanceDataPointWithThreshold(t *testing.T) {
+	threshold, err := ParseThreshold("80", "@0:5")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	mon := newCheckResponse("checked")
+	assert.NoError(t, addPerformanceDataPointWithThreshold(mon, monitoringplugin.NewPerformanceDataPoint("usage", 50).SetLabel("a"), threshold))
+	assert.Equal(t, monitoringplugin.OK, mon.GetStatusCode())
+	assert.NoError(t, addPerformanceDataPointWithThreshold(mon, monitoringplugin.NewPerformanceDataPoint("usage", 85).SetLabel("b"), threshold))
+	assert.Equal(t, monitoringplugin.WARNING, mon.GetStatusCode())
+	assert.NoError(t, addPerformanceDataPointWithThreshold(mon, monitoringplugin.NewPerformanceDataPoint("usage", "3.000").SetLabel("c"), threshold))
+	assert.Equal(t, monitoringplugin.CRITICAL, mon.GetStatusCode())
+
+	info := mon.GetInfo()
+	assert.True(t, strings.Contains(info.RawOutput, "usage (c) is inside of CRITICAL threshold"), info.RawOutput)
+	assert.True(t, strings.Contains(info.RawOutput, "'usage_b'=85;80;@5"), info.RawOutput)
+}
+
+func TestAddPerformanceDataPointWithThreshold_invertedRangePerformanceData(t *testing.T) {
+	threshold, err := ParseThreshold("@10:20", "~:90")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	mon := newCheckResponse("checked")
+	assert.NoError(t, addPerformanceDataPointWithThreshold(mon, monitoringplugin.NewPerformanceDataPoint("temperature", 15.5).SetUnit("C").SetMin(0), threshold))
+	assert.NoError(t, mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("usage", 50).SetUnit("%").SetThresholds(monitoringplugin.Thresholds{WarningMax: 80})))
+	assert.Equal(t, monitoringplugin.WARNING, mon.GetStatusCode())
+
+	info := mon.GetInfo()
+	assert.True(t, strings.Contains(info.RawOutput, "'temperature'=15.5C;@10:20;~:90;0;"), info.RawOutput)
+	assert.True(t, strings.Contains(info.RawOutput, "'usage'=50%;~:80;;;"), info.RawOutput)
+
+	mon.SetPerformanceDataJSONLabel(true)
+	info = mon.GetInfo()
+	assert.True(t, strings.Contains(info.RawOutput, `'{"metric":"temperature"}'=15.5C;@10:20;~:90;0;`), info.RawOutput)
+
+	mon.PrintPerformanceData(false)
+	info = mon.GetInfo()
+	assert.False(t, strings.Contains(info.RawOutput, "|"), info.RawOutput)
+}
@@ -0,0 +1,133 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CacheStatistics
+//
+// CacheStatistics contains the statistics of an in-process cache.
+//
+// swagger:model
+type CacheStatistics struct {
+	Enabled       bool    `json:"enabled" xml:"enabled"`
+	Size          int     `json:"size" xml:"size"`
+	MaxSize       int     `json:"max_size" xml:"max_size"`
+	Hits          uint64  `json:"hits" xml:"hits"`
+	Misses        uint64  `json:"misses" xml:"misses"`
+	Invalidations uint64  `json:"invalidations" xml:"invalidations"`
+	HitRate       float64 `json:"hit_rate" xml:"hit_rate"`
+}
+
+// ttlCache is an in-process cache whose entries expire after the TTL.
+// The least recently used entry is removed if the cache is full (maxSize 0 => unlimited).
+type ttlCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	maxSize int
+
+	entries map[string]*list.Element
+	lru     *list.List
+
+	hits          uint64
+	misses        uint64
+	invalidations uint64
+}
+
+type ttlCacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration, maxSize int) *ttlCache {
+	return &ttlCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (c *ttlCache) get(key string) (interface{}, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	elem, ok := c.entries[key]
+	if ok && time.Now().After(elem.Value.(*ttlCacheEntry).expires) {
+		c.remove(elem)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*ttlCacheEntry).value, true
+}
+
+func (c *ttlCache) set(key string, value interface{}) {
+	c.Lock()
+	defer c.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&ttlCacheEntry{
+		key:     key,
+		value:   value,
+		expires: time.Now().Add(c.ttl),
+	})
+
+	for c.maxSize > 0 && c.lru.Len() > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *ttlCache) invalidate(key string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+		c.invalidations++
+	}
+}
+
+func (c *ttlCache) flush() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+func (c *ttlCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*ttlCacheEntry).key)
+}
+
+func (c *ttlCache) statistics() CacheStatistics {
+	c.Lock()
+	defer c.Unlock()
+
+	stats := CacheStatistics{
+		Enabled:       true,
+		Size:          c.lru.Len(),
+		MaxSize:       c.maxSize,
+		Hits:          c.hits,
+		Misses:        c.misses,
+		Invalidations: c.invalidations,
+	}
+	if total := c.hits + c.misses; total > 0 {
+		stats.HitRate = float64(c.hits) / float64(total)
+	}
+	return stats
+}
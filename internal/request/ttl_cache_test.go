@@ -0,0 +1,52 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	cache := newTTLCache(time.Hour, 2)
+
+	cache.set("a", 1)
+	cache.set("b", 2)
+
+	value, ok := cache.get("a")
+	if assert.True(t, ok) {
+		assert.Equal(t, 1, value)
+	}
+
+	// "b" is the least recently used entry and is removed
+	cache.set("c", 3)
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+
+	cache.invalidate("a")
+	_, ok = cache.get("a")
+	assert.False(t, ok)
+
+	stats := cache.statistics()
+	assert.Equal(t, 1, stats.Size)
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Invalidations)
+	assert.InDelta(t, 1.0/3.0, stats.HitRate, 0.0001)
+
+	cache.flush()
+	assert.Equal(t, 0, cache.statistics().Size)
+}
+
+func TestTTLCache_expired(t *testing.T) {
+	cache := newTTLCache(time.Millisecond, 0)
+
+	cache.set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := cache.get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.statistics().Size)
+}
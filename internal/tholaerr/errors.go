@@ -1,6 +1,10 @@
 package tholaerr
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+)
 
 type networkError interface {
 	networkError() bool
@@ -40,6 +44,20 @@ func (e HTTPError) networkError() bool {
 	return true
 }
 
+// SSHError is an error returned by ssh functions.
+type SSHError struct {
+	error
+}
+
+// NewSSHError returns an ssh error.
+func NewSSHError(msg string) error {
+	return SSHError{errors.New(msg)}
+}
+
+func (e SSHError) networkError() bool {
+	return true
+}
+
 type notFoundError interface {
 	notFoundError() bool
 }
@@ -64,6 +82,55 @@ func IsNotFoundError(err error) bool {
 	return ok && e.notFoundError()
 }
 
+type emptyValueError interface {
+	emptyValueError() bool
+}
+
+// EmptyValueError occurs when a value was read but is empty. It is also a NotFoundError.
+type EmptyValueError struct {
+	NotFoundError
+}
+
+// NewEmptyValueError returns an EmptyValueError
+func NewEmptyValueError(msg string) error {
+	return EmptyValueError{NotFoundError{errors.New(msg)}}
+}
+
+func (e EmptyValueError) emptyValueError() bool {
+	return true
+}
+
+// IsEmptyValueError returns if the error is an EmptyValueError
+func IsEmptyValueError(err error) bool {
+	e, ok := errors.Cause(err).(emptyValueError)
+	return ok && e.emptyValueError()
+}
+
+type valueUnavailableError interface {
+	valueUnavailableError() bool
+}
+
+// ValueUnavailableError occurs when a value can't be calculated for a single row, e.g. because of a division by zero.
+// It is also a NotFoundError.
+type ValueUnavailableError struct {
+	NotFoundError
+}
+
+// NewValueUnavailableError returns a ValueUnavailableError
+func NewValueUnavailableError(msg string) error {
+	return ValueUnavailableError{NotFoundError{errors.New(msg)}}
+}
+
+func (e ValueUnavailableError) valueUnavailableError() bool {
+	return true
+}
+
+// IsValueUnavailableError returns if the error is a ValueUnavailableError
+func IsValueUnavailableError(err error) bool {
+	e, ok := errors.Cause(err).(valueUnavailableError)
+	return ok && e.valueUnavailableError()
+}
+
 type preConditionError interface {
 	preConditionError() bool
 }
@@ -184,11 +251,292 @@ func IsDidNotMatchError(err error) bool {
 	return ok && e.didNotMatchError()
 }
 
+type timeoutError interface {
+	timeoutError() bool
+}
+
+// TimeoutError occurs when an action did not finish within its time budget
+type TimeoutError struct {
+	error
+}
+
+// NewTimeoutError returns an TimeoutError
+func NewTimeoutError(msg string) error {
+	return TimeoutError{errors.New(msg)}
+}
+
+func (p TimeoutError) timeoutError() bool {
+	return true
+}
+
+// IsTimeoutError returns if the error is an TimeoutError
+func IsTimeoutError(err error) bool {
+	e, ok := errors.Cause(err).(timeoutError)
+	return ok && e.timeoutError()
+}
+
+// SNMPTimeoutError occurs when a device does not answer an snmp request in time.
+type SNMPTimeoutError struct {
+	error
+}
+
+// NewSNMPTimeoutError returns an SNMPTimeoutError
+func NewSNMPTimeoutError(msg string) error {
+	return SNMPTimeoutError{errors.New(msg)}
+}
+
+func (e SNMPTimeoutError) networkError() bool {
+	return true
+}
+
+func (e SNMPTimeoutError) snmpTimeoutError() bool {
+	return true
+}
+
+type snmpTimeoutError interface {
+	snmpTimeoutError() bool
+}
+
+// IsSNMPTimeoutError returns if the error is an SNMPTimeoutError
+func IsSNMPTimeoutError(err error) bool {
+	e, ok := errors.Cause(err).(snmpTimeoutError)
+	return ok && e.snmpTimeoutError()
+}
+
+type authError interface {
+	authError() bool
+}
+
+// AuthError occurs when a device rejects the given credentials.
+type AuthError struct {
+	error
+}
+
+// NewAuthError returns an AuthError
+func NewAuthError(msg string) error {
+	return AuthError{errors.New(msg)}
+}
+
+func (e AuthError) authError() bool {
+	return true
+}
+
+// IsAuthError returns if the error is an AuthError
+func IsAuthError(err error) bool {
+	e, ok := errors.Cause(err).(authError)
+	return ok && e.authError()
+}
+
+type parseError interface {
+	parseError() bool
+}
+
+// ParseError occurs when a value returned by a device cannot be parsed.
+type ParseError struct {
+	error
+}
+
+// NewParseError returns a ParseError
+func NewParseError(msg string) error {
+	return ParseError{errors.New(msg)}
+}
+
+func (e ParseError) parseError() bool {
+	return true
+}
+
+// IsParseError returns if the error is a ParseError
+func IsParseError(err error) bool {
+	e, ok := errors.Cause(err).(parseError)
+	return ok && e.parseError()
+}
+
+// ComponentError is an error which occurred while reading a single component.
+type ComponentError struct {
+	Component string
+	Err       error
+}
+
+func (e ComponentError) Error() string {
+	return e.Component + ": " + e.Err.Error()
+}
+
+func (e ComponentError) Cause() error {
+	return e.Err
+}
+
+func (e ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects the errors of multiple components, so that a failing component does not hide the errors of the
+// other components. The predicates like IsNotFoundError can be used on each of the collected errors.
+type MultiError struct {
+	errs []ComponentError
+}
+
+// Add adds the error of the given component. Nil errors are ignored.
+func (m *MultiError) Add(component string, err error) {
+	if err == nil {
+		return
+	}
+	m.errs = append(m.errs, ComponentError{Component: component, Err: err})
+}
+
+// Errors returns the collected errors in the order they were added.
+func (m *MultiError) Errors() []ComponentError {
+	return m.errs
+}
+
+// ErrorOrNil returns the MultiError if at least one error was added, otherwise nil.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d components failed: %s", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// ErrorCode is a stable machine-readable code which describes the kind of an error.
+type ErrorCode string
+
+// All error codes which can be returned by the API.
+const (
+	ErrorCodeComponentNotFound ErrorCode = "COMPONENT_NOT_FOUND"
+	ErrorCodeNotImplemented    ErrorCode = "NOT_IMPLEMENTED"
+	ErrorCodeSNMPTimeout       ErrorCode = "SNMP_TIMEOUT"
+	ErrorCodeAuthFailed        ErrorCode = "AUTH_FAILED"
+	ErrorCodeParseError        ErrorCode = "PARSE_ERROR"
+	ErrorCodeNotFound          ErrorCode = "NOT_FOUND"
+	ErrorCodeTimeout           ErrorCode = "TIMEOUT"
+	ErrorCodeNetworkError      ErrorCode = "NETWORK_ERROR"
+	ErrorCodeTooManyRequests   ErrorCode = "TOO_MANY_REQUESTS"
+	ErrorCodeInvalidRequest    ErrorCode = "INVALID_REQUEST"
+	ErrorCodeRequestFailed     ErrorCode = "REQUEST_FAILED"
+)
+
+// codedError annotates an error with an explicit error code.
+type codedError struct {
+	cause error
+	code  ErrorCode
+}
+
+func (e codedError) Error() string {
+	return e.cause.Error()
+}
+
+func (e codedError) Cause() error {
+	return e.cause
+}
+
+func (e codedError) Unwrap() error {
+	return e.cause
+}
+
+// WithCode annotates an error with the given code. The code survives wrapping the error
+// and takes precedence over the code derived from the type of the error.
+func WithCode(err error, code ErrorCode) error {
+	if err == nil {
+		return nil
+	}
+	return codedError{cause: err, code: code}
+}
+
+type causer interface {
+	Cause() error
+}
+
+// Code returns the error code of an error. If the error was not annotated with a code,
+// the code is derived from the type of the cause of the error.
+func Code(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	for e := err; e != nil; {
+		if c, ok := e.(codedError); ok {
+			return c.code
+		}
+		cause, ok := e.(causer)
+		if !ok {
+			break
+		}
+		e = cause.Cause()
+	}
+
+	switch {
+	case IsComponentNotFoundError(err):
+		return ErrorCodeComponentNotFound
+	case IsNotImplementedError(err):
+		return ErrorCodeNotImplemented
+	case IsSNMPTimeoutError(err):
+		return ErrorCodeSNMPTimeout
+	case IsAuthError(err):
+		return ErrorCodeAuthFailed
+	case IsParseError(err):
+		return ErrorCodeParseError
+	case IsNotFoundError(err):
+		return ErrorCodeNotFound
+	case IsTimeoutError(err):
+		return ErrorCodeTimeout
+	case IsNetworkError(err):
+		return ErrorCodeNetworkError
+	case IsTooManyRequestsError(err):
+		return ErrorCodeTooManyRequests
+	case IsPreConditionError(err):
+		return ErrorCodeInvalidRequest
+	}
+	return ErrorCodeRequestFailed
+}
+
 // OutputError
 //
 // OutputError embeds all error messages which occur in requests on the API.
 //
 // swagger:model
 type OutputError struct {
+	// The error message including its category, kept for compatibility
+	//
+	// example: Not found: no cpu component available for this device
 	Error string `json:"error" xml:"error"`
+
+	// Stable machine-readable code of the error
+	//
+	// example: COMPONENT_NOT_FOUND
+	Code ErrorCode `json:"code,omitempty" xml:"code,omitempty"`
+
+	// The message of the underlying cause of the error
+	//
+	// example: no cpu component available for this device
+	Message string `json:"message,omitempty" xml:"message,omitempty"`
+
+	// The full error including the context it occurred in
+	//
+	// example: failed to get cpu load: no cpu component available for this device
+	Details string `json:"details,omitempty" xml:"details,omitempty"`
+}
+
+// NewOutputError returns the OutputError for the given error. The error message is prefixed with the given category.
+func NewOutputError(category string, err error) OutputError {
+	o := OutputError{
+		Error:   err.Error(),
+		Code:    Code(err),
+		Message: errors.Cause(err).Error(),
+	}
+	if category != "" {
+		o.Error = category + ": " + o.Error
+	}
+	if o.Message != err.Error() {
+		o.Details = err.Error()
+	}
+	return o
 }
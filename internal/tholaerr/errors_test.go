@@ -0,0 +1,86 @@
+package tholaerr
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	cases := map[ErrorCode]error{
+		ErrorCodeComponentNotFound: NewComponentNotFoundError("no cpu component available for this device"),
+		ErrorCodeNotImplemented:    NewNotImplementedError("function is not implemented for this communicator"),
+		ErrorCodeSNMPTimeout:       NewSNMPTimeoutError("request timeout (after 1 retries)"),
+		ErrorCodeAuthFailed:        NewAuthError("snmpv3 authentication failed: wrong digest"),
+		ErrorCodeParseError:        NewParseError("strconv.Atoi: parsing \"abc\": invalid syntax"),
+		ErrorCodeNotFound:          NewNotFoundError("No Such Object available on this agent at this OID"),
+		ErrorCodeTimeout:           NewTimeoutError("request timed out"),
+		ErrorCodeNetworkError:      NewSNMPError("cannot connect with any of the given connection data"),
+		ErrorCodeTooManyRequests:   NewTooManyRequestsError("too many requests"),
+		ErrorCodeInvalidRequest:    NewPreConditionError("invalid connection preferences"),
+		ErrorCodeRequestFailed:     errors.New("something went wrong"),
+	}
+	for code, err := range cases {
+		assert.Equal(t, code, Code(err), err.Error())
+		assert.Equal(t, code, Code(errors.Wrap(errors.Wrap(err, "error in code communicator"), "failed to get cpu load")), err.Error())
+	}
+	assert.Equal(t, ErrorCode(""), Code(nil))
+}
+
+func TestCode_snmpTimeoutIsNetworkError(t *testing.T) {
+	err := errors.Wrap(NewSNMPTimeoutError("request timeout (after 1 retries)"), "error during snmpget")
+	assert.True(t, IsNetworkError(err))
+	assert.True(t, IsSNMPTimeoutError(err))
+	assert.Equal(t, ErrorCodeSNMPTimeout, Code(err))
+}
+
+func TestWithCode(t *testing.T) {
+	err := errors.Wrap(WithCode(NewNotFoundError("interface not found"), ErrorCodeInvalidRequest), "failed to read interfaces")
+	assert.Equal(t, ErrorCodeInvalidRequest, Code(err))
+	assert.True(t, IsNotFoundError(err))
+	assert.Equal(t, "failed to read interfaces: interface not found", err.Error())
+	assert.Nil(t, WithCode(nil, ErrorCodeInvalidRequest))
+}
+
+func TestMultiError(t *testing.T) {
+	var m MultiError
+	assert.Nil(t, m.ErrorOrNil())
+
+	m.Add("cpu", nil)
+	m.Add("ups", errors.Wrap(NewNotFoundError("no ups data available"), "failed to get ups component"))
+	m.Add("cpu", errors.Wrap(NewNotImplementedError("no detection is implemented for cpu load"), "failed to get cpu load"))
+
+	err := m.ErrorOrNil()
+	if assert.Error(t, err) {
+		assert.Equal(t, "2 components failed: ups: failed to get ups component: no ups data available; cpu: failed to get cpu load: no detection is implemented for cpu load", err.Error())
+	}
+
+	errs := m.Errors()
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "ups", errs[0].Component)
+		assert.True(t, IsNotFoundError(errs[0]))
+		assert.False(t, IsNotImplementedError(errs[0]))
+		assert.Equal(t, ErrorCodeNotFound, Code(errs[0]))
+
+		assert.Equal(t, "cpu", errs[1].Component)
+		assert.True(t, IsNotImplementedError(errs[1]))
+		assert.Equal(t, ErrorCodeNotImplemented, Code(errs[1]))
+	}
+}
+
+func TestNewOutputError(t *testing.T) {
+	err := errors.Wrap(NewComponentNotFoundError("no cpu component available for this device"), "failed to get cpu load")
+
+	assert.Equal(t, OutputError{
+		Error:   "Request failed: failed to get cpu load: no cpu component available for this device",
+		Code:    ErrorCodeComponentNotFound,
+		Message: "no cpu component available for this device",
+		Details: "failed to get cpu load: no cpu component available for this device",
+	}, NewOutputError("Request failed", err))
+
+	assert.Equal(t, OutputError{
+		Error:   "request timed out",
+		Code:    ErrorCodeTimeout,
+		Message: "request timed out",
+	}, NewOutputError("", NewTimeoutError("request timed out")))
+}
@@ -0,0 +1,65 @@
+package value
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"strings"
+	"unicode"
+)
+
+// macAddressLength is the length of a MAC address in bytes.
+const macAddressLength = 6
+
+// NewHexString creates a new value which contains the bytes as an upper case hex string.
+// Six bytes are rendered as a colon separated MAC address, all other lengths as plain hex.
+func NewHexString(b []byte) Value {
+	if len(b) == macAddressLength {
+		return value(formatMACAddress(b))
+	}
+	return value(strings.ToUpper(hex.EncodeToString(b)))
+}
+
+// HexToMAC converts a hex string value to a colon separated MAC address.
+// The hex string may be plain or separated by colons, dashes, dots or spaces.
+// A ParseError is returned if the value is not a hex string of six bytes.
+func HexToMAC(v Value) (Value, error) {
+	b, err := decodeHexString(v.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != macAddressLength {
+		return nil, tholaerr.NewParseError(fmt.Sprintf("hex string has %d bytes, but a mac address has %d bytes", len(b), macAddressLength))
+	}
+	return value(formatMACAddress(b)), nil
+}
+
+// HexToASCII decodes a hex string value to ASCII. The hex string may be plain or separated by colons, dashes, dots
+// or spaces. Non-printable characters at the beginning and the end, like trailing null bytes, are removed.
+func HexToASCII(v Value) (Value, error) {
+	b, err := decodeHexString(v.String())
+	if err != nil {
+		return nil, err
+	}
+	return value(strings.TrimFunc(string(b), func(r rune) bool {
+		return !unicode.IsGraphic(r)
+	})), nil
+}
+
+func decodeHexString(s string) ([]byte, error) {
+	s = strings.NewReplacer(":", "", "-", "", ".", "", " ", "").Replace(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(tholaerr.NewParseError(err.Error()), "invalid hex string")
+	}
+	return b, nil
+}
+
+func formatMACAddress(b []byte) string {
+	parts := make([]string, len(b))
+	for i := range b {
+		parts[i] = strings.ToUpper(hex.EncodeToString(b[i : i+1]))
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,44 @@
+package value
+
+import (
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewHexString(t *testing.T) {
+	assert.Equal(t, New("00:0C:29:AB:CD:EF"), NewHexString([]byte{0x00, 0x0c, 0x29, 0xab, 0xcd, 0xef}))
+	assert.Equal(t, New("000C29ABCDEF0001"), NewHexString([]byte{0x00, 0x0c, 0x29, 0xab, 0xcd, 0xef, 0x00, 0x01}))
+	assert.Equal(t, New("0A"), NewHexString([]byte{0x0a}))
+	assert.Equal(t, New(""), NewHexString([]byte{}))
+	assert.Equal(t, New(""), NewHexString(nil))
+}
+
+func TestHexToMAC(t *testing.T) {
+	for _, hex := range []string{"000c29abcdef", "00:0C:29:AB:CD:EF", "00-0c-29-ab-cd-ef", "000c.29ab.cdef", "00 0C 29 AB CD EF", "0x000c29abcdef"} {
+		res, err := HexToMAC(New(hex))
+		if assert.NoError(t, err, hex) {
+			assert.Equal(t, New("00:0C:29:AB:CD:EF"), res, hex)
+		}
+	}
+
+	for _, hex := range []string{"", "000c29abcdef0001", "000c29abcd", "not hex"} {
+		_, err := HexToMAC(New(hex))
+		assert.True(t, tholaerr.IsParseError(err), hex)
+	}
+}
+
+func TestHexToASCII(t *testing.T) {
+	res, err := HexToASCII(New("46 44 4F 32 31 33 34 30 41 42 43 00 00"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, New("FDO21340ABC"), res)
+	}
+
+	res, err = HexToASCII(New(""))
+	if assert.NoError(t, err) {
+		assert.Equal(t, New(""), res)
+	}
+
+	_, err = HexToASCII(New("4G"))
+	assert.True(t, tholaerr.IsParseError(err))
+}
@@ -2,6 +2,7 @@ package value
 
 import (
 	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"math/big"
 	"reflect"
@@ -58,22 +59,38 @@ func (v value) String() string {
 
 // Float64 returns the value as a float 64
 func (v value) Float64() (float64, error) {
-	return strconv.ParseFloat(string(v), 64)
+	f, err := strconv.ParseFloat(string(v), 64)
+	if err != nil {
+		return 0, tholaerr.NewParseError(err.Error())
+	}
+	return f, nil
 }
 
 // Int returns the value as an int
 func (v value) Int() (int, error) {
-	return strconv.Atoi(string(v))
+	i, err := strconv.Atoi(string(v))
+	if err != nil {
+		return 0, tholaerr.NewParseError(err.Error())
+	}
+	return i, nil
 }
 
 // UInt64 returns the value as an uint64
 func (v value) UInt64() (uint64, error) {
-	return strconv.ParseUint(string(v), 10, 64)
+	u, err := strconv.ParseUint(string(v), 10, 64)
+	if err != nil {
+		return 0, tholaerr.NewParseError(err.Error())
+	}
+	return u, nil
 }
 
 // Bool returns the value as a bool
 func (v value) Bool() (bool, error) {
-	return strconv.ParseBool(string(v))
+	b, err := strconv.ParseBool(string(v))
+	if err != nil {
+		return false, tholaerr.NewParseError(err.Error())
+	}
+	return b, nil
 }
 
 // IsEmpty returns if the value is empty
@@ -89,12 +106,12 @@ func (v value) Cmp(val Value) (int, error) {
 	var v1, v2 big.Float
 	_, _, err := v1.Parse(v.String(), 10)
 	if err != nil {
-		return 0, errors.Wrap(err, "can't parse value")
+		return 0, errors.Wrap(tholaerr.NewParseError(err.Error()), "can't parse value")
 	}
 
 	_, _, err = v2.Parse(val.String(), 10)
 	if err != nil {
-		return 0, errors.Wrap(err, "can't parse value")
+		return 0, errors.Wrap(tholaerr.NewParseError(err.Error()), "can't parse value")
 	}
 
 	return v1.Cmp(&v2), nil
@@ -0,0 +1,37 @@
+package thola_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/pkg/thola"
+	"log"
+)
+
+func ExampleCreateCommunicator() {
+	ctx := context.Background()
+
+	// recorded snmp data is used instead of a real device, a real device is addressed by its ip address or hostname
+	com, err := thola.CreateCommunicator(ctx, thola.DeviceConnectionConfig{
+		IPAddress: "file://../../test/testdata/devices/routeros/CHR_1/public.snmprec",
+		SNMP: thola.SNMPConnectionData{
+			Communities: []string{"public"},
+			Versions:    []string{"2c"},
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer com.Close()
+
+	dev := com.Device()
+	fmt.Println(dev.Class, *dev.Properties.Vendor, *dev.Properties.Model)
+
+	interfaces, err := com.GetInterfaces(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(len(interfaces), "interfaces")
+	// Output:
+	// routeros Mikrotik CHR
+	// 32 interfaces
+}
@@ -0,0 +1,230 @@
+// Package thola provides an API to embed the device identification and the reading of devices into other Go programs,
+// without the command line interface or the REST API.
+//
+// The package does not require any global configuration, all settings are part of the DeviceConnectionConfig.
+package thola
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/communicator"
+	"github.com/inexio/thola/internal/communicator/create"
+	"github.com/inexio/thola/internal/component"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+)
+
+// SNMPConnectionData includes all SNMP connection information for a device.
+type SNMPConnectionData = network.SNMPConnectionData
+
+// SNMPv3ConnectionData includes all SNMP v3 specific connection data.
+type SNMPv3ConnectionData = network.SNMPv3ConnectionData
+
+// Device represents a device with its device class and identify properties.
+type Device = device.Device
+
+// Properties are the identify properties of a device, like vendor and model.
+type Properties = device.Properties
+
+// Interface represents all interface values which can be read.
+type Interface = device.Interface
+
+// CPU contains the load of a single cpu.
+type CPU = device.CPU
+
+// MemoryPool contains the usage of a single memory pool.
+type MemoryPool = device.MemoryPool
+
+// DiskComponent represents a disk component.
+type DiskComponent = device.DiskComponent
+
+// UPSComponent represents a ups component.
+type UPSComponent = device.UPSComponent
+
+// HardwareHealthComponent represents a hardware health component.
+type HardwareHealthComponent = device.HardwareHealthComponent
+
+// Snapshot is the state of a device at one point in time, the device and its interfaces.
+type Snapshot = device.Snapshot
+
+// DiffOptions are the options of Diff.
+type DiffOptions = device.DiffOptions
+
+// Delta is the difference between two snapshots of a device.
+type Delta = device.Delta
+
+// Change is a changed value of a device or an interface.
+type Change = device.Change
+
+// InterfaceChange contains the changed values of an interface.
+type InterfaceChange = device.InterfaceChange
+
+// Diff returns the changes between an old and a new snapshot of a device. Interfaces are matched by their ifIndex,
+// volatile values like counters are only compared if they are requested in the options.
+func Diff(old, new Snapshot, options DiffOptions) (Delta, error) {
+	return device.Diff(old, new, options)
+}
+
+const (
+	defaultSNMPDiscoverParRequests = 5
+	defaultSNMPDiscoverTimeout     = 2
+	defaultSNMPDiscoverRetries     = 0
+)
+
+// DeviceConnectionConfig contains the address of a device and the data that is needed to connect to it.
+type DeviceConnectionConfig struct {
+	// IPAddress is the ip address or hostname of the device.
+	IPAddress string
+	// SNMP contains the snmp credentials of the device. Unset discover settings use the defaults of thola.
+	SNMP SNMPConnectionData
+}
+
+// NetworkDeviceCommunicator is used to read a device which was identified by CreateCommunicator.
+// It holds the connection to the device, which has to be closed with Close when it is no longer needed.
+type NetworkDeviceCommunicator struct {
+	communicator communicator.Communicator
+	connection   *network.RequestDeviceConnection
+	device       device.Device
+}
+
+// CreateCommunicator connects to the device, identifies it and returns a communicator for the device class of the
+// device.
+func CreateCommunicator(ctx context.Context, config DeviceConnectionConfig) (*NetworkDeviceCommunicator, error) {
+	if config.IPAddress == "" {
+		return nil, errors.New("no ip address given")
+	}
+
+	snmpData := config.SNMP
+	setSNMPConnectionDataDefaults(&snmpData)
+
+	snmpClient, err := network.NewSNMPClientByConnectionData(ctx, config.IPAddress, &snmpData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create snmp client")
+	}
+
+	c := NetworkDeviceCommunicator{
+		connection: &network.RequestDeviceConnection{
+			RawConnectionData: network.ConnectionData{SNMP: &snmpData},
+			SNMP:              &network.RequestDeviceConnectionSNMP{SnmpClient: snmpClient},
+		},
+	}
+
+	ctx = c.newContext(ctx)
+	com, err := create.IdentifyNetworkDeviceCommunicator(ctx)
+	if err != nil {
+		c.Close()
+		return nil, errors.Wrap(err, "failed to identify device")
+	}
+	properties, err := com.GetIdentifyProperties(ctx)
+	if err != nil {
+		c.Close()
+		return nil, errors.Wrap(err, "failed to get identify properties")
+	}
+	c.communicator = com
+	c.device = device.Device{
+		Class:      com.GetIdentifier(),
+		Properties: properties,
+	}
+
+	err = com.UpdateConnection(c.newContext(ctx))
+	if err != nil {
+		c.Close()
+		return nil, errors.Wrap(err, "failed to update connection")
+	}
+
+	return &c, nil
+}
+
+func setSNMPConnectionDataDefaults(data *SNMPConnectionData) {
+	if len(data.Communities) == 0 {
+		data.Communities = []string{"public"}
+	}
+	if len(data.Versions) == 0 {
+		data.Versions = []string{"2c", "1"}
+	}
+	if len(data.Ports) == 0 {
+		data.Ports = []int{161}
+	}
+	if data.DiscoverParallelRequests == nil {
+		parRequests := defaultSNMPDiscoverParRequests
+		data.DiscoverParallelRequests = &parRequests
+	}
+	if data.DiscoverTimeout == nil {
+		timeout := defaultSNMPDiscoverTimeout
+		data.DiscoverTimeout = &timeout
+	}
+	if data.DiscoverRetries == nil {
+		retries := defaultSNMPDiscoverRetries
+		data.DiscoverRetries = &retries
+	}
+}
+
+// newContext returns a new context with the connection and the properties of the device, which are needed by the
+// communicator.
+func (c *NetworkDeviceCommunicator) newContext(ctx context.Context) context.Context {
+	ctx = network.NewContextWithDeviceConnection(ctx, c.connection)
+	ctx = network.NewContextWithSNMPWalkCache(ctx)
+	if c.communicator != nil {
+		ctx = device.NewContextWithDeviceProperties(ctx, c.device)
+	}
+	return ctx
+}
+
+// Close closes the connection to the device.
+func (c *NetworkDeviceCommunicator) Close() {
+	c.connection.CloseConnections()
+}
+
+// Device returns the identified device.
+func (c *NetworkDeviceCommunicator) Device() Device {
+	return c.device
+}
+
+// GetAvailableComponents returns the components that are available for the device.
+func (c *NetworkDeviceCommunicator) GetAvailableComponents() []string {
+	return c.communicator.GetAvailableComponents()
+}
+
+// HasComponent returns whether the component with the given name (e.g. "cpu" or "ups") is available for the device.
+func (c *NetworkDeviceCommunicator) HasComponent(name string) bool {
+	comp, err := component.CreateComponent(name)
+	if err != nil {
+		return false
+	}
+	return c.communicator.HasComponent(comp)
+}
+
+// GetInterfaces returns the interfaces of the device.
+func (c *NetworkDeviceCommunicator) GetInterfaces(ctx context.Context) ([]Interface, error) {
+	return c.communicator.GetInterfaces(c.newContext(ctx))
+}
+
+// GetCountInterfaces returns the count of interfaces of the device.
+func (c *NetworkDeviceCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
+	return c.communicator.GetCountInterfaces(c.newContext(ctx))
+}
+
+// GetCPULoad returns the cpu load of the device.
+func (c *NetworkDeviceCommunicator) GetCPULoad(ctx context.Context) ([]CPU, error) {
+	return c.communicator.GetCPUComponentCPULoad(c.newContext(ctx))
+}
+
+// GetMemoryUsage returns the memory usage of the device.
+func (c *NetworkDeviceCommunicator) GetMemoryUsage(ctx context.Context) ([]MemoryPool, error) {
+	return c.communicator.GetMemoryComponentMemoryUsage(c.newContext(ctx))
+}
+
+// GetDiskComponent returns the disk component of the device.
+func (c *NetworkDeviceCommunicator) GetDiskComponent(ctx context.Context) (DiskComponent, error) {
+	return c.communicator.GetDiskComponent(c.newContext(ctx))
+}
+
+// GetUPSComponent returns the ups component of the device.
+func (c *NetworkDeviceCommunicator) GetUPSComponent(ctx context.Context) (UPSComponent, error) {
+	return c.communicator.GetUPSComponent(c.newContext(ctx))
+}
+
+// GetHardwareHealthComponent returns the hardware health component of the device.
+func (c *NetworkDeviceCommunicator) GetHardwareHealthComponent(ctx context.Context) (HardwareHealthComponent, error) {
+	return c.communicator.GetHardwareHealthComponent(c.newContext(ctx))
+}
@@ -193,6 +193,8 @@ func getDeviceTestData(device string) (test.DeviceTestData, error) {
 		log.Info().Err(err).Msg("identify for device " + device + " failed")
 	} else {
 		identifyResponse = res.(*request.IdentifyResponse)
+		// the snmp connection depends on the test environment
+		identifyResponse.SNMPConnection = nil
 	}
 
 	var readCountInterfacesResponse *request.ReadCountInterfacesResponse
@@ -147,6 +147,15 @@ func ProcessRequest(r request.Request, port int) (request.Response, error) {
 	case *request.CheckHardwareHealthRequest:
 		requestEndpoint = "check/hardware-health"
 		response = &request.CheckResponse{}
+	case *request.CheckPOERequest:
+		requestEndpoint = "check/poe"
+		response = &request.CheckResponse{}
+	case *request.CheckEnvironmentRequest:
+		requestEndpoint = "check/environment"
+		response = &request.CheckResponse{}
+	case *request.CheckRoutesRequest:
+		requestEndpoint = "check/routes"
+		response = &request.CheckResponse{}
 	default:
 		return nil, errors.New("unknown request type")
 	}
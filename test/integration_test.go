@@ -301,8 +301,10 @@ func (t *testDevice) checkForRetryRequest() bool {
 func (e *DeviceTestDataExpectations) compareExpectations(response request.Response, requestType string) error {
 	switch requestType {
 	case "identify":
-		if !cmp.Equal(e.Identify, response) {
-			return errors.New("difference:" + cmp.Diff(e.Identify, response))
+		// the snmp connection is not part of the recorded test data
+		ignoreSNMPConnection := cmpopts.IgnoreFields(request.IdentifyResponse{}, "SNMPConnection")
+		if !cmp.Equal(e.Identify, response, ignoreSNMPConnection) {
+			return errors.New("difference:" + cmp.Diff(e.Identify, response, ignoreSNMPConnection))
 		}
 	case "read count-interfaces":
 		if !cmp.Equal(e.ReadCountInterfaces, response) {
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/parser"
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestRecordedDevices processes the requests of all test devices directly against their recorded snmp data,
+// so that no snmpsim container is needed.
+func TestRecordedDevices(t *testing.T) {
+	viper.Set("db.no-cache", true)
+	viper.Set("device.snmp-discover-par-requests", 5)
+	viper.Set("device.snmp-discover-timeout", 2)
+
+	_, currFilename, _, _ := runtime.Caller(0)
+	recDir := testConf.SNMPRecDir
+	if !filepath.IsAbs(recDir) {
+		recDir = filepath.Join(path.Dir(currFilename), "testdata", recDir)
+	}
+
+	found := false
+	err := filepath.Walk(recDir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(file, ".testdata") {
+			return err
+		}
+		recording := strings.TrimSuffix(file, ".testdata") + ".snmprec"
+		if _, err := os.Stat(recording); err != nil {
+			return nil
+		}
+		found = true
+
+		var testData DeviceTestData
+		contents, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := parser.ToStruct(contents, "json", &testData); err != nil {
+			return err
+		}
+
+		deviceInfo := testDeviceInfoSNMPSim{
+			requestDeviceData: request.DeviceData{
+				IPAddress: network.SNMPRecScheme + recording,
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{"public"},
+						Versions:    []string{"2c"},
+						Ports:       []int{161},
+					},
+				},
+			},
+		}
+
+		name, _ := filepath.Rel(recDir, strings.TrimSuffix(file, ".testdata"))
+		for _, requestType := range testData.GetAvailableRequestTypes() {
+			t.Run(name+"/"+requestType, func(t *testing.T) {
+				r, err := deviceInfo.generateRequest(requestType)
+				if !assert.NoError(t, err) {
+					return
+				}
+				res, err := request.ProcessRequest(context.Background(), r)
+				if !assert.NoError(t, err) {
+					return
+				}
+				// the expectations are compared to the responses of the api, so the response is encoded like them
+				res, err = encodeResponse(res)
+				if assert.NoError(t, err) {
+					assert.NoError(t, testData.Expectations.compareExpectations(res, requestType))
+				}
+			})
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, found, "no recorded test devices found")
+}
+
+// encodeResponse encodes the response to json and decodes it again.
+func encodeResponse(res request.Response) (request.Response, error) {
+	b, err := parser.Parse(res, "json")
+	if err != nil {
+		return nil, err
+	}
+	decoded := reflect.New(reflect.TypeOf(res).Elem()).Interface().(request.Response)
+	if err := parser.ToStruct(b, "json", decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}